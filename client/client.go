@@ -15,9 +15,11 @@
 package client
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"strings"
@@ -31,6 +33,12 @@ const (
 
 var (
 	cacert string
+
+	// Injected through the client Config and applied to every request the
+	// receivers send, see Config for what each one does.
+	transport http.RoundTripper
+	proxy     func(*http.Request) (*url.URL, error)
+	tlsConfig *tls.Config
 )
 
 // Client is a struct for exposing some operations of opensds resources.
@@ -51,6 +59,24 @@ type Client struct {
 type Config struct {
 	Endpoint    string
 	AuthOptions AuthOptions
+
+	// HTTPTransport replaces the transport every request is sent through,
+	// so callers can inject proxies, custom CA pools or instrumentation
+	// such as tracing round trippers. A custom transport fully controls
+	// the connection, including TLS. Nil keeps the default transport.
+	HTTPTransport http.RoundTripper
+
+	// Proxy selects the proxy for each request when no custom transport
+	// is given, for example http.ProxyFromEnvironment. Nil disables
+	// proxying.
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// TLSConfig is the TLS client configuration used for https endpoints
+	// when no custom transport is given, so deployments behind
+	// intercepting proxies can trust their own CA pool. Nil keeps the
+	// historical behavior of verifying the server against the CA file at
+	// constants.OpensdsCaCertFile.
+	TLSConfig *tls.Config
 }
 
 // NewClient method creates a new Client.
@@ -61,9 +87,14 @@ func NewClient(c *Config) (*Client, error) {
 		log.Printf("WARNING: OpenSDS Endpoint is not specified, use default(%s)\n", c.Endpoint)
 	}
 
-	// If https is enabled, CA cert file should be provided.
+	transport = c.HTTPTransport
+	proxy = c.Proxy
+	tlsConfig = c.TLSConfig
+
+	// If https is enabled, CA cert file should be provided, unless the
+	// caller brought its own TLS configuration or transport.
 	u, _ := url.Parse(c.Endpoint)
-	if u.Scheme == "https" {
+	if u.Scheme == "https" && c.TLSConfig == nil && c.HTTPTransport == nil {
 		cacert = constants.OpensdsCaCertFile
 		_, err := os.Stat(cacert)
 		if err != nil {
@@ -15,8 +15,13 @@
 package client
 
 import (
+	"io/ioutil"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/sodafoundation/api/pkg/utils/constants"
 )
 
 var assertTestResult = func(t *testing.T, got, expected interface{}) {
@@ -26,6 +31,46 @@ var assertTestResult = func(t *testing.T, got, expected interface{}) {
 	}
 }
 
+// recordingTransport answers every request itself and keeps what it saw,
+// standing in for the instrumentation or proxy transports callers inject.
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.requests = append(rt.requests, req)
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"name": "v1beta", "status": "SUPPORTED"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestNewClientWithCustomTransport(t *testing.T) {
+	rt := &recordingTransport{}
+	// An https endpoint without the CA file on disk proves a custom
+	// transport also takes over the TLS configuration.
+	client, err := NewClient(&Config{
+		Endpoint:      "https://localhost:50040",
+		AuthOptions:   NewNoauthOptions(constants.DefaultTenantId),
+		HTTPTransport: rt,
+	})
+	defer func() { transport = nil }()
+	if err != nil {
+		t.Fatalf("expected the client to build with a custom transport, got %v\n", err)
+	}
+
+	version, err := client.GetVersion("v1beta")
+	if err != nil {
+		t.Fatalf("expected the request to go through the custom transport, got %v\n", err)
+	}
+	assertTestResult(t, len(rt.requests), 1)
+	assertTestResult(t, rt.requests[0].URL.String(), "https://localhost:50040/v1beta")
+	assertTestResult(t, version.Name, "v1beta")
+}
+
 func TestProcessListParam(t *testing.T) {
 
 	t.Run("The args should only support one parameter", func(t *testing.T) {
@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"strings"
 
 	"github.com/sodafoundation/api/pkg/model"
@@ -36,12 +37,17 @@ type DockMgr struct {
 }
 
 func (d *DockMgr) GetDock(dckID string) (*model.DockSpec, error) {
+	return d.GetDockWithContext(context.Background(), dckID)
+}
+
+// GetDockWithContext implementation
+func (d *DockMgr) GetDockWithContext(ctx context.Context, dckID string) (*model.DockSpec, error) {
 	var res model.DockSpec
 	url := strings.Join([]string{
 		d.Endpoint,
 		urls.GenerateDockURL(urls.Client, d.TenantId, dckID)}, "/")
 
-	if err := d.Recv(url, "GET", nil, &res); err != nil {
+	if err := d.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -49,6 +55,11 @@ func (d *DockMgr) GetDock(dckID string) (*model.DockSpec, error) {
 }
 
 func (d *DockMgr) ListDocks(args ...interface{}) ([]*model.DockSpec, error) {
+	return d.ListDocksWithContext(context.Background(), args...)
+}
+
+// ListDocksWithContext implementation
+func (d *DockMgr) ListDocksWithContext(ctx context.Context, args ...interface{}) ([]*model.DockSpec, error) {
 	var res []*model.DockSpec
 
 	url := strings.Join([]string{
@@ -64,7 +75,7 @@ func (d *DockMgr) ListDocks(args ...interface{}) ([]*model.DockSpec, error) {
 		url += "?" + param
 	}
 
-	if err := d.Recv(url, "GET", nil, &res); err != nil {
+	if err := d.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
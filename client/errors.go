@@ -0,0 +1,60 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+// Sentinel errors mirroring the server error codes. A failed call returns an
+// *HttpError that wraps one of these, so callers can branch with
+// errors.Is(err, client.ErrNotFound) instead of matching on the HTTP status
+// code, and reach the code and raw message with errors.As.
+var (
+	ErrBadRequest     = errors.New("bad request")
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrForbidden      = errors.New("forbidden")
+	ErrNotFound       = errors.New("not found")
+	ErrConflict       = errors.New("conflict")
+	ErrEntityTooLarge = errors.New("request entity too large")
+	ErrInternalServer = errors.New("internal server error")
+	ErrNotImplemented = errors.New("not implemented")
+)
+
+// Unwrap maps the HTTP status code to its sentinel error, giving HttpError
+// errors.Is support. Nil is returned for a status without a sentinel.
+func (e *HttpError) Unwrap() error {
+	switch e.Code {
+	case model.ErrorBadRequest:
+		return ErrBadRequest
+	case model.ErrorUnauthorized:
+		return ErrUnauthorized
+	case model.ErrorForbidden:
+		return ErrForbidden
+	case model.ErrorNotFound:
+		return ErrNotFound
+	case model.ErrorConflict:
+		return ErrConflict
+	case model.ErrorEntityTooLarge:
+		return ErrEntityTooLarge
+	case model.ErrorInternalServer:
+		return ErrInternalServer
+	case model.ErrorNotImplemented:
+		return ErrNotImplemented
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestHttpErrorSentinels(t *testing.T) {
+
+	t.Run("Should match the sentinel of its status code", func(t *testing.T) {
+		err := NewHttpError(http.StatusNotFound, `{"message": "volume xyz not found"}`)
+		assertTestResult(t, errors.Is(err, ErrNotFound), true)
+		assertTestResult(t, errors.Is(err, ErrConflict), false)
+	})
+
+	t.Run("Should expose the status code through errors.As", func(t *testing.T) {
+		err := NewHttpError(http.StatusConflict, `{"message": "volume is in use"}`)
+		var httpErr *HttpError
+		assertTestResult(t, errors.As(err, &httpErr), true)
+		assertTestResult(t, httpErr.Code, http.StatusConflict)
+	})
+
+	t.Run("Should not match any sentinel for an unmapped status", func(t *testing.T) {
+		err := NewHttpError(http.StatusBadGateway, "bad gateway")
+		assertTestResult(t, errors.Is(err, ErrInternalServer), false)
+	})
+}
@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -488,3 +489,37 @@ func (*fakeHostReceiver) Recv(
 
 	return nil
 }
+
+// RecvWithContext variants of the fake receivers just ignore the context and
+// delegate to Recv, since no real network request is made here.
+func (f *fakeDockReceiver) RecvWithContext(ctx context.Context, url string, method string, in interface{}, out interface{}) error {
+	return f.Recv(url, method, in, out)
+}
+
+func (f *fakePoolReceiver) RecvWithContext(ctx context.Context, url string, method string, in interface{}, out interface{}) error {
+	return f.Recv(url, method, in, out)
+}
+
+func (f *fakeProfileReceiver) RecvWithContext(ctx context.Context, url string, method string, in interface{}, out interface{}) error {
+	return f.Recv(url, method, in, out)
+}
+
+func (f *fakeVolumeReceiver) RecvWithContext(ctx context.Context, url string, method string, in interface{}, out interface{}) error {
+	return f.Recv(url, method, in, out)
+}
+
+func (f *fakeReplicationReceiver) RecvWithContext(ctx context.Context, url string, method string, in interface{}, out interface{}) error {
+	return f.Recv(url, method, in, out)
+}
+
+func (f *fakeVersionReceiver) RecvWithContext(ctx context.Context, url string, method string, in interface{}, out interface{}) error {
+	return f.Recv(url, method, in, out)
+}
+
+func (f *fakeFileShareReceiver) RecvWithContext(ctx context.Context, url string, method string, in interface{}, out interface{}) error {
+	return f.Recv(url, method, in, out)
+}
+
+func (f *fakeHostReceiver) RecvWithContext(ctx context.Context, url string, method string, in interface{}, out interface{}) error {
+	return f.Recv(url, method, in, out)
+}
@@ -0,0 +1,239 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package fake implements the client SDK against an in-memory store instead
+of a live API server, so downstream projects like CSI drivers and
+dashboards can unit test their SODA integration without standing one up.
+
+Resources created through the client land in the Store, reads and lists
+serve them back, and state transitions the real server would drive
+asynchronously are under the test's control through Store.SetStatus.
+*/
+
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/sodafoundation/api/client"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+// The endpoint the fake client pretends to talk to.
+const Endpoint = "http://fake-opensds-endpoint:50040"
+
+// NewClient returns a client whose managers run against a fresh in-memory
+// store, together with the store itself for seeding records and driving
+// state transitions.
+func NewClient() (*client.Client, *Store) {
+	store := NewStore()
+	tenantId := constants.DefaultTenantId
+	return &client.Client{
+		ProfileMgr:     client.NewProfileMgr(store, Endpoint, tenantId),
+		DockMgr:        client.NewDockMgr(store, Endpoint, tenantId),
+		PoolMgr:        client.NewPoolMgr(store, Endpoint, tenantId),
+		VolumeMgr:      client.NewVolumeMgr(store, Endpoint, tenantId),
+		VersionMgr:     client.NewVersionMgr(store, Endpoint, tenantId),
+		ReplicationMgr: client.NewReplicationMgr(store, Endpoint, tenantId),
+		FileShareMgr:   client.NewFileShareMgr(store, Endpoint, tenantId),
+		HostMgr:        client.NewHostMgr(store, Endpoint, tenantId),
+	}, store
+}
+
+// Store is an in-memory resource store implementing the client Receiver.
+// Records are kept as decoded json objects keyed by their resource path,
+// e.g. v1beta/<tenantId>/block/volumes/<id>.
+type Store struct {
+	lock    sync.Mutex
+	records map[string]map[string]interface{}
+
+	// CreateStatus maps a collection name to the status stamped on records
+	// created through it, mirroring the transitional status the real server
+	// would set, e.g. "volumes" to "creating". Collections without an entry
+	// keep the status of the request body.
+	CreateStatus map[string]string
+}
+
+func NewStore() *Store {
+	return &Store{
+		records:      map[string]map[string]interface{}{},
+		CreateStatus: map[string]string{},
+	}
+}
+
+// Recv serves one client call from the store.
+func (s *Store) Recv(url string, method string, input interface{}, output interface{}) error {
+	return s.RecvWithContext(context.Background(), url, method, input, output)
+}
+
+// RecvWithContext implementation
+func (s *Store) RecvWithContext(ctx context.Context, url string, method string, input interface{}, output interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := resourcePath(url)
+	switch strings.ToUpper(method) {
+	case "POST":
+		return s.create(path, input, output)
+	case "GET":
+		if isListOutput(output) {
+			return s.list(path, output)
+		}
+		return s.get(path, output)
+	case "PUT":
+		return s.update(path, input, output)
+	case "DELETE":
+		delete(s.records, path)
+		return nil
+	}
+	return fmt.Errorf("method %s not supported by the fake client", method)
+}
+
+// SetStatus drives the state transition of the stored resource with the
+// given id, standing in for the dock that would report back in a real
+// deployment. For example a volume created through the client starts in
+// "creating" and the test moves it to "available" when it sees fit.
+func (s *Store) SetStatus(id, status string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, record := range s.records {
+		if record["id"] == id {
+			record["status"] = status
+			return nil
+		}
+	}
+	return fmt.Errorf("no resource with id %s in the fake store", id)
+}
+
+// Reset drops every stored record.
+func (s *Store) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.records = map[string]map[string]interface{}{}
+}
+
+func (s *Store) create(path string, input, output interface{}) error {
+	record, err := toRecord(input)
+	if err != nil {
+		return err
+	}
+	id, _ := record["id"].(string)
+	if id == "" {
+		id = uuid.NewV4().String()
+		record["id"] = id
+	}
+	if record["createdAt"] == nil {
+		record["createdAt"] = utils.FormatTime(time.Now())
+	}
+	collection := path[strings.LastIndex(path, "/")+1:]
+	if status := s.CreateStatus[collection]; status != "" {
+		record["status"] = status
+	}
+	s.records[path+"/"+id] = record
+	return fromRecord(record, output)
+}
+
+func (s *Store) get(path string, output interface{}) error {
+	record, ok := s.records[path]
+	if !ok {
+		return &client.HttpError{Code: model.ErrorNotFound,
+			Msg: fmt.Sprintf("resource %s not found in the fake store", path)}
+	}
+	return fromRecord(record, output)
+}
+
+func (s *Store) update(path string, input, output interface{}) error {
+	record, ok := s.records[path]
+	if !ok {
+		return &client.HttpError{Code: model.ErrorNotFound,
+			Msg: fmt.Sprintf("resource %s not found in the fake store", path)}
+	}
+	changes, err := toRecord(input)
+	if err != nil {
+		return err
+	}
+	for k, v := range changes {
+		if v != nil && v != "" {
+			record[k] = v
+		}
+	}
+	return fromRecord(record, output)
+}
+
+func (s *Store) list(path string, output interface{}) error {
+	var matched []map[string]interface{}
+	prefix := path + "/"
+	for key, record := range s.records {
+		if strings.HasPrefix(key, prefix) && !strings.Contains(key[len(prefix):], "/") {
+			matched = append(matched, record)
+		}
+	}
+	return fromRecord(matched, output)
+}
+
+// resourcePath strips the endpoint prefix and the query parameters off the
+// url the manager built, leaving the resource path used as store key.
+func resourcePath(url string) string {
+	path := strings.TrimPrefix(url, Endpoint)
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	return strings.Trim(path, "/")
+}
+
+// isListOutput reports whether the call expects a collection, which the
+// managers express by decoding into a pointer to a slice.
+func isListOutput(output interface{}) bool {
+	return strings.HasPrefix(fmt.Sprintf("%T", output), "*[]")
+}
+
+// toRecord decodes the request body into the generic json object records
+// are stored as, so the store works for every resource type alike.
+func toRecord(input interface{}) (map[string]interface{}, error) {
+	record := map[string]interface{}{}
+	if input == nil {
+		return record, nil
+	}
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// fromRecord encodes a stored record (or list of records) back into the
+// typed output of the client call.
+func fromRecord(record interface{}, output interface{}) error {
+	if output == nil {
+		return nil
+	}
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, output)
+}
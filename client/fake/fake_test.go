@@ -0,0 +1,115 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sodafoundation/api/client"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func TestFakeClientVolumeLifecycle(t *testing.T) {
+	cli, store := NewClient()
+	store.CreateStatus["volumes"] = model.VolumeCreating
+
+	vol, err := cli.CreateVolume(&model.VolumeSpec{
+		BaseModel: &model.BaseModel{},
+		Name:      "fake-volume",
+		Size:      10,
+	})
+	if err != nil {
+		t.Fatalf("failed to create volume in the fake client: %v", err)
+	}
+	if vol.Id == "" {
+		t.Error("expected the fake store to assign an id")
+	}
+	if vol.Status != model.VolumeCreating {
+		t.Errorf("expected status %s, got %s", model.VolumeCreating, vol.Status)
+	}
+
+	vols, err := cli.ListVolumes()
+	if err != nil {
+		t.Fatalf("failed to list volumes in the fake client: %v", err)
+	}
+	if len(vols) != 1 {
+		t.Fatalf("expected 1 volume, got %d", len(vols))
+	}
+
+	// The test drives the transition the dock would report in a real
+	// deployment.
+	if err := store.SetStatus(vol.Id, model.VolumeAvailable); err != nil {
+		t.Fatalf("failed to transition the volume: %v", err)
+	}
+	got, err := cli.GetVolume(vol.Id)
+	if err != nil {
+		t.Fatalf("failed to get volume in the fake client: %v", err)
+	}
+	if got.Status != model.VolumeAvailable {
+		t.Errorf("expected status %s, got %s", model.VolumeAvailable, got.Status)
+	}
+
+	updated, err := cli.UpdateVolume(vol.Id, &model.VolumeSpec{
+		BaseModel: &model.BaseModel{},
+		Name:      "renamed-volume",
+	})
+	if err != nil {
+		t.Fatalf("failed to update volume in the fake client: %v", err)
+	}
+	if updated.Name != "renamed-volume" || updated.Size != 10 {
+		t.Errorf("expected the update to merge over the record, got %+v", updated)
+	}
+
+	if err := cli.DeleteVolume(vol.Id, nil); err != nil {
+		t.Fatalf("failed to delete volume in the fake client: %v", err)
+	}
+	if _, err := cli.GetVolume(vol.Id); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after deletion, got %v", err)
+	}
+}
+
+func TestFakeClientIsolatesResources(t *testing.T) {
+	cli, store := NewClient()
+
+	if _, err := cli.CreateProfile(&model.ProfileSpec{
+		BaseModel:   &model.BaseModel{},
+		Name:        "fake-profile",
+		StorageType: "block",
+	}); err != nil {
+		t.Fatalf("failed to create profile in the fake client: %v", err)
+	}
+
+	vols, err := cli.ListVolumes()
+	if err != nil {
+		t.Fatalf("failed to list volumes in the fake client: %v", err)
+	}
+	if len(vols) != 0 {
+		t.Errorf("expected no volumes, got %d", len(vols))
+	}
+	profiles, err := cli.ListProfiles()
+	if err != nil {
+		t.Fatalf("failed to list profiles in the fake client: %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Errorf("expected 1 profile, got %d", len(profiles))
+	}
+
+	store.Reset()
+	profiles, _ = cli.ListProfiles()
+	if len(profiles) != 0 {
+		t.Errorf("expected no profiles after reset, got %d", len(profiles))
+	}
+}
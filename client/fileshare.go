@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"strings"
 
 	"github.com/sodafoundation/api/pkg/model"
@@ -54,13 +55,18 @@ type FileShareMgr struct {
 
 // CreateFileShare implementation
 func (v *FileShareMgr) CreateFileShare(body FileShareBuilder) (*model.FileShareSpec, error) {
+	return v.CreateFileShareWithContext(context.Background(), body)
+}
+
+// CreateFileShareWithContext implementation
+func (v *FileShareMgr) CreateFileShareWithContext(ctx context.Context, body FileShareBuilder) (*model.FileShareSpec, error) {
 	var res model.FileShareSpec
 
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareURL(urls.Client, v.TenantID)}, "/")
 
-	if err := v.Recv(url, "POST", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -69,21 +75,31 @@ func (v *FileShareMgr) CreateFileShare(body FileShareBuilder) (*model.FileShareS
 
 // DeleteFileShare implementation
 func (v *FileShareMgr) DeleteFileShare(ID string) error {
+	return v.DeleteFileShareWithContext(context.Background(), ID)
+}
+
+// DeleteFileShareWithContext implementation
+func (v *FileShareMgr) DeleteFileShareWithContext(ctx context.Context, ID string) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareURL(urls.Client, v.TenantID, ID)}, "/")
 
-	return v.Recv(url, "DELETE", nil, nil)
+	return v.RecvWithContext(ctx, url, "DELETE", nil, nil)
 }
 
 // GetFileShare implementation
 func (v *FileShareMgr) GetFileShare(ID string) (*model.FileShareSpec, error) {
+	return v.GetFileShareWithContext(context.Background(), ID)
+}
+
+// GetFileShareWithContext implementation
+func (v *FileShareMgr) GetFileShareWithContext(ctx context.Context, ID string) (*model.FileShareSpec, error) {
 	var res model.FileShareSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareURL(urls.Client, v.TenantID, ID)}, "/")
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -92,6 +108,11 @@ func (v *FileShareMgr) GetFileShare(ID string) (*model.FileShareSpec, error) {
 
 // ListFileShares implementation
 func (v *FileShareMgr) ListFileShares(args ...interface{}) ([]*model.FileShareSpec, error) {
+	return v.ListFileSharesWithContext(context.Background(), args...)
+}
+
+// ListFileSharesWithContext implementation
+func (v *FileShareMgr) ListFileSharesWithContext(ctx context.Context, args ...interface{}) ([]*model.FileShareSpec, error) {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareURL(urls.Client, v.TenantID)}, "/")
@@ -106,7 +127,7 @@ func (v *FileShareMgr) ListFileShares(args ...interface{}) ([]*model.FileShareSp
 	}
 
 	var res []*model.FileShareSpec
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -114,12 +135,17 @@ func (v *FileShareMgr) ListFileShares(args ...interface{}) ([]*model.FileShareSp
 
 // UpdateFileShare implementation
 func (v *FileShareMgr) UpdateFileShare(ID string, body FileShareBuilder) (*model.FileShareSpec, error) {
+	return v.UpdateFileShareWithContext(context.Background(), ID, body)
+}
+
+// UpdateFileShareWithContext implementation
+func (v *FileShareMgr) UpdateFileShareWithContext(ctx context.Context, ID string, body FileShareBuilder) (*model.FileShareSpec, error) {
 	var res model.FileShareSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareURL(urls.Client, v.TenantID, ID)}, "/")
 
-	if err := v.Recv(url, "PUT", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "PUT", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -128,13 +154,18 @@ func (v *FileShareMgr) UpdateFileShare(ID string, body FileShareBuilder) (*model
 
 // CreateFileShareSnapshot implementation
 func (v *FileShareMgr) CreateFileShareSnapshot(body FileShareSnapshotBuilder) (*model.FileShareSnapshotSpec, error) {
+	return v.CreateFileShareSnapshotWithContext(context.Background(), body)
+}
+
+// CreateFileShareSnapshotWithContext implementation
+func (v *FileShareMgr) CreateFileShareSnapshotWithContext(ctx context.Context, body FileShareSnapshotBuilder) (*model.FileShareSnapshotSpec, error) {
 	var res model.FileShareSnapshotSpec
 
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareSnapshotURL(urls.Client, v.TenantID)}, "/")
 
-	if err := v.Recv(url, "POST", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -143,21 +174,31 @@ func (v *FileShareMgr) CreateFileShareSnapshot(body FileShareSnapshotBuilder) (*
 
 // DeleteFileShareSnapshot implementation
 func (v *FileShareMgr) DeleteFileShareSnapshot(ID string) error {
+	return v.DeleteFileShareSnapshotWithContext(context.Background(), ID)
+}
+
+// DeleteFileShareSnapshotWithContext implementation
+func (v *FileShareMgr) DeleteFileShareSnapshotWithContext(ctx context.Context, ID string) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareSnapshotURL(urls.Client, v.TenantID, ID)}, "/")
 
-	return v.Recv(url, "DELETE", nil, nil)
+	return v.RecvWithContext(ctx, url, "DELETE", nil, nil)
 }
 
 // GetFileShareSnapshot implementation
 func (v *FileShareMgr) GetFileShareSnapshot(ID string) (*model.FileShareSnapshotSpec, error) {
+	return v.GetFileShareSnapshotWithContext(context.Background(), ID)
+}
+
+// GetFileShareSnapshotWithContext implementation
+func (v *FileShareMgr) GetFileShareSnapshotWithContext(ctx context.Context, ID string) (*model.FileShareSnapshotSpec, error) {
 	var res model.FileShareSnapshotSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareSnapshotURL(urls.Client, v.TenantID, ID)}, "/")
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -166,6 +207,11 @@ func (v *FileShareMgr) GetFileShareSnapshot(ID string) (*model.FileShareSnapshot
 
 // ListFileShareSnapshots implementation
 func (v *FileShareMgr) ListFileShareSnapshots(args ...interface{}) ([]*model.FileShareSnapshotSpec, error) {
+	return v.ListFileShareSnapshotsWithContext(context.Background(), args...)
+}
+
+// ListFileShareSnapshotsWithContext implementation
+func (v *FileShareMgr) ListFileShareSnapshotsWithContext(ctx context.Context, args ...interface{}) ([]*model.FileShareSnapshotSpec, error) {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareSnapshotURL(urls.Client, v.TenantID)}, "/")
@@ -180,7 +226,7 @@ func (v *FileShareMgr) ListFileShareSnapshots(args ...interface{}) ([]*model.Fil
 	}
 
 	var res []*model.FileShareSnapshotSpec
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -188,12 +234,17 @@ func (v *FileShareMgr) ListFileShareSnapshots(args ...interface{}) ([]*model.Fil
 
 // UpdateFileShareSnapshot implementation
 func (v *FileShareMgr) UpdateFileShareSnapshot(ID string, body FileShareSnapshotBuilder) (*model.FileShareSnapshotSpec, error) {
+	return v.UpdateFileShareSnapshotWithContext(context.Background(), ID, body)
+}
+
+// UpdateFileShareSnapshotWithContext implementation
+func (v *FileShareMgr) UpdateFileShareSnapshotWithContext(ctx context.Context, ID string, body FileShareSnapshotBuilder) (*model.FileShareSnapshotSpec, error) {
 	var res model.FileShareSnapshotSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareSnapshotURL(urls.Client, v.TenantID, ID)}, "/")
 
-	if err := v.Recv(url, "PUT", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "PUT", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -202,13 +253,18 @@ func (v *FileShareMgr) UpdateFileShareSnapshot(ID string, body FileShareSnapshot
 
 // CreateFileShareAcl implementation
 func (v *FileShareMgr) CreateFileShareAcl(body FileShareAclBuilder) (*model.FileShareAclSpec, error) {
+	return v.CreateFileShareAclWithContext(context.Background(), body)
+}
+
+// CreateFileShareAclWithContext implementation
+func (v *FileShareMgr) CreateFileShareAclWithContext(ctx context.Context, body FileShareAclBuilder) (*model.FileShareAclSpec, error) {
 	var res model.FileShareAclSpec
 
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareAclURL(urls.Client, v.TenantID)}, "/")
 
-	if err := v.Recv(url, "POST", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -217,21 +273,31 @@ func (v *FileShareMgr) CreateFileShareAcl(body FileShareAclBuilder) (*model.File
 
 // DeleteFileShareAcl implementation
 func (v *FileShareMgr) DeleteFileShareAcl(ID string) error {
+	return v.DeleteFileShareAclWithContext(context.Background(), ID)
+}
+
+// DeleteFileShareAclWithContext implementation
+func (v *FileShareMgr) DeleteFileShareAclWithContext(ctx context.Context, ID string) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareAclURL(urls.Client, v.TenantID, ID)}, "/")
 
-	return v.Recv(url, "DELETE", nil, nil)
+	return v.RecvWithContext(ctx, url, "DELETE", nil, nil)
 }
 
 // GetFileShareAcl implementation
 func (v *FileShareMgr) GetFileShareAcl(ID string) (*model.FileShareAclSpec, error) {
+	return v.GetFileShareAclWithContext(context.Background(), ID)
+}
+
+// GetFileShareAclWithContext implementation
+func (v *FileShareMgr) GetFileShareAclWithContext(ctx context.Context, ID string) (*model.FileShareAclSpec, error) {
 	var res model.FileShareAclSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareAclURL(urls.Client, v.TenantID, ID)}, "/")
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -240,6 +306,11 @@ func (v *FileShareMgr) GetFileShareAcl(ID string) (*model.FileShareAclSpec, erro
 
 // ListFileSharesAcl implementation
 func (v *FileShareMgr) ListFileSharesAcl(args ...interface{}) ([]*model.FileShareAclSpec, error) {
+	return v.ListFileSharesAclWithContext(context.Background(), args...)
+}
+
+// ListFileSharesAclWithContext implementation
+func (v *FileShareMgr) ListFileSharesAclWithContext(ctx context.Context, args ...interface{}) ([]*model.FileShareAclSpec, error) {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateFileShareAclURL(urls.Client, v.TenantID)}, "/")
@@ -254,7 +325,7 @@ func (v *FileShareMgr) ListFileSharesAcl(args ...interface{}) ([]*model.FileShar
 	}
 
 	var res []*model.FileShareAclSpec
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
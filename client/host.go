@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"strings"
 
 	"github.com/sodafoundation/api/pkg/model"
@@ -42,13 +43,18 @@ type HostMgr struct {
 
 // CreateHost implementation
 func (h *HostMgr) CreateHost(body HostBuilder) (*model.HostSpec, error) {
+	return h.CreateHostWithContext(context.Background(), body)
+}
+
+// CreateHostWithContext implementation
+func (h *HostMgr) CreateHostWithContext(ctx context.Context, body HostBuilder) (*model.HostSpec, error) {
 	var res model.HostSpec
 
 	url := strings.Join([]string{
 		h.Endpoint,
 		urls.GenerateHostURL(urls.Client, h.TenantID)}, "/")
 
-	if err := h.Recv(url, "POST", body, &res); err != nil {
+	if err := h.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -57,12 +63,17 @@ func (h *HostMgr) CreateHost(body HostBuilder) (*model.HostSpec, error) {
 
 // GetHost implementation
 func (h *HostMgr) GetHost(ID string) (*model.HostSpec, error) {
+	return h.GetHostWithContext(context.Background(), ID)
+}
+
+// GetHostWithContext implementation
+func (h *HostMgr) GetHostWithContext(ctx context.Context, ID string) (*model.HostSpec, error) {
 	var res model.HostSpec
 	url := strings.Join([]string{
 		h.Endpoint,
 		urls.GenerateHostURL(urls.Client, h.TenantID, ID)}, "/")
 
-	if err := h.Recv(url, "GET", nil, &res); err != nil {
+	if err := h.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -71,6 +82,11 @@ func (h *HostMgr) GetHost(ID string) (*model.HostSpec, error) {
 
 // ListHosts implementation
 func (h *HostMgr) ListHosts(args ...interface{}) ([]*model.HostSpec, error) {
+	return h.ListHostsWithContext(context.Background(), args...)
+}
+
+// ListHostsWithContext implementation
+func (h *HostMgr) ListHostsWithContext(ctx context.Context, args ...interface{}) ([]*model.HostSpec, error) {
 	url := strings.Join([]string{
 		h.Endpoint,
 		urls.GenerateHostURL(urls.Client, h.TenantID)}, "/")
@@ -85,7 +101,7 @@ func (h *HostMgr) ListHosts(args ...interface{}) ([]*model.HostSpec, error) {
 	}
 
 	var res []*model.HostSpec
-	if err := h.Recv(url, "GET", nil, &res); err != nil {
+	if err := h.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -93,12 +109,17 @@ func (h *HostMgr) ListHosts(args ...interface{}) ([]*model.HostSpec, error) {
 
 // UpdateHost implementation
 func (h *HostMgr) UpdateHost(ID string, body HostBuilder) (*model.HostSpec, error) {
+	return h.UpdateHostWithContext(context.Background(), ID, body)
+}
+
+// UpdateHostWithContext implementation
+func (h *HostMgr) UpdateHostWithContext(ctx context.Context, ID string, body HostBuilder) (*model.HostSpec, error) {
 	var res model.HostSpec
 	url := strings.Join([]string{
 		h.Endpoint,
 		urls.GenerateHostURL(urls.Client, h.TenantID, ID)}, "/")
 
-	if err := h.Recv(url, "PUT", body, &res); err != nil {
+	if err := h.RecvWithContext(ctx, url, "PUT", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -107,9 +128,14 @@ func (h *HostMgr) UpdateHost(ID string, body HostBuilder) (*model.HostSpec, erro
 
 // DeleteHost implementation
 func (h *HostMgr) DeleteHost(ID string) error {
+	return h.DeleteHostWithContext(context.Background(), ID)
+}
+
+// DeleteHostWithContext implementation
+func (h *HostMgr) DeleteHostWithContext(ctx context.Context, ID string) error {
 	url := strings.Join([]string{
 		h.Endpoint,
 		urls.GenerateHostURL(urls.Client, h.TenantID, ID)}, "/")
 
-	return h.Recv(url, "DELETE", nil, nil)
+	return h.RecvWithContext(ctx, url, "DELETE", nil, nil)
 }
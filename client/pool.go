@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"strings"
 
 	"github.com/sodafoundation/api/pkg/model"
@@ -39,12 +40,17 @@ type PoolMgr struct {
 
 // GetPool
 func (p *PoolMgr) GetPool(polID string) (*model.StoragePoolSpec, error) {
+	return p.GetPoolWithContext(context.Background(), polID)
+}
+
+// GetPoolWithContext implementation
+func (p *PoolMgr) GetPoolWithContext(ctx context.Context, polID string) (*model.StoragePoolSpec, error) {
 	var res model.StoragePoolSpec
 	url := strings.Join([]string{
 		p.Endpoint,
 		urls.GeneratePoolURL(urls.Client, p.TenantId, polID)}, "/")
 
-	if err := p.Recv(url, "GET", nil, &res); err != nil {
+	if err := p.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -53,6 +59,11 @@ func (p *PoolMgr) GetPool(polID string) (*model.StoragePoolSpec, error) {
 
 // ListPools
 func (p *PoolMgr) ListPools(args ...interface{}) ([]*model.StoragePoolSpec, error) {
+	return p.ListPoolsWithContext(context.Background(), args...)
+}
+
+// ListPoolsWithContext implementation
+func (p *PoolMgr) ListPoolsWithContext(ctx context.Context, args ...interface{}) ([]*model.StoragePoolSpec, error) {
 	var res []*model.StoragePoolSpec
 
 	url := strings.Join([]string{
@@ -68,7 +79,7 @@ func (p *PoolMgr) ListPools(args ...interface{}) ([]*model.StoragePoolSpec, erro
 		url += "?" + param
 	}
 
-	if err := p.Recv(url, "GET", nil, &res); err != nil {
+	if err := p.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"strings"
 
 	"github.com/sodafoundation/api/pkg/model"
@@ -50,12 +51,17 @@ type ProfileMgr struct {
 
 // CreateProfile
 func (p *ProfileMgr) CreateProfile(body ProfileBuilder) (*model.ProfileSpec, error) {
+	return p.CreateProfileWithContext(context.Background(), body)
+}
+
+// CreateProfileWithContext implementation
+func (p *ProfileMgr) CreateProfileWithContext(ctx context.Context, body ProfileBuilder) (*model.ProfileSpec, error) {
 	var res model.ProfileSpec
 	url := strings.Join([]string{
 		p.Endpoint,
 		urls.GenerateProfileURL(urls.Client, p.TenantId)}, "/")
 
-	if err := p.Recv(url, "POST", body, &res); err != nil {
+	if err := p.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -64,12 +70,17 @@ func (p *ProfileMgr) CreateProfile(body ProfileBuilder) (*model.ProfileSpec, err
 
 // GetProfile
 func (p *ProfileMgr) GetProfile(prfID string) (*model.ProfileSpec, error) {
+	return p.GetProfileWithContext(context.Background(), prfID)
+}
+
+// GetProfileWithContext implementation
+func (p *ProfileMgr) GetProfileWithContext(ctx context.Context, prfID string) (*model.ProfileSpec, error) {
 	var res model.ProfileSpec
 	url := strings.Join([]string{
 		p.Endpoint,
 		urls.GenerateProfileURL(urls.Client, p.TenantId, prfID)}, "/")
 
-	if err := p.Recv(url, "GET", nil, &res); err != nil {
+	if err := p.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -78,12 +89,17 @@ func (p *ProfileMgr) GetProfile(prfID string) (*model.ProfileSpec, error) {
 
 // UpdateProfile ...
 func (p *ProfileMgr) UpdateProfile(prfID string, body ProfileBuilder) (*model.ProfileSpec, error) {
+	return p.UpdateProfileWithContext(context.Background(), prfID, body)
+}
+
+// UpdateProfileWithContext implementation
+func (p *ProfileMgr) UpdateProfileWithContext(ctx context.Context, prfID string, body ProfileBuilder) (*model.ProfileSpec, error) {
 	var res model.ProfileSpec
 	url := strings.Join([]string{
 		p.Endpoint,
 		urls.GenerateProfileURL(urls.Client, p.TenantId, prfID)}, "/")
 
-	if err := p.Recv(url, "PUT", body, &res); err != nil {
+	if err := p.RecvWithContext(ctx, url, "PUT", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -92,6 +108,11 @@ func (p *ProfileMgr) UpdateProfile(prfID string, body ProfileBuilder) (*model.Pr
 
 // ListProfiles
 func (p *ProfileMgr) ListProfiles(args ...interface{}) ([]*model.ProfileSpec, error) {
+	return p.ListProfilesWithContext(context.Background(), args...)
+}
+
+// ListProfilesWithContext implementation
+func (p *ProfileMgr) ListProfilesWithContext(ctx context.Context, args ...interface{}) ([]*model.ProfileSpec, error) {
 	var res []*model.ProfileSpec
 
 	url := strings.Join([]string{
@@ -106,7 +127,7 @@ func (p *ProfileMgr) ListProfiles(args ...interface{}) ([]*model.ProfileSpec, er
 	if param != "" {
 		url += "?" + param
 	}
-	if err := p.Recv(url, "GET", nil, &res); err != nil {
+	if err := p.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -115,22 +136,32 @@ func (p *ProfileMgr) ListProfiles(args ...interface{}) ([]*model.ProfileSpec, er
 
 // DeleteProfile
 func (p *ProfileMgr) DeleteProfile(prfID string) error {
+	return p.DeleteProfileWithContext(context.Background(), prfID)
+}
+
+// DeleteProfileWithContext implementation
+func (p *ProfileMgr) DeleteProfileWithContext(ctx context.Context, prfID string) error {
 	url := strings.Join([]string{
 		p.Endpoint,
 		urls.GenerateProfileURL(urls.Client, p.TenantId, prfID)}, "/")
 
-	return p.Recv(url, "DELETE", nil, nil)
+	return p.RecvWithContext(ctx, url, "DELETE", nil, nil)
 }
 
 // AddCustomProperty
 func (p *ProfileMgr) AddCustomProperty(prfID string, body CustomBuilder) (*model.CustomPropertiesSpec, error) {
+	return p.AddCustomPropertyWithContext(context.Background(), prfID, body)
+}
+
+// AddCustomPropertyWithContext implementation
+func (p *ProfileMgr) AddCustomPropertyWithContext(ctx context.Context, prfID string, body CustomBuilder) (*model.CustomPropertiesSpec, error) {
 	var res model.CustomPropertiesSpec
 	url := strings.Join([]string{
 		p.Endpoint,
 		urls.GenerateProfileURL(urls.Client, p.TenantId, prfID),
 		"customProperties"}, "/")
 
-	if err := p.Recv(url, "POST", body, &res); err != nil {
+	if err := p.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -139,13 +170,18 @@ func (p *ProfileMgr) AddCustomProperty(prfID string, body CustomBuilder) (*model
 
 // ListCustomProperties
 func (p *ProfileMgr) ListCustomProperties(prfID string) (*model.CustomPropertiesSpec, error) {
+	return p.ListCustomPropertiesWithContext(context.Background(), prfID)
+}
+
+// ListCustomPropertiesWithContext implementation
+func (p *ProfileMgr) ListCustomPropertiesWithContext(ctx context.Context, prfID string) (*model.CustomPropertiesSpec, error) {
 	var res model.CustomPropertiesSpec
 	url := strings.Join([]string{
 		p.Endpoint,
 		urls.GenerateProfileURL(urls.Client, p.TenantId, prfID),
 		"customProperties"}, "/")
 
-	if err := p.Recv(url, "GET", nil, &res); err != nil {
+	if err := p.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -154,10 +190,15 @@ func (p *ProfileMgr) ListCustomProperties(prfID string) (*model.CustomProperties
 
 // RemoveCustomProperty
 func (p *ProfileMgr) RemoveCustomProperty(prfID, customKey string) error {
+	return p.RemoveCustomPropertyWithContext(context.Background(), prfID, customKey)
+}
+
+// RemoveCustomPropertyWithContext implementation
+func (p *ProfileMgr) RemoveCustomPropertyWithContext(ctx context.Context, prfID, customKey string) error {
 	url := strings.Join([]string{
 		p.Endpoint,
 		urls.GenerateProfileURL(urls.Client, p.TenantId, prfID),
 		"customProperties", customKey}, "/")
 
-	return p.Recv(url, "DELETE", nil, nil)
+	return p.RecvWithContext(ctx, url, "DELETE", nil, nil)
 }
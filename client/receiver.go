@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -63,6 +64,10 @@ type HeaderOption map[string]string
 // Receiver
 type Receiver interface {
 	Recv(url string, method string, input interface{}, output interface{}) error
+
+	// RecvWithContext behaves like Recv, but the request can be cancelled
+	// or get a deadline through the given context.
+	RecvWithContext(ctx context.Context, url string, method string, input interface{}, output interface{}) error
 }
 
 // NewReceiver
@@ -95,13 +100,34 @@ func customVerify(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error
 	return nil
 }
 
-func request(urlStr string, method string, headers HeaderOption, input interface{}, output interface{}) error {
+func request(ctx context.Context, urlStr string, method string, headers HeaderOption, input interface{}, output interface{}) error {
 	req := httplib.NewBeegoRequest(urlStr, strings.ToUpper(method))
 
+	// Attach the caller's context to the underlying http request, so that
+	// cancellation and deadlines are honored by the transport.
+	if ctx != nil {
+		r := req.GetRequest()
+		*r = *r.WithContext(ctx)
+	}
+
+	// A custom transport owns the whole connection, including proxying
+	// and TLS; the proxy and TLS settings below only apply to the default
+	// transport.
+	if transport != nil {
+		req.SetTransport(transport)
+	} else if proxy != nil {
+		req.SetProxy(proxy)
+	}
+
 	u, _ := url.Parse(urlStr)
-	if u.Scheme == "https" && cacert != "" {
-		log.Println("Https mode.")
-		req.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true, VerifyPeerCertificate: customVerify})
+	if u.Scheme == "https" && transport == nil {
+		switch {
+		case tlsConfig != nil:
+			req.SetTLSClientConfig(tlsConfig)
+		case cacert != "":
+			log.Println("Https mode.")
+			req.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true, VerifyPeerCertificate: customVerify})
+		}
 	}
 
 	// Set the request timeout a little bit longer upload snapshot to cloud temporarily.
@@ -151,10 +177,14 @@ func request(urlStr string, method string, headers HeaderOption, input interface
 
 type receiver struct{}
 
-func (*receiver) Recv(url string, method string, input interface{}, output interface{}) error {
+func (r *receiver) Recv(url string, method string, input interface{}, output interface{}) error {
+	return r.RecvWithContext(context.Background(), url, method, input, output)
+}
+
+func (*receiver) RecvWithContext(ctx context.Context, url string, method string, input interface{}, output interface{}) error {
 	headers := HeaderOption{}
 	headers["Content-Type"] = constants.ContentType
-	return request(url, method, headers, input, output)
+	return request(ctx, url, method, headers, input, output)
 }
 
 func NewKeystoneReceiver(auth *KeystoneAuthOptions) (Receiver, error) {
@@ -208,6 +238,10 @@ func (k *KeystoneReceiver) GetToken() error {
 }
 
 func (k *KeystoneReceiver) Recv(url string, method string, body interface{}, output interface{}) error {
+	return k.RecvWithContext(context.Background(), url, method, body, output)
+}
+
+func (k *KeystoneReceiver) RecvWithContext(ctx context.Context, url string, method string, body interface{}, output interface{}) error {
 	desc := fmt.Sprintf("%s %s", method, url)
 	return utils.Retry(2, desc, true, func(retryIdx int, lastErr error) error {
 		if retryIdx > 0 {
@@ -222,7 +256,7 @@ func (k *KeystoneReceiver) Recv(url string, method string, body interface{}, out
 		headers := HeaderOption{}
 		headers["Content-Type"] = constants.ContentType
 		headers[constants.AuthTokenHeader] = k.Auth.TokenID
-		return request(url, method, headers, body, output)
+		return request(ctx, url, method, headers, body, output)
 	})
 }
 
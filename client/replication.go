@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"strings"
 
 	"github.com/sodafoundation/api/pkg/model"
@@ -42,12 +43,17 @@ type ReplicationMgr struct {
 
 // CreateReplication
 func (v *ReplicationMgr) CreateReplication(body ReplicationBuilder) (*model.ReplicationSpec, error) {
+	return v.CreateReplicationWithContext(context.Background(), body)
+}
+
+// CreateReplicationWithContext implementation
+func (v *ReplicationMgr) CreateReplicationWithContext(ctx context.Context, body ReplicationBuilder) (*model.ReplicationSpec, error) {
 	var res model.ReplicationSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateReplicationURL(urls.Client, v.TenantId)}, "/")
 
-	if err := v.Recv(url, "POST", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -56,12 +62,17 @@ func (v *ReplicationMgr) CreateReplication(body ReplicationBuilder) (*model.Repl
 
 // GetReplication
 func (v *ReplicationMgr) GetReplication(replicaId string) (*model.ReplicationSpec, error) {
+	return v.GetReplicationWithContext(context.Background(), replicaId)
+}
+
+// GetReplicationWithContext implementation
+func (v *ReplicationMgr) GetReplicationWithContext(ctx context.Context, replicaId string) (*model.ReplicationSpec, error) {
 	var res model.ReplicationSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateReplicationURL(urls.Client, v.TenantId, replicaId)}, "/")
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -70,6 +81,11 @@ func (v *ReplicationMgr) GetReplication(replicaId string) (*model.ReplicationSpe
 
 // ListReplications
 func (v *ReplicationMgr) ListReplications(args ...interface{}) ([]*model.ReplicationSpec, error) {
+	return v.ListReplicationsWithContext(context.Background(), args...)
+}
+
+// ListReplicationsWithContext implementation
+func (v *ReplicationMgr) ListReplicationsWithContext(ctx context.Context, args ...interface{}) ([]*model.ReplicationSpec, error) {
 	var res []*model.ReplicationSpec
 
 	url := strings.Join([]string{
@@ -85,7 +101,7 @@ func (v *ReplicationMgr) ListReplications(args ...interface{}) ([]*model.Replica
 		url += "?" + param
 	}
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -94,20 +110,30 @@ func (v *ReplicationMgr) ListReplications(args ...interface{}) ([]*model.Replica
 
 // DeleteReplication
 func (v *ReplicationMgr) DeleteReplication(replicaId string, body ReplicationBuilder) error {
+	return v.DeleteReplicationWithContext(context.Background(), replicaId, body)
+}
+
+// DeleteReplicationWithContext implementation
+func (v *ReplicationMgr) DeleteReplicationWithContext(ctx context.Context, replicaId string, body ReplicationBuilder) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateReplicationURL(urls.Client, v.TenantId, replicaId)}, "/")
-	return v.Recv(url, "DELETE", body, nil)
+	return v.RecvWithContext(ctx, url, "DELETE", body, nil)
 }
 
 // UpdateReplication
 func (v *ReplicationMgr) UpdateReplication(replicaId string, body ReplicationBuilder) (*model.ReplicationSpec, error) {
+	return v.UpdateReplicationWithContext(context.Background(), replicaId, body)
+}
+
+// UpdateReplicationWithContext implementation
+func (v *ReplicationMgr) UpdateReplicationWithContext(ctx context.Context, replicaId string, body ReplicationBuilder) (*model.ReplicationSpec, error) {
 	var res model.ReplicationSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateReplicationURL(urls.Client, v.TenantId, replicaId)}, "/")
 
-	if err := v.Recv(url, "PUT", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "PUT", body, &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
@@ -115,24 +141,39 @@ func (v *ReplicationMgr) UpdateReplication(replicaId string, body ReplicationBui
 
 // EnableReplication
 func (v *ReplicationMgr) EnableReplication(replicaId string) error {
+	return v.EnableReplicationWithContext(context.Background(), replicaId)
+}
+
+// EnableReplicationWithContext implementation
+func (v *ReplicationMgr) EnableReplicationWithContext(ctx context.Context, replicaId string) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateReplicationURL(urls.Client, v.TenantId, replicaId, "enable")}, "/")
-	return v.Recv(url, "POST", nil, nil)
+	return v.RecvWithContext(ctx, url, "POST", nil, nil)
 }
 
 // EnableReplication
 func (v *ReplicationMgr) DisableReplication(replicaId string) error {
+	return v.DisableReplicationWithContext(context.Background(), replicaId)
+}
+
+// DisableReplicationWithContext implementation
+func (v *ReplicationMgr) DisableReplicationWithContext(ctx context.Context, replicaId string) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateReplicationURL(urls.Client, v.TenantId, replicaId, "disable")}, "/")
-	return v.Recv(url, "POST", nil, nil)
+	return v.RecvWithContext(ctx, url, "POST", nil, nil)
 }
 
 // EnableReplication
 func (v *ReplicationMgr) FailoverReplication(replicaId string, body FailoverReplicationBuilder) error {
+	return v.FailoverReplicationWithContext(context.Background(), replicaId, body)
+}
+
+// FailoverReplicationWithContext implementation
+func (v *ReplicationMgr) FailoverReplicationWithContext(ctx context.Context, replicaId string, body FailoverReplicationBuilder) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateReplicationURL(urls.Client, v.TenantId, replicaId, "failover")}, "/")
-	return v.Recv(url, "POST", body, nil)
+	return v.RecvWithContext(ctx, url, "POST", body, nil)
 }
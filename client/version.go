@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"strings"
 
 	"github.com/sodafoundation/api/pkg/model"
@@ -42,11 +43,16 @@ type VersionMgr struct {
 
 // GetVersion ...
 func (v *VersionMgr) GetVersion(apiVersion string) (*model.VersionSpec, error) {
+	return v.GetVersionWithContext(context.Background(), apiVersion)
+}
+
+// GetVersionWithContext implementation
+func (v *VersionMgr) GetVersionWithContext(ctx context.Context, apiVersion string) (*model.VersionSpec, error) {
 	var res model.VersionSpec
 	url := strings.Join([]string{
 		v.Endpoint, apiVersion}, "/")
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -55,10 +61,15 @@ func (v *VersionMgr) GetVersion(apiVersion string) (*model.VersionSpec, error) {
 
 // ListVersions ...
 func (v *VersionMgr) ListVersions() ([]*model.VersionSpec, error) {
+	return v.ListVersionsWithContext(context.Background())
+}
+
+// ListVersionsWithContext implementation
+func (v *VersionMgr) ListVersionsWithContext(ctx context.Context) ([]*model.VersionSpec, error) {
 	var res []*model.VersionSpec
 	url := v.Endpoint
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
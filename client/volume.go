@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"strings"
 
 	"github.com/sodafoundation/api/pkg/model"
@@ -64,12 +65,17 @@ type VolumeMgr struct {
 
 // CreateVolume
 func (v *VolumeMgr) CreateVolume(body VolumeBuilder) (*model.VolumeSpec, error) {
+	return v.CreateVolumeWithContext(context.Background(), body)
+}
+
+// CreateVolumeWithContext implementation
+func (v *VolumeMgr) CreateVolumeWithContext(ctx context.Context, body VolumeBuilder) (*model.VolumeSpec, error) {
 	var res model.VolumeSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateVolumeURL(urls.Client, v.TenantId)}, "/")
 
-	if err := v.Recv(url, "POST", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -78,12 +84,17 @@ func (v *VolumeMgr) CreateVolume(body VolumeBuilder) (*model.VolumeSpec, error)
 
 // GetVolume
 func (v *VolumeMgr) GetVolume(volID string) (*model.VolumeSpec, error) {
+	return v.GetVolumeWithContext(context.Background(), volID)
+}
+
+// GetVolumeWithContext implementation
+func (v *VolumeMgr) GetVolumeWithContext(ctx context.Context, volID string) (*model.VolumeSpec, error) {
 	var res model.VolumeSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateVolumeURL(urls.Client, v.TenantId, volID)}, "/")
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -92,6 +103,11 @@ func (v *VolumeMgr) GetVolume(volID string) (*model.VolumeSpec, error) {
 
 // ListVolumes
 func (v *VolumeMgr) ListVolumes(args ...interface{}) ([]*model.VolumeSpec, error) {
+	return v.ListVolumesWithContext(context.Background(), args...)
+}
+
+// ListVolumesWithContext implementation
+func (v *VolumeMgr) ListVolumesWithContext(ctx context.Context, args ...interface{}) ([]*model.VolumeSpec, error) {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateVolumeURL(urls.Client, v.TenantId)}, "/")
@@ -106,7 +122,7 @@ func (v *VolumeMgr) ListVolumes(args ...interface{}) ([]*model.VolumeSpec, error
 	}
 
 	var res []*model.VolumeSpec
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -114,21 +130,31 @@ func (v *VolumeMgr) ListVolumes(args ...interface{}) ([]*model.VolumeSpec, error
 
 // DeleteVolume
 func (v *VolumeMgr) DeleteVolume(volID string, body VolumeBuilder) error {
+	return v.DeleteVolumeWithContext(context.Background(), volID, body)
+}
+
+// DeleteVolumeWithContext implementation
+func (v *VolumeMgr) DeleteVolumeWithContext(ctx context.Context, volID string, body VolumeBuilder) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateVolumeURL(urls.Client, v.TenantId, volID)}, "/")
 
-	return v.Recv(url, "DELETE", body, nil)
+	return v.RecvWithContext(ctx, url, "DELETE", body, nil)
 }
 
 // UpdateVolume
 func (v *VolumeMgr) UpdateVolume(volID string, body VolumeBuilder) (*model.VolumeSpec, error) {
+	return v.UpdateVolumeWithContext(context.Background(), volID, body)
+}
+
+// UpdateVolumeWithContext implementation
+func (v *VolumeMgr) UpdateVolumeWithContext(ctx context.Context, volID string, body VolumeBuilder) (*model.VolumeSpec, error) {
 	var res model.VolumeSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateVolumeURL(urls.Client, v.TenantId, volID)}, "/")
 
-	if err := v.Recv(url, "PUT", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "PUT", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -137,12 +163,17 @@ func (v *VolumeMgr) UpdateVolume(volID string, body VolumeBuilder) (*model.Volum
 
 // ExtendVolume ...
 func (v *VolumeMgr) ExtendVolume(volID string, body ExtendVolumeBuilder) (*model.VolumeSpec, error) {
+	return v.ExtendVolumeWithContext(context.Background(), volID, body)
+}
+
+// ExtendVolumeWithContext implementation
+func (v *VolumeMgr) ExtendVolumeWithContext(ctx context.Context, volID string, body ExtendVolumeBuilder) (*model.VolumeSpec, error) {
 	var res model.VolumeSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateVolumeURL(urls.Client, v.TenantId, volID, "resize")}, "/")
 
-	if err := v.Recv(url, "POST", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -151,12 +182,17 @@ func (v *VolumeMgr) ExtendVolume(volID string, body ExtendVolumeBuilder) (*model
 
 // CreateVolumeAttachment
 func (v *VolumeMgr) CreateVolumeAttachment(body VolumeAttachmentBuilder) (*model.VolumeAttachmentSpec, error) {
+	return v.CreateVolumeAttachmentWithContext(context.Background(), body)
+}
+
+// CreateVolumeAttachmentWithContext implementation
+func (v *VolumeMgr) CreateVolumeAttachmentWithContext(ctx context.Context, body VolumeAttachmentBuilder) (*model.VolumeAttachmentSpec, error) {
 	var res model.VolumeAttachmentSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateAttachmentURL(urls.Client, v.TenantId)}, "/")
 
-	if err := v.Recv(url, "POST", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -165,12 +201,17 @@ func (v *VolumeMgr) CreateVolumeAttachment(body VolumeAttachmentBuilder) (*model
 
 // UpdateVolumeAttachment
 func (v *VolumeMgr) UpdateVolumeAttachment(atcID string, body VolumeAttachmentBuilder) (*model.VolumeAttachmentSpec, error) {
+	return v.UpdateVolumeAttachmentWithContext(context.Background(), atcID, body)
+}
+
+// UpdateVolumeAttachmentWithContext implementation
+func (v *VolumeMgr) UpdateVolumeAttachmentWithContext(ctx context.Context, atcID string, body VolumeAttachmentBuilder) (*model.VolumeAttachmentSpec, error) {
 	var res model.VolumeAttachmentSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateAttachmentURL(urls.Client, v.TenantId, atcID)}, "/")
 
-	if err := v.Recv(url, "PUT", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "PUT", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -179,12 +220,17 @@ func (v *VolumeMgr) UpdateVolumeAttachment(atcID string, body VolumeAttachmentBu
 
 // GetVolumeAttachment
 func (v *VolumeMgr) GetVolumeAttachment(atcID string) (*model.VolumeAttachmentSpec, error) {
+	return v.GetVolumeAttachmentWithContext(context.Background(), atcID)
+}
+
+// GetVolumeAttachmentWithContext implementation
+func (v *VolumeMgr) GetVolumeAttachmentWithContext(ctx context.Context, atcID string) (*model.VolumeAttachmentSpec, error) {
 	var res model.VolumeAttachmentSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateAttachmentURL(urls.Client, v.TenantId, atcID)}, "/")
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -193,6 +239,11 @@ func (v *VolumeMgr) GetVolumeAttachment(atcID string) (*model.VolumeAttachmentSp
 
 // ListVolumeAttachments
 func (v *VolumeMgr) ListVolumeAttachments(args ...interface{}) ([]*model.VolumeAttachmentSpec, error) {
+	return v.ListVolumeAttachmentsWithContext(context.Background(), args...)
+}
+
+// ListVolumeAttachmentsWithContext implementation
+func (v *VolumeMgr) ListVolumeAttachmentsWithContext(ctx context.Context, args ...interface{}) ([]*model.VolumeAttachmentSpec, error) {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateAttachmentURL(urls.Client, v.TenantId)}, "/")
@@ -206,7 +257,7 @@ func (v *VolumeMgr) ListVolumeAttachments(args ...interface{}) ([]*model.VolumeA
 		url += "?" + param
 	}
 	var res []*model.VolumeAttachmentSpec
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -214,21 +265,31 @@ func (v *VolumeMgr) ListVolumeAttachments(args ...interface{}) ([]*model.VolumeA
 
 // DeleteVolumeAttachment
 func (v *VolumeMgr) DeleteVolumeAttachment(atcID string, body VolumeAttachmentBuilder) error {
+	return v.DeleteVolumeAttachmentWithContext(context.Background(), atcID, body)
+}
+
+// DeleteVolumeAttachmentWithContext implementation
+func (v *VolumeMgr) DeleteVolumeAttachmentWithContext(ctx context.Context, atcID string, body VolumeAttachmentBuilder) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateAttachmentURL(urls.Client, v.TenantId, atcID)}, "/")
 
-	return v.Recv(url, "DELETE", body, nil)
+	return v.RecvWithContext(ctx, url, "DELETE", body, nil)
 }
 
 // CreateVolumeSnapshot
 func (v *VolumeMgr) CreateVolumeSnapshot(body VolumeSnapshotBuilder) (*model.VolumeSnapshotSpec, error) {
+	return v.CreateVolumeSnapshotWithContext(context.Background(), body)
+}
+
+// CreateVolumeSnapshotWithContext implementation
+func (v *VolumeMgr) CreateVolumeSnapshotWithContext(ctx context.Context, body VolumeSnapshotBuilder) (*model.VolumeSnapshotSpec, error) {
 	var res model.VolumeSnapshotSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateSnapshotURL(urls.Client, v.TenantId)}, "/")
 
-	if err := v.Recv(url, "POST", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -237,12 +298,17 @@ func (v *VolumeMgr) CreateVolumeSnapshot(body VolumeSnapshotBuilder) (*model.Vol
 
 // GetVolumeSnapshot
 func (v *VolumeMgr) GetVolumeSnapshot(snpID string) (*model.VolumeSnapshotSpec, error) {
+	return v.GetVolumeSnapshotWithContext(context.Background(), snpID)
+}
+
+// GetVolumeSnapshotWithContext implementation
+func (v *VolumeMgr) GetVolumeSnapshotWithContext(ctx context.Context, snpID string) (*model.VolumeSnapshotSpec, error) {
 	var res model.VolumeSnapshotSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateSnapshotURL(urls.Client, v.TenantId, snpID)}, "/")
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -251,6 +317,11 @@ func (v *VolumeMgr) GetVolumeSnapshot(snpID string) (*model.VolumeSnapshotSpec,
 
 // ListVolumeSnapshots
 func (v *VolumeMgr) ListVolumeSnapshots(args ...interface{}) ([]*model.VolumeSnapshotSpec, error) {
+	return v.ListVolumeSnapshotsWithContext(context.Background(), args...)
+}
+
+// ListVolumeSnapshotsWithContext implementation
+func (v *VolumeMgr) ListVolumeSnapshotsWithContext(ctx context.Context, args ...interface{}) ([]*model.VolumeSnapshotSpec, error) {
 	var res []*model.VolumeSnapshotSpec
 
 	url := strings.Join([]string{
@@ -266,7 +337,7 @@ func (v *VolumeMgr) ListVolumeSnapshots(args ...interface{}) ([]*model.VolumeSna
 		url += "?" + param
 	}
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -275,21 +346,31 @@ func (v *VolumeMgr) ListVolumeSnapshots(args ...interface{}) ([]*model.VolumeSna
 
 // DeleteVolumeSnapshot
 func (v *VolumeMgr) DeleteVolumeSnapshot(snpID string, body VolumeSnapshotBuilder) error {
+	return v.DeleteVolumeSnapshotWithContext(context.Background(), snpID, body)
+}
+
+// DeleteVolumeSnapshotWithContext implementation
+func (v *VolumeMgr) DeleteVolumeSnapshotWithContext(ctx context.Context, snpID string, body VolumeSnapshotBuilder) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateSnapshotURL(urls.Client, v.TenantId, snpID)}, "/")
 
-	return v.Recv(url, "DELETE", body, nil)
+	return v.RecvWithContext(ctx, url, "DELETE", body, nil)
 }
 
 // UpdateVolumeSnapshot
 func (v *VolumeMgr) UpdateVolumeSnapshot(snpID string, body VolumeSnapshotBuilder) (*model.VolumeSnapshotSpec, error) {
+	return v.UpdateVolumeSnapshotWithContext(context.Background(), snpID, body)
+}
+
+// UpdateVolumeSnapshotWithContext implementation
+func (v *VolumeMgr) UpdateVolumeSnapshotWithContext(ctx context.Context, snpID string, body VolumeSnapshotBuilder) (*model.VolumeSnapshotSpec, error) {
 	var res model.VolumeSnapshotSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateSnapshotURL(urls.Client, v.TenantId, snpID)}, "/")
 
-	if err := v.Recv(url, "PUT", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "PUT", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -298,12 +379,17 @@ func (v *VolumeMgr) UpdateVolumeSnapshot(snpID string, body VolumeSnapshotBuilde
 
 // CreateVolumeGroup
 func (v *VolumeMgr) CreateVolumeGroup(body VolumeGroupBuilder) (*model.VolumeGroupSpec, error) {
+	return v.CreateVolumeGroupWithContext(context.Background(), body)
+}
+
+// CreateVolumeGroupWithContext implementation
+func (v *VolumeMgr) CreateVolumeGroupWithContext(ctx context.Context, body VolumeGroupBuilder) (*model.VolumeGroupSpec, error) {
 	var res model.VolumeGroupSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateVolumeGroupURL(urls.Client, v.TenantId)}, "/")
 
-	if err := v.Recv(url, "POST", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "POST", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -312,12 +398,17 @@ func (v *VolumeMgr) CreateVolumeGroup(body VolumeGroupBuilder) (*model.VolumeGro
 
 // GetVolumeGroup
 func (v *VolumeMgr) GetVolumeGroup(vgId string) (*model.VolumeGroupSpec, error) {
+	return v.GetVolumeGroupWithContext(context.Background(), vgId)
+}
+
+// GetVolumeGroupWithContext implementation
+func (v *VolumeMgr) GetVolumeGroupWithContext(ctx context.Context, vgId string) (*model.VolumeGroupSpec, error) {
 	var res model.VolumeGroupSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateVolumeGroupURL(urls.Client, v.TenantId, vgId)}, "/")
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -326,6 +417,11 @@ func (v *VolumeMgr) GetVolumeGroup(vgId string) (*model.VolumeGroupSpec, error)
 
 // ListVolumeGroups
 func (v *VolumeMgr) ListVolumeGroups(args ...interface{}) ([]*model.VolumeGroupSpec, error) {
+	return v.ListVolumeGroupsWithContext(context.Background(), args...)
+}
+
+// ListVolumeGroupsWithContext implementation
+func (v *VolumeMgr) ListVolumeGroupsWithContext(ctx context.Context, args ...interface{}) ([]*model.VolumeGroupSpec, error) {
 	var res []*model.VolumeGroupSpec
 	url := strings.Join([]string{
 		v.Endpoint,
@@ -340,7 +436,7 @@ func (v *VolumeMgr) ListVolumeGroups(args ...interface{}) ([]*model.VolumeGroupS
 		url += "?" + param
 	}
 
-	if err := v.Recv(url, "GET", nil, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "GET", nil, &res); err != nil {
 		return nil, err
 	}
 
@@ -349,21 +445,31 @@ func (v *VolumeMgr) ListVolumeGroups(args ...interface{}) ([]*model.VolumeGroupS
 
 // DeleteVolumeGroup
 func (v *VolumeMgr) DeleteVolumeGroup(vgId string, body VolumeGroupBuilder) error {
+	return v.DeleteVolumeGroupWithContext(context.Background(), vgId, body)
+}
+
+// DeleteVolumeGroupWithContext implementation
+func (v *VolumeMgr) DeleteVolumeGroupWithContext(ctx context.Context, vgId string, body VolumeGroupBuilder) error {
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateVolumeGroupURL(urls.Client, v.TenantId, vgId)}, "/")
 
-	return v.Recv(url, "DELETE", body, nil)
+	return v.RecvWithContext(ctx, url, "DELETE", body, nil)
 }
 
 // UpdateVolumeSnapshot
 func (v *VolumeMgr) UpdateVolumeGroup(vgId string, body VolumeGroupBuilder) (*model.VolumeGroupSpec, error) {
+	return v.UpdateVolumeGroupWithContext(context.Background(), vgId, body)
+}
+
+// UpdateVolumeGroupWithContext implementation
+func (v *VolumeMgr) UpdateVolumeGroupWithContext(ctx context.Context, vgId string, body VolumeGroupBuilder) (*model.VolumeGroupSpec, error) {
 	var res model.VolumeGroupSpec
 	url := strings.Join([]string{
 		v.Endpoint,
 		urls.GenerateVolumeGroupURL(urls.Client, v.TenantId, vgId)}, "/")
 
-	if err := v.Recv(url, "PUT", body, &res); err != nil {
+	if err := v.RecvWithContext(ctx, url, "PUT", body, &res); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,74 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the api conformance command. It runs the checks of
+testutils/conformance against a live endpoint and exits non-zero when the
+endpoint diverges, so vendors can wire it into their release gates:
+
+	conformance -endpoint http://localhost:50040 -tenant <uuid>
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sodafoundation/api/client"
+	"github.com/sodafoundation/api/testutils/conformance"
+)
+
+func main() {
+	var (
+		endpoint = flag.String("endpoint", "", "The api endpoint under test, for example http://localhost:50040")
+		tenant   = flag.String("tenant", "", "The tenant the suite provisions its resources in")
+		timeout  = flag.Duration("timeout", 2*time.Minute, "How long to wait for a resource state transition")
+		interval = flag.Duration("poll-interval", time.Second, "How often to poll a resource state transition")
+	)
+	flag.Parse()
+
+	cli, err := client.NewClient(&client.Config{
+		Endpoint:    *endpoint,
+		AuthOptions: client.NewNoauthOptions(*tenant),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "connect to %s failed: %v\n", *endpoint, err)
+		os.Exit(2)
+	}
+
+	suite := conformance.NewSuite(cli)
+	suite.Timeout = *timeout
+	suite.PollInterval = *interval
+
+	results := suite.Run()
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			fmt.Printf("SKIP %-22s %s\n", result.Name, result.SkipReason)
+		case result.Err != nil:
+			fmt.Printf("FAIL %-22s %v\n", result.Name, result.Err)
+		default:
+			fmt.Printf("PASS %-22s (%s)\n", result.Name, result.Duration.Round(time.Millisecond))
+		}
+	}
+
+	if failed := conformance.Failures(results); len(failed) > 0 {
+		fmt.Printf("%d of %d checks diverged\n", len(failed), len(results))
+		os.Exit(1)
+	}
+	fmt.Printf("all %d checks conform\n", len(results))
+}
@@ -21,14 +21,21 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"os"
 
 	"github.com/sodafoundation/api/pkg/api"
+	"github.com/sodafoundation/api/pkg/api/selftest"
 	"github.com/sodafoundation/api/pkg/db"
 	. "github.com/sodafoundation/api/pkg/utils/config"
 	"github.com/sodafoundation/api/pkg/utils/daemon"
 	"github.com/sodafoundation/api/pkg/utils/logs"
 )
 
+// selftestMode is set by the -selftest flag and replaces the normal serve
+// loop with the scripted smoke scenario.
+var selftestMode bool
+
 func init() {
 	// Load global configuration from specified config file.
 	CONF.Load()
@@ -52,8 +59,31 @@ func init() {
 	flag.StringVar(&CONF.OsdsApiServer.GrafanaUrl, "grafana-url", CONF.OsdsApiServer.GrafanaUrl, "Grafana listen endpoint")
 	// prometheus and alert manager configuration reload url
 	flag.StringVar(&CONF.OsdsApiServer.ConfReloadUrl, "conf-reload-url", CONF.OsdsApiServer.ConfReloadUrl, "Prometheus and Alert manager conf. reload URL")
+	// developer mode related
+	var noauth bool
+	flag.BoolVar(&noauth, "noauth", false, "Disable authentication with -noauth=true, shorthand for -auth-strategy=noauth")
+	flag.StringVar(&CONF.OsdsApiServer.AuthStrategy, "auth-strategy", CONF.OsdsApiServer.AuthStrategy, "Authentication strategy of api-server service, one of keystone and noauth")
+	flag.BoolVar(&CONF.OsdsApiServer.FakeController, "fake-controller", CONF.OsdsApiServer.FakeController, "Replace the controller client with an in-process simulator, for development only")
+	flag.DurationVar(&CONF.OsdsApiServer.FakeControllerLatency, "fake-controller-latency", CONF.OsdsApiServer.FakeControllerLatency, "Latency the fake controller adds to every operation")
+	flag.IntVar(&CONF.OsdsApiServer.FakeControllerFailureRate, "fake-controller-failure-rate", CONF.OsdsApiServer.FakeControllerFailureRate, "Percentage of operations the fake controller fails on purpose")
+	flag.BoolVar(&CONF.OsdsApiServer.RecordTraffic, "record-traffic", CONF.OsdsApiServer.RecordTraffic, "Record anonymized request/response pairs for replay testing")
+	flag.StringVar(&CONF.OsdsApiServer.RecordTrafficDir, "record-traffic-dir", CONF.OsdsApiServer.RecordTrafficDir, "Directory the traffic recordings are written to")
+	flag.BoolVar(&selftestMode, "selftest", false, "Boot against the in-memory db, run a scripted CRUD smoke scenario and exit with its verdict")
 	flag.Parse()
 
+	if noauth {
+		CONF.OsdsApiServer.AuthStrategy = "noauth"
+	}
+	if selftestMode {
+		// The self test needs a server that stands alone, so it swaps in
+		// the in-memory db and the in-process fake controller and drops
+		// authentication and https regardless of what the config file says.
+		CONF.Database.Driver = "fake"
+		CONF.OsdsApiServer.AuthStrategy = "noauth"
+		CONF.OsdsApiServer.FakeController = true
+		CONF.OsdsApiServer.HTTPSEnabled = false
+	}
+
 	daemon.CheckAndRunDaemon(CONF.OsdsApiServer.Daemon)
 }
 
@@ -65,6 +95,19 @@ func main() {
 	// Set up database session.
 	db.Init(&CONF.Database)
 
+	// In self test mode boot the server in the background, run the
+	// scripted scenario against it and exit with its verdict.
+	if selftestMode {
+		go api.Run(CONF.OsdsApiServer)
+		err := selftest.Run(CONF.OsdsApiServer.ApiEndpoint)
+		logs.FlushLogs()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Start OpenSDS northbound REST service.
 	api.Run(CONF.OsdsApiServer)
 }
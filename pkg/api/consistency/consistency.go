@@ -0,0 +1,259 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the consistency checker which periodically compares
+database records against the backend inventory and records discrepancies.
+A volume or fileshare in a steady status whose pool has disappeared or gone
+stale is recorded as a missing backend resource. For every healthy pool the
+checker additionally asks the controller for the resources the backend
+actually hosts, and records backend resources without a database record as
+unknown backend resources. The latest report is kept in memory and served
+through the admin consistency-report endpoint.
+*/
+
+package consistency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	client "github.com/sodafoundation/api/pkg/api/controllerclient"
+	"github.com/sodafoundation/api/pkg/api/util"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	pb "github.com/sodafoundation/api/pkg/model/proto"
+	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+var (
+	latestLock   sync.RWMutex
+	latestReport *model.ConsistencyReportSpec
+)
+
+// Latest returns the report of the most recent completed run, or nil when
+// the checker has not completed one yet.
+func Latest() *model.ConsistencyReportSpec {
+	latestLock.RLock()
+	defer latestLock.RUnlock()
+	return latestReport
+}
+
+func setLatest(report *model.ConsistencyReportSpec) {
+	latestLock.Lock()
+	defer latestLock.Unlock()
+	latestReport = report
+}
+
+// Checker periodically compares database records against the dock reported
+// inventory and records the discrepancies it finds.
+type Checker struct {
+	interval  time.Duration
+	CtrClient client.Client
+}
+
+func NewChecker(interval time.Duration) *Checker {
+	return &Checker{
+		interval:  interval,
+		CtrClient: client.NewClient(),
+	}
+}
+
+// Run starts the check loop. It blocks, so callers usually run it in its
+// own goroutine.
+func (ck *Checker) Run() {
+	for {
+		time.Sleep(ck.interval)
+		ck.CheckOnce()
+	}
+}
+
+// steadyVolumeStatuses are the volume statuses in which the backend
+// resource must exist. Transitional and error statuses are skipped, the
+// watchdog and the user handle those.
+var steadyVolumeStatuses = []string{
+	model.VolumeAvailable, model.VolumeInUse, model.VolumeReserved,
+}
+
+// steadyFileShareStatuses are the fileshare statuses in which the backend
+// resource must exist.
+var steadyFileShareStatuses = []string{
+	model.FileShareAvailable, model.FileShareInUse,
+}
+
+// checkPool classifies the pool a resource claims to live on. It returns
+// an empty detail when the pool looks healthy.
+func checkPool(pools map[string]*model.StoragePoolSpec, poolId string) string {
+	pool, ok := pools[poolId]
+	if !ok {
+		return fmt.Sprintf("pool %s is no longer reported by any dock", poolId)
+	}
+	if util.PoolIsStale(pool) {
+		return fmt.Sprintf("pool %s has not been refreshed by its dock for longer than the staleness threshold", poolId)
+	}
+	return ""
+}
+
+// CheckOnce runs one consistency check, records the resulting report as
+// the latest one and returns it.
+func (ck *Checker) CheckOnce() *model.ConsistencyReportSpec {
+	ctx := c.NewAdminContext()
+	report := &model.ConsistencyReportSpec{
+		Discrepancies: []*model.ConsistencyDiscrepancySpec{},
+	}
+
+	poolList, err := db.C.ListPools(ctx)
+	if err != nil {
+		log.Error("consistency checker failed to list pools:", err)
+		return nil
+	}
+	pools := make(map[string]*model.StoragePoolSpec, len(poolList))
+	for _, pool := range poolList {
+		pools[pool.Id] = pool
+	}
+
+	volumes, err := db.C.ListVolumes(ctx)
+	if err != nil {
+		log.Error("consistency checker failed to list volumes:", err)
+	}
+	for _, volume := range volumes {
+		if !utils.Contained(volume.Status, steadyVolumeStatuses) {
+			continue
+		}
+		report.CheckedResources++
+		if detail := checkPool(pools, volume.PoolId); detail != "" {
+			log.Warningf("event: volume %s of tenant %s has no reachable backend: %s",
+				volume.Id, volume.TenantId, detail)
+			report.Discrepancies = append(report.Discrepancies, &model.ConsistencyDiscrepancySpec{
+				ResourceType: "volume",
+				ResourceId:   volume.Id,
+				TenantId:     volume.TenantId,
+				PoolId:       volume.PoolId,
+				Kind:         model.ConsistencyMissingBackendResource,
+				Detail:       detail,
+			})
+		}
+	}
+
+	shares, err := db.C.ListFileShares(ctx)
+	if err != nil {
+		log.Error("consistency checker failed to list fileshares:", err)
+	}
+	for _, share := range shares {
+		if !utils.Contained(share.Status, steadyFileShareStatuses) {
+			continue
+		}
+		report.CheckedResources++
+		if detail := checkPool(pools, share.PoolId); detail != "" {
+			log.Warningf("event: fileshare %s of tenant %s has no reachable backend: %s",
+				share.Id, share.TenantId, detail)
+			report.Discrepancies = append(report.Discrepancies, &model.ConsistencyDiscrepancySpec{
+				ResourceType: "fileshare",
+				ResourceId:   share.Id,
+				TenantId:     share.TenantId,
+				PoolId:       share.PoolId,
+				Kind:         model.ConsistencyMissingBackendResource,
+				Detail:       detail,
+			})
+		}
+	}
+
+	// Compare the backend inventory of every healthy pool against the
+	// database, finding backend resources no record points at.
+	if err := ck.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("consistency checker failed to connect the controller:", err)
+	} else {
+		knownVolumes := make(map[string]struct{}, len(volumes))
+		for _, volume := range volumes {
+			knownVolumes[volume.Id] = struct{}{}
+		}
+		knownShares := make(map[string]struct{}, len(shares))
+		for _, share := range shares {
+			knownShares[share.Id] = struct{}{}
+		}
+		for _, pool := range poolList {
+			if checkPool(pools, pool.Id) != "" {
+				continue
+			}
+			ck.checkBackendInventory(ctx, pool, knownVolumes, knownShares, report)
+		}
+	}
+
+	report.CompletedAt = utils.FormatTime(time.Now())
+	setLatest(report)
+	return report
+}
+
+// checkBackendInventory asks the controller for the resources one pool
+// actually hosts and records every backend resource without a database
+// record. A pool whose inventory can not be collected is logged and
+// skipped, so the rest of the report still gets built.
+func (ck *Checker) checkBackendInventory(ctx *c.Context, pool *model.StoragePoolSpec,
+	knownVolumes, knownShares map[string]struct{}, report *model.ConsistencyReportSpec) {
+	opt := &pb.ListBackendResourcesOpts{
+		PoolId:  pool.Id,
+		DockId:  pool.DockId,
+		Context: ctx.ToJson(),
+	}
+	res, err := ck.CtrClient.ListBackendResources(context.Background(), opt)
+	if err != nil {
+		log.Errorf("consistency checker failed to list backend resources of pool %s: %v", pool.Id, err)
+		return
+	}
+	if errorMsg := res.GetError(); errorMsg != nil {
+		log.Errorf("consistency checker failed to list backend resources of pool %s, code: %v, message: %v",
+			pool.Id, errorMsg.GetCode(), errorMsg.GetDescription())
+		return
+	}
+	var inventory = model.BackendInventorySpec{}
+	if err := json.Unmarshal([]byte(res.GetResult().GetMessage()), &inventory); err != nil {
+		log.Errorf("consistency checker failed to unmarshal the inventory of pool %s: %v", pool.Id, err)
+		return
+	}
+
+	for _, id := range inventory.VolumeIds {
+		if _, ok := knownVolumes[id]; ok {
+			continue
+		}
+		detail := fmt.Sprintf("the backend of pool %s hosts volume %s which has no database record", pool.Id, id)
+		log.Warningf("event: %s", detail)
+		report.Discrepancies = append(report.Discrepancies, &model.ConsistencyDiscrepancySpec{
+			ResourceType: "volume",
+			ResourceId:   id,
+			PoolId:       pool.Id,
+			Kind:         model.ConsistencyUnknownBackendResource,
+			Detail:       detail,
+		})
+	}
+	for _, id := range inventory.FileShareIds {
+		if _, ok := knownShares[id]; ok {
+			continue
+		}
+		detail := fmt.Sprintf("the backend of pool %s hosts fileshare %s which has no database record", pool.Id, id)
+		log.Warningf("event: %s", detail)
+		report.Discrepancies = append(report.Discrepancies, &model.ConsistencyDiscrepancySpec{
+			ResourceType: "fileshare",
+			ResourceId:   id,
+			PoolId:       pool.Id,
+			Kind:         model.ConsistencyUnknownBackendResource,
+			Detail:       detail,
+		})
+	}
+}
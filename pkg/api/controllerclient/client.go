@@ -15,14 +15,21 @@
 package client
 
 import (
+	gocontext "context"
+	"fmt"
 	"time"
 
 	log "github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	c "github.com/sodafoundation/api/pkg/context"
 	pb "github.com/sodafoundation/api/pkg/model/proto"
+	"github.com/sodafoundation/api/pkg/utils/config"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 )
 
 // Client interface provides an abstract description about how to interact
@@ -45,7 +52,31 @@ type client struct {
 	*grpc.ClientConn
 }
 
-func NewClient() Client { return &client{} }
+// NewClient returns the gRPC backed client, or the in-process fake
+// controller when fake_controller is enabled. The choice is deferred to
+// the first Connect call, because the routers construct their portals
+// while the package initializers run, before the configuration and the
+// command line flags have been loaded.
+func NewClient() Client {
+	return &lazyClient{}
+}
+
+// lazyClient picks the real or the fake delegate once Connect is called
+// with the configuration in place.
+type lazyClient struct {
+	Client
+}
+
+func (l *lazyClient) Connect(edp string) error {
+	if l.Client == nil {
+		if config.CONF.OsdsApiServer.FakeController {
+			l.Client = NewFakeClient()
+		} else {
+			l.Client = &client{}
+		}
+	}
+	return l.Client.Connect(edp)
+}
 
 func (c *client) Connect(edp string) error {
 	// Set up a connection to the Dock server.
@@ -57,7 +88,17 @@ func (c *client) Connect(edp string) error {
 		Timeout:             time.Second,      // wait 1 second for ping ack before considering the connection dead
 		PermitWithoutStream: true,             // send pings even without active streams
 	}
-	conn, err := grpc.Dial(edp, grpc.WithInsecure(), grpc.WithKeepaliveParams(kacp))
+	opts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithKeepaliveParams(kacp),
+		grpc.WithUnaryInterceptor(attachAuthMetadata),
+	}
+	if config.CONF.OsdsApiServer.CtrGrpcCompression {
+		// Large profiles and metadata maps compress well, so optionally gzip
+		// every call on this channel.
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	}
+	conn, err := grpc.Dial(edp, opts...)
 	if err != nil {
 		log.Errorf("did not connect: %+v\n", err)
 		return err
@@ -69,3 +110,42 @@ func (c *client) Connect(edp string) error {
 
 	return nil
 }
+
+// optsCarrier is satisfied by every generated opts message through the
+// getter of its Context field.
+type optsCarrier interface {
+	GetContext() string
+}
+
+// attachAuthMetadata mirrors the auth context serialized inside the opts
+// into standard gRPC metadata following the schema in pkg/context, so the
+// controller side can act on tenancy, user and roles in interceptors
+// without deserializing the opts. The JSON field keeps carrying the full
+// context during the deprecation window.
+func attachAuthMetadata(ctx gocontext.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if carrier, ok := req.(optsCarrier); ok && carrier.GetContext() != "" {
+		md := c.NewContextFromJson(carrier.GetContext()).ToGrpcMetadata()
+		if existing, ok := metadata.FromOutgoingContext(ctx); ok {
+			md = metadata.Join(existing, md)
+		}
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// CheckOptsSize returns an error when the serialized opts exceed the
+// configured controller_max_opts_size. The check runs before the resource is
+// committed, so a request carrying an oversized profile or metadata map is
+// rejected with a clear message instead of failing deep inside the grpc
+// stack. A zero or negative limit disables the check.
+func CheckOptsSize(opt proto.Message) error {
+	limit := config.CONF.OsdsApiServer.CtrMaxOptsSize
+	if limit <= 0 {
+		return nil
+	}
+	if size := proto.Size(opt); size > limit {
+		return fmt.Errorf("serialized request options are %d bytes, which exceeds the %d byte limit", size, limit)
+	}
+	return nil
+}
@@ -0,0 +1,416 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	log "github.com/golang/glog"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	pb "github.com/sodafoundation/api/pkg/model/proto"
+	"github.com/sodafoundation/api/pkg/utils"
+	"github.com/sodafoundation/api/pkg/utils/config"
+
+	"google.golang.org/grpc"
+)
+
+// fakeClient simulates the controller and dock in process. It is enabled
+// with the fake_controller option (or the -fake-controller flag) and lets
+// UI and SDK developers exercise the whole REST api without running etcd,
+// controller and dock. Instead of dispatching requests over gRPC it sleeps
+// for the configured latency and then drives the resource to the state the
+// real dock would eventually report, optionally injecting failures at the
+// configured rate.
+type fakeClient struct {
+	latency     time.Duration
+	failureRate int
+}
+
+// NewFakeClient returns a Client that simulates the controller in process,
+// configured from the fake_controller_* options.
+func NewFakeClient() Client {
+	return &fakeClient{
+		latency:     config.CONF.OsdsApiServer.FakeControllerLatency,
+		failureRate: config.CONF.OsdsApiServer.FakeControllerFailureRate,
+	}
+}
+
+func (f *fakeClient) Connect(edp string) error { return nil }
+
+// simulate sleeps for the configured latency, rolls the failure dice and
+// runs the matching db transition, mirroring what the real dock would
+// eventually persist for the operation.
+func (f *fakeClient) simulate(op, ctxJson string, success, failure func(*c.Context) error) (*pb.GenericResponse, error) {
+	time.Sleep(f.latency)
+	ctx := c.NewContextFromJson(ctxJson)
+	if f.failureRate > 0 && rand.Intn(100) < f.failureRate {
+		if failure != nil {
+			if err := failure(ctx); err != nil {
+				log.Errorf("fake controller: %s failure transition: %v", op, err)
+			}
+		}
+		return pb.GenericResponseError(fmt.Sprintf("fake controller injected failure for %s", op)), nil
+	}
+	if err := success(ctx); err != nil {
+		return pb.GenericResponseError(err), nil
+	}
+	return pb.GenericResponseResult(nil), nil
+}
+
+func (f *fakeClient) CreateVolume(ctx context.Context, in *pb.CreateVolumeOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("CreateVolume", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeStatus(ctx, db.C, in.Id, model.VolumeAvailable)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeFault(ctx, db.C, in.Id, model.VolumeError,
+				"fake controller injected failure for CreateVolume")
+		})
+}
+
+func (f *fakeClient) DeleteVolume(ctx context.Context, in *pb.DeleteVolumeOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("DeleteVolume", in.Context,
+		func(ctx *c.Context) error {
+			return db.C.DeleteVolume(ctx, in.Id)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeFault(ctx, db.C, in.Id, model.VolumeErrorDeleting,
+				"fake controller injected failure for DeleteVolume")
+		})
+}
+
+func (f *fakeClient) ExtendVolume(ctx context.Context, in *pb.ExtendVolumeOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("ExtendVolume", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeStatus(ctx, db.C, in.Id, model.VolumeAvailable)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeFault(ctx, db.C, in.Id, model.VolumeErrorExtending,
+				"fake controller injected failure for ExtendVolume")
+		})
+}
+
+func (f *fakeClient) CreateVolumeSnapshot(ctx context.Context, in *pb.CreateVolumeSnapshotOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("CreateVolumeSnapshot", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeSnapshotStatus(ctx, db.C, in.Id, model.VolumeSnapAvailable)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeSnapshotFault(ctx, db.C, in.Id, model.VolumeSnapError,
+				"fake controller injected failure for CreateVolumeSnapshot")
+		})
+}
+
+func (f *fakeClient) DeleteVolumeSnapshot(ctx context.Context, in *pb.DeleteVolumeSnapshotOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("DeleteVolumeSnapshot", in.Context,
+		func(ctx *c.Context) error {
+			return db.C.DeleteVolumeSnapshot(ctx, in.Id)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeSnapshotFault(ctx, db.C, in.Id, model.VolumeSnapErrorDeleting,
+				"fake controller injected failure for DeleteVolumeSnapshot")
+		})
+}
+
+func (f *fakeClient) CreateVolumeAttachment(ctx context.Context, in *pb.CreateVolumeAttachmentOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("CreateVolumeAttachment", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeAttachmentStatus(ctx, db.C, in.Id, model.VolumeAttachAvailable)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeAttachmentFault(ctx, db.C, in.Id, model.VolumeAttachError,
+				"fake controller injected failure for CreateVolumeAttachment")
+		})
+}
+
+func (f *fakeClient) DeleteVolumeAttachment(ctx context.Context, in *pb.DeleteVolumeAttachmentOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("DeleteVolumeAttachment", in.Context,
+		func(ctx *c.Context) error {
+			return db.C.DeleteVolumeAttachment(ctx, in.Id)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeAttachmentFault(ctx, db.C, in.Id, model.VolumeAttachErrorDeleting,
+				"fake controller injected failure for DeleteVolumeAttachment")
+		})
+}
+
+func (f *fakeClient) CreateReplication(ctx context.Context, in *pb.CreateReplicationOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("CreateReplication", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateReplicationStatus(ctx, db.C, in.Id, model.ReplicationAvailable)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateReplicationFault(ctx, db.C, in.Id, model.ReplicationError,
+				"fake controller injected failure for CreateReplication")
+		})
+}
+
+func (f *fakeClient) DeleteReplication(ctx context.Context, in *pb.DeleteReplicationOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("DeleteReplication", in.Context,
+		func(ctx *c.Context) error {
+			return db.C.DeleteReplication(ctx, in.Id)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateReplicationFault(ctx, db.C, in.Id, model.ReplicationErrorDeleting,
+				"fake controller injected failure for DeleteReplication")
+		})
+}
+
+func (f *fakeClient) EnableReplication(ctx context.Context, in *pb.EnableReplicationOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("EnableReplication", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateReplicationStatus(ctx, db.C, in.Id, model.ReplicationEnabled)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateReplicationFault(ctx, db.C, in.Id, model.ReplicationErrorEnabling,
+				"fake controller injected failure for EnableReplication")
+		})
+}
+
+func (f *fakeClient) DisableReplication(ctx context.Context, in *pb.DisableReplicationOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("DisableReplication", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateReplicationStatus(ctx, db.C, in.Id, model.ReplicationDisabled)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateReplicationFault(ctx, db.C, in.Id, model.ReplicationErrorDisabling,
+				"fake controller injected failure for DisableReplication")
+		})
+}
+
+func (f *fakeClient) FailoverReplication(ctx context.Context, in *pb.FailoverReplicationOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("FailoverReplication", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateReplicationStatus(ctx, db.C, in.Id, model.ReplicationFailover)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateReplicationFault(ctx, db.C, in.Id, model.ReplicationErrorFailover,
+				"fake controller injected failure for FailoverReplication")
+		})
+}
+
+func (f *fakeClient) GetReplicationStatus(ctx context.Context, in *pb.GetReplicationStatusOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	time.Sleep(f.latency)
+	if f.failureRate > 0 && rand.Intn(100) < f.failureRate {
+		return pb.GenericResponseError("fake controller injected failure for GetReplicationStatus"), nil
+	}
+	return pb.GenericResponseResult(&model.ReplicationStatusSpec{
+		ReplicationId: in.Id,
+		LastSyncTime:  utils.FormatTime(time.Now()),
+	}), nil
+}
+
+func (f *fakeClient) CreateVolumeGroup(ctx context.Context, in *pb.CreateVolumeGroupOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("CreateVolumeGroup", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeGroupStatus(ctx, db.C, in.Id, model.VolumeGroupAvailable)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeGroupFault(ctx, db.C, in.Id, model.VolumeGroupError,
+				"fake controller injected failure for CreateVolumeGroup")
+		})
+}
+
+func (f *fakeClient) UpdateVolumeGroup(ctx context.Context, in *pb.UpdateVolumeGroupOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("UpdateVolumeGroup", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeGroupStatus(ctx, db.C, in.Id, model.VolumeGroupAvailable)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeGroupFault(ctx, db.C, in.Id, model.VolumeGroupError,
+				"fake controller injected failure for UpdateVolumeGroup")
+		})
+}
+
+func (f *fakeClient) DeleteVolumeGroup(ctx context.Context, in *pb.DeleteVolumeGroupOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("DeleteVolumeGroup", in.Context,
+		func(ctx *c.Context) error {
+			return db.C.DeleteVolumeGroup(ctx, in.Id)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateVolumeGroupFault(ctx, db.C, in.Id, model.VolumeGroupErrorDeleting,
+				"fake controller injected failure for DeleteVolumeGroup")
+		})
+}
+
+func (f *fakeClient) GetMetrics(ctx context.Context, in *pb.GetMetricsOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	time.Sleep(f.latency)
+	return pb.GenericResponseResult("[]"), nil
+}
+
+func (f *fakeClient) CollectMetrics(ctx context.Context, in *pb.CollectMetricsOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	time.Sleep(f.latency)
+	return pb.GenericResponseResult("[]"), nil
+}
+
+func (f *fakeClient) GetUrls(ctx context.Context, in *pb.NoParams, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	time.Sleep(f.latency)
+	return pb.GenericResponseResult("[]"), nil
+}
+
+func (f *fakeClient) ListBackendResources(ctx context.Context, in *pb.ListBackendResourcesOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	time.Sleep(f.latency)
+	if f.failureRate > 0 && rand.Intn(100) < f.failureRate {
+		return pb.GenericResponseError("fake controller injected failure for ListBackendResources"), nil
+	}
+	// The simulated backend hosts exactly what the database says it does,
+	// so the consistency checker finds a clean inventory.
+	reqCtx := c.NewContextFromJson(in.Context)
+	inventory := &model.BackendInventorySpec{PoolId: in.PoolId}
+	volumes, err := db.C.ListVolumes(reqCtx)
+	if err != nil {
+		return pb.GenericResponseError(err), nil
+	}
+	for _, volume := range volumes {
+		if volume.PoolId == in.PoolId {
+			inventory.VolumeIds = append(inventory.VolumeIds, volume.Id)
+		}
+	}
+	shares, err := db.C.ListFileShares(reqCtx)
+	if err != nil {
+		return pb.GenericResponseError(err), nil
+	}
+	for _, share := range shares {
+		if share.PoolId == in.PoolId {
+			inventory.FileShareIds = append(inventory.FileShareIds, share.Id)
+		}
+	}
+	return pb.GenericResponseResult(inventory), nil
+}
+
+func (f *fakeClient) CreateFileShare(ctx context.Context, in *pb.CreateFileShareOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("CreateFileShare", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateFileShareStatus(ctx, db.C, in.Id, model.FileShareAvailable)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateFileShareFault(ctx, db.C, in.Id, model.FileShareError,
+				"fake controller injected failure for CreateFileShare")
+		})
+}
+
+func (f *fakeClient) DeleteFileShare(ctx context.Context, in *pb.DeleteFileShareOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("DeleteFileShare", in.Context,
+		func(ctx *c.Context) error {
+			return db.C.DeleteFileShare(ctx, in.Id)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateFileShareFault(ctx, db.C, in.Id, model.FileShareErrorDeleting,
+				"fake controller injected failure for DeleteFileShare")
+		})
+}
+
+func (f *fakeClient) CreateFileShareSnapshot(ctx context.Context, in *pb.CreateFileShareSnapshotOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("CreateFileShareSnapshot", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateFileShareSnapshotStatus(ctx, db.C, in.Id, model.FileShareSnapAvailable)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateFileShareSnapshotFault(ctx, db.C, in.Id, model.FileShareSnapError,
+				"fake controller injected failure for CreateFileShareSnapshot")
+		})
+}
+
+func (f *fakeClient) DeleteFileShareSnapshot(ctx context.Context, in *pb.DeleteFileShareSnapshotOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("DeleteFileShareSnapshot", in.Context,
+		func(ctx *c.Context) error {
+			return db.C.DeleteFileShareSnapshot(ctx, in.Id)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateFileShareSnapshotFault(ctx, db.C, in.Id, model.FileShareSnapErrorDeleting,
+				"fake controller injected failure for DeleteFileShareSnapshot")
+		})
+}
+
+func (f *fakeClient) CopyFileShareSnapshot(ctx context.Context, in *pb.CopyFileShareSnapshotOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("CopyFileShareSnapshot", in.Context,
+		func(ctx *c.Context) error {
+			_, err := db.C.UpdateFileShareSnapshotCopy(ctx, in.TrackingId,
+				&model.FileShareSnapshotCopySpec{Status: model.FileShareSnapCopyAvailable})
+			return err
+		},
+		func(ctx *c.Context) error {
+			_, err := db.C.UpdateFileShareSnapshotCopy(ctx, in.TrackingId,
+				&model.FileShareSnapshotCopySpec{
+					Status: model.FileShareSnapCopyError,
+					Fault:  model.NewFault("fake controller injected failure for CopyFileShareSnapshot"),
+				})
+			return err
+		})
+}
+
+func (f *fakeClient) BackupFileShareSnapshot(ctx context.Context, in *pb.BackupFileShareSnapshotOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("BackupFileShareSnapshot", in.Context,
+		func(ctx *c.Context) error {
+			_, err := db.C.UpdateFileShareSnapshotBackup(ctx, in.TrackingId,
+				&model.FileShareSnapshotBackupSpec{
+					Status:   model.FileShareSnapBackupAvailable,
+					Location: fmt.Sprintf("%s/%s/%s", in.TargetBucket, in.FileshareId, in.TrackingId),
+				})
+			return err
+		},
+		func(ctx *c.Context) error {
+			_, err := db.C.UpdateFileShareSnapshotBackup(ctx, in.TrackingId,
+				&model.FileShareSnapshotBackupSpec{
+					Status: model.FileShareSnapBackupError,
+					Fault:  model.NewFault("fake controller injected failure for BackupFileShareSnapshot"),
+				})
+			return err
+		})
+}
+
+func (f *fakeClient) RestoreFileShareSnapshotBackup(ctx context.Context, in *pb.RestoreFileShareSnapshotBackupOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("RestoreFileShareSnapshotBackup", in.Context,
+		func(ctx *c.Context) error {
+			_, err := db.C.UpdateFileShareSnapshotBackup(ctx, in.Id,
+				&model.FileShareSnapshotBackupSpec{Status: model.FileShareSnapBackupAvailable})
+			return err
+		},
+		func(ctx *c.Context) error {
+			_, err := db.C.UpdateFileShareSnapshotBackup(ctx, in.Id,
+				&model.FileShareSnapshotBackupSpec{
+					Status: model.FileShareSnapBackupError,
+					Fault:  model.NewFault("fake controller injected failure for RestoreFileShareSnapshotBackup"),
+				})
+			return err
+		})
+}
+
+func (f *fakeClient) CreateFileShareAcl(ctx context.Context, in *pb.CreateFileShareAclOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("CreateFileShareAcl", in.Context,
+		func(ctx *c.Context) error {
+			return db.UpdateFileShareAclStatus(ctx, db.C, in.Id, model.FileShareAclAvailable)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateFileShareAclFault(ctx, db.C, in.Id, model.FileShareAclError,
+				"fake controller injected failure for CreateFileShareAcl")
+		})
+}
+
+func (f *fakeClient) DeleteFileShareAcl(ctx context.Context, in *pb.DeleteFileShareAclOpts, opts ...grpc.CallOption) (*pb.GenericResponse, error) {
+	return f.simulate("DeleteFileShareAcl", in.Context,
+		func(ctx *c.Context) error {
+			return db.C.DeleteFileShareAcl(ctx, in.Id)
+		},
+		func(ctx *c.Context) error {
+			return db.UpdateFileShareAclFault(ctx, db.C, in.Id, model.FileShareAclErrorDeleting,
+				"fake controller injected failure for DeleteFileShareAcl")
+		})
+}
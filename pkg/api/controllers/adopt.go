@@ -0,0 +1,307 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the admin importer that adopts existing Cinder
+volumes and Manila shares as managed records, so brownfield OpenStack
+storage does not have to be recreated.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/satori/go.uuid"
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+func NewImportPortal() *ImportPortal {
+	return &ImportPortal{}
+}
+
+// ImportPortal implements the admin importer. The records it creates carry
+// the source deployment and the source id in their metadata, so rerunning
+// the same export is idempotent and an adopted resource keeps pointing at
+// its origin.
+type ImportPortal struct {
+	BasePortal
+}
+
+const (
+	importSourceKey   = "importSource"
+	importSourceIdKey = "importSourceId"
+)
+
+// Import serves the brownfield adoption of a Cinder volume list or a
+// Manila share export, admin only. Without execute set the importer only
+// reports the diff between the export and the database.
+func (p *ImportPortal) Import() {
+	if !policy.Authorize(p.Ctx, "import:create") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to import resources"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	var in = model.ImportRequestSpec{}
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&in); err != nil {
+		errMsg := fmt.Sprintf("parse import request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	switch in.Source {
+	case "cinder":
+		if len(in.Volumes) == 0 {
+			errMsg := "a cinder import needs the volumes of the export"
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	case "manila":
+		if len(in.Shares) == 0 {
+			errMsg := "a manila import needs the shares of the export"
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	default:
+		errMsg := fmt.Sprintf("source must be either cinder or manila, got %q", in.Source)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	plan, err := p.planImport(ctx, &in)
+	if err != nil {
+		errMsg := fmt.Sprintf("plan import failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(plan)
+	p.SuccessHandle(StatusOK, body)
+	return
+}
+
+// planImport works through the export entry by entry. Every entry ends up
+// in the plan exactly once, either adopted or skipped with the reason, so
+// the operator can fix the mappings and rerun the same export.
+func (p *ImportPortal) planImport(ctx *c.Context, in *model.ImportRequestSpec) (*model.ImportPlanSpec, error) {
+	plan := &model.ImportPlanSpec{
+		DryRun:  !in.Execute,
+		Created: []*model.ImportItemSpec{},
+		Skipped: []*model.ImportItemSpec{},
+	}
+
+	// The source ids already adopted earlier, so rerunning an export only
+	// picks up what is still missing.
+	imported := map[string]bool{}
+	if in.Source == "cinder" {
+		volumes, err := db.C.ListVolumes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, volume := range volumes {
+			if id := volume.Metadata[importSourceIdKey]; id != "" {
+				imported[id] = true
+			}
+		}
+		for _, src := range in.Volumes {
+			p.importVolume(ctx, in, src, imported, plan)
+		}
+		return plan, nil
+	}
+
+	shares, err := db.C.ListFileShares(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, share := range shares {
+		if id := share.Metadata[importSourceIdKey]; id != "" {
+			imported[id] = true
+		}
+	}
+	for _, src := range in.Shares {
+		p.importShare(ctx, in, src, imported, plan)
+	}
+	return plan, nil
+}
+
+// resolveMappings fills profile, pool and availability zone of the item
+// from the mappings of the request and checks the targets exist. The
+// returned reason is empty when the item can be adopted.
+func (p *ImportPortal) resolveMappings(ctx *c.Context, in *model.ImportRequestSpec,
+	item *model.ImportItemSpec, sourceType, sourceZone, sourceHost string) string {
+	profileId, ok := in.TypeToProfile[sourceType]
+	if !ok {
+		return fmt.Sprintf("no profile mapped for type %q", sourceType)
+	}
+	if _, err := db.C.GetProfile(ctx, profileId); err != nil {
+		return fmt.Sprintf("mapped profile %s not found: %v", profileId, err)
+	}
+	item.ProfileId = profileId
+
+	poolId, ok := in.HostToPool[sourceHost]
+	if !ok {
+		return fmt.Sprintf("no pool mapped for host %q", sourceHost)
+	}
+	if _, err := db.C.GetPool(ctx, poolId); err != nil {
+		return fmt.Sprintf("mapped pool %s not found: %v", poolId, err)
+	}
+	item.PoolId = poolId
+
+	zone := sourceZone
+	if mapped, ok := in.ZoneMapping[zone]; ok {
+		zone = mapped
+	}
+	if zone == "" {
+		zone = "default"
+	}
+	item.AvailabilityZone = zone
+	return ""
+}
+
+func (p *ImportPortal) importVolume(ctx *c.Context, in *model.ImportRequestSpec,
+	src *model.CinderVolumeSpec, imported map[string]bool, plan *model.ImportPlanSpec) {
+	item := &model.ImportItemSpec{
+		SourceId:     src.Id,
+		Name:         src.Name,
+		ResourceType: "volume",
+		Size:         src.Size,
+	}
+	skip := func(reason string) {
+		item.Reason = reason
+		plan.Skipped = append(plan.Skipped, item)
+	}
+	if src.Id == "" {
+		skip("the export entry carries no id")
+		return
+	}
+	if imported[src.Id] {
+		skip("already imported")
+		return
+	}
+	if src.Status != "available" && src.Status != "in-use" {
+		skip(fmt.Sprintf("status %q is not importable", src.Status))
+		return
+	}
+	if reason := p.resolveMappings(ctx, in, item, src.VolumeType, src.AvailabilityZone, src.Host); reason != "" {
+		skip(reason)
+		return
+	}
+
+	if in.Execute {
+		status := model.VolumeAvailable
+		if src.Status == "in-use" {
+			status = model.VolumeInUse
+		}
+		volume := &model.VolumeSpec{
+			BaseModel: &model.BaseModel{
+				Id:        uuid.NewV4().String(),
+				CreatedAt: utils.FormatTime(time.Now()),
+			},
+			TenantId:         ctx.TenantId,
+			UserId:           ctx.UserId,
+			Name:             src.Name,
+			Description:      src.Description,
+			Size:             src.Size,
+			AvailabilityZone: item.AvailabilityZone,
+			Status:           status,
+			ProfileId:        item.ProfileId,
+			PoolId:           item.PoolId,
+			Metadata: utils.MergeStringMaps(src.Metadata, map[string]string{
+				importSourceKey:   in.Source,
+				importSourceIdKey: src.Id,
+			}),
+		}
+		if _, err := db.C.CreateVolume(ctx, volume); err != nil {
+			skip(fmt.Sprintf("create volume failed: %v", err))
+			return
+		}
+		item.Id = volume.Id
+	}
+	imported[src.Id] = true
+	plan.Created = append(plan.Created, item)
+}
+
+func (p *ImportPortal) importShare(ctx *c.Context, in *model.ImportRequestSpec,
+	src *model.ManilaShareSpec, imported map[string]bool, plan *model.ImportPlanSpec) {
+	item := &model.ImportItemSpec{
+		SourceId:     src.Id,
+		Name:         src.Name,
+		ResourceType: "fileshare",
+		Size:         src.Size,
+	}
+	skip := func(reason string) {
+		item.Reason = reason
+		plan.Skipped = append(plan.Skipped, item)
+	}
+	if src.Id == "" {
+		skip("the export entry carries no id")
+		return
+	}
+	if imported[src.Id] {
+		skip("already imported")
+		return
+	}
+	if src.Status != "available" {
+		skip(fmt.Sprintf("status %q is not importable", src.Status))
+		return
+	}
+	if reason := p.resolveMappings(ctx, in, item, src.ShareType, src.AvailabilityZone, src.Host); reason != "" {
+		skip(reason)
+		return
+	}
+
+	if in.Execute {
+		share := &model.FileShareSpec{
+			BaseModel: &model.BaseModel{
+				Id:        uuid.NewV4().String(),
+				CreatedAt: utils.FormatTime(time.Now()),
+			},
+			TenantId:         ctx.TenantId,
+			UserId:           ctx.UserId,
+			Name:             src.Name,
+			Description:      src.Description,
+			Size:             src.Size,
+			AvailabilityZone: item.AvailabilityZone,
+			Status:           model.FileShareAvailable,
+			ProfileId:        item.ProfileId,
+			PoolId:           item.PoolId,
+			ExportLocations:  src.ExportLocations,
+			Metadata: map[string]string{
+				importSourceKey:   in.Source,
+				importSourceIdKey: src.Id,
+			},
+		}
+		if src.ShareProto != "" {
+			share.Protocols = []string{src.ShareProto}
+		}
+		if _, err := db.C.CreateFileShare(ctx, share); err != nil {
+			skip(fmt.Sprintf("create fileshare failed: %v", err))
+			return
+		}
+		item.Id = share.Id
+	}
+	imported[src.Id] = true
+	plan.Created = append(plan.Created, item)
+}
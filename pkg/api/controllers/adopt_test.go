@@ -0,0 +1,189 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+	"github.com/stretchr/testify/mock"
+)
+
+// //////////////////////////////////////////////////////////////////////////////
+//
+//	Prepare for mock server                               //
+//
+// //////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/admin/import", &ImportPortal{}, "post:Import")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                             Tests for import                               //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestImport(t *testing.T) {
+	importRequest := func(body string) (*httptest.ResponseRecorder, *http.Request) {
+		r, _ := http.NewRequest("POST", "/v1beta/admin/import", bytes.NewBufferString(body))
+		return httptest.NewRecorder(), r
+	}
+
+	t.Run("Should report the diff of a cinder export in a dry run", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListVolumes", c.NewAdminContext()).Return(nil, nil)
+		mockClient.On("GetProfile", c.NewAdminContext(), "1106b972-66ef-11e7-b172-db03f3689c9c").
+			Return(&SampleProfiles[0], nil)
+		mockClient.On("GetPool", c.NewAdminContext(), "084bf71e-a102-11e7-88a8-e31fe6d52248").
+			Return(&SamplePools[0], nil)
+		db.C = mockClient
+
+		body := `{
+			"source": "cinder",
+			"typeToProfile": {"lvm": "1106b972-66ef-11e7-b172-db03f3689c9c"},
+			"zoneMapping": {"nova": "default"},
+			"hostToPool": {"node1@lvm#pool1": "084bf71e-a102-11e7-88a8-e31fe6d52248"},
+			"volumes": [
+				{"id": "8aa20a2e-71d5-4f36-9bc5-780b1a1b308e", "name": "vol1", "size": 2,
+					"status": "available", "volume_type": "lvm", "availability_zone": "nova",
+					"os-vol-host-attr:host": "node1@lvm#pool1"},
+				{"id": "4cd21e24-6b10-4f32-9821-37df52c4a7d3", "name": "vol2", "size": 1,
+					"status": "available", "volume_type": "ceph", "availability_zone": "nova",
+					"os-vol-host-attr:host": "node1@lvm#pool1"}
+			]
+		}`
+		w, r := importRequest(body)
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var plan model.ImportPlanSpec
+		json.Unmarshal(w.Body.Bytes(), &plan)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, plan.DryRun, true)
+		assertTestResult(t, len(plan.Created), 1)
+		assertTestResult(t, plan.Created[0].SourceId, "8aa20a2e-71d5-4f36-9bc5-780b1a1b308e")
+		assertTestResult(t, plan.Created[0].Id, "")
+		assertTestResult(t, plan.Created[0].PoolId, "084bf71e-a102-11e7-88a8-e31fe6d52248")
+		assertTestResult(t, plan.Created[0].AvailabilityZone, "default")
+		assertTestResult(t, len(plan.Skipped), 1)
+		assertTestResult(t, plan.Skipped[0].Reason, `no profile mapped for type "ceph"`)
+	})
+
+	t.Run("Should adopt a manila share with execute set", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListFileShares", c.NewAdminContext()).Return(nil, nil)
+		mockClient.On("GetProfile", c.NewAdminContext(), "1106b972-66ef-11e7-b172-db03f3689c9c").
+			Return(&SampleProfiles[0], nil)
+		mockClient.On("GetPool", c.NewAdminContext(), "084bf71e-a102-11e7-88a8-e31fe6d52248").
+			Return(&SamplePools[0], nil)
+		mockClient.On("CreateFileShare", c.NewAdminContext(),
+			mock.MatchedBy(func(share *model.FileShareSpec) bool {
+				return share.Id != "" &&
+					share.Metadata[importSourceIdKey] == "00137b40-1ea5-4caa-a004-e42700a4b2e7" &&
+					share.Status == model.FileShareAvailable
+			})).Return(nil, nil)
+		db.C = mockClient
+
+		body := `{
+			"source": "manila",
+			"execute": true,
+			"typeToProfile": {"default": "1106b972-66ef-11e7-b172-db03f3689c9c"},
+			"hostToPool": {"node1@generic#pool1": "084bf71e-a102-11e7-88a8-e31fe6d52248"},
+			"shares": [
+				{"id": "00137b40-1ea5-4caa-a004-e42700a4b2e7", "name": "share1", "size": 1,
+					"status": "available", "share_type": "default", "share_proto": "NFS",
+					"host": "node1@generic#pool1",
+					"export_locations": ["10.0.0.5:/share1"]}
+			]
+		}`
+		w, r := importRequest(body)
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var plan model.ImportPlanSpec
+		json.Unmarshal(w.Body.Bytes(), &plan)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, plan.DryRun, false)
+		assertTestResult(t, len(plan.Created), 1)
+		assertTestResult(t, plan.Created[0].Id != "", true)
+		mockClient.AssertNumberOfCalls(t, "CreateFileShare", 1)
+	})
+
+	t.Run("Should skip entries that were adopted by an earlier run", func(t *testing.T) {
+		adopted := &model.VolumeSpec{
+			BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+			Metadata:  map[string]string{importSourceIdKey: "8aa20a2e-71d5-4f36-9bc5-780b1a1b308e"},
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListVolumes", c.NewAdminContext()).
+			Return([]*model.VolumeSpec{adopted}, nil)
+		db.C = mockClient
+
+		body := `{
+			"source": "cinder",
+			"volumes": [
+				{"id": "8aa20a2e-71d5-4f36-9bc5-780b1a1b308e", "name": "vol1", "size": 2,
+					"status": "available", "volume_type": "lvm"}
+			]
+		}`
+		w, r := importRequest(body)
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var plan model.ImportPlanSpec
+		json.Unmarshal(w.Body.Bytes(), &plan)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, len(plan.Created), 0)
+		assertTestResult(t, len(plan.Skipped), 1)
+		assertTestResult(t, plan.Skipped[0].Reason, "already imported")
+	})
+
+	t.Run("Should return 400 for an unknown source", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		w, r := importRequest(`{"source": "netapp"}`)
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 403 for non-admin users", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		w, r := importRequest(`{"source": "cinder"}`)
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+}
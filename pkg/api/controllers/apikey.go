@@ -0,0 +1,236 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewApiKeyPortal() *ApiKeyPortal {
+	return &ApiKeyPortal{}
+}
+
+type ApiKeyPortal struct {
+	BasePortal
+}
+
+// generateApiKeySecret returns a new random api key secret. The secret is
+// returned to the caller exactly once, only its hash is kept at rest.
+func generateApiKeySecret() (string, error) {
+	var buf = make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func (p *ApiKeyPortal) CreateApiKey() {
+	if !policy.Authorize(p.Ctx, "apikey:create") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	var apikey = model.ApiKeySpec{
+		BaseModel: &model.BaseModel{},
+	}
+
+	// Unmarshal the request body
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&apikey); err != nil {
+		errMsg := fmt.Sprintf("parse api key request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if apikey.Name == "" {
+		errMsg := "api key name can not be empty"
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if apikey.Role == "" {
+		apikey.Role = model.ApiKeyRoleUser
+	}
+	if apikey.Role != model.ApiKeyRoleAdmin && apikey.Role != model.ApiKeyRoleUser {
+		errMsg := fmt.Sprintf("invalid api key role %s, supported roles are %s and %s",
+			apikey.Role, model.ApiKeyRoleAdmin, model.ApiKeyRoleUser)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	// A user must not be able to mint a key with more privileges than its
+	// own.
+	if apikey.Role == model.ApiKeyRoleAdmin && !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to create admin api keys"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	secret, err := generateApiKeySecret()
+	if err != nil {
+		errMsg := fmt.Sprintf("create api key failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	apikey.UserId = ctx.UserId
+	apikey.HashedSecret = model.HashApiKeySecret(secret)
+
+	result, err := db.C.CreateApiKey(ctx, &apikey)
+	if err != nil {
+		errMsg := fmt.Sprintf("create api key failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// The secret is only returned here, it can not be recovered later.
+	result.Secret = secret
+	result.HashedSecret = ""
+	body, err := json.Marshal(result)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal api key created result failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	p.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+func (p *ApiKeyPortal) ListApiKeys() {
+	if !policy.Authorize(p.Ctx, "apikey:list") {
+		return
+	}
+	result, err := db.C.ListApiKeys(c.GetContext(p.Ctx))
+	if err != nil {
+		errMsg := fmt.Sprintf("list api keys failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	for _, key := range result {
+		key.HashedSecret = ""
+	}
+	p.SuccessHandleList(StatusOK, result)
+
+	return
+}
+
+func (p *ApiKeyPortal) GetApiKey() {
+	if !policy.Authorize(p.Ctx, "apikey:get") {
+		return
+	}
+	id := p.Ctx.Input.Param(":apikeyId")
+	result, err := db.C.GetApiKey(c.GetContext(p.Ctx), id)
+	if err != nil {
+		errMsg := fmt.Sprintf("api key %s not found: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	result.HashedSecret = ""
+	body, err := json.Marshal(result)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal api key showed result failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	p.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+// RotateApiKey replaces the secret of an api key with a freshly generated
+// one. The old secret stops working immediately, the new one is returned
+// exactly once.
+func (p *ApiKeyPortal) RotateApiKey() {
+	if !policy.Authorize(p.Ctx, "apikey:rotate") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	id := p.Ctx.Input.Param(":apikeyId")
+	apikey, err := db.C.GetApiKey(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("api key %s not found: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Rotating hands out a fresh secret, so the same privilege rule as for
+	// creation applies: a plain tenant member must not get hold of an admin
+	// secret, and may only rotate its own keys.
+	if apikey.Role == model.ApiKeyRoleAdmin && !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to rotate admin api keys"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+	if !ctx.IsAdmin && apikey.UserId != ctx.UserId {
+		errMsg := "only the owner of an api key or an admin is allowed to rotate it"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	secret, err := generateApiKeySecret()
+	if err != nil {
+		errMsg := fmt.Sprintf("rotate api key failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	apikey.HashedSecret = model.HashApiKeySecret(secret)
+
+	result, err := db.C.UpdateApiKey(ctx, apikey)
+	if err != nil {
+		errMsg := fmt.Sprintf("rotate api key failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// The new secret is only returned here, it can not be recovered later.
+	result.Secret = secret
+	result.HashedSecret = ""
+	body, err := json.Marshal(result)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal api key rotated result failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	p.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+func (p *ApiKeyPortal) DeleteApiKey() {
+	if !policy.Authorize(p.Ctx, "apikey:revoke") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	id := p.Ctx.Input.Param(":apikeyId")
+	if _, err := db.C.GetApiKey(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("api key %s not found: %s", id, err.Error())
+		p.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	if err := db.C.DeleteApiKey(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("delete api key failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	p.SuccessHandle(StatusOK, nil)
+
+	return
+}
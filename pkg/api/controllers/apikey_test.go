@@ -0,0 +1,157 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func init() {
+	beego.Router("/v1beta/apikeys", NewApiKeyPortal(), "post:CreateApiKey;get:ListApiKeys")
+	beego.Router("/v1beta/apikeys/:apikeyId", NewApiKeyPortal(), "get:GetApiKey;delete:DeleteApiKey")
+	beego.Router("/v1beta/apikeys/:apikeyId/rotate", NewApiKeyPortal(), "post:RotateApiKey")
+}
+
+func TestListApiKeys(t *testing.T) {
+
+	t.Run("Should return 200 and no hashed secret if everything works well", func(t *testing.T) {
+		fakeApiKey := SampleApiKeys[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListApiKeys", c.NewAdminContext()).Return(
+			[]*model.ApiKeySpec{&fakeApiKey}, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/apikeys", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []model.ApiKeySpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, len(output), 1)
+		assertTestResult(t, output[0].Name, SampleApiKeys[0].Name)
+		assertTestResult(t, output[0].HashedSecret, "")
+	})
+}
+
+func TestRotateApiKey(t *testing.T) {
+
+	t.Run("Should return 403 if a non-admin rotates an admin key", func(t *testing.T) {
+		adminKey := SampleApiKeys[0]
+		adminKey.Role = model.ApiKeyRoleAdmin
+		userCtx := &c.Context{TenantId: adminKey.TenantId, UserId: "some-other-user"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetApiKey", userCtx, adminKey.Id).Return(&adminKey, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/apikeys/"+adminKey.Id+"/rotate", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", userCtx)
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+
+	t.Run("Should return 403 if a non-admin rotates somebody else's key", func(t *testing.T) {
+		fakeApiKey := SampleApiKeys[0]
+		fakeApiKey.UserId = "the-owner"
+		userCtx := &c.Context{TenantId: fakeApiKey.TenantId, UserId: "some-other-user"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetApiKey", userCtx, fakeApiKey.Id).Return(&fakeApiKey, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/apikeys/"+fakeApiKey.Id+"/rotate", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", userCtx)
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+
+	t.Run("Should return 200 and a fresh secret for an admin", func(t *testing.T) {
+		fakeApiKey := SampleApiKeys[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetApiKey", c.NewAdminContext(), fakeApiKey.Id).Return(&fakeApiKey, nil)
+		mockClient.On("UpdateApiKey", c.NewAdminContext(), &fakeApiKey).Return(&fakeApiKey, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/apikeys/"+fakeApiKey.Id+"/rotate", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.ApiKeySpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.HashedSecret, "")
+		if output.Secret == "" {
+			t.Errorf("Expected a fresh secret in the rotate response")
+		}
+	})
+}
+
+func TestGetApiKey(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		fakeApiKey := SampleApiKeys[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetApiKey", c.NewAdminContext(), fakeApiKey.Id).Return(&fakeApiKey, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/apikeys/"+fakeApiKey.Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.ApiKeySpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Name, SampleApiKeys[0].Name)
+		assertTestResult(t, output.HashedSecret, "")
+	})
+
+	t.Run("Should return 404 if get api key with bad request", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetApiKey", c.NewAdminContext(), SampleApiKeys[0].Id).Return(
+			nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/apikeys/"+SampleApiKeys[0].Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
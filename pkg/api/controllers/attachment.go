@@ -26,6 +26,7 @@ import (
 
 	log "github.com/golang/glog"
 	"github.com/sodafoundation/api/pkg/api/policy"
+	"github.com/sodafoundation/api/pkg/api/util"
 	c "github.com/sodafoundation/api/pkg/context"
 	"github.com/sodafoundation/api/pkg/api/controllerclient"
 	"github.com/sodafoundation/api/pkg/db"
@@ -63,6 +64,19 @@ func (v *VolumeAttachmentPortal) CreateVolumeAttachment() {
 		return
 	}
 
+	if err := attachment.ValidateMountAutomation(); err != nil {
+		errMsg := fmt.Sprintf("create volume attachment failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// A create without a host reserves the attachment for a later
+	// finalize, mirroring the two-step attachment flow of live migration.
+	if attachment.HostId == "" {
+		v.reserveVolumeAttachment(ctx, &attachment)
+		return
+	}
+
 	// Check if host exists
 	host, err := db.C.GetHost(ctx, attachment.HostId)
 	if err != nil {
@@ -87,7 +101,9 @@ func (v *VolumeAttachmentPortal) CreateVolumeAttachment() {
 		return
 	}
 
-	if vol.Status == model.VolumeAvailable {
+	if vol.Status == model.VolumeAvailable || vol.Status == model.VolumeReserved {
+		// Reserved volumes were claimed through the volume action endpoint
+		// and continue the attach state machine here.
 		db.UpdateVolumeStatus(ctx, db.C, vol.Id, model.VolumeAttaching)
 	} else if vol.Status == model.VolumeInUse {
 		if vol.MultiAttach {
@@ -98,7 +114,7 @@ func (v *VolumeAttachmentPortal) CreateVolumeAttachment() {
 			return
 		}
 	} else {
-		errMsg := "status of volume is available. It can be attached to host"
+		errMsg := fmt.Sprintf("only a volume with the status available or reserved can be attached, the volume status is %s", vol.Status)
 		v.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
@@ -191,6 +207,188 @@ func (v *VolumeAttachmentPortal) CreateVolumeAttachment() {
 	return
 }
 
+// reserveVolumeAttachment creates an attachment in the "reserved" status,
+// claiming the volume without deriving any connection info. The attachment
+// is completed later through FinalizeVolumeAttachment, when the target
+// host of a live migration is known.
+func (v *VolumeAttachmentPortal) reserveVolumeAttachment(ctx *c.Context, attachment *model.VolumeAttachmentSpec) {
+	vol, err := db.C.GetVolume(ctx, attachment.VolumeId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get volume failed in create volume attachment method: %v", err)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if vol.Status == model.VolumeAvailable {
+		db.UpdateVolumeStatus(ctx, db.C, vol.Id, model.VolumeReserved)
+	} else if vol.Status == model.VolumeInUse && vol.MultiAttach {
+		// A multi-attach volume stays inUse while a further attachment is
+		// reserved.
+	} else {
+		errMsg := fmt.Sprintf("only a volume with the status available can be reserved, the volume status is %s", vol.Status)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// Set AttachMode, rw is a default setting
+	if attachment.AttachMode != "ro" && attachment.AttachMode != "rw" {
+		attachment.AttachMode = "rw"
+	}
+	attachment.Status = model.VolumeAttachReserved
+
+	result, err := db.C.CreateVolumeAttachment(ctx, attachment)
+	if err != nil {
+		errMsg := fmt.Sprintf("create volume attachment failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	v.SuccessHandle(StatusAccepted, body)
+	return
+}
+
+// FinalizeVolumeAttachment completes a reserved attachment with the target
+// host, deriving the connection info through the dock like an initial
+// attach. Together with the reserved create this mirrors the two-step
+// attachment flow live migration needs to move a volume without downtime.
+func (v *VolumeAttachmentPortal) FinalizeVolumeAttachment() {
+	if !policy.Authorize(v.Ctx, "volume:finalize_attachment") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	id := v.Ctx.Input.Param(":attachmentId")
+
+	attachment, err := db.C.GetVolumeAttachment(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("volume attachment %s not found: %s", id, err.Error())
+		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if attachment.Status != model.VolumeAttachReserved {
+		errMsg := fmt.Sprintf("only a reserved volume attachment can be finalized, the attachment status is %s", attachment.Status)
+		v.ErrorHandle(model.ErrorConflict, errMsg)
+		return
+	}
+
+	var in = model.VolumeAttachmentSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&in); err != nil {
+		errMsg := fmt.Sprintf("parse volume attachment request body failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if in.HostId == "" {
+		errMsg := "hostId can not be empty when finalizing a volume attachment"
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	host, err := db.C.GetHost(ctx, in.HostId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get host failed in finalize volume attachment method: %v", err)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	vol, err := db.C.GetVolume(ctx, attachment.VolumeId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get volume failed in finalize volume attachment method: %v", err)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if !utils.Contains(host.AvailabilityZones, vol.AvailabilityZone) {
+		errMsg := fmt.Sprintf("availability zone of volume: %s is not in the host availability zones: %v",
+			vol.AvailabilityZone,
+			host.AvailabilityZones)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if vol.Status == model.VolumeReserved || vol.Status == model.VolumeAvailable ||
+		(vol.Status == model.VolumeInUse && vol.MultiAttach) {
+		db.UpdateVolumeStatus(ctx, db.C, vol.Id, model.VolumeAttaching)
+	} else {
+		errMsg := fmt.Sprintf("only a volume with the status available or reserved can be attached, the volume status is %s", vol.Status)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// Set AccessProtocol
+	pol, err := db.C.GetPool(ctx, vol.PoolId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get pool failed in finalize volume attachment method: %v", err)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	var protocol = pol.Extras.IOConnectivity.AccessProtocol
+	if protocol == "" {
+		// Default protocol is iscsi
+		protocol = constants.ISCSIProtocol
+	}
+
+	attachment.HostId = in.HostId
+	attachment.AccessProtocol = protocol
+	attachment.Status = model.VolumeAttachCreating
+	result, err := db.C.UpdateVolumeAttachment(ctx, attachment.Id, attachment)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to update volume attachment: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	log.Infof("audit: attachment %s finalized on host %s by tenant %s",
+		attachment.Id, in.HostId, ctx.TenantId)
+
+	body, _ := json.Marshal(util.MaskVolumeAttachment(ctx, result))
+	v.SuccessHandle(StatusAccepted, body)
+
+	// NOTE:The real volume attachment creation process. The Dock derives
+	// the connection info for the target host and sets the attachment
+	// status to "available" when it is done.
+	if err := v.CtrClient.Connect(apiconfig.CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+
+	var initiators []*pb.Initiator
+	for _, e := range host.Initiators {
+		initiator := pb.Initiator{
+			PortName: e.PortName,
+			Protocol: e.Protocol,
+		}
+		initiators = append(initiators, &initiator)
+	}
+	opt := &pb.CreateVolumeAttachmentOpts{
+		Id:             result.Id,
+		VolumeId:       result.VolumeId,
+		PoolId:         vol.PoolId,
+		AccessProtocol: protocol,
+		HostInfo: &pb.HostInfo{
+			OsType:     host.OsType,
+			Ip:         host.IP,
+			Host:       host.HostName,
+			Initiators: initiators,
+		},
+		Metadata: vol.Metadata,
+		Context:  ctx.ToJson(),
+	}
+
+	response, err := v.CtrClient.CreateVolumeAttachment(context.Background(), opt)
+	if err != nil {
+		log.Error("create volume attachment failed in controller service:", err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to create volume attachment in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+		return
+	}
+
+	return
+}
+
 func (v *VolumeAttachmentPortal) ListVolumeAttachments() {
 	if !policy.Authorize(v.Ctx, "volume:list_attachments") {
 		return
@@ -210,9 +408,7 @@ func (v *VolumeAttachmentPortal) ListVolumeAttachments() {
 		return
 	}
 
-	// Marshal the result.
-	body, _ := json.Marshal(result)
-	v.SuccessHandle(StatusOK, body)
+	v.SuccessHandleList(StatusOK, util.MaskVolumeAttachments(c.GetContext(v.Ctx), result))
 
 	return
 }
@@ -230,8 +426,9 @@ func (v *VolumeAttachmentPortal) GetVolumeAttachment() {
 		return
 	}
 
-	// Marshal the result.
-	body, _ := json.Marshal(result)
+	// Marshal the result. Connection secrets are only serialized for the
+	// attaching host and service roles.
+	body, _ := json.Marshal(util.MaskVolumeAttachment(c.GetContext(v.Ctx), result))
 	v.SuccessHandle(StatusOK, body)
 
 	return
@@ -253,6 +450,12 @@ func (v *VolumeAttachmentPortal) UpdateVolumeAttachment() {
 	}
 	attachment.Id = id
 
+	if err := attachment.ValidateMountAutomation(); err != nil {
+		errMsg := fmt.Sprintf("update volume attachment failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
 	result, err := db.C.UpdateVolumeAttachment(c.GetContext(v.Ctx), id, &attachment)
 	if err != nil {
 		errMsg := fmt.Sprintf("update volume attachment failed: %s", err.Error())
@@ -277,13 +480,13 @@ func (v *VolumeAttachmentPortal) DeleteVolumeAttachment() {
 	attachment, err := db.C.GetVolumeAttachment(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("volume attachment %s not found: %s", id, err.Error())
-		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		v.DeleteNotFoundHandle(errMsg)
 		return
 	}
 
 	// Check if attachment can be deleted
 	validStatus := []string{model.VolumeAttachAvailable, model.VolumeAttachError,
-		model.VolumeAttachErrorDeleting}
+		model.VolumeAttachErrorDeleting, model.VolumeAttachReserved}
 	if !utils.Contained(attachment.Status, validStatus) {
 		errMsg := fmt.Sprintf("only the volume attachment with the status available, error, error_deleting can be deleted, the volume status is %s", attachment.Status)
 		v.ErrorHandle(model.ErrorBadRequest, errMsg)
@@ -303,6 +506,21 @@ func (v *VolumeAttachmentPortal) DeleteVolumeAttachment() {
 		return
 	}
 
+	// A reserved attachment was never connected, so there is nothing to
+	// tear down on the dock; drop the entry and release the volume.
+	if attachment.Status == model.VolumeAttachReserved {
+		if err := db.C.DeleteVolumeAttachment(ctx, attachment.Id); err != nil {
+			errMsg := fmt.Sprintf("failed to delete volume attachment: %s", err.Error())
+			v.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+		if vol.Status == model.VolumeReserved {
+			db.UpdateVolumeStatus(ctx, db.C, vol.Id, model.VolumeAvailable)
+		}
+		v.SuccessHandle(StatusAccepted, nil)
+		return
+	}
+
 	host, err := db.C.GetHost(ctx, attachment.HostId)
 	if err != nil {
 		errMsg := fmt.Sprintf("get host failed in delete volume attachment method: %v", err)
@@ -328,6 +546,17 @@ func (v *VolumeAttachmentPortal) DeleteVolumeAttachment() {
 		return
 	}
 
+	if err := v.dispatchDeleteVolumeAttachment(ctx, attachment, vol, host); err != nil {
+		log.Error(err)
+	}
+
+	return
+}
+
+// dispatchDeleteVolumeAttachment hands one attachment deletion to the dock
+// over the already connected controller client.
+func (v *VolumeAttachmentPortal) dispatchDeleteVolumeAttachment(ctx *c.Context,
+	attachment *model.VolumeAttachmentSpec, vol *model.VolumeSpec, host *model.HostSpec) error {
 	var initiators []*pb.Initiator
 	for _, e := range host.Initiators {
 		initiator := pb.Initiator{
@@ -352,11 +581,181 @@ func (v *VolumeAttachmentPortal) DeleteVolumeAttachment() {
 	}
 	response, err := v.CtrClient.DeleteVolumeAttachment(context.Background(), opt)
 	if err != nil {
-		log.Error("delete volume attachment failed in controller service:", err)
+		return fmt.Errorf("delete volume attachment failed in controller service: %v", err)
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return fmt.Errorf("failed to delete volume attachment in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+	return nil
+}
+
+// DetachAllVolumeAttachments tears down every attachment of a volume in
+// one call, for emergency isolation of a compromised workload. Each
+// attachment goes through the same flow as a single detach: a reserved
+// attachment was never connected and only has its db entry dropped, the
+// others are marked deleting and handed to the dock one by one. The
+// response lists the attachments whose teardown was kicked off.
+func (v *VolumeAttachmentPortal) DetachAllVolumeAttachments() {
+	if !policy.Authorize(v.Ctx, "volume:detach_all") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	volId := v.Ctx.Input.Param(":volumeId")
+
+	vol, err := db.C.GetVolume(ctx, volId)
+	if err != nil {
+		errMsg := fmt.Sprintf("volume %s not found: %s", volId, err.Error())
+		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	attachments, err := db.C.ListVolumeAttachments(ctx, volId)
+	if err != nil {
+		errMsg := fmt.Sprintf("list attachments of volume %s failed: %s", volId, err.Error())
+		v.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// An isolation must make progress with whatever it can: attachments
+	// that cannot be marked are logged and skipped, not fatal.
+	var detaching []*model.VolumeAttachmentSpec
+	for _, attachment := range attachments {
+		if attachment.Status == model.VolumeAttachReserved {
+			if err := db.C.DeleteVolumeAttachment(ctx, attachment.Id); err != nil {
+				log.Errorf("when dropping reserved attachment %s of volume %s: %v",
+					attachment.Id, volId, err)
+			}
+			continue
+		}
+		attachment.Status = model.VolumeAttachDeleting
+		if _, err := db.C.UpdateVolumeAttachment(ctx, attachment.Id, attachment); err != nil {
+			log.Errorf("when marking attachment %s of volume %s deleting: %v",
+				attachment.Id, volId, err)
+			continue
+		}
+		detaching = append(detaching, attachment)
+	}
+
+	body, _ := json.Marshal(detaching)
+	v.SuccessHandle(StatusAccepted, body)
+
+	if len(detaching) == 0 {
+		return
+	}
+	if err := v.CtrClient.Connect(apiconfig.CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+	// The teardowns are paced in configured batches with bounded
+	// parallelism, checkpointing progress in the cascade task.
+	attachmentById := map[string]*model.VolumeAttachmentSpec{}
+	var items []string
+	for _, attachment := range detaching {
+		attachmentById[attachment.Id] = attachment
+		items = append(items, attachment.Id)
+	}
+	task := util.StartCascadeTask(ctx, "volume", volId, "detachAll", int64(len(items)))
+	util.NewCascadeRunner().Run(ctx, task, items, func(itemId string) error {
+		attachment := attachmentById[itemId]
+		host, err := db.C.GetHost(ctx, attachment.HostId)
+		if err != nil {
+			return fmt.Errorf("get host failed in detach all method for attachment %s: %v",
+				attachment.Id, err)
+		}
+		return v.dispatchDeleteVolumeAttachment(ctx, attachment, vol, host)
+	})
+}
+
+// RegenerateVolumeAttachmentConnection re-derives the connection info of an
+// existing attachment and returns it, for hosts that lost their copy (for
+// example after a reimage). The dock refreshes the attachment record with
+// the new connection info asynchronously, just like the initial attach.
+func (v *VolumeAttachmentPortal) RegenerateVolumeAttachmentConnection() {
+	if !policy.Authorize(v.Ctx, "volume:regenerate_attachment_connection") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	id := v.Ctx.Input.Param(":attachmentId")
+
+	attachment, err := db.C.GetVolumeAttachment(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("volume attachment %s not found: %s", id, err.Error())
+		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	validStatus := []string{model.VolumeAttachAvailable, model.VolumeAttachError}
+	if !utils.Contained(attachment.Status, validStatus) {
+		errMsg := fmt.Sprintf("only the connection info of a volume attachment with the status available or error can be regenerated, the attachment status is %s", attachment.Status)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	vol, err := db.C.GetVolume(ctx, attachment.VolumeId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get volume failed in regenerate connection method: %v", err)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	host, err := db.C.GetHost(ctx, attachment.HostId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get host failed in regenerate connection method: %v", err)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	attachment.Status = model.VolumeAttachCreating
+	result, err := db.C.UpdateVolumeAttachment(ctx, attachment.Id, attachment)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to update volume attachment: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	log.Infof("audit: connection info regeneration for attachment %s on host %s requested by tenant %s",
+		attachment.Id, attachment.HostId, ctx.TenantId)
+
+	body, _ := json.Marshal(util.MaskVolumeAttachment(ctx, result))
+	v.SuccessHandle(StatusAccepted, body)
+
+	// NOTE:The real connection info regeneration process. The request is
+	// handled by the Dock like an initial attach; the Dock updates the
+	// attachment with fresh connection info and sets its status back to
+	// "available" when it is done.
+	if err := v.CtrClient.Connect(apiconfig.CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+
+	var initiators []*pb.Initiator
+	for _, e := range host.Initiators {
+		initiator := pb.Initiator{
+			PortName: e.PortName,
+			Protocol: e.Protocol,
+		}
+		initiators = append(initiators, &initiator)
+	}
+	opt := &pb.CreateVolumeAttachmentOpts{
+		Id:             attachment.Id,
+		VolumeId:       attachment.VolumeId,
+		PoolId:         vol.PoolId,
+		AccessProtocol: attachment.AccessProtocol,
+		HostInfo: &pb.HostInfo{
+			OsType:     host.OsType,
+			Ip:         host.IP,
+			Host:       host.HostName,
+			Initiators: initiators,
+		},
+		Metadata: vol.Metadata,
+		Context:  ctx.ToJson(),
+	}
+	response, err := v.CtrClient.CreateVolumeAttachment(context.Background(), opt)
+	if err != nil {
+		log.Error("regenerate volume attachment connection failed in controller service:", err)
 		return
 	}
 	if errorMsg := response.GetError(); errorMsg != nil {
-		log.Errorf("failed to delete volume attachment in controller, code: %v, message: %v",
+		log.Errorf("failed to regenerate volume attachment connection in controller, code: %v, message: %v",
 			errorMsg.GetCode(), errorMsg.GetDescription())
 		return
 	}
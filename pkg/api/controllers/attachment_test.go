@@ -16,6 +16,7 @@ package controllers
 
 import (
 	"bytes"
+	ctx "context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -27,7 +28,9 @@ import (
 	c "github.com/sodafoundation/api/pkg/context"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
+	pb "github.com/sodafoundation/api/pkg/model/proto"
 	. "github.com/sodafoundation/api/testutils/collection"
+	ctrtest "github.com/sodafoundation/api/testutils/controller/testing"
 	dbtest "github.com/sodafoundation/api/testutils/db/testing"
 )
 
@@ -40,6 +43,39 @@ func init() {
 		"post:CreateVolumeAttachment;get:ListVolumeAttachments")
 	beego.Router("/v1beta/block/attachments/:attachmentId", &VolumeAttachmentPortal{},
 		"get:GetVolumeAttachment;put:UpdateVolumeAttachment;delete:DeleteVolumeAttachment")
+	beego.Router("/v1beta/block/attachments/:attachmentId/connection", &VolumeAttachmentPortal{},
+		"post:RegenerateVolumeAttachmentConnection")
+	beego.Router("/v1beta/block/attachments/:attachmentId/finalize", &VolumeAttachmentPortal{},
+		"post:FinalizeVolumeAttachment")
+	beego.Router("/v1beta/block/volumes/:volumeId/detach-all", NewFakeVolumeAttachmentPortal(),
+		"post:DetachAllVolumeAttachments")
+}
+
+func NewFakeVolumeAttachmentPortal() *VolumeAttachmentPortal {
+	mockClient := new(ctrtest.Client)
+
+	mockClient.On("Connect", "localhost:50049").Return(nil)
+	mockClient.On("Close").Return(nil)
+	mockClient.On("DeleteVolumeAttachment", ctx.Background(), &pb.DeleteVolumeAttachmentOpts{
+		Id:             "f2dda3d2-bf79-11e7-8665-f750b088f63e",
+		VolumeId:       "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		PoolId:         "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		AccessProtocol: "",
+		HostInfo: &pb.HostInfo{
+			OsType: "",
+			Ip:     "192.168.56.12",
+			Host:   "sap1",
+			Initiators: []*pb.Initiator{
+				{PortName: "20000024ff5bb888", Protocol: "iscsi"},
+				{PortName: "20000024ff5bc999", Protocol: "iscsi"},
+			},
+		},
+		Context: c.NewAdminContext().ToJson(),
+	}).Return(&pb.GenericResponse{}, nil)
+
+	return &VolumeAttachmentPortal{
+		CtrClient: mockClient,
+	}
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -199,4 +235,232 @@ func TestUpdateVolumeAttachment(t *testing.T) {
 		beego.BeeApp.Handlers.ServeHTTP(w, r)
 		assertTestResult(t, w.Code, 500)
 	})
+
+	t.Run("Should return 400 if a mount option is not whitelisted", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		var body = []byte(`{
+			"fsType": "ext4",
+			"mountOptions": ["noatime", "exec=/bin/sh"]
+		}`)
+		r, _ := http.NewRequest("PUT", "/v1beta/block/attachments/f2dda3d2-bf79-11e7-8665-f750b088f63e", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestRegenerateVolumeAttachmentConnection(t *testing.T) {
+
+	t.Run("Should return 404 if the volume attachment does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolumeAttachment", c.NewAdminContext(), "f2dda3d2-bf79-11e7-8665-f750b088f63e").
+			Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST",
+			"/v1beta/block/attachments/f2dda3d2-bf79-11e7-8665-f750b088f63e/connection", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+
+	t.Run("Should return 400 if the volume attachment is still creating", func(t *testing.T) {
+		var attachment = SampleAttachments[0]
+		attachment.Status = model.VolumeAttachCreating
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolumeAttachment", c.NewAdminContext(), "f2dda3d2-bf79-11e7-8665-f750b088f63e").
+			Return(&attachment, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST",
+			"/v1beta/block/attachments/f2dda3d2-bf79-11e7-8665-f750b088f63e/connection", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestReserveVolumeAttachment(t *testing.T) {
+
+	t.Run("Should return 202 and a reserved attachment if no host is given", func(t *testing.T) {
+		volume := SampleVolumes[0]
+		volume.Status = model.VolumeAvailable
+		in := &model.VolumeAttachmentSpec{
+			BaseModel:  &model.BaseModel{},
+			VolumeId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			AttachMode: "rw",
+			Status:     model.VolumeAttachReserved,
+		}
+		reserved := SampleAttachments[0]
+		reserved.Status = model.VolumeAttachReserved
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").
+			Return(&volume, nil)
+		mockClient.On("UpdateStatus", c.NewAdminContext(), &volume, model.VolumeReserved).Return(nil)
+		mockClient.On("CreateVolumeAttachment", c.NewAdminContext(), in).Return(&reserved, nil)
+		db.C = mockClient
+
+		body := []byte(`{"volumeId": "bd5b12a8-a101-11e7-941e-d77981b584d8"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/block/attachments", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.VolumeAttachmentSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 202)
+		assertTestResult(t, output.Status, model.VolumeAttachReserved)
+	})
+
+	t.Run("Should return 400 if the volume is already attached without multiattach", func(t *testing.T) {
+		volume := SampleVolumes[0]
+		volume.Status = model.VolumeInUse
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").
+			Return(&volume, nil)
+		db.C = mockClient
+
+		body := []byte(`{"volumeId": "bd5b12a8-a101-11e7-941e-d77981b584d8"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/block/attachments", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestFinalizeVolumeAttachment(t *testing.T) {
+
+	t.Run("Should return 409 if the attachment is not reserved", func(t *testing.T) {
+		attachment := SampleAttachments[0]
+		attachment.Status = model.VolumeAttachAvailable
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolumeAttachment", c.NewAdminContext(), "f2dda3d2-bf79-11e7-8665-f750b088f63e").
+			Return(&attachment, nil)
+		db.C = mockClient
+
+		body := []byte(`{"hostId": "202964b5-8e73-46fd-b41b-a8e403f3c30b"}`)
+		r, _ := http.NewRequest("POST",
+			"/v1beta/block/attachments/f2dda3d2-bf79-11e7-8665-f750b088f63e/finalize", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+
+	t.Run("Should return 400 if the target host is missing", func(t *testing.T) {
+		attachment := SampleAttachments[0]
+		attachment.Status = model.VolumeAttachReserved
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolumeAttachment", c.NewAdminContext(), "f2dda3d2-bf79-11e7-8665-f750b088f63e").
+			Return(&attachment, nil)
+		db.C = mockClient
+
+		body := []byte(`{}`)
+		r, _ := http.NewRequest("POST",
+			"/v1beta/block/attachments/f2dda3d2-bf79-11e7-8665-f750b088f63e/finalize", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestDetachAllVolumeAttachments(t *testing.T) {
+
+	t.Run("Should return 202 and dispatch every connected attachment", func(t *testing.T) {
+		var attachment = SampleAttachments[0]
+		var volume = SampleVolumes[0]
+		var host = SampleHosts[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").
+			Return(&volume, nil)
+		mockClient.On("ListVolumeAttachments", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").
+			Return([]*model.VolumeAttachmentSpec{&attachment}, nil)
+		deleting := attachment
+		deleting.Status = model.VolumeAttachDeleting
+		mockClient.On("UpdateVolumeAttachment", c.NewAdminContext(), "f2dda3d2-bf79-11e7-8665-f750b088f63e", &deleting).
+			Return(&deleting, nil)
+		mockClient.On("GetHost", c.NewAdminContext(), "202964b5-8e73-46fd-b41b-a8e403f3c30b").
+			Return(&host, nil)
+		task := &model.CascadeTaskSpec{BaseModel: &model.BaseModel{}, ResourceType: "volume",
+			ResourceId: "bd5b12a8-a101-11e7-941e-d77981b584d8", Action: "detachAll",
+			Status: model.CascadeTaskRunning, TotalItems: 1}
+		mockClient.On("ListCascadeTasks", c.NewAdminContext()).Return(nil, nil)
+		mockClient.On("CreateCascadeTask", c.NewAdminContext(), task).Return(task, nil)
+		mockClient.On("UpdateCascadeTask", c.NewAdminContext(), task).Return(task, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/detach-all", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output []*model.VolumeAttachmentSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 202)
+		assertTestResult(t, len(output), 1)
+		assertTestResult(t, output[0].Status, model.VolumeAttachDeleting)
+	})
+
+	t.Run("Should return 202 and drop a reserved attachment without dispatching", func(t *testing.T) {
+		reserved := SampleAttachments[0]
+		reserved.Status = model.VolumeAttachReserved
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").
+			Return(&SampleVolumes[0], nil)
+		mockClient.On("ListVolumeAttachments", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").
+			Return([]*model.VolumeAttachmentSpec{&reserved}, nil)
+		mockClient.On("DeleteVolumeAttachment", c.NewAdminContext(), "f2dda3d2-bf79-11e7-8665-f750b088f63e").
+			Return(nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/detach-all", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output []*model.VolumeAttachmentSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 202)
+		assertTestResult(t, len(output), 0)
+	})
+
+	t.Run("Should return 404 if the volume does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").
+			Return(nil, errors.New("specified volume(bd5b12a8-a101-11e7-941e-d77981b584d8) can't find"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/detach-all", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
 }
@@ -0,0 +1,530 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/controllerclient"
+	"github.com/sodafoundation/api/pkg/api/policy"
+	"github.com/sodafoundation/api/pkg/api/util"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	pb "github.com/sodafoundation/api/pkg/model/proto"
+	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+func NewBackupTargetPortal() *BackupTargetPortal {
+	return &BackupTargetPortal{}
+}
+
+type BackupTargetPortal struct {
+	BasePortal
+}
+
+// maskBackupTarget blanks the secret key before a backup target is
+// serialized. The secret is only accepted as request input.
+func maskBackupTarget(target *model.BackupTargetSpec) *model.BackupTargetSpec {
+	masked := *target
+	masked.SecretKey = ""
+	return &masked
+}
+
+func (b *BackupTargetPortal) CreateBackupTarget() {
+	if !policy.Authorize(b.Ctx, "backup_target:create") {
+		return
+	}
+	ctx := c.GetContext(b.Ctx)
+
+	var target = model.BackupTargetSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(b.Ctx.Request.Body).Decode(&target); err != nil {
+		errMsg := fmt.Sprintf("parse backup target request body failed: %s", err.Error())
+		b.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if target.Name == "" {
+		errMsg := "create backup target failed: name must be specified"
+		b.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if !utils.Contained(target.Type, model.BackupTargetTypes) {
+		errMsg := fmt.Sprintf("invalid backup target type %s, supported types are %v", target.Type, model.BackupTargetTypes)
+		b.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if target.Endpoint == "" || target.Bucket == "" {
+		errMsg := "create backup target failed: endpoint and bucket must be specified"
+		b.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	result, err := db.C.CreateBackupTarget(ctx, &target)
+	if err != nil {
+		errMsg := fmt.Sprintf("create backup target failed: %s", err.Error())
+		b.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(maskBackupTarget(result))
+	b.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (b *BackupTargetPortal) ListBackupTargets() {
+	if !policy.Authorize(b.Ctx, "backup_target:list") {
+		return
+	}
+	ctx := c.GetContext(b.Ctx)
+
+	result, err := db.C.ListBackupTargets(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list backup targets failed: %s", err.Error())
+		b.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	var masked = []*model.BackupTargetSpec{}
+	for _, target := range result {
+		masked = append(masked, maskBackupTarget(target))
+	}
+	b.SuccessHandleList(StatusOK, masked)
+	return
+}
+
+func (b *BackupTargetPortal) GetBackupTarget() {
+	if !policy.Authorize(b.Ctx, "backup_target:get") {
+		return
+	}
+	id := b.Ctx.Input.Param(":targetId")
+	ctx := c.GetContext(b.Ctx)
+
+	result, err := db.C.GetBackupTarget(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("backup target %s not found: %s", id, err.Error())
+		b.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(maskBackupTarget(result))
+	b.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (b *BackupTargetPortal) UpdateBackupTarget() {
+	if !policy.Authorize(b.Ctx, "backup_target:update") {
+		return
+	}
+	id := b.Ctx.Input.Param(":targetId")
+	ctx := c.GetContext(b.Ctx)
+
+	var target = model.BackupTargetSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(b.Ctx.Request.Body).Decode(&target); err != nil {
+		errMsg := fmt.Sprintf("parse backup target request body failed: %s", err.Error())
+		b.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if target.Type != "" {
+		errMsg := "the type of a backup target can not be updated"
+		b.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	result, err := db.C.UpdateBackupTarget(ctx, id, &target)
+	if err != nil {
+		errMsg := fmt.Sprintf("update backup target failed: %s", err.Error())
+		b.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(maskBackupTarget(result))
+	b.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (b *BackupTargetPortal) DeleteBackupTarget() {
+	if !policy.Authorize(b.Ctx, "backup_target:delete") {
+		return
+	}
+	id := b.Ctx.Input.Param(":targetId")
+	ctx := c.GetContext(b.Ctx)
+
+	target, err := db.C.GetBackupTarget(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("backup target %s not found: %s", id, err.Error())
+		b.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	// A target that still holds backups can not be removed, the backups
+	// would become unrestorable.
+	backups, err := db.C.ListFileShareSnapshotBackups(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list fileshare snapshot backups failed: %s", err.Error())
+		b.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	for _, backup := range backups {
+		if backup.TargetId == target.Id {
+			errMsg := fmt.Sprintf("backup target %s is still referenced by backup %s", id, backup.Id)
+			b.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+
+	if err := db.C.DeleteBackupTarget(ctx, target.Id); err != nil {
+		errMsg := fmt.Sprintf("delete backup target failed: %s", err.Error())
+		b.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	b.SuccessHandle(StatusOK, nil)
+	return
+}
+
+// BackupFileShareSnapshot backs up a fileshare snapshot to an object storage
+// backup target and tracks the backup in a record of its own.
+func (f *FileShareSnapshotPortal) BackupFileShareSnapshot() {
+	if !policy.Authorize(f.Ctx, "snapshot:backup") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	id := f.Ctx.Input.Param(":snapshotId")
+
+	var backup = model.FileShareSnapshotBackupSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(f.Ctx.Request.Body).Decode(&backup); err != nil {
+		errMsg := fmt.Sprintf("parse fileshare snapshot backup request body failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if backup.TargetId == "" {
+		errMsg := "backup fileshare snapshot failed: targetId must be specified"
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := util.ValidateMaxBandwidth(backup.MaxBandwidthMBps); err != nil {
+		errMsg := fmt.Sprintf("backup fileshare snapshot failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	snapshot, err := db.C.GetFileShareSnapshot(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare snapshot %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if snapshot.Status != model.FileShareSnapAvailable {
+		errMsg := fmt.Sprintf("only fileshare snapshot with status available can be backed up, the status of snapshot %s is %s", id, snapshot.Status)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	target, err := db.C.GetBackupTarget(ctx, backup.TargetId)
+	if err != nil {
+		errMsg := fmt.Sprintf("backup target (%s) not found: %v", backup.TargetId, err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	prf, err := db.C.GetProfile(ctx, snapshot.ProfileId)
+	if err != nil {
+		errMsg := fmt.Sprintf("profile (%s) not found: %v", snapshot.ProfileId, err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// An incremental backup needs an earlier backup of the same fileshare on
+	// the same target as its base. Without one, or when the backend can not
+	// back up incrementally, a full backup is taken instead.
+	if backup.Incremental {
+		backup.BaseBackupId = findBaseBackup(ctx, snapshot.FileShareId, target.Id)
+		if backup.BaseBackupId == "" {
+			backup.Incremental = false
+		}
+	}
+
+	backup.SnapshotId = snapshot.Id
+	backup.FileShareId = snapshot.FileShareId
+	backup.UserId = ctx.UserId
+	backup.Status = model.FileShareSnapBackupBackingUp
+
+	// NOTE:It will create a tracking record into the database and initialize
+	// its status as "backingUp". It will not wait for the real backup to
+	// complete and will return result immediately.
+	result, err := db.C.CreateFileShareSnapshotBackup(ctx, &backup)
+	if err != nil {
+		errMsg := fmt.Sprintf("backup fileshare snapshot failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	f.SuccessHandle(StatusAccepted, body)
+
+	// NOTE:The real fileshare snapshot backup process.
+	// Backup request is sent to the Dock. Dock will update the tracking
+	// record status to "available" and fill in the location once the backup
+	// completes.
+	if err := f.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+
+	opt := &pb.BackupFileShareSnapshotOpts{
+		Id:             snapshot.Id,
+		FileshareId:    snapshot.FileShareId,
+		TargetType:     target.Type,
+		TargetEndpoint: target.Endpoint,
+		TargetRegion:   target.Region,
+		TargetBucket:   target.Bucket,
+		AccessKey:      target.AccessKey,
+		SecretKey:      target.SecretKey,
+		Incremental:    result.Incremental,
+		BaseBackupId:   result.BaseBackupId,
+		TrackingId:     result.Id,
+		Context:        ctx.ToJson(),
+		Profile:        prf.ToJson(),
+		Metadata:       snapshot.Metadata,
+		// the dock throttles the transfer to this limit, 0 is unlimited
+		MaxBandwidthMBps: result.MaxBandwidthMBps,
+	}
+	response, err := f.CtrClient.BackupFileShareSnapshot(context.Background(), opt)
+	if err != nil {
+		log.Error("backup file share snapshot failed in controller service:", err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to backup file share snapshot in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+		return
+	}
+
+	return
+}
+
+// findBaseBackup returns the id of the newest available backup of the given
+// fileshare on the given target, or an empty string when there is none.
+func findBaseBackup(ctx *c.Context, fileShareId, targetId string) string {
+	backups, err := db.C.ListFileShareSnapshotBackups(ctx)
+	if err != nil {
+		log.Error("list fileshare snapshot backups failed:", err)
+		return ""
+	}
+	var base *model.FileShareSnapshotBackupSpec
+	for _, backup := range backups {
+		if backup.FileShareId != fileShareId || backup.TargetId != targetId {
+			continue
+		}
+		if backup.Status != model.FileShareSnapBackupAvailable {
+			continue
+		}
+		if base == nil || backup.CreatedAt > base.CreatedAt {
+			base = backup
+		}
+	}
+	if base == nil {
+		return ""
+	}
+	return base.Id
+}
+
+func NewFileShareSnapshotBackupPortal() *FileShareSnapshotBackupPortal {
+	return &FileShareSnapshotBackupPortal{
+		CtrClient: client.NewClient(),
+	}
+}
+
+type FileShareSnapshotBackupPortal struct {
+	BasePortal
+
+	CtrClient client.Client
+}
+
+func (f *FileShareSnapshotBackupPortal) ListFileShareSnapshotBackups() {
+	if !policy.Authorize(f.Ctx, "backup:list") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+
+	result, err := db.C.ListFileShareSnapshotBackups(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list fileshare snapshot backups failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	f.SuccessHandleList(StatusOK, result)
+	return
+}
+
+func (f *FileShareSnapshotBackupPortal) GetFileShareSnapshotBackup() {
+	if !policy.Authorize(f.Ctx, "backup:get") {
+		return
+	}
+	id := f.Ctx.Input.Param(":backupId")
+	ctx := c.GetContext(f.Ctx)
+
+	result, err := db.C.GetFileShareSnapshotBackup(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare snapshot backup %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	f.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (f *FileShareSnapshotBackupPortal) DeleteFileShareSnapshotBackup() {
+	if !policy.Authorize(f.Ctx, "backup:delete") {
+		return
+	}
+	id := f.Ctx.Input.Param(":backupId")
+	ctx := c.GetContext(f.Ctx)
+
+	backup, err := db.C.GetFileShareSnapshotBackup(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare snapshot backup %s not found: %s", id, err.Error())
+		f.DeleteNotFoundHandle(errMsg)
+		return
+	}
+	if backup.Status == model.FileShareSnapBackupBackingUp || backup.Status == model.FileShareSnapBackupRestoring {
+		errMsg := fmt.Sprintf("fileshare snapshot backup %s can not be deleted while its status is %s", id, backup.Status)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if err := db.C.DeleteFileShareSnapshotBackup(ctx, backup.Id); err != nil {
+		errMsg := fmt.Sprintf("delete fileshare snapshot backup failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	f.SuccessHandle(StatusOK, nil)
+	return
+}
+
+// RestoreFileShareSnapshotBackup restores a fileshare snapshot backup into
+// the fileshare it was taken from.
+func (f *FileShareSnapshotBackupPortal) RestoreFileShareSnapshotBackup() {
+	if !policy.Authorize(f.Ctx, "backup:restore") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	id := f.Ctx.Input.Param(":backupId")
+
+	backup, err := db.C.GetFileShareSnapshotBackup(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare snapshot backup %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if backup.Status != model.FileShareSnapBackupAvailable {
+		errMsg := fmt.Sprintf("only fileshare snapshot backup with status available can be restored, the status of backup %s is %s", id, backup.Status)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	target, err := db.C.GetBackupTarget(ctx, backup.TargetId)
+	if err != nil {
+		errMsg := fmt.Sprintf("backup target (%s) not found: %v", backup.TargetId, err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	fileshare, err := db.C.GetFileShare(ctx, backup.FileShareId)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare (%s) not found: %v", backup.FileShareId, err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	prf, err := db.C.GetProfile(ctx, fileshare.ProfileId)
+	if err != nil {
+		errMsg := fmt.Sprintf("profile (%s) not found: %v", fileshare.ProfileId, err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	result, err := db.C.UpdateFileShareSnapshotBackup(ctx, backup.Id,
+		&model.FileShareSnapshotBackupSpec{Status: model.FileShareSnapBackupRestoring})
+	if err != nil {
+		errMsg := fmt.Sprintf("restore fileshare snapshot backup failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	f.SuccessHandle(StatusAccepted, body)
+
+	// NOTE:The real fileshare snapshot backup restore process.
+	// Restore request is sent to the Dock. Dock will update the backup
+	// status back to "available" after the restore completes.
+	if err := f.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+
+	opt := &pb.RestoreFileShareSnapshotBackupOpts{
+		Id:             backup.Id,
+		SnapshotId:     backup.SnapshotId,
+		FileshareId:    backup.FileShareId,
+		TargetType:     target.Type,
+		TargetEndpoint: target.Endpoint,
+		TargetRegion:   target.Region,
+		TargetBucket:   target.Bucket,
+		AccessKey:      target.AccessKey,
+		SecretKey:      target.SecretKey,
+		Location:       backup.Location,
+		Context:        ctx.ToJson(),
+		Profile:        prf.ToJson(),
+		Metadata:       fileshare.Metadata,
+	}
+	response, err := f.CtrClient.RestoreFileShareSnapshotBackup(context.Background(), opt)
+	if err != nil {
+		log.Error("restore file share snapshot backup failed in controller service:", err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to restore file share snapshot backup in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+		return
+	}
+
+	return
+}
@@ -0,0 +1,181 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+//                      Prepare for mock server                               //
+////////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/file/backupTargets", &BackupTargetPortal{},
+		"post:CreateBackupTarget;get:ListBackupTargets")
+	beego.Router("/v1beta/file/backupTargets/:targetId", &BackupTargetPortal{},
+		"get:GetBackupTarget;put:UpdateBackupTarget;delete:DeleteBackupTarget")
+	beego.Router("/v1beta/file/snapshots/:snapshotId/backup", &FileShareSnapshotPortal{},
+		"post:BackupFileShareSnapshot")
+	beego.Router("/v1beta/file/backups", &FileShareSnapshotBackupPortal{},
+		"get:ListFileShareSnapshotBackups")
+	beego.Router("/v1beta/file/backups/:backupId", &FileShareSnapshotBackupPortal{},
+		"get:GetFileShareSnapshotBackup;delete:DeleteFileShareSnapshotBackup")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                          Tests for backup target                           //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestCreateBackupTarget(t *testing.T) {
+
+	t.Run("Should return 400 if the target type is not supported", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"name": "sample-backup-target", "type": "ftp"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/file/backupTargets", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestGetBackupTarget(t *testing.T) {
+
+	t.Run("Should return 200 and never serialize the secret key", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetBackupTarget", c.NewAdminContext(), "2f9c0a04-66ef-11e7-ade2-43158893e017").Return(&SampleBackupTargets[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/file/backupTargets/2f9c0a04-66ef-11e7-ade2-43158893e017", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.BackupTargetSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Bucket, SampleBackupTargets[0].Bucket)
+		assertTestResult(t, output.SecretKey, "")
+	})
+
+	t.Run("Should return 404 if the backup target does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetBackupTarget", c.NewAdminContext(), "2f9c0a04-66ef-11e7-ade2-43158893e017").Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/file/backupTargets/2f9c0a04-66ef-11e7-ade2-43158893e017", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                     Tests for fileshare snapshot backup                    //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestBackupFileShareSnapshot(t *testing.T) {
+
+	t.Run("Should return 400 if the target id is missing", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"description": "nightly backup"}`)
+		r, _ := http.NewRequest("POST",
+			"/v1beta/file/snapshots/3769855c-a102-11e7-b772-17b880d2f537/backup", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestListFileShareSnapshotBackups(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		var sampleBackups = []*model.FileShareSnapshotBackupSpec{&SampleFileShareSnapshotBackups[0]}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListFileShareSnapshotBackups", c.NewAdminContext()).Return(sampleBackups, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/file/backups", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.FileShareSnapshotBackupSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, sampleBackups)
+	})
+}
+
+func TestDeleteFileShareSnapshotBackup(t *testing.T) {
+
+	t.Run("Should return 400 if the backup is still being written", func(t *testing.T) {
+		var backup = SampleFileShareSnapshotBackups[0]
+		backup.Status = model.FileShareSnapBackupBackingUp
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShareSnapshotBackup", c.NewAdminContext(), backup.Id).Return(&backup, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE", "/v1beta/file/backups/"+backup.Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShareSnapshotBackup", c.NewAdminContext(), SampleFileShareSnapshotBackups[0].Id).Return(&SampleFileShareSnapshotBackups[0], nil)
+		mockClient.On("DeleteFileShareSnapshotBackup", c.NewAdminContext(), SampleFileShareSnapshotBackups[0].Id).Return(nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE", "/v1beta/file/backups/"+SampleFileShareSnapshotBackups[0].Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 200)
+	})
+}
@@ -15,20 +15,34 @@
 package controllers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/astaxie/beego"
 	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/filter/latency"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils/constants"
 )
 
 const (
-	StatusOK       = http.StatusOK
-	StatusAccepted = http.StatusAccepted
+	StatusOK        = http.StatusOK
+	StatusAccepted  = http.StatusAccepted
+	StatusNoContent = http.StatusNoContent
 )
 
+// NDJsonContentType is the media type clients put into the Accept header to
+// get a list response streamed one resource per line.
+const NDJsonContentType = "application/x-ndjson"
+
 type BasePortal struct {
 	beego.Controller
 }
@@ -84,6 +98,14 @@ func (b *BasePortal) ErrorHandle(errType int, errMsg string) {
 		errBody = model.ErrorForbiddenStatus(errMsg)
 	case model.ErrorNotFound:
 		errBody = model.ErrorNotFoundStatus(errMsg)
+	case model.ErrorConflict:
+		errBody = model.ErrorConflictStatus(errMsg)
+	case model.ErrorLocked:
+		errBody = model.ErrorLockedStatus(errMsg)
+	case model.ErrorEntityTooLarge:
+		errBody = model.ErrorEntityTooLargeStatus(errMsg)
+	case model.ErrorTooManyRequests:
+		errBody = model.ErrorTooManyRequestsStatus(errMsg)
 	case model.ErrorInternalServer:
 		errBody = model.ErrorInternalServerStatus(errMsg)
 	default:
@@ -92,14 +114,146 @@ func (b *BasePortal) ErrorHandle(errType int, errMsg string) {
 
 	b.Ctx.Output.SetStatus(errType)
 	b.Ctx.Output.Header("Content-Type", "application/json; charset=utf-8")
+	if b.envelopeRequested() {
+		// Clients that opted into the envelope get errors wrapped the same
+		// way, the status code still tells success and failure apart.
+		errBody = b.envelope(errBody)
+	}
 	b.Ctx.Output.Body(errBody)
 	log.Error(errMsg)
 }
 
+// DeleteNotFoundHandle writes the response for a delete request whose
+// target does not exist. With the ignoreNotFound=true query parameter the
+// request succeeds with 204 instead of 404, so automation that retries
+// deletes can treat an already deleted resource as success.
+func (b *BasePortal) DeleteNotFoundHandle(errMsg string) {
+	if b.Ctx.Input.Query("ignoreNotFound") == "true" {
+		log.V(5).Infof("ignoring missing resource on delete: %s", errMsg)
+		b.SuccessHandle(StatusNoContent, nil)
+		return
+	}
+	b.ErrorHandle(model.ErrorNotFound, errMsg)
+}
+
+// listTombstones handles the deleted=true query parameter of a list
+// endpoint. It returns true when it wrote the response: the tombstone
+// records of deleted resources of the given type, or an error. Tombstones
+// may belong to any tenant, so they are only listable by administrators.
+func (b *BasePortal) listTombstones(m map[string][]string, resourceType string) bool {
+	vs, ok := m["deleted"]
+	if !ok || len(vs) == 0 {
+		return false
+	}
+	delete(m, "deleted")
+	deleted, err := strconv.ParseBool(vs[0])
+	if err != nil {
+		errMsg := fmt.Sprintf("invalid deleted parameter: %s", err.Error())
+		b.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return true
+	}
+	if !deleted {
+		return false
+	}
+	ctx := c.GetContext(b.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "listing deleted resources is only available to administrators"
+		b.ErrorHandle(model.ErrorForbidden, errMsg)
+		return true
+	}
+	tombstones, err := db.C.ListTombstones(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list deleted resources failed: %s", err.Error())
+		b.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return true
+	}
+	var result = []*model.TombstoneSpec{}
+	for _, tombstone := range tombstones {
+		if tombstone.ResourceType == resourceType {
+			result = append(result, tombstone)
+		}
+	}
+	b.SuccessHandleList(StatusOK, result)
+	return true
+}
+
+// responseEnvelope wraps a response body with request metadata, for
+// clients on platforms that can not read response headers.
+type responseEnvelope struct {
+	RequestId  string          `json:"requestId,omitempty"`
+	ApiVersion string          `json:"apiVersion"`
+	DurationMs float64         `json:"durationMs"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// envelopeRequested tells whether the client opted into the response
+// envelope, either through the envelope=true query parameter or through an
+// Accept header carrying the envelope profile.
+func (b *BasePortal) envelopeRequested() bool {
+	if b.Ctx.Input.Query("envelope") == "true" {
+		return true
+	}
+	return strings.Contains(b.Ctx.Input.Header("Accept"), "profile=envelope")
+}
+
+// envelope wraps a marshaled response body with the request metadata.
+// Streamed ndjson lists bypass SuccessHandle and are never enveloped.
+func (b *BasePortal) envelope(body []byte) []byte {
+	env := responseEnvelope{
+		RequestId:  c.GetContext(b.Ctx).RequestId,
+		ApiVersion: constants.APIVersion,
+		Data:       body,
+	}
+	if start, ok := b.Ctx.Input.GetData(c.RequestStartKey).(time.Time); ok {
+		env.DurationMs = float64(time.Since(start)) / float64(time.Millisecond)
+	}
+	out, err := json.Marshal(env)
+	if err != nil {
+		log.Error("marshal response envelope failed:", err)
+		return body
+	}
+	return out
+}
+
 func (b *BasePortal) SuccessHandle(status int, body []byte) {
 	b.Ctx.Output.SetStatus(status)
 	b.Ctx.Output.Header("Content-Type", "application/json; charset=utf-8")
 	if body != nil {
+		if b.envelopeRequested() {
+			body = b.envelope(body)
+		}
 		b.Ctx.Output.Body(body)
 	}
 }
+
+// SuccessHandleList writes a list response. By default the whole list is
+// marshaled into one json array. When the client accepts
+// application/x-ndjson every resource is encoded on its own line and flushed
+// as it is written, so very large result sets are never buffered in memory.
+func (b *BasePortal) SuccessHandleList(status int, list interface{}) {
+	start := time.Now()
+	defer func() { latency.RecordPhase(b.Ctx, "serialization", time.Since(start)) }()
+
+	if !strings.Contains(b.Ctx.Input.Header("Accept"), NDJsonContentType) {
+		body, err := json.Marshal(list)
+		if err != nil {
+			errMsg := "marshal listed result failed: " + err.Error()
+			b.ErrorHandle(model.ErrorInternalServer, errMsg)
+			return
+		}
+		b.SuccessHandle(status, body)
+		return
+	}
+
+	b.Ctx.Output.Header("Content-Type", NDJsonContentType+"; charset=utf-8")
+	b.Ctx.ResponseWriter.WriteHeader(status)
+	enc := json.NewEncoder(b.Ctx.ResponseWriter)
+	v := reflect.ValueOf(list)
+	for i := 0; i < v.Len(); i++ {
+		if err := enc.Encode(v.Index(i).Interface()); err != nil {
+			log.Error("stream listed result failed:", err)
+			return
+		}
+		b.Ctx.ResponseWriter.Flush()
+	}
+}
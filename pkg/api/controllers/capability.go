@@ -0,0 +1,102 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+func NewCapabilityPortal() *CapabilityPortal {
+	return &CapabilityPortal{}
+}
+
+// CapabilityPortal aggregates the features supported by the current
+// deployment into one converged discovery document.
+type CapabilityPortal struct {
+	BasePortal
+}
+
+func (p *CapabilityPortal) GetCapabilities() {
+	if !policy.Authorize(p.Ctx, "capability:get") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+
+	docks, err := db.C.ListDocks(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("get capabilities failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	pools, err := db.C.ListPools(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("get capabilities failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	var capability = model.CapabilitySpec{
+		StorageTypes:      []string{},
+		Protocols:         []string{},
+		AvailabilityZones: []string{},
+		Snapshot:          len(docks) > 0,
+		AuthStrategy:      CONF.OsdsApiServer.AuthStrategy,
+		HTTPSEnabled:      CONF.OsdsApiServer.HTTPSEnabled,
+	}
+	// Count pools per storage type, more than one pool of the same type
+	// enables migration and retype.
+	poolsPerType := map[string]int{}
+	for _, pool := range pools {
+		if pool.StorageType != "" {
+			capability.StorageTypes = append(capability.StorageTypes, pool.StorageType)
+			poolsPerType[pool.StorageType]++
+		}
+		if pool.AvailabilityZone != "" {
+			capability.AvailabilityZones = append(capability.AvailabilityZones, pool.AvailabilityZone)
+		}
+		if protocol := pool.Extras.IOConnectivity.AccessProtocol; protocol != "" {
+			capability.Protocols = append(capability.Protocols, protocol)
+		}
+		if pool.ReplicationType != "" {
+			capability.Replication = true
+		}
+		if pool.Extras.IOConnectivity.MaxIOPS > 0 || pool.Extras.IOConnectivity.MaxBWS > 0 {
+			capability.QoS = true
+		}
+	}
+	capability.StorageTypes = utils.RvRepElement(capability.StorageTypes)
+	capability.Protocols = utils.RvRepElement(capability.Protocols)
+	capability.AvailabilityZones = utils.RvRepElement(capability.AvailabilityZones)
+	for _, count := range poolsPerType {
+		if count > 1 {
+			capability.Migration = true
+			capability.Retype = true
+		}
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(&capability)
+	p.SuccessHandle(StatusOK, body)
+
+	return
+}
@@ -0,0 +1,73 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func init() {
+	beego.Router("/v1beta/capabilities", NewCapabilityPortal(), "get:GetCapabilities")
+}
+
+func TestGetCapabilities(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		var sampleDocks = []*model.DockSpec{&SampleDocks[0]}
+		var samplePools = []*model.StoragePoolSpec{&SamplePools[0], &SamplePools[1]}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListDocks", c.NewAdminContext()).Return(sampleDocks, nil)
+		mockClient.On("ListPools", c.NewAdminContext()).Return(samplePools, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/capabilities", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.CapabilitySpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Snapshot, true)
+	})
+
+	t.Run("Should return 500 if list pools fails", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListDocks", c.NewAdminContext()).Return(nil, nil)
+		mockClient.On("ListPools", c.NewAdminContext()).Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/capabilities", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 500)
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+func NewCatalogPortal() *CatalogPortal {
+	return &CatalogPortal{}
+}
+
+// CatalogPortal serves the service catalog at the root of the api
+// version, like the version endpoints it needs no authentication.
+type CatalogPortal struct {
+	BasePortal
+}
+
+// GetCatalog lists the sub-APIs this deployment routes together with the
+// optional features its configuration enables. Unlike the capabilities
+// endpoint, which aggregates what the registered backends can do, the
+// catalog only reflects the server itself and never touches the db.
+func (p *CatalogPortal) GetCatalog() {
+	tenantPrefix := "/" + constants.APIVersion + "/:tenantId"
+	catalog := model.ServiceCatalogSpec{
+		Name:   constants.APIVersion,
+		Status: "CURRENT",
+		Services: []*model.ServiceEntrySpec{
+			{Name: "block", Prefix: tenantPrefix + "/block"},
+			{Name: "file", Prefix: tenantPrefix + "/file"},
+			{Name: "host", Prefix: tenantPrefix + "/host"},
+			{Name: "metrics", Prefix: tenantPrefix + "/metrics"},
+			{Name: "alert", Prefix: "/" + constants.APIVersion + "/alert"},
+			{Name: "admin", Prefix: "/" + constants.APIVersion + "/admin"},
+		},
+		Features: map[string]bool{
+			// sub-features served on every deployment of this version,
+			// listed so older clients can tell them apart from servers
+			// that predate them
+			"quotas":          true,
+			"defaultTags":     true,
+			"networkPolicies": true,
+			"resourceClasses": true,
+			// features that depend on how this deployment is configured
+			"validationWebhook":      CONF.OsdsApiServer.ValidationWebhookUrl != "",
+			"quotaWebhook":           CONF.OsdsApiServer.QuotaWebhookUrl != "",
+			"snapshotQuiesceWebhook": CONF.OsdsApiServer.SnapshotQuiesceWebhookUrl != "",
+			"dockRegistration":       CONF.OsdsApiServer.DockBootstrapToken != "",
+			"tombstones":             CONF.OsdsApiServer.TombstoneRetention > 0,
+			"poolStalenessTracking":  CONF.OsdsApiServer.PoolStaleThreshold > 0,
+			"consistencyReport":      CONF.OsdsApiServer.ConsistencyCheckInterval > 0,
+			"loadShedding": CONF.OsdsApiServer.ShedDbLatencyThreshold > 0 ||
+				CONF.OsdsApiServer.ShedDbQueueDepthThreshold > 0,
+			"snakeCaseFields": CONF.OsdsApiServer.AcceptSnakeCaseFields,
+		},
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(&catalog)
+	p.SuccessHandle(StatusOK, body)
+
+	return
+}
@@ -0,0 +1,71 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+func init() {
+	beego.Router("/v1beta", NewCatalogPortal(), "get:GetCatalog")
+}
+
+func TestGetCatalog(t *testing.T) {
+
+	t.Run("Should list the routed sub-APIs", func(t *testing.T) {
+		r, _ := http.NewRequest("GET", "/v1beta", nil)
+		w := httptest.NewRecorder()
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.ServiceCatalogSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Name, "v1beta")
+
+		var prefixes = map[string]string{}
+		for _, service := range output.Services {
+			prefixes[service.Name] = service.Prefix
+		}
+		assertTestResult(t, prefixes["block"], "/v1beta/:tenantId/block")
+		assertTestResult(t, prefixes["file"], "/v1beta/:tenantId/file")
+		assertTestResult(t, prefixes["admin"], "/v1beta/admin")
+		assertTestResult(t, output.Features["quotas"], true)
+		assertTestResult(t, output.Features["dockRegistration"], false)
+	})
+
+	t.Run("Should reflect the configured features", func(t *testing.T) {
+		CONF.OsdsApiServer.DockBootstrapToken = "bootstrap-secret"
+		CONF.OsdsApiServer.ValidationWebhookUrl = "http://localhost:8080/validate"
+		defer func() {
+			CONF.OsdsApiServer.DockBootstrapToken = ""
+			CONF.OsdsApiServer.ValidationWebhookUrl = ""
+		}()
+
+		r, _ := http.NewRequest("GET", "/v1beta", nil)
+		w := httptest.NewRecorder()
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.ServiceCatalogSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Features["dockRegistration"], true)
+		assertTestResult(t, output.Features["validationWebhook"], true)
+	})
+}
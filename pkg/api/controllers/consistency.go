@@ -0,0 +1,62 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the admin endpoint serving the report of the
+consistency checker.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+
+	"github.com/sodafoundation/api/pkg/api/consistency"
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewConsistencyPortal() *ConsistencyPortal {
+	return &ConsistencyPortal{}
+}
+
+type ConsistencyPortal struct {
+	BasePortal
+}
+
+// GetConsistencyReport serves the report of the most recent consistency
+// checker run, admin only.
+func (p *ConsistencyPortal) GetConsistencyReport() {
+	if !policy.Authorize(p.Ctx, "consistency:get") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to read the consistency report"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	report := consistency.Latest()
+	if report == nil {
+		errMsg := "no consistency report is available yet, the checker has not completed a run"
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(report)
+	p.SuccessHandle(StatusOK, body)
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	ctx "context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	"github.com/sodafoundation/api/pkg/api/consistency"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	pb "github.com/sodafoundation/api/pkg/model/proto"
+	. "github.com/sodafoundation/api/testutils/collection"
+	ctrtest "github.com/sodafoundation/api/testutils/controller/testing"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func init() {
+	beego.Router("/v1beta/admin/consistency-report", &ConsistencyPortal{}, "get:GetConsistencyReport")
+}
+
+func TestGetConsistencyReport(t *testing.T) {
+
+	t.Run("Should return 404 before the checker has completed a run", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		r, _ := http.NewRequest("GET", "/v1beta/admin/consistency-report", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+
+	t.Run("Should serve the discrepancies of the latest run", func(t *testing.T) {
+		var orphan = SampleVolumes[0]
+		orphan.Status = model.VolumeAvailable
+		orphan.PoolId = "gone-pool"
+		var share = SampleFileShares[0]
+		share.Status = model.FileShareAvailable
+		share.PoolId = SamplePools[0].Id
+
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListPools", c.NewAdminContext()).Return([]*model.StoragePoolSpec{&SamplePools[0]}, nil)
+		mockClient.On("ListVolumes", c.NewAdminContext()).Return([]*model.VolumeSpec{&orphan}, nil)
+		mockClient.On("ListFileShares", c.NewAdminContext()).Return([]*model.FileShareSpec{&share}, nil)
+		db.C = mockClient
+
+		if report := consistency.NewChecker(time.Minute).CheckOnce(); report == nil {
+			t.Fatal("expected the checker to complete a run")
+		}
+
+		r, _ := http.NewRequest("GET", "/v1beta/admin/consistency-report", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.ConsistencyReportSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		if len(output.Discrepancies) != 1 {
+			t.Fatalf("expected 1 discrepancy, got %d", len(output.Discrepancies))
+		}
+		d := output.Discrepancies[0]
+		assertTestResult(t, d.ResourceId, orphan.Id)
+		assertTestResult(t, d.Kind, model.ConsistencyMissingBackendResource)
+	})
+
+	t.Run("Should record backend resources without a database record", func(t *testing.T) {
+		var volume = SampleVolumes[0]
+		volume.Status = model.VolumeAvailable
+		volume.PoolId = SamplePools[0].Id
+
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListPools", c.NewAdminContext()).Return([]*model.StoragePoolSpec{&SamplePools[0]}, nil)
+		mockClient.On("ListVolumes", c.NewAdminContext()).Return([]*model.VolumeSpec{&volume}, nil)
+		mockClient.On("ListFileShares", c.NewAdminContext()).Return([]*model.FileShareSpec{}, nil)
+		db.C = mockClient
+
+		mockCtrClient := new(ctrtest.Client)
+		mockCtrClient.On("Connect", "localhost:50049").Return(nil)
+		mockCtrClient.On("ListBackendResources", ctx.Background(), &pb.ListBackendResourcesOpts{
+			PoolId:  SamplePools[0].Id,
+			DockId:  SamplePools[0].DockId,
+			Context: c.NewAdminContext().ToJson(),
+		}).Return(pb.GenericResponseResult(&model.BackendInventorySpec{
+			PoolId:    SamplePools[0].Id,
+			VolumeIds: []string{volume.Id, "9f1d1a6e-4f3e-11e8-8a5c-977218a83359"},
+		}), nil)
+
+		checker := consistency.NewChecker(time.Minute)
+		checker.CtrClient = mockCtrClient
+		report := checker.CheckOnce()
+		if report == nil {
+			t.Fatal("expected the checker to complete a run")
+		}
+		if len(report.Discrepancies) != 1 {
+			t.Fatalf("expected 1 discrepancy, got %d", len(report.Discrepancies))
+		}
+		d := report.Discrepancies[0]
+		assertTestResult(t, d.ResourceId, "9f1d1a6e-4f3e-11e8-8a5c-977218a83359")
+		assertTestResult(t, d.Kind, model.ConsistencyUnknownBackendResource)
+	})
+
+	t.Run("Should return 403 for non-admin users", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		r, _ := http.NewRequest("GET", "/v1beta/admin/consistency-report", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+}
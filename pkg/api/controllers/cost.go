@@ -0,0 +1,139 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewCostPortal() *CostPortal {
+	return &CostPortal{}
+}
+
+type CostPortal struct {
+	BasePortal
+}
+
+// estimateCost builds the showback estimate of a single resource from its
+// size and the costPerGbMonth of its profile. A missing profile or one
+// without a price yields a zero cost, so unpriced deployments still get a
+// well formed report.
+func estimateCost(ctx *c.Context, resourceType, resourceId, profileId string, size int64) *model.CostEstimateSpec {
+	var estimate = &model.CostEstimateSpec{
+		ResourceId:   resourceId,
+		ResourceType: resourceType,
+		ProfileId:    profileId,
+		Size:         size,
+	}
+	if profileId == "" {
+		return estimate
+	}
+	prf, err := db.C.GetProfile(ctx, profileId)
+	if err != nil {
+		log.Errorf("when getting profile %s for cost estimate of %s %s: %v",
+			profileId, resourceType, resourceId, err)
+		return estimate
+	}
+	estimate.CostPerGbMonth = prf.CostPerGbMonth
+	estimate.CostPerMonth = float64(size) * prf.CostPerGbMonth
+	return estimate
+}
+
+// GetFileShareCostEstimate returns the estimated monthly cost of a single
+// fileshare, priced by the costPerGbMonth of its profile.
+func (p *CostPortal) GetFileShareCostEstimate() {
+	if !policy.Authorize(p.Ctx, "cost:get") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+
+	id := p.Ctx.Input.Param(":fileshareId")
+	fileshare, err := db.C.GetFileShare(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	result := estimateCost(ctx, "fileshare", fileshare.Id, fileshare.ProfileId, fileshare.Size)
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	p.SuccessHandle(StatusOK, body)
+	return
+}
+
+// GetTenantCostEstimate aggregates the estimated monthly cost of every
+// volume and fileshare of the tenant in the url, for showback reporting.
+// A tenant can read its own report, administrators any.
+func (p *CostPortal) GetTenantCostEstimate() {
+	if !policy.Authorize(p.Ctx, "cost:get") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	tenantId := p.Ctx.Input.Param(":tenantId")
+
+	if !ctx.IsAdmin && tenantId != ctx.TenantId {
+		errMsg := fmt.Sprintf("only administrators can read the cost report of another tenant")
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+	tenantCtx := c.NewInternalTenantContext(tenantId, ctx.UserId)
+
+	volumes, err := db.C.ListVolumes(tenantCtx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list volumes of tenant %s failed: %s", tenantId, err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	fileshares, err := db.C.ListFileShares(tenantCtx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list fileshares of tenant %s failed: %s", tenantId, err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	var result = &model.TenantCostEstimateSpec{
+		TenantId:  tenantId,
+		Resources: []*model.CostEstimateSpec{},
+	}
+	for _, volume := range volumes {
+		estimate := estimateCost(tenantCtx, "volume", volume.Id, volume.ProfileId, volume.Size)
+		result.Resources = append(result.Resources, estimate)
+		result.CostPerMonth += estimate.CostPerMonth
+	}
+	for _, fileshare := range fileshares {
+		estimate := estimateCost(tenantCtx, "fileshare", fileshare.Id, fileshare.ProfileId, fileshare.Size)
+		result.Resources = append(result.Resources, estimate)
+		result.CostPerMonth += estimate.CostPerMonth
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	p.SuccessHandle(StatusOK, body)
+	return
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+// //////////////////////////////////////////////////////////////////////////////
+//
+//	Prepare for mock server                               //
+//
+// //////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/:tenantId/file/shares/:fileshareId/cost-estimate", &CostPortal{},
+		"get:GetFileShareCostEstimate")
+	beego.Router("/v1beta/:tenantId/cost-estimate", &CostPortal{},
+		"get:GetTenantCostEstimate")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                          Tests for cost estimate                           //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestGetFileShareCostEstimate(t *testing.T) {
+
+	t.Run("Should return 200 with the cost derived from the profile", func(t *testing.T) {
+		profile := SampleProfiles[0]
+		profile.CostPerGbMonth = 2.5
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").
+			Return(&SampleFileShares[0], nil)
+		mockClient.On("GetProfile", c.NewAdminContext(), SampleFileShares[0].ProfileId).
+			Return(&profile, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET",
+			"/v1beta/e93b4c0934da416eb9c8d120c5d04d96/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/cost-estimate", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output model.CostEstimateSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.CostPerGbMonth, 2.5)
+		assertTestResult(t, output.CostPerMonth, 2.5)
+	})
+
+	t.Run("Should return 404 if the fileshare does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").
+			Return(nil, errors.New("specified fileshare can not be found"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET",
+			"/v1beta/e93b4c0934da416eb9c8d120c5d04d96/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/cost-estimate", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
+
+func TestGetTenantCostEstimate(t *testing.T) {
+
+	t.Run("Should return 200 with the aggregated tenant cost", func(t *testing.T) {
+		profile := SampleProfiles[0]
+		profile.CostPerGbMonth = 2
+		volumes := []*model.VolumeSpec{
+			{BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+				ProfileId: profile.Id, Size: 10},
+		}
+		fileshares := []*model.FileShareSpec{
+			{BaseModel: &model.BaseModel{Id: "d2975ebe-d82c-430f-b28e-f373746a71ca"},
+				ProfileId: profile.Id, Size: 5},
+		}
+		tenantCtx := c.NewInternalTenantContext("e93b4c0934da416eb9c8d120c5d04d96", "")
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListVolumes", tenantCtx).Return(volumes, nil)
+		mockClient.On("ListFileShares", tenantCtx).Return(fileshares, nil)
+		mockClient.On("GetProfile", tenantCtx, profile.Id).Return(&profile, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/cost-estimate", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output model.TenantCostEstimateSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.CostPerMonth, float64(30))
+		assertTestResult(t, len(output.Resources), 2)
+	})
+
+	t.Run("Should return 403 if a tenant reads the report of another tenant", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/cost-estimate", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "x7a91c0a2ab41b9cf76f71a91b"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+}
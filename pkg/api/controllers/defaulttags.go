@@ -0,0 +1,135 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the endpoints managing the default tags of a
+tenant, merged into the metadata of every resource the tenant creates.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewDefaultTagsPortal() *DefaultTagsPortal {
+	return &DefaultTagsPortal{}
+}
+
+type DefaultTagsPortal struct {
+	BasePortal
+}
+
+// GetDefaultTags returns the default tags of the tenant in the url. A
+// tenant can read its own default tags, administrators any.
+func (d *DefaultTagsPortal) GetDefaultTags() {
+	if !policy.Authorize(d.Ctx, "default_tags:get") {
+		return
+	}
+	ctx := c.GetContext(d.Ctx)
+	tenantId := d.Ctx.Input.Param(":tenantId")
+
+	if !ctx.IsAdmin && tenantId != ctx.TenantId {
+		errMsg := fmt.Sprintf("only administrators can read the default tags of another tenant")
+		d.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	tags, err := db.C.GetDefaultTags(ctx, tenantId)
+	if err != nil {
+		errMsg := fmt.Sprintf("default tags of tenant %s not found: %s", tenantId, err.Error())
+		d.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(tags)
+	d.SuccessHandle(StatusOK, body)
+	return
+}
+
+// SetDefaultTags creates or replaces the default tags of the tenant in the
+// url.
+func (d *DefaultTagsPortal) SetDefaultTags() {
+	if !policy.Authorize(d.Ctx, "default_tags:set") {
+		return
+	}
+	ctx := c.GetContext(d.Ctx)
+	tenantId := d.Ctx.Input.Param(":tenantId")
+
+	var tags = model.DefaultTagsSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(d.Ctx.Request.Body).Decode(&tags); err != nil {
+		errMsg := fmt.Sprintf("parse default tags request body failed: %s", err.Error())
+		d.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if len(tags.Tags) == 0 {
+		errMsg := "default tags can not be empty, delete the record to remove them"
+		d.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	for k := range tags.Tags {
+		if k == "" {
+			errMsg := "default tag keys can not be empty"
+			d.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+	tags.TenantId = tenantId
+
+	result, err := db.C.SetDefaultTags(ctx, &tags)
+	if err != nil {
+		errMsg := fmt.Sprintf("set default tags failed: %s", err.Error())
+		d.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	d.SuccessHandle(StatusOK, body)
+	return
+}
+
+// DeleteDefaultTags removes the default tags of the tenant in the url.
+// Resources created so far keep the tags they were created with.
+func (d *DefaultTagsPortal) DeleteDefaultTags() {
+	if !policy.Authorize(d.Ctx, "default_tags:delete") {
+		return
+	}
+	ctx := c.GetContext(d.Ctx)
+	tenantId := d.Ctx.Input.Param(":tenantId")
+
+	if _, err := db.C.GetDefaultTags(ctx, tenantId); err != nil {
+		errMsg := fmt.Sprintf("default tags of tenant %s not found: %s", tenantId, err.Error())
+		d.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	if err := db.C.DeleteDefaultTags(ctx, tenantId); err != nil {
+		errMsg := fmt.Sprintf("delete default tags failed: %s", err.Error())
+		d.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	d.SuccessHandle(StatusOK, nil)
+	return
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func init() {
+	beego.Router("/v1beta/:tenantId/defaultTags", &DefaultTagsPortal{},
+		"get:GetDefaultTags;put:SetDefaultTags;delete:DeleteDefaultTags")
+}
+
+func TestGetDefaultTags(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetDefaultTags", c.NewAdminContext(), "e93b4c0934da416eb9c8d120c5d04d96").
+			Return(&SampleDefaultTags[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/defaultTags", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output model.DefaultTagsSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleDefaultTags[0])
+	})
+
+	t.Run("Should return 403 if a tenant reads the default tags of another tenant", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/defaultTags", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "x7a91c0a2ab41b9cf76f71a91b"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+
+	t.Run("Should return 404 if the tenant has no default tags", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetDefaultTags", c.NewAdminContext(), "e93b4c0934da416eb9c8d120c5d04d96").
+			Return(nil, errors.New("Wrong resource uuid provided!"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/defaultTags", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
+
+func TestSetDefaultTags(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		tags := &model.DefaultTagsSpec{
+			BaseModel: &model.BaseModel{},
+			TenantId:  "e93b4c0934da416eb9c8d120c5d04d96",
+			Tags: map[string]string{
+				"costCenter": "cc-1042",
+			},
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("SetDefaultTags", c.NewAdminContext(), tags).Return(&SampleDefaultTags[0], nil)
+		db.C = mockClient
+
+		body := []byte(`{"tags": {"costCenter": "cc-1042"}}`)
+		r, _ := http.NewRequest("PUT", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/defaultTags", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output model.DefaultTagsSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleDefaultTags[0])
+	})
+
+	t.Run("Should return 400 if the tags are empty", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		body := []byte(`{"tags": {}}`)
+		r, _ := http.NewRequest("PUT", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/defaultTags", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestDeleteDefaultTags(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetDefaultTags", c.NewAdminContext(), "e93b4c0934da416eb9c8d120c5d04d96").
+			Return(&SampleDefaultTags[0], nil)
+		mockClient.On("DeleteDefaultTags", c.NewAdminContext(), "e93b4c0934da416eb9c8d120c5d04d96").
+			Return(nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/defaultTags", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 200)
+	})
+}
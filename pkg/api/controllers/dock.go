@@ -20,13 +20,17 @@ This module implements a entry into the OpenSDS northbound service.
 package controllers
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 
+	log "github.com/golang/glog"
 	"github.com/sodafoundation/api/pkg/api/policy"
 	c "github.com/sodafoundation/api/pkg/context"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/constants"
 )
 
 // DockPortal
@@ -34,6 +38,99 @@ type DockPortal struct {
 	BasePortal
 }
 
+// RegisterDock admits a new dock into the cluster. The caller authenticates
+// with the bootstrap token configured in dock_bootstrap_token, the server
+// assigns the dock id and logs the registration, so joining a storage node
+// is an auditable api operation instead of a direct db write.
+func (d *DockPortal) RegisterDock() {
+	if !policy.Authorize(d.Ctx, "dock:register") {
+		return
+	}
+	token := CONF.OsdsApiServer.DockBootstrapToken
+	if token == "" {
+		errMsg := "dock registration is disabled, set dock_bootstrap_token to enable it"
+		d.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+	presented := d.Ctx.Input.Header(constants.DockBootstrapTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+		errMsg := "invalid dock bootstrap token"
+		d.ErrorHandle(model.ErrorUnauthorized, errMsg)
+		return
+	}
+
+	var dck = model.DockSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	// Unmarshal the request body
+	if err := json.NewDecoder(d.Ctx.Request.Body).Decode(&dck); err != nil {
+		errMsg := fmt.Sprintf("parse dock request body failed: %s", err.Error())
+		d.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if dck.Name == "" || dck.Endpoint == "" || dck.DriverName == "" {
+		errMsg := "dock name, endpoint and driverName can not be empty"
+		d.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	// The id is assigned by the server, a dock must not pick its own.
+	if dck.Id != "" {
+		errMsg := "dock id is assigned by the server and can not be set"
+		d.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	dck.Status = model.DockAvailable
+
+	result, err := db.C.CreateDock(c.NewAdminContext(), &dck)
+	if err != nil {
+		errMsg := fmt.Sprintf("register dock failed: %s", err.Error())
+		d.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	log.Infof("dock %s (%s) registered from %s", result.Id, result.Name, d.Ctx.Input.IP())
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal dock failed: %s", err.Error())
+		d.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	d.SuccessHandle(StatusOK, body)
+	return
+}
+
+// DeleteDock revokes the registration of a dock, admin only.
+func (d *DockPortal) DeleteDock() {
+	if !policy.Authorize(d.Ctx, "dock:delete") {
+		return
+	}
+	ctx := c.GetContext(d.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to revoke a dock registration"
+		d.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+	id := d.Ctx.Input.Param(":dockId")
+	if _, err := db.C.GetDock(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("dock %s not found: %s", id, err.Error())
+		d.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	if err := db.C.DeleteDock(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("delete dock failed: %s", err.Error())
+		d.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	log.Infof("dock %s registration revoked", id)
+	d.SuccessHandle(StatusOK, nil)
+
+	return
+}
+
 // ListDocks
 func (d *DockPortal) ListDocks() {
 	if !policy.Authorize(d.Ctx, "dock:list") {
@@ -53,15 +150,7 @@ func (d *DockPortal) ListDocks() {
 		return
 	}
 
-	// Marshal the result.
-	body, err := json.Marshal(result)
-	if err != nil {
-		errMsg := fmt.Sprintf("marshal docks failed: %s", err.Error())
-		d.ErrorHandle(model.ErrorInternalServer, errMsg)
-		return
-	}
-
-	d.SuccessHandle(StatusOK, body)
+	d.SuccessHandleList(StatusOK, result)
 	return
 }
 
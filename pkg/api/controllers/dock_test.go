@@ -15,6 +15,7 @@
 package controllers
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -27,6 +28,8 @@ import (
 	c "github.com/sodafoundation/api/pkg/context"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/constants"
 	. "github.com/sodafoundation/api/testutils/collection"
 	dbtest "github.com/sodafoundation/api/testutils/db/testing"
 )
@@ -41,7 +44,8 @@ var assertTestResult = func(t *testing.T, got, expected interface{}) {
 func init() {
 	var dockPortal DockPortal
 	beego.Router("/v1beta/docks", &dockPortal, "get:ListDocks")
-	beego.Router("/v1beta/docks/:dockId", &dockPortal, "get:GetDock")
+	beego.Router("/v1beta/docks/register", &dockPortal, "post:RegisterDock")
+	beego.Router("/v1beta/docks/:dockId", &dockPortal, "get:GetDock;delete:DeleteDock")
 }
 
 func TestListDocks(t *testing.T) {
@@ -127,3 +131,116 @@ func TestGetDock(t *testing.T) {
 		assertTestResult(t, w.Code, 404)
 	})
 }
+
+func TestRegisterDock(t *testing.T) {
+
+	t.Run("Should return 200 and the server assigned id if the bootstrap token matches", func(t *testing.T) {
+		CONF.OsdsApiServer.DockBootstrapToken = "bootstrap-secret"
+		defer func() { CONF.OsdsApiServer.DockBootstrapToken = "" }()
+
+		var expected = &model.DockSpec{
+			BaseModel:  &model.BaseModel{},
+			Name:       "sample",
+			Endpoint:   "localhost:50050",
+			DriverName: "sample",
+			Status:     model.DockAvailable,
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("CreateDock", c.NewAdminContext(), expected).Return(&SampleDocks[0], nil)
+		db.C = mockClient
+
+		body := []byte(`{"name": "sample", "endpoint": "localhost:50050", "driverName": "sample"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/docks/register", bytes.NewBuffer(body))
+		r.Header.Set(constants.DockBootstrapTokenHeader, "bootstrap-secret")
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.DockSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleDocks[0])
+	})
+
+	t.Run("Should return 401 if the bootstrap token does not match", func(t *testing.T) {
+		CONF.OsdsApiServer.DockBootstrapToken = "bootstrap-secret"
+		defer func() { CONF.OsdsApiServer.DockBootstrapToken = "" }()
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"name": "sample", "endpoint": "localhost:50050", "driverName": "sample"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/docks/register", bytes.NewBuffer(body))
+		r.Header.Set(constants.DockBootstrapTokenHeader, "wrong")
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 401)
+	})
+
+	t.Run("Should return 403 if registration is disabled", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"name": "sample", "endpoint": "localhost:50050", "driverName": "sample"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/docks/register", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+
+	t.Run("Should return 400 if the dock picks its own id", func(t *testing.T) {
+		CONF.OsdsApiServer.DockBootstrapToken = "bootstrap-secret"
+		defer func() { CONF.OsdsApiServer.DockBootstrapToken = "" }()
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"id": "b7602e18-771e-11e7-8f38-dbd6d291f4e0", "name": "sample", "endpoint": "localhost:50050", "driverName": "sample"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/docks/register", bytes.NewBuffer(body))
+		r.Header.Set(constants.DockBootstrapTokenHeader, "bootstrap-secret")
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestDeleteDock(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetDock", c.NewAdminContext(), "b7602e18-771e-11e7-8f38-dbd6d291f4e0").Return(&SampleDocks[0], nil)
+		mockClient.On("DeleteDock", c.NewAdminContext(), "b7602e18-771e-11e7-8f38-dbd6d291f4e0").Return(nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE",
+			"/v1beta/docks/b7602e18-771e-11e7-8f38-dbd6d291f4e0", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 200)
+	})
+
+	t.Run("Should return 403 for non admin users", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE",
+			"/v1beta/docks/b7602e18-771e-11e7-8f38-dbd6d291f4e0", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+}
@@ -15,15 +15,19 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
 
 	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/controllerclient"
 	"github.com/sodafoundation/api/pkg/api/policy"
 	"github.com/sodafoundation/api/pkg/api/util"
 	c "github.com/sodafoundation/api/pkg/context"
-	"github.com/sodafoundation/api/pkg/api/controllerclient"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
 	pb "github.com/sodafoundation/api/pkg/model/proto"
@@ -112,6 +116,7 @@ func (f *FileSharePortal) CreateFileShareAcl() {
 		Type:             result.Type,
 		AccessCapability: result.AccessCapability,
 		AccessTo:         result.AccessTo,
+		SecurityFlavor:   result.SecurityFlavor,
 		Metadata:         fileshare.Metadata,
 		Context:          ctx.ToJson(),
 		Profile:          prf.ToJson(),
@@ -146,9 +151,7 @@ func (f *FileSharePortal) ListFileSharesAcl() {
 		f.ErrorHandle(model.ErrorInternalServer, errMsg)
 		return
 	}
-	// Marshal the result.
-	body, _ := json.Marshal(result)
-	f.SuccessHandle(StatusOK, body)
+	f.SuccessHandleList(StatusOK, result)
 
 	return
 }
@@ -171,10 +174,28 @@ func (f *FileSharePortal) CreateFileShare() {
 		return
 	}
 
+	// Creating a fileshare public right away needs the same permission as
+	// the publish endpoint.
+	if fileshare.IsPublic && !policy.Authorize(f.Ctx, "fileshare:publish") {
+		return
+	}
+
+	fileshare.Metadata = util.ApplyDefaultTags(ctx, fileshare.Metadata)
+
 	// make the fileshare name global so that we can use at multiple place
 	var fileshareMetadata map[string]string
 	fileshareMetadata = fileshare.Metadata
 	var snapshotName string
+	var sourceShare *model.FileShareSpec
+
+	// Copying the access rules of the source share is only meaningful when
+	// the new share is created from a snapshot.
+	inheritAcls := f.Ctx.Input.Query("inheritAcls") == "true"
+	if inheritAcls && fileshare.SnapshotId == "" {
+		errMsg := "inheritAcls=true is only valid when creating a fileshare from a snapshot"
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
 
 	// Validate the snapthot existance
 	if fileshare.SnapshotId != "" {
@@ -191,7 +212,15 @@ func (f *FileSharePortal) CreateFileShare() {
 			return
 		}
 		fileshareMetadata = existingFs.Metadata
+		// the new share stays at the chain depth of the snapshot, so
+		// re-snapshotting it keeps deepening the chain
+		if depth := util.ChainDepth(snapshot.Metadata); depth > 0 {
+			fileshareMetadata = utils.MergeStringMaps(existingFs.Metadata, map[string]string{
+				util.SnapshotChainDepthKey: strconv.Itoa(depth),
+			})
+		}
 		snapshotName = snapshot.Name
+		sourceShare = existingFs
 	}
 
 	// Get profile
@@ -213,14 +242,78 @@ func (f *FileSharePortal) CreateFileShare() {
 			f.ErrorHandle(model.ErrorBadRequest, errMsg)
 			return
 		}
-		if prf.StorageType != constants.File {
-			errMsg := fmt.Sprintf("storageType should be only file. Currently it is: %s", prf.StorageType)
-			log.Error(errMsg)
+	}
+	if prf.StorageType != constants.File {
+		errMsg := fmt.Sprintf("storageType should be only file. Currently it is: %s", prf.StorageType)
+		log.Error(errMsg)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if !ctx.IsAdmin && !prf.VisibleTo(ctx.TenantId) {
+		errMsg := fmt.Sprintf("profile %s is not available to tenant %s", prf.Id, ctx.TenantId)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// The profile may suggest default export options; an explicit request
+	// wins over the profile.
+	if fileshare.ExportOptions == nil {
+		fileshare.ExportOptions = prf.ProvisioningProperties.ExportOptions
+	}
+	if fileshare.ExportOptions != nil {
+		if err := fileshare.ExportOptions.Validate(); err != nil {
+			errMsg := fmt.Sprintf("invalid fileshare export options: %s", err.Error())
 			f.ErrorHandle(model.ErrorBadRequest, errMsg)
 			return
 		}
 	}
 
+	// Normalize the requested protocols and validate them against the profile
+	// and the protocols advertised by the registered file pools.
+	if err := util.ValidateFileShareProtocols(ctx, prf, &fileshare); err != nil {
+		errMsg := fmt.Sprintf("invalid fileshare protocols: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		log.Error(errMsg)
+		return
+	}
+
+	if err := util.ValidateBackendMetadata(fileshare.Metadata); err != nil {
+		errMsg := fmt.Sprintf("invalid fileshare metadata: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// The export network: an explicit request wins, then the profile, then
+	// the network registered as default.
+	shareNetwork, err := util.ResolveShareNetwork(ctx, prf, &fileshare)
+	if err != nil {
+		errMsg := fmt.Sprintf("resolve share network failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// A dry run: report how the placement of this fileshare would be
+	// decided instead of creating it.
+	if f.Ctx.Input.Query("explain") == "true" {
+		if fileshare.Size <= 0 {
+			errMsg := fmt.Sprintf("invalid fileshare size: %d", fileshare.Size)
+			f.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+		if fileshare.AvailabilityZone == "" {
+			fileshare.AvailabilityZone = "default"
+		}
+		explain, err := util.ExplainPlacement(ctx, "fileshare", prf, fileshare.AvailabilityZone, fileshare.Size)
+		if err != nil {
+			errMsg := fmt.Sprintf("explain fileshare placement failed: %s", err.Error())
+			f.ErrorHandle(model.ErrorInternalServer, errMsg)
+			return
+		}
+		body, _ := json.Marshal(explain)
+		f.SuccessHandle(StatusOK, body)
+		return
+	}
+
 	// NOTE: It will create a file share entry into the database and initialize its status
 	// as "creating". It will not wait for the real file share creation to complete
 	// and will return result immediately.
@@ -231,24 +324,6 @@ func (f *FileSharePortal) CreateFileShare() {
 		log.Error(reason)
 		return
 	}
-	// Marshal the result.
-	body, err := json.Marshal(result)
-	if err != nil {
-		reason := fmt.Sprintf("marshal fileshare created result failed: %s", err.Error())
-		f.ErrorHandle(model.ErrorBadRequest, reason)
-		log.Error(reason)
-		return
-	}
-	f.SuccessHandle(StatusAccepted, body)
-
-	// NOTE: The real file share creation process.
-	// FileShare creation request is sent to the Dock. Dock will update file share status to "available"
-	// after file share creation is completed.
-	if err := f.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
-		log.Error("when connecting controller client:", err)
-		return
-	}
-
 	opt := &pb.CreateFileShareOpts{
 		Id:               result.Id,
 		Name:             result.Name,
@@ -260,19 +335,119 @@ func (f *FileSharePortal) CreateFileShare() {
 		ExportLocations:  result.ExportLocations,
 		SnapshotId:       result.SnapshotId,
 		SnapshotName:     snapshotName,
-		Metadata:         fileshareMetadata,
-		Context:          ctx.ToJson(),
+		Protocols:        result.Protocols,
+		// Only the backend.* metadata entries travel to the driver, the
+		// remaining metadata stays api-side.
+		Metadata: util.BackendMetadata(fileshareMetadata),
+		Context:  ctx.ToJson(),
+	}
+	if result.ExportOptions != nil {
+		exportOptions, _ := json.Marshal(result.ExportOptions)
+		opt.ExportOptions = string(exportOptions)
+	}
+	if shareNetwork != nil {
+		networkJson, _ := json.Marshal(shareNetwork)
+		opt.ShareNetwork = string(networkJson)
+	}
+	if err := client.CheckOptsSize(opt); err != nil {
+		if err := db.C.DeleteFileShare(ctx, result.Id); err != nil {
+			log.Error("delete fileshare in db failed:", err)
+		}
+		reason := fmt.Sprintf("create fileshare failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorEntityTooLarge, reason)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		reason := fmt.Sprintf("marshal fileshare created result failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, reason)
+		log.Error(reason)
+		return
 	}
-	response, err := f.CtrClient.CreateFileShare(context.Background(), opt)
+	f.SuccessHandle(StatusAccepted, body)
+
+	// NOTE: The real file share creation process.
+	// FileShare creation request is sent to the Dock. Dock will update file share status to "available"
+	// after file share creation is completed. A dispatch that fails
+	// transiently is retried per the configured policy before the share is
+	// marked error.
+	task := util.StartTask(ctx, "fileshare", result.Id, "createFileShare")
+	var response *pb.GenericResponse
+	attempts, err := util.DispatchWithRetry("fileshare:create", func() error {
+		if err := f.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+			return err
+		}
+		var callErr error
+		response, callErr = f.CtrClient.CreateFileShare(context.Background(), opt)
+		return callErr
+	})
 	if err != nil {
-		log.Error("create file share failed in controller service:", err)
+		reason := fmt.Sprintf("create file share failed in controller service: %v", err)
+		log.Error(reason)
+		if err := db.UpdateFileShareDispatchFault(ctx, db.C, result.Id, model.FileShareError, reason, attempts); err != nil {
+			log.Error("when update file share status in db:", err)
+		}
+		util.CloseTask(ctx, task, err)
 		return
 	}
 	if errorMsg := response.GetError(); errorMsg != nil {
 		log.Errorf("failed to create file share in controller, code: %v, message: %v",
 			errorMsg.GetCode(), errorMsg.GetDescription())
+		util.CloseTask(ctx, task, fmt.Errorf(errorMsg.GetDescription()))
 		return
 	}
+	util.CloseTask(ctx, task, nil)
+
+	// Now that the new share is ready, copy the access rules of the source
+	// share when the user asked for it with inheritAcls=true. An access rule
+	// that can not be copied is logged and skipped, the remaining rules are
+	// still applied.
+	if inheritAcls {
+		acls, err := db.C.ListFileShareAclsByShareId(ctx, sourceShare.Id)
+		if err != nil {
+			log.Error("when listing acls of the source fileshare:", err)
+			return
+		}
+		for _, acl := range acls {
+			newAcl, err := util.CreateFileShareAclDBEntry(ctx, &model.FileShareAclSpec{
+				BaseModel:        &model.BaseModel{},
+				FileShareId:      result.Id,
+				Type:             acl.Type,
+				AccessCapability: acl.AccessCapability,
+				AccessTo:         acl.AccessTo,
+				SecurityFlavor:   acl.SecurityFlavor,
+				Description:      acl.Description,
+				ProfileId:        result.ProfileId,
+			})
+			if err != nil {
+				log.Error("when creating inherited fileshare acl db entry:", err)
+				continue
+			}
+			aclOpt := &pb.CreateFileShareAclOpts{
+				Id:               newAcl.Id,
+				FileshareId:      newAcl.FileShareId,
+				Description:      newAcl.Description,
+				Type:             newAcl.Type,
+				AccessCapability: newAcl.AccessCapability,
+				AccessTo:         newAcl.AccessTo,
+				SecurityFlavor:   newAcl.SecurityFlavor,
+				Metadata:         result.Metadata,
+				Context:          ctx.ToJson(),
+				Profile:          prf.ToJson(),
+			}
+			response, err := f.CtrClient.CreateFileShareAcl(context.Background(), aclOpt)
+			if err != nil {
+				log.Error("create inherited file share acl failed in controller service:", err)
+				continue
+			}
+			if errorMsg := response.GetError(); errorMsg != nil {
+				log.Errorf("failed to create inherited file share acl in controller, code: %v, message: %v",
+					errorMsg.GetCode(), errorMsg.GetDescription())
+			}
+		}
+	}
 
 	return
 }
@@ -287,19 +462,64 @@ func (f *FileSharePortal) ListFileShares() {
 		f.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
+	if f.listTombstones(m, model.TombstoneResourceFileShare) {
+		return
+	}
+	// fileshares published by other tenants are discoverable organization
+	// wide, served in a redacted read-only form
+	if f.Ctx.Input.Query("public") == "true" {
+		f.listPublicFileShares(m)
+		return
+	}
+	createdFilter, err := util.ParseCreatedAtFilter(m)
+	if err != nil {
+		errMsg := fmt.Sprintf("list fileshares failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	// a member without the tenant admin role only sees their own fileshares
+	util.ScopeListToCreator(c.GetContext(f.Ctx), m)
+
 	result, err := db.C.ListFileSharesWithFilter(c.GetContext(f.Ctx), m)
 	if err != nil {
 		errMsg := fmt.Sprintf("list fileshares failed: %s", err.Error())
 		f.ErrorHandle(model.ErrorInternalServer, errMsg)
 		return
 	}
-	// Marshal the result.
-	body, _ := json.Marshal(result)
-	f.SuccessHandle(StatusOK, body)
+	if createdFilter != nil {
+		var matched = []*model.FileShareSpec{}
+		for _, fileshare := range result {
+			if createdFilter.Match(fileshare.CreatedAt) {
+				matched = append(matched, fileshare)
+			}
+		}
+		result = matched
+	}
+	f.SuccessHandleList(StatusOK, result)
 
 	return
 }
 
+// listPublicFileShares serves the fileshares every tenant may discover.
+// The listing spans all tenants, so it runs with an admin context and
+// every entry is reduced to its public view.
+func (f *FileSharePortal) listPublicFileShares(m map[string][]string) {
+	delete(m, "public")
+	result, err := db.C.ListFileSharesWithFilter(c.NewAdminContext(), m)
+	if err != nil {
+		errMsg := fmt.Sprintf("list public fileshares failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	var public = []*model.FileShareSpec{}
+	for _, fileshare := range result {
+		if fileshare.IsPublic {
+			public = append(public, util.PublicFileShareView(fileshare))
+		}
+	}
+	f.SuccessHandleList(StatusOK, public)
+}
+
 func (f *FileSharePortal) GetFileShareAcl() {
 	if !policy.Authorize(f.Ctx, "fileshareacl:get") {
 		return
@@ -336,43 +556,491 @@ func (f *FileSharePortal) GetFileShare() {
 	// Call db api module to handle get file share request.
 	result, err := db.C.GetFileShare(c.GetContext(f.Ctx), id)
 	if err != nil {
-		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
-		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if err := util.CheckCreatorVisibility(c.GetContext(f.Ctx), result.UserId); err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	if err != nil {
+		reason := fmt.Sprintf("marshal fileshare list result failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, reason)
+		log.Error(reason)
+		return
+	}
+	f.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+// GetFileShareLineage returns the tree of snapshots taken from the given
+// fileshare and the fileshares created from those snapshots, so that the
+// dependencies of a resource can be inspected before it is deleted.
+func (f *FileSharePortal) GetFileShareLineage() {
+	if !policy.Authorize(f.Ctx, "fileshare:get_lineage") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	id := f.Ctx.Input.Param(":fileshareId")
+
+	fileshare, err := db.C.GetFileShare(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	snapshots, err := db.C.ListFileShareSnapshots(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list fileshare snapshots failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	fileshares, err := db.C.ListFileShares(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list fileshares failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Group the snapshots by the fileshare they were taken from and the
+	// fileshares by the snapshot they were created from, then walk the
+	// tree down from the requested fileshare.
+	snapshotsByShare := make(map[string][]*model.FileShareSnapshotSpec)
+	for _, snapshot := range snapshots {
+		snapshotsByShare[snapshot.FileShareId] = append(snapshotsByShare[snapshot.FileShareId], snapshot)
+	}
+	sharesBySnapshot := make(map[string][]*model.FileShareSpec)
+	for _, share := range fileshares {
+		if share.SnapshotId != "" {
+			sharesBySnapshot[share.SnapshotId] = append(sharesBySnapshot[share.SnapshotId], share)
+		}
+	}
+	root := buildFileShareLineage(fileshare, snapshotsByShare, sharesBySnapshot,
+		map[string]bool{fileshare.Id: true})
+
+	// Marshal the result.
+	body, err := json.Marshal(root)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal fileshare lineage failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	f.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+// buildFileShareLineage builds the lineage node of a fileshare and recurses
+// into the fileshares created from its snapshots. The visited set guards
+// against malformed references that would otherwise loop forever.
+func buildFileShareLineage(share *model.FileShareSpec,
+	snapshotsByShare map[string][]*model.FileShareSnapshotSpec,
+	sharesBySnapshot map[string][]*model.FileShareSpec,
+	visited map[string]bool) *model.FileShareLineageNode {
+
+	node := &model.FileShareLineageNode{
+		Id:        share.Id,
+		Name:      share.Name,
+		Kind:      model.FileShareLineageKindShare,
+		CreatedAt: share.CreatedAt,
+		Status:    share.Status,
+	}
+	for _, snapshot := range snapshotsByShare[share.Id] {
+		snapshotNode := &model.FileShareLineageNode{
+			Id:        snapshot.Id,
+			Name:      snapshot.Name,
+			Kind:      model.FileShareLineageKindSnapshot,
+			CreatedAt: snapshot.CreatedAt,
+			Status:    snapshot.Status,
+		}
+		for _, child := range sharesBySnapshot[snapshot.Id] {
+			if visited[child.Id] {
+				continue
+			}
+			visited[child.Id] = true
+			snapshotNode.Children = append(snapshotNode.Children,
+				buildFileShareLineage(child, snapshotsByShare, sharesBySnapshot, visited))
+		}
+		node.Children = append(node.Children, snapshotNode)
+	}
+	return node
+}
+
+// CreateFileShareAnnotation appends an operator note to the fileshare. The
+// notes are kept separate from the machine generated metadata.
+func (f *FileSharePortal) CreateFileShareAnnotation() {
+	if !policy.Authorize(f.Ctx, "annotation:create") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	id := f.Ctx.Input.Param(":fileshareId")
+
+	var anno = model.AnnotationSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(f.Ctx.Request.Body).Decode(&anno); err != nil {
+		errMsg := fmt.Sprintf("parse annotation request body failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if anno.Note == "" {
+		errMsg := "annotation note can not be empty"
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if _, err := db.C.GetFileShare(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	anno.ResourceType = model.AnnotationResourceFileShare
+	anno.ResourceId = id
+	anno.UserId = ctx.UserId
+	result, err := db.C.CreateAnnotation(ctx, &anno)
+	if err != nil {
+		errMsg := fmt.Sprintf("create annotation failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal annotation failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	f.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+// ListFileShareAnnotations lists the operator notes appended to the
+// fileshare, oldest first.
+func (f *FileSharePortal) ListFileShareAnnotations() {
+	if !policy.Authorize(f.Ctx, "annotation:list") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	id := f.Ctx.Input.Param(":fileshareId")
+
+	if _, err := db.C.GetFileShare(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	result, err := db.C.ListAnnotations(ctx, model.AnnotationResourceFileShare, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("list annotations failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	f.SuccessHandleList(StatusOK, result)
+
+	return
+}
+
+// fileShareLockBlocks writes the 423 response when the lock of a fileshare
+// blocks the request. The lock owner and administrators pass through.
+func (f *FileSharePortal) fileShareLockBlocks(ctx *c.Context, fileshare *model.FileShareSpec) bool {
+	if fileshare.Lock == nil || ctx.IsAdmin || ctx.UserId == fileshare.Lock.Owner {
+		return false
+	}
+	errMsg := fmt.Sprintf("fileshare %s is locked by user %s: %s",
+		fileshare.Id, fileshare.Lock.Owner, fileshare.Lock.Reason)
+	f.ErrorHandle(model.ErrorLocked, errMsg)
+	return true
+}
+
+// LockFileShare protects a fileshare against accidental modification.
+// While locked, update and delete requests are rejected with 423
+// unless they come from the lock owner or an administrator.
+func (f *FileSharePortal) LockFileShare() {
+	if !policy.Authorize(f.Ctx, "fileshare:lock") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+
+	id := f.Ctx.Input.Param(":fileshareId")
+	fileshare, err := db.C.GetFileShare(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if fileshare.Lock != nil {
+		errMsg := fmt.Sprintf("fileshare %s is already locked by user %s", id, fileshare.Lock.Owner)
+		f.ErrorHandle(model.ErrorConflict, errMsg)
+		return
+	}
+
+	var in = model.FileShareLockSpec{}
+	if err := json.NewDecoder(f.Ctx.Request.Body).Decode(&in); err != nil {
+		errMsg := fmt.Sprintf("parse fileshare lock request body failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	result, err := db.C.UpdateFileShare(ctx, &model.FileShareSpec{
+		BaseModel: &model.BaseModel{Id: id},
+		Lock: &model.FileShareLockSpec{
+			Owner:    ctx.UserId,
+			Reason:   in.Reason,
+			LockedAt: utils.FormatTime(time.Now()),
+		},
+	})
+	if err != nil {
+		errMsg := fmt.Sprintf("lock fileshare failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	f.SuccessHandle(StatusOK, body)
+}
+
+// UnlockFileShare releases the lock of a fileshare. Only the lock owner
+// and administrators may release it.
+func (f *FileSharePortal) UnlockFileShare() {
+	if !policy.Authorize(f.Ctx, "fileshare:unlock") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+
+	id := f.Ctx.Input.Param(":fileshareId")
+	fileshare, err := db.C.GetFileShare(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if fileshare.Lock == nil {
+		errMsg := fmt.Sprintf("fileshare %s is not locked", id)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if !ctx.IsAdmin && ctx.UserId != fileshare.Lock.Owner {
+		errMsg := fmt.Sprintf("only the lock owner or an administrator can unlock fileshare %s", id)
+		f.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	// An empty lock releases the lock in the db driver.
+	result, err := db.C.UpdateFileShare(ctx, &model.FileShareSpec{
+		BaseModel: &model.BaseModel{Id: id},
+		Lock:      &model.FileShareLockSpec{},
+	})
+	if err != nil {
+		errMsg := fmt.Sprintf("unlock fileshare failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	f.SuccessHandle(StatusOK, body)
+}
+
+// PublishFileShare makes a fileshare discoverable by other tenants through
+// the public listing.
+func (f *FileSharePortal) PublishFileShare() {
+	if !policy.Authorize(f.Ctx, "fileshare:publish") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+
+	id := f.Ctx.Input.Param(":fileshareId")
+	fileshare, err := db.C.GetFileShare(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if f.fileShareLockBlocks(ctx, fileshare) {
+		return
+	}
+	if fileshare.IsPublic {
+		errMsg := fmt.Sprintf("fileshare %s is already public", id)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	result, err := db.C.UpdateFileSharePublic(ctx, id, true)
+	if err != nil {
+		errMsg := fmt.Sprintf("publish fileshare failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	f.SuccessHandle(StatusOK, body)
+}
+
+// UnpublishFileShare removes a fileshare from the public listing again.
+func (f *FileSharePortal) UnpublishFileShare() {
+	if !policy.Authorize(f.Ctx, "fileshare:unpublish") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+
+	id := f.Ctx.Input.Param(":fileshareId")
+	fileshare, err := db.C.GetFileShare(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if f.fileShareLockBlocks(ctx, fileshare) {
+		return
+	}
+	if !fileshare.IsPublic {
+		errMsg := fmt.Sprintf("fileshare %s is not public", id)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	result, err := db.C.UpdateFileSharePublic(ctx, id, false)
+	if err != nil {
+		errMsg := fmt.Sprintf("unpublish fileshare failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	f.SuccessHandle(StatusOK, body)
+}
+
+func (f *FileSharePortal) UpdateFileShare() {
+	if !policy.Authorize(f.Ctx, "fileshare:update") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	var fshare = model.FileShareSpec{
+		BaseModel: &model.BaseModel{},
+	}
+
+	id := f.Ctx.Input.Param(":fileshareId")
+	if err := json.NewDecoder(f.Ctx.Request.Body).Decode(&fshare); err != nil {
+		errMsg := fmt.Sprintf("parse fileshare request body failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	current, err := db.C.GetFileShare(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if f.fileShareLockBlocks(ctx, current) {
+		return
+	}
+
+	if err := util.ValidateExpiresAt(fshare.ExpiresAt); err != nil {
+		errMsg := fmt.Sprintf("update fileshare failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := util.ValidateSystemMetadata(fshare.Metadata); err != nil {
+		errMsg := fmt.Sprintf("update fileshare failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// A retype must point at a profile of the same endpoint family.
+	if fshare.ProfileId != "" {
+		prf, err := db.C.GetProfile(ctx, fshare.ProfileId)
+		if err != nil {
+			errMsg := fmt.Sprintf("get profile failed: %s", err.Error())
+			f.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+		if prf.StorageType != constants.File {
+			errMsg := fmt.Sprintf("storageType should be only file. Currently it is: %s", prf.StorageType)
+			log.Error(errMsg)
+			f.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+
+	fshare.Id = id
+	if err := util.ValidateWithWebhook(c.GetContext(f.Ctx), "fileshare", util.WebhookOperationUpdate, &fshare); err != nil {
+		errMsg := fmt.Sprintf("update fileshare failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	result, err := db.C.UpdateFileShare(c.GetContext(f.Ctx), &fshare)
+	if err != nil {
+		errMsg := fmt.Sprintf("update fileshare failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
 		return
 	}
 
 	// Marshal the result.
 	body, _ := json.Marshal(result)
-	if err != nil {
-		reason := fmt.Sprintf("marshal fileshare list result failed: %s", err.Error())
-		f.ErrorHandle(model.ErrorBadRequest, reason)
-		log.Error(reason)
-		return
-	}
 	f.SuccessHandle(StatusOK, body)
 
 	return
 }
 
-func (f *FileSharePortal) UpdateFileShare() {
-	if !policy.Authorize(f.Ctx, "fileshare:update") {
+// UpdateFileShareMetadata merges the posted entries into the metadata of
+// the fileshare, with the same per-key conditions as the volume variant.
+func (f *FileSharePortal) UpdateFileShareMetadata() {
+	if !policy.Authorize(f.Ctx, "fileshare:update_metadata") {
 		return
 	}
-	var fshare = model.FileShareSpec{
-		BaseModel: &model.BaseModel{},
-	}
-
+	ctx := c.GetContext(f.Ctx)
 	id := f.Ctx.Input.Param(":fileshareId")
-	if err := json.NewDecoder(f.Ctx.Request.Body).Decode(&fshare); err != nil {
-		errMsg := fmt.Sprintf("parse fileshare request body failed: %s", err.Error())
+
+	var update = model.MetadataUpdateSpec{}
+	if err := json.NewDecoder(f.Ctx.Request.Body).Decode(&update); err != nil {
+		errMsg := fmt.Sprintf("parse metadata update request body failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if len(update.Metadata) == 0 {
+		errMsg := "metadata to merge can not be empty"
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := util.ValidateSystemMetadata(update.Metadata); err != nil {
+		errMsg := fmt.Sprintf("update fileshare metadata failed: %s", err.Error())
 		f.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
 
-	fshare.Id = id
-	result, err := db.C.UpdateFileShare(c.GetContext(f.Ctx), &fshare)
+	// Merging metadata mutates the fileshare, so the lock blocks it like
+	// any other update.
+	fileshare, err := db.C.GetFileShare(ctx, id)
 	if err != nil {
-		errMsg := fmt.Sprintf("update fileshare failed: %s", err.Error())
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if f.fileShareLockBlocks(ctx, fileshare) {
+		return
+	}
+
+	result, err := db.C.UpdateFileShareMetadata(ctx, id, &update)
+	if err != nil {
+		errMsg := fmt.Sprintf("update fileshare metadata failed: %s", err.Error())
+		if _, ok := err.(*model.MetadataConditionError); ok {
+			f.ErrorHandle(model.ErrorConflict, errMsg)
+			return
+		}
 		f.ErrorHandle(model.ErrorInternalServer, errMsg)
 		return
 	}
@@ -394,7 +1062,7 @@ func (f *FileSharePortal) DeleteFileShareAcl() {
 	acl, err := db.C.GetFileShareAcl(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("fileshare acl %s not found: %s", id, err.Error())
-		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		f.DeleteNotFoundHandle(errMsg)
 		return
 	}
 	fileshare, err := db.C.GetFileShare(ctx, acl.FileShareId)
@@ -453,6 +1121,91 @@ func (f *FileSharePortal) DeleteFileShareAcl() {
 	return
 }
 
+// RevokeAllFileShareAccess removes every acl of a fileshare in one call,
+// for emergency isolation of a compromised workload. Each acl goes
+// through the same deletion flow as a single revoke; acls that cannot be
+// marked deleting are logged and skipped, so the isolation makes progress
+// with whatever it can. The response lists the acls whose removal was
+// kicked off.
+func (f *FileSharePortal) RevokeAllFileShareAccess() {
+	if !policy.Authorize(f.Ctx, "fileshare:revoke_all_access") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+
+	id := f.Ctx.Input.Param(":fileshareId")
+	fileshare, err := db.C.GetFileShare(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	prf, err := db.C.GetProfile(ctx, fileshare.ProfileId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get profile failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	acls, err := db.C.ListFileShareAclsByShareId(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("list acls of fileshare %s failed: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	var revoking []*model.FileShareAclSpec
+	for _, acl := range acls {
+		if err := util.DeleteFileShareAclDBEntry(ctx, acl); err != nil {
+			log.Errorf("when marking acl %s of fileshare %s deleting: %v", acl.Id, id, err)
+			continue
+		}
+		revoking = append(revoking, acl)
+	}
+
+	body, _ := json.Marshal(revoking)
+	f.SuccessHandle(StatusAccepted, body)
+
+	if len(revoking) == 0 {
+		return
+	}
+	if err := f.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+	// The revocations are paced in configured batches with bounded
+	// parallelism, checkpointing progress in the cascade task.
+	aclById := map[string]*model.FileShareAclSpec{}
+	var items []string
+	for _, acl := range revoking {
+		aclById[acl.Id] = acl
+		items = append(items, acl.Id)
+	}
+	task := util.StartCascadeTask(ctx, "fileshare", id, "revokeAllAccess", int64(len(items)))
+	util.NewCascadeRunner().Run(ctx, task, items, func(itemId string) error {
+		acl := aclById[itemId]
+		opt := &pb.DeleteFileShareAclOpts{
+			Id:               acl.Id,
+			FileshareId:      acl.FileShareId,
+			Description:      acl.Description,
+			Type:             acl.Type,
+			AccessCapability: acl.AccessCapability,
+			AccessTo:         acl.AccessTo,
+			Metadata:         utils.MergeStringMaps(fileshare.Metadata, acl.Metadata),
+			Context:          ctx.ToJson(),
+			Profile:          prf.ToJson(),
+		}
+		response, err := f.CtrClient.DeleteFileShareAcl(context.Background(), opt)
+		if err != nil {
+			return fmt.Errorf("revoke acl %s failed in controller service: %v", acl.Id, err)
+		}
+		if errorMsg := response.GetError(); errorMsg != nil {
+			return fmt.Errorf("failed to revoke acl %s in controller, code: %v, message: %v",
+				acl.Id, errorMsg.GetCode(), errorMsg.GetDescription())
+		}
+		return nil
+	})
+}
+
 func (f *FileSharePortal) DeleteFileShare() {
 	if !policy.Authorize(f.Ctx, "fileshare:delete") {
 		return
@@ -463,7 +1216,10 @@ func (f *FileSharePortal) DeleteFileShare() {
 	fileshare, err := db.C.GetFileShare(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
-		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		f.DeleteNotFoundHandle(errMsg)
+		return
+	}
+	if f.fileShareLockBlocks(ctx, fileshare) {
 		return
 	}
 	prf, err := db.C.GetProfile(ctx, fileshare.ProfileId)
@@ -473,6 +1229,25 @@ func (f *FileSharePortal) DeleteFileShare() {
 		return
 	}
 
+	// Serialize mutating operations on the fileshare, a concurrent request
+	// gets a conflict until the running operation completes.
+	pendingOp, err := db.C.AcquirePendingOperation(ctx, &model.PendingOperationSpec{
+		BaseModel:    &model.BaseModel{},
+		ResourceType: "fileshare",
+		ResourceId:   id,
+		Operation:    "delete",
+	})
+	if err != nil {
+		errMsg := fmt.Sprintf("delete fileshare failed: %v", err.Error())
+		f.ErrorHandle(model.ErrorConflict, errMsg)
+		return
+	}
+	defer func() {
+		if err := db.C.ReleasePendingOperation(ctx, "fileshare", id, pendingOp.Token); err != nil {
+			log.Error("when releasing pending operation:", err)
+		}
+	}()
+
 	// If profileId or poolId of the file share doesn't exist, it would mean that
 	// the file share provisioning operation failed before the create method in
 	// storage driver was called, therefore the file share entry should be deleted
@@ -500,8 +1275,10 @@ func (f *FileSharePortal) DeleteFileShare() {
 	// NOTE: The real file share deletion process.
 	// File Share deletion request is sent to the Dock. Dock will delete file share from driver
 	// and database or update file share status to "errorDeleting" if deletion from driver failed.
+	task := util.StartTask(ctx, "fileshare", fileshare.Id, "deleteFileShare")
 	if err := f.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
 		log.Error("when connecting controller client:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 
@@ -516,13 +1293,16 @@ func (f *FileSharePortal) DeleteFileShare() {
 	response, err := f.CtrClient.DeleteFileShare(context.Background(), opt)
 	if err != nil {
 		log.Error("delete fileshare failed in controller service:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 	if errorMsg := response.GetError(); errorMsg != nil {
 		log.Errorf("failed to delete fileshare in controller, code: %v, message: %v",
 			errorMsg.GetCode(), errorMsg.GetDescription())
+		util.CloseTask(ctx, task, fmt.Errorf(errorMsg.GetDescription()))
 		return
 	}
+	util.CloseTask(ctx, task, nil)
 
 	return
 }
@@ -595,8 +1375,10 @@ func (f *FileShareSnapshotPortal) CreateFileShareSnapshot() {
 	// NOTE:The real file share snapshot creation process.
 	// FileShare snapshot creation request is sent to the Dock. Dock will update file share snapshot status to "available"
 	// after file share snapshot creation complete.
+	task := util.StartTask(ctx, "fileshareSnapshot", result.Id, "createFileShareSnapshot")
 	if err := f.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
 		log.Error("when connecting controller client:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 
@@ -613,13 +1395,16 @@ func (f *FileShareSnapshotPortal) CreateFileShareSnapshot() {
 	response, err := f.CtrClient.CreateFileShareSnapshot(context.Background(), opt)
 	if err != nil {
 		log.Error("create file share snapthot failed in controller service:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 	if errorMsg := response.GetError(); errorMsg != nil {
 		log.Errorf("failed to create file share snapshot in controller, code: %v, message: %v",
 			errorMsg.GetCode(), errorMsg.GetDescription())
+		util.CloseTask(ctx, task, fmt.Errorf(errorMsg.GetDescription()))
 		return
 	}
+	util.CloseTask(ctx, task, nil)
 
 	return
 }
@@ -635,6 +1420,9 @@ func (f *FileShareSnapshotPortal) ListFileShareSnapshots() {
 		return
 	}
 
+	// a member without the tenant admin role only sees their own snapshots
+	util.ScopeListToCreator(c.GetContext(f.Ctx), m)
+
 	result, err := db.C.ListFileShareSnapshotsWithFilter(c.GetContext(f.Ctx), m)
 	if err != nil {
 		errMsg := fmt.Sprintf("list fileshare snapshots failed: %s", err.Error())
@@ -642,9 +1430,7 @@ func (f *FileShareSnapshotPortal) ListFileShareSnapshots() {
 		return
 	}
 
-	// Marshal the result.
-	body, _ := json.Marshal(result)
-	f.SuccessHandle(StatusOK, body)
+	f.SuccessHandleList(StatusOK, result)
 	return
 }
 
@@ -660,6 +1446,11 @@ func (f *FileShareSnapshotPortal) GetFileShareSnapshot() {
 		f.ErrorHandle(model.ErrorNotFound, errMsg)
 		return
 	}
+	if err := util.CheckCreatorVisibility(c.GetContext(f.Ctx), result.UserId); err != nil {
+		errMsg := fmt.Sprintf("fileshare snapshot %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
 
 	// Marshal the result.
 	body, _ := json.Marshal(result)
@@ -682,8 +1473,19 @@ func (f *FileShareSnapshotPortal) UpdateFileShareSnapshot() {
 		f.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
+	if err := util.ValidateSystemMetadata(snapshot.Metadata); err != nil {
+		errMsg := fmt.Sprintf("update fileshare snapshot failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
 	snapshot.Id = id
 
+	if err := util.ValidateExpiresAt(snapshot.ExpiresAt); err != nil {
+		errMsg := fmt.Sprintf("update fileshare snapshot failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
 	result, err := db.C.UpdateFileShareSnapshot(c.GetContext(f.Ctx), id, &snapshot)
 	if err != nil {
 		errMsg := fmt.Sprintf("update fileshare snapshot failed: %s", err.Error())
@@ -708,7 +1510,7 @@ func (f *FileShareSnapshotPortal) DeleteFileShareSnapshot() {
 	snapshot, err := db.C.GetFileShareSnapshot(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("fileshare snapshot %s not found: %s", id, err.Error())
-		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		f.DeleteNotFoundHandle(errMsg)
 		return
 	}
 
@@ -733,8 +1535,10 @@ func (f *FileShareSnapshotPortal) DeleteFileShareSnapshot() {
 	// NOTE:The real file share snapshot deletion process.
 	// FileShare snapshot deletion request is sent to the Dock. Dock will delete file share snapshot from driver and
 	// database or update its status to "errorDeleting" if file share snapshot deletion from driver failed.
+	task := util.StartTask(ctx, "fileshareSnapshot", snapshot.Id, "deleteFileShareSnapshot")
 	if err := f.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
 		log.Error("when connecting controller client:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 
@@ -748,13 +1552,253 @@ func (f *FileShareSnapshotPortal) DeleteFileShareSnapshot() {
 	response, err := f.CtrClient.DeleteFileShareSnapshot(context.Background(), opt)
 	if err != nil {
 		log.Error("delete file share snapshot failed in controller service:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 	if errorMsg := response.GetError(); errorMsg != nil {
 		log.Errorf("failed to delete file share snapshot in controller, code: %v, message: %v",
 			errorMsg.GetCode(), errorMsg.GetDescription())
+		util.CloseTask(ctx, task, fmt.Errorf(errorMsg.GetDescription()))
+		return
+	}
+	util.CloseTask(ctx, task, nil)
+
+	return
+}
+
+// CopyFileShareSnapshot copies a fileshare snapshot to a remote SODA endpoint
+// and tracks the copy in a record of its own.
+func (f *FileShareSnapshotPortal) CopyFileShareSnapshot() {
+	if !policy.Authorize(f.Ctx, "snapshot:copy") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	id := f.Ctx.Input.Param(":snapshotId")
+
+	var snpCopy = model.FileShareSnapshotCopySpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(f.Ctx.Request.Body).Decode(&snpCopy); err != nil {
+		errMsg := fmt.Sprintf("parse fileshare snapshot copy request body failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if snpCopy.TargetEndpoint == "" {
+		errMsg := "copy fileshare snapshot failed: targetEndpoint must be specified"
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := util.ValidateMaxBandwidth(snpCopy.MaxBandwidthMBps); err != nil {
+		errMsg := fmt.Sprintf("copy fileshare snapshot failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	snapshot, err := db.C.GetFileShareSnapshot(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("fileshare snapshot %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if snapshot.Status != model.FileShareSnapAvailable {
+		errMsg := fmt.Sprintf("only fileshare snapshot with status available can be copied, the status of snapshot %s is %s", id, snapshot.Status)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	prf, err := db.C.GetProfile(ctx, snapshot.ProfileId)
+	if err != nil {
+		errMsg := fmt.Sprintf("profile (%s) not found: %v", snapshot.ProfileId, err.Error())
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// The token is only handed over to the controller, it must never end up
+	// in the tracking record.
+	authToken := snpCopy.AuthToken
+	snpCopy.AuthToken = ""
+	snpCopy.SnapshotId = snapshot.Id
+	snpCopy.FileShareId = snapshot.FileShareId
+	snpCopy.UserId = ctx.UserId
+	snpCopy.Status = model.FileShareSnapCopyCopying
+
+	// NOTE:It will create a tracking record into the database and initialize
+	// its status as "copying". It will not wait for the real copy to complete
+	// and will return result immediately.
+	result, err := db.C.CreateFileShareSnapshotCopy(ctx, &snpCopy)
+	if err != nil {
+		errMsg := fmt.Sprintf("copy fileshare snapshot failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	f.SuccessHandle(StatusAccepted, body)
+
+	// NOTE:The real fileshare snapshot copy process.
+	// Copy request is sent to the Dock. Dock will update the tracking record
+	// status to "available" after the copy complete.
+	if err := f.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+
+	opt := &pb.CopyFileShareSnapshotOpts{
+		Id:             snapshot.Id,
+		FileshareId:    snapshot.FileShareId,
+		TargetEndpoint: result.TargetEndpoint,
+		TargetTenantId: result.TargetTenantId,
+		AuthToken:      authToken,
+		TrackingId:     result.Id,
+		Context:        ctx.ToJson(),
+		Profile:        prf.ToJson(),
+		Metadata:       snapshot.Metadata,
+		// the dock throttles the transfer to this limit, 0 is unlimited
+		MaxBandwidthMBps: result.MaxBandwidthMBps,
+	}
+	response, err := f.CtrClient.CopyFileShareSnapshot(context.Background(), opt)
+	if err != nil {
+		log.Error("copy file share snapshot failed in controller service:", err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to copy file share snapshot in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+		return
+	}
+
+	return
+}
+
+// CreateNestedFileShareSnapshot creates a snapshot under its parent
+// fileshare route. It validates that the parent exists and belongs to the
+// caller and then hands the request to the flat create handler with the
+// fileshare id taken from the route.
+func (f *FileShareSnapshotPortal) CreateNestedFileShareSnapshot() {
+	if !policy.Authorize(f.Ctx, "snapshot:create") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	id := f.Ctx.Input.Param(":fileshareId")
+
+	if _, err := db.C.GetFileShare(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	var snapshot = model.FileShareSnapshotSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	raw, _ := ioutil.ReadAll(f.Ctx.Request.Body)
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &snapshot); err != nil {
+			errMsg := fmt.Sprintf("parse fileshare snapshot request body failed: %s", err.Error())
+			f.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+	if snapshot.FileShareId != "" && snapshot.FileShareId != id {
+		errMsg := fmt.Sprintf("fileshare id %s in the request body does not match the route", snapshot.FileShareId)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	snapshot.FileShareId = id
+
+	rewritten, _ := json.Marshal(&snapshot)
+	f.Ctx.Request.Body = ioutil.NopCloser(bytes.NewReader(rewritten))
+	f.CreateFileShareSnapshot()
+}
+
+// ListNestedFileShareSnapshots lists the snapshots of one fileshare under
+// its parent route.
+func (f *FileShareSnapshotPortal) ListNestedFileShareSnapshots() {
+	if !policy.Authorize(f.Ctx, "snapshot:list") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	id := f.Ctx.Input.Param(":fileshareId")
+
+	if _, err := db.C.GetFileShare(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	result, err := db.C.ListSnapshotsByShareId(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("list fileshare snapshots failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	f.SuccessHandleList(StatusOK, result)
+	return
+}
+
+// CreateNestedFileShareAcl creates an acl under its parent fileshare route.
+// It validates that the parent exists and belongs to the caller and then
+// hands the request to the flat create handler with the fileshare id taken
+// from the route.
+func (f *FileSharePortal) CreateNestedFileShareAcl() {
+	if !policy.Authorize(f.Ctx, "fileshareacl:create") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	id := f.Ctx.Input.Param(":fileshareId")
+
+	if _, err := db.C.GetFileShare(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	var fileshareacl = model.FileShareAclSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	raw, _ := ioutil.ReadAll(f.Ctx.Request.Body)
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &fileshareacl); err != nil {
+			errMsg := fmt.Sprintf("parse fileshare access rules request body failed: %s", err.Error())
+			f.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+	if fileshareacl.FileShareId != "" && fileshareacl.FileShareId != id {
+		errMsg := fmt.Sprintf("fileshare id %s in the request body does not match the route", fileshareacl.FileShareId)
+		f.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	fileshareacl.FileShareId = id
+
+	rewritten, _ := json.Marshal(&fileshareacl)
+	f.Ctx.Request.Body = ioutil.NopCloser(bytes.NewReader(rewritten))
+	f.CreateFileShareAcl()
+}
+
+// ListNestedFileShareAcls lists the acls of one fileshare under its parent
+// route.
+func (f *FileSharePortal) ListNestedFileShareAcls() {
+	if !policy.Authorize(f.Ctx, "fileshareacl:list") {
+		return
+	}
+	ctx := c.GetContext(f.Ctx)
+	id := f.Ctx.Input.Param(":fileshareId")
+
+	if _, err := db.C.GetFileShare(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("fileshare %s not found: %s", id, err.Error())
+		f.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	result, err := db.C.ListFileShareAclsByShareId(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("list fileshare access rules failed: %s", err.Error())
+		f.ErrorHandle(model.ErrorInternalServer, errMsg)
 		return
 	}
 
+	f.SuccessHandleList(StatusOK, result)
 	return
 }
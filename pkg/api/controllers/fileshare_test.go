@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/astaxie/beego"
 	"github.com/astaxie/beego/context"
@@ -29,6 +30,7 @@ import (
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
 	pb "github.com/sodafoundation/api/pkg/model/proto"
+	"github.com/sodafoundation/api/pkg/utils"
 	. "github.com/sodafoundation/api/testutils/collection"
 	ctrtest "github.com/sodafoundation/api/testutils/controller/testing"
 	dbtest "github.com/sodafoundation/api/testutils/db/testing"
@@ -42,11 +44,29 @@ func init() {
 		"post:CreateFileShare;get:ListFileShares")
 	beego.Router("/v1beta/file/shares/:fileshareId", NewFakeFileSharePortal(),
 		"get:GetFileShare;put:UpdateFileShare;delete:DeleteFileShare")
+	beego.Router("/v1beta/file/shares/:fileshareId/metadata", NewFakeFileSharePortal(),
+		"post:UpdateFileShareMetadata")
+	beego.Router("/v1beta/file/shares/:fileshareId/lock", NewFakeFileSharePortal(),
+		"post:LockFileShare")
+	beego.Router("/v1beta/file/shares/:fileshareId/unlock", NewFakeFileSharePortal(),
+		"post:UnlockFileShare")
+	beego.Router("/v1beta/file/shares/:fileshareId/publish", NewFakeFileSharePortal(),
+		"post:PublishFileShare")
+	beego.Router("/v1beta/file/shares/:fileshareId/unpublish", NewFakeFileSharePortal(),
+		"post:UnpublishFileShare")
+	beego.Router("/v1beta/file/shares/:fileshareId/revoke-all-access", NewFakeFileSharePortal(),
+		"post:RevokeAllFileShareAccess")
+	beego.Router("/v1beta/file/shares/:fileshareId/lineage", NewFakeFileSharePortal(),
+		"get:GetFileShareLineage")
+	beego.Router("/v1beta/file/shares/:fileshareId/annotations", NewFakeFileSharePortal(),
+		"post:CreateFileShareAnnotation;get:ListFileShareAnnotations")
 
 	beego.Router("/v1beta/file/snapshots", &FileShareSnapshotPortal{},
 		"post:CreateFileShareSnapshot;get:ListFileShareSnapshots")
 	beego.Router("/v1beta/file/snapshots/:snapshotId", &FileShareSnapshotPortal{},
 		"get:GetFileShareSnapshot;put:UpdateFileShareSnapshot;delete:DeleteFileShareSnapshot")
+	beego.Router("/v1beta/file/snapshots/:snapshotId/copy", &FileShareSnapshotPortal{},
+		"post:CopyFileShareSnapshot")
 
 }
 
@@ -61,6 +81,17 @@ func NewFakeFileSharePortal() *FileSharePortal {
 	mockClient.On("DeleteFileShare", ctx.Background(), &pb.DeleteFileShareOpts{
 		Context: c.NewAdminContext().ToJson(),
 	}).Return(&pb.GenericResponse{}, nil)
+	mockClient.On("DeleteFileShareAcl", ctx.Background(), &pb.DeleteFileShareAclOpts{
+		Id:               "6ad25d59-a160-45b2-8920-211be282e2df",
+		FileshareId:      "d2975ebe-d82c-430f-b28e-f373746a71ca",
+		Description:      "This is a sample Acl for testing",
+		Type:             "ip",
+		AccessCapability: []string{"Read", "Write"},
+		AccessTo:         "10.32.109.15",
+		Metadata:         map[string]string{},
+		Context:          c.NewAdminContext().ToJson(),
+		Profile:          SampleProfiles[0].ToJson(),
+	}).Return(&pb.GenericResponse{}, nil)
 
 	return &FileSharePortal{
 		CtrClient: mockClient,
@@ -134,6 +165,73 @@ func TestListFileShares(t *testing.T) {
 		beego.BeeApp.Handlers.ServeHTTP(w, r)
 		assertTestResult(t, w.Code, 500)
 	})
+
+	t.Run("Should return 200 and redacted public fileshares for public=true", func(t *testing.T) {
+		published := SampleFileShares[0]
+		published.IsPublic = true
+		mockClient := new(dbtest.Client)
+		// The public listing spans all tenants, so the handler queries with
+		// an admin context whatever the caller's tenant is.
+		mockClient.On("ListFileSharesWithFilter", c.NewAdminContext(), map[string][]string{}).Return(
+			[]*model.FileShareSpec{&published, &SampleFileShares[1]}, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/file/shares?public=true", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "other-tenant", UserId: "2f9c0a04"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var expected = []*model.FileShareSpec{
+			{
+				BaseModel:        &model.BaseModel{Id: "d2975ebe-d82c-430f-b28e-f373746a71ca"},
+				Name:             "sample-fileshare-01",
+				Description:      "This is first sample fileshare for testing",
+				Size:             int64(1),
+				Status:           "available",
+				AvailabilityZone: "default",
+				IsPublic:         true,
+			},
+		}
+		var output []*model.FileShareSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, expected)
+	})
+
+	t.Run("Should return 200 and fileshare tombstones if an admin asks for deleted=true", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListTombstones", c.NewAdminContext()).Return(
+			[]*model.TombstoneSpec{&SampleTombstones[0], &SampleTombstones[1]}, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/file/shares?deleted=true", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var expected = []*model.TombstoneSpec{&SampleTombstones[0]}
+		var output []*model.TombstoneSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, expected)
+	})
+
+	t.Run("Should return 403 if a non admin asks for deleted=true", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/file/shares?deleted=true", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
 }
 
 func TestGetFileShare(t *testing.T) {
@@ -192,6 +290,7 @@ func TestUpdateFileShare(t *testing.T) {
 		fileshare := model.FileShareSpec{BaseModel: &model.BaseModel{}}
 		json.NewDecoder(bytes.NewBuffer(jsonStr)).Decode(&fileshare)
 		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleFileShares[0], nil)
 		mockClient.On("UpdateFileShare", c.NewAdminContext(), &fileshare).Return(&expected, nil)
 		db.C = mockClient
 
@@ -212,6 +311,7 @@ func TestUpdateFileShare(t *testing.T) {
 		fileshare := model.FileShareSpec{BaseModel: &model.BaseModel{}}
 		json.NewDecoder(bytes.NewBuffer(jsonStr)).Decode(&fileshare)
 		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleFileShares[0], nil)
 		mockClient.On("UpdateFileShare", c.NewAdminContext(), &fileshare).Return(nil, errors.New("db error"))
 		db.C = mockClient
 
@@ -224,6 +324,325 @@ func TestUpdateFileShare(t *testing.T) {
 		beego.BeeApp.Handlers.ServeHTTP(w, r)
 		assertTestResult(t, w.Code, 500)
 	})
+
+	t.Run("Should return 423 if the fileshare is locked by another user", func(t *testing.T) {
+		userCtx := &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96", UserId: "2f9c0a04"}
+		locked := SampleFileShares[0]
+		locked.Lock = &model.FileShareLockSpec{Owner: "7d51a0c5", Reason: "migration in progress"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", userCtx, "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&locked, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("PUT", "/v1beta/file/shares/bd5b12a8-a101-11e7-941e-d77981b584d8", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", userCtx)
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 423)
+	})
+
+	t.Run("Should return 400 if the fileshare is retyped to a block profile", func(t *testing.T) {
+		blockProfile := SampleProfiles[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleFileShares[0], nil)
+		mockClient.On("GetProfile", c.NewAdminContext(), blockProfile.Id).Return(&blockProfile, nil)
+		db.C = mockClient
+
+		var retypeStr = []byte(`{"profileId": "` + blockProfile.Id + `"}`)
+		r, _ := http.NewRequest("PUT", "/v1beta/file/shares/bd5b12a8-a101-11e7-941e-d77981b584d8", bytes.NewBuffer(retypeStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestUpdateFileShareMetadata(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		fileshare := SampleFileShares[0]
+		updated := fileshare
+		updated.Metadata = map[string]string{"owner": "team-a"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), fileshare.Id).Return(&fileshare, nil)
+		mockClient.On("UpdateFileShareMetadata", c.NewAdminContext(), fileshare.Id,
+			&model.MetadataUpdateSpec{Metadata: map[string]string{"owner": "team-a"}}).Return(&updated, nil)
+		db.C = mockClient
+
+		var jsonStr = []byte(`{"metadata": {"owner": "team-a"}}`)
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/"+fileshare.Id+"/metadata", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.FileShareSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Metadata["owner"], "team-a")
+	})
+
+	t.Run("Should return 423 if the fileshare is locked by another user", func(t *testing.T) {
+		userCtx := &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96", UserId: "2f9c0a04"}
+		locked := SampleFileShares[0]
+		locked.Lock = &model.FileShareLockSpec{Owner: "7d51a0c5", Reason: "migration in progress"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", userCtx, locked.Id).Return(&locked, nil)
+		db.C = mockClient
+
+		var jsonStr = []byte(`{"metadata": {"owner": "team-a"}}`)
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/"+locked.Id+"/metadata", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", userCtx)
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 423)
+	})
+}
+
+func TestLockFileShare(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		var fileshare = SampleFileShares[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&fileshare, nil)
+		// The handler stamps the lock with its own clock, so accept the
+		// second rolling over between here and the request.
+		now := time.Now()
+		for _, stamp := range []time.Time{now, now.Add(time.Second)} {
+			locked := fileshare
+			locked.Lock = &model.FileShareLockSpec{Reason: "migration in progress", LockedAt: utils.FormatTime(stamp)}
+			mockClient.On("UpdateFileShare", c.NewAdminContext(), &model.FileShareSpec{
+				BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+				Lock:      locked.Lock,
+			}).Return(&locked, nil)
+		}
+		db.C = mockClient
+
+		var jsonStr = []byte(`{"reason": "migration in progress"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/bd5b12a8-a101-11e7-941e-d77981b584d8/lock", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.FileShareSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Lock.Reason, "migration in progress")
+	})
+
+	t.Run("Should return 409 if the fileshare is already locked", func(t *testing.T) {
+		locked := SampleFileShares[0]
+		locked.Lock = &model.FileShareLockSpec{Owner: "7d51a0c5"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&locked, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/bd5b12a8-a101-11e7-941e-d77981b584d8/lock", bytes.NewBuffer([]byte(`{}`)))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+}
+
+func TestUnlockFileShare(t *testing.T) {
+
+	t.Run("Should return 200 if an administrator releases the lock", func(t *testing.T) {
+		locked := SampleFileShares[0]
+		locked.Lock = &model.FileShareLockSpec{Owner: "7d51a0c5"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&locked, nil)
+		// An empty lock releases the lock in the db driver.
+		mockClient.On("UpdateFileShare", c.NewAdminContext(), &model.FileShareSpec{
+			BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+			Lock:      &model.FileShareLockSpec{},
+		}).Return(&SampleFileShares[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/bd5b12a8-a101-11e7-941e-d77981b584d8/unlock", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 200)
+	})
+
+	t.Run("Should return 403 if the user does not hold the lock", func(t *testing.T) {
+		userCtx := &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96", UserId: "2f9c0a04"}
+		locked := SampleFileShares[0]
+		locked.Lock = &model.FileShareLockSpec{Owner: "7d51a0c5"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", userCtx, "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&locked, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/bd5b12a8-a101-11e7-941e-d77981b584d8/unlock", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", userCtx)
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+}
+
+func TestPublishFileShare(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		var fileshare = SampleFileShares[0]
+		published := fileshare
+		published.IsPublic = true
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&fileshare, nil)
+		mockClient.On("UpdateFileSharePublic", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca", true).Return(&published, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/publish", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.FileShareSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.IsPublic, true)
+	})
+
+	t.Run("Should return 400 if the fileshare is already public", func(t *testing.T) {
+		published := SampleFileShares[0]
+		published.IsPublic = true
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&published, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/publish", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestUnpublishFileShare(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		published := SampleFileShares[0]
+		published.IsPublic = true
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&published, nil)
+		mockClient.On("UpdateFileSharePublic", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca", false).Return(&SampleFileShares[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/unpublish", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 200)
+	})
+
+	t.Run("Should return 400 if the fileshare is not public", func(t *testing.T) {
+		var fileshare = SampleFileShares[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&fileshare, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/unpublish", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestRevokeAllFileShareAccess(t *testing.T) {
+
+	t.Run("Should return 202 and mark every acl deleting", func(t *testing.T) {
+		var fileshare = SampleFileShares[0]
+		acl := SampleFileSharesAcl[2]
+		acl.Status = model.FileShareAclAvailable
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&fileshare, nil)
+		mockClient.On("GetProfile", c.NewAdminContext(), "b3585ebe-c42c-120g-b28e-f373746a71ca").Return(&SampleProfiles[0], nil)
+		mockClient.On("ListFileShareAclsByShareId", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(
+			[]*model.FileShareAclSpec{&acl}, nil)
+		mockClient.On("UpdateFileShareAcl", c.NewAdminContext(), &acl).Return(&acl, nil)
+		task := &model.CascadeTaskSpec{BaseModel: &model.BaseModel{}, ResourceType: "fileshare",
+			ResourceId: "d2975ebe-d82c-430f-b28e-f373746a71ca", Action: "revokeAllAccess",
+			Status: model.CascadeTaskRunning, TotalItems: 1}
+		mockClient.On("ListCascadeTasks", c.NewAdminContext()).Return(nil, nil)
+		mockClient.On("CreateCascadeTask", c.NewAdminContext(), task).Return(task, nil)
+		mockClient.On("UpdateCascadeTask", c.NewAdminContext(), task).Return(task, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/revoke-all-access", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.FileShareAclSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 202)
+		assertTestResult(t, len(output), 1)
+		assertTestResult(t, output[0].Status, model.FileShareAclDeleting)
+	})
+
+	t.Run("Should return 202 with an empty list if the fileshare has no acls", func(t *testing.T) {
+		var fileshare = SampleFileShares[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&fileshare, nil)
+		mockClient.On("GetProfile", c.NewAdminContext(), "b3585ebe-c42c-120g-b28e-f373746a71ca").Return(&SampleProfiles[0], nil)
+		mockClient.On("ListFileShareAclsByShareId", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(
+			nil, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/revoke-all-access", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.FileShareAclSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 202)
+		assertTestResult(t, len(output), 0)
+	})
+
+	t.Run("Should return 404 if the fileshare does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(
+			nil, errors.New("specified fileshare(d2975ebe-d82c-430f-b28e-f373746a71ca) can't find"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/revoke-all-access", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -312,6 +731,40 @@ func TestGetFileShareSnapshot(t *testing.T) {
 	})
 }
 
+func TestCopyFileShareSnapshot(t *testing.T) {
+
+	t.Run("Should return 400 if target endpoint is missing", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"authToken": "secret"}`)
+		r, _ := http.NewRequest("POST",
+			"/v1beta/file/snapshots/3769855c-a102-11e7-b772-17b880d2f537/copy", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 404 if the snapshot does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShareSnapshot", c.NewAdminContext(), "3769855c-a102-11e7-b772-17b880d2f537").Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		body := []byte(`{"targetEndpoint": "http://remote-soda:50040"}`)
+		r, _ := http.NewRequest("POST",
+			"/v1beta/file/snapshots/3769855c-a102-11e7-b772-17b880d2f537/copy", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
+
 func TestUpdateFileShareSnapshot(t *testing.T) {
 	var jsonStr = []byte(`{
 		"id": "3769855c-a102-11e7-b772-17b880d2f537",
@@ -368,3 +821,118 @@ func TestUpdateFileShareSnapshot(t *testing.T) {
 		assertTestResult(t, w.Code, 500)
 	})
 }
+
+func TestGetFileShareLineage(t *testing.T) {
+
+	t.Run("Should return 200 and the snapshot tree if everything works well", func(t *testing.T) {
+		var sampleSnapshots = []*model.FileShareSnapshotSpec{&SampleFileShareSnapshots[0], &SampleFileShareSnapshots[1]}
+		var sampleShares = []*model.FileShareSpec{&SampleFileShares[0], &SampleFileShares[1]}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&SampleFileShares[0], nil)
+		mockClient.On("ListFileShareSnapshots", c.NewAdminContext()).Return(sampleSnapshots, nil)
+		mockClient.On("ListFileShares", c.NewAdminContext()).Return(sampleShares, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/lineage", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.FileShareLineageNode
+		json.Unmarshal(w.Body.Bytes(), &output)
+		var expected = model.FileShareLineageNode{
+			Id:     SampleFileShares[0].Id,
+			Name:   SampleFileShares[0].Name,
+			Kind:   model.FileShareLineageKindShare,
+			Status: SampleFileShares[0].Status,
+			Children: []*model.FileShareLineageNode{
+				{
+					Id:     SampleFileShareSnapshots[0].Id,
+					Name:   SampleFileShareSnapshots[0].Name,
+					Kind:   model.FileShareLineageKindSnapshot,
+					Status: SampleFileShareSnapshots[0].Status,
+				},
+			},
+		}
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &expected)
+	})
+
+	t.Run("Should return 404 if the fileshare does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/lineage", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
+
+func TestFileShareAnnotations(t *testing.T) {
+
+	t.Run("Should return 200 when appending an annotation", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&SampleFileShares[0], nil)
+		mockClient.On("CreateAnnotation", c.NewAdminContext(), &model.AnnotationSpec{
+			BaseModel:    &model.BaseModel{},
+			ResourceType: model.AnnotationResourceFileShare,
+			ResourceId:   "d2975ebe-d82c-430f-b28e-f373746a71ca",
+			Note:         "extended per ticket 4521",
+		}).Return(&SampleAnnotations[0], nil)
+		db.C = mockClient
+
+		body := []byte(`{"note": "extended per ticket 4521"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/annotations", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.AnnotationSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleAnnotations[0])
+	})
+
+	t.Run("Should return 400 if the note is empty", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"note": ""}`)
+		r, _ := http.NewRequest("POST", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/annotations", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 200 and the annotations when listing them", func(t *testing.T) {
+		var sampleAnnotations = []*model.AnnotationSpec{&SampleAnnotations[0]}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", c.NewAdminContext(), "d2975ebe-d82c-430f-b28e-f373746a71ca").Return(&SampleFileShares[0], nil)
+		mockClient.On("ListAnnotations", c.NewAdminContext(), model.AnnotationResourceFileShare,
+			"d2975ebe-d82c-430f-b28e-f373746a71ca").Return(sampleAnnotations, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/file/shares/d2975ebe-d82c-430f-b28e-f373746a71ca/annotations", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.AnnotationSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, sampleAnnotations)
+	})
+}
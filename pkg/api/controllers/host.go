@@ -20,21 +20,36 @@ This module implements a entry into the OpenSDS northbound service.
 package controllers
 
 import (
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
+	"strings"
 
+	log "github.com/golang/glog"
 	"github.com/sodafoundation/api/pkg/api/policy"
+	"github.com/sodafoundation/api/pkg/api/util"
 	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/api/controllerclient"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
+	pb "github.com/sodafoundation/api/pkg/model/proto"
+	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
 )
 
 type HostPortal struct {
 	BasePortal
+
+	CtrClient client.Client
 }
 
 func NewHostPortal() *HostPortal {
-	return &HostPortal{}
+	return &HostPortal{
+		CtrClient: client.NewClient(),
+	}
 }
 
 func (p *HostPortal) ListHosts() {
@@ -56,14 +71,7 @@ func (p *HostPortal) ListHosts() {
 		return
 	}
 
-	body, err := json.Marshal(hosts)
-	if err != nil {
-		errMsg := fmt.Sprintf("marshal hosts failed: %s", err.Error())
-		p.ErrorHandle(model.ErrorBadRequest, errMsg)
-		return
-	}
-
-	p.SuccessHandle(StatusOK, body)
+	p.SuccessHandleList(StatusOK, hosts)
 	return
 }
 
@@ -115,6 +123,155 @@ func (p *HostPortal) CreateHost() {
 	return
 }
 
+// parseHostsCsv reads an import body in csv form. The header row names the
+// columns; hostName is required, ip, osType, accessMode, availabilityZones
+// and initiators are picked up when present. List-valued columns separate
+// their entries with ';', an initiator entry is "portName@protocol".
+func parseHostsCsv(body io.Reader) ([]*model.HostSpec, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("csv body is empty")
+	}
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns["hostName"]; !ok {
+		return nil, fmt.Errorf("csv header must name a hostName column")
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var hosts []*model.HostSpec
+	for _, record := range records[1:] {
+		host := &model.HostSpec{
+			BaseModel:  &model.BaseModel{},
+			HostName:   field(record, "hostName"),
+			IP:         field(record, "ip"),
+			OsType:     field(record, "osType"),
+			AccessMode: field(record, "accessMode"),
+		}
+		if azs := field(record, "availabilityZones"); azs != "" {
+			host.AvailabilityZones = strings.Split(azs, ";")
+		}
+		if initiators := field(record, "initiators"); initiators != "" {
+			for _, entry := range strings.Split(initiators, ";") {
+				parts := strings.SplitN(entry, "@", 2)
+				initiator := &model.Initiator{PortName: parts[0]}
+				if len(parts) == 2 {
+					initiator.Protocol = parts[1]
+				}
+				host.Initiators = append(host.Initiators, initiator)
+			}
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// ImportHosts registers a whole list of hosts in one request, so onboarding
+// a large cluster does not take one call per node. The body is either a
+// json array of host specs or csv, see parseHostsCsv. All rows are
+// validated and checked for duplicates up front; when any row is bad the
+// import creates nothing and returns the per-row report with 400, so a
+// fixed list can be resubmitted as one unit.
+func (p *HostPortal) ImportHosts() {
+	if !policy.Authorize(p.Ctx, "host:import") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+
+	var hosts []*model.HostSpec
+	var err error
+	if strings.Contains(p.Ctx.Input.Header("Content-Type"), "text/csv") {
+		hosts, err = parseHostsCsv(p.Ctx.Request.Body)
+	} else {
+		err = json.NewDecoder(p.Ctx.Request.Body).Decode(&hosts)
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("parse host import request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if len(hosts) == 0 {
+		errMsg := "host import list is empty"
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	existing, err := db.C.ListHosts(ctx, map[string][]string{})
+	if err != nil {
+		errMsg := fmt.Sprintf("check existing hosts failed in ImportHosts method: %v", err)
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	names := map[string]bool{}
+	for _, host := range existing {
+		names[host.HostName] = true
+	}
+
+	var report = &model.HostImportResultSpec{}
+	for i, host := range hosts {
+		if host.BaseModel == nil {
+			host.BaseModel = &model.BaseModel{}
+		}
+		row := model.HostImportRowSpec{
+			Row:      i + 1,
+			HostName: host.HostName,
+			Status:   model.HostImportRowCreated,
+		}
+		switch {
+		case host.HostName == "":
+			row.Error = "hostName is required"
+		case host.IP != "" && net.ParseIP(host.IP) == nil:
+			row.Error = fmt.Sprintf("ip %s is not a valid address", host.IP)
+		case names[host.HostName]:
+			row.Error = fmt.Sprintf("the host with name %s already exists in the system or earlier in the list", host.HostName)
+		}
+		if row.Error != "" {
+			row.Status = model.HostImportRowError
+			report.Failed++
+		} else {
+			// The name also counts as taken for the rest of the list.
+			names[host.HostName] = true
+		}
+		report.Rows = append(report.Rows, row)
+	}
+	if report.Failed > 0 {
+		body, _ := json.Marshal(report)
+		p.Ctx.Output.SetStatus(model.ErrorBadRequest)
+		p.Ctx.Output.Header("Content-Type", "application/json; charset=utf-8")
+		p.Ctx.Output.Body(body)
+		return
+	}
+
+	for i, host := range hosts {
+		result, err := db.C.CreateHost(ctx, host)
+		if err != nil {
+			report.Rows[i].Status = model.HostImportRowError
+			report.Rows[i].Error = fmt.Sprintf("create host failed: %v", err)
+			report.Failed++
+			continue
+		}
+		report.Rows[i].HostId = result.Id
+		report.Created++
+	}
+
+	body, _ := json.Marshal(report)
+	p.SuccessHandle(StatusOK, body)
+	return
+}
+
 func (p *HostPortal) GetHost() {
 	if !policy.Authorize(p.Ctx, "host:get") {
 		return
@@ -181,34 +338,172 @@ func (p *HostPortal) DeleteHost() {
 	if !policy.Authorize(p.Ctx, "host:delete") {
 		return
 	}
+	ctx := c.GetContext(p.Ctx)
 	id := p.Ctx.Input.Param(":hostId")
-	host, err := db.C.GetHost(c.GetContext(p.Ctx), id)
+	cascade := p.Ctx.Input.Query("cascade") == "true"
+	host, err := db.C.GetHost(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("host %s not found: %s", id, err.Error())
-		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		p.DeleteNotFoundHandle(errMsg)
 		return
 	}
 
 	// Check relationship with volume
-	attachments, err := db.C.ListVolumeAttachmentsWithFilter(c.GetContext(p.Ctx), map[string][]string{"hostId": []string{id}})
+	attachments, err := db.C.ListVolumeAttachmentsWithFilter(ctx, map[string][]string{"hostId": []string{id}})
 	if err != nil {
 		errMsg := fmt.Sprintf("list attachments failed in DeleteHost method: %v", err)
 		p.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
-	if len(attachments) > 0 {
-		errMsg := fmt.Sprintf("some volumes are attached to host: %s, please detach them first", host.HostName)
+	if len(attachments) > 0 && !cascade {
+		errMsg := fmt.Sprintf("some volumes are attached to host: %s, please detach them first or delete with cascade=true", host.HostName)
 		p.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
 
-	err = db.C.DeleteHost(c.GetContext(p.Ctx), id)
-	if err != nil {
+	// With cascade the fileshare access rules granted to the host are
+	// removed together with the attachments before the host disappears.
+	var acls []*model.FileShareAclSpec
+	if cascade && host.IP != "" {
+		acls, err = db.C.ListFileSharesAclWithFilter(ctx, map[string][]string{"accessTo": []string{host.IP}})
+		if err != nil {
+			errMsg := fmt.Sprintf("list fileshare acls failed in DeleteHost method: %v", err)
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+
+	// NOTE: It will mark the attachments and access rules which depend on
+	// the host for deletion in the database and return the result
+	// immediately, the real detach operations run against the dock below.
+	for _, attachment := range attachments {
+		attachment.Status = model.VolumeAttachDeleting
+		if _, err := db.C.UpdateVolumeAttachment(ctx, attachment.Id, attachment); err != nil {
+			errMsg := fmt.Sprintf("failed to update volume attachment %s: %v", attachment.Id, err)
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+	for _, acl := range acls {
+		if err := util.DeleteFileShareAclDBEntry(ctx, acl); err != nil {
+			errMsg := fmt.Sprintf("delete fileshare acl %s failed: %v", acl.Id, err)
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+
+	if err := db.C.DeleteHost(ctx, id); err != nil {
 		errMsg := fmt.Sprintf("delete host failed: %v", err)
 		p.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
 
-	p.SuccessHandle(StatusOK, nil)
-	return
+	if len(attachments) == 0 && len(acls) == 0 {
+		p.SuccessHandle(StatusOK, nil)
+		return
+	}
+	p.SuccessHandle(StatusAccepted, nil)
+
+	// NOTE: The real volume detach and access rule removal process.
+	// The requests are sent to the Dock, which removes the resources from
+	// the database or marks them with an error status if the driver fails.
+	if err := p.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+
+	var initiators []*pb.Initiator
+	for _, e := range host.Initiators {
+		initiators = append(initiators, &pb.Initiator{
+			PortName: e.PortName,
+			Protocol: e.Protocol,
+		})
+	}
+
+	// The dependent items are worked through in configured batches with
+	// bounded parallelism, checkpointing progress in the cascade task.
+	attachmentById := map[string]*model.VolumeAttachmentSpec{}
+	aclById := map[string]*model.FileShareAclSpec{}
+	var items []string
+	for _, attachment := range attachments {
+		attachmentById[attachment.Id] = attachment
+		items = append(items, attachment.Id)
+	}
+	for _, acl := range acls {
+		aclById[acl.Id] = acl
+		items = append(items, acl.Id)
+	}
+	task := util.StartCascadeTask(ctx, "host", id, "cascadeDelete", int64(len(items)))
+	util.NewCascadeRunner().Run(ctx, task, items, func(itemId string) error {
+		if attachment, ok := attachmentById[itemId]; ok {
+			return p.dispatchDeleteHostAttachment(ctx, host, initiators, attachment)
+		}
+		return p.dispatchDeleteHostAcl(ctx, aclById[itemId])
+	})
+}
+
+// dispatchDeleteHostAttachment sends the detach of one attachment of a
+// cascade host deletion to the controller.
+func (p *HostPortal) dispatchDeleteHostAttachment(ctx *c.Context, host *model.HostSpec,
+	initiators []*pb.Initiator, attachment *model.VolumeAttachmentSpec) error {
+	vol, err := db.C.GetVolume(ctx, attachment.VolumeId)
+	if err != nil {
+		return fmt.Errorf("get volume %s failed in DeleteHost method: %v", attachment.VolumeId, err)
+	}
+	opt := &pb.DeleteVolumeAttachmentOpts{
+		Id:             attachment.Id,
+		VolumeId:       attachment.VolumeId,
+		PoolId:         vol.PoolId,
+		AccessProtocol: attachment.AccessProtocol,
+		HostInfo: &pb.HostInfo{
+			OsType:     host.OsType,
+			Ip:         host.IP,
+			Host:       host.HostName,
+			Initiators: initiators,
+		},
+		Metadata: vol.Metadata,
+		Context:  ctx.ToJson(),
+	}
+	response, err := p.CtrClient.DeleteVolumeAttachment(context.Background(), opt)
+	if err != nil {
+		return fmt.Errorf("delete volume attachment failed in controller service: %v", err)
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return fmt.Errorf("failed to delete volume attachment in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+	return nil
+}
+
+// dispatchDeleteHostAcl sends the removal of one access rule of a cascade
+// host deletion to the controller.
+func (p *HostPortal) dispatchDeleteHostAcl(ctx *c.Context, acl *model.FileShareAclSpec) error {
+	fileshare, err := db.C.GetFileShare(ctx, acl.FileShareId)
+	if err != nil {
+		return fmt.Errorf("fileshare for the acl %s not found: %v", acl.Id, err)
+	}
+	prf, err := db.C.GetProfile(ctx, fileshare.ProfileId)
+	if err != nil {
+		return fmt.Errorf("get profile failed in DeleteHost method: %v", err)
+	}
+	opt := &pb.DeleteFileShareAclOpts{
+		Id:               acl.Id,
+		FileshareId:      acl.FileShareId,
+		Description:      acl.Description,
+		Type:             acl.Type,
+		AccessCapability: acl.AccessCapability,
+		AccessTo:         acl.AccessTo,
+		Metadata:         utils.MergeStringMaps(fileshare.Metadata, acl.Metadata),
+		Context:          ctx.ToJson(),
+		Profile:          prf.ToJson(),
+	}
+	response, err := p.CtrClient.DeleteFileShareAcl(context.Background(), opt)
+	if err != nil {
+		return fmt.Errorf("delete fileshare acl failed in controller service: %v", err)
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		return fmt.Errorf("failed to delete fileshare acl in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+	return nil
 }
@@ -0,0 +1,768 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS northbound service.
+
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/controllerclient"
+	"github.com/sodafoundation/api/pkg/api/policy"
+	"github.com/sodafoundation/api/pkg/api/util"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	pb "github.com/sodafoundation/api/pkg/model/proto"
+	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+func NewHostGroupPortal() *HostGroupPortal {
+	return &HostGroupPortal{
+		CtrClient: client.NewClient(),
+	}
+}
+
+type HostGroupPortal struct {
+	BasePortal
+
+	CtrClient client.Client
+}
+
+// resolveGroupHosts deduplicates the given host ids and resolves every one
+// of them to a registered host.
+func resolveGroupHosts(ctx *c.Context, hostIds []string) ([]string, []*model.HostSpec, error) {
+	var ids []string
+	var hosts []*model.HostSpec
+	seen := map[string]bool{}
+	for _, id := range hostIds {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		host, err := db.C.GetHost(ctx, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("host %s not found: %v", id, err)
+		}
+		ids = append(ids, id)
+		hosts = append(hosts, host)
+	}
+	return ids, hosts, nil
+}
+
+func (p *HostGroupPortal) CreateHostGroup() {
+	if !policy.Authorize(p.Ctx, "host_group:create") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	var hg = model.HostGroupSpec{
+		BaseModel: &model.BaseModel{},
+	}
+
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&hg); err != nil {
+		errMsg := fmt.Sprintf("parse host group request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if hg.Name == "" {
+		errMsg := "host group name can not be empty"
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	ids, _, err := resolveGroupHosts(ctx, hg.HostIds)
+	if err != nil {
+		errMsg := fmt.Sprintf("create host group failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	hg.HostIds = ids
+
+	result, err := db.C.CreateHostGroup(ctx, &hg)
+	if err != nil {
+		errMsg := fmt.Sprintf("create host group failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	body, _ := json.Marshal(result)
+	p.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (p *HostGroupPortal) ListHostGroups() {
+	if !policy.Authorize(p.Ctx, "host_group:list") {
+		return
+	}
+
+	result, err := db.C.ListHostGroups(c.GetContext(p.Ctx))
+	if err != nil {
+		errMsg := fmt.Sprintf("list host groups failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	p.SuccessHandleList(StatusOK, result)
+	return
+}
+
+func (p *HostGroupPortal) GetHostGroup() {
+	if !policy.Authorize(p.Ctx, "host_group:get") {
+		return
+	}
+	id := p.Ctx.Input.Param(":groupId")
+
+	result, err := db.C.GetHostGroup(c.GetContext(p.Ctx), id)
+	if err != nil {
+		errMsg := fmt.Sprintf("host group %s not found: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	body, _ := json.Marshal(result)
+	p.SuccessHandle(StatusOK, body)
+	return
+}
+
+// UpdateHostGroup updates the name, description or membership of a host
+// group. When the membership changes, the acls and attachments that were
+// created through the group are expanded to hosts that joined and removed
+// from hosts that left, so group grants always match the current members.
+func (p *HostGroupPortal) UpdateHostGroup() {
+	if !policy.Authorize(p.Ctx, "host_group:update") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	id := p.Ctx.Input.Param(":groupId")
+	var hg = model.HostGroupSpec{
+		BaseModel: &model.BaseModel{},
+	}
+
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&hg); err != nil {
+		errMsg := fmt.Sprintf("parse host group request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	hg.Id = id
+
+	existing, err := db.C.GetHostGroup(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("host group %s not found: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	if hg.HostIds != nil {
+		ids, _, err := resolveGroupHosts(ctx, hg.HostIds)
+		if err != nil {
+			errMsg := fmt.Sprintf("update host group failed: %s", err.Error())
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+		if ids == nil {
+			ids = []string{}
+		}
+		hg.HostIds = ids
+	}
+
+	result, err := db.C.UpdateHostGroup(ctx, &hg)
+	if err != nil {
+		errMsg := fmt.Sprintf("update host group failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	body, _ := json.Marshal(result)
+	p.SuccessHandle(StatusOK, body)
+
+	// NOTE: The real expansion sync process. Changed membership is pushed to
+	// the Dock per host; failures are logged and retried on the next update.
+	if hg.HostIds != nil {
+		added, removed := diffMembers(existing.HostIds, result.HostIds)
+		if len(added) > 0 || len(removed) > 0 {
+			p.syncGroupExpansions(ctx, result, added, removed)
+		}
+	}
+	return
+}
+
+func (p *HostGroupPortal) DeleteHostGroup() {
+	if !policy.Authorize(p.Ctx, "host_group:delete") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	id := p.Ctx.Input.Param(":groupId")
+
+	if _, err := db.C.GetHostGroup(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("host group %s not found: %s", id, err.Error())
+		p.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	// A group with live grants can not be deleted, otherwise the per-host
+	// acls and attachments created through it would be orphaned.
+	atts, acls, err := listGroupExpansions(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("delete host group failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	if len(atts) > 0 || len(acls) > 0 {
+		errMsg := fmt.Sprintf("host group %s still has %d attachments and %d acls created through it, delete those first", id, len(atts), len(acls))
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if err := db.C.DeleteHostGroup(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("delete host group failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	p.SuccessHandle(StatusOK, nil)
+	return
+}
+
+// AttachVolumeToHostGroup expands one attach request into one attachment per
+// member host. The attachments carry the group id, so they follow membership
+// changes and block the group from being deleted while they exist.
+func (p *HostGroupPortal) AttachVolumeToHostGroup() {
+	if !policy.Authorize(p.Ctx, "volume:create_attachment") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	id := p.Ctx.Input.Param(":groupId")
+
+	hg, err := db.C.GetHostGroup(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("host group %s not found: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if len(hg.HostIds) == 0 {
+		errMsg := fmt.Sprintf("host group %s has no member hosts", id)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	var attachment = model.VolumeAttachmentSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&attachment); err != nil {
+		errMsg := fmt.Sprintf("parse volume attachment request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	vol, err := db.C.GetVolume(ctx, attachment.VolumeId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get volume failed in attach volume to host group method: %v", err)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if len(hg.HostIds) > 1 && !vol.MultiAttach {
+		errMsg := fmt.Sprintf("volume %s multiattach must be true to attach it to the %d hosts of group %s", vol.Id, len(hg.HostIds), id)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if vol.Status != model.VolumeAvailable && !(vol.Status == model.VolumeInUse && vol.MultiAttach) {
+		errMsg := fmt.Sprintf("status of volume is %s. It can not be attached to the host group", vol.Status)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	_, hosts, err := resolveGroupHosts(ctx, hg.HostIds)
+	if err != nil {
+		errMsg := fmt.Sprintf("attach volume to host group failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	for _, host := range hosts {
+		if !utils.Contains(host.AvailabilityZones, vol.AvailabilityZone) {
+			errMsg := fmt.Sprintf("availability zone of volume: %s is not in the availability zones of host %s: %v",
+				vol.AvailabilityZone, host.Id, host.AvailabilityZones)
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+
+	// Set AccessProtocol
+	pol, err := db.C.GetPool(ctx, vol.PoolId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get pool failed in attach volume to host group method: %v", err)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	var protocol = pol.Extras.IOConnectivity.AccessProtocol
+	if protocol == "" {
+		// Default protocol is iscsi
+		protocol = constants.ISCSIProtocol
+	}
+	if attachment.AttachMode != "ro" && attachment.AttachMode != "rw" {
+		attachment.AttachMode = "rw"
+	}
+
+	db.UpdateVolumeStatus(ctx, db.C, vol.Id, model.VolumeAttaching)
+
+	var results []*model.VolumeAttachmentSpec
+	for _, host := range hosts {
+		att := &model.VolumeAttachmentSpec{
+			BaseModel:      &model.BaseModel{},
+			VolumeId:       vol.Id,
+			HostId:         host.Id,
+			HostGroupId:    hg.Id,
+			AccessProtocol: protocol,
+			AttachMode:     attachment.AttachMode,
+			Status:         model.VolumeAttachCreating,
+		}
+		result, err := db.C.CreateVolumeAttachment(ctx, att)
+		if err != nil {
+			errMsg := fmt.Sprintf("create volume attachment for host %s failed: %s", host.Id, err.Error())
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+		results = append(results, result)
+	}
+
+	body, _ := json.Marshal(results)
+	p.SuccessHandle(StatusAccepted, body)
+
+	// NOTE: The real attachment creation process, one dock request per host,
+	// exactly like a single-host attach.
+	if err := p.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+	for i, host := range hosts {
+		p.requestAttachmentCreation(ctx, results[i], vol, host)
+	}
+	return
+}
+
+// GrantFileShareToHostGroup expands one acl request into one ip based acl
+// per member host. The acls carry the group id, so they follow membership
+// changes and block the group from being deleted while they exist.
+func (p *HostGroupPortal) GrantFileShareToHostGroup() {
+	if !policy.Authorize(p.Ctx, "fileshareacl:create") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	id := p.Ctx.Input.Param(":groupId")
+
+	hg, err := db.C.GetHostGroup(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("host group %s not found: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	if len(hg.HostIds) == 0 {
+		errMsg := fmt.Sprintf("host group %s has no member hosts", id)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	var acl = model.FileShareAclSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&acl); err != nil {
+		errMsg := fmt.Sprintf("parse fileshare access rules request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	fileshare, err := db.C.GetFileShare(ctx, acl.FileShareId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get fileshare failed in grant fileshare to host group method: %v", err)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if len(acl.ProfileId) == 0 {
+		acl.ProfileId = fileshare.ProfileId
+	}
+	prf, err := db.C.GetProfile(ctx, acl.ProfileId)
+	if err != nil {
+		errMsg := fmt.Sprintf("get profile failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	_, hosts, err := resolveGroupHosts(ctx, hg.HostIds)
+	if err != nil {
+		errMsg := fmt.Sprintf("grant fileshare to host group failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	var results []*model.FileShareAclSpec
+	for _, host := range hosts {
+		hostAcl := &model.FileShareAclSpec{
+			BaseModel:        &model.BaseModel{},
+			FileShareId:      fileshare.Id,
+			HostGroupId:      hg.Id,
+			Type:             "ip",
+			AccessTo:         host.IP,
+			AccessCapability: acl.AccessCapability,
+			Description:      acl.Description,
+			ProfileId:        acl.ProfileId,
+			Metadata:         map[string]string{"hostId": host.Id},
+		}
+		result, err := util.CreateFileShareAclDBEntry(ctx, hostAcl)
+		if err != nil {
+			errMsg := fmt.Sprintf("create fileshare acl for host %s failed: %s", host.Id, err.Error())
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+		results = append(results, result)
+	}
+
+	body, _ := json.Marshal(results)
+	p.SuccessHandle(StatusAccepted, body)
+
+	// NOTE: The real acl creation process, one dock request per host,
+	// exactly like a single-host acl.
+	if err := p.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+	for _, result := range results {
+		p.requestAclCreation(ctx, result, fileshare, prf)
+	}
+	return
+}
+
+// diffMembers returns the host ids present only in the new membership and
+// the ones present only in the old membership.
+func diffMembers(old, new []string) (added, removed []string) {
+	oldSet := map[string]bool{}
+	for _, id := range old {
+		oldSet[id] = true
+	}
+	newSet := map[string]bool{}
+	for _, id := range new {
+		newSet[id] = true
+		if !oldSet[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range old {
+		if !newSet[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+// listGroupExpansions returns the attachments and acls that were created
+// through the given host group.
+func listGroupExpansions(ctx *c.Context, groupId string) ([]*model.VolumeAttachmentSpec, []*model.FileShareAclSpec, error) {
+	allAtts, err := db.C.ListVolumeAttachments(ctx, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	var atts []*model.VolumeAttachmentSpec
+	for _, att := range allAtts {
+		if att.HostGroupId == groupId {
+			atts = append(atts, att)
+		}
+	}
+	allAcls, err := db.C.ListFileSharesAcl(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var acls []*model.FileShareAclSpec
+	for _, acl := range allAcls {
+		if acl.HostGroupId == groupId {
+			acls = append(acls, acl)
+		}
+	}
+	return atts, acls, nil
+}
+
+// syncGroupExpansions brings the attachments and acls created through the
+// group in line with the new membership: hosts that joined get the grants
+// every member has, hosts that left lose theirs. It runs after the update
+// response was already sent, so problems are only logged.
+func (p *HostGroupPortal) syncGroupExpansions(ctx *c.Context, hg *model.HostGroupSpec, added, removed []string) {
+	atts, acls, err := listGroupExpansions(ctx, hg.Id)
+	if err != nil {
+		log.Errorf("when listing expansions of host group %s: %v", hg.Id, err)
+		return
+	}
+	if len(atts) == 0 && len(acls) == 0 {
+		return
+	}
+	if err := p.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+
+	for _, hostId := range removed {
+		for _, att := range atts {
+			if att.HostId == hostId {
+				p.removeGroupAttachment(ctx, att)
+			}
+		}
+		for _, acl := range acls {
+			if acl.Metadata["hostId"] == hostId {
+				p.revokeGroupAcl(ctx, acl)
+			}
+		}
+	}
+
+	for _, hostId := range added {
+		host, err := db.C.GetHost(ctx, hostId)
+		if err != nil {
+			log.Errorf("when getting host %s joining group %s: %v", hostId, hg.Id, err)
+			continue
+		}
+		p.expandAttachmentsToHost(ctx, hg, atts, host)
+		p.expandAclsToHost(ctx, hg, acls, host)
+	}
+}
+
+// expandAttachmentsToHost creates an attachment on the joining host for
+// every volume the group is attached to.
+func (p *HostGroupPortal) expandAttachmentsToHost(ctx *c.Context, hg *model.HostGroupSpec, atts []*model.VolumeAttachmentSpec, host *model.HostSpec) {
+	seen := map[string]bool{}
+	for _, att := range atts {
+		if att.HostId == host.Id || seen[att.VolumeId] {
+			continue
+		}
+		seen[att.VolumeId] = true
+
+		vol, err := db.C.GetVolume(ctx, att.VolumeId)
+		if err != nil {
+			log.Errorf("when getting volume %s of host group %s: %v", att.VolumeId, hg.Id, err)
+			continue
+		}
+		if !vol.MultiAttach {
+			log.Warningf("volume %s is not multiattach, not attaching it to host %s joining group %s", vol.Id, host.Id, hg.Id)
+			continue
+		}
+		if !utils.Contains(host.AvailabilityZones, vol.AvailabilityZone) {
+			log.Warningf("availability zone of volume %s is not served by host %s joining group %s", vol.Id, host.Id, hg.Id)
+			continue
+		}
+		newAtt := &model.VolumeAttachmentSpec{
+			BaseModel:      &model.BaseModel{},
+			VolumeId:       vol.Id,
+			HostId:         host.Id,
+			HostGroupId:    hg.Id,
+			AccessProtocol: att.AccessProtocol,
+			AttachMode:     att.AttachMode,
+			Status:         model.VolumeAttachCreating,
+		}
+		result, err := db.C.CreateVolumeAttachment(ctx, newAtt)
+		if err != nil {
+			log.Errorf("when creating attachment for host %s joining group %s: %v", host.Id, hg.Id, err)
+			continue
+		}
+		p.requestAttachmentCreation(ctx, result, vol, host)
+	}
+}
+
+// expandAclsToHost creates an acl on the joining host for every fileshare
+// the group was granted.
+func (p *HostGroupPortal) expandAclsToHost(ctx *c.Context, hg *model.HostGroupSpec, acls []*model.FileShareAclSpec, host *model.HostSpec) {
+	seen := map[string]bool{}
+	for _, acl := range acls {
+		if acl.Metadata["hostId"] == host.Id || seen[acl.FileShareId] {
+			continue
+		}
+		seen[acl.FileShareId] = true
+
+		fileshare, err := db.C.GetFileShare(ctx, acl.FileShareId)
+		if err != nil {
+			log.Errorf("when getting fileshare %s of host group %s: %v", acl.FileShareId, hg.Id, err)
+			continue
+		}
+		prf, err := db.C.GetProfile(ctx, acl.ProfileId)
+		if err != nil {
+			log.Errorf("when getting profile %s of host group acl %s: %v", acl.ProfileId, acl.Id, err)
+			continue
+		}
+		hostAcl := &model.FileShareAclSpec{
+			BaseModel:        &model.BaseModel{},
+			FileShareId:      fileshare.Id,
+			HostGroupId:      hg.Id,
+			Type:             "ip",
+			AccessTo:         host.IP,
+			AccessCapability: acl.AccessCapability,
+			Description:      acl.Description,
+			ProfileId:        acl.ProfileId,
+			Metadata:         map[string]string{"hostId": host.Id},
+		}
+		result, err := util.CreateFileShareAclDBEntry(ctx, hostAcl)
+		if err != nil {
+			log.Errorf("when creating acl for host %s joining group %s: %v", host.Id, hg.Id, err)
+			continue
+		}
+		p.requestAclCreation(ctx, result, fileshare, prf)
+	}
+}
+
+// removeGroupAttachment marks a group created attachment for deletion and
+// asks the dock to detach the volume from the leaving host.
+func (p *HostGroupPortal) removeGroupAttachment(ctx *c.Context, att *model.VolumeAttachmentSpec) {
+	vol, err := db.C.GetVolume(ctx, att.VolumeId)
+	if err != nil {
+		log.Errorf("when getting volume %s of attachment %s: %v", att.VolumeId, att.Id, err)
+		return
+	}
+	host, err := db.C.GetHost(ctx, att.HostId)
+	if err != nil {
+		log.Errorf("when getting host %s of attachment %s: %v", att.HostId, att.Id, err)
+		return
+	}
+
+	att.Status = model.VolumeAttachDeleting
+	if _, err := db.C.UpdateVolumeAttachment(ctx, att.Id, att); err != nil {
+		log.Errorf("when updating attachment %s: %v", att.Id, err)
+		return
+	}
+
+	opt := &pb.DeleteVolumeAttachmentOpts{
+		Id:             att.Id,
+		VolumeId:       att.VolumeId,
+		PoolId:         vol.PoolId,
+		AccessProtocol: att.AccessProtocol,
+		HostInfo:       hostInfoFromHost(host),
+		Metadata:       vol.Metadata,
+		Context:        ctx.ToJson(),
+	}
+	response, err := p.CtrClient.DeleteVolumeAttachment(context.Background(), opt)
+	if err != nil {
+		log.Error("delete volume attachment failed in controller service:", err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to delete volume attachment in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+}
+
+// revokeGroupAcl marks a group created acl for deletion and asks the dock
+// to revoke the access of the leaving host.
+func (p *HostGroupPortal) revokeGroupAcl(ctx *c.Context, acl *model.FileShareAclSpec) {
+	fileshare, err := db.C.GetFileShare(ctx, acl.FileShareId)
+	if err != nil {
+		log.Errorf("when getting fileshare %s of acl %s: %v", acl.FileShareId, acl.Id, err)
+		return
+	}
+	prf, err := db.C.GetProfile(ctx, acl.ProfileId)
+	if err != nil {
+		log.Errorf("when getting profile %s of acl %s: %v", acl.ProfileId, acl.Id, err)
+		return
+	}
+	if err := util.DeleteFileShareAclDBEntry(ctx, acl); err != nil {
+		log.Errorf("when deleting acl %s: %v", acl.Id, err)
+		return
+	}
+
+	opt := &pb.DeleteFileShareAclOpts{
+		Id:               acl.Id,
+		FileshareId:      acl.FileShareId,
+		Description:      acl.Description,
+		Type:             acl.Type,
+		AccessCapability: acl.AccessCapability,
+		AccessTo:         acl.AccessTo,
+		Metadata:         utils.MergeStringMaps(fileshare.Metadata, acl.Metadata),
+		Context:          ctx.ToJson(),
+		Profile:          prf.ToJson(),
+	}
+	response, err := p.CtrClient.DeleteFileShareAcl(context.Background(), opt)
+	if err != nil {
+		log.Error("delete fileshare acl failed in controller service:", err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to delete file share acl in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+}
+
+// requestAttachmentCreation sends one attachment creation request to the
+// dock. The controller client must already be connected.
+func (p *HostGroupPortal) requestAttachmentCreation(ctx *c.Context, att *model.VolumeAttachmentSpec, vol *model.VolumeSpec, host *model.HostSpec) {
+	opt := &pb.CreateVolumeAttachmentOpts{
+		Id:             att.Id,
+		VolumeId:       att.VolumeId,
+		PoolId:         vol.PoolId,
+		AccessProtocol: att.AccessProtocol,
+		HostInfo:       hostInfoFromHost(host),
+		Metadata:       vol.Metadata,
+		Context:        ctx.ToJson(),
+	}
+	response, err := p.CtrClient.CreateVolumeAttachment(context.Background(), opt)
+	if err != nil {
+		log.Error("create volume attachment failed in controller service:", err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to create volume attachment in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+}
+
+// requestAclCreation sends one acl creation request to the dock. The
+// controller client must already be connected.
+func (p *HostGroupPortal) requestAclCreation(ctx *c.Context, acl *model.FileShareAclSpec, fileshare *model.FileShareSpec, prf *model.ProfileSpec) {
+	opt := &pb.CreateFileShareAclOpts{
+		Id:               acl.Id,
+		FileshareId:      acl.FileShareId,
+		Description:      acl.Description,
+		Type:             acl.Type,
+		AccessCapability: acl.AccessCapability,
+		AccessTo:         acl.AccessTo,
+		SecurityFlavor:   acl.SecurityFlavor,
+		Metadata:         fileshare.Metadata,
+		Context:          ctx.ToJson(),
+		Profile:          prf.ToJson(),
+	}
+	response, err := p.CtrClient.CreateFileShareAcl(context.Background(), opt)
+	if err != nil {
+		log.Error("create file share acl failed in controller service:", err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to create file share acl in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+}
+
+// hostInfoFromHost converts a registered host into the dock host info.
+func hostInfoFromHost(host *model.HostSpec) *pb.HostInfo {
+	var initiators []*pb.Initiator
+	for _, e := range host.Initiators {
+		initiators = append(initiators, &pb.Initiator{
+			PortName: e.PortName,
+			Protocol: e.Protocol,
+		})
+	}
+	return &pb.HostInfo{
+		OsType:     host.OsType,
+		Ip:         host.IP,
+		Host:       host.HostName,
+		Initiators: initiators,
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+//                      Prepare for mock server                               //
+////////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/:tenantId/host-groups", &HostGroupPortal{},
+		"post:CreateHostGroup;get:ListHostGroups")
+	beego.Router("/v1beta/:tenantId/host-groups/:groupId", &HostGroupPortal{},
+		"get:GetHostGroup;put:UpdateHostGroup;delete:DeleteHostGroup")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                           Tests for host group                             //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestCreateHostGroup(t *testing.T) {
+
+	t.Run("Should return 400 if the host group has no name", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"hostIds": ["202964b5-8e73-46fd-b41b-a8e403f3c30b"]}`)
+		r, _ := http.NewRequest("POST", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/host-groups", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 400 if a member host does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetHost", c.NewAdminContext(), "202964b5-8e73-46fd-b41b-a8e403f3c30b").
+			Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		body := []byte(`{"name": "sap-cluster", "hostIds": ["202964b5-8e73-46fd-b41b-a8e403f3c30b"]}`)
+		r, _ := http.NewRequest("POST", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/host-groups", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestGetHostGroup(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetHostGroup", c.NewAdminContext(), "f2a27cd8-935b-47b9-9a0a-4c0d3d021f46").
+			Return(&SampleHostGroups[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET",
+			"/v1beta/e93b4c0934da416eb9c8d120c5d04d96/host-groups/f2a27cd8-935b-47b9-9a0a-4c0d3d021f46", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.HostGroupSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleHostGroups[0])
+	})
+
+	t.Run("Should return 404 if the host group does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetHostGroup", c.NewAdminContext(), "f2a27cd8-935b-47b9-9a0a-4c0d3d021f46").
+			Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET",
+			"/v1beta/e93b4c0934da416eb9c8d120c5d04d96/host-groups/f2a27cd8-935b-47b9-9a0a-4c0d3d021f46", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
+
+func TestDeleteHostGroup(t *testing.T) {
+
+	t.Run("Should return 400 if attachments were created through the group", func(t *testing.T) {
+		var groupAttachment = SampleAttachments[0]
+		groupAttachment.HostGroupId = "f2a27cd8-935b-47b9-9a0a-4c0d3d021f46"
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetHostGroup", c.NewAdminContext(), "f2a27cd8-935b-47b9-9a0a-4c0d3d021f46").
+			Return(&SampleHostGroups[0], nil)
+		mockClient.On("ListVolumeAttachments", c.NewAdminContext(), "").
+			Return([]*model.VolumeAttachmentSpec{&groupAttachment}, nil)
+		mockClient.On("ListFileSharesAcl", c.NewAdminContext()).
+			Return([]*model.FileShareAclSpec{}, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE",
+			"/v1beta/e93b4c0934da416eb9c8d120c5d04d96/host-groups/f2a27cd8-935b-47b9-9a0a-4c0d3d021f46", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 200 if nothing references the group", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetHostGroup", c.NewAdminContext(), "f2a27cd8-935b-47b9-9a0a-4c0d3d021f46").
+			Return(&SampleHostGroups[0], nil)
+		mockClient.On("ListVolumeAttachments", c.NewAdminContext(), "").
+			Return([]*model.VolumeAttachmentSpec{}, nil)
+		mockClient.On("ListFileSharesAcl", c.NewAdminContext()).
+			Return([]*model.FileShareAclSpec{}, nil)
+		mockClient.On("DeleteHostGroup", c.NewAdminContext(), "f2a27cd8-935b-47b9-9a0a-4c0d3d021f46").
+			Return(nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE",
+			"/v1beta/e93b4c0934da416eb9c8d120c5d04d96/host-groups/f2a27cd8-935b-47b9-9a0a-4c0d3d021f46", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 200)
+	})
+}
@@ -16,7 +16,9 @@ package controllers
 
 import (
 	"bytes"
+	ctx "context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -27,14 +29,42 @@ import (
 	"github.com/astaxie/beego"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
+	pb "github.com/sodafoundation/api/pkg/model/proto"
 	. "github.com/sodafoundation/api/testutils/collection"
+	ctrtest "github.com/sodafoundation/api/testutils/controller/testing"
 	dbtest "github.com/sodafoundation/api/testutils/db/testing"
 )
 
 func init() {
-	var hostPortal HostPortal
-	beego.Router("/v1beta/host/hosts", &hostPortal, "get:ListHosts;post:CreateHost")
-	beego.Router("/v1beta/host/hosts/:hostId", &hostPortal, "get:GetHost;put:UpdateHost;delete:DeleteHost")
+	hostPortal := NewFakeHostPortal()
+	beego.Router("/v1beta/host/hosts", hostPortal, "get:ListHosts;post:CreateHost")
+	beego.Router("/v1beta/host/hosts/import", hostPortal, "post:ImportHosts")
+	beego.Router("/v1beta/host/hosts/:hostId", hostPortal, "get:GetHost;put:UpdateHost;delete:DeleteHost")
+}
+
+func NewFakeHostPortal() *HostPortal {
+	mockClient := new(ctrtest.Client)
+
+	mockClient.On("Connect", "localhost:50049").Return(nil)
+	mockClient.On("Close").Return(nil)
+	mockClient.On("DeleteVolumeAttachment", ctx.Background(), &pb.DeleteVolumeAttachmentOpts{
+		Id:       "f2dda3d2-bf79-11e7-8665-f750b088f63e",
+		VolumeId: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		PoolId:   "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		HostInfo: &pb.HostInfo{
+			Ip:   "192.168.56.12",
+			Host: "sap1",
+			Initiators: []*pb.Initiator{
+				{PortName: "20000024ff5bb888", Protocol: "iscsi"},
+				{PortName: "20000024ff5bc999", Protocol: "iscsi"},
+			},
+		},
+		Context: c.NewAdminContext().ToJson(),
+	}).Return(&pb.GenericResponse{}, nil)
+
+	return &HostPortal{
+		CtrClient: mockClient,
+	}
 }
 
 var (
@@ -104,6 +134,109 @@ func TestCreateHost(t *testing.T) {
 	})
 }
 
+func TestImportHosts(t *testing.T) {
+
+	t.Run("Should return 200 and create every row if everything works well", func(t *testing.T) {
+		host1 := &model.HostSpec{
+			BaseModel: &model.BaseModel{},
+			HostName:  "node-01",
+			IP:        "192.168.56.21",
+		}
+		host2 := &model.HostSpec{
+			BaseModel: &model.BaseModel{},
+			HostName:  "node-02",
+			IP:        "192.168.56.22",
+		}
+		created1 := &model.HostSpec{BaseModel: &model.BaseModel{Id: "e1d22dc8-7b9a-11e9-9f51-4f12a9fd8e11"}, HostName: "node-01"}
+		created2 := &model.HostSpec{BaseModel: &model.BaseModel{Id: "ed5ab35e-7b9a-11e9-9f4d-3f50cdaef25b"}, HostName: "node-02"}
+
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListHosts", c.NewAdminContext(), map[string][]string{}).Return(nil, nil)
+		mockClient.On("CreateHost", c.NewAdminContext(), host1).Return(created1, nil)
+		mockClient.On("CreateHost", c.NewAdminContext(), host2).Return(created2, nil)
+		db.C = mockClient
+
+		body := []byte(`[
+			{"hostName": "node-01", "ip": "192.168.56.21"},
+			{"hostName": "node-02", "ip": "192.168.56.22"}
+		]`)
+		r, _ := http.NewRequest("POST", "/v1beta/host/hosts/import", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output model.HostImportResultSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Created, 2)
+		assertTestResult(t, output.Failed, 0)
+		assertTestResult(t, output.Rows[0].HostId, created1.Id)
+		assertTestResult(t, output.Rows[1].HostId, created2.Id)
+	})
+
+	t.Run("Should return 200 if a csv body is imported", func(t *testing.T) {
+		host := &model.HostSpec{
+			BaseModel: &model.BaseModel{},
+			HostName:  "node-03",
+			IP:        "192.168.56.23",
+			OsType:    "linux",
+			Initiators: []*model.Initiator{
+				{PortName: "20000024ff5bb888", Protocol: "iscsi"},
+			},
+		}
+		created := &model.HostSpec{BaseModel: &model.BaseModel{Id: "fb0f24a6-7b9a-11e9-b812-97f5ae2f8f62"}, HostName: "node-03"}
+
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListHosts", c.NewAdminContext(), map[string][]string{}).Return(nil, nil)
+		mockClient.On("CreateHost", c.NewAdminContext(), host).Return(created, nil)
+		db.C = mockClient
+
+		body := []byte("hostName,ip,osType,initiators\nnode-03,192.168.56.23,linux,20000024ff5bb888@iscsi\n")
+		r, _ := http.NewRequest("POST", "/v1beta/host/hosts/import", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "text/csv")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output model.HostImportResultSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Created, 1)
+	})
+
+	t.Run("Should return 400 and create nothing if a row duplicates an existing host", func(t *testing.T) {
+		existing := []*model.HostSpec{{BaseModel: &model.BaseModel{}, HostName: "sap1"}}
+
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListHosts", c.NewAdminContext(), map[string][]string{}).Return(existing, nil)
+		db.C = mockClient
+
+		body := []byte(`[
+			{"hostName": "node-04", "ip": "192.168.56.24"},
+			{"hostName": "sap1", "ip": "192.168.56.12"}
+		]`)
+		r, _ := http.NewRequest("POST", "/v1beta/host/hosts/import", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output model.HostImportResultSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 400)
+		assertTestResult(t, output.Created, 0)
+		assertTestResult(t, output.Failed, 1)
+		assertTestResult(t, output.Rows[1].Status, model.HostImportRowError)
+	})
+}
+
 func TestListHosts(t *testing.T) {
 
 	t.Run("Should return 200 if everything works well", func(t *testing.T) {
@@ -192,4 +325,94 @@ func TestDeleteHost(t *testing.T) {
 		assertTestResult(t, w.Code, 200)
 
 	})
+
+	t.Run("Should return 404 if the host is already gone", func(t *testing.T) {
+		fakeHost := &SampleHosts[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetHost", c.NewAdminContext(), fakeHost.Id).Return(
+			nil, errors.New("specified host("+fakeHost.Id+") can't find"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE", "/v1beta/host/hosts/"+fakeHost.Id, nil)
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		assertTestResult(t, w.Code, 404)
+	})
+
+	t.Run("Should return 204 if the host is already gone and ignoreNotFound is set", func(t *testing.T) {
+		fakeHost := &SampleHosts[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetHost", c.NewAdminContext(), fakeHost.Id).Return(
+			nil, errors.New("specified host("+fakeHost.Id+") can't find"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE", "/v1beta/host/hosts/"+fakeHost.Id+"?ignoreNotFound=true", nil)
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		assertTestResult(t, w.Code, 204)
+	})
+}
+
+func TestDeleteHostCascade(t *testing.T) {
+
+	t.Run("Should return 400 if attachments exist and cascade is not set", func(t *testing.T) {
+		fakeHost := &SampleHosts[0]
+		fakeAttachments := []*model.VolumeAttachmentSpec{&SampleAttachments[0]}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetHost", c.NewAdminContext(), fakeHost.Id).Return(fakeHost, nil)
+		mockClient.On("ListVolumeAttachmentsWithFilter", c.NewAdminContext(),
+			map[string][]string{"hostId": []string{fakeHost.Id}}).Return(fakeAttachments, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE", "/v1beta/host/hosts/"+fakeHost.Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 202 and detach the volumes if cascade is set", func(t *testing.T) {
+		fakeHost := &SampleHosts[0]
+		fakeAttachment := SampleAttachments[0]
+		fakeAttachments := []*model.VolumeAttachmentSpec{&fakeAttachment}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetHost", c.NewAdminContext(), fakeHost.Id).Return(fakeHost, nil)
+		mockClient.On("ListVolumeAttachmentsWithFilter", c.NewAdminContext(),
+			map[string][]string{"hostId": []string{fakeHost.Id}}).Return(fakeAttachments, nil)
+		mockClient.On("ListFileSharesAclWithFilter", c.NewAdminContext(),
+			map[string][]string{"accessTo": []string{fakeHost.IP}}).Return(nil, nil)
+		mockClient.On("UpdateVolumeAttachment", c.NewAdminContext(), fakeAttachment.Id,
+			&fakeAttachment).Return(&fakeAttachment, nil)
+		mockClient.On("DeleteHost", c.NewAdminContext(), fakeHost.Id).Return(nil)
+		mockClient.On("GetVolume", c.NewAdminContext(), fakeAttachment.VolumeId).Return(&SampleVolumes[0], nil)
+		task := &model.CascadeTaskSpec{BaseModel: &model.BaseModel{}, ResourceType: "host",
+			ResourceId: fakeHost.Id, Action: "cascadeDelete",
+			Status: model.CascadeTaskRunning, TotalItems: 1}
+		mockClient.On("ListCascadeTasks", c.NewAdminContext()).Return(nil, nil)
+		mockClient.On("CreateCascadeTask", c.NewAdminContext(), task).Return(task, nil)
+		mockClient.On("UpdateCascadeTask", c.NewAdminContext(), task).Return(task, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE", "/v1beta/host/hosts/"+fakeHost.Id+"?cascade=true", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		assertTestResult(t, w.Code, 202)
+	})
 }
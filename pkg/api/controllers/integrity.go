@@ -0,0 +1,53 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the admin endpoint serving the corrupt records the
+db drivers skipped while listing.
+*/
+
+package controllers
+
+import (
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db/integrity"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewIntegrityPortal() *IntegrityPortal {
+	return &IntegrityPortal{}
+}
+
+type IntegrityPortal struct {
+	BasePortal
+}
+
+// ListCorruptRecords serves the db records that failed to parse and were
+// skipped by list calls since startup, admin only.
+func (p *IntegrityPortal) ListCorruptRecords() {
+	if !policy.Authorize(p.Ctx, "integrity:list") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to read the corrupt records"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	p.SuccessHandleList(StatusOK, integrity.Records())
+
+	return
+}
@@ -0,0 +1,68 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db/integrity"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func init() {
+	beego.Router("/v1beta/admin/corrupt-records", &IntegrityPortal{}, "get:ListCorruptRecords")
+}
+
+func TestListCorruptRecords(t *testing.T) {
+
+	t.Run("Should serve the recorded corrupt records", func(t *testing.T) {
+		integrity.RecordCorruption("/v1beta/block/volumes/broken", errors.New("unexpected end of JSON input"))
+
+		r, _ := http.NewRequest("GET", "/v1beta/admin/corrupt-records", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.CorruptRecordSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+
+		var found bool
+		for _, record := range output {
+			if record.Key == "/v1beta/block/volumes/broken" {
+				found = true
+			}
+		}
+		assertTestResult(t, found, true)
+	})
+
+	t.Run("Should return 403 for non admin users", func(t *testing.T) {
+		r, _ := http.NewRequest("GET", "/v1beta/admin/corrupt-records", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+}
@@ -0,0 +1,168 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sodafoundation/api/pkg/api/jobs"
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewJobPortal() *JobPortal {
+	return &JobPortal{}
+}
+
+// JobPortal serves the admin jobs endpoint, the common home of the
+// administrative background operations. A job is submitted with its type and
+// parameters, runs in the background, and its record can be listed,
+// inspected and cancelled here while the history retention keeps finished
+// jobs around.
+type JobPortal struct {
+	BasePortal
+}
+
+func (p *JobPortal) CreateJob() {
+	if !policy.Authorize(p.Ctx, "job:create") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to submit jobs"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	var job = model.JobSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&job); err != nil {
+		errMsg := fmt.Sprintf("parse job request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if !jobs.Supported(job.Type) {
+		errMsg := fmt.Sprintf("unknown job type %s, supported types are: %s",
+			job.Type, strings.Join(jobs.SupportedTypes(), ", "))
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	result, err := jobs.Submit(ctx, &job)
+	if err != nil {
+		if err == jobs.ErrTooManyJobs {
+			p.ErrorHandle(model.ErrorConflict, err.Error())
+			return
+		}
+		errMsg := fmt.Sprintf("submit job failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	p.SuccessHandle(StatusAccepted, body)
+	return
+}
+
+func (p *JobPortal) ListJobs() {
+	if !policy.Authorize(p.Ctx, "job:list") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to list jobs"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	jobList, err := db.C.ListJobs(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list jobs failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Narrow the listing to one type or status when the matching query
+	// parameter is given.
+	var result = []*model.JobSpec{}
+	for _, job := range jobList {
+		if v := p.Ctx.Input.Query("type"); v != "" && job.Type != v {
+			continue
+		}
+		if v := p.Ctx.Input.Query("status"); v != "" && job.Status != v {
+			continue
+		}
+		result = append(result, job)
+	}
+
+	p.SuccessHandleList(StatusOK, result)
+	return
+}
+
+func (p *JobPortal) GetJob() {
+	if !policy.Authorize(p.Ctx, "job:get") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to get jobs"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+	id := p.Ctx.Input.Param(":jobId")
+
+	result, err := db.C.GetJob(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("job %s not found: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	p.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (p *JobPortal) CancelJob() {
+	if !policy.Authorize(p.Ctx, "job:cancel") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to cancel jobs"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+	id := p.Ctx.Input.Param(":jobId")
+
+	result, err := jobs.Cancel(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("cancel job %s failed: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	p.SuccessHandle(StatusAccepted, body)
+	return
+}
@@ -0,0 +1,228 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+	"github.com/stretchr/testify/mock"
+)
+
+// //////////////////////////////////////////////////////////////////////////////
+//
+//	Prepare for mock server                               //
+//
+// //////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/admin/jobs", &JobPortal{},
+		"post:CreateJob;get:ListJobs")
+	beego.Router("/v1beta/admin/jobs/:jobId", &JobPortal{},
+		"get:GetJob")
+	beego.Router("/v1beta/admin/jobs/:jobId/cancel", &JobPortal{},
+		"post:CancelJob")
+}
+
+var sampleJobs = []*model.JobSpec{
+	{
+		BaseModel:  &model.BaseModel{Id: "6e68c7d7-9c4c-42d1-a48c-9e688cbbf7f2"},
+		TenantId:   "e93b4c0934da416eb9c8d120c5d04d96",
+		Type:       "purgeTombstones",
+		Params:     map[string]string{"olderThan": "24h"},
+		Status:     model.JobSucceeded,
+		Result:     map[string]string{"purged": "4"},
+		StartedAt:  "2019-03-20T12:49:00.497Z",
+		FinishedAt: "2019-03-20T12:49:02.114Z",
+	},
+	{
+		BaseModel:    &model.BaseModel{Id: "2f9c0a04-66ef-4dc8-9a0e-53e6a2e28589"},
+		TenantId:     "e93b4c0934da416eb9c8d120c5d04d96",
+		Type:         "purgeTasks",
+		Status:       model.JobFailed,
+		ErrorMessage: "db error",
+		StartedAt:    "2019-03-20T12:50:00.497Z",
+		FinishedAt:   "2019-03-20T12:50:01.031Z",
+	},
+}
+
+func TestCreateJob(t *testing.T) {
+
+	t.Run("Should return 202 and run the job in the background", func(t *testing.T) {
+		CONF.OsdsApiServer.JobConcurrency = 2
+		defer func() { CONF.OsdsApiServer.JobConcurrency = 0 }()
+
+		// The runner goroutine outlives the request, so everything it
+		// touches is mocked and the test waits for the final update
+		// before letting the mock go out of scope.
+		finished := make(chan struct{})
+		mockClient := new(dbtest.Client)
+		mockClient.On("CreateJob", mock.Anything, mock.Anything).Return(sampleJobs[0], nil)
+		mockClient.On("ListTombstones", mock.Anything).Return(nil, nil)
+		mockClient.On("UpdateJob", mock.Anything, mock.Anything).Return(sampleJobs[0], nil).
+			Run(func(args mock.Arguments) { close(finished) })
+		db.C = mockClient
+
+		jsonStr := []byte(`{"type": "purgeTombstones", "params": {"olderThan": "24h"}}`)
+		r, _ := http.NewRequest("POST", "/v1beta/admin/jobs", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.JobSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 202)
+		assertTestResult(t, &output, sampleJobs[0])
+		<-finished
+	})
+
+	t.Run("Should return 400 for an unknown job type", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		jsonStr := []byte(`{"type": "defragmentEverything"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/admin/jobs", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 409 when the concurrency limit is reached", func(t *testing.T) {
+		CONF.OsdsApiServer.JobConcurrency = 0
+		db.C = new(dbtest.Client)
+
+		jsonStr := []byte(`{"type": "purgeTombstones"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/admin/jobs", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+
+	t.Run("Should return 403 for non-admin users", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		jsonStr := []byte(`{"type": "purgeTombstones"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/admin/jobs", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+}
+
+func TestListJobs(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListJobs", c.NewAdminContext()).Return(sampleJobs, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/admin/jobs", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.JobSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, sampleJobs)
+	})
+
+	t.Run("Should narrow the listing with the query parameters", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListJobs", c.NewAdminContext()).Return(sampleJobs, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/admin/jobs?status=failed", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.JobSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, sampleJobs[1:])
+	})
+}
+
+func TestGetJob(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetJob", c.NewAdminContext(), sampleJobs[0].Id).Return(sampleJobs[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/admin/jobs/"+sampleJobs[0].Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.JobSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, sampleJobs[0])
+	})
+
+	t.Run("Should return 404 if the job does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetJob", c.NewAdminContext(), "unknown").Return(
+			nil, errors.New("specified job(unknown) can't find"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/admin/jobs/unknown", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
+
+func TestCancelJob(t *testing.T) {
+
+	t.Run("Should return 404 if the job is not running", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		r, _ := http.NewRequest("POST", "/v1beta/admin/jobs/unknown/cancel", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
@@ -32,6 +32,8 @@ import (
 	"github.com/sodafoundation/api/pkg/api/policy"
 	c "github.com/sodafoundation/api/pkg/context"
 	"github.com/sodafoundation/api/pkg/api/controllerclient"
+	"github.com/sodafoundation/api/pkg/api/telemetry"
+	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
 	pb "github.com/sodafoundation/api/pkg/model/proto"
 	. "github.com/sodafoundation/api/pkg/utils/config"
@@ -282,6 +284,87 @@ func (m *MetricsPortal) CollectMetrics() {
 	return
 }
 
+// IngestMetrics accepts a batch of telemetry samples pushed by a dock and
+// persists it to the configured metrics store. Docks authenticate like any
+// other service account, e.g. with an api key, and every batch must name
+// the registered dock it comes from.
+func (m *MetricsPortal) IngestMetrics() {
+	if !policy.Authorize(m.Ctx, "metrics:ingest") {
+		return
+	}
+	var batch = model.MetricsBatchSpec{}
+
+	// Unmarshal the request body
+	if err := json.NewDecoder(m.Ctx.Request.Body).Decode(&batch); err != nil {
+		errMsg := fmt.Sprintf("parse metrics batch request body failed: %s", err.Error())
+		m.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if batch.DockId == "" {
+		errMsg := "metrics batch dockId can not be empty"
+		m.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if len(batch.Samples) == 0 {
+		errMsg := "metrics batch contains no samples"
+		m.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	for _, sample := range batch.Samples {
+		if sample.Name == "" {
+			errMsg := "every sample in a metrics batch needs a name"
+			m.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+		if len(sample.MetricValues) == 0 {
+			errMsg := fmt.Sprintf("sample %s carries no values", sample.Name)
+			m.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+
+	// Only registered docks may push samples. Docks are admin resources,
+	// so look the dock up with an admin context.
+	if _, err := db.C.GetDock(c.NewAdminContext(), batch.DockId); err != nil {
+		errMsg := fmt.Sprintf("dock %s is not registered: %s", batch.DockId, err.Error())
+		m.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	if err := telemetry.GetStore().Write(batch.Samples); err != nil {
+		errMsg := fmt.Sprintf("persist metrics batch failed: %s", err.Error())
+		m.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	m.SuccessHandle(StatusAccepted, nil)
+
+	return
+}
+
+// QueryMetrics reads pushed samples back from the configured metrics
+// store. External stores answer reads through their own query api, in that
+// case the endpoint returns 501.
+func (m *MetricsPortal) QueryMetrics() {
+	if !policy.Authorize(m.Ctx, "metrics:query") {
+		return
+	}
+	result, err := telemetry.GetStore().Query(
+		m.Ctx.Input.Query("name"), m.Ctx.Input.Query("instanceId"))
+	if err == telemetry.ErrQueryNotSupported {
+		m.ErrorHandle(model.ErrorNotImplemented, err.Error())
+		return
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("query metrics failed: %s", err.Error())
+		m.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	m.SuccessHandleList(StatusOK, result)
+
+	return
+}
+
 func (m *MetricsPortal) GetUrls() {
 	if !policy.Authorize(m.Ctx, "metrics:urls") {
 		return
@@ -0,0 +1,194 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewNetworkPolicyPortal() *NetworkPolicyPortal {
+	return &NetworkPolicyPortal{}
+}
+
+type NetworkPolicyPortal struct {
+	BasePortal
+}
+
+// validateCidrs returns an error naming the first entry of either list that
+// is not a valid CIDR expression.
+func validateCidrs(cidrLists ...[]string) error {
+	for _, cidrs := range cidrLists {
+		for _, cidr := range cidrs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid CIDR %s: %v", cidr, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (n *NetworkPolicyPortal) CreateNetworkPolicy() {
+	if !policy.Authorize(n.Ctx, "network_policy:create") {
+		return
+	}
+	ctx := c.GetContext(n.Ctx)
+
+	var np = model.NetworkPolicySpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(n.Ctx.Request.Body).Decode(&np); err != nil {
+		errMsg := fmt.Sprintf("parse network policy request body failed: %s", err.Error())
+		n.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := validateCidrs(np.AllowedCidrs, np.DeniedCidrs); err != nil {
+		errMsg := fmt.Sprintf("create network policy failed: %s", err.Error())
+		n.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// A tenant has at most one network policy, otherwise the enforcement
+	// result would depend on the order policies are evaluated in.
+	policies, err := db.C.ListNetworkPolicies(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list network policies failed: %s", err.Error())
+		n.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	if len(policies) > 0 {
+		errMsg := fmt.Sprintf("tenant %s already has network policy %s", ctx.TenantId, policies[0].Id)
+		n.ErrorHandle(model.ErrorConflict, errMsg)
+		return
+	}
+
+	result, err := db.C.CreateNetworkPolicy(ctx, &np)
+	if err != nil {
+		errMsg := fmt.Sprintf("create network policy failed: %s", err.Error())
+		n.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	n.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (n *NetworkPolicyPortal) ListNetworkPolicies() {
+	if !policy.Authorize(n.Ctx, "network_policy:list") {
+		return
+	}
+	ctx := c.GetContext(n.Ctx)
+
+	result, err := db.C.ListNetworkPolicies(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list network policies failed: %s", err.Error())
+		n.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	n.SuccessHandleList(StatusOK, result)
+	return
+}
+
+func (n *NetworkPolicyPortal) GetNetworkPolicy() {
+	if !policy.Authorize(n.Ctx, "network_policy:get") {
+		return
+	}
+	id := n.Ctx.Input.Param(":policyId")
+	ctx := c.GetContext(n.Ctx)
+
+	result, err := db.C.GetNetworkPolicy(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("network policy %s not found: %s", id, err.Error())
+		n.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	n.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (n *NetworkPolicyPortal) UpdateNetworkPolicy() {
+	if !policy.Authorize(n.Ctx, "network_policy:update") {
+		return
+	}
+	id := n.Ctx.Input.Param(":policyId")
+	ctx := c.GetContext(n.Ctx)
+
+	var np = model.NetworkPolicySpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(n.Ctx.Request.Body).Decode(&np); err != nil {
+		errMsg := fmt.Sprintf("parse network policy request body failed: %s", err.Error())
+		n.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := validateCidrs(np.AllowedCidrs, np.DeniedCidrs); err != nil {
+		errMsg := fmt.Sprintf("update network policy failed: %s", err.Error())
+		n.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	result, err := db.C.UpdateNetworkPolicy(ctx, id, &np)
+	if err != nil {
+		errMsg := fmt.Sprintf("update network policy failed: %s", err.Error())
+		n.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	n.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (n *NetworkPolicyPortal) DeleteNetworkPolicy() {
+	if !policy.Authorize(n.Ctx, "network_policy:delete") {
+		return
+	}
+	id := n.Ctx.Input.Param(":policyId")
+	ctx := c.GetContext(n.Ctx)
+
+	np, err := db.C.GetNetworkPolicy(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("network policy %s not found: %s", id, err.Error())
+		n.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	if err := db.C.DeleteNetworkPolicy(ctx, np.Id); err != nil {
+		errMsg := fmt.Sprintf("delete network policy failed: %s", err.Error())
+		n.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	n.SuccessHandle(StatusOK, nil)
+	return
+}
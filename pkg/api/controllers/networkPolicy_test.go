@@ -0,0 +1,113 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+//                      Prepare for mock server                               //
+////////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/:tenantId/networkPolicies", &NetworkPolicyPortal{},
+		"post:CreateNetworkPolicy;get:ListNetworkPolicies")
+	beego.Router("/v1beta/:tenantId/networkPolicies/:policyId", &NetworkPolicyPortal{},
+		"get:GetNetworkPolicy;put:UpdateNetworkPolicy;delete:DeleteNetworkPolicy")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                         Tests for network policy                           //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestCreateNetworkPolicy(t *testing.T) {
+
+	t.Run("Should return 400 if a CIDR does not parse", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"allowedCidrs": ["10.0.0.0/33"]}`)
+		r, _ := http.NewRequest("POST", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/networkPolicies", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 409 if the tenant already has a policy", func(t *testing.T) {
+		var samplePolicies = []*model.NetworkPolicySpec{&SampleNetworkPolicies[0]}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListNetworkPolicies", c.NewAdminContext()).Return(samplePolicies, nil)
+		db.C = mockClient
+
+		body := []byte(`{"allowedCidrs": ["10.0.0.0/8"]}`)
+		r, _ := http.NewRequest("POST", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/networkPolicies", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+}
+
+func TestGetNetworkPolicy(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetNetworkPolicy", c.NewAdminContext(), "7a7e1a06-66f0-11e7-9ae0-2fbbbf092f12").Return(&SampleNetworkPolicies[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/networkPolicies/7a7e1a06-66f0-11e7-9ae0-2fbbbf092f12", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.NetworkPolicySpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleNetworkPolicies[0])
+	})
+
+	t.Run("Should return 404 if the network policy does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetNetworkPolicy", c.NewAdminContext(), "7a7e1a06-66f0-11e7-9ae0-2fbbbf092f12").Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/networkPolicies/7a7e1a06-66f0-11e7-9ae0-2fbbbf092f12", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
@@ -22,8 +22,10 @@ package controllers
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/sodafoundation/api/pkg/api/policy"
+	"github.com/sodafoundation/api/pkg/api/util"
 	c "github.com/sodafoundation/api/pkg/context"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
@@ -44,14 +46,7 @@ func (p *PoolPortal) ListAvailabilityZones() {
 		return
 	}
 
-	body, err := json.Marshal(azs)
-	if err != nil {
-		errMsg := fmt.Sprintf("marshal AvailabilityZones failed: %s", err.Error())
-		p.ErrorHandle(model.ErrorInternalServer, errMsg)
-		return
-	}
-
-	p.SuccessHandle(StatusOK, body)
+	p.SuccessHandleList(StatusOK, azs)
 	return
 }
 
@@ -67,17 +62,81 @@ func (p *PoolPortal) ListPools() {
 		return
 	}
 
+	capFilter, err := util.ParsePoolCapabilityFilter(m)
+	if err != nil {
+		errMsg := fmt.Sprintf("list pool parameters failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	createdFilter, err := util.ParseCreatedAtFilter(m)
+	if err != nil {
+		errMsg := fmt.Sprintf("list pool parameters failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	var excludeStale bool
+	if v, ok := m["excludeStale"]; ok {
+		delete(m, "excludeStale")
+		excludeStale, err = strconv.ParseBool(v[0])
+		if err != nil {
+			errMsg := fmt.Sprintf("invalid excludeStale %s, expected true or false", v[0])
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+
 	result, err := db.C.ListPoolsWithFilter(c.GetContext(p.Ctx), m)
 	if err != nil {
 		errMsg := fmt.Sprintf("list pools failed: %s", err.Error())
 		p.ErrorHandle(model.ErrorInternalServer, errMsg)
 		return
 	}
+	if capFilter != nil {
+		result = capFilter.FilterPools(result)
+	}
+	if createdFilter != nil {
+		var matched = []*model.StoragePoolSpec{}
+		for _, pool := range result {
+			if createdFilter.Match(pool.CreatedAt) {
+				matched = append(matched, pool)
+			}
+		}
+		result = matched
+	}
+	util.MarkStalePools(result)
+	if excludeStale {
+		var fresh = []*model.StoragePoolSpec{}
+		for _, pool := range result {
+			if !pool.Stale {
+				fresh = append(fresh, pool)
+			}
+		}
+		result = fresh
+	}
+
+	p.SuccessHandleList(StatusOK, result)
+	return
+}
+
+func (p *PoolPortal) GetPool() {
+	if !policy.Authorize(p.Ctx, "pool:get") {
+		return
+	}
+	id := p.Ctx.Input.Param(":poolId")
+	result, err := db.C.GetPool(c.GetContext(p.Ctx), id)
+	if err != nil {
+		errMsg := fmt.Sprintf("pool %s not found: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	result.Stale = util.PoolIsStale(result)
 
 	// Marshal the result.
 	body, err := json.Marshal(result)
 	if err != nil {
-		errMsg := fmt.Sprintf("marshal pools failed: %s", err.Error())
+		errMsg := fmt.Sprintf("marshal pool failed: %s", err.Error())
 		p.ErrorHandle(model.ErrorInternalServer, errMsg)
 		return
 	}
@@ -86,18 +145,100 @@ func (p *PoolPortal) ListPools() {
 	return
 }
 
-func (p *PoolPortal) GetPool() {
-	if !policy.Authorize(p.Ctx, "pool:get") {
+// PoolAction drives the maintenance state of a pool. A pool in maintenance
+// keeps serving its in-flight resources but is skipped by new create
+// requests, so the backing storage controller can be upgraded without
+// failing workloads onto it.
+func (p *PoolPortal) PoolAction() {
+	if !policy.Authorize(p.Ctx, "pool:action") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to change the maintenance state of a pool"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	var action model.StoragePoolActionSpec
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&action); err != nil {
+		errMsg := fmt.Sprintf("parse pool action request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if action.Maintenance == nil {
+		errMsg := "the pool action body must carry the maintenance flag"
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
+
 	id := p.Ctx.Input.Param(":poolId")
-	result, err := db.C.GetPool(c.GetContext(p.Ctx), id)
-	if err != nil {
+	if _, err := db.C.GetPool(ctx, id); err != nil {
 		errMsg := fmt.Sprintf("pool %s not found: %s", id, err.Error())
 		p.ErrorHandle(model.ErrorNotFound, errMsg)
 		return
 	}
 
+	result, err := db.C.UpdatePoolMaintenance(ctx, id, *action.Maintenance)
+	if err != nil {
+		errMsg := fmt.Sprintf("update pool %s maintenance failed: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal pool failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	p.SuccessHandle(StatusOK, body)
+	return
+}
+
+// UpdatePool lets an admin tune the capacity settings of a pool, currently
+// the reserved capacity and the over subscription ratio. The other pool
+// properties are reported by the dock and can not be changed here.
+func (p *PoolPortal) UpdatePool() {
+	if !policy.Authorize(p.Ctx, "pool:update") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to update pool capacity settings"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+	id := p.Ctx.Input.Param(":poolId")
+
+	var pool = model.StoragePoolSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&pool); err != nil {
+		errMsg := fmt.Sprintf("parse pool request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if pool.ReservedCapacity < 0 {
+		errMsg := fmt.Sprintf("invalid reservedCapacity: %d", pool.ReservedCapacity)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if pool.MaxOverSubscriptionRatio != 0 && pool.MaxOverSubscriptionRatio < 1 {
+		errMsg := fmt.Sprintf("invalid maxOverSubscriptionRatio: %v, it must be at least 1", pool.MaxOverSubscriptionRatio)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	result, err := db.C.UpdatePoolCapacitySettings(ctx, id, pool.ReservedCapacity, pool.MaxOverSubscriptionRatio)
+	if err != nil {
+		errMsg := fmt.Sprintf("update pool %s failed: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
 	// Marshal the result.
 	body, err := json.Marshal(result)
 	if err != nil {
@@ -15,16 +15,20 @@
 package controllers
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/astaxie/beego"
 	c "github.com/sodafoundation/api/pkg/context"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/constants"
 	. "github.com/sodafoundation/api/testutils/collection"
 	dbtest "github.com/sodafoundation/api/testutils/db/testing"
 )
@@ -34,6 +38,7 @@ func init() {
 	beego.Router("/v1beta/pools", &poolPortal, "get:ListPools")
 	beego.Router("/v1beta/availabilityZones", &poolPortal, "get:ListAvailabilityZones")
 	beego.Router("/v1beta/pools/:poolId", &poolPortal, "get:GetPool")
+	beego.Router("/v1beta/pools/:poolId/action", &poolPortal, "post:PoolAction")
 }
 
 func TestListAvailabilityZones(t *testing.T) {
@@ -76,6 +81,38 @@ func TestListPools(t *testing.T) {
 		assertTestResult(t, output, samplePools)
 	})
 
+	t.Run("Should filter out stale pools when excludeStale is true", func(t *testing.T) {
+		CONF.OsdsApiServer.PoolStaleThreshold = 10 * time.Minute
+		defer func() { CONF.OsdsApiServer.PoolStaleThreshold = 0 }()
+
+		var freshPool = model.StoragePoolSpec{
+			BaseModel: &model.BaseModel{
+				Id:        "f4486139-78d5-462d-a7b9-fdaf6c797e1b",
+				UpdatedAt: time.Now().Format(constants.TimeFormat),
+			},
+			Name: "fresh",
+		}
+		var stalePool = model.StoragePoolSpec{
+			BaseModel: &model.BaseModel{
+				Id:        "a594b8ac-a103-11e7-985f-d723bcf01b5f",
+				UpdatedAt: time.Now().Add(-time.Hour).Format(constants.TimeFormat),
+			},
+			Name: "stale",
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListPoolsWithFilter", c.NewAdminContext(), map[string][]string{}).
+			Return([]*model.StoragePoolSpec{&freshPool, &stalePool}, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/pools?excludeStale=true", nil)
+		w := httptest.NewRecorder()
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.StoragePoolSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, []*model.StoragePoolSpec{&freshPool})
+	})
+
 	t.Run("Should return 500 if list pools with bad request", func(t *testing.T) {
 		mockClient := new(dbtest.Client)
 		m := map[string][]string{
@@ -122,3 +159,52 @@ func TestGetPool(t *testing.T) {
 		assertTestResult(t, w.Code, 404)
 	})
 }
+
+func TestPoolAction(t *testing.T) {
+
+	t.Run("Should return 200 if the pool is put into maintenance", func(t *testing.T) {
+		var pool = SamplePools[0]
+		pool.Maintenance = true
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetPool", c.NewAdminContext(), "084bf71e-a102-11e7-88a8-e31fe6d52248").Return(&SamplePools[0], nil)
+		mockClient.On("UpdatePoolMaintenance", c.NewAdminContext(), "084bf71e-a102-11e7-88a8-e31fe6d52248", true).
+			Return(&pool, nil)
+		db.C = mockClient
+
+		body := []byte(`{"maintenance": true}`)
+		r, _ := http.NewRequest("POST",
+			"/v1beta/pools/084bf71e-a102-11e7-88a8-e31fe6d52248/action", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.StoragePoolSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Maintenance, true)
+	})
+
+	t.Run("Should return 400 if the body carries no maintenance flag", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{}`)
+		r, _ := http.NewRequest("POST",
+			"/v1beta/pools/084bf71e-a102-11e7-88a8-e31fe6d52248/action", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 404 if the pool does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetPool", c.NewAdminContext(), "084bf71e-a102-11e7-88a8-e31fe6d52248").
+			Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		body := []byte(`{"maintenance": false}`)
+		r, _ := http.NewRequest("POST",
+			"/v1beta/pools/084bf71e-a102-11e7-88a8-e31fe6d52248/action", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
@@ -26,9 +26,11 @@ import (
 
 	log "github.com/golang/glog"
 	"github.com/sodafoundation/api/pkg/api/policy"
+	"github.com/sodafoundation/api/pkg/api/util"
 	c "github.com/sodafoundation/api/pkg/context"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
 	"github.com/sodafoundation/api/pkg/utils/constants"
 )
 
@@ -75,6 +77,26 @@ func (p *ProfilePortal) CreateProfile() {
 		return
 	}
 
+	if profile.Visibility != "" && !utils.Contained(profile.Visibility, model.ProfileVisibilities) {
+		errMsg := fmt.Sprintf("invalid profile visibility %s, must be one of %v", profile.Visibility, model.ProfileVisibilities)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if profile.CostPerGbMonth < 0 {
+		errMsg := fmt.Sprintf("invalid costPerGbMonth %v, the price can not be negative", profile.CostPerGbMonth)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if profile.BaseProfileId != "" {
+		if err := util.ValidateBaseProfile(c.GetContext(p.Ctx), &profile); err != nil {
+			errMsg := fmt.Sprintf("invalid baseProfileId: %v", err)
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+
 	// Call db api module to handle create profile request.
 	result, err := db.C.CreateProfile(c.GetContext(p.Ctx), &profile)
 	if err != nil {
@@ -107,22 +129,26 @@ func (p *ProfilePortal) ListProfiles() {
 		return
 	}
 
-	result, err := db.C.ListProfilesWithFilter(c.GetContext(p.Ctx), m)
+	ctx := c.GetContext(p.Ctx)
+	result, err := db.C.ListProfilesWithFilter(ctx, m)
 	if err != nil {
 		errMsg := fmt.Sprintf("list profiles failed: %v", err)
 		p.ErrorHandle(model.ErrorInternalServer, errMsg)
 		return
 	}
 
-	// Marshal the result.
-	body, err := json.Marshal(result)
-	if err != nil {
-		errMsg := fmt.Sprintf("marshal profiles listed result failed: %v", err)
-		p.ErrorHandle(model.ErrorInternalServer, errMsg)
-		return
+	// Hide restricted profiles the caller's tenant is not allowed to see.
+	if !ctx.IsAdmin {
+		visible := make([]*model.ProfileSpec, 0, len(result))
+		for _, prf := range result {
+			if prf.VisibleTo(ctx.TenantId) {
+				visible = append(visible, prf)
+			}
+		}
+		result = visible
 	}
 
-	p.SuccessHandle(StatusOK, body)
+	p.SuccessHandleList(StatusOK, result)
 	return
 }
 
@@ -131,14 +157,33 @@ func (p *ProfilePortal) GetProfile() {
 		return
 	}
 	id := p.Ctx.Input.Param(":profileId")
+	ctx := c.GetContext(p.Ctx)
 
-	result, err := db.C.GetProfile(c.GetContext(p.Ctx), id)
+	result, err := db.C.GetProfile(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("profile %s not found: %v", id, err)
 		p.ErrorHandle(model.ErrorNotFound, errMsg)
 		return
 	}
 
+	// A restricted profile is reported as missing rather than forbidden, so
+	// its existence is not leaked to tenants outside the allowed list.
+	if !ctx.IsAdmin && !result.VisibleTo(ctx.TenantId) {
+		errMsg := fmt.Sprintf("profile %s not found", id)
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// The resolved view overlays the custom properties of the whole
+	// inheritance chain, the profile winning on shared keys.
+	if p.Ctx.Input.Query("resolved") == "true" {
+		if result, err = util.ResolveProfile(ctx, result); err != nil {
+			errMsg := fmt.Sprintf("resolve profile %s failed: %v", id, err)
+			p.ErrorHandle(model.ErrorInternalServer, errMsg)
+			return
+		}
+	}
+
 	// Marshal the result.
 	body, err := json.Marshal(result)
 	if err != nil {
@@ -167,6 +212,39 @@ func (p *ProfilePortal) UpdateProfile() {
 		return
 	}
 
+	if profile.Visibility != "" && !utils.Contained(profile.Visibility, model.ProfileVisibilities) {
+		errMsg := fmt.Sprintf("invalid profile visibility %s, must be one of %v", profile.Visibility, model.ProfileVisibilities)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if profile.CostPerGbMonth < 0 {
+		errMsg := fmt.Sprintf("invalid costPerGbMonth %v, the price can not be negative", profile.CostPerGbMonth)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if profile.BaseProfileId != "" {
+		current, err := db.C.GetProfile(c.GetContext(p.Ctx), id)
+		if err != nil {
+			errMsg := fmt.Sprintf("profile %s not found: %v", id, err)
+			p.ErrorHandle(model.ErrorNotFound, errMsg)
+			return
+		}
+		// The update body rarely carries the storage type, so the check
+		// runs against the stored one.
+		check := profile
+		check.Id = id
+		if check.StorageType == "" {
+			check.StorageType = current.StorageType
+		}
+		if err := util.ValidateBaseProfile(c.GetContext(p.Ctx), &check); err != nil {
+			errMsg := fmt.Sprintf("invalid baseProfileId: %v", err)
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+
 	result, err := db.C.UpdateProfile(c.GetContext(p.Ctx), id, &profile)
 	if err != nil {
 		errMsg := fmt.Sprintf("update profiles failed: %v", err)
@@ -196,14 +274,28 @@ func (p *ProfilePortal) DeleteProfile() {
 	profile, err := db.C.GetProfile(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("profile %s not found: %v", id, err)
-		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		p.DeleteNotFoundHandle(errMsg)
 		return
 	}
 
 	// Check the depedency before deletion of profile
 	// If no dependency then only allow user to delete profile
-	// 1. Check the volumes created through that profile
-	// 2. Check the fileshares created through that profile
+	// 1. Check the profiles inheriting from that profile
+	// 2. Check the volumes created through that profile
+	// 3. Check the fileshares created through that profile
+	profiles, err := db.C.ListProfiles(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to fetch profiles: %v", err)
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	for _, child := range profiles {
+		if child.BaseProfileId == id {
+			errMsg := fmt.Sprintf("There are dependent profiles : %v for the specified profile %v", child.Id, id)
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
 	if profile.StorageType == constants.Block {
 		vols, err := db.C.ListVolumesByProfileId(ctx, id)
 		if err != nil {
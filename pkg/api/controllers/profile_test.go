@@ -253,6 +253,87 @@ func TestGetProfile(t *testing.T) {
 		beego.BeeApp.Handlers.ServeHTTP(w, r)
 		assertTestResult(t, w.Code, 404)
 	})
+
+	t.Run("Should return 404 if the profile is restricted to other tenants", func(t *testing.T) {
+		var restricted = SampleProfiles[1]
+		restricted.Visibility = model.ProfileVisibilityRestricted
+		restricted.AllowedTenants = []string{"1106b972-66ef-11e7-b172-db03f3689c9c"}
+		tenantCtx := &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetProfile", tenantCtx, "2f9c0a04-66ef-11e7-ade2-43158893e017").
+			Return(&restricted, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET",
+			"/v1beta/profiles/2f9c0a04-66ef-11e7-ade2-43158893e017", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", tenantCtx)
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
+
+func TestGetProfileResolved(t *testing.T) {
+	base := &model.ProfileSpec{
+		BaseModel:   &model.BaseModel{Id: "1106b972-66ef-11e7-b172-db03f3689c9c"},
+		Name:        "gold",
+		StorageType: "block",
+		CustomProperties: model.CustomPropertiesSpec{
+			"diskType": "SSD",
+			"latency":  "3ms",
+		},
+	}
+	child := &model.ProfileSpec{
+		BaseModel:     &model.BaseModel{Id: "2f9c0a04-66ef-11e7-ade2-43158893e017"},
+		Name:          "gold-encrypted",
+		StorageType:   "block",
+		BaseProfileId: base.Id,
+		CustomProperties: model.CustomPropertiesSpec{
+			"latency":   "5ms",
+			"encrypted": true,
+		},
+	}
+
+	t.Run("Should overlay the custom properties of the base", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetProfile", c.NewAdminContext(), child.Id).Return(child, nil)
+		mockClient.On("GetProfile", c.NewAdminContext(), base.Id).Return(base, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/profiles/"+child.Id+"?resolved=true", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.ProfileSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.CustomProperties, model.CustomPropertiesSpec{
+			"diskType":  "SSD",
+			"latency":   "5ms",
+			"encrypted": true,
+		})
+	})
+
+	t.Run("Should return 400 if the base of a new profile is missing", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetProfile", c.NewAdminContext(), base.Id).
+			Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		body := []byte(`{"name": "gold-encrypted", "storageType": "block",
+			"baseProfileId": "` + base.Id + `"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/profiles", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
 }
 
 func TestDeleteProfile(t *testing.T) {
@@ -261,6 +342,7 @@ func TestDeleteProfile(t *testing.T) {
 		mockClient := new(dbtest.Client)
 		mockClient.On("GetProfile", c.NewAdminContext(), "2f9c0a04-66ef-11e7-ade2-43158893e017").Return(
 			&SampleProfiles[1], nil)
+		mockClient.On("ListProfiles", c.NewAdminContext()).Return(nil, nil)
 		mockClient.On("ListVolumesByProfileId", c.NewAdminContext(), "2f9c0a04-66ef-11e7-ade2-43158893e017").Return(
 			SampleVolumeNames, nil)
 		mockClient.On("DeleteProfile", c.NewAdminContext(), "2f9c0a04-66ef-11e7-ade2-43158893e017").Return(nil)
@@ -519,6 +601,7 @@ func TestDeleteFileShareProfile(t *testing.T) {
 		mockClient := new(dbtest.Client)
 		mockClient.On("GetProfile", c.NewAdminContext(), "2f9c0a04-66ef-11e7-ade2-43158893e017").Return(
 			&SampleFileShareProfiles[1], nil)
+		mockClient.On("ListProfiles", c.NewAdminContext()).Return(nil, nil)
 		mockClient.On("ListFileSharesByProfileId", c.NewAdminContext(), "2f9c0a04-66ef-11e7-ade2-43158893e017").Return(
 			SampleShareNames, nil)
 		mockClient.On("DeleteProfile", c.NewAdminContext(), "2f9c0a04-66ef-11e7-ade2-43158893e017").Return(nil)
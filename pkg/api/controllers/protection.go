@@ -0,0 +1,250 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the protection report endpoint feeding SLA and
+compliance reviews: which volumes and fileshares of a tenant have a recent
+snapshot, which take part in an enabled replication, and which are
+unprotected.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+func NewProtectionReportPortal() *ProtectionReportPortal {
+	return &ProtectionReportPortal{}
+}
+
+type ProtectionReportPortal struct {
+	BasePortal
+}
+
+// defaultSnapshotMaxAge is how young the most recent snapshot of a
+// resource has to be to count as protection, unless the request or the
+// profile of the resource says otherwise.
+const defaultSnapshotMaxAge = 24 * time.Hour
+
+// SnapshotMaxAgeKey is the profile custom property tightening or loosening
+// the snapshot age limit for the resources of the profile, a duration
+// string like "4h".
+const SnapshotMaxAgeKey = "snapshotMaxAge"
+
+// GetProtectionReport serves the protection report of a tenant. Admins may
+// ask for any tenant through the tenantId parameter, everyone else only
+// for their own. The snapshotMaxAge parameter overrides the default age
+// limit of the report.
+func (p *ProtectionReportPortal) GetProtectionReport() {
+	if !policy.Authorize(p.Ctx, "protection_report:get") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+
+	tenantId := p.Ctx.Input.Query("tenantId")
+	if tenantId == "" {
+		tenantId = ctx.TenantId
+	}
+	if tenantId != ctx.TenantId && !ctx.IsAdmin {
+		errMsg := fmt.Sprintf("only admin users are allowed to read the protection report of tenant %s", tenantId)
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+	maxAge := defaultSnapshotMaxAge
+	if v := p.Ctx.Input.Query("snapshotMaxAge"); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed <= 0 {
+			errMsg := fmt.Sprintf("invalid snapshotMaxAge %s, expected a positive duration like 24h", v)
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+		maxAge = parsed
+	}
+
+	report, err := p.buildProtectionReport(ctx, tenantId, maxAge)
+	if err != nil {
+		errMsg := fmt.Sprintf("build protection report failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(report)
+	p.SuccessHandle(StatusOK, body)
+	return
+}
+
+// latestSnapshot remembers the most recent snapshot seen for a resource.
+type latestSnapshot struct {
+	id string
+	at time.Time
+}
+
+// observe keeps the younger of the remembered and the offered snapshot.
+func observe(latest map[string]latestSnapshot, resourceId, snapshotId, createdAt string) {
+	at, err := utils.ParseTime(createdAt)
+	if err != nil {
+		log.Warningf("skipping snapshot %s with unparsable creation time %q in the protection report",
+			snapshotId, createdAt)
+		return
+	}
+	if current, ok := latest[resourceId]; ok && !at.After(current.at) {
+		return
+	}
+	latest[resourceId] = latestSnapshot{id: snapshotId, at: at}
+}
+
+// buildProtectionReport derives the report from the db records alone: no
+// dock is asked, so the report is cheap enough for periodic reviews.
+func (p *ProtectionReportPortal) buildProtectionReport(ctx *c.Context, tenantId string, maxAge time.Duration) (*model.ProtectionReportSpec, error) {
+	volumes, err := db.C.ListVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shares, err := db.C.ListFileShares(ctx)
+	if err != nil {
+		return nil, err
+	}
+	volumeSnapshots, err := db.C.ListVolumeSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shareSnapshots, err := db.C.ListFileShareSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	replications, err := db.C.ListReplication(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	latestVolumeSnap := map[string]latestSnapshot{}
+	for _, snapshot := range volumeSnapshots {
+		if snapshot.Status != model.VolumeSnapAvailable {
+			continue
+		}
+		observe(latestVolumeSnap, snapshot.VolumeId, snapshot.Id, snapshot.CreatedAt)
+	}
+	latestShareSnap := map[string]latestSnapshot{}
+	for _, snapshot := range shareSnapshots {
+		if snapshot.Status != model.FileShareSnapAvailable {
+			continue
+		}
+		observe(latestShareSnap, snapshot.FileShareId, snapshot.Id, snapshot.CreatedAt)
+	}
+	replicated := map[string]string{}
+	for _, replication := range replications {
+		if replication.ReplicationStatus != model.ReplicationEnabled {
+			continue
+		}
+		replicated[replication.PrimaryVolumeId] = replication.Id
+		replicated[replication.SecondaryVolumeId] = replication.Id
+	}
+
+	// The age limit of a resource: the snapshotMaxAge custom property of
+	// its profile wins over the limit of the report.
+	profiles := map[string]*model.ProfileSpec{}
+	ageLimit := func(profileId string) time.Duration {
+		if profileId == "" {
+			return maxAge
+		}
+		prf, ok := profiles[profileId]
+		if !ok {
+			var err error
+			if prf, err = db.C.GetProfile(ctx, profileId); err != nil {
+				log.Warningf("when getting profile %s for the protection report: %v", profileId, err)
+				prf = nil
+			}
+			profiles[profileId] = prf
+		}
+		if prf == nil {
+			return maxAge
+		}
+		if v, ok := prf.CustomProperties[SnapshotMaxAgeKey].(string); ok {
+			if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+				return parsed
+			}
+		}
+		return maxAge
+	}
+
+	now := time.Now()
+	report := &model.ProtectionReportSpec{
+		TenantId:       tenantId,
+		GeneratedAt:    utils.FormatTime(now),
+		SnapshotMaxAge: maxAge.String(),
+		Resources:      []*model.ProtectionResourceSpec{},
+	}
+	add := func(entry *model.ProtectionResourceSpec) {
+		report.TotalResources++
+		if entry.Protected {
+			report.ProtectedResources++
+		} else {
+			report.UnprotectedResources++
+		}
+		report.Resources = append(report.Resources, entry)
+	}
+	for _, volume := range volumes {
+		if volume.TenantId != tenantId {
+			continue
+		}
+		entry := &model.ProtectionResourceSpec{
+			Id:           volume.Id,
+			Name:         volume.Name,
+			ResourceType: "volume",
+		}
+		if latest, ok := latestVolumeSnap[volume.Id]; ok {
+			entry.LastSnapshotId = latest.id
+			entry.LastSnapshotAt = utils.FormatTime(latest.at)
+			entry.SnapshotFresh = now.Sub(latest.at) <= ageLimit(volume.ProfileId)
+		}
+		entry.ReplicationId = replicated[volume.Id]
+		entry.Protected = entry.SnapshotFresh || entry.ReplicationId != ""
+		add(entry)
+	}
+	for _, share := range shares {
+		if share.TenantId != tenantId {
+			continue
+		}
+		entry := &model.ProtectionResourceSpec{
+			Id:           share.Id,
+			Name:         share.Name,
+			ResourceType: "fileshare",
+		}
+		if latest, ok := latestShareSnap[share.Id]; ok {
+			entry.LastSnapshotId = latest.id
+			entry.LastSnapshotAt = utils.FormatTime(latest.at)
+			entry.SnapshotFresh = now.Sub(latest.at) <= ageLimit(share.ProfileId)
+		}
+		entry.Protected = entry.SnapshotFresh
+		add(entry)
+	}
+
+	// The unprotected resources are what a review acts on, so they lead.
+	sort.SliceStable(report.Resources, func(i, j int) bool {
+		return !report.Resources[i].Protected && report.Resources[j].Protected
+	})
+	return report, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+// //////////////////////////////////////////////////////////////////////////////
+//
+//	Prepare for mock server                               //
+//
+// //////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/reports/protection", NewProtectionReportPortal(),
+		"get:GetProtectionReport")
+}
+
+const protectionTenant = "e93b4c0934da416eb9c8d120c5d04d96"
+
+func protectionReportRequest(t *testing.T, url string) (int, *model.ProtectionReportSpec) {
+	r, _ := http.NewRequest("GET", url, nil)
+	w := httptest.NewRecorder()
+	beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+		httpCtx.Input.SetData("context", c.NewAdminContext())
+	})
+	beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+	var report model.ProtectionReportSpec
+	json.Unmarshal(w.Body.Bytes(), &report)
+	return w.Code, &report
+}
+
+func TestGetProtectionReport(t *testing.T) {
+	volume := &model.VolumeSpec{
+		BaseModel: &model.BaseModel{Id: "bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		TenantId:  protectionTenant,
+		Name:      "sample-volume",
+	}
+	share := &model.FileShareSpec{
+		BaseModel: &model.BaseModel{Id: "d2975ebe-d82c-430f-b28e-f373746a71ca"},
+		TenantId:  protectionTenant,
+		Name:      "sample-fileshare",
+	}
+
+	mockLists := func(mockClient *dbtest.Client, volumeSnaps []*model.VolumeSnapshotSpec,
+		shareSnaps []*model.FileShareSnapshotSpec, replications []*model.ReplicationSpec) {
+		mockClient.On("ListVolumes", c.NewAdminContext()).Return([]*model.VolumeSpec{volume}, nil)
+		mockClient.On("ListFileShares", c.NewAdminContext()).Return([]*model.FileShareSpec{share}, nil)
+		mockClient.On("ListVolumeSnapshots", c.NewAdminContext()).Return(volumeSnaps, nil)
+		mockClient.On("ListFileShareSnapshots", c.NewAdminContext()).Return(shareSnaps, nil)
+		mockClient.On("ListReplication", c.NewAdminContext()).Return(replications, nil)
+	}
+
+	t.Run("A fresh snapshot protects the resource", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockLists(mockClient,
+			[]*model.VolumeSnapshotSpec{{
+				BaseModel: &model.BaseModel{
+					Id:        "3769855c-a102-11e7-b772-17b880d2f537",
+					CreatedAt: utils.FormatTime(time.Now().Add(-time.Hour)),
+				},
+				VolumeId: volume.Id,
+				Status:   model.VolumeSnapAvailable,
+			}},
+			nil, nil)
+		db.C = mockClient
+
+		code, report := protectionReportRequest(t,
+			"/v1beta/reports/protection?tenantId="+protectionTenant)
+		assertTestResult(t, code, 200)
+		assertTestResult(t, report.TotalResources, 2)
+		assertTestResult(t, report.ProtectedResources, 1)
+		assertTestResult(t, report.UnprotectedResources, 1)
+		// The unprotected fileshare leads, the protected volume follows.
+		assertTestResult(t, report.Resources[0].Id, share.Id)
+		assertTestResult(t, report.Resources[1].Protected, true)
+		assertTestResult(t, report.Resources[1].SnapshotFresh, true)
+	})
+
+	t.Run("A stale snapshot does not protect the resource", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockLists(mockClient,
+			[]*model.VolumeSnapshotSpec{{
+				BaseModel: &model.BaseModel{
+					Id:        "3769855c-a102-11e7-b772-17b880d2f537",
+					CreatedAt: "2017-08-02T09:17:05",
+				},
+				VolumeId: volume.Id,
+				Status:   model.VolumeSnapAvailable,
+			}},
+			nil, nil)
+		db.C = mockClient
+
+		code, report := protectionReportRequest(t,
+			"/v1beta/reports/protection?tenantId="+protectionTenant)
+		assertTestResult(t, code, 200)
+		assertTestResult(t, report.ProtectedResources, 0)
+		assertTestResult(t, report.UnprotectedResources, 2)
+		assertTestResult(t, report.Resources[0].SnapshotFresh, false)
+	})
+
+	t.Run("An enabled replication protects the volume", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockLists(mockClient, nil, nil,
+			[]*model.ReplicationSpec{{
+				BaseModel:         &model.BaseModel{Id: "c299a978-4f3e-11e8-8a5c-977218a83359"},
+				PrimaryVolumeId:   volume.Id,
+				ReplicationStatus: model.ReplicationEnabled,
+			}})
+		db.C = mockClient
+
+		code, report := protectionReportRequest(t,
+			"/v1beta/reports/protection?tenantId="+protectionTenant)
+		assertTestResult(t, code, 200)
+		assertTestResult(t, report.ProtectedResources, 1)
+		assertTestResult(t, report.Resources[1].ReplicationId,
+			"c299a978-4f3e-11e8-8a5c-977218a83359")
+	})
+
+	t.Run("Should return 400 for an invalid snapshotMaxAge", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		code, _ := protectionReportRequest(t,
+			"/v1beta/reports/protection?snapshotMaxAge=soon")
+		assertTestResult(t, code, 400)
+	})
+
+	t.Run("Should return 403 when a non-admin asks for another tenant", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		r, _ := http.NewRequest("GET",
+			"/v1beta/reports/protection?tenantId="+protectionTenant, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "any-other-tenant"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sodafoundation/api/pkg/api/policy"
+	"github.com/sodafoundation/api/pkg/api/util"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewQuotaPortal() *QuotaPortal {
+	return &QuotaPortal{}
+}
+
+type QuotaPortal struct {
+	BasePortal
+}
+
+// GetQuota returns the quota of the tenant in the url together with its
+// current usage. A tenant can read its own quota, administrators any.
+func (q *QuotaPortal) GetQuota() {
+	if !policy.Authorize(q.Ctx, "quota:get") {
+		return
+	}
+	ctx := c.GetContext(q.Ctx)
+	tenantId := q.Ctx.Input.Param(":tenantId")
+
+	if !ctx.IsAdmin && tenantId != ctx.TenantId {
+		errMsg := fmt.Sprintf("only administrators can read the quota of another tenant")
+		q.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	quota, err := db.C.GetQuota(ctx, tenantId)
+	if err != nil {
+		errMsg := fmt.Sprintf("quota of tenant %s not found: %s", tenantId, err.Error())
+		q.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	used, err := util.UsedGbOfTenant(c.NewInternalTenantContext(tenantId, ctx.UserId))
+	if err != nil {
+		errMsg := fmt.Sprintf("compute quota usage of tenant %s failed: %s", tenantId, err.Error())
+		q.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	quota.UsedGb = used
+	if quota.GbQuota > 0 {
+		quota.UsedPercent = float64(used) * 100 / float64(quota.GbQuota)
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(quota)
+	q.SuccessHandle(StatusOK, body)
+	return
+}
+
+// SetQuota creates or replaces the quota of the tenant in the url.
+func (q *QuotaPortal) SetQuota() {
+	if !policy.Authorize(q.Ctx, "quota:set") {
+		return
+	}
+	ctx := c.GetContext(q.Ctx)
+	tenantId := q.Ctx.Input.Param(":tenantId")
+
+	var quota = model.QuotaSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(q.Ctx.Request.Body).Decode(&quota); err != nil {
+		errMsg := fmt.Sprintf("parse quota request body failed: %s", err.Error())
+		q.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if quota.GbQuota < 0 {
+		errMsg := fmt.Sprintf("gbQuota must not be negative")
+		q.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	quota.TenantId = tenantId
+
+	result, err := db.C.SetQuota(ctx, &quota)
+	if err != nil {
+		errMsg := fmt.Sprintf("set quota failed: %s", err.Error())
+		q.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	q.SuccessHandle(StatusOK, body)
+	return
+}
+
+// DeleteQuota removes the quota of the tenant in the url, lifting the cap.
+func (q *QuotaPortal) DeleteQuota() {
+	if !policy.Authorize(q.Ctx, "quota:delete") {
+		return
+	}
+	ctx := c.GetContext(q.Ctx)
+	tenantId := q.Ctx.Input.Param(":tenantId")
+
+	if _, err := db.C.GetQuota(ctx, tenantId); err != nil {
+		errMsg := fmt.Sprintf("quota of tenant %s not found: %s", tenantId, err.Error())
+		q.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	if err := db.C.DeleteQuota(ctx, tenantId); err != nil {
+		errMsg := fmt.Sprintf("delete quota failed: %s", err.Error())
+		q.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	q.SuccessHandle(StatusOK, nil)
+	return
+}
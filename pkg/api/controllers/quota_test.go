@@ -0,0 +1,126 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+// //////////////////////////////////////////////////////////////////////////////
+//
+//	Prepare for mock server                               //
+//
+// //////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/:tenantId/quotas", &QuotaPortal{},
+		"get:GetQuota;put:SetQuota;delete:DeleteQuota")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                             Tests for quota                                //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestGetQuota(t *testing.T) {
+
+	t.Run("Should return 200 with the usage percentage filled in", func(t *testing.T) {
+		quota := SampleQuotas[0]
+		volumes := []*model.VolumeSpec{{BaseModel: &model.BaseModel{}, Size: 40}}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetQuota", c.NewAdminContext(), "e93b4c0934da416eb9c8d120c5d04d96").
+			Return(&quota, nil)
+		mockClient.On("ListVolumes", c.NewInternalTenantContext("e93b4c0934da416eb9c8d120c5d04d96", "")).
+			Return(volumes, nil)
+		mockClient.On("ListFileShares", c.NewInternalTenantContext("e93b4c0934da416eb9c8d120c5d04d96", "")).
+			Return(nil, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/quotas", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output model.QuotaSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.UsedGb, int64(40))
+		assertTestResult(t, output.UsedPercent, float64(40))
+	})
+
+	t.Run("Should return 403 if a tenant reads the quota of another tenant", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/quotas", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "x7a91c0a2ab41b9cf76f71a91b"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+}
+
+func TestSetQuota(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		quota := &model.QuotaSpec{
+			BaseModel: &model.BaseModel{},
+			TenantId:  "e93b4c0934da416eb9c8d120c5d04d96",
+			GbQuota:   100,
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("SetQuota", c.NewAdminContext(), quota).Return(&SampleQuotas[0], nil)
+		db.C = mockClient
+
+		body := []byte(`{"gbQuota": 100}`)
+		r, _ := http.NewRequest("PUT", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/quotas", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output model.QuotaSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleQuotas[0])
+	})
+
+	t.Run("Should return 400 if the quota is negative", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		body := []byte(`{"gbQuota": -1}`)
+		r, _ := http.NewRequest("PUT", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/quotas", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
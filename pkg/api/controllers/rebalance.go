@@ -0,0 +1,295 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/jobs"
+	"github.com/sodafoundation/api/pkg/api/policy"
+	"github.com/sodafoundation/api/pkg/api/util"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+func NewRebalancePortal() *RebalancePortal {
+	return &RebalancePortal{}
+}
+
+// RebalancePortal implements the admin planner that proposes fileshare
+// migrations to even out pool utilization. Migrations never leave the
+// availability zone of the share and only target pools that can serve the
+// access protocol of its profile.
+type RebalancePortal struct {
+	BasePortal
+}
+
+const (
+	defaultRebalanceSpread        = 10
+	defaultRebalanceMaxMigrations = 10
+)
+
+// Planning is also exposed as a background job, so a large plan does not
+// have to finish within one admin request.
+func init() {
+	jobs.Register("rebalance", rebalanceJob)
+}
+
+// rebalancePool is the planner's working copy of a pool, so proposed
+// migrations can be simulated without touching the stored records.
+type rebalancePool struct {
+	pool *model.StoragePoolSpec
+	free int64
+}
+
+func (p *rebalancePool) utilization() float64 {
+	if p.pool.TotalCapacity <= 0 {
+		return 0
+	}
+	return float64(p.pool.TotalCapacity-p.free) / float64(p.pool.TotalCapacity)
+}
+
+func (p *RebalancePortal) Rebalance() {
+	if !policy.Authorize(p.Ctx, "rebalance:create") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to rebalance shares across pools"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	var in = model.RebalanceRequestSpec{}
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&in); err != nil {
+		errMsg := fmt.Sprintf("parse rebalance request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if in.SpreadPercent < 0 || in.MaxMigrations < 0 {
+		errMsg := "spreadPercent and maxMigrations must not be negative"
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := util.ValidateMaxBandwidth(in.MaxBandwidthMBps); err != nil {
+		errMsg := fmt.Sprintf("plan rebalance failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if in.SpreadPercent == 0 {
+		in.SpreadPercent = defaultRebalanceSpread
+	}
+	if in.MaxMigrations == 0 {
+		in.MaxMigrations = defaultRebalanceMaxMigrations
+	}
+	// NOTE: Driving the proposed migrations needs fileshare migration
+	// support in the connected controller, which is not available yet.
+	// Until it is, the planner only serves dry runs.
+	if in.Execute {
+		errMsg := "executing migrations is not supported by the connected controller yet, " +
+			"rerun without execute to get the migration plan"
+		p.ErrorHandle(model.ErrorNotImplemented, errMsg)
+		return
+	}
+
+	plan, err := planRebalance(ctx, &in, func() bool { return false })
+	if err != nil {
+		errMsg := fmt.Sprintf("plan rebalance failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(plan)
+	p.SuccessHandle(StatusOK, body)
+	return
+}
+
+// rebalanceJob plans a rebalance as a background job. The fields of the
+// rebalance request are accepted as job parameters: spreadPercent,
+// maxMigrations and maxBandwidthMBps as numbers. Like the endpoint, the job
+// only produces a plan; migrations are never carried out.
+func rebalanceJob(ctx *c.Context, job *model.JobSpec, cancelled func() bool) error {
+	var in = model.RebalanceRequestSpec{
+		SpreadPercent: defaultRebalanceSpread,
+		MaxMigrations: defaultRebalanceMaxMigrations,
+	}
+	if raw, ok := job.Params["spreadPercent"]; ok && raw != "" {
+		spread, err := strconv.ParseFloat(raw, 64)
+		if err != nil || spread < 0 {
+			return fmt.Errorf("invalid spreadPercent parameter %s", raw)
+		}
+		in.SpreadPercent = spread
+	}
+	if raw, ok := job.Params["maxMigrations"]; ok && raw != "" {
+		max, err := strconv.Atoi(raw)
+		if err != nil || max < 0 {
+			return fmt.Errorf("invalid maxMigrations parameter %s", raw)
+		}
+		in.MaxMigrations = max
+	}
+	if raw, ok := job.Params["maxBandwidthMBps"]; ok && raw != "" {
+		bandwidth, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid maxBandwidthMBps parameter %s", raw)
+		}
+		if err := util.ValidateMaxBandwidth(bandwidth); err != nil {
+			return err
+		}
+		in.MaxBandwidthMBps = bandwidth
+	}
+
+	plan, err := planRebalance(ctx, &in, cancelled)
+	if err != nil {
+		return err
+	}
+	job.Result = map[string]string{"migrations": strconv.Itoa(len(plan.Migrations))}
+	return nil
+}
+
+// planRebalance proposes migrations availability zone by availability zone,
+// repeatedly moving a share from the most utilized pool to the least
+// utilized one until the utilization spread drops below the requested
+// threshold or the migration budget is spent.
+func planRebalance(ctx *c.Context, in *model.RebalanceRequestSpec, cancelled func() bool) (*model.RebalancePlanSpec, error) {
+	pools, err := db.C.ListPools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rejectStale := CONF.OsdsApiServer.RejectStalePools
+	var zones = map[string][]*rebalancePool{}
+	var poolsById = map[string]*rebalancePool{}
+	for _, pool := range pools {
+		if pool.StorageType != constants.File || pool.TotalCapacity <= 0 {
+			continue
+		}
+		// a pool under maintenance must not receive migrated shares
+		if pool.Maintenance {
+			continue
+		}
+		if rejectStale && util.PoolIsStale(pool) {
+			continue
+		}
+		rp := &rebalancePool{pool: pool, free: pool.FreeCapacity}
+		zones[pool.AvailabilityZone] = append(zones[pool.AvailabilityZone], rp)
+		poolsById[pool.Id] = rp
+	}
+
+	shares, err := db.C.ListFileShares(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var sharesByPool = map[string][]*model.FileShareSpec{}
+	for _, share := range shares {
+		if share.Status != model.FileShareAvailable {
+			continue
+		}
+		sharesByPool[share.PoolId] = append(sharesByPool[share.PoolId], share)
+	}
+
+	var plan = &model.RebalancePlanSpec{
+		DryRun:     !in.Execute,
+		Migrations: []*model.RebalanceMigrationSpec{},
+	}
+	var profiles = map[string]*model.ProfileSpec{}
+	budget := in.MaxMigrations
+	for zone, zonePools := range zones {
+		if len(zonePools) < 2 {
+			continue
+		}
+		for budget > 0 && !cancelled() {
+			sort.Slice(zonePools, func(i, j int) bool {
+				return zonePools[i].utilization() > zonePools[j].utilization()
+			})
+			src, dst := zonePools[0], zonePools[len(zonePools)-1]
+			if (src.utilization()-dst.utilization())*100 <= in.SpreadPercent {
+				break
+			}
+			migration := proposeMigration(ctx, src, dst, sharesByPool, profiles, zone)
+			if migration == nil {
+				break
+			}
+			// the bandwidth limit of the request rides on every proposed
+			// migration, so executing the plan honors it
+			migration.MaxBandwidthMBps = in.MaxBandwidthMBps
+			plan.Migrations = append(plan.Migrations, migration)
+			budget--
+		}
+	}
+	return plan, nil
+}
+
+// proposeMigration picks the smallest movable share of the source pool that
+// the target pool can host and simulates the move. Nil is returned when no
+// share of the source pool fits the target.
+func proposeMigration(ctx *c.Context, src, dst *rebalancePool,
+	sharesByPool map[string][]*model.FileShareSpec, profiles map[string]*model.ProfileSpec,
+	zone string) *model.RebalanceMigrationSpec {
+	candidates := sharesByPool[src.pool.Id]
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Size < candidates[j].Size })
+	for i, share := range candidates {
+		if share.Size <= 0 || share.Size > dst.free {
+			continue
+		}
+		if !profileFitsPool(ctx, share.ProfileId, dst.pool, profiles) {
+			continue
+		}
+		src.free += share.Size
+		dst.free -= share.Size
+		sharesByPool[src.pool.Id] = append(candidates[:i], candidates[i+1:]...)
+		return &model.RebalanceMigrationSpec{
+			FileShareId:      share.Id,
+			TenantId:         share.TenantId,
+			Size:             share.Size,
+			SourcePoolId:     src.pool.Id,
+			TargetPoolId:     dst.pool.Id,
+			AvailabilityZone: zone,
+		}
+	}
+	return nil
+}
+
+// profileFitsPool reports whether the pool can serve shares of the profile.
+// A profile that names an access protocol restricts the share to pools
+// advertising the same protocol.
+func profileFitsPool(ctx *c.Context, profileId string,
+	pool *model.StoragePoolSpec, profiles map[string]*model.ProfileSpec) bool {
+	if profileId == "" {
+		return true
+	}
+	prf, ok := profiles[profileId]
+	if !ok {
+		var err error
+		prf, err = db.C.GetProfile(ctx, profileId)
+		if err != nil {
+			log.Errorf("when getting profile %s for rebalance: %v", profileId, err)
+			return false
+		}
+		profiles[profileId] = prf
+	}
+	protocol := prf.ProvisioningProperties.IOConnectivity.AccessProtocol
+	if protocol == "" {
+		return true
+	}
+	return strings.EqualFold(pool.Extras.IOConnectivity.AccessProtocol, protocol)
+}
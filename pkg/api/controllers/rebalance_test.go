@@ -0,0 +1,109 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+// //////////////////////////////////////////////////////////////////////////////
+//
+//	Prepare for mock server                               //
+//
+// //////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/admin/rebalance", &RebalancePortal{}, "post:Rebalance")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                           Tests for rebalance                              //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestRebalance(t *testing.T) {
+	var filePool = func(id string, total, free int64) *model.StoragePoolSpec {
+		return &model.StoragePoolSpec{
+			BaseModel:        &model.BaseModel{Id: id},
+			StorageType:      "file",
+			TotalCapacity:    total,
+			FreeCapacity:     free,
+			AvailabilityZone: "default",
+		}
+	}
+
+	t.Run("Should propose moving a share off the hot pool", func(t *testing.T) {
+		pools := []*model.StoragePoolSpec{
+			filePool("hot-pool", 100, 10),
+			filePool("cold-pool", 100, 90),
+		}
+		shares := []*model.FileShareSpec{
+			{BaseModel: &model.BaseModel{Id: "d2975ebe-d82c-430f-b28e-f373746a71ca"},
+				PoolId: "hot-pool", Size: 30, Status: model.FileShareAvailable},
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListPools", c.NewAdminContext()).Return(pools, nil)
+		mockClient.On("ListFileShares", c.NewAdminContext()).Return(shares, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/admin/rebalance", bytes.NewBuffer([]byte(`{}`)))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var plan model.RebalancePlanSpec
+		json.Unmarshal(w.Body.Bytes(), &plan)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, plan.DryRun, true)
+		assertTestResult(t, len(plan.Migrations), 1)
+		assertTestResult(t, plan.Migrations[0].SourcePoolId, "hot-pool")
+		assertTestResult(t, plan.Migrations[0].TargetPoolId, "cold-pool")
+	})
+
+	t.Run("Should return 501 for the execute mode", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		r, _ := http.NewRequest("POST", "/v1beta/admin/rebalance", bytes.NewBuffer([]byte(`{"execute": true}`)))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 501)
+	})
+
+	t.Run("Should return 403 for non-admin users", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		r, _ := http.NewRequest("POST", "/v1beta/admin/rebalance", bytes.NewBuffer([]byte(`{}`)))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96"})
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 403)
+	})
+}
@@ -0,0 +1,308 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/jobs"
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+// The reconciler also runs as a background job, so large record sets can be
+// repaired without holding an admin request open.
+func init() {
+	jobs.Register("reconcile", reconcileJob)
+}
+
+func NewReconcilePortal() *ReconcilePortal {
+	return &ReconcilePortal{}
+}
+
+// ReconcilePortal implements the admin job that fixes stale statuses in the
+// database. Resources can be left in a transitional status (e.g. "deleting")
+// forever when a dock finished or crashed without reporting back. This portal
+// inspects the selected resource types and repairs such entries: resources
+// stuck in "deleting" are purged from the database, any other transitional
+// status is turned into "error" so that the user can retry the operation.
+type ReconcilePortal struct {
+	BasePortal
+}
+
+var reconcileResourceTypes = []string{
+	model.ReconcileFileShare,
+	model.ReconcileFileShareSnapshot,
+	model.ReconcileFileShareAcl,
+	model.ReconcileVolume,
+	model.ReconcileVolumeSnapshot,
+	model.ReconcileVolumeAttachment,
+}
+
+// isStale checks whether the resource was last updated before the staleness
+// boundary given in the reconciliation request.
+func isStale(updatedAt string, olderThan int64) bool {
+	if olderThan <= 0 {
+		return true
+	}
+	t, err := utils.ParseTime(updatedAt)
+	if err != nil {
+		// Resources without a parsable update time can not be aged, treat
+		// them as stale so they do not stay broken forever.
+		return true
+	}
+	return time.Since(t) > time.Duration(olderThan)*time.Second
+}
+
+func (p *ReconcilePortal) Reconcile() {
+	if !policy.Authorize(p.Ctx, "reconcile:create") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to reconcile resource statuses"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	var in = model.ReconcileRequestSpec{}
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&in); err != nil {
+		errMsg := fmt.Sprintf("parse reconcile request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if len(in.ResourceTypes) == 0 {
+		in.ResourceTypes = reconcileResourceTypes
+	}
+	for _, rt := range in.ResourceTypes {
+		if !utils.Contained(rt, reconcileResourceTypes) {
+			errMsg := fmt.Sprintf("invalid resource type %s in reconcile request", rt)
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+
+	report, err := runReconcile(ctx, &in, func() bool { return false })
+	if err != nil {
+		p.ErrorHandle(model.ErrorInternalServer, err.Error())
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(report)
+	p.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+// runReconcile checks the requested resource types and repairs the stale
+// entries. It is shared between the synchronous reconcile endpoint and the
+// reconcile job type.
+func runReconcile(ctx *c.Context, in *model.ReconcileRequestSpec, cancelled func() bool) (*model.ReconcileReportSpec, error) {
+	report := &model.ReconcileReportSpec{
+		DryRun:     in.DryRun,
+		Reconciled: []*model.ReconcileItemSpec{},
+	}
+	for _, rt := range in.ResourceTypes {
+		if cancelled() {
+			break
+		}
+		if err := reconcileResources(ctx, rt, in, report); err != nil {
+			return nil, fmt.Errorf("reconcile %s resources failed: %s", rt, err.Error())
+		}
+	}
+	return report, nil
+}
+
+// reconcileJob runs a reconciliation as a background job. The fields of the
+// reconcile request are accepted as job parameters: resourceTypes as a comma
+// separated list, olderThan as a duration and dryRun as a boolean.
+func reconcileJob(ctx *c.Context, job *model.JobSpec, cancelled func() bool) error {
+	var in = model.ReconcileRequestSpec{ResourceTypes: reconcileResourceTypes}
+	if raw, ok := job.Params["resourceTypes"]; ok && raw != "" {
+		in.ResourceTypes = nil
+		for _, rt := range strings.Split(raw, ",") {
+			rt = strings.TrimSpace(rt)
+			if !utils.Contained(rt, reconcileResourceTypes) {
+				return fmt.Errorf("invalid resource type %s in reconcile request", rt)
+			}
+			in.ResourceTypes = append(in.ResourceTypes, rt)
+		}
+	}
+	if raw, ok := job.Params["olderThan"]; ok && raw != "" {
+		olderThan, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid olderThan parameter %s: %v", raw, err)
+		}
+		in.OlderThan = int64(olderThan / time.Second)
+	}
+	in.DryRun = job.Params["dryRun"] == "true"
+
+	report, err := runReconcile(ctx, &in, cancelled)
+	if err != nil {
+		return err
+	}
+	job.Result = map[string]string{
+		"checked":    strconv.FormatInt(report.Checked, 10),
+		"reconciled": strconv.Itoa(len(report.Reconciled)),
+	}
+	return nil
+}
+
+// reconcileResources collects the stale entries of one resource type and
+// repairs them, appending every fixed entry to the report.
+func reconcileResources(ctx *c.Context, resourceType string, in *model.ReconcileRequestSpec, report *model.ReconcileReportSpec) error {
+	type staleEntry struct {
+		id, tenantId, status, updatedAt string
+	}
+	var entries []staleEntry
+	var deletingStatus, creatingStatus []string
+
+	switch resourceType {
+	case model.ReconcileFileShare:
+		shares, err := db.C.ListFileShares(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range shares {
+			entries = append(entries, staleEntry{s.Id, s.TenantId, s.Status, s.UpdatedAt})
+		}
+		deletingStatus = []string{model.FileShareDeleting}
+		creatingStatus = []string{model.FileShareCreating}
+	case model.ReconcileFileShareSnapshot:
+		snaps, err := db.C.ListFileShareSnapshots(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range snaps {
+			entries = append(entries, staleEntry{s.Id, s.TenantId, s.Status, s.UpdatedAt})
+		}
+		deletingStatus = []string{model.FileShareSnapDeleting}
+		creatingStatus = []string{model.FileShareSnapCreating}
+	case model.ReconcileFileShareAcl:
+		acls, err := db.C.ListFileSharesAcl(ctx)
+		if err != nil {
+			return err
+		}
+		for _, a := range acls {
+			entries = append(entries, staleEntry{a.Id, a.TenantId, a.Status, a.UpdatedAt})
+		}
+		deletingStatus = []string{model.FileShareAclDeleting}
+	case model.ReconcileVolume:
+		vols, err := db.C.ListVolumes(ctx)
+		if err != nil {
+			return err
+		}
+		for _, v := range vols {
+			entries = append(entries, staleEntry{v.Id, v.TenantId, v.Status, v.UpdatedAt})
+		}
+		deletingStatus = []string{model.VolumeDeleting}
+		creatingStatus = []string{model.VolumeCreating, model.VolumeExtending}
+	case model.ReconcileVolumeSnapshot:
+		snaps, err := db.C.ListVolumeSnapshots(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range snaps {
+			entries = append(entries, staleEntry{s.Id, s.TenantId, s.Status, s.UpdatedAt})
+		}
+		deletingStatus = []string{model.VolumeSnapDeleting}
+		creatingStatus = []string{model.VolumeSnapCreating}
+	case model.ReconcileVolumeAttachment:
+		atcs, err := db.C.ListVolumeAttachments(ctx, "")
+		if err != nil {
+			return err
+		}
+		for _, a := range atcs {
+			entries = append(entries, staleEntry{a.Id, a.TenantId, a.Status, a.UpdatedAt})
+		}
+		deletingStatus = []string{model.VolumeAttachDeleting}
+		creatingStatus = []string{model.VolumeAttachCreating}
+	}
+
+	for _, e := range entries {
+		report.Checked++
+		if !isStale(e.updatedAt, in.OlderThan) {
+			continue
+		}
+		var action string
+		if utils.Contained(e.status, deletingStatus) {
+			action = model.ReconcileActionPurged
+		} else if utils.Contained(e.status, creatingStatus) {
+			action = model.ReconcileActionMarkedError
+		} else {
+			continue
+		}
+		if !in.DryRun {
+			if err := repairResource(ctx, resourceType, e.id, action); err != nil {
+				log.Errorf("failed to reconcile %s %s: %v", resourceType, e.id, err)
+				continue
+			}
+		}
+		report.Reconciled = append(report.Reconciled, &model.ReconcileItemSpec{
+			ResourceType: resourceType,
+			Id:           e.id,
+			TenantId:     e.tenantId,
+			OldStatus:    e.status,
+			Action:       action,
+		})
+	}
+	return nil
+}
+
+// The fault recorded on resources the reconciler marks as error.
+const reconcileFaultReason = "operation timed out, the resource was marked error by the reconciler"
+
+// repairResource applies the reconciliation action to one database entry.
+func repairResource(ctx *c.Context, resourceType, id, action string) error {
+	switch resourceType {
+	case model.ReconcileFileShare:
+		if action == model.ReconcileActionPurged {
+			return db.C.DeleteFileShare(ctx, id)
+		}
+		return db.UpdateFileShareFault(ctx, db.C, id, model.FileShareError, reconcileFaultReason)
+	case model.ReconcileFileShareSnapshot:
+		if action == model.ReconcileActionPurged {
+			return db.C.DeleteFileShareSnapshot(ctx, id)
+		}
+		return db.UpdateFileShareSnapshotFault(ctx, db.C, id, model.FileShareSnapError, reconcileFaultReason)
+	case model.ReconcileFileShareAcl:
+		return db.C.DeleteFileShareAcl(ctx, id)
+	case model.ReconcileVolume:
+		if action == model.ReconcileActionPurged {
+			return db.C.DeleteVolume(ctx, id)
+		}
+		return db.UpdateVolumeFault(ctx, db.C, id, model.VolumeError, reconcileFaultReason)
+	case model.ReconcileVolumeSnapshot:
+		if action == model.ReconcileActionPurged {
+			return db.C.DeleteVolumeSnapshot(ctx, id)
+		}
+		return db.UpdateVolumeSnapshotFault(ctx, db.C, id, model.VolumeSnapError, reconcileFaultReason)
+	case model.ReconcileVolumeAttachment:
+		if action == model.ReconcileActionPurged {
+			return db.C.DeleteVolumeAttachment(ctx, id)
+		}
+		return db.UpdateVolumeAttachmentFault(ctx, db.C, id, model.VolumeAttachError, reconcileFaultReason)
+	}
+	return fmt.Errorf("unknown resource type %s", resourceType)
+}
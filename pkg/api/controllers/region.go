@@ -0,0 +1,224 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+func NewRegionPortal() *RegionPortal {
+	return &RegionPortal{}
+}
+
+type RegionPortal struct {
+	BasePortal
+}
+
+var regionStatuses = []string{
+	model.RegionStatusUp,
+	model.RegionStatusDown,
+	model.RegionStatusUnknown,
+}
+
+// validateRegion checks the fields a create or update carries. The fields
+// not carried are left to their defaults respectively to their stored
+// values, so only the name is required and only on create.
+func validateRegion(region *model.RegionSpec, nameRequired bool) error {
+	if nameRequired && region.Name == "" {
+		return fmt.Errorf("region name is required")
+	}
+	if region.Url != "" {
+		u, err := url.Parse(region.Url)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("region url %s is not an absolute url", region.Url)
+		}
+	}
+	if region.Status != "" && !utils.Contains(regionStatuses, region.Status) {
+		return fmt.Errorf("region status %s is not one of %v", region.Status, regionStatuses)
+	}
+	return nil
+}
+
+func (r *RegionPortal) CreateRegion() {
+	if !policy.Authorize(r.Ctx, "region:create") {
+		return
+	}
+	ctx := c.GetContext(r.Ctx)
+
+	var region = model.RegionSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(r.Ctx.Request.Body).Decode(&region); err != nil {
+		errMsg := fmt.Sprintf("parse region request body failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := validateRegion(&region, true); err != nil {
+		errMsg := fmt.Sprintf("create region failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// A second region with the same name would make discovery by name
+	// ambiguous.
+	regions, err := db.C.ListRegions(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list regions failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	for _, existing := range regions {
+		if existing.Name == region.Name {
+			errMsg := fmt.Sprintf("region name %s is already registered as %s", region.Name, existing.Id)
+			r.ErrorHandle(model.ErrorConflict, errMsg)
+			return
+		}
+	}
+
+	result, err := db.C.CreateRegion(ctx, &region)
+	if err != nil {
+		errMsg := fmt.Sprintf("create region failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	r.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (r *RegionPortal) ListRegions() {
+	if !policy.Authorize(r.Ctx, "region:list") {
+		return
+	}
+	ctx := c.GetContext(r.Ctx)
+
+	result, err := db.C.ListRegions(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list regions failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	r.SuccessHandleList(StatusOK, result)
+	return
+}
+
+func (r *RegionPortal) GetRegion() {
+	if !policy.Authorize(r.Ctx, "region:get") {
+		return
+	}
+	id := r.Ctx.Input.Param(":regionId")
+	ctx := c.GetContext(r.Ctx)
+
+	result, err := db.C.GetRegion(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("region %s not found: %s", id, err.Error())
+		r.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	r.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (r *RegionPortal) UpdateRegion() {
+	if !policy.Authorize(r.Ctx, "region:update") {
+		return
+	}
+	id := r.Ctx.Input.Param(":regionId")
+	ctx := c.GetContext(r.Ctx)
+
+	var region = model.RegionSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(r.Ctx.Request.Body).Decode(&region); err != nil {
+		errMsg := fmt.Sprintf("parse region request body failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := validateRegion(&region, false); err != nil {
+		errMsg := fmt.Sprintf("update region failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if region.Name != "" {
+		regions, err := db.C.ListRegions(ctx)
+		if err != nil {
+			errMsg := fmt.Sprintf("list regions failed: %s", err.Error())
+			r.ErrorHandle(model.ErrorInternalServer, errMsg)
+			return
+		}
+		for _, existing := range regions {
+			if existing.Name == region.Name && existing.Id != id {
+				errMsg := fmt.Sprintf("region name %s is already registered as %s", region.Name, existing.Id)
+				r.ErrorHandle(model.ErrorConflict, errMsg)
+				return
+			}
+		}
+	}
+
+	result, err := db.C.UpdateRegion(ctx, id, &region)
+	if err != nil {
+		errMsg := fmt.Sprintf("update region failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	r.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (r *RegionPortal) DeleteRegion() {
+	if !policy.Authorize(r.Ctx, "region:delete") {
+		return
+	}
+	id := r.Ctx.Input.Param(":regionId")
+	ctx := c.GetContext(r.Ctx)
+
+	region, err := db.C.GetRegion(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("region %s not found: %s", id, err.Error())
+		r.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	if err := db.C.DeleteRegion(ctx, region.Id); err != nil {
+		errMsg := fmt.Sprintf("delete region failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	r.SuccessHandle(StatusOK, nil)
+	return
+}
@@ -0,0 +1,155 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+// //////////////////////////////////////////////////////////////////////////////
+//
+//	Prepare for mock server                               //
+//
+// //////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/:tenantId/regions", &RegionPortal{},
+		"post:CreateRegion;get:ListRegions")
+	beego.Router("/v1beta/:tenantId/regions/:regionId", &RegionPortal{},
+		"get:GetRegion;put:UpdateRegion;delete:DeleteRegion")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                            Tests for region                                //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestCreateRegion(t *testing.T) {
+
+	t.Run("Should return 400 if the url is not absolute", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"name": "eu-west", "url": "soda.eu-west.example.com"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/regions", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 409 if the region name is already registered", func(t *testing.T) {
+		var sampleRegions = []*model.RegionSpec{&SampleRegions[0]}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListRegions", c.NewAdminContext()).Return(sampleRegions, nil)
+		db.C = mockClient
+
+		body := []byte(`{"name": "eu-west", "url": "https://other.example.com:50040"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/regions", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListRegions", c.NewAdminContext()).Return(nil, nil)
+		mockClient.On("CreateRegion", c.NewAdminContext(), &SampleRegions[0]).Return(&SampleRegions[0], nil)
+		db.C = mockClient
+
+		body, _ := json.Marshal(&SampleRegions[0])
+		r, _ := http.NewRequest("POST", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/regions", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.RegionSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleRegions[0])
+	})
+}
+
+func TestListRegions(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		var sampleRegions = []*model.RegionSpec{&SampleRegions[0]}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListRegions", c.NewAdminContext()).Return(sampleRegions, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/regions", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.RegionSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, sampleRegions)
+	})
+}
+
+func TestGetRegion(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetRegion", c.NewAdminContext(), "9a2c5e8a-7a94-11e9-bd36-17d27ce58a31").Return(&SampleRegions[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/regions/9a2c5e8a-7a94-11e9-bd36-17d27ce58a31", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.RegionSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleRegions[0])
+	})
+
+	t.Run("Should return 404 if the region does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetRegion", c.NewAdminContext(), "9a2c5e8a-7a94-11e9-bd36-17d27ce58a31").Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/regions/9a2c5e8a-7a94-11e9-bd36-17d27ce58a31", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+func NewReloadPortal() *ReloadPortal {
+	return &ReloadPortal{}
+}
+
+// ReloadPortal re-reads the server configuration and the policy rules at
+// runtime, so changed settings take effect without restarting osdsapiserver
+// and dropping in-flight operations. The same reload also runs when the
+// process receives a SIGHUP.
+type ReloadPortal struct {
+	BasePortal
+}
+
+// ReloadServerConfig re-reads the configuration file and the policy rules.
+// It is shared between the admin endpoint and the SIGHUP handler.
+func ReloadServerConfig() error {
+	CONF.Reload()
+	if err := policy.Reload(); err != nil {
+		return fmt.Errorf("reload policy rules failed: %s", err.Error())
+	}
+	log.Info("server configuration and policy rules reloaded")
+	return nil
+}
+
+func (p *ReloadPortal) ReloadConfig() {
+	if !policy.Authorize(p.Ctx, "config:reload") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	if !ctx.IsAdmin {
+		errMsg := "only admin users are allowed to reload the configuration"
+		p.ErrorHandle(model.ErrorForbidden, errMsg)
+		return
+	}
+
+	if err := ReloadServerConfig(); err != nil {
+		errMsg := fmt.Sprintf("reload configuration failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	p.SuccessHandle(StatusOK, nil)
+	return
+}
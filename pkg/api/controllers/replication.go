@@ -131,15 +131,7 @@ func (r *ReplicationPortal) ListReplications() {
 		return
 	}
 
-	// Marshal the result.
-	body, err := json.Marshal(r.outputFilter(result, whiteListSimple))
-	if err != nil {
-		errMsg := fmt.Sprintf("marshal replications listed result failed: %s", err.Error())
-		r.ErrorHandle(model.ErrorInternalServer, errMsg)
-		return
-	}
-
-	r.SuccessHandle(StatusOK, body)
+	r.SuccessHandleList(StatusOK, r.outputFilter(result, whiteListSimple))
 	return
 
 }
@@ -164,15 +156,87 @@ func (r *ReplicationPortal) ListReplicationsDetail() {
 		return
 	}
 
-	// Marshal the result.
-	body, err := json.Marshal(result)
+	r.SuccessHandleList(StatusOK, result)
+	return
+}
+
+// ListReplicationsTopology relates every replication to the volume, pool,
+// availability zone and dock on both of its sides in one call, so disaster
+// recovery tooling can render the topology without a lookup per replication.
+func (r *ReplicationPortal) ListReplicationsTopology() {
+	if !policy.Authorize(r.Ctx, "replication:list") {
+		return
+	}
+	ctx := c.GetContext(r.Ctx)
+
+	params, err := r.GetParameters()
 	if err != nil {
-		errMsg := fmt.Sprintf("marshal replications detail listed result failed: %s", err.Error())
+		errMsg := fmt.Sprintf("list replications topology failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	replications, err := db.C.ListReplicationWithFilter(ctx, params)
+	if err != nil {
+		errMsg := fmt.Sprintf("list replications topology failed: %s", err.Error())
 		r.ErrorHandle(model.ErrorInternalServer, errMsg)
 		return
 	}
 
-	r.SuccessHandle(StatusOK, body)
+	// Load the volumes and pools once and resolve the sides from maps,
+	// instead of issuing a pair of lookups per replication.
+	volumes, err := db.C.ListVolumes(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list replications topology failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	pools, err := db.C.ListPools(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list replications topology failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	volumeById := make(map[string]*model.VolumeSpec, len(volumes))
+	for _, volume := range volumes {
+		volumeById[volume.Id] = volume
+	}
+	poolById := make(map[string]*model.StoragePoolSpec, len(pools))
+	for _, pool := range pools {
+		poolById[pool.Id] = pool
+	}
+
+	// A deleted or not yet created volume leaves its side with just the
+	// volume id, the topology of the remaining sides is still rendered.
+	leg := func(volumeId string) *model.ReplicationTopologyLegSpec {
+		leg := &model.ReplicationTopologyLegSpec{VolumeId: volumeId}
+		volume, ok := volumeById[volumeId]
+		if !ok {
+			return leg
+		}
+		leg.VolumeName = volume.Name
+		leg.PoolId = volume.PoolId
+		leg.AvailabilityZone = volume.AvailabilityZone
+		if pool, ok := poolById[volume.PoolId]; ok {
+			leg.PoolName = pool.Name
+			leg.DockId = pool.DockId
+		}
+		return leg
+	}
+
+	topology := make([]*model.ReplicationTopologySpec, 0, len(replications))
+	for _, replication := range replications {
+		topology = append(topology, &model.ReplicationTopologySpec{
+			ReplicationId:     replication.Id,
+			Name:              replication.Name,
+			ReplicationStatus: replication.ReplicationStatus,
+			ReplicationMode:   replication.ReplicationMode,
+			Primary:           leg(replication.PrimaryVolumeId),
+			Secondary:         leg(replication.SecondaryVolumeId),
+		})
+	}
+
+	r.SuccessHandleList(StatusOK, topology)
 	return
 }
 
@@ -216,6 +280,11 @@ func (r *ReplicationPortal) UpdateReplication() {
 		r.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
+	if err := util.ValidateSystemMetadata(mr.Metadata); err != nil {
+		errMsg := fmt.Sprintf("update replication failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
 
 	if mr.ProfileId != "" {
 		if _, err := db.C.GetProfile(c.GetContext(r.Ctx), mr.ProfileId); err != nil {
@@ -255,7 +324,7 @@ func (r *ReplicationPortal) DeleteReplication() {
 	rep, err := db.C.GetReplication(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("get replication failed: %s", err.Error())
-		r.ErrorHandle(model.ErrorNotFound, errMsg)
+		r.DeleteNotFoundHandle(errMsg)
 		return
 	}
 
@@ -460,3 +529,64 @@ func (r *ReplicationPortal) FailoverReplication() {
 
 	return
 }
+
+func (r *ReplicationPortal) GetReplicationStatus() {
+	if !policy.Authorize(r.Ctx, "replication:get_status") {
+		return
+	}
+	ctx := c.GetContext(r.Ctx)
+
+	id := r.Ctx.Input.Param(":replicationId")
+	rep, err := db.C.GetReplication(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("get replication failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// The synchronization status is collected from the replication driver on
+	// every request, so the replication status request is sent to the
+	// controller synchronously and the result is returned in the response.
+	if err := r.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		errMsg := fmt.Sprintf("when connecting controller client: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	opt := &pb.GetReplicationStatusOpts{
+		Id:       rep.Id,
+		Metadata: rep.Metadata,
+		Context:  ctx.ToJson(),
+	}
+	res, err := r.CtrClient.GetReplicationStatus(context.Background(), opt)
+	if err != nil {
+		errMsg := fmt.Sprintf("get replication status failed in controller service: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	if errorMsg := res.GetError(); errorMsg != nil {
+		errMsg := fmt.Sprintf("failed to get replication status in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	var status = model.ReplicationStatusSpec{}
+	if err := json.Unmarshal([]byte(res.GetResult().GetMessage()), &status); err != nil {
+		errMsg := fmt.Sprintf("unmarshal replication status failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	status.ReplicationId = rep.Id
+
+	// Marshal the result.
+	body, err := json.Marshal(&status)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal replication status failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	r.SuccessHandle(StatusOK, body)
+	return
+}
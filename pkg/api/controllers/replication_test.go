@@ -16,6 +16,7 @@ package controllers
 
 import (
 	"bytes"
+	ctx "context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -27,7 +28,9 @@ import (
 	c "github.com/sodafoundation/api/pkg/context"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
+	pb "github.com/sodafoundation/api/pkg/model/proto"
 	. "github.com/sodafoundation/api/testutils/collection"
+	ctrtest "github.com/sodafoundation/api/testutils/controller/testing"
 	dbtest "github.com/sodafoundation/api/testutils/db/testing"
 )
 
@@ -36,8 +39,33 @@ func init() {
 		"post:CreateReplication;get:ListReplications")
 	beego.Router("/v1beta/block/replications/detail", NewReplicationPortal(),
 		"get:ListReplicationsDetail")
+	beego.Router("/v1beta/block/replications/topology", NewReplicationPortal(),
+		"get:ListReplicationsTopology")
 	beego.Router("/v1beta/block/replications/:replicationId", NewReplicationPortal(),
 		"get:GetReplication;put:UpdateReplication;delete:DeleteReplication")
+	beego.Router("/v1beta/block/replications/:replicationId/status", NewFakeReplicationPortal(),
+		"get:GetReplicationStatus")
+}
+
+var fakeReplicationStatus = &model.ReplicationStatusSpec{
+	LastSyncTime:     "2019-03-20T12:49:00.497Z",
+	LagSeconds:       30,
+	TransferredBytes: 1048576,
+}
+
+func NewFakeReplicationPortal() *ReplicationPortal {
+	mockClient := new(ctrtest.Client)
+
+	mockClient.On("Connect", "localhost:50049").Return(nil)
+	mockClient.On("Close").Return(nil)
+	mockClient.On("GetReplicationStatus", ctx.Background(), &pb.GetReplicationStatusOpts{
+		Id:      "c299a978-4f3e-11e8-8a5c-977218a83359",
+		Context: c.NewAdminContext().ToJson(),
+	}).Return(pb.GenericResponseResult(fakeReplicationStatus), nil)
+
+	return &ReplicationPortal{
+		CtrClient: mockClient,
+	}
 }
 
 func TestListReplicationsDetail(t *testing.T) {
@@ -122,6 +150,67 @@ func TestListReplications(t *testing.T) {
 	})
 }
 
+func TestListReplicationsTopology(t *testing.T) {
+
+	t.Run("Should return 200 and resolve both sides of every replication", func(t *testing.T) {
+		var sampleReplications = []*model.ReplicationSpec{&SampleReplications[0]}
+		mockClient := new(dbtest.Client)
+		m := map[string][]string{}
+		mockClient.On("ListReplicationWithFilter", c.NewAdminContext(), m).Return(sampleReplications, nil)
+		mockClient.On("ListVolumes", c.NewAdminContext()).Return([]*model.VolumeSpec{&SampleVolumes[0]}, nil)
+		mockClient.On("ListPools", c.NewAdminContext()).Return([]*model.StoragePoolSpec{&SamplePools[0]}, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/block/replications/topology", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.ReplicationTopologySpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		expected := []*model.ReplicationTopologySpec{
+			{
+				ReplicationId: SampleReplications[0].Id,
+				Name:          SampleReplications[0].Name,
+				Primary: &model.ReplicationTopologyLegSpec{
+					VolumeId:         SampleVolumes[0].Id,
+					VolumeName:       SampleVolumes[0].Name,
+					PoolId:           SamplePools[0].Id,
+					PoolName:         SamplePools[0].Name,
+					DockId:           SamplePools[0].DockId,
+					AvailabilityZone: SampleVolumes[0].AvailabilityZone,
+				},
+				Secondary: &model.ReplicationTopologyLegSpec{
+					VolumeId:         SampleVolumes[0].Id,
+					VolumeName:       SampleVolumes[0].Name,
+					PoolId:           SamplePools[0].Id,
+					PoolName:         SamplePools[0].Name,
+					DockId:           SamplePools[0].DockId,
+					AvailabilityZone: SampleVolumes[0].AvailabilityZone,
+				},
+			},
+		}
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, expected)
+	})
+
+	t.Run("Should return 500 if listing the replications fails", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		m := map[string][]string{}
+		mockClient.On("ListReplicationWithFilter", c.NewAdminContext(), m).Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/block/replications/topology", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 500)
+	})
+}
+
 func TestGetReplication(t *testing.T) {
 	var expectedJson = []byte(`{
 			"id": "c299a978-4f3e-11e8-8a5c-977218a83359",
@@ -225,3 +314,39 @@ func TestUpdateReplication(t *testing.T) {
 		assertTestResult(t, w.Code, 500)
 	})
 }
+
+func TestGetReplicationStatus(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetReplication", c.NewAdminContext(), "c299a978-4f3e-11e8-8a5c-977218a83359").Return(&SampleReplications[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/block/replications/c299a978-4f3e-11e8-8a5c-977218a83359/status", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.ReplicationStatusSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		var expected = *fakeReplicationStatus
+		expected.ReplicationId = "c299a978-4f3e-11e8-8a5c-977218a83359"
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &expected)
+	})
+
+	t.Run("Should return 404 if the replication does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetReplication", c.NewAdminContext(), "c299a978-4f3e-11e8-8a5c-977218a83359").Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/block/replications/c299a978-4f3e-11e8-8a5c-977218a83359/status", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
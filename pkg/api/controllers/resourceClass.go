@@ -0,0 +1,218 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewResourceClassPortal() *ResourceClassPortal {
+	return &ResourceClassPortal{}
+}
+
+type ResourceClassPortal struct {
+	BasePortal
+}
+
+// attachedElsewhere returns the id of another class one of the tenants is
+// already attached to, or an empty string. A tenant attached to two classes
+// would make the enforcement result depend on evaluation order.
+func attachedElsewhere(classes []*model.ResourceClassSpec, class *model.ResourceClassSpec) (string, string) {
+	for _, existing := range classes {
+		if existing.Id == class.Id {
+			continue
+		}
+		for _, tenant := range class.Tenants {
+			for _, attached := range existing.Tenants {
+				if attached == tenant {
+					return tenant, existing.Id
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+func (r *ResourceClassPortal) CreateResourceClass() {
+	if !policy.Authorize(r.Ctx, "resource_class:create") {
+		return
+	}
+	ctx := c.GetContext(r.Ctx)
+
+	var class = model.ResourceClassSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(r.Ctx.Request.Body).Decode(&class); err != nil {
+		errMsg := fmt.Sprintf("parse resource class request body failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := class.Validate(); err != nil {
+		errMsg := fmt.Sprintf("create resource class failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	classes, err := db.C.ListResourceClasses(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list resource classes failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	if tenant, classId := attachedElsewhere(classes, &class); tenant != "" {
+		errMsg := fmt.Sprintf("tenant %s is already attached to resource class %s", tenant, classId)
+		r.ErrorHandle(model.ErrorConflict, errMsg)
+		return
+	}
+
+	result, err := db.C.CreateResourceClass(ctx, &class)
+	if err != nil {
+		errMsg := fmt.Sprintf("create resource class failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	r.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (r *ResourceClassPortal) ListResourceClasses() {
+	if !policy.Authorize(r.Ctx, "resource_class:list") {
+		return
+	}
+	ctx := c.GetContext(r.Ctx)
+
+	result, err := db.C.ListResourceClasses(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list resource classes failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	r.SuccessHandleList(StatusOK, result)
+	return
+}
+
+func (r *ResourceClassPortal) GetResourceClass() {
+	if !policy.Authorize(r.Ctx, "resource_class:get") {
+		return
+	}
+	id := r.Ctx.Input.Param(":classId")
+	ctx := c.GetContext(r.Ctx)
+
+	result, err := db.C.GetResourceClass(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("resource class %s not found: %s", id, err.Error())
+		r.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	r.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (r *ResourceClassPortal) UpdateResourceClass() {
+	if !policy.Authorize(r.Ctx, "resource_class:update") {
+		return
+	}
+	id := r.Ctx.Input.Param(":classId")
+	ctx := c.GetContext(r.Ctx)
+
+	var class = model.ResourceClassSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(r.Ctx.Request.Body).Decode(&class); err != nil {
+		errMsg := fmt.Sprintf("parse resource class request body failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	// The name stays as it is when the update does not carry one.
+	if class.Name != "" || class.NamingPattern != "" || class.MinSize != 0 || class.MaxSize != 0 {
+		checked := class
+		if checked.Name == "" {
+			checked.Name = "unchanged"
+		}
+		if err := checked.Validate(); err != nil {
+			errMsg := fmt.Sprintf("update resource class failed: %s", err.Error())
+			r.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+	if class.Tenants != nil {
+		classes, err := db.C.ListResourceClasses(ctx)
+		if err != nil {
+			errMsg := fmt.Sprintf("list resource classes failed: %s", err.Error())
+			r.ErrorHandle(model.ErrorInternalServer, errMsg)
+			return
+		}
+		class.Id = id
+		if tenant, classId := attachedElsewhere(classes, &class); tenant != "" {
+			errMsg := fmt.Sprintf("tenant %s is already attached to resource class %s", tenant, classId)
+			r.ErrorHandle(model.ErrorConflict, errMsg)
+			return
+		}
+	}
+
+	result, err := db.C.UpdateResourceClass(ctx, id, &class)
+	if err != nil {
+		errMsg := fmt.Sprintf("update resource class failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	r.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (r *ResourceClassPortal) DeleteResourceClass() {
+	if !policy.Authorize(r.Ctx, "resource_class:delete") {
+		return
+	}
+	id := r.Ctx.Input.Param(":classId")
+	ctx := c.GetContext(r.Ctx)
+
+	class, err := db.C.GetResourceClass(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("resource class %s not found: %s", id, err.Error())
+		r.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	if err := db.C.DeleteResourceClass(ctx, class.Id); err != nil {
+		errMsg := fmt.Sprintf("delete resource class failed: %s", err.Error())
+		r.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	r.SuccessHandle(StatusOK, nil)
+	return
+}
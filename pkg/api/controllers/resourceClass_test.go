@@ -0,0 +1,134 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+// //////////////////////////////////////////////////////////////////////////////
+//
+//	Prepare for mock server                               //
+//
+// //////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/:tenantId/resourceClasses", &ResourceClassPortal{},
+		"post:CreateResourceClass;get:ListResourceClasses")
+	beego.Router("/v1beta/:tenantId/resourceClasses/:classId", &ResourceClassPortal{},
+		"get:GetResourceClass;put:UpdateResourceClass;delete:DeleteResourceClass")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                         Tests for resource class                           //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestCreateResourceClass(t *testing.T) {
+
+	t.Run("Should return 400 if the naming pattern does not compile", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		body := []byte(`{"name": "gold", "namingPattern": "["}`)
+		r, _ := http.NewRequest("POST", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/resourceClasses", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 409 if a tenant is attached to another class", func(t *testing.T) {
+		var sampleClasses = []*model.ResourceClassSpec{&SampleResourceClasses[0]}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListResourceClasses", c.NewAdminContext()).Return(sampleClasses, nil)
+		db.C = mockClient
+
+		body := []byte(`{"name": "silver", "tenants": ["x7a91c0a2ab41b9cf76f71a91b"]}`)
+		r, _ := http.NewRequest("POST", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/resourceClasses", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListResourceClasses", c.NewAdminContext()).Return(nil, nil)
+		mockClient.On("CreateResourceClass", c.NewAdminContext(), &SampleResourceClasses[0]).Return(&SampleResourceClasses[0], nil)
+		db.C = mockClient
+
+		body, _ := json.Marshal(&SampleResourceClasses[0])
+		r, _ := http.NewRequest("POST", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/resourceClasses", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.ResourceClassSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleResourceClasses[0])
+	})
+}
+
+func TestGetResourceClass(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetResourceClass", c.NewAdminContext(), "3b8b1f56-6f72-11e9-9c6a-3b0ac2523f45").Return(&SampleResourceClasses[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/resourceClasses/3b8b1f56-6f72-11e9-9c6a-3b0ac2523f45", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.ResourceClassSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &SampleResourceClasses[0])
+	})
+
+	t.Run("Should return 404 if the resource class does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetResourceClass", c.NewAdminContext(), "3b8b1f56-6f72-11e9-9c6a-3b0ac2523f45").Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/resourceClasses/3b8b1f56-6f72-11e9-9c6a-3b0ac2523f45", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
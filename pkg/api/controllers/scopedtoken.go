@@ -0,0 +1,182 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+func NewScopedTokenPortal() *ScopedTokenPortal {
+	return &ScopedTokenPortal{}
+}
+
+type ScopedTokenPortal struct {
+	BasePortal
+}
+
+// CreateScopedToken mints a short lived token restricted to the requested
+// actions and, optionally, to specific resources. The token secret is
+// returned exactly once and can not be recovered later.
+func (p *ScopedTokenPortal) CreateScopedToken() {
+	if !policy.Authorize(p.Ctx, "scopedtoken:create") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	var token = model.ScopedTokenSpec{
+		BaseModel: &model.BaseModel{},
+	}
+
+	// Unmarshal the request body
+	if err := json.NewDecoder(p.Ctx.Request.Body).Decode(&token); err != nil {
+		errMsg := fmt.Sprintf("parse scoped token request body failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if len(token.Scope) == 0 {
+		errMsg := "scoped token scope can not be empty"
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	for _, entry := range token.Scope {
+		if !strings.Contains(entry, ":") {
+			errMsg := fmt.Sprintf("invalid scope entry %s, expected the resource:action format", entry)
+			p.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
+	// Scoped tokens are short lived by construction, one without an expiry
+	// would be a full credential in disguise.
+	if token.ExpiresAt == "" {
+		errMsg := "scoped token expiresAt can not be empty"
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	expiresAt, err := utils.ParseTime(token.ExpiresAt)
+	if err != nil {
+		errMsg := fmt.Sprintf("create scoped token failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if !expiresAt.After(time.Now()) {
+		errMsg := fmt.Sprintf("scoped token expiry %s is in the past", token.ExpiresAt)
+		p.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	secret, err := generateApiKeySecret()
+	if err != nil {
+		errMsg := fmt.Sprintf("create scoped token failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	token.UserId = ctx.UserId
+	token.HashedSecret = model.HashApiKeySecret(secret)
+
+	result, err := db.C.CreateScopedToken(ctx, &token)
+	if err != nil {
+		errMsg := fmt.Sprintf("create scoped token failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// The secret is only returned here, it can not be recovered later.
+	result.Secret = secret
+	result.HashedSecret = ""
+	body, err := json.Marshal(result)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal scoped token created result failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	p.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+func (p *ScopedTokenPortal) ListScopedTokens() {
+	if !policy.Authorize(p.Ctx, "scopedtoken:list") {
+		return
+	}
+	result, err := db.C.ListScopedTokens(c.GetContext(p.Ctx))
+	if err != nil {
+		errMsg := fmt.Sprintf("list scoped tokens failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	for _, token := range result {
+		token.HashedSecret = ""
+	}
+	p.SuccessHandleList(StatusOK, result)
+
+	return
+}
+
+func (p *ScopedTokenPortal) GetScopedToken() {
+	if !policy.Authorize(p.Ctx, "scopedtoken:get") {
+		return
+	}
+	id := p.Ctx.Input.Param(":tokenId")
+	result, err := db.C.GetScopedToken(c.GetContext(p.Ctx), id)
+	if err != nil {
+		errMsg := fmt.Sprintf("scoped token %s not found: %s", id, err.Error())
+		p.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	result.HashedSecret = ""
+	body, err := json.Marshal(result)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal scoped token showed result failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	p.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+// DeleteScopedToken revokes a scoped token before its expiry. The token
+// stops working immediately.
+func (p *ScopedTokenPortal) DeleteScopedToken() {
+	if !policy.Authorize(p.Ctx, "scopedtoken:revoke") {
+		return
+	}
+	ctx := c.GetContext(p.Ctx)
+	id := p.Ctx.Input.Param(":tokenId")
+	if _, err := db.C.GetScopedToken(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("scoped token %s not found: %s", id, err.Error())
+		p.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	if err := db.C.DeleteScopedToken(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("delete scoped token failed: %s", err.Error())
+		p.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	p.SuccessHandle(StatusOK, nil)
+
+	return
+}
@@ -0,0 +1,125 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func init() {
+	beego.Router("/v1beta/scopedTokens", NewScopedTokenPortal(), "post:CreateScopedToken;get:ListScopedTokens")
+	beego.Router("/v1beta/scopedTokens/:tokenId", NewScopedTokenPortal(), "get:GetScopedToken;delete:DeleteScopedToken")
+}
+
+func TestCreateScopedToken(t *testing.T) {
+
+	t.Run("Should return 400 if the scope is empty", func(t *testing.T) {
+		var jsonStr = []byte(`{"name": "nightly-backup", "expiresAt": "2119-11-11T11:01:33"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/scopedTokens", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 400 if the expiry is in the past", func(t *testing.T) {
+		var jsonStr = []byte(`{"scope": ["fileshare_snapshot:create"], "expiresAt": "2019-11-11T11:01:33"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/scopedTokens", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestListScopedTokens(t *testing.T) {
+
+	t.Run("Should return 200 and no hashed secret if everything works well", func(t *testing.T) {
+		fakeToken := SampleScopedTokens[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListScopedTokens", c.NewAdminContext()).Return(
+			[]*model.ScopedTokenSpec{&fakeToken}, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/scopedTokens", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []model.ScopedTokenSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, len(output), 1)
+		assertTestResult(t, output[0].Name, SampleScopedTokens[0].Name)
+		assertTestResult(t, output[0].HashedSecret, "")
+	})
+}
+
+func TestGetScopedToken(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		fakeToken := SampleScopedTokens[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetScopedToken", c.NewAdminContext(), fakeToken.Id).Return(&fakeToken, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/scopedTokens/"+fakeToken.Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.ScopedTokenSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Name, SampleScopedTokens[0].Name)
+		assertTestResult(t, output.HashedSecret, "")
+	})
+
+	t.Run("Should return 404 if get scoped token with bad request", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetScopedToken", c.NewAdminContext(), SampleScopedTokens[0].Id).Return(
+			nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/scopedTokens/"+SampleScopedTokens[0].Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
@@ -0,0 +1,236 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewShareNetworkPortal() *ShareNetworkPortal {
+	return &ShareNetworkPortal{}
+}
+
+// ShareNetworkPortal manages the export networks of a multi-network NAS
+// deployment. Admins register the subnets and VLANs here; share creation
+// picks one of them, see ResolveShareNetwork.
+type ShareNetworkPortal struct {
+	BasePortal
+}
+
+// validateShareNetwork checks the fields shared by create and update. The
+// cidr is only required on create, an update may leave it empty.
+func validateShareNetwork(network *model.ShareNetworkSpec, cidrRequired bool) error {
+	if network.Cidr == "" {
+		if cidrRequired {
+			return fmt.Errorf("cidr must be specified")
+		}
+	} else if _, _, err := net.ParseCIDR(network.Cidr); err != nil {
+		return fmt.Errorf("invalid cidr %s: %v", network.Cidr, err)
+	}
+	if network.Vlan < 0 || network.Vlan > 4094 {
+		return fmt.Errorf("invalid vlan id %d, must be between 0 and 4094", network.Vlan)
+	}
+	return nil
+}
+
+func (s *ShareNetworkPortal) CreateShareNetwork() {
+	if !policy.Authorize(s.Ctx, "share_network:create") {
+		return
+	}
+	ctx := c.GetContext(s.Ctx)
+
+	var network = model.ShareNetworkSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(s.Ctx.Request.Body).Decode(&network); err != nil {
+		errMsg := fmt.Sprintf("parse share network request body failed: %s", err.Error())
+		s.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if network.Name == "" {
+		errMsg := "create share network failed: name must be specified"
+		s.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := validateShareNetwork(&network, true); err != nil {
+		errMsg := fmt.Sprintf("create share network failed: %s", err.Error())
+		s.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	// At most one network carries the default mark, otherwise the fallback
+	// of share creation would depend on list order.
+	if network.Default {
+		networks, err := db.C.ListShareNetworks(ctx)
+		if err != nil {
+			errMsg := fmt.Sprintf("list share networks failed: %s", err.Error())
+			s.ErrorHandle(model.ErrorInternalServer, errMsg)
+			return
+		}
+		for _, existing := range networks {
+			if existing.Default {
+				errMsg := fmt.Sprintf("share network %s is already the default", existing.Id)
+				s.ErrorHandle(model.ErrorConflict, errMsg)
+				return
+			}
+		}
+	}
+
+	result, err := db.C.CreateShareNetwork(ctx, &network)
+	if err != nil {
+		errMsg := fmt.Sprintf("create share network failed: %s", err.Error())
+		s.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	s.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (s *ShareNetworkPortal) ListShareNetworks() {
+	if !policy.Authorize(s.Ctx, "share_network:list") {
+		return
+	}
+	ctx := c.GetContext(s.Ctx)
+
+	result, err := db.C.ListShareNetworks(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list share networks failed: %s", err.Error())
+		s.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	s.SuccessHandleList(StatusOK, result)
+	return
+}
+
+func (s *ShareNetworkPortal) GetShareNetwork() {
+	if !policy.Authorize(s.Ctx, "share_network:get") {
+		return
+	}
+	id := s.Ctx.Input.Param(":networkId")
+	ctx := c.GetContext(s.Ctx)
+
+	result, err := db.C.GetShareNetwork(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("share network %s not found: %s", id, err.Error())
+		s.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	s.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (s *ShareNetworkPortal) UpdateShareNetwork() {
+	if !policy.Authorize(s.Ctx, "share_network:update") {
+		return
+	}
+	id := s.Ctx.Input.Param(":networkId")
+	ctx := c.GetContext(s.Ctx)
+
+	var network = model.ShareNetworkSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(s.Ctx.Request.Body).Decode(&network); err != nil {
+		errMsg := fmt.Sprintf("parse share network request body failed: %s", err.Error())
+		s.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := validateShareNetwork(&network, false); err != nil {
+		errMsg := fmt.Sprintf("update share network failed: %s", err.Error())
+		s.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if network.Default {
+		networks, err := db.C.ListShareNetworks(ctx)
+		if err != nil {
+			errMsg := fmt.Sprintf("list share networks failed: %s", err.Error())
+			s.ErrorHandle(model.ErrorInternalServer, errMsg)
+			return
+		}
+		for _, existing := range networks {
+			if existing.Default && existing.Id != id {
+				errMsg := fmt.Sprintf("share network %s is already the default", existing.Id)
+				s.ErrorHandle(model.ErrorConflict, errMsg)
+				return
+			}
+		}
+	}
+
+	result, err := db.C.UpdateShareNetwork(ctx, id, &network)
+	if err != nil {
+		errMsg := fmt.Sprintf("update share network failed: %s", err.Error())
+		s.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	s.SuccessHandle(StatusOK, body)
+	return
+}
+
+func (s *ShareNetworkPortal) DeleteShareNetwork() {
+	if !policy.Authorize(s.Ctx, "share_network:delete") {
+		return
+	}
+	id := s.Ctx.Input.Param(":networkId")
+	ctx := c.GetContext(s.Ctx)
+
+	if _, err := db.C.GetShareNetwork(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("share network %s not found: %s", id, err.Error())
+		s.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+	// A network that fileshares still reference must not disappear under
+	// them.
+	shares, err := db.C.ListFileShares(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list fileshares failed: %s", err.Error())
+		s.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	for _, share := range shares {
+		if share.ShareNetworkId == id {
+			errMsg := fmt.Sprintf("share network %s is still used by fileshare %s", id, share.Id)
+			s.ErrorHandle(model.ErrorConflict, errMsg)
+			return
+		}
+	}
+
+	if err := db.C.DeleteShareNetwork(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("delete share network failed: %s", err.Error())
+		s.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	s.SuccessHandle(StatusOK, nil)
+	return
+}
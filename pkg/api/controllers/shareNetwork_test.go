@@ -0,0 +1,181 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+// //////////////////////////////////////////////////////////////////////////////
+//
+//	Prepare for mock server                               //
+//
+// //////////////////////////////////////////////////////////////////////////////
+func init() {
+	var shareNetworkPortal ShareNetworkPortal
+	beego.Router("/v1beta/file/shareNetworks", &shareNetworkPortal,
+		"post:CreateShareNetwork;get:ListShareNetworks")
+	beego.Router("/v1beta/file/shareNetworks/:networkId", &shareNetworkPortal,
+		"get:GetShareNetwork;put:UpdateShareNetwork;delete:DeleteShareNetwork")
+}
+
+var fakeShareNetwork = &model.ShareNetworkSpec{
+	BaseModel: &model.BaseModel{
+		Id:        "8f5f9c52-5e73-4cf5-a1d5-1e1086b20acd",
+		CreatedAt: "2019-03-20T12:49:00.497Z",
+	},
+	Name:        "prod-nas",
+	Description: "production NAS network",
+	Cidr:        "192.168.100.0/24",
+	Vlan:        120,
+	Default:     true,
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//                         Tests for share network                            //
+////////////////////////////////////////////////////////////////////////////////
+
+func TestCreateShareNetwork(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		network := model.ShareNetworkSpec{
+			BaseModel: &model.BaseModel{},
+			Name:      "prod-nas",
+			Cidr:      "192.168.100.0/24",
+			Vlan:      120,
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("CreateShareNetwork", c.NewAdminContext(), &network).
+			Return(fakeShareNetwork, nil)
+		db.C = mockClient
+
+		body, _ := json.Marshal(network)
+		r, _ := http.NewRequest("POST", "/v1beta/file/shareNetworks", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output model.ShareNetworkSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, fakeShareNetwork)
+	})
+
+	t.Run("Should return 400 if the cidr is invalid", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		body := []byte(`{"name": "prod-nas", "cidr": "not-a-cidr"}`)
+		r, _ := http.NewRequest("POST", "/v1beta/file/shareNetworks", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+
+	t.Run("Should return 409 if another network is already the default", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListShareNetworks", c.NewAdminContext()).
+			Return([]*model.ShareNetworkSpec{fakeShareNetwork}, nil)
+		db.C = mockClient
+
+		body := []byte(`{"name": "dev-nas", "cidr": "192.168.101.0/24", "default": true}`)
+		r, _ := http.NewRequest("POST", "/v1beta/file/shareNetworks", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+}
+
+func TestListShareNetworks(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListShareNetworks", c.NewAdminContext()).
+			Return([]*model.ShareNetworkSpec{fakeShareNetwork}, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/file/shareNetworks", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output []*model.ShareNetworkSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, []*model.ShareNetworkSpec{fakeShareNetwork})
+	})
+}
+
+func TestDeleteShareNetwork(t *testing.T) {
+
+	t.Run("Should return 409 if a fileshare still uses the network", func(t *testing.T) {
+		share := SampleFileShares[0]
+		share.ShareNetworkId = fakeShareNetwork.Id
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetShareNetwork", c.NewAdminContext(), fakeShareNetwork.Id).
+			Return(fakeShareNetwork, nil)
+		mockClient.On("ListFileShares", c.NewAdminContext()).
+			Return([]*model.FileShareSpec{&share}, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE", "/v1beta/file/shareNetworks/"+fakeShareNetwork.Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+
+	t.Run("Should return 200 if nothing references the network", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetShareNetwork", c.NewAdminContext(), fakeShareNetwork.Id).
+			Return(fakeShareNetwork, nil)
+		mockClient.On("ListFileShares", c.NewAdminContext()).
+			Return(nil, nil)
+		mockClient.On("DeleteShareNetwork", c.NewAdminContext(), fakeShareNetwork.Id).
+			Return(nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("DELETE", "/v1beta/file/shareNetworks/"+fakeShareNetwork.Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 200)
+	})
+}
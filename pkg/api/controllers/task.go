@@ -0,0 +1,94 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements a entry into the OpenSDS service.
+
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sodafoundation/api/pkg/api/policy"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func NewTaskPortal() *TaskPortal {
+	return &TaskPortal{}
+}
+
+type TaskPortal struct {
+	BasePortal
+}
+
+func (t *TaskPortal) ListTasks() {
+	if !policy.Authorize(t.Ctx, "task:list") {
+		return
+	}
+	ctx := c.GetContext(t.Ctx)
+
+	tasks, err := db.C.ListTasks(ctx)
+	if err != nil {
+		errMsg := fmt.Sprintf("list tasks failed: %s", err.Error())
+		t.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Narrow the listing to one resource, operation or status when the
+	// matching query parameter is given.
+	var result = []*model.TaskSpec{}
+	for _, task := range tasks {
+		if v := t.Ctx.Input.Query("resourceType"); v != "" && task.ResourceType != v {
+			continue
+		}
+		if v := t.Ctx.Input.Query("resourceId"); v != "" && task.ResourceId != v {
+			continue
+		}
+		if v := t.Ctx.Input.Query("operation"); v != "" && task.Operation != v {
+			continue
+		}
+		if v := t.Ctx.Input.Query("status"); v != "" && task.Status != v {
+			continue
+		}
+		result = append(result, task)
+	}
+
+	t.SuccessHandleList(StatusOK, result)
+	return
+}
+
+func (t *TaskPortal) GetTask() {
+	if !policy.Authorize(t.Ctx, "task:get") {
+		return
+	}
+	id := t.Ctx.Input.Param(":taskId")
+	ctx := c.GetContext(t.Ctx)
+
+	result, err := db.C.GetTask(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("task %s not found: %s", id, err.Error())
+		t.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	t.SuccessHandle(StatusOK, body)
+	return
+}
@@ -0,0 +1,159 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+// //////////////////////////////////////////////////////////////////////////////
+//
+//	Prepare for mock server                               //
+//
+// //////////////////////////////////////////////////////////////////////////////
+func init() {
+	beego.Router("/v1beta/:tenantId/tasks", &TaskPortal{},
+		"get:ListTasks")
+	beego.Router("/v1beta/:tenantId/tasks/:taskId", &TaskPortal{},
+		"get:GetTask")
+}
+
+var sampleTasks = []*model.TaskSpec{
+	{
+		BaseModel:    &model.BaseModel{Id: "5b2fb6cf-e553-4961-a9a1-0f35f65261bc"},
+		TenantId:     "e93b4c0934da416eb9c8d120c5d04d96",
+		ResourceType: "volume",
+		ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		Operation:    "createVolume",
+		Status:       model.TaskSucceeded,
+		Transitions: []*model.TaskTransitionSpec{
+			{Status: model.TaskRunning, Timestamp: "2019-03-20T12:49:00.497Z"},
+			{Status: model.TaskSucceeded, Timestamp: "2019-03-20T12:49:02.114Z"},
+		},
+	},
+	{
+		BaseModel:    &model.BaseModel{Id: "8a2cf3a3-1664-4e3c-8c12-eebca55d6a0f"},
+		TenantId:     "e93b4c0934da416eb9c8d120c5d04d96",
+		ResourceType: "fileshare",
+		ResourceId:   "d2975ebe-d82c-430f-b28e-f373746a71ca",
+		Operation:    "deleteFileShare",
+		Status:       model.TaskFailed,
+		ErrorMessage: "delete fileshare failed in controller service",
+		Transitions: []*model.TaskTransitionSpec{
+			{Status: model.TaskRunning, Timestamp: "2019-03-20T12:50:00.497Z"},
+			{Status: model.TaskFailed, Timestamp: "2019-03-20T12:50:01.031Z",
+				Message: "delete fileshare failed in controller service"},
+		},
+	},
+}
+
+func TestListTasks(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListTasks", c.NewAdminContext()).Return(sampleTasks, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/tasks", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.TaskSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, sampleTasks)
+	})
+
+	t.Run("Should narrow the listing with the query parameters", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListTasks", c.NewAdminContext()).Return(sampleTasks, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET",
+			"/v1beta/e93b4c0934da416eb9c8d120c5d04d96/tasks?resourceType=fileshare&status=failed", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output []*model.TaskSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, sampleTasks[1:])
+	})
+
+	t.Run("Should return 500 if listing the tasks fails", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListTasks", c.NewAdminContext()).Return(nil, errors.New("db error"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/tasks", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 500)
+	})
+}
+
+func TestGetTask(t *testing.T) {
+
+	t.Run("Should return 200 if everything works well", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetTask", c.NewAdminContext(), sampleTasks[1].Id).Return(sampleTasks[1], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET",
+			"/v1beta/e93b4c0934da416eb9c8d120c5d04d96/tasks/"+sampleTasks[1].Id, nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.TaskSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, sampleTasks[1])
+	})
+
+	t.Run("Should return 404 if the task does not exist", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetTask", c.NewAdminContext(), "unknown").Return(
+			nil, errors.New("specified task(unknown) can't find"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/e93b4c0934da416eb9c8d120c5d04d96/tasks/unknown", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 404)
+	})
+}
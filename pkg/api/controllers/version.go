@@ -43,14 +43,7 @@ type VersionPortal struct {
 
 // ListVersions
 func (v *VersionPortal) ListVersions() {
-	body, err := json.Marshal(KnownVersions)
-	if err != nil {
-		errMsg := fmt.Sprintf("marshal versions failed: %s", err.Error())
-		v.ErrorHandle(model.ErrorInternalServer, errMsg)
-		return
-	}
-
-	v.SuccessHandle(StatusOK, body)
+	v.SuccessHandleList(StatusOK, KnownVersions)
 	return
 }
 
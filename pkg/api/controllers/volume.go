@@ -32,7 +32,9 @@ import (
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
 	pb "github.com/sodafoundation/api/pkg/model/proto"
+	"github.com/sodafoundation/api/pkg/utils"
 	. "github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/constants"
 )
 
 func NewVolumePortal() *VolumePortal {
@@ -85,6 +87,46 @@ func (v *VolumePortal) CreateVolume() {
 		v.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
+	if !ctx.IsAdmin && !prf.VisibleTo(ctx.TenantId) {
+		errMsg := fmt.Sprintf("profile %s is not available to tenant %s", prf.Id, ctx.TenantId)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if prf.StorageType != constants.Block {
+		errMsg := fmt.Sprintf("storageType should be only block. Currently it is: %s", prf.StorageType)
+		log.Error(errMsg)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if err := util.ValidateBackendMetadata(volume.Metadata); err != nil {
+		errMsg := fmt.Sprintf("invalid volume metadata: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	volume.Metadata = util.ApplyDefaultTags(ctx, volume.Metadata)
+
+	// A dry run: report how the placement of this volume would be decided
+	// instead of creating it.
+	if v.Ctx.Input.Query("explain") == "true" {
+		if volume.Size <= 0 {
+			errMsg := fmt.Sprintf("invalid volume size: %d", volume.Size)
+			v.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+		if volume.AvailabilityZone == "" {
+			volume.AvailabilityZone = "default"
+		}
+		explain, err := util.ExplainPlacement(ctx, "volume", prf, volume.AvailabilityZone, volume.Size)
+		if err != nil {
+			errMsg := fmt.Sprintf("explain volume placement failed: %s", err.Error())
+			v.ErrorHandle(model.ErrorInternalServer, errMsg)
+			return
+		}
+		body, _ := json.Marshal(explain)
+		v.SuccessHandle(StatusOK, body)
+		return
+	}
 
 	// NOTE:It will create a volume entry into the database and initialize its status
 	// as "creating". It will not wait for the real volume creation to complete
@@ -98,43 +140,66 @@ func (v *VolumePortal) CreateVolume() {
 
 	log.V(8).Infof("create volume DB entry success %+v", result)
 
-	// Marshal the result.
-	body, _ := json.Marshal(result)
-	v.SuccessHandle(StatusAccepted, body)
-
-	// NOTE:The real volume creation process.
-	// Volume creation request is sent to the Dock. Dock will update volume status to "available"
-	// after volume creation is completed.
-	if err := v.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
-		log.Error("when connecting controller client:", err)
-		return
-	}
-
 	opt := &pb.CreateVolumeOpts{
 		Id:               result.Id,
 		Name:             result.Name,
 		Description:      result.Description,
 		Size:             result.Size,
 		AvailabilityZone: result.AvailabilityZone,
-		// TODO: ProfileId will be removed later.
-		ProfileId:         result.ProfileId,
-		Profile:           prf.ToJson(),
-		PoolId:            result.PoolId,
-		SnapshotId:        result.SnapshotId,
-		Metadata:          result.Metadata,
+		ProfileId:        result.ProfileId,
+		Profile:          prf.ToJson(),
+		PoolId:           result.PoolId,
+		SnapshotId:       result.SnapshotId,
+		// Only the backend.* metadata entries travel to the driver, the
+		// remaining metadata stays api-side.
+		Metadata:          util.BackendMetadata(result.Metadata),
 		SnapshotFromCloud: result.SnapshotFromCloud,
 		Context:           ctx.ToJson(),
 	}
-	response, err := v.CtrClient.CreateVolume(context.Background(), opt)
+	if err := client.CheckOptsSize(opt); err != nil {
+		if err := db.C.DeleteVolume(ctx, result.Id); err != nil {
+			log.Error("delete volume in db failed:", err)
+		}
+		errMsg := fmt.Sprintf("create volume failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorEntityTooLarge, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	v.SuccessHandle(StatusAccepted, body)
+
+	// NOTE:The real volume creation process.
+	// Volume creation request is sent to the Dock. Dock will update volume status to "available"
+	// after volume creation is completed. A dispatch that fails transiently
+	// is retried per the configured policy before the volume is marked
+	// error.
+	task := util.StartTask(ctx, "volume", result.Id, "createVolume")
+	var response *pb.GenericResponse
+	attempts, err := util.DispatchWithRetry("volume:create", func() error {
+		if err := v.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+			return err
+		}
+		var callErr error
+		response, callErr = v.CtrClient.CreateVolume(context.Background(), opt)
+		return callErr
+	})
 	if err != nil {
-		log.Error("create volume failed in controller service:", err)
+		reason := fmt.Sprintf("create volume failed in controller service: %v", err)
+		log.Error(reason)
+		if err := db.UpdateVolumeDispatchFault(ctx, db.C, result.Id, model.VolumeError, reason, attempts); err != nil {
+			log.Error("when update volume status in db:", err)
+		}
+		util.CloseTask(ctx, task, err)
 		return
 	}
 	if errorMsg := response.GetError(); errorMsg != nil {
 		log.Errorf("failed to create volume in controller, code: %v, message: %v",
 			errorMsg.GetCode(), errorMsg.GetDescription())
+		util.CloseTask(ctx, task, fmt.Errorf(errorMsg.GetDescription()))
 		return
 	}
+	util.CloseTask(ctx, task, nil)
 
 	return
 }
@@ -150,6 +215,19 @@ func (v *VolumePortal) ListVolumes() {
 		v.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
+	if v.listTombstones(m, model.TombstoneResourceVolume) {
+		return
+	}
+
+	createdFilter, err := util.ParseCreatedAtFilter(m)
+	if err != nil {
+		errMsg := fmt.Sprintf("list volumes failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// a member without the tenant admin role only sees their own volumes
+	util.ScopeListToCreator(c.GetContext(v.Ctx), m)
 
 	result, err := db.C.ListVolumesWithFilter(c.GetContext(v.Ctx), m)
 	if err != nil {
@@ -157,10 +235,17 @@ func (v *VolumePortal) ListVolumes() {
 		v.ErrorHandle(model.ErrorInternalServer, errMsg)
 		return
 	}
+	if createdFilter != nil {
+		var matched = []*model.VolumeSpec{}
+		for _, volume := range result {
+			if createdFilter.Match(volume.CreatedAt) {
+				matched = append(matched, volume)
+			}
+		}
+		result = matched
+	}
 
-	// Marshal the result.
-	body, _ := json.Marshal(result)
-	v.SuccessHandle(StatusOK, body)
+	v.SuccessHandleList(StatusOK, result)
 
 	return
 }
@@ -178,6 +263,11 @@ func (v *VolumePortal) GetVolume() {
 		v.ErrorHandle(model.ErrorNotFound, errMsg)
 		return
 	}
+	if err := util.CheckCreatorVisibility(c.GetContext(v.Ctx), result.UserId); err != nil {
+		errMsg := fmt.Sprintf("volume %s not found: %s", id, err.Error())
+		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
 
 	// Marshal the result.
 	body, _ := json.Marshal(result)
@@ -200,8 +290,34 @@ func (v *VolumePortal) UpdateVolume() {
 		v.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
+	if err := util.ValidateSystemMetadata(volume.Metadata); err != nil {
+		errMsg := fmt.Sprintf("update volume failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	// A retype must point at a profile of the same endpoint family.
+	if volume.ProfileId != "" {
+		prf, err := db.C.GetProfile(c.GetContext(v.Ctx), volume.ProfileId)
+		if err != nil {
+			errMsg := fmt.Sprintf("get profile failed: %s", err.Error())
+			v.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+		if prf.StorageType != constants.Block {
+			errMsg := fmt.Sprintf("storageType should be only block. Currently it is: %s", prf.StorageType)
+			log.Error(errMsg)
+			v.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	}
 
 	volume.Id = id
+	if err := util.ValidateWithWebhook(c.GetContext(v.Ctx), "volume", util.WebhookOperationUpdate, &volume); err != nil {
+		errMsg := fmt.Sprintf("update volume failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
 	result, err := db.C.UpdateVolume(c.GetContext(v.Ctx), &volume)
 	if err != nil {
 		errMsg := fmt.Sprintf("update volume failed: %s", err.Error())
@@ -216,6 +332,129 @@ func (v *VolumePortal) UpdateVolume() {
 	return
 }
 
+// UpdateVolumeMetadata merges the posted entries into the metadata of the
+// volume. Unlike a PUT of the volume only the listed keys are touched, and
+// the per-key conditions let a writer fail instead of overwriting a value
+// another tool wrote concurrently.
+func (v *VolumePortal) UpdateVolumeMetadata() {
+	if !policy.Authorize(v.Ctx, "volume:update_metadata") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	id := v.Ctx.Input.Param(":volumeId")
+
+	var update = model.MetadataUpdateSpec{}
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&update); err != nil {
+		errMsg := fmt.Sprintf("parse metadata update request body failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if len(update.Metadata) == 0 {
+		errMsg := "metadata to merge can not be empty"
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if err := util.ValidateSystemMetadata(update.Metadata); err != nil {
+		errMsg := fmt.Sprintf("update volume metadata failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	result, err := db.C.UpdateVolumeMetadata(ctx, id, &update)
+	if err != nil {
+		errMsg := fmt.Sprintf("update volume metadata failed: %s", err.Error())
+		if _, ok := err.(*model.MetadataConditionError); ok {
+			v.ErrorHandle(model.ErrorConflict, errMsg)
+			return
+		}
+		v.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, _ := json.Marshal(result)
+	v.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+// CreateVolumeAnnotation appends an operator note to the volume. The notes
+// are kept separate from the machine generated metadata.
+func (v *VolumePortal) CreateVolumeAnnotation() {
+	if !policy.Authorize(v.Ctx, "annotation:create") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	id := v.Ctx.Input.Param(":volumeId")
+
+	var anno = model.AnnotationSpec{
+		BaseModel: &model.BaseModel{},
+	}
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&anno); err != nil {
+		errMsg := fmt.Sprintf("parse annotation request body failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if anno.Note == "" {
+		errMsg := "annotation note can not be empty"
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	if _, err := db.C.GetVolume(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("volume %s not found: %s", id, err.Error())
+		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	anno.ResourceType = model.AnnotationResourceVolume
+	anno.ResourceId = id
+	anno.UserId = ctx.UserId
+	result, err := db.C.CreateAnnotation(ctx, &anno)
+	if err != nil {
+		errMsg := fmt.Sprintf("create annotation failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(result)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal annotation failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	v.SuccessHandle(StatusOK, body)
+
+	return
+}
+
+// ListVolumeAnnotations lists the operator notes appended to the volume,
+// oldest first.
+func (v *VolumePortal) ListVolumeAnnotations() {
+	if !policy.Authorize(v.Ctx, "annotation:list") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	id := v.Ctx.Input.Param(":volumeId")
+
+	if _, err := db.C.GetVolume(ctx, id); err != nil {
+		errMsg := fmt.Sprintf("volume %s not found: %s", id, err.Error())
+		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	result, err := db.C.ListAnnotations(ctx, model.AnnotationResourceVolume, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("list annotations failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	v.SuccessHandleList(StatusOK, result)
+
+	return
+}
+
 // ExtendVolume ...
 func (v *VolumePortal) ExtendVolume() {
 	if !policy.Authorize(v.Ctx, "volume:extend") {
@@ -245,11 +484,34 @@ func (v *VolumePortal) ExtendVolume() {
 		return
 	}
 
+	// Serialize mutating operations on the volume, a concurrent request gets
+	// a conflict until the running operation completes.
+	pendingOp, err := db.C.AcquirePendingOperation(ctx, &model.PendingOperationSpec{
+		BaseModel:    &model.BaseModel{},
+		ResourceType: "volume",
+		ResourceId:   id,
+		Operation:    "extend",
+	})
+	if err != nil {
+		errMsg := fmt.Sprintf("extend volume failed: %v", err.Error())
+		v.ErrorHandle(model.ErrorConflict, errMsg)
+		return
+	}
+	defer func() {
+		if err := db.C.ReleasePendingOperation(ctx, "volume", id, pendingOp.Token); err != nil {
+			log.Error("when releasing pending operation:", err)
+		}
+	}()
+
 	// NOTE:It will update the the status of the volume waiting for expansion in
 	// the database to "extending" and return the result immediately.
 	result, err := util.ExtendVolumeDBEntry(ctx, id, &extendRequestBody)
 	if err != nil {
 		errMsg := fmt.Sprintf("extend volume failed: %s", err.Error())
+		if _, ok := err.(*util.ResizeCooldownError); ok {
+			v.ErrorHandle(model.ErrorTooManyRequests, errMsg)
+			return
+		}
 		v.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
@@ -261,8 +523,10 @@ func (v *VolumePortal) ExtendVolume() {
 	// NOTE:The real volume extension process.
 	// Volume extension request is sent to the Dock. Dock will update volume status to "available"
 	// after volume extension is completed.
+	task := util.StartTask(ctx, "volume", id, "extendVolume")
 	if err = v.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
 		log.Error("when connecting controller client:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 
@@ -276,17 +540,85 @@ func (v *VolumePortal) ExtendVolume() {
 	response, err := v.CtrClient.ExtendVolume(context.Background(), opt)
 	if err != nil {
 		log.Error("extend volume failed in controller service:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 	if errorMsg := response.GetError(); errorMsg != nil {
 		log.Errorf("failed to extend volume in controller, code: %v, message: %v",
 			errorMsg.GetCode(), errorMsg.GetDescription())
+		util.CloseTask(ctx, task, fmt.Errorf(errorMsg.GetDescription()))
 		return
 	}
+	util.CloseTask(ctx, task, nil)
 
 	return
 }
 
+// volumeActionTransitions maps each volume action to the statuses it may
+// start from and the status it moves the volume to. The full state machine
+// is documented on model.VolumeActionSpec.
+var volumeActionTransitions = map[string]struct {
+	from []string
+	to   string
+}{
+	model.VolumeActionReserve:   {[]string{model.VolumeAvailable}, model.VolumeReserved},
+	model.VolumeActionUnreserve: {[]string{model.VolumeReserved}, model.VolumeAvailable},
+	model.VolumeActionAttach:    {[]string{model.VolumeAttaching}, model.VolumeInUse},
+	model.VolumeActionDetach:    {[]string{model.VolumeInUse}, model.VolumeAvailable},
+}
+
+// VolumeAction applies one step of the attach state machine to a volume, so
+// orchestrators can claim and hand over volumes through a clear contract
+// instead of racing on the attachment resource.
+func (v *VolumePortal) VolumeAction() {
+	if !policy.Authorize(v.Ctx, "volume:action") {
+		return
+	}
+	ctx := c.GetContext(v.Ctx)
+	var action model.VolumeActionSpec
+
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&action); err != nil {
+		errMsg := fmt.Sprintf("parse volume action request body failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	transition, ok := volumeActionTransitions[action.Action]
+	if !ok {
+		errMsg := fmt.Sprintf("invalid volume action %s, supported actions are: %s, %s, %s and %s",
+			action.Action, model.VolumeActionReserve, model.VolumeActionUnreserve,
+			model.VolumeActionAttach, model.VolumeActionDetach)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	id := v.Ctx.Input.Param(":volumeId")
+	volume, err := db.C.GetVolume(ctx, id)
+	if err != nil {
+		errMsg := fmt.Sprintf("volume %s not found: %s", id, err.Error())
+		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	if !utils.Contained(volume.Status, transition.from) {
+		errMsg := fmt.Sprintf("volume %s can not %s while its status is %s, the action requires one of: %v",
+			id, action.Action, volume.Status, transition.from)
+		v.ErrorHandle(model.ErrorConflict, errMsg)
+		return
+	}
+
+	if err := db.UpdateVolumeStatus(ctx, db.C, id, transition.to); err != nil {
+		errMsg := fmt.Sprintf("%s volume failed: %s", action.Action, err.Error())
+		v.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	volume.Status = transition.to
+
+	// Marshal the result.
+	body, _ := json.Marshal(volume)
+	v.SuccessHandle(StatusOK, body)
+}
+
 func (v *VolumePortal) DeleteVolume() {
 	if !policy.Authorize(v.Ctx, "volume:delete") {
 		return
@@ -298,9 +630,28 @@ func (v *VolumePortal) DeleteVolume() {
 	volume, err := db.C.GetVolume(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("volume %s not found: %s", id, err.Error())
-		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		v.DeleteNotFoundHandle(errMsg)
+		return
+	}
+
+	// Serialize mutating operations on the volume, a concurrent request gets
+	// a conflict until the running operation completes.
+	pendingOp, err := db.C.AcquirePendingOperation(ctx, &model.PendingOperationSpec{
+		BaseModel:    &model.BaseModel{},
+		ResourceType: "volume",
+		ResourceId:   id,
+		Operation:    "delete",
+	})
+	if err != nil {
+		errMsg := fmt.Sprintf("delete volume failed: %v", err.Error())
+		v.ErrorHandle(model.ErrorConflict, errMsg)
 		return
 	}
+	defer func() {
+		if err := db.C.ReleasePendingOperation(ctx, "volume", id, pendingOp.Token); err != nil {
+			log.Error("when releasing pending operation:", err)
+		}
+	}()
 
 	// If profileId or poolId of the volume doesn't exist, it would mean that
 	// the volume provisioning operation failed before the create method in
@@ -336,8 +687,10 @@ func (v *VolumePortal) DeleteVolume() {
 	// NOTE:The real volume deletion process.
 	// Volume deletion request is sent to the Dock. Dock will delete volume from driver
 	// and database or update volume status to "errorDeleting" if deletion from driver faild.
+	task := util.StartTask(ctx, "volume", volume.Id, "deleteVolume")
 	if err := v.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
 		log.Error("when connecting controller client:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 
@@ -352,13 +705,16 @@ func (v *VolumePortal) DeleteVolume() {
 	response, err := v.CtrClient.DeleteVolume(context.Background(), opt)
 	if err != nil {
 		log.Error("delete volume failed in controller service:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 	if errorMsg := response.GetError(); errorMsg != nil {
 		log.Errorf("failed to delete volume in controller, code: %v, message: %v",
 			errorMsg.GetCode(), errorMsg.GetDescription())
+		util.CloseTask(ctx, task, fmt.Errorf(errorMsg.GetDescription()))
 		return
 	}
+	util.CloseTask(ctx, task, nil)
 
 	return
 }
@@ -390,6 +746,25 @@ func (v *VolumeSnapshotPortal) CreateVolumeSnapshot() {
 		return
 	}
 
+	// An application consistent snapshot quiesces the workload through the
+	// configured webhook around the cut; without a webhook only crash
+	// consistency can be honored.
+	switch snapshot.Consistency {
+	case "":
+		snapshot.Consistency = constants.SnapshotCrashConsistent
+	case constants.SnapshotCrashConsistent:
+	case constants.SnapshotApplicationConsistent:
+		if CONF.OsdsApiServer.SnapshotQuiesceWebhookUrl == "" {
+			errMsg := "application consistent snapshots are not available, set snapshot_quiesce_webhook_url to enable them"
+			v.ErrorHandle(model.ErrorBadRequest, errMsg)
+			return
+		}
+	default:
+		errMsg := fmt.Sprintf("invalid consistency %s, supported values are crash and application", snapshot.Consistency)
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
 	// If user doesn't specified profile, using profile derived from volume
 	if len(snapshot.ProfileId) == 0 {
 		log.Warning("User doesn't specified profile id, using profile derived from volume")
@@ -424,31 +799,71 @@ func (v *VolumeSnapshotPortal) CreateVolumeSnapshot() {
 	// NOTE:The real volume snapshot creation process.
 	// Volume snapshot creation request is sent to the Dock. Dock will update volume snapshot status to "available"
 	// after volume snapshot creation complete.
+	task := util.StartTask(ctx, "volumeSnapshot", result.Id, "createVolumeSnapshot")
 	if err := v.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
 		log.Error("when connecting controller client:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 
+	// Freeze the workload around the cut for an application consistent
+	// snapshot. When the workload can not be frozen the snapshot is failed
+	// instead of silently degrading to crash consistency.
+	if result.Consistency == constants.SnapshotApplicationConsistent {
+		if err := util.QuiesceWorkload(ctx, util.QuiescePhasePre, result); err != nil {
+			reason := fmt.Sprintf("quiesce workload before snapshot failed: %v", err)
+			log.Error(reason)
+			if err := db.UpdateVolumeSnapshotFault(ctx, db.C, result.Id, model.VolumeSnapError, reason); err != nil {
+				log.Error("when update volume snapshot status in db:", err)
+			}
+			util.CloseTask(ctx, task, err)
+			return
+		}
+		defer func() {
+			if err := util.QuiesceWorkload(ctx, util.QuiescePhasePost, result); err != nil {
+				log.Errorf("unquiesce workload after snapshot %s failed, the workload may still be frozen: %v",
+					result.Id, err)
+			}
+		}()
+	}
+
 	opt := &pb.CreateVolumeSnapshotOpts{
 		Id:          result.Id,
 		Name:        result.Name,
 		Description: result.Description,
 		VolumeId:    result.VolumeId,
 		Size:        result.Size,
-		Metadata:    result.Metadata,
-		Context:     ctx.ToJson(),
-		Profile:     prf.ToJson(),
-	}
-	response, err := v.CtrClient.CreateVolumeSnapshot(context.Background(), opt)
+		// The generated snapshot options carry no consistency field, so
+		// the level rides in the metadata for the driver.
+		Metadata: utils.MergeStringMaps(result.Metadata,
+			map[string]string{"consistency": result.Consistency}),
+		Context: ctx.ToJson(),
+		Profile: prf.ToJson(),
+	}
+	// A dispatch that fails transiently is retried per the configured
+	// policy before the snapshot is marked error.
+	var response *pb.GenericResponse
+	attempts, err := util.DispatchWithRetry("volume_snapshot:create", func() error {
+		var callErr error
+		response, callErr = v.CtrClient.CreateVolumeSnapshot(context.Background(), opt)
+		return callErr
+	})
 	if err != nil {
-		log.Error("create volume snapthot failed in controller service:", err)
+		reason := fmt.Sprintf("create volume snapshot failed in controller service: %v", err)
+		log.Error(reason)
+		if err := db.UpdateVolumeSnapshotDispatchFault(ctx, db.C, result.Id, model.VolumeSnapError, reason, attempts); err != nil {
+			log.Error("when update volume snapshot status in db:", err)
+		}
+		util.CloseTask(ctx, task, err)
 		return
 	}
 	if errorMsg := response.GetError(); errorMsg != nil {
 		log.Errorf("failed to create volume snapshot in controller, code: %v, message: %v",
 			errorMsg.GetCode(), errorMsg.GetDescription())
+		util.CloseTask(ctx, task, fmt.Errorf(errorMsg.GetDescription()))
 		return
 	}
+	util.CloseTask(ctx, task, nil)
 
 	return
 }
@@ -464,6 +879,9 @@ func (v *VolumeSnapshotPortal) ListVolumeSnapshots() {
 		return
 	}
 
+	// a member without the tenant admin role only sees their own snapshots
+	util.ScopeListToCreator(c.GetContext(v.Ctx), m)
+
 	result, err := db.C.ListVolumeSnapshotsWithFilter(c.GetContext(v.Ctx), m)
 	if err != nil {
 		errMsg := fmt.Sprintf("list volume snapshots failed: %s", err.Error())
@@ -471,9 +889,7 @@ func (v *VolumeSnapshotPortal) ListVolumeSnapshots() {
 		return
 	}
 
-	// Marshal the result.
-	body, _ := json.Marshal(result)
-	v.SuccessHandle(StatusOK, body)
+	v.SuccessHandleList(StatusOK, result)
 
 	return
 }
@@ -490,6 +906,11 @@ func (v *VolumeSnapshotPortal) GetVolumeSnapshot() {
 		v.ErrorHandle(model.ErrorNotFound, errMsg)
 		return
 	}
+	if err := util.CheckCreatorVisibility(c.GetContext(v.Ctx), result.UserId); err != nil {
+		errMsg := fmt.Sprintf("volume snapshot %s not found: %s", id, err.Error())
+		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
 
 	// Marshal the result.
 	body, _ := json.Marshal(result)
@@ -513,6 +934,11 @@ func (v *VolumeSnapshotPortal) UpdateVolumeSnapshot() {
 		v.ErrorHandle(model.ErrorBadRequest, errMsg)
 		return
 	}
+	if err := util.ValidateSystemMetadata(snapshot.Metadata); err != nil {
+		errMsg := fmt.Sprintf("update volume snapshot failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
 	snapshot.Id = id
 
 	result, err := db.C.UpdateVolumeSnapshot(c.GetContext(v.Ctx), id, &snapshot)
@@ -539,7 +965,7 @@ func (v *VolumeSnapshotPortal) DeleteVolumeSnapshot() {
 	snapshot, err := db.C.GetVolumeSnapshot(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("volume snapshot %s not found: %s", id, err.Error())
-		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		v.DeleteNotFoundHandle(errMsg)
 		return
 	}
 
@@ -564,8 +990,10 @@ func (v *VolumeSnapshotPortal) DeleteVolumeSnapshot() {
 	// NOTE:The real volume snapshot deletion process.
 	// Volume snapshot deletion request is sent to the Dock. Dock will delete volume snapshot from driver and
 	// database or update its status to "errorDeleting" if volume snapshot deletion from driver failed.
+	task := util.StartTask(ctx, "volumeSnapshot", snapshot.Id, "deleteVolumeSnapshot")
 	if err := v.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
 		log.Error("when connecting controller client:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 
@@ -579,13 +1007,16 @@ func (v *VolumeSnapshotPortal) DeleteVolumeSnapshot() {
 	response, err := v.CtrClient.DeleteVolumeSnapshot(context.Background(), opt)
 	if err != nil {
 		log.Error("delete volume snapthot failed in controller service:", err)
+		util.CloseTask(ctx, task, err)
 		return
 	}
 	if errorMsg := response.GetError(); errorMsg != nil {
 		log.Errorf("failed to delete volume snapshot in controller, code: %v, message: %v",
 			errorMsg.GetCode(), errorMsg.GetDescription())
+		util.CloseTask(ctx, task, fmt.Errorf(errorMsg.GetDescription()))
 		return
 	}
+	util.CloseTask(ctx, task, nil)
 
 	return
 }
@@ -188,6 +188,117 @@ func (v *VolumeGroupPortal) UpdateVolumeGroup() {
 	return
 }
 
+// AddVolumes appends volumes to an existing volume group, after validating
+// that every volume shares the group's profile and pool and is not a
+// member of another group. This replaces recreating the whole group just
+// to change its membership.
+func (v *VolumeGroupPortal) AddVolumes() {
+	if !policy.Authorize(v.Ctx, "volume_group:add_volumes") {
+		return
+	}
+	var spec = struct {
+		AddVolumes []string `json:"addVolumes"`
+	}{}
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&spec); err != nil {
+		errMsg := fmt.Sprintf("parse add volumes request body failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if len(spec.AddVolumes) == 0 {
+		errMsg := "no volumes to add were specified"
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	v.updateVolumeGroupMembership(&model.VolumeGroupSpec{
+		BaseModel:  &model.BaseModel{Id: v.Ctx.Input.Param(":groupId")},
+		AddVolumes: spec.AddVolumes,
+	})
+}
+
+// RemoveVolumes takes volumes out of an existing volume group, after
+// validating that they are members and in a state that allows removal.
+func (v *VolumeGroupPortal) RemoveVolumes() {
+	if !policy.Authorize(v.Ctx, "volume_group:remove_volumes") {
+		return
+	}
+	var spec = struct {
+		RemoveVolumes []string `json:"removeVolumes"`
+	}{}
+	if err := json.NewDecoder(v.Ctx.Request.Body).Decode(&spec); err != nil {
+		errMsg := fmt.Sprintf("parse remove volumes request body failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+	if len(spec.RemoveVolumes) == 0 {
+		errMsg := "no volumes to remove were specified"
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	v.updateVolumeGroupMembership(&model.VolumeGroupSpec{
+		BaseModel:     &model.BaseModel{Id: v.Ctx.Input.Param(":groupId")},
+		RemoveVolumes: spec.RemoveVolumes,
+	})
+}
+
+// updateVolumeGroupMembership validates the membership change, persists it
+// and notifies the controller, shared by AddVolumes and RemoveVolumes.
+func (v *VolumeGroupPortal) updateVolumeGroupMembership(vg *model.VolumeGroupSpec) {
+	ctx := c.GetContext(v.Ctx)
+
+	addVolumes, removeVolumes, err := util.UpdateVolumeGroupDBEntry(ctx, vg)
+	if err != nil {
+		errMsg := fmt.Sprintf("update volume group membership failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorBadRequest, errMsg)
+		return
+	}
+
+	vgNew, err := db.C.GetVolumeGroup(ctx, vg.Id)
+	if err != nil {
+		errMsg := fmt.Sprintf("volume group %s not found: %s", vg.Id, err.Error())
+		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		return
+	}
+
+	// Marshal the result.
+	body, err := json.Marshal(vgNew)
+	if err != nil {
+		errMsg := fmt.Sprintf("marshal volume group updated result failed: %s", err.Error())
+		v.ErrorHandle(model.ErrorInternalServer, errMsg)
+		return
+	}
+	v.SuccessHandle(StatusAccepted, body)
+
+	// NOTE:The real volume group update process.
+	// The membership change is sent to the Dock. Dock will set volume
+	// group status to 'available' after the update operation is completed.
+	if err = v.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+
+	opt := &pb.UpdateVolumeGroupOpts{
+		Id:            vg.Id,
+		AddVolumes:    addVolumes,
+		RemoveVolumes: removeVolumes,
+		PoolId:        vgNew.PoolId,
+		Context:       ctx.ToJson(),
+	}
+	response, err := v.CtrClient.UpdateVolumeGroup(context.Background(), opt)
+	if err != nil {
+		log.Error("update volume group failed in controller service:", err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to update volume group in controller, code: %v, message: %v",
+			errorMsg.GetCode(), errorMsg.GetDescription())
+		return
+	}
+
+	return
+}
+
 func (v *VolumeGroupPortal) DeleteVolumeGroup() {
 	if !policy.Authorize(v.Ctx, "volume_group:delete") {
 		return
@@ -198,7 +309,7 @@ func (v *VolumeGroupPortal) DeleteVolumeGroup() {
 	vg, err := db.C.GetVolumeGroup(ctx, id)
 	if err != nil {
 		errMsg := fmt.Sprintf("volume group %s not found: %s", id, err.Error())
-		v.ErrorHandle(model.ErrorNotFound, errMsg)
+		v.DeleteNotFoundHandle(errMsg)
 		return
 	}
 
@@ -282,14 +393,6 @@ func (v *VolumeGroupPortal) ListVolumeGroups() {
 		return
 	}
 
-	// Marshal the result.
-	body, err := json.Marshal(result)
-	if err != nil {
-		errMsg := fmt.Sprintf("marshal volume groups listed result failed: %s", err.Error())
-		v.ErrorHandle(model.ErrorInternalServer, errMsg)
-		return
-	}
-
-	v.SuccessHandle(StatusOK, body)
+	v.SuccessHandleList(StatusOK, result)
 	return
 }
@@ -41,6 +41,8 @@ import (
 func init() {
 	beego.Router("/v1beta/block/volumeGroups", NewFakeVolumeGroupPortal(), "post:CreateVolumeGroup;get:ListVolumeGroups")
 	beego.Router("/v1beta/block/volumeGroups/:groupId", NewFakeVolumeGroupPortal(), "put:UpdateVolumeGroup;get:GetVolumeGroup;delete:DeleteVolumeGroup")
+	beego.Router("/v1beta/block/volumeGroups/:groupId/addVolumes", NewFakeVolumeGroupPortal(), "post:AddVolumes")
+	beego.Router("/v1beta/block/volumeGroups/:groupId/removeVolumes", NewFakeVolumeGroupPortal(), "post:RemoveVolumes")
 }
 
 func NewFakeVolumeGroupPortal() *VolumeGroupPortal {
@@ -54,6 +56,18 @@ func NewFakeVolumeGroupPortal() *VolumeGroupPortal {
 	mockClient.On("DeleteVolumeGroup", ctx.Background(), &pb.DeleteVolumeGroupOpts{
 		Context: c.NewAdminContext().ToJson(),
 	}).Return(&pb.GenericResponse{}, nil)
+	mockClient.On("UpdateVolumeGroup", ctx.Background(), &pb.UpdateVolumeGroupOpts{
+		Id:         "3769855c-a102-11e7-b772-17b880d2f555",
+		AddVolumes: []string{"bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		PoolId:     "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Context:    c.NewAdminContext().ToJson(),
+	}).Return(&pb.GenericResponse{}, nil)
+	mockClient.On("UpdateVolumeGroup", ctx.Background(), &pb.UpdateVolumeGroupOpts{
+		Id:            "3769855c-a102-11e7-b772-17b880d2f555",
+		RemoveVolumes: []string{"bd5b12a8-a101-11e7-941e-d77981b584d8"},
+		PoolId:        "084bf71e-a102-11e7-88a8-e31fe6d52248",
+		Context:       c.NewAdminContext().ToJson(),
+	}).Return(&pb.GenericResponse{}, nil)
 
 	return &VolumeGroupPortal{
 		CtrClient: mockClient,
@@ -244,6 +258,98 @@ func TestUpdateVolumeGroup(t *testing.T) {
 	})
 }
 
+func TestAddVolumes(t *testing.T) {
+	var jsonStr = []byte(`{"addVolumes": ["bd5b12a8-a101-11e7-941e-d77981b584d8"]}`)
+
+	t.Run("Should return 202 if everything works well", func(t *testing.T) {
+		vgNew := &model.VolumeGroupSpec{BaseModel: &model.BaseModel{
+			Id:        "3769855c-a102-11e7-b772-17b880d2f555",
+			UpdatedAt: time.Now().Format(constants.TimeFormat),
+		},
+			Status: model.VolumeGroupUpdating,
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolumeGroup", c.NewAdminContext(), "3769855c-a102-11e7-b772-17b880d2f555").Return(&SampleVolumeGroups[1], nil)
+		mockClient.On("ListVolumesByGroupId", c.NewAdminContext(), SampleVolumeGroups[1].Id).Return(nil, nil)
+		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleVolumes[0], nil)
+		mockClient.On("UpdateVolumeGroup", c.NewAdminContext(), vgNew).Return(&SampleVolumeGroups[1], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumeGroups/3769855c-a102-11e7-b772-17b880d2f555/addVolumes", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.VolumeGroupSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 202)
+		assertTestResult(t, &output, &SampleVolumeGroups[1])
+	})
+
+	t.Run("Should return 400 if no volumes are specified", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumeGroups/3769855c-a102-11e7-b772-17b880d2f555/addVolumes", bytes.NewBuffer([]byte(`{}`)))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestRemoveVolumes(t *testing.T) {
+	var jsonStr = []byte(`{"removeVolumes": ["bd5b12a8-a101-11e7-941e-d77981b584d8"]}`)
+
+	t.Run("Should return 202 if everything works well", func(t *testing.T) {
+		vgNew := &model.VolumeGroupSpec{BaseModel: &model.BaseModel{
+			Id:        "3769855c-a102-11e7-b772-17b880d2f555",
+			UpdatedAt: time.Now().Format(constants.TimeFormat),
+		},
+			Status: model.VolumeGroupUpdating,
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolumeGroup", c.NewAdminContext(), "3769855c-a102-11e7-b772-17b880d2f555").Return(&SampleVolumeGroups[1], nil)
+		mockClient.On("ListVolumesByGroupId", c.NewAdminContext(), SampleVolumeGroups[1].Id).Return(
+			[]*model.VolumeSpec{&SampleVolumes[0]}, nil)
+		mockClient.On("UpdateVolumeGroup", c.NewAdminContext(), vgNew).Return(&SampleVolumeGroups[1], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumeGroups/3769855c-a102-11e7-b772-17b880d2f555/removeVolumes", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.VolumeGroupSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 202)
+		assertTestResult(t, &output, &SampleVolumeGroups[1])
+	})
+
+	t.Run("Should return 400 if the volume is not in the group", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolumeGroup", c.NewAdminContext(), "3769855c-a102-11e7-b772-17b880d2f555").Return(&SampleVolumeGroups[1], nil)
+		mockClient.On("ListVolumesByGroupId", c.NewAdminContext(), SampleVolumeGroups[1].Id).Return(nil, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumeGroups/3769855c-a102-11e7-b772-17b880d2f555/removeVolumes", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
 func TestDeleteVolumeGroup(t *testing.T) {
 	t.Run("Should return 202 if everything works well", func(t *testing.T) {
 		mockClient := new(dbtest.Client)
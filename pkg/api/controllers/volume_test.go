@@ -21,6 +21,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/astaxie/beego"
@@ -45,6 +46,10 @@ func init() {
 		"get:GetVolume;put:UpdateVolume;delete:DeleteVolume")
 	beego.Router("/v1beta/block/volumes/:volumeId/resize", NewFakeVolumePortal(),
 		"post:ExtendVolume")
+	beego.Router("/v1beta/block/volumes/:volumeId/action", NewFakeVolumePortal(),
+		"post:VolumeAction")
+	beego.Router("/v1beta/block/volumes/:volumeId/metadata", NewFakeVolumePortal(),
+		"post:UpdateVolumeMetadata")
 
 	beego.Router("/v1beta/block/snapshots", &VolumeSnapshotPortal{},
 		"post:CreateVolumeSnapshot;get:ListVolumeSnapshots")
@@ -123,6 +128,69 @@ func TestListVolumes(t *testing.T) {
 		assertTestResult(t, output, sampleVolumes)
 	})
 
+	t.Run("Should filter volumes created before the requested time", func(t *testing.T) {
+		var recentVolume = model.VolumeSpec{
+			BaseModel: &model.BaseModel{
+				Id:        "bd5b12a8-a101-11e7-941e-d77981b584d8",
+				CreatedAt: "2019-07-01T08:00:00Z",
+			},
+			Name: "recent",
+		}
+		var oldVolume = model.VolumeSpec{
+			BaseModel: &model.BaseModel{
+				Id:        "591c43e6-1156-42f5-9fbc-161153da185c",
+				CreatedAt: "2019-01-02T10:00:00",
+			},
+			Name: "old",
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListVolumesWithFilter", c.NewAdminContext(), map[string][]string{}).
+			Return([]*model.VolumeSpec{&recentVolume, &oldVolume}, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/block/volumes?createdAt>=2019-06-01T00:00:00Z", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var output []*model.VolumeSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output, []*model.VolumeSpec{&recentVolume})
+	})
+
+	t.Run("Should stream one volume per line if the client accepts ndjson", func(t *testing.T) {
+		var sampleVolumes = []*model.VolumeSpec{&SampleVolumes[0], &SampleVolumes[1]}
+		mockClient := new(dbtest.Client)
+		m := map[string][]string{
+			"offset":  {"0"},
+			"limit":   {"1"},
+			"sortDir": {"asc"},
+			"sortKey": {"name"},
+		}
+		mockClient.On("ListVolumesWithFilter", c.NewAdminContext(), m).Return(sampleVolumes, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/block/volumes?offset=0&limit=1&sortDir=asc&sortKey=name", nil)
+		r.Header.Set("Accept", NDJsonContentType)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		assertTestResult(t, w.Code, 200)
+		lines := strings.Split(strings.TrimRight(w.Body.String(), "\n"), "\n")
+		assertTestResult(t, len(lines), len(sampleVolumes))
+		for i, line := range lines {
+			var output model.VolumeSpec
+			json.Unmarshal([]byte(line), &output)
+			assertTestResult(t, &output, sampleVolumes[i])
+		}
+	})
+
 	t.Run("Should return 500 if list volume with bad request", func(t *testing.T) {
 		mockClient := new(dbtest.Client)
 		m := map[string][]string{
@@ -163,6 +231,29 @@ func TestGetVolume(t *testing.T) {
 		assertTestResult(t, &output, &SampleVolumes[0])
 	})
 
+	t.Run("Should wrap the response when the envelope is requested", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleVolumes[0], nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("GET", "/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8?envelope=true", nil)
+		w := httptest.NewRecorder()
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+
+		var envelope struct {
+			RequestId  string           `json:"requestId"`
+			ApiVersion string           `json:"apiVersion"`
+			Data       model.VolumeSpec `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &envelope)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, envelope.ApiVersion, "v1beta")
+		assertTestResult(t, &envelope.Data, &SampleVolumes[0])
+	})
+
 	t.Run("Should return 404 if get volume replication with bad request", func(t *testing.T) {
 		mockClient := new(dbtest.Client)
 		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(nil, errors.New("db error"))
@@ -232,6 +323,91 @@ func TestUpdateVolume(t *testing.T) {
 		beego.BeeApp.Handlers.ServeHTTP(w, r)
 		assertTestResult(t, w.Code, 500)
 	})
+
+	t.Run("Should return 400 if the volume is retyped to a file profile", func(t *testing.T) {
+		fileProfile := SampleFileShareProfiles[0]
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetProfile", c.NewAdminContext(), fileProfile.Id).Return(&fileProfile, nil)
+		db.C = mockClient
+
+		var retypeStr = []byte(`{"profileId": "` + fileProfile.Id + `"}`)
+		r, _ := http.NewRequest("PUT", "/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8", bytes.NewBuffer(retypeStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
+}
+
+func TestUpdateVolumeMetadata(t *testing.T) {
+
+	t.Run("Should return 200 if the merge goes through", func(t *testing.T) {
+		update := &model.MetadataUpdateSpec{
+			Metadata: map[string]string{"owner": "team-a"},
+			IfAbsent: []string{"owner"},
+		}
+		expected := SampleVolumes[0]
+		expected.Metadata = map[string]string{"owner": "team-a"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("UpdateVolumeMetadata", c.NewAdminContext(),
+			"bd5b12a8-a101-11e7-941e-d77981b584d8", update).Return(&expected, nil)
+		db.C = mockClient
+
+		body, _ := json.Marshal(update)
+		r, _ := http.NewRequest("POST",
+			"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/metadata", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.VolumeSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, &output, &expected)
+	})
+
+	t.Run("Should return 409 if a condition does not hold", func(t *testing.T) {
+		update := &model.MetadataUpdateSpec{
+			Metadata: map[string]string{"owner": "team-a"},
+			IfValue:  map[string]string{"owner": "team-b"},
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("UpdateVolumeMetadata", c.NewAdminContext(),
+			"bd5b12a8-a101-11e7-941e-d77981b584d8", update).
+			Return(nil, &model.MetadataConditionError{Key: "owner", Reason: "expected current value \"team-b\", got \"team-a\""})
+		db.C = mockClient
+
+		body, _ := json.Marshal(update)
+		r, _ := http.NewRequest("POST",
+			"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/metadata", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+
+	t.Run("Should return 400 if nothing is merged", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		r, _ := http.NewRequest("POST",
+			"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/metadata",
+			bytes.NewBuffer([]byte(`{"metadata": {}}`)))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
 }
 
 func TestExtendVolume(t *testing.T) {
@@ -251,11 +427,27 @@ func TestExtendVolume(t *testing.T) {
 	var expected model.VolumeSpec
 	json.Unmarshal(expectedJson, &expected)
 
+	var fakePendingOp = &model.PendingOperationSpec{
+		BaseModel:    &model.BaseModel{},
+		ResourceType: "volume",
+		ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		Operation:    "extend",
+	}
+	var fakeAcquiredOp = &model.PendingOperationSpec{
+		BaseModel:    &model.BaseModel{},
+		ResourceType: "volume",
+		ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		Operation:    "extend",
+		Token:        "2dd77a92-a101-11e7-941e-d77981b584d8",
+	}
+
 	t.Run("Should return 200 if everything works well", func(t *testing.T) {
 		mockClient := new(dbtest.Client)
 		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleVolumes[0], nil)
 		mockClient.On("ExtendVolume", c.NewAdminContext(), &expected).Return(&expected, nil)
 		mockClient.On("GetProfile", c.NewAdminContext(), SampleReplications[0].ProfileId).Return(&SampleProfiles[0], nil)
+		mockClient.On("AcquirePendingOperation", c.NewAdminContext(), fakePendingOp).Return(fakeAcquiredOp, nil)
+		mockClient.On("ReleasePendingOperation", c.NewAdminContext(), "volume", "bd5b12a8-a101-11e7-941e-d77981b584d8", fakeAcquiredOp.Token).Return(nil)
 		db.C = mockClient
 
 		r, _ := http.NewRequest("POST", "/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/resize", bytes.NewBuffer(jsonStr))
@@ -279,6 +471,8 @@ func TestExtendVolume(t *testing.T) {
 		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleVolumes[0], nil)
 		mockClient.On("ExtendVolume", c.NewAdminContext(), &expected).Return(&expected, nil)
 		mockClient.On("GetProfile", c.NewAdminContext(), SampleReplications[0].ProfileId).Return(&SampleProfiles[0], nil)
+		mockClient.On("AcquirePendingOperation", c.NewAdminContext(), fakePendingOp).Return(fakeAcquiredOp, nil)
+		mockClient.On("ReleasePendingOperation", c.NewAdminContext(), "volume", "bd5b12a8-a101-11e7-941e-d77981b584d8", fakeAcquiredOp.Token).Return(nil)
 		db.C = mockClient
 
 		r, _ := http.NewRequest("POST", "/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/resize", bytes.NewBuffer(jsonStr))
@@ -290,6 +484,86 @@ func TestExtendVolume(t *testing.T) {
 		beego.BeeApp.Handlers.ServeHTTP(w, r)
 		assertTestResult(t, w.Code, 400)
 	})
+
+	t.Run("Should return 409 if another operation is running on the volume", func(t *testing.T) {
+		jsonStr = []byte(`{
+			"newSize": 20
+		}`)
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(&SampleVolumes[0], nil)
+		mockClient.On("GetProfile", c.NewAdminContext(), SampleReplications[0].ProfileId).Return(&SampleProfiles[0], nil)
+		mockClient.On("AcquirePendingOperation", c.NewAdminContext(), fakePendingOp).Return(
+			nil, errors.New("operation delete is already running on volume bd5b12a8-a101-11e7-941e-d77981b584d8"))
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8/resize", bytes.NewBuffer(jsonStr))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+}
+
+func TestVolumeAction(t *testing.T) {
+
+	t.Run("Should return 200 when reserving an available volume", func(t *testing.T) {
+		// Earlier tests leave the shared sample in another status.
+		var vol = SampleVolumes[0]
+		vol.Status = model.VolumeAvailable
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", c.NewAdminContext(), vol.Id).Return(&vol, nil)
+		mockClient.On("UpdateStatus", c.NewAdminContext(), &vol, model.VolumeReserved).Return(nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumes/"+vol.Id+"/action",
+			bytes.NewBuffer([]byte(`{"action": "reserve"}`)))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		var output model.VolumeSpec
+		json.Unmarshal(w.Body.Bytes(), &output)
+		assertTestResult(t, w.Code, 200)
+		assertTestResult(t, output.Status, model.VolumeReserved)
+	})
+
+	t.Run("Should return 409 when attaching a volume that is not attaching", func(t *testing.T) {
+		var vol = SampleVolumes[0]
+		vol.Status = model.VolumeAvailable
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", c.NewAdminContext(), vol.Id).Return(&vol, nil)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumes/"+vol.Id+"/action",
+			bytes.NewBuffer([]byte(`{"action": "attach"}`)))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 409)
+	})
+
+	t.Run("Should return 400 for an unknown action", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		db.C = mockClient
+
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumes/"+SampleVolumes[0].Id+"/action",
+			bytes.NewBuffer([]byte(`{"action": "explode"}`)))
+		w := httptest.NewRecorder()
+		r.Header.Set("Content-Type", "application/JSON")
+		beego.InsertFilter("*", beego.BeforeExec, func(httpCtx *context.Context) {
+			httpCtx.Input.SetData("context", c.NewAdminContext())
+		})
+		beego.BeeApp.Handlers.ServeHTTP(w, r)
+		assertTestResult(t, w.Code, 400)
+	})
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,69 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Api key authentication middleware for service accounts.
+
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	bctx "github.com/astaxie/beego/context"
+	"github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+// NewApiKeyAuth wraps the configured authentication strategy. A request
+// carrying the X-Auth-ApiKey header is authenticated against the stored
+// api keys, every other request falls through to the wrapped strategy.
+func NewApiKeyAuth(next AuthBase) AuthBase {
+	return &ApiKeyAuth{next: next}
+}
+
+type ApiKeyAuth struct {
+	next AuthBase
+}
+
+func (auth *ApiKeyAuth) Filter(httpCtx *bctx.Context) {
+	secret := strings.TrimSpace(httpCtx.Input.Header(constants.AuthApiKeyHeader))
+	if secret == "" {
+		auth.next.Filter(httpCtx)
+		return
+	}
+
+	// Only the hash of the secret is kept at rest, so look the key up by
+	// the hash of what the caller presented.
+	hashed := model.HashApiKeySecret(secret)
+	keys, err := db.C.ListApiKeys(context.NewAdminContext())
+	if err != nil {
+		context.HttpError(httpCtx, http.StatusUnauthorized, "validate api key failed,%v", err)
+		return
+	}
+	for _, key := range keys {
+		if key.HashedSecret == hashed {
+			param := map[string]interface{}{
+				"TenantId": key.TenantId,
+				"UserId":   key.UserId,
+				"Roles":    []string{key.Role},
+				"IsAdmin":  key.Role == model.ApiKeyRoleAdmin,
+			}
+			context.UpdateContext(httpCtx, param)
+			return
+		}
+	}
+	context.HttpError(httpCtx, http.StatusUnauthorized, "invalid api key")
+}
@@ -55,5 +55,8 @@ func Factory() beego.FilterFunc {
 		auth = NewNoAuth()
 	}
 	log.Info(auth)
-	return auth.Filter
+	// An api key in the X-Auth-ApiKey header or a scoped token in the
+	// X-Auth-ScopedToken header wins over the configured strategy, so
+	// service accounts and automation work with both keystone and noauth.
+	return NewScopedTokenAuth(NewApiKeyAuth(auth)).Filter
 }
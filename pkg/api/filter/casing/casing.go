@@ -0,0 +1,135 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package casing rewrites legacy snake_case field names in JSON request
+// bodies to the camelCase the API documents. The rewrite keeps old SDKs
+// working during the deprecation window; once the window closes the filter
+// is disabled through accept_snake_case_fields and only camelCase input is
+// accepted. When a body carries a field in both casings the camelCase one
+// wins and the snake_case duplicate is dropped.
+package casing
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	log "github.com/golang/glog"
+)
+
+// camelCase converts a snake_case key to its camelCase form, leaving keys
+// without underscores untouched. Leading and trailing underscores are kept
+// so intentionally odd keys inside metadata maps survive the rewrite.
+func camelCase(key string) string {
+	core := strings.Trim(key, "_")
+	if !strings.Contains(core, "_") {
+		return key
+	}
+	parts := strings.Split(core, "_")
+	var b strings.Builder
+	b.WriteString(key[:len(key)-len(strings.TrimLeft(key, "_"))])
+	b.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		if part == "" {
+			continue
+		}
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	b.WriteString(key[len(strings.TrimRight(key, "_")):])
+	return b.String()
+}
+
+// normalize walks a decoded JSON document and renames snake_case object
+// keys to camelCase. It reports whether anything was renamed.
+func normalize(doc interface{}) (interface{}, bool) {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		changed := false
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			nv, ch := normalize(value)
+			changed = changed || ch
+			ck := camelCase(key)
+			if ck != key {
+				changed = true
+				if _, both := v[ck]; both {
+					// The camelCase field is also present, it wins.
+					continue
+				}
+				key = ck
+			}
+			out[key] = nv
+		}
+		return out, changed
+	case []interface{}:
+		changed := false
+		for i, item := range v {
+			nv, ch := normalize(item)
+			changed = changed || ch
+			v[i] = nv
+		}
+		return v, changed
+	default:
+		return doc, false
+	}
+}
+
+// NormalizeBody rewrites snake_case keys of a JSON body to camelCase and
+// reports whether the body was changed. Bodies that do not parse as JSON
+// are returned untouched, the controller reports those itself.
+func NormalizeBody(body []byte) ([]byte, bool) {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body, false
+	}
+	doc, changed := normalize(doc)
+	if !changed {
+		return body, false
+	}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return body, false
+	}
+	return out, true
+}
+
+func Factory() beego.FilterFunc {
+	return func(httpCtx *context.Context) {
+		switch httpCtx.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			return
+		}
+		body := httpCtx.Input.RequestBody
+		if len(body) == 0 {
+			return
+		}
+		out, changed := NormalizeBody(body)
+		if !changed {
+			return
+		}
+		log.Warningf("deprecated: request %s %s uses snake_case field names, they will stop being accepted when the deprecation window closes",
+			httpCtx.Request.Method, httpCtx.Request.URL.Path)
+		httpCtx.Input.RequestBody = out
+		httpCtx.Request.Body = ioutil.NopCloser(bytes.NewReader(out))
+		httpCtx.Request.ContentLength = int64(len(out))
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casing
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCamelCase(t *testing.T) {
+	cases := map[string]string{
+		"availability_zone":           "availabilityZone",
+		"profile_id":                  "profileId",
+		"name":                        "name",
+		"alreadyCamel":                "alreadyCamel",
+		"max_over_subscription_ratio": "maxOverSubscriptionRatio",
+		"_private_key":                "_privateKey",
+		"trailing_":                   "trailing_",
+		"_":                           "_",
+	}
+	for in, expected := range cases {
+		if got := camelCase(in); got != expected {
+			t.Errorf("camelCase(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestNormalizeBody(t *testing.T) {
+	t.Run("Snake case keys should be rewritten recursively", func(t *testing.T) {
+		body := []byte(`{"availability_zone":"az-a","extras":{"io_connectivity":{"access_protocol":"nfs"}},"protocols":["NFS"]}`)
+		out, changed := NormalizeBody(body)
+		if !changed {
+			t.Fatal("expected the body to be reported as changed")
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(out, &got); err != nil {
+			t.Fatalf("normalized body does not parse: %v", err)
+		}
+		expected := map[string]interface{}{
+			"availabilityZone": "az-a",
+			"extras": map[string]interface{}{
+				"ioConnectivity": map[string]interface{}{
+					"accessProtocol": "nfs",
+				},
+			},
+			"protocols": []interface{}{"NFS"},
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("Camel case bodies should pass through untouched", func(t *testing.T) {
+		body := []byte(`{"availabilityZone":"az-a","size":10}`)
+		out, changed := NormalizeBody(body)
+		if changed {
+			t.Error("expected the body to be reported as unchanged")
+		}
+		if string(out) != string(body) {
+			t.Errorf("expected the body to be returned as is, got %s", out)
+		}
+	})
+
+	t.Run("The camel case field should win when both casings are sent", func(t *testing.T) {
+		body := []byte(`{"profile_id":"old","profileId":"new"}`)
+		out, changed := NormalizeBody(body)
+		if !changed {
+			t.Fatal("expected the body to be reported as changed")
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(out, &got); err != nil {
+			t.Fatalf("normalized body does not parse: %v", err)
+		}
+		if got["profileId"] != "new" || len(got) != 1 {
+			t.Errorf("expected only the camelCase field to survive, got %v", got)
+		}
+	})
+
+	t.Run("Bodies that are not json should pass through", func(t *testing.T) {
+		body := []byte("not json at all")
+		out, changed := NormalizeBody(body)
+		if changed || string(out) != string(body) {
+			t.Errorf("expected the body to be returned as is, got %s", out)
+		}
+	})
+}
@@ -0,0 +1,246 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compat serves the legacy OpenSDS v1beta conventions on the routes
+// the original API had, so existing Cinder/Manila north-bound plugins keep
+// working against an upgraded server. It is the response-side counterpart of
+// the casing filter: on legacy routes field names are rewritten back to
+// snake_case and status codes this API introduced are mapped to the codes
+// the legacy API used. Endpoints added since the legacy API (sub-resource
+// actions and new top-level resources) are untouched, they never had legacy
+// clients. The shim is enabled through legacy_compat_mode.
+package compat
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/astaxie/beego"
+	bctx "github.com/astaxie/beego/context"
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+// The data key under which the buffered response is kept on the request.
+const compatDataKey = "legacyCompat"
+
+// legacyCollections are the collections the original OpenSDS v1beta API
+// served, keyed by their path below the tenant segment. Requests addressing
+// a collection or one of its members get the legacy treatment; anything
+// nested deeper is a later addition and keeps the documented conventions.
+var legacyCollections = map[string]bool{
+	"block/volumes":      true,
+	"block/attachments":  true,
+	"block/snapshots":    true,
+	"block/replications": true,
+	"block/volumeGroups": true,
+	"file/shares":        true,
+	"file/snapshots":     true,
+	"file/acls":          true,
+	"profiles":           true,
+	"docks":              true,
+	"pools":              true,
+	"availabilityZones":  true,
+}
+
+// legacyStatus maps status codes this API introduced to the code the legacy
+// API used for the same situation. The body's code field is kept in step by
+// the rewrite.
+var legacyStatus = map[int]int{
+	// Locked fileshares reported 400 before the lock endpoints existed.
+	http.StatusLocked: http.StatusBadRequest,
+	// Oversized requests reported 400 before the opts size limit existed.
+	http.StatusRequestEntityTooLarge: http.StatusBadRequest,
+}
+
+// LegacyRoute reports whether path addresses a collection or member the
+// legacy API served, i.e. whether its response needs the legacy treatment.
+func LegacyRoute(path string) bool {
+	trimmed := strings.TrimPrefix(path, "/"+constants.APIVersion+"/")
+	if trimmed == path {
+		return false
+	}
+	segs := strings.Split(strings.Trim(trimmed, "/"), "/")
+	// Drop the tenant segment.
+	if len(segs) < 2 {
+		return false
+	}
+	segs = segs[1:]
+
+	width := 1
+	if segs[0] == "block" || segs[0] == "file" {
+		width = 2
+	}
+	if len(segs) < width || len(segs) > width+1 {
+		return false
+	}
+	return legacyCollections[strings.Join(segs[:width], "/")]
+}
+
+// snakeCase converts a camelCase key to its snake_case form, leaving keys
+// without upper case letters untouched. Keys inside metadata maps that the
+// user wrote in camelCase are rewritten too; the casing filter turns them
+// back on the way in, so legacy clients round-trip cleanly.
+func snakeCase(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// denormalize walks a decoded JSON document and renames camelCase object
+// keys to snake_case. It reports whether anything was renamed.
+func denormalize(doc interface{}) (interface{}, bool) {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		changed := false
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			nv, ch := denormalize(value)
+			changed = changed || ch
+			sk := snakeCase(key)
+			if sk != key {
+				changed = true
+				key = sk
+			}
+			out[key] = nv
+		}
+		return out, changed
+	case []interface{}:
+		changed := false
+		for i, item := range v {
+			nv, ch := denormalize(item)
+			changed = changed || ch
+			v[i] = nv
+		}
+		return v, changed
+	default:
+		return doc, false
+	}
+}
+
+// LegacyBody rewrites camelCase keys of a JSON body to snake_case and
+// reports whether the body was changed. Bodies that do not parse as JSON,
+// such as streamed ndjson lists, are returned untouched.
+func LegacyBody(body []byte) ([]byte, bool) {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body, false
+	}
+	doc, changed := denormalize(doc)
+	if !changed {
+		return body, false
+	}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return body, false
+	}
+	return out, true
+}
+
+// LegacyStatusCode maps a status code to the one the legacy API used, and
+// returns the input for codes both APIs share.
+func LegacyStatusCode(status int) int {
+	if legacy, ok := legacyStatus[status]; ok {
+		return legacy
+	}
+	return status
+}
+
+// responseBuffer holds back the response so the shim can rewrite it after
+// the handler finished. The status and body only reach the client when
+// flush runs.
+type responseBuffer struct {
+	http.ResponseWriter
+	status int
+	body   []byte
+}
+
+func (w *responseBuffer) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *responseBuffer) Write(b []byte) (int, error) {
+	w.body = append(w.body, b...)
+	return len(b), nil
+}
+
+func (w *responseBuffer) flush(status int, body []byte) {
+	if len(body) != 0 {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	w.ResponseWriter.WriteHeader(status)
+	if _, err := w.ResponseWriter.Write(body); err != nil {
+		log.Error("when writing the buffered response:", err)
+	}
+}
+
+// Start is inserted before the handler runs and swaps in the buffering
+// response writer, on legacy routes only.
+func Start() beego.FilterFunc {
+	return func(httpCtx *bctx.Context) {
+		if !LegacyRoute(httpCtx.Request.URL.Path) {
+			return
+		}
+		buffer := &responseBuffer{ResponseWriter: httpCtx.ResponseWriter.ResponseWriter, status: http.StatusOK}
+		httpCtx.ResponseWriter.ResponseWriter = buffer
+		httpCtx.Input.SetData(compatDataKey, buffer)
+	}
+}
+
+// Finish is inserted after the handler ran. It rewrites the buffered
+// response to the legacy conventions and releases it to the client.
+func Finish() beego.FilterFunc {
+	return func(httpCtx *bctx.Context) {
+		buffer, ok := httpCtx.Input.GetData(compatDataKey).(*responseBuffer)
+		if !ok {
+			return
+		}
+		status := LegacyStatusCode(buffer.status)
+		body, _ := LegacyBody(buffer.body)
+		if status != buffer.status {
+			// Keep the code field of the error body in step with the
+			// mapped status line.
+			body = rewriteErrorCode(body, status)
+		}
+		buffer.flush(status, body)
+	}
+}
+
+// rewriteErrorCode sets the code field of an error body to the mapped
+// status, so body and status line keep telling the same story.
+func rewriteErrorCode(body []byte, status int) []byte {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body
+	}
+	if _, ok := doc["code"]; !ok {
+		return body
+	}
+	doc["code"] = status
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return body
+	}
+	return out
+}
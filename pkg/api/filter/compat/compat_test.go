@@ -0,0 +1,131 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compat
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"availabilityZone":         "availability_zone",
+		"profileId":                "profile_id",
+		"name":                     "name",
+		"already_snake":            "already_snake",
+		"maxOverSubscriptionRatio": "max_over_subscription_ratio",
+	}
+	for in, expected := range cases {
+		if got := snakeCase(in); got != expected {
+			t.Errorf("snakeCase(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestLegacyRoute(t *testing.T) {
+	cases := map[string]bool{
+		"/v1beta/e93b4c09/block/volumes":                 true,
+		"/v1beta/e93b4c09/block/volumes/bd5b12a8":        true,
+		"/v1beta/e93b4c09/block/volumes/bd5b12a8/action": false,
+		"/v1beta/e93b4c09/block/volumes/bd5b12a8/resize": false,
+		"/v1beta/e93b4c09/file/shares":                   true,
+		"/v1beta/e93b4c09/file/shares/d2975ebe/lock":     false,
+		"/v1beta/e93b4c09/profiles":                      true,
+		"/v1beta/e93b4c09/profiles/1106b972":             true,
+		"/v1beta/e93b4c09/pools":                         true,
+		"/v1beta/e93b4c09/scopedTokens":                  false,
+		"/v1beta/e93b4c09/file/backupTargets":            false,
+		"/v1beta":                                        false,
+		"/healthz":                                       false,
+	}
+	for path, expected := range cases {
+		if got := LegacyRoute(path); got != expected {
+			t.Errorf("LegacyRoute(%q) = %v, expected %v", path, got, expected)
+		}
+	}
+}
+
+func TestLegacyBody(t *testing.T) {
+	t.Run("Camel case keys should be rewritten recursively", func(t *testing.T) {
+		body := []byte(`{"availabilityZone":"az-a","ioConnectivity":{"accessProtocol":"nfs"},"protocols":["NFS"]}`)
+		out, changed := LegacyBody(body)
+		if !changed {
+			t.Fatal("expected the body to be reported as changed")
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(out, &got); err != nil {
+			t.Fatalf("rewritten body does not parse: %v", err)
+		}
+		expected := map[string]interface{}{
+			"availability_zone": "az-a",
+			"io_connectivity": map[string]interface{}{
+				"access_protocol": "nfs",
+			},
+			"protocols": []interface{}{"NFS"},
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("Snake case bodies should pass through untouched", func(t *testing.T) {
+		body := []byte(`{"availability_zone":"az-a","size":10}`)
+		out, changed := LegacyBody(body)
+		if changed {
+			t.Error("expected the body to be reported as unchanged")
+		}
+		if string(out) != string(body) {
+			t.Errorf("expected the body to be returned as is, got %s", out)
+		}
+	})
+
+	t.Run("Bodies that are not json should pass through", func(t *testing.T) {
+		body := []byte("not json at all")
+		out, changed := LegacyBody(body)
+		if changed || string(out) != string(body) {
+			t.Errorf("expected the body to be returned as is, got %s", out)
+		}
+	})
+}
+
+func TestLegacyStatusCode(t *testing.T) {
+	cases := map[int]int{
+		http.StatusLocked:                http.StatusBadRequest,
+		http.StatusRequestEntityTooLarge: http.StatusBadRequest,
+		http.StatusOK:                    http.StatusOK,
+		http.StatusAccepted:              http.StatusAccepted,
+		http.StatusNotFound:              http.StatusNotFound,
+		http.StatusServiceUnavailable:    http.StatusServiceUnavailable,
+	}
+	for in, expected := range cases {
+		if got := LegacyStatusCode(in); got != expected {
+			t.Errorf("LegacyStatusCode(%d) = %d, expected %d", in, got, expected)
+		}
+	}
+}
+
+func TestRewriteErrorCode(t *testing.T) {
+	body := []byte(`{"code":423,"message":"fileshare is locked"}`)
+	out := rewriteErrorCode(body, http.StatusBadRequest)
+	var got map[string]interface{}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("rewritten body does not parse: %v", err)
+	}
+	if got["code"] != float64(http.StatusBadRequest) {
+		t.Errorf("expected the code field to follow the mapped status, got %v", got["code"])
+	}
+}
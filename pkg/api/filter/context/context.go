@@ -15,15 +15,38 @@
 package context
 
 import (
+	"time"
+
 	"github.com/astaxie/beego"
 	bctx "github.com/astaxie/beego/context"
+	uuid "github.com/satori/go.uuid"
+	"github.com/sodafoundation/api/pkg/api/filter/remoteip"
 	c "github.com/sodafoundation/api/pkg/context"
 )
 
 func Factory() beego.FilterFunc {
 	return func(httpCtx *bctx.Context) {
+		// Correlation id of the request: the one the client sent is kept,
+		// otherwise one is minted. It is echoed in the X-Request-Id header
+		// and in the response envelope when the client asks for one.
+		requestId := httpCtx.Input.Header("X-Request-Id")
+		if requestId == "" {
+			requestId = uuid.NewV4().String()
+		}
+		httpCtx.Output.Header("X-Request-Id", requestId)
+		httpCtx.Input.SetData(c.RequestStartKey, time.Now())
 		c.UpdateContext(httpCtx, map[string]interface{}{
 			"Uri": httpCtx.Input.URI(),
+			// Queries stay scoped to the caller's tenant unless an admin
+			// explicitly asks for all tenants.
+			"AllTenants": httpCtx.Input.Query("allTenants") == "true",
+			"RequestId":  requestId,
+			// Who sent the request, recorded in the system metadata
+			// section of resources it creates. The address comes from the
+			// socket, so the audit trail can not be forged with forwarded
+			// headers.
+			"SourceIp":  remoteip.FromRequest(httpCtx.Request),
+			"UserAgent": httpCtx.Input.Header("User-Agent"),
 		})
 	}
 }
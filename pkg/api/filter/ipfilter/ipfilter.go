@@ -0,0 +1,102 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipfilter rejects mutating requests coming from addresses outside
+// the network policy of the tenant. The policy is a per tenant resource with
+// an allow list and a deny list of CIDRs; the deny list wins. Rejections are
+// written to the log as audit entries.
+package ipfilter
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/filter/remoteip"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+)
+
+// matchCidr returns the first CIDR of the list containing the ip, or an
+// empty string when none does. Entries that do not parse are skipped, they
+// are rejected when the policy is written.
+func matchCidr(ip net.IP, cidrs []string) string {
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(ip) {
+			return cidr
+		}
+	}
+	return ""
+}
+
+func Factory() beego.FilterFunc {
+	return func(httpCtx *context.Context) {
+		switch httpCtx.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			return
+		}
+		ctx := c.GetContext(httpCtx)
+		// Administrators are never locked out, otherwise a broken policy
+		// could not be repaired any more.
+		if ctx.IsAdmin || ctx.TenantId == "" {
+			return
+		}
+
+		adminCtx := c.NewAdminContext()
+		adminCtx.TenantId = ctx.TenantId
+		policies, err := db.C.ListNetworkPolicies(adminCtx)
+		if err != nil {
+			// Reads are never filtered, so when the policy can not be read
+			// the request is let through rather than taking every mutating
+			// endpoint down with the database.
+			log.Error("when listing network policies for ip filtering:", err)
+			return
+		}
+		if len(policies) == 0 {
+			return
+		}
+
+		// The address comes from the socket, not from spoofable forwarded
+		// headers, unless the request arrived through a trusted proxy.
+		remote := remoteip.FromRequest(httpCtx.Request)
+		ip := net.ParseIP(remote)
+		if ip == nil {
+			log.Errorf("audit: rejected %s %s for tenant %s, remote address %s can not be parsed",
+				httpCtx.Request.Method, httpCtx.Request.URL.Path, ctx.TenantId, remote)
+			c.HttpError(httpCtx, http.StatusForbidden, "request address can not be verified against the network policy")
+			return
+		}
+
+		np := policies[0]
+		if cidr := matchCidr(ip, np.DeniedCidrs); cidr != "" {
+			log.Warningf("audit: rejected %s %s for tenant %s, address %s matches denied CIDR %s of network policy %s",
+				httpCtx.Request.Method, httpCtx.Request.URL.Path, ctx.TenantId, ip, cidr, np.Id)
+			c.HttpError(httpCtx, http.StatusForbidden, "address %s is denied by the network policy of tenant %s", ip, ctx.TenantId)
+			return
+		}
+		if len(np.AllowedCidrs) > 0 && matchCidr(ip, np.AllowedCidrs) == "" {
+			log.Warningf("audit: rejected %s %s for tenant %s, address %s matches no allowed CIDR of network policy %s",
+				httpCtx.Request.Method, httpCtx.Request.URL.Path, ctx.TenantId, ip, np.Id)
+			c.HttpError(httpCtx, http.StatusForbidden, "address %s is not allowed by the network policy of tenant %s", ip, ctx.TenantId)
+			return
+		}
+	}
+}
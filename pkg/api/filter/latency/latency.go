@@ -0,0 +1,176 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Middleware that enforces a latency budget on every request. A request
+// that finishes over its budget emits one structured slow-request log line
+// with a breakdown of the instrumented phases (auth, dispatch,
+// serialization), so a performance regression points at the guilty layer
+// instead of just at a slow endpoint. Components between the filters can
+// contribute their own phases through RecordPhase; time nothing accounted
+// for shows up inside the dispatch phase.
+
+package latency
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+	bctx "github.com/astaxie/beego/context"
+	log "github.com/golang/glog"
+)
+
+// The data key under which the in-flight tracker is kept on the request.
+const trackerKey = "latencyTracker"
+
+type phase struct {
+	name string
+	d    time.Duration
+}
+
+// tracker accumulates the timing of one request. Phases are kept in the
+// order they were recorded.
+type tracker struct {
+	lock          sync.Mutex
+	start         time.Time
+	dispatchStart time.Time
+	phases        []phase
+}
+
+// RecordPhase attributes d to the named phase of the current request. It is
+// a no-op when slow-request logging is disabled.
+func RecordPhase(httpCtx *bctx.Context, name string, d time.Duration) {
+	t, ok := httpCtx.Input.GetData(trackerKey).(*tracker)
+	if !ok {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.phases = append(t.phases, phase{name: name, d: d})
+}
+
+// Phase wraps a filter so the time it spends is recorded under the given
+// phase name.
+func Phase(name string, f beego.FilterFunc) beego.FilterFunc {
+	return func(httpCtx *bctx.Context) {
+		start := time.Now()
+		f(httpCtx)
+		RecordPhase(httpCtx, name, time.Since(start))
+	}
+}
+
+// routeBudget overrides the default budget for every path below a prefix.
+type routeBudget struct {
+	prefix string
+	budget time.Duration
+}
+
+// Budgets holds the default latency budget and the per-route overrides.
+type Budgets struct {
+	def    time.Duration
+	routes []routeBudget
+}
+
+// ParseBudgets builds the budget table from configuration. Overrides are
+// given as a comma separated list of prefix=duration pairs, for example
+// "/v1beta/file=2s,/v1beta/block/volumes=500ms". The most specific prefix
+// wins; paths without an override use the default budget.
+func ParseBudgets(def time.Duration, routes string) (*Budgets, error) {
+	b := &Budgets{def: def}
+	for _, entry := range strings.Split(routes, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("malformed latency route budget %q, want prefix=duration", entry)
+		}
+		d, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("malformed latency route budget %q: %v", entry, err)
+		}
+		b.routes = append(b.routes, routeBudget{prefix: parts[0], budget: d})
+	}
+	sort.Slice(b.routes, func(i, j int) bool {
+		return len(b.routes[i].prefix) > len(b.routes[j].prefix)
+	})
+	return b, nil
+}
+
+// Enabled reports whether any budget is configured at all.
+func (b *Budgets) Enabled() bool {
+	return b.def > 0 || len(b.routes) > 0
+}
+
+// forPath returns the budget for a request path, zero meaning unlimited.
+func (b *Budgets) forPath(path string) time.Duration {
+	for _, r := range b.routes {
+		if strings.HasPrefix(path, r.prefix) {
+			return r.budget
+		}
+	}
+	return b.def
+}
+
+// Start is inserted before every other filter and notes the arrival time.
+func Start() beego.FilterFunc {
+	return func(httpCtx *bctx.Context) {
+		httpCtx.Input.SetData(trackerKey, &tracker{start: time.Now()})
+	}
+}
+
+// MarkDispatch is inserted after the last regular filter, so the time from
+// here to the end of the request counts as controller dispatch.
+func MarkDispatch() beego.FilterFunc {
+	return func(httpCtx *bctx.Context) {
+		if t, ok := httpCtx.Input.GetData(trackerKey).(*tracker); ok {
+			t.dispatchStart = time.Now()
+		}
+	}
+}
+
+// Finish is inserted after the response was sent. It compares the total
+// elapsed time against the budget of the route and logs the breakdown when
+// the request was over budget.
+func (b *Budgets) Finish() func(httpCtx *bctx.Context) {
+	return func(httpCtx *bctx.Context) {
+		t, ok := httpCtx.Input.GetData(trackerKey).(*tracker)
+		if !ok {
+			return
+		}
+		budget := b.forPath(httpCtx.Request.URL.Path)
+		total := time.Since(t.start)
+		if budget <= 0 || total <= budget {
+			return
+		}
+		if !t.dispatchStart.IsZero() {
+			t.lock.Lock()
+			t.phases = append(t.phases, phase{name: "dispatch", d: time.Since(t.dispatchStart)})
+			t.lock.Unlock()
+		}
+		var breakdown []string
+		t.lock.Lock()
+		for _, p := range t.phases {
+			breakdown = append(breakdown, fmt.Sprintf("%s=%v", p.name, p.d))
+		}
+		t.lock.Unlock()
+		log.Warningf("slow request: %s %s took %v, budget %v, %s",
+			httpCtx.Request.Method, httpCtx.Request.URL.Path, total, budget,
+			strings.Join(breakdown, " "))
+	}
+}
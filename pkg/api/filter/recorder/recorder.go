@@ -0,0 +1,189 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Debug middleware that records anonymized request/response pairs to disk,
+// so production traffic patterns can be replayed as regression or load
+// tests with the replay helper in testutils.
+
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	bctx "github.com/astaxie/beego/context"
+	log "github.com/golang/glog"
+)
+
+// The data key under which the in-flight recording is kept on the request.
+const recordingDataKey = "trafficRecording"
+
+// Body fields that are blanked before an entry is written to disk, so
+// recordings never contain credentials.
+var sensitiveBodyFields = []string{
+	"secret", "hashedSecret", "authToken", "password", "auth_token", "chap_password",
+}
+
+// Entry is one recorded request/response pair. Entries are written as one
+// JSON object per line and replayed in file order.
+type Entry struct {
+	// The time the request arrived.
+	Time string `json:"time"`
+
+	// How long the handler took, in milliseconds.
+	DurationMs int64 `json:"durationMs"`
+
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+
+	// The anonymized request and response bodies. Bodies that are not
+	// JSON are dropped rather than risk recording something sensitive.
+	RequestBody  json.RawMessage `json:"requestBody,omitempty"`
+	ResponseBody json.RawMessage `json:"responseBody,omitempty"`
+
+	Status int `json:"status"`
+}
+
+// Recorder appends one entry per handled request to a recording file.
+type Recorder struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// New opens a new recording file in dir, named after the start time.
+func New(dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("traffic-%s.jsonl", time.Now().Format("20060102-150405"))
+	file, err := os.OpenFile(path.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("recording api traffic to %s", file.Name())
+	return &Recorder{file: file}, nil
+}
+
+// recording carries the in-flight state between the two filter hooks.
+type recording struct {
+	start  time.Time
+	writer *responseRecorder
+}
+
+// responseRecorder tees the response so the status and body can be
+// recorded after the handler finished.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   []byte
+}
+
+func (w *responseRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body = append(w.body, b...)
+	return w.ResponseWriter.Write(b)
+}
+
+// Start is inserted before the handler runs. It notes the arrival time and
+// swaps in the teeing response writer.
+func (r *Recorder) Start(httpCtx *bctx.Context) {
+	writer := &responseRecorder{ResponseWriter: httpCtx.ResponseWriter.ResponseWriter, status: http.StatusOK}
+	httpCtx.ResponseWriter.ResponseWriter = writer
+	httpCtx.Input.SetData(recordingDataKey, &recording{start: time.Now(), writer: writer})
+}
+
+// Finish is inserted after the response was sent and appends the entry.
+func (r *Recorder) Finish(httpCtx *bctx.Context) {
+	rec, ok := httpCtx.Input.GetData(recordingDataKey).(*recording)
+	if !ok {
+		return
+	}
+	entry := &Entry{
+		Time:         rec.start.Format(time.RFC3339),
+		DurationMs:   time.Since(rec.start).Milliseconds(),
+		Method:       httpCtx.Request.Method,
+		Path:         httpCtx.Request.URL.Path,
+		Query:        httpCtx.Request.URL.RawQuery,
+		RequestBody:  anonymizeBody(httpCtx.Input.RequestBody),
+		ResponseBody: anonymizeBody(rec.writer.body),
+		Status:       rec.writer.status,
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Error("when marshaling traffic recording entry:", err)
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		log.Error("when writing traffic recording entry:", err)
+	}
+}
+
+// anonymizeBody blanks the sensitive fields of a JSON body, at any nesting
+// depth. Non-JSON bodies are dropped.
+func anonymizeBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil
+	}
+	scrubbed, err := json.Marshal(scrub(parsed))
+	if err != nil {
+		return nil
+	}
+	return scrubbed
+}
+
+func scrub(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSensitiveField(key) {
+				v[key] = "***"
+				continue
+			}
+			v[key] = scrub(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = scrub(value)
+		}
+		return v
+	default:
+		return in
+	}
+}
+
+func isSensitiveField(key string) bool {
+	for _, field := range sensitiveBodyFields {
+		if key == field {
+			return true
+		}
+	}
+	return false
+}
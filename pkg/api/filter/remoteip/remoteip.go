@@ -0,0 +1,76 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remoteip derives the client address of a request from the socket
+// it arrived on. Forwarded headers like X-Forwarded-For are client
+// controlled and trivially spoofed, so they are only honored when the
+// directly connected peer is one of the trusted proxies configured with
+// trusted_proxies. Everything enforcing or recording a client address
+// should go through this package instead of beego's Input.IP().
+package remoteip
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// isTrustedProxy tells whether the address belongs to one of the configured
+// trusted proxies. Entries may be plain addresses or CIDRs.
+func isTrustedProxy(ip net.IP) bool {
+	for _, proxy := range CONF.OsdsApiServer.TrustedProxies {
+		if _, ipNet, err := net.ParseCIDR(proxy); err == nil {
+			if ipNet.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if parsed := net.ParseIP(strings.TrimSpace(proxy)); parsed != nil && parsed.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromRequest returns the client address of the request. It starts from the
+// socket address and only follows the X-Forwarded-For chain, right to left,
+// while the hops are trusted proxies; the first untrusted hop is the client.
+func FromRequest(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	remote := net.ParseIP(strings.TrimSpace(host))
+	if remote == nil {
+		return host
+	}
+	if !isTrustedProxy(remote) {
+		return remote.String()
+	}
+
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		if !isTrustedProxy(hop) {
+			return hop.String()
+		}
+		remote = hop
+	}
+	return remote.String()
+}
@@ -0,0 +1,81 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteip
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+func TestFromRequest(t *testing.T) {
+	defer func() { CONF.OsdsApiServer.TrustedProxies = nil }()
+
+	cases := []struct {
+		name       string
+		proxies    []string
+		remoteAddr string
+		forwarded  string
+		expected   string
+	}{
+		{
+			name:       "a forwarded header from an untrusted peer is ignored",
+			proxies:    nil,
+			remoteAddr: "203.0.113.7:42312",
+			forwarded:  "192.0.2.10",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "a trusted proxy may forward the client address",
+			proxies:    []string{"10.0.0.1"},
+			remoteAddr: "10.0.0.1:42312",
+			forwarded:  "192.0.2.10",
+			expected:   "192.0.2.10",
+		},
+		{
+			name:       "only the hop added by the proxy is trusted, not the client's own entries",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:42312",
+			forwarded:  "192.0.2.99, 192.0.2.10",
+			expected:   "192.0.2.10",
+		},
+		{
+			name:       "a chain of trusted proxies is walked to the client",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:42312",
+			forwarded:  "192.0.2.10, 10.0.0.2",
+			expected:   "192.0.2.10",
+		},
+		{
+			name:       "a trusted proxy without forwarded header stays the address",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:42312",
+			forwarded:  "",
+			expected:   "10.0.0.1",
+		},
+	}
+	for _, tc := range cases {
+		CONF.OsdsApiServer.TrustedProxies = tc.proxies
+		r, _ := http.NewRequest("POST", "/v1beta/block/volumes", nil)
+		r.RemoteAddr = tc.remoteAddr
+		if tc.forwarded != "" {
+			r.Header.Set("X-Forwarded-For", tc.forwarded)
+		}
+		if got := FromRequest(r); got != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expected, got)
+		}
+	}
+}
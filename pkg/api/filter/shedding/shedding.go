@@ -0,0 +1,113 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Middleware that sheds load when the database falls behind. The db
+// drivers report the latency and queue depth of their operations to the
+// health package; once either crosses its configured threshold the
+// lowest-priority requests - reads, typically issued by dashboards and
+// pollers - are rejected with 503 and a Retry-After hint, keeping the
+// remaining db capacity for mutations. Which read routes are sheddable
+// can be narrowed to route prefixes; mutations are never shed.
+package shedding
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego"
+	bctx "github.com/astaxie/beego/context"
+	log "github.com/golang/glog"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db/health"
+)
+
+// Shedder holds the overload thresholds and the route prefixes of the
+// reads that may be shed.
+type Shedder struct {
+	latency time.Duration
+	depth   int
+	routes  []string
+}
+
+// ParseShedder builds the shedder from configuration. The routes are a
+// comma separated list of path prefixes, for example
+// "/v1beta/block/volumes,/v1beta/pools"; an empty list makes every read
+// sheddable. A zero threshold disables that signal.
+func ParseShedder(latency time.Duration, depth int, routes string) *Shedder {
+	s := &Shedder{latency: latency, depth: depth}
+	for _, entry := range strings.Split(routes, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		s.routes = append(s.routes, entry)
+	}
+	return s
+}
+
+// Enabled reports whether any threshold is configured at all.
+func (s *Shedder) Enabled() bool {
+	return s.latency > 0 || s.depth > 0
+}
+
+// sheddable reports whether a request is low-priority enough to reject
+// under overload. Only reads qualify, and only on the configured route
+// prefixes when any are given.
+func (s *Shedder) sheddable(method, path string) bool {
+	if method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+	if len(s.routes) == 0 {
+		return true
+	}
+	for _, prefix := range s.routes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// overloaded reports whether a threshold is crossed, and which one.
+func (s *Shedder) overloaded() (bool, string) {
+	if s.latency > 0 {
+		if observed := health.AvgLatency(); observed > s.latency {
+			return true, "db latency " + observed.String() + " is over " + s.latency.String()
+		}
+	}
+	if s.depth > 0 {
+		if observed := health.QueueDepth(); observed > s.depth {
+			return true, "db queue depth is over the threshold"
+		}
+	}
+	return false, ""
+}
+
+// Filter rejects sheddable requests with 503 while the db is overloaded.
+func (s *Shedder) Filter() beego.FilterFunc {
+	return func(httpCtx *bctx.Context) {
+		if !s.sheddable(httpCtx.Request.Method, httpCtx.Request.URL.Path) {
+			return
+		}
+		over, reason := s.overloaded()
+		if !over {
+			return
+		}
+		log.Warningf("shedding %s %s: %s", httpCtx.Request.Method, httpCtx.Request.URL.Path, reason)
+		httpCtx.Output.Header("Retry-After", "1")
+		c.HttpError(httpCtx, http.StatusServiceUnavailable,
+			"the server is overloaded, low-priority requests are temporarily rejected")
+	}
+}
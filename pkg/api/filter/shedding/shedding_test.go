@@ -0,0 +1,71 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shedding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sodafoundation/api/pkg/db/health"
+)
+
+func TestSheddable(t *testing.T) {
+	s := ParseShedder(time.Second, 0, "/v1beta/block/volumes, /v1beta/pools")
+
+	cases := []struct {
+		method, path string
+		expected     bool
+	}{
+		{"GET", "/v1beta/block/volumes", true},
+		{"GET", "/v1beta/pools/084bf71e-a102-11e7-88a8-e31fe6d52248", true},
+		{"GET", "/v1beta/file/shares", false},
+		{"POST", "/v1beta/block/volumes", false},
+		{"DELETE", "/v1beta/pools/084bf71e-a102-11e7-88a8-e31fe6d52248", false},
+	}
+	for _, c := range cases {
+		if got := s.sheddable(c.method, c.path); got != c.expected {
+			t.Errorf("sheddable(%s %s): expected %v, got %v", c.method, c.path, c.expected, got)
+		}
+	}
+
+	all := ParseShedder(time.Second, 0, "")
+	if !all.sheddable("GET", "/v1beta/file/shares") {
+		t.Error("an empty route list should make every read sheddable")
+	}
+}
+
+func TestOverloaded(t *testing.T) {
+	s := ParseShedder(50*time.Millisecond, 0, "")
+
+	health.Observe(10*time.Millisecond, 0)
+	if over, _ := s.overloaded(); over {
+		t.Error("a fast db should not be considered overloaded")
+	}
+
+	// Push the moving average over the threshold.
+	for i := 0; i < 256; i++ {
+		health.Observe(200*time.Millisecond, 1)
+	}
+	if over, _ := s.overloaded(); !over {
+		t.Error("a slow db should be considered overloaded")
+	}
+
+	depth := ParseShedder(0, 2, "")
+	health.Observe(time.Millisecond, 5)
+	if over, _ := depth.overloaded(); !over {
+		t.Error("a deep db queue should be considered overloaded")
+	}
+	health.Observe(time.Millisecond, 0)
+}
@@ -0,0 +1,212 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Middleware that bounds how long a handler may run. A handler over its
+// limit gets its request context canceled, which stops the db operations
+// started for the request, and the caller receives 503 with a Retry-After
+// hint instead of a connection that hangs for minutes on a slow db or
+// controller. Limits are per route prefix, like the latency budgets.
+
+package timeout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+// routeTimeout overrides the default limit for every path below a prefix.
+type routeTimeout struct {
+	prefix  string
+	timeout time.Duration
+}
+
+// Timeouts holds the default handler timeout and the per-route overrides.
+type Timeouts struct {
+	def    time.Duration
+	routes []routeTimeout
+}
+
+// ParseTimeouts builds the timeout table from configuration. Overrides are
+// given as a comma separated list of prefix=duration pairs, for example
+// "/v1beta/file=30s,/v1beta/block/volumes=10s". The most specific prefix
+// wins; paths without an override use the default timeout.
+func ParseTimeouts(def time.Duration, routes string) (*Timeouts, error) {
+	t := &Timeouts{def: def}
+	for _, entry := range strings.Split(routes, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("malformed handler route timeout %q, want prefix=duration", entry)
+		}
+		d, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("malformed handler route timeout %q: %v", entry, err)
+		}
+		t.routes = append(t.routes, routeTimeout{prefix: parts[0], timeout: d})
+	}
+	sort.Slice(t.routes, func(i, j int) bool {
+		return len(t.routes[i].prefix) > len(t.routes[j].prefix)
+	})
+	return t, nil
+}
+
+// Enabled reports whether any timeout is configured at all.
+func (t *Timeouts) Enabled() bool {
+	return t.def > 0 || len(t.routes) > 0
+}
+
+// forPath returns the timeout for a request path, zero meaning unlimited.
+func (t *Timeouts) forPath(path string) time.Duration {
+	for _, r := range t.routes {
+		if strings.HasPrefix(path, r.prefix) {
+			return r.timeout
+		}
+	}
+	return t.def
+}
+
+// Middleware wraps the server handler. The wrapped handler runs in its own
+// goroutine against a buffered response writer; when it finishes in time
+// the buffered response is released, otherwise the request context is
+// canceled and the caller gets 503. The handler goroutine keeps running
+// until it observes the cancellation, its late writes are discarded.
+func (t *Timeouts) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := t.forPath(r.URL.Path)
+		if limit <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), limit)
+		defer cancel()
+		r = r.WithContext(ctx)
+
+		tw := &timeoutWriter{header: http.Header{}}
+		done := make(chan struct{})
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					log.Errorf("handler of %s %s crashed: %v", r.Method, r.URL.Path, p)
+					tw.abandoned(http.StatusInternalServerError,
+						model.ErrorInternalServerStatus("the handler crashed"))
+				}
+				close(done)
+			}()
+			next.ServeHTTP(tw, r)
+		}()
+
+		select {
+		case <-done:
+			tw.release(w)
+		case <-ctx.Done():
+			tw.markTimedOut()
+			log.Warningf("handler of %s %s did not finish within %v, returning 503",
+				r.Method, r.URL.Path, limit)
+			retryAfter := int(math.Ceil(limit.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			body, _ := json.Marshal(&model.ErrorSpec{
+				Code: http.StatusServiceUnavailable,
+				Message: fmt.Sprintf(
+					"the request did not finish within %v, retry after %d seconds", limit, retryAfter),
+			})
+			w.Write(body)
+		}
+	})
+}
+
+// timeoutWriter buffers everything the handler writes, so nothing reaches
+// the caller before it is known whether the handler beat its timeout.
+type timeoutWriter struct {
+	lock     sync.Mutex
+	header   http.Header
+	code     int
+	body     []byte
+	timedOut bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.code == 0 {
+		w.code = code
+	}
+}
+
+func (w *timeoutWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.timedOut {
+		// The 503 already went out, late writes go nowhere.
+		return 0, http.ErrHandlerTimeout
+	}
+	w.body = append(w.body, p...)
+	return len(p), nil
+}
+
+// markTimedOut makes every later write of the handler fail.
+func (w *timeoutWriter) markTimedOut() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.timedOut = true
+}
+
+// abandoned replaces whatever the handler wrote so far with an error
+// response, used when the handler crashed.
+func (w *timeoutWriter) abandoned(code int, body []byte) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.code = code
+	w.body = body
+}
+
+// release copies the buffered response to the real writer.
+func (w *timeoutWriter) release(dst http.ResponseWriter) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for key, values := range w.header {
+		for _, value := range values {
+			dst.Header().Add(key, value)
+		}
+	}
+	code := w.code
+	if code == 0 {
+		code = http.StatusOK
+	}
+	dst.WriteHeader(code)
+	dst.Write(w.body)
+}
@@ -0,0 +1,290 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the runner for administrative background jobs. A job
+is submitted through the admin jobs endpoint with its type and parameters,
+runs in its own goroutine with the concurrency bounded by job_concurrency,
+and records its progress in the database so it can be listed, inspected and
+cancelled while it runs. Finished jobs stay behind as history until the
+reaper purges them after job_history_retention.
+*/
+
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	uuid "github.com/satori/go.uuid"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// RunFunc does the actual work of one job type. It is called in its own
+// goroutine and should check cancelled between units of work, returning
+// early once it reports true.
+type RunFunc func(ctx *c.Context, job *model.JobSpec, cancelled func() bool) error
+
+// registry maps the job type names accepted by the jobs endpoint to their
+// implementations.
+var registry = map[string]RunFunc{
+	"purgeTombstones": purgeTombstones,
+	"purgeTasks":      purgeTasks,
+	"reindexSearch":   reindexSearch,
+}
+
+// Register adds a job type to the registry. Job implementations living in
+// packages this one can not import, like the admin controllers, register
+// themselves through this hook at startup.
+func Register(jobType string, fn RunFunc) {
+	registry[jobType] = fn
+}
+
+// ErrTooManyJobs is returned by Submit when job_concurrency jobs are
+// already running.
+var ErrTooManyJobs = errors.New("too many jobs are running already")
+
+// runningJob is the in-process handle of a running job, kept only for
+// cancellation.
+type runningJob struct {
+	cancel     chan struct{}
+	cancelOnce sync.Once
+}
+
+var (
+	runningLock sync.Mutex
+	running     = map[string]*runningJob{}
+)
+
+// Supported tells whether the given job type is registered.
+func Supported(jobType string) bool {
+	_, ok := registry[jobType]
+	return ok
+}
+
+// SupportedTypes lists the registered job types, for error messages.
+func SupportedTypes() []string {
+	var types []string
+	for t := range registry {
+		types = append(types, t)
+	}
+	return types
+}
+
+// Submit persists the job and starts running it in the background. The
+// returned record already carries the running status, so the caller can
+// hand it straight back to the client with 202.
+func Submit(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	fn, ok := registry[job.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown job type %s", job.Type)
+	}
+	if job.BaseModel == nil {
+		job.BaseModel = &model.BaseModel{}
+	}
+	if job.Id == "" {
+		job.Id = uuid.NewV4().String()
+	}
+	job.Status = model.JobRunning
+	job.StartedAt = utils.FormatTime(time.Now())
+
+	// Reserve the slot before the job record exists, so two concurrent
+	// submissions can not both slip under the limit.
+	handle := &runningJob{cancel: make(chan struct{})}
+	runningLock.Lock()
+	if len(running) >= CONF.OsdsApiServer.JobConcurrency {
+		runningLock.Unlock()
+		return nil, ErrTooManyJobs
+	}
+	running[job.Id] = handle
+	runningLock.Unlock()
+
+	result, err := db.C.CreateJob(ctx, job)
+	if err != nil {
+		runningLock.Lock()
+		delete(running, job.Id)
+		runningLock.Unlock()
+		return nil, err
+	}
+
+	// The runner gets its own copy of the record, the returned one stays
+	// with the submitting request until it marshaled the 202 response.
+	go run(ctx, copyJob(result), fn, handle)
+	return result, nil
+}
+
+// copyJob clones a job record, so the runner goroutine never shares memory
+// with the record handed back to the submitter.
+func copyJob(job *model.JobSpec) *model.JobSpec {
+	jobCopy := *job
+	if job.BaseModel != nil {
+		base := *job.BaseModel
+		jobCopy.BaseModel = &base
+	}
+	if job.Params != nil {
+		jobCopy.Params = utils.MergeStringMaps(job.Params)
+	}
+	if job.Result != nil {
+		jobCopy.Result = utils.MergeStringMaps(job.Result)
+	}
+	return &jobCopy
+}
+
+// Cancel asks the running job to stop. The job keeps running until its
+// implementation observes the request, so it moves to cancelling first and
+// to cancelled once the runner winds down.
+func Cancel(ctx *c.Context, jobId string) (*model.JobSpec, error) {
+	runningLock.Lock()
+	handle, ok := running[jobId]
+	runningLock.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("job %s is not running", jobId)
+	}
+	handle.cancelOnce.Do(func() { close(handle.cancel) })
+	return db.C.UpdateJob(ctx, &model.JobSpec{
+		BaseModel: &model.BaseModel{Id: jobId},
+		Status:    model.JobCancelling,
+	})
+}
+
+// run drives one job to completion and records its outcome.
+func run(ctx *c.Context, job *model.JobSpec, fn RunFunc, handle *runningJob) {
+	cancelled := func() bool {
+		select {
+		case <-handle.cancel:
+			return true
+		default:
+			return false
+		}
+	}
+
+	err := fn(ctx, job, cancelled)
+
+	switch {
+	case cancelled():
+		job.Status = model.JobCancelled
+	case err != nil:
+		job.Status = model.JobFailed
+		job.ErrorMessage = err.Error()
+		log.Errorf("job %s of type %s failed: %v", job.Id, job.Type, err)
+	default:
+		job.Status = model.JobSucceeded
+	}
+	job.FinishedAt = utils.FormatTime(time.Now())
+
+	runningLock.Lock()
+	delete(running, job.Id)
+	runningLock.Unlock()
+
+	if _, err := db.C.UpdateJob(ctx, job); err != nil {
+		log.Errorf("failed to record the outcome of job %s: %v", job.Id, err)
+	}
+	log.Infof("event: job %s of type %s finished with status %s", job.Id, job.Type, job.Status)
+}
+
+// olderThanParam reads the optional olderThan duration parameter of a purge
+// job. Jobs without it purge every eligible record.
+func olderThanParam(job *model.JobSpec) (time.Duration, error) {
+	raw, ok := job.Params["olderThan"]
+	if !ok || raw == "" {
+		return 0, nil
+	}
+	olderThan, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid olderThan parameter %s: %v", raw, err)
+	}
+	return olderThan, nil
+}
+
+// purgeTombstones removes tombstone records older than the olderThan
+// parameter, regardless of the configured retention.
+func purgeTombstones(ctx *c.Context, job *model.JobSpec, cancelled func() bool) error {
+	olderThan, err := olderThanParam(job)
+	if err != nil {
+		return err
+	}
+	tombstones, err := db.C.ListTombstones(ctx)
+	if err != nil {
+		return err
+	}
+	var purged int
+	for _, tombstone := range tombstones {
+		if cancelled() {
+			break
+		}
+		deletedAt, err := utils.ParseTime(tombstone.DeletedAt)
+		if err == nil && time.Since(deletedAt) < olderThan {
+			continue
+		}
+		if err := db.C.DeleteTombstone(ctx, tombstone.Id); err != nil {
+			log.Errorf("job %s failed to delete tombstone %s: %v", job.Id, tombstone.Id, err)
+			continue
+		}
+		purged++
+	}
+	job.Result = map[string]string{"purged": strconv.Itoa(purged)}
+	return nil
+}
+
+// reindexSearch rebuilds the in-memory search indexes backing the ?q=
+// parameter of the list endpoints from the stored records.
+func reindexSearch(ctx *c.Context, job *model.JobSpec, cancelled func() bool) error {
+	indexed, err := db.C.RebuildSearchIndexes(ctx)
+	if err != nil {
+		return err
+	}
+	job.Result = map[string]string{"indexed": strconv.Itoa(indexed)}
+	return nil
+}
+
+// purgeTasks removes finished task records older than the olderThan
+// parameter, trimming the async operation history.
+func purgeTasks(ctx *c.Context, job *model.JobSpec, cancelled func() bool) error {
+	olderThan, err := olderThanParam(job)
+	if err != nil {
+		return err
+	}
+	tasks, err := db.C.ListTasks(ctx)
+	if err != nil {
+		return err
+	}
+	var purged int
+	for _, task := range tasks {
+		if cancelled() {
+			break
+		}
+		if task.Status != model.TaskSucceeded && task.Status != model.TaskFailed {
+			continue
+		}
+		createdAt, err := utils.ParseTime(task.CreatedAt)
+		if err == nil && time.Since(createdAt) < olderThan {
+			continue
+		}
+		if err := db.C.DeleteTask(ctx, task.Id); err != nil {
+			log.Errorf("job %s failed to delete task %s: %v", job.Id, task.Id, err)
+			continue
+		}
+		purged++
+	}
+	job.Result = map[string]string{"purged": strconv.Itoa(purged)}
+	return nil
+}
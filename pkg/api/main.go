@@ -21,14 +21,29 @@ package api
 import (
 	"crypto/tls"
 	"fmt"
+	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/astaxie/beego"
+	log "github.com/golang/glog"
+	"github.com/sodafoundation/api/pkg/api/consistency"
+	"github.com/sodafoundation/api/pkg/api/controllers"
 	"github.com/sodafoundation/api/pkg/api/filter/accesslog"
 	"github.com/sodafoundation/api/pkg/api/filter/auth"
+	"github.com/sodafoundation/api/pkg/api/filter/casing"
+	"github.com/sodafoundation/api/pkg/api/filter/compat"
 	"github.com/sodafoundation/api/pkg/api/filter/context"
+	"github.com/sodafoundation/api/pkg/api/filter/ipfilter"
+	"github.com/sodafoundation/api/pkg/api/filter/latency"
+	"github.com/sodafoundation/api/pkg/api/filter/recorder"
+	"github.com/sodafoundation/api/pkg/api/filter/shedding"
+	"github.com/sodafoundation/api/pkg/api/filter/timeout"
 	"github.com/sodafoundation/api/pkg/api/filter/validation"
+	"github.com/sodafoundation/api/pkg/api/reaper"
+	"github.com/sodafoundation/api/pkg/api/watchdog"
 	cfg "github.com/sodafoundation/api/pkg/utils/config"
 	"github.com/sodafoundation/api/pkg/utils/constants"
 
@@ -73,13 +88,93 @@ func Run(apiServerCfg cfg.OsdsApiServer) {
 	beego.BConfig.EnableErrorsShow = false
 	beego.BConfig.EnableErrorsRender = false
 	beego.BConfig.WebConfig.AutoRender = false
+	// log requests that blow their latency budget, with a phase breakdown
+	budgets, err := latency.ParseBudgets(apiServerCfg.LatencyBudget, apiServerCfg.LatencyRouteBudgets)
+	if err != nil {
+		log.Error("when parsing latency route budgets:", err)
+		budgets, _ = latency.ParseBudgets(apiServerCfg.LatencyBudget, "")
+	}
+
 	// insert some auth rules
 	pattern := fmt.Sprintf("/%s/*", constants.APIVersion)
+	if budgets.Enabled() {
+		beego.InsertFilter("*", beego.BeforeExec, latency.Start())
+	}
+	// reject low-priority reads with 503 while the db is overloaded
+	shedder := shedding.ParseShedder(apiServerCfg.ShedDbLatencyThreshold,
+		apiServerCfg.ShedDbQueueDepthThreshold, apiServerCfg.ShedRoutes)
+	if shedder.Enabled() {
+		beego.InsertFilter(pattern, beego.BeforeExec, shedder.Filter())
+	}
 	beego.InsertFilter(pattern, beego.BeforeExec, context.Factory())
-	beego.InsertFilter(pattern, beego.BeforeExec, auth.Factory())
+	beego.InsertFilter(pattern, beego.BeforeExec, latency.Phase("auth", auth.Factory()))
+	// reject mutating requests from addresses outside the tenant's network policy
+	beego.InsertFilter(pattern, beego.BeforeExec, ipfilter.Factory())
+	// rewrite legacy snake_case field names to camelCase while the
+	// deprecation window is open, so the validation below and the
+	// controllers only ever see one casing
+	if apiServerCfg.AcceptSnakeCaseFields {
+		beego.InsertFilter(pattern, beego.BeforeExec, casing.Factory())
+	}
+	// serve the legacy v1beta conventions on the routes the original API
+	// had, so old north-bound plugins survive the upgrade
+	if apiServerCfg.LegacyCompatMode {
+		beego.InsertFilter(pattern, beego.BeforeExec, compat.Start())
+		beego.InsertFilter(pattern, beego.FinishRouter, compat.Finish(), false)
+	}
 	beego.InsertFilter("*", beego.BeforeExec, accesslog.Factory())
 	beego.InsertFilter("*", beego.BeforeExec, validation.Factory(apiServerCfg.ApiSpecPath))
+	if budgets.Enabled() {
+		beego.InsertFilter("*", beego.BeforeExec, latency.MarkDispatch())
+		beego.InsertFilter("*", beego.FinishRouter, budgets.Finish(), false)
+	}
+
+	// record anonymized traffic for replay testing when asked to
+	if apiServerCfg.RecordTraffic {
+		if rec, err := recorder.New(apiServerCfg.RecordTrafficDir); err != nil {
+			log.Error("when starting the traffic recorder:", err)
+		} else {
+			beego.InsertFilter("*", beego.BeforeExec, rec.Start)
+			beego.InsertFilter("*", beego.FinishRouter, rec.Finish, false)
+		}
+	}
+
+	// bound how long a handler may run, returning 503 over the limit
+	timeouts, err := timeout.ParseTimeouts(apiServerCfg.HandlerTimeout, apiServerCfg.HandlerRouteTimeouts)
+	if err != nil {
+		log.Error("when parsing handler route timeouts:", err)
+		timeouts, _ = timeout.ParseTimeouts(apiServerCfg.HandlerTimeout, "")
+	}
+
+	// start the reaper which deletes expired resources
+	go reaper.NewReaper(apiServerCfg.TtlReaperInterval).Run()
+
+	// start the watchdog which fails resources stuck in a transitional status
+	if apiServerCfg.StuckStatusTimeout > 0 {
+		go watchdog.NewWatchdog(apiServerCfg.StuckStatusCheckInterval, apiServerCfg.StuckStatusTimeout).Run()
+	}
+
+	// start the consistency checker which records db/backend discrepancies
+	if apiServerCfg.ConsistencyCheckInterval > 0 {
+		go consistency.NewChecker(apiServerCfg.ConsistencyCheckInterval).Run()
+	}
+
+	// reload configuration and policy rules on SIGHUP
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	go func() {
+		for range sigs {
+			log.Info("received SIGHUP, reloading server configuration")
+			if err := controllers.ReloadServerConfig(); err != nil {
+				log.Error("when reloading server configuration:", err)
+			}
+		}
+	}()
 
 	// start service
+	if timeouts.Enabled() {
+		beego.RunWithMiddleWares(apiServerCfg.ApiEndpoint, timeouts.Middleware)
+		return
+	}
 	beego.Run(apiServerCfg.ApiEndpoint)
 }
@@ -37,6 +37,12 @@ func init() {
 	enforcer.LoadRules(false)
 }
 
+// Reload forces the policy rules to be re-read from the policy file(s), so
+// rule changes take effect without a restart.
+func Reload() error {
+	return enforcer.LoadRules(true)
+}
+
 type DefaultRule struct {
 	Name     string
 	CheckStr string
@@ -185,10 +191,17 @@ func (r *Rules) String() string {
 }
 
 func Authorize(httpCtx *bctx.Context, action string) bool {
+	ctx := context.GetContext(httpCtx)
+	// A scoped token only authorizes the actions it was minted for,
+	// whatever the configured auth strategy is.
+	if len(ctx.TokenScope) > 0 && !utils.Contained(action, ctx.TokenScope) {
+		context.HttpError(httpCtx, http.StatusForbidden,
+			"action %s is not covered by the scoped token", action)
+		return false
+	}
 	if config.CONF.AuthStrategy != "keystone" {
 		return true
 	}
-	ctx := context.GetContext(httpCtx)
 	credentials := ctx.ToPolicyValue()
 	TenantId := httpCtx.Input.Param(":tenantId")
 
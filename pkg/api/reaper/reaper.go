@@ -0,0 +1,305 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the TTL reaper which deletes file shares, file
+share snapshots and file share acls whose expiresAt time has passed.
+Expired resources are removed through the normal deletion path, so the
+dock gets a chance to clean up the backend resource as well.
+*/
+
+package reaper
+
+import (
+	"context"
+	"time"
+
+	log "github.com/golang/glog"
+	client "github.com/sodafoundation/api/pkg/api/controllerclient"
+	"github.com/sodafoundation/api/pkg/api/util"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	pb "github.com/sodafoundation/api/pkg/model/proto"
+	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// Reaper periodically scans the database for expired file shares and file
+// share snapshots and drives their deletion.
+type Reaper struct {
+	CtrClient client.Client
+
+	interval time.Duration
+}
+
+func NewReaper(interval time.Duration) *Reaper {
+	return &Reaper{
+		CtrClient: client.NewClient(),
+		interval:  interval,
+	}
+}
+
+// Run starts the reap loop. It blocks, so callers usually run it in its own
+// goroutine.
+func (r *Reaper) Run() {
+	for {
+		time.Sleep(r.interval)
+		r.reapOnce()
+	}
+}
+
+// isExpired tells whether the given expiresAt time has passed. Resources
+// without expiresAt never expire.
+func isExpired(expiresAt string) bool {
+	if expiresAt == "" {
+		return false
+	}
+	t, err := utils.ParseTime(expiresAt)
+	if err != nil {
+		log.Errorf("can not parse expiresAt %s: %v", expiresAt, err)
+		return false
+	}
+	return time.Now().After(t)
+}
+
+func (r *Reaper) reapOnce() {
+	ctx := c.NewAdminContext()
+
+	// Snapshots are reaped before their shares, because a share can not be
+	// deleted while it still has snapshots.
+	snapshots, err := db.C.ListFileShareSnapshots(ctx)
+	if err != nil {
+		log.Error("reaper failed to list fileshare snapshots:", err)
+		return
+	}
+	for _, snapshot := range snapshots {
+		if isExpired(snapshot.ExpiresAt) && snapshot.Status == model.FileShareSnapAvailable {
+			r.reapFileShareSnapshot(ctx, snapshot)
+		}
+	}
+
+	// Acls are reaped before their shares, so a temporary access grant is
+	// revoked on the backend before its share can go away underneath it.
+	acls, err := db.C.ListFileSharesAcl(ctx)
+	if err != nil {
+		log.Error("reaper failed to list fileshare acls:", err)
+		return
+	}
+	for _, acl := range acls {
+		if isExpired(acl.ExpiresAt) && acl.Status == model.FileShareAclAvailable {
+			r.reapFileShareAcl(ctx, acl)
+		}
+	}
+
+	shares, err := db.C.ListFileShares(ctx)
+	if err != nil {
+		log.Error("reaper failed to list fileshares:", err)
+		return
+	}
+	for _, share := range shares {
+		if isExpired(share.ExpiresAt) && share.Status == model.FileShareAvailable {
+			r.reapFileShare(ctx, share)
+		}
+	}
+
+	r.purgeTombstones(ctx)
+	r.purgeJobs(ctx)
+}
+
+// purgeTombstones removes tombstone records of deleted resources once they
+// are older than the configured retention.
+func (r *Reaper) purgeTombstones(ctx *c.Context) {
+	retention := CONF.OsdsApiServer.TombstoneRetention
+	if retention <= 0 {
+		return
+	}
+	tombstones, err := db.C.ListTombstones(ctx)
+	if err != nil {
+		log.Error("reaper failed to list tombstones:", err)
+		return
+	}
+	for _, tombstone := range tombstones {
+		deletedAt, err := utils.ParseTime(tombstone.DeletedAt)
+		if err != nil {
+			log.Errorf("can not parse deletedAt %s of tombstone %s: %v",
+				tombstone.DeletedAt, tombstone.Id, err)
+			continue
+		}
+		if time.Since(deletedAt) < retention {
+			continue
+		}
+		if err := db.C.DeleteTombstone(ctx, tombstone.Id); err != nil {
+			log.Errorf("reaper failed to delete tombstone %s: %v", tombstone.Id, err)
+			continue
+		}
+		log.Infof("event: tombstone %s of %s %s outlived the retention, purged",
+			tombstone.Id, tombstone.ResourceType, tombstone.ResourceId)
+	}
+}
+
+// purgeJobs removes the records of finished admin jobs once they are older
+// than the configured history retention. Running jobs are never touched.
+func (r *Reaper) purgeJobs(ctx *c.Context) {
+	retention := CONF.OsdsApiServer.JobHistoryRetention
+	if retention <= 0 {
+		return
+	}
+	jobs, err := db.C.ListJobs(ctx)
+	if err != nil {
+		log.Error("reaper failed to list jobs:", err)
+		return
+	}
+	for _, job := range jobs {
+		if job.FinishedAt == "" {
+			continue
+		}
+		finishedAt, err := utils.ParseTime(job.FinishedAt)
+		if err != nil {
+			log.Errorf("can not parse finishedAt %s of job %s: %v",
+				job.FinishedAt, job.Id, err)
+			continue
+		}
+		if time.Since(finishedAt) < retention {
+			continue
+		}
+		if err := db.C.DeleteJob(ctx, job.Id); err != nil {
+			log.Errorf("reaper failed to delete job %s: %v", job.Id, err)
+			continue
+		}
+		log.Infof("event: job %s of type %s outlived the history retention, purged",
+			job.Id, job.Type)
+	}
+}
+
+func (r *Reaper) reapFileShare(ctx *c.Context, fileshare *model.FileShareSpec) {
+	tenantCtx := c.NewInternalTenantContext(fileshare.TenantId, fileshare.UserId)
+
+	prf, err := db.C.GetProfile(tenantCtx, fileshare.ProfileId)
+	if err != nil {
+		log.Errorf("reaper failed to get profile of expired fileshare %s: %v", fileshare.Id, err)
+		return
+	}
+	if err := util.DeleteFileShareDBEntry(tenantCtx, fileshare); err != nil {
+		log.Errorf("reaper failed to delete expired fileshare %s in db: %v", fileshare.Id, err)
+		return
+	}
+	log.Infof("event: fileshare %s of tenant %s expired at %s, deleting",
+		fileshare.Id, fileshare.TenantId, fileshare.ExpiresAt)
+
+	if err := r.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+	opt := &pb.DeleteFileShareOpts{
+		Id:              fileshare.Id,
+		PoolId:          fileshare.PoolId,
+		Metadata:        fileshare.Metadata,
+		Context:         tenantCtx.ToJson(),
+		Profile:         prf.ToJson(),
+		ExportLocations: fileshare.ExportLocations,
+	}
+	response, err := r.CtrClient.DeleteFileShare(context.Background(), opt)
+	if err != nil {
+		log.Errorf("delete expired fileshare %s failed in controller service: %v", fileshare.Id, err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to delete expired fileshare %s in controller, code: %v, message: %v",
+			fileshare.Id, errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+}
+
+func (r *Reaper) reapFileShareAcl(ctx *c.Context, acl *model.FileShareAclSpec) {
+	tenantCtx := c.NewInternalTenantContext(acl.TenantId, "")
+
+	fileshare, err := db.C.GetFileShare(tenantCtx, acl.FileShareId)
+	if err != nil {
+		log.Errorf("reaper failed to get fileshare of expired acl %s: %v", acl.Id, err)
+		return
+	}
+	prf, err := db.C.GetProfile(tenantCtx, fileshare.ProfileId)
+	if err != nil {
+		log.Errorf("reaper failed to get profile of expired fileshare acl %s: %v", acl.Id, err)
+		return
+	}
+	if err := util.DeleteFileShareAclDBEntry(tenantCtx, acl); err != nil {
+		log.Errorf("reaper failed to delete expired fileshare acl %s in db: %v", acl.Id, err)
+		return
+	}
+	log.Infof("event: fileshare acl %s of tenant %s granting %s access to fileshare %s expired at %s, revoking",
+		acl.Id, acl.TenantId, acl.AccessTo, acl.FileShareId, acl.ExpiresAt)
+
+	if err := r.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+	opt := &pb.DeleteFileShareAclOpts{
+		Id:               acl.Id,
+		FileshareId:      acl.FileShareId,
+		Description:      acl.Description,
+		Type:             acl.Type,
+		AccessCapability: acl.AccessCapability,
+		AccessTo:         acl.AccessTo,
+		Metadata:         utils.MergeStringMaps(fileshare.Metadata, acl.Metadata),
+		Context:          tenantCtx.ToJson(),
+		Profile:          prf.ToJson(),
+	}
+	response, err := r.CtrClient.DeleteFileShareAcl(context.Background(), opt)
+	if err != nil {
+		log.Errorf("delete expired fileshare acl %s failed in controller service: %v", acl.Id, err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to delete expired fileshare acl %s in controller, code: %v, message: %v",
+			acl.Id, errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+}
+
+func (r *Reaper) reapFileShareSnapshot(ctx *c.Context, snapshot *model.FileShareSnapshotSpec) {
+	tenantCtx := c.NewInternalTenantContext(snapshot.TenantId, snapshot.UserId)
+
+	prf, err := db.C.GetProfile(tenantCtx, snapshot.ProfileId)
+	if err != nil {
+		log.Errorf("reaper failed to get profile of expired fileshare snapshot %s: %v", snapshot.Id, err)
+		return
+	}
+	if err := util.DeleteFileShareSnapshotDBEntry(tenantCtx, snapshot); err != nil {
+		log.Errorf("reaper failed to delete expired fileshare snapshot %s in db: %v", snapshot.Id, err)
+		return
+	}
+	log.Infof("event: fileshare snapshot %s of tenant %s expired at %s, deleting",
+		snapshot.Id, snapshot.TenantId, snapshot.ExpiresAt)
+
+	if err := r.CtrClient.Connect(CONF.OsdsLet.ApiEndpoint); err != nil {
+		log.Error("when connecting controller client:", err)
+		return
+	}
+	opt := &pb.DeleteFileShareSnapshotOpts{
+		Id:          snapshot.Id,
+		FileshareId: snapshot.FileShareId,
+		Context:     tenantCtx.ToJson(),
+		Profile:     prf.ToJson(),
+		Metadata:    snapshot.Metadata,
+	}
+	response, err := r.CtrClient.DeleteFileShareSnapshot(context.Background(), opt)
+	if err != nil {
+		log.Errorf("delete expired fileshare snapshot %s failed in controller service: %v", snapshot.Id, err)
+		return
+	}
+	if errorMsg := response.GetError(); errorMsg != nil {
+		log.Errorf("failed to delete expired fileshare snapshot %s in controller, code: %v, message: %v",
+			snapshot.Id, errorMsg.GetCode(), errorMsg.GetDescription())
+	}
+}
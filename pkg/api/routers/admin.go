@@ -0,0 +1,46 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routers
+
+import (
+	"github.com/astaxie/beego"
+	"github.com/sodafoundation/api/pkg/api/controllers"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+func init() {
+
+	// add router for admin only api
+	adminns :=
+		beego.NewNamespace("/"+constants.APIVersion+"/admin",
+			// Reconcile fixes stale resource statuses in the database, admin only
+			beego.NSRouter("/reconcile", controllers.NewReconcilePortal(), "post:Reconcile"),
+			// Re-reads configuration and policy rules without a restart, admin only
+			beego.NSRouter("/reload-config", controllers.NewReloadPortal(), "post:ReloadConfig"),
+			// Proposes fileshare migrations that even out pool utilization, admin only
+			beego.NSRouter("/rebalance", controllers.NewRebalancePortal(), "post:Rebalance"),
+			// Serves the latest report of the consistency checker, admin only
+			beego.NSRouter("/consistency-report", controllers.NewConsistencyPortal(), "get:GetConsistencyReport"),
+			// Serves the corrupt db records skipped by list calls, admin only
+			beego.NSRouter("/corrupt-records", controllers.NewIntegrityPortal(), "get:ListCorruptRecords"),
+			// Adopts existing Cinder volumes or Manila shares, admin only
+			beego.NSRouter("/import", controllers.NewImportPortal(), "post:Import"),
+			// Runs administrative background jobs like purges, admin only
+			beego.NSRouter("/jobs", controllers.NewJobPortal(), "post:CreateJob;get:ListJobs"),
+			beego.NSRouter("/jobs/:jobId", controllers.NewJobPortal(), "get:GetJob"),
+			beego.NSRouter("/jobs/:jobId/cancel", controllers.NewJobPortal(), "post:CancelJob"),
+		)
+	beego.AddNamespace(adminns)
+}
@@ -32,10 +32,25 @@ func init() {
 			beego.NSRouter("/volumes/:volumeId", controllers.NewVolumePortal(), "get:GetVolume;put:UpdateVolume;delete:DeleteVolume"),
 			// Extend Volume
 			beego.NSRouter("/volumes/:volumeId/resize", controllers.NewVolumePortal(), "post:ExtendVolume"),
+			// Volume actions drive the attach state machine of the volume, see
+			// model.VolumeActionSpec for the documented transitions.
+			beego.NSRouter("/volumes/:volumeId/action", controllers.NewVolumePortal(), "post:VolumeAction"),
+			// Detach-all tears down every attachment of a volume in one
+			// call, for emergency isolation of a compromised workload.
+			beego.NSRouter("/volumes/:volumeId/detach-all", controllers.NewVolumeAttachmentPortal(), "post:DetachAllVolumeAttachments"),
+			// Operator notes appended to the volume, kept separate from metadata.
+			beego.NSRouter("/volumes/:volumeId/annotations", controllers.NewVolumePortal(), "post:CreateVolumeAnnotation;get:ListVolumeAnnotations"),
+			// Merges metadata entries instead of replacing the whole map,
+			// with per-key conditions for concurrent writers.
+			beego.NSRouter("/volumes/:volumeId/metadata", controllers.NewVolumePortal(), "post:UpdateVolumeMetadata"),
 
 			// Creates, shows, lists, unpdates and deletes attachment.
 			beego.NSRouter("/attachments", controllers.NewVolumeAttachmentPortal(), "post:CreateVolumeAttachment;get:ListVolumeAttachments"),
 			beego.NSRouter("/attachments/:attachmentId", controllers.NewVolumeAttachmentPortal(), "get:GetVolumeAttachment;put:UpdateVolumeAttachment;delete:DeleteVolumeAttachment"),
+			// Re-derives the connection info of an attachment, for hosts that lost it (e.g. after a reimage).
+			beego.NSRouter("/attachments/:attachmentId/connection", controllers.NewVolumeAttachmentPortal(), "post:RegenerateVolumeAttachmentConnection"),
+			// Completes a reserved attachment with the target host, the second step of the live-migration flow.
+			beego.NSRouter("/attachments/:attachmentId/finalize", controllers.NewVolumeAttachmentPortal(), "post:FinalizeVolumeAttachment"),
 
 			// Snapshot is a point-in-time copy of the data that a volume contains.
 			// Creates, shows, lists, unpdates and deletes snapshot.
@@ -45,13 +60,18 @@ func init() {
 			// Creates, shows, lists, unpdates and deletes replication.
 			beego.NSRouter("/replications", controllers.NewReplicationPortal(), "post:CreateReplication;get:ListReplications"),
 			beego.NSRouter("/replications/detail", controllers.NewReplicationPortal(), "get:ListReplicationsDetail"),
+			// Relates both sides of every replication to its volumes, pools, zones and docks in one call.
+			beego.NSRouter("/replications/topology", controllers.NewReplicationPortal(), "get:ListReplicationsTopology"),
 			beego.NSRouter("/replications/:replicationId", controllers.NewReplicationPortal(), "get:GetReplication;put:UpdateReplication;delete:DeleteReplication"),
+			beego.NSRouter("/replications/:replicationId/status", controllers.NewReplicationPortal(), "get:GetReplicationStatus"),
 			beego.NSRouter("/replications/:replicationId/enable", controllers.NewReplicationPortal(), "post:EnableReplication"),
 			beego.NSRouter("/replications/:replicationId/disable", controllers.NewReplicationPortal(), "post:DisableReplication"),
 			beego.NSRouter("/replications/:replicationId/failover", controllers.NewReplicationPortal(), "post:FailoverReplication"),
 			// Volume group contains a list of volumes that are used in the same application.
 			beego.NSRouter("/volumeGroups", controllers.NewVolumeGroupPortal(), "post:CreateVolumeGroup;get:ListVolumeGroups"),
 			beego.NSRouter("/volumeGroups/:groupId", controllers.NewVolumeGroupPortal(), "put:UpdateVolumeGroup;get:GetVolumeGroup;delete:DeleteVolumeGroup"),
+			beego.NSRouter("/volumeGroups/:groupId/addVolumes", controllers.NewVolumeGroupPortal(), "post:AddVolumes"),
+			beego.NSRouter("/volumeGroups/:groupId/removeVolumes", controllers.NewVolumeGroupPortal(), "post:RemoveVolumes"),
 		)
 	beego.AddNamespace(blockns)
 }
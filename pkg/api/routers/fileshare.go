@@ -28,10 +28,48 @@ func init() {
 		beego.NewNamespace("/"+constants.APIVersion+"/:tenantId/file",
 			beego.NSRouter("/shares", controllers.NewFileSharePortal(), "post:CreateFileShare;get:ListFileShares"),
 			beego.NSRouter("/shares/:fileshareId", controllers.NewFileSharePortal(), "get:GetFileShare;put:UpdateFileShare;delete:DeleteFileShare"),
+			// Locks the fileshare against accidental modification and releases the lock.
+			beego.NSRouter("/shares/:fileshareId/lock", controllers.NewFileSharePortal(), "post:LockFileShare"),
+			beego.NSRouter("/shares/:fileshareId/unlock", controllers.NewFileSharePortal(), "post:UnlockFileShare"),
+			// Publishing makes a fileshare discoverable by other tenants
+			// through GET /file/shares?public=true, in a redacted read-only form.
+			beego.NSRouter("/shares/:fileshareId/publish", controllers.NewFileSharePortal(), "post:PublishFileShare"),
+			beego.NSRouter("/shares/:fileshareId/unpublish", controllers.NewFileSharePortal(), "post:UnpublishFileShare"),
+			// Shows the tree of snapshots and fileshares that depend on the fileshare.
+			beego.NSRouter("/shares/:fileshareId/lineage", controllers.NewFileSharePortal(), "get:GetFileShareLineage"),
+			// The estimated monthly cost of the fileshare, priced by its profile.
+			beego.NSRouter("/shares/:fileshareId/cost-estimate", controllers.NewCostPortal(), "get:GetFileShareCostEstimate"),
+			// Operator notes appended to the fileshare, kept separate from metadata.
+			beego.NSRouter("/shares/:fileshareId/annotations", controllers.NewFileSharePortal(), "post:CreateFileShareAnnotation;get:ListFileShareAnnotations"),
+			// Merges metadata entries instead of replacing the whole map,
+			// with per-key conditions for concurrent writers.
+			beego.NSRouter("/shares/:fileshareId/metadata", controllers.NewFileSharePortal(), "post:UpdateFileShareMetadata"),
+			// Nested aliases of /snapshots and /acls scoped to the parent fileshare.
+			beego.NSRouter("/shares/:fileshareId/snapshots", controllers.NewFileShareSnapshotPortal(), "post:CreateNestedFileShareSnapshot;get:ListNestedFileShareSnapshots"),
+			beego.NSRouter("/shares/:fileshareId/acls", controllers.NewFileSharePortal(), "post:CreateNestedFileShareAcl;get:ListNestedFileShareAcls"),
+			// Revoke-all removes every acl of a fileshare in one call, for
+			// emergency isolation of a compromised workload.
+			beego.NSRouter("/shares/:fileshareId/revoke-all-access", controllers.NewFileSharePortal(), "post:RevokeAllFileShareAccess"),
 			// Snapshot is a point-in-time copy of the data that a FileShare contains.
 			// Creates, shows, lists, unpdates and deletes snapshot.
 			beego.NSRouter("/snapshots", controllers.NewFileShareSnapshotPortal(), "post:CreateFileShareSnapshot;get:ListFileShareSnapshots"),
 			beego.NSRouter("/snapshots/:snapshotId", controllers.NewFileShareSnapshotPortal(), "get:GetFileShareSnapshot;put:UpdateFileShareSnapshot;delete:DeleteFileShareSnapshot"),
+			// Copies the snapshot to a remote SODA endpoint and tracks the copy.
+			beego.NSRouter("/snapshots/:snapshotId/copy", controllers.NewFileShareSnapshotPortal(), "post:CopyFileShareSnapshot"),
+			// Backs up the snapshot to an object storage backup target and tracks the backup.
+			beego.NSRouter("/snapshots/:snapshotId/backup", controllers.NewFileShareSnapshotPortal(), "post:BackupFileShareSnapshot"),
+			// BackupTarget is an object storage location that snapshots can be backed up to.
+			beego.NSRouter("/backupTargets", controllers.NewBackupTargetPortal(), "post:CreateBackupTarget;get:ListBackupTargets"),
+			beego.NSRouter("/backupTargets/:targetId", controllers.NewBackupTargetPortal(), "get:GetBackupTarget;put:UpdateBackupTarget;delete:DeleteBackupTarget"),
+			// ShareNetwork is an export subnet/VLAN that fileshares can be
+			// exported on, registered by admins and picked at share creation.
+			beego.NSRouter("/shareNetworks", controllers.NewShareNetworkPortal(), "post:CreateShareNetwork;get:ListShareNetworks"),
+			beego.NSRouter("/shareNetworks/:networkId", controllers.NewShareNetworkPortal(), "get:GetShareNetwork;put:UpdateShareNetwork;delete:DeleteShareNetwork"),
+			// Backup is the record tracking one backup of a snapshot to a backup target.
+			beego.NSRouter("/backups", controllers.NewFileShareSnapshotBackupPortal(), "get:ListFileShareSnapshotBackups"),
+			beego.NSRouter("/backups/:backupId", controllers.NewFileShareSnapshotBackupPortal(), "get:GetFileShareSnapshotBackup;delete:DeleteFileShareSnapshotBackup"),
+			// Restores the backup into the fileshare it was taken from.
+			beego.NSRouter("/backups/:backupId/restore", controllers.NewFileShareSnapshotBackupPortal(), "post:RestoreFileShareSnapshotBackup"),
 			// Access is to set acl's for fileshare
 			beego.NSRouter("/acls", controllers.NewFileSharePortal(), "post:CreateFileShareAcl;get:ListFileSharesAcl"),
 			beego.NSRouter("/acls/:aclId", controllers.NewFileSharePortal(), "get:GetFileShareAcl;delete:DeleteFileShareAcl"),
@@ -26,7 +26,19 @@ func init() {
 	filens :=
 		beego.NewNamespace("/"+constants.APIVersion+"/:tenantId/host",
 			beego.NSRouter("/hosts", controllers.NewHostPortal(), "post:CreateHost;get:ListHosts"),
+			// Registers a whole list of hosts (json or csv) in one request
+			// with a per-row result report.
+			beego.NSRouter("/hosts/import", controllers.NewHostPortal(), "post:ImportHosts"),
 			beego.NSRouter("/hosts/:hostId", controllers.NewHostPortal(), "get:GetHost;put:UpdateHost;delete:DeleteHost"),
 		)
 	beego.AddNamespace(filens)
+
+	// HostGroup names a set of registered hosts, so shares can be granted and
+	// volumes attached to all of them at once. Grants created through a group
+	// follow its membership.
+	beego.Router("/"+constants.APIVersion+"/:tenantId/host-groups", controllers.NewHostGroupPortal(), "post:CreateHostGroup;get:ListHostGroups")
+	beego.Router("/"+constants.APIVersion+"/:tenantId/host-groups/:groupId", controllers.NewHostGroupPortal(), "get:GetHostGroup;put:UpdateHostGroup;delete:DeleteHostGroup")
+	// Expand one attach or grant request to every member host of the group.
+	beego.Router("/"+constants.APIVersion+"/:tenantId/host-groups/:groupId/attachments", controllers.NewHostGroupPortal(), "post:AttachVolumeToHostGroup")
+	beego.Router("/"+constants.APIVersion+"/:tenantId/host-groups/:groupId/acls", controllers.NewHostGroupPortal(), "post:GrantFileShareToHostGroup")
 }
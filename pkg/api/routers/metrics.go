@@ -34,6 +34,11 @@ func init() {
 			beego.NSRouter("/uploadconf", controllers.NewMetricsPortal(), "post:UploadConfFile"),
 			beego.NSRouter("/downloadconf", controllers.NewMetricsPortal(), "get:DownloadConfFile"),
 
+			// Push gateway style ingestion of telemetry samples from docks,
+			// persisted to the configured metrics store, and queries over
+			// the stored samples.
+			beego.NSRouter("/samples", controllers.NewMetricsPortal(), "post:IngestMetrics;get:QueryMetrics"),
+
 			beego.NSRouter("/urls", controllers.NewMetricsPortal(), "get:GetUrls"),
 		)
 	beego.AddNamespace(metricns)
@@ -42,8 +42,11 @@ func init() {
 
 			// List all dock services, including a list of dock object
 			beego.NSRouter("/:tenantId/docks", &controllers.DockPortal{}, "get:ListDocks"),
+			// Registers a new dock service, authenticated by the bootstrap token
+			beego.NSRouter("/:tenantId/docks/register", &controllers.DockPortal{}, "post:RegisterDock"),
 			// Show one dock service, including endpoint, driverName and so on
-			beego.NSRouter("/:tenantId/docks/:dockId", &controllers.DockPortal{}, "get:GetDock"),
+			// DeleteDock revokes a dock registration, admin only
+			beego.NSRouter("/:tenantId/docks/:dockId", &controllers.DockPortal{}, "get:GetDock;delete:DeleteDock"),
 
 			// Profile is a set of policies configured by admin and provided for users
 			// CreateProfile, UpdateProfile and DeleteProfile are used for admin only
@@ -59,12 +62,79 @@ func init() {
 			// and every pool is atomic, which means every pool contains a specific set of features.
 			// ListPools and GetPool are used for checking the status of backend pool, admin only
 			beego.NSRouter("/:tenantId/pools", &controllers.PoolPortal{}, "get:ListPools"),
-			beego.NSRouter("/:tenantId/pools/:poolId", &controllers.PoolPortal{}, "get:GetPool"),
+			beego.NSRouter("/:tenantId/pools/:poolId", &controllers.PoolPortal{}, "get:GetPool;put:UpdatePool"),
+			// Toggles the maintenance state of a pool, marking it
+			// unschedulable while its controller is upgraded.
+			beego.NSRouter("/:tenantId/pools/:poolId/action", &controllers.PoolPortal{}, "post:PoolAction"),
 			beego.NSRouter("/:tenantId/availabilityZones", &controllers.PoolPortal{}, "get:ListAvailabilityZones"),
+
+			// Api keys are long lived credentials for service accounts, bound to
+			// a tenant and a role. The key secret is only returned on create and
+			// rotate, and only its hash is kept at rest.
+			beego.NSRouter("/:tenantId/apikeys", controllers.NewApiKeyPortal(), "post:CreateApiKey;get:ListApiKeys"),
+			beego.NSRouter("/:tenantId/apikeys/:apikeyId", controllers.NewApiKeyPortal(), "get:GetApiKey;delete:DeleteApiKey"),
+			beego.NSRouter("/:tenantId/apikeys/:apikeyId/rotate", controllers.NewApiKeyPortal(), "post:RotateApiKey"),
+
+			// Scoped tokens are short lived credentials restricted to specific
+			// actions and resources, presented in the X-Auth-ScopedToken
+			// header. The token secret is only returned on create.
+			beego.NSRouter("/:tenantId/scopedTokens", controllers.NewScopedTokenPortal(), "post:CreateScopedToken;get:ListScopedTokens"),
+			beego.NSRouter("/:tenantId/scopedTokens/:tokenId", controllers.NewScopedTokenPortal(), "get:GetScopedToken;delete:DeleteScopedToken"),
+
+			// The network policy restricts the addresses from which the
+			// tokens of the tenant may call mutating endpoints. A tenant has
+			// at most one policy.
+			beego.NSRouter("/:tenantId/networkPolicies", controllers.NewNetworkPolicyPortal(), "post:CreateNetworkPolicy;get:ListNetworkPolicies"),
+			beego.NSRouter("/:tenantId/networkPolicies/:policyId", controllers.NewNetworkPolicyPortal(), "get:GetNetworkPolicy;put:UpdateNetworkPolicy;delete:DeleteNetworkPolicy"),
+
+			// A resource class is a named bundle of validation rules that
+			// admins attach to tenants; create requests of an attached
+			// tenant are checked against the rules.
+			beego.NSRouter("/:tenantId/resourceClasses", controllers.NewResourceClassPortal(), "post:CreateResourceClass;get:ListResourceClasses"),
+			beego.NSRouter("/:tenantId/resourceClasses/:classId", controllers.NewResourceClassPortal(), "get:GetResourceClass;put:UpdateResourceClass;delete:DeleteResourceClass"),
+
+			// A region registers a peer SODA API endpoint with its
+			// capabilities and health, so remote endpoints can be
+			// discovered from the API instead of out-of-band config.
+			beego.NSRouter("/:tenantId/regions", controllers.NewRegionPortal(), "post:CreateRegion;get:ListRegions"),
+			beego.NSRouter("/:tenantId/regions/:regionId", controllers.NewRegionPortal(), "get:GetRegion;put:UpdateRegion;delete:DeleteRegion"),
+
+			// The gb quota of the tenant in the url; GET also reports the
+			// current usage percentage.
+			beego.NSRouter("/:tenantId/quotas", controllers.NewQuotaPortal(), "get:GetQuota;put:SetQuota;delete:DeleteQuota"),
+
+			// The default tags of the tenant in the url, merged into the
+			// metadata of every resource the tenant creates; user-provided
+			// values win over the defaults. Set and delete are admin only.
+			beego.NSRouter("/:tenantId/defaultTags", controllers.NewDefaultTagsPortal(), "get:GetDefaultTags;put:SetDefaultTags;delete:DeleteDefaultTags"),
+
+			// The aggregated showback cost report of the tenant in the url,
+			// priced by the costPerGbMonth of the profiles.
+			beego.NSRouter("/:tenantId/cost-estimate", controllers.NewCostPortal(), "get:GetTenantCostEstimate"),
+
+			// Tasks are the records of asynchronous operations, with the
+			// state transitions each operation went through and the error
+			// that failed it.
+			beego.NSRouter("/:tenantId/tasks", controllers.NewTaskPortal(), "get:ListTasks"),
+			beego.NSRouter("/:tenantId/tasks/:taskId", controllers.NewTaskPortal(), "get:GetTask"),
 		)
 	beego.AddNamespace(ns)
 
 	// add router for api version
 	beego.Router("/", &controllers.VersionPortal{}, "get:ListVersions")
 	beego.Router("/:apiVersion", &controllers.VersionPortal{}, "get:GetVersion")
+
+	// The service catalog at the root of the current version lists the
+	// routed sub-APIs and enabled features; the fixed route takes
+	// precedence over the version lookup above and stays compatible with
+	// it.
+	beego.Router("/"+constants.APIVersion, controllers.NewCatalogPortal(), "get:GetCatalog")
+
+	// add router for converged capability discovery
+	beego.Router("/"+constants.APIVersion+"/capabilities", controllers.NewCapabilityPortal(), "get:GetCapabilities")
+
+	// The protection report of a tenant: which volumes and fileshares have
+	// a recent snapshot or an enabled replication, and which are
+	// unprotected.
+	beego.Router("/"+constants.APIVersion+"/reports/protection", controllers.NewProtectionReportPortal(), "get:GetProtectionReport")
 }
@@ -0,0 +1,190 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the scripted smoke scenario behind the -selftest
+flag of the api server. The scenario drives the REST api of a server that
+was booted against the in-memory db and the in-process fake controller, so
+it exercises the routing, filter chain, controllers and db layer without
+needing etcd, keystone or a running dock. Every step is reported with its
+latency on standard output, which makes the mode usable for packaging
+validation and as a canary check after deploys.
+*/
+
+package selftest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+// step is one request of the scripted scenario. The path is relative to
+// the tenant namespace and may reference the id captured by an earlier
+// step as $name; a step with a capture name stores the id of the resource
+// it returned for the steps after it.
+type step struct {
+	name    string
+	method  string
+	path    string
+	body    string
+	want    int
+	capture string
+}
+
+// scenario walks every resource family once: list and get the discovered
+// inventory, then create, read and delete the resources the fake
+// controller can simulate. The canned records of the in-memory db provide
+// the inventory the steps navigate.
+var scenario = []step{
+	{name: "list pools", method: "GET", path: "/pools", want: 200, capture: "pool"},
+	{name: "get pool", method: "GET", path: "/pools/$pool", want: 200},
+	{name: "list availability zones", method: "GET", path: "/availabilityZones", want: 200},
+	{name: "list docks", method: "GET", path: "/docks", want: 200},
+	{name: "list profiles", method: "GET", path: "/profiles", want: 200, capture: "profile"},
+	{name: "get profile", method: "GET", path: "/profiles/$profile", want: 200},
+	{name: "create volume", method: "POST", path: "/block/volumes",
+		body: `{"name": "selftest-vol", "description": "created by the self test", "size": 1}`,
+		want: 202, capture: "volume"},
+	{name: "get volume", method: "GET", path: "/block/volumes/$volume", want: 200},
+	{name: "list volumes", method: "GET", path: "/block/volumes", want: 200},
+	{name: "create volume snapshot", method: "POST", path: "/block/snapshots",
+		body: `{"name": "selftest-snap", "volumeId": "$volume"}`,
+		want: 202, capture: "snapshot"},
+	{name: "list volume snapshots", method: "GET", path: "/block/snapshots", want: 200},
+	{name: "delete volume snapshot", method: "DELETE", path: "/block/snapshots/$snapshot", want: 202},
+	{name: "list attachments", method: "GET", path: "/block/attachments", want: 200},
+	{name: "list replications", method: "GET", path: "/block/replications", want: 200},
+	{name: "list volume groups", method: "GET", path: "/block/volumeGroups", want: 200},
+	{name: "create fileshare", method: "POST", path: "/file/shares",
+		body: `{"name": "selftest-share", "description": "created by the self test", "size": 1}`,
+		want: 202, capture: "fileshare"},
+	{name: "get fileshare", method: "GET", path: "/file/shares/$fileshare", want: 200},
+	{name: "list fileshares", method: "GET", path: "/file/shares", want: 200},
+	{name: "list fileshare snapshots", method: "GET", path: "/file/snapshots", want: 200},
+	{name: "list fileshare acls", method: "GET", path: "/file/acls", want: 200},
+	{name: "list hosts", method: "GET", path: "/host/hosts", want: 200},
+}
+
+// Run waits for the server listening on endpoint to come up, executes the
+// scripted scenario against it and prints one line per step with the
+// status and latency. It returns an error when the server never became
+// ready or any step got a different status than the scenario expects.
+func Run(endpoint string) error {
+	base := fmt.Sprintf("http://%s/%s/%s", endpoint, constants.APIVersion, constants.DefaultTenantId)
+	if err := waitReady("http://" + endpoint + "/"); err != nil {
+		return err
+	}
+
+	var failed int
+	captured := map[string]string{}
+	start := time.Now()
+	for _, s := range scenario {
+		status, body, elapsed, err := run(s, base, captured)
+		verdict := "ok  "
+		switch {
+		case err != nil:
+			verdict, failed = "FAIL", failed+1
+			fmt.Fprintf(os.Stdout, "%s %-28s %-6s %s (%v)\n", verdict, s.name, s.method, s.path, err)
+			continue
+		case status != s.want:
+			verdict, failed = "FAIL", failed+1
+		}
+		fmt.Fprintf(os.Stdout, "%s %-28s %-6s %-40s %d (want %d) in %v\n",
+			verdict, s.name, s.method, s.path, status, s.want, elapsed.Round(time.Microsecond))
+		if verdict == "ok  " && s.capture != "" {
+			if id := firstId(body); id != "" {
+				captured[s.capture] = id
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stdout, "self test: %d of %d steps passed in %v\n",
+		len(scenario)-failed, len(scenario), time.Since(start).Round(time.Millisecond))
+	if failed != 0 {
+		return fmt.Errorf("self test failed: %d of %d steps did not pass", failed, len(scenario))
+	}
+	return nil
+}
+
+// waitReady polls the version endpoint until the server answers, so the
+// scenario does not race the beego listener coming up.
+func waitReady(url string) error {
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			return nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return fmt.Errorf("the server at %s did not become ready within 30s", url)
+}
+
+// run executes one step, substituting the ids captured so far into the
+// path and body.
+func run(s step, base string, captured map[string]string) (int, []byte, time.Duration, error) {
+	path, body := s.path, s.body
+	for name, id := range captured {
+		path = strings.Replace(path, "$"+name, id, -1)
+		body = strings.Replace(body, "$"+name, id, -1)
+	}
+
+	req, err := http.NewRequest(s.method, base+path, bytes.NewBufferString(body))
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		return 0, nil, elapsed, err
+	}
+	defer resp.Body.Close()
+	got, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, elapsed, err
+	}
+	return resp.StatusCode, got, elapsed, nil
+}
+
+// firstId extracts the id of the resource a response carries, accepting
+// both a single object and a list.
+func firstId(body []byte) string {
+	var obj struct {
+		Id string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &obj); err == nil && obj.Id != "" {
+		return obj.Id
+	}
+	var list []struct {
+		Id string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &list); err == nil && len(list) != 0 {
+		return list[0].Id
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"sync"
+
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+// memoryStoreCapacity bounds the number of samples the built-in store
+// keeps. When the bound is reached the oldest samples are dropped first.
+const memoryStoreCapacity = 16384
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{}
+}
+
+// memoryStore keeps pushed samples in memory. It is the default store, so
+// the telemetry loop works out of the box, and the only one that answers
+// queries itself.
+type memoryStore struct {
+	mu      sync.Mutex
+	samples []*model.MetricSpec
+}
+
+func (s *memoryStore) Write(samples []*model.MetricSpec) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = append(s.samples, samples...)
+	if overflow := len(s.samples) - memoryStoreCapacity; overflow > 0 {
+		s.samples = append([]*model.MetricSpec{}, s.samples[overflow:]...)
+	}
+	return nil
+}
+
+func (s *memoryStore) Query(name, instanceId string) ([]*model.MetricSpec, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result = []*model.MetricSpec{}
+	for _, sample := range s.samples {
+		if name != "" && sample.Name != name {
+			continue
+		}
+		if instanceId != "" && sample.InstanceID != instanceId {
+			continue
+		}
+		result = append(result, sample)
+	}
+	return result, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func newInfluxStore(endpoint, database string) *influxStore {
+	return &influxStore{endpoint: endpoint, database: database}
+}
+
+// influxStore forwards samples to an influxdb instance with the line
+// protocol. Queries are served by the influxdb query api directly.
+type influxStore struct {
+	endpoint string
+	database string
+}
+
+func (s *influxStore) Write(samples []*model.MetricSpec) error {
+	var lines []string
+	for _, sample := range samples {
+		for _, value := range sample.MetricValues {
+			lines = append(lines, influxLine(sample, value))
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	u := fmt.Sprintf("%s/write?db=%s",
+		strings.TrimSuffix(s.endpoint, "/"), url.QueryEscape(s.database))
+	resp, err := http.Post(u, "text/plain; charset=utf-8",
+		strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("metrics store returned status %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *influxStore) Query(name, instanceId string) ([]*model.MetricSpec, error) {
+	return nil, ErrQueryNotSupported
+}
+
+// influxLine renders one sample value in the influxdb line protocol,
+// tagging it with the instance and the sample labels.
+func influxLine(sample *model.MetricSpec, value *model.Metric) string {
+	var b strings.Builder
+	b.WriteString(escapeInfluxTag(sample.Name))
+	if sample.InstanceID != "" {
+		b.WriteString(",instanceId=" + escapeInfluxTag(sample.InstanceID))
+	}
+	for _, key := range sortedLabelKeys(sample.Labels) {
+		b.WriteString("," + escapeInfluxTag(key) + "=" + escapeInfluxTag(sample.Labels[key]))
+	}
+	timestamp := value.Timestamp
+	if timestamp == 0 {
+		timestamp = time.Now().Unix()
+	}
+	b.WriteString(fmt.Sprintf(" value=%g %d", value.Value, timestamp*int64(time.Second)))
+	return b.String()
+}
+
+func escapeInfluxTag(s string) string {
+	r := strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	return r.Replace(s)
+}
+
+func newPushGatewayStore(endpoint string) *pushGatewayStore {
+	return &pushGatewayStore{endpoint: endpoint}
+}
+
+// pushGatewayStore forwards samples to a prometheus pushgateway in the
+// text exposition format, from where the prometheus server scrapes them.
+// Queries are served by the prometheus query api directly.
+type pushGatewayStore struct {
+	endpoint string
+}
+
+func (s *pushGatewayStore) Write(samples []*model.MetricSpec) error {
+	// The pushgateway keeps one group per job, so push the samples grouped
+	// by their job.
+	byJob := map[string][]string{}
+	for _, sample := range samples {
+		job := sample.Job
+		if job == "" {
+			job = "opensds"
+		}
+		for _, value := range sample.MetricValues {
+			byJob[job] = append(byJob[job], expositionLine(sample, value))
+		}
+	}
+	for job, lines := range byJob {
+		u := fmt.Sprintf("%s/metrics/job/%s",
+			strings.TrimSuffix(s.endpoint, "/"), url.PathEscape(job))
+		resp, err := http.Post(u, "text/plain; version=0.0.4",
+			strings.NewReader(strings.Join(lines, "\n")+"\n"))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("metrics store returned status %s", resp.Status)
+		}
+	}
+	return nil
+}
+
+func (s *pushGatewayStore) Query(name, instanceId string) ([]*model.MetricSpec, error) {
+	return nil, ErrQueryNotSupported
+}
+
+// expositionLine renders one sample value in the prometheus text
+// exposition format. The pushgateway rejects pushed timestamps, so the
+// value is pushed without one.
+func expositionLine(sample *model.MetricSpec, value *model.Metric) string {
+	var labels []string
+	if sample.InstanceID != "" {
+		labels = append(labels, fmt.Sprintf("instanceId=%q", sample.InstanceID))
+	}
+	for _, key := range sortedLabelKeys(sample.Labels) {
+		labels = append(labels, fmt.Sprintf("%s=%q", key, sample.Labels[key]))
+	}
+	if len(labels) == 0 {
+		return fmt.Sprintf("%s %g", sample.Name, value.Value)
+	}
+	return fmt.Sprintf("%s{%s} %g", sample.Name, strings.Join(labels, ","), value.Value)
+}
+
+// sortedLabelKeys returns the label keys in a stable order, so rendered
+// lines do not flap between pushes.
+func sortedLabelKeys(labels map[string]string) []string {
+	var keys []string
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
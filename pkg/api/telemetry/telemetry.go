@@ -0,0 +1,64 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telemetry persists the telemetry samples docks push through the
+// metrics ingestion endpoint. The store backing it is pluggable: samples
+// can be forwarded to an influxdb instance or a prometheus pushgateway, or
+// kept in a bounded in-memory store when no external tsdb is configured.
+package telemetry
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// ErrQueryNotSupported is returned by stores that are write-only through
+// the api; their native query interface serves reads instead.
+var ErrQueryNotSupported = errors.New("the configured metrics store does not support queries through the api")
+
+// Store is the time series database pushed samples are persisted to.
+type Store interface {
+	// Write persists a batch of samples.
+	Write(samples []*model.MetricSpec) error
+
+	// Query returns the stored samples, narrowed to a metric name and an
+	// instance when those are not empty. Stores that only forward samples
+	// return ErrQueryNotSupported.
+	Query(name, instanceId string) ([]*model.MetricSpec, error)
+}
+
+var (
+	storeOnce sync.Once
+	store     Store
+)
+
+// GetStore returns the store selected by the metrics_store_backend option.
+// The selection is made once, on the first batch or query.
+func GetStore() Store {
+	storeOnce.Do(func() {
+		switch CONF.OsdsApiServer.MetricsStoreBackend {
+		case "influxdb":
+			store = newInfluxStore(CONF.OsdsApiServer.MetricsStoreEndpoint,
+				CONF.OsdsApiServer.MetricsStoreDatabase)
+		case "prometheus":
+			store = newPushGatewayStore(CONF.OsdsApiServer.MetricsStoreEndpoint)
+		default:
+			store = newMemoryStore()
+		}
+	})
+	return store
+}
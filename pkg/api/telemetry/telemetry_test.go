@@ -0,0 +1,74 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+var fakeSample = &model.MetricSpec{
+	InstanceID: "bd5b12a8-a101-11e7-941e-d77981b584d8",
+	Name:       "disk_read_bytes_total",
+	Labels:     map[string]string{"pool": "pool1", "device": "dm-0"},
+	MetricValues: []*model.Metric{
+		{Timestamp: 1573470093, Value: 42.5},
+	},
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := newMemoryStore()
+	other := &model.MetricSpec{
+		InstanceID:   "another-instance",
+		Name:         "disk_write_bytes_total",
+		MetricValues: []*model.Metric{{Value: 1}},
+	}
+	if err := s.Write([]*model.MetricSpec{fakeSample, other}); err != nil {
+		t.Errorf("write to memory store failed: %v", err)
+	}
+
+	result, err := s.Query("disk_read_bytes_total", "")
+	if err != nil {
+		t.Errorf("query memory store failed: %v", err)
+	}
+	if len(result) != 1 || result[0].InstanceID != fakeSample.InstanceID {
+		t.Errorf("expected the one matching sample, got %v", result)
+	}
+
+	result, _ = s.Query("", "another-instance")
+	if len(result) != 1 || result[0].Name != "disk_write_bytes_total" {
+		t.Errorf("expected the one matching sample, got %v", result)
+	}
+
+	result, _ = s.Query("no-such-metric", "")
+	if len(result) != 0 {
+		t.Errorf("expected no samples, got %v", result)
+	}
+}
+
+func TestInfluxLine(t *testing.T) {
+	expected := `disk_read_bytes_total,instanceId=bd5b12a8-a101-11e7-941e-d77981b584d8,device=dm-0,pool=pool1 value=42.5 1573470093000000000`
+	if line := influxLine(fakeSample, fakeSample.MetricValues[0]); line != expected {
+		t.Errorf("expected %s, got %s", expected, line)
+	}
+}
+
+func TestExpositionLine(t *testing.T) {
+	expected := `disk_read_bytes_total{instanceId="bd5b12a8-a101-11e7-941e-d77981b584d8",device="dm-0",pool="pool1"} 42.5`
+	if line := expositionLine(fakeSample, fakeSample.MetricValues[0]); line != expected {
+		t.Errorf("expected %s, got %s", expected, line)
+	}
+}
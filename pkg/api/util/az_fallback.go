@@ -0,0 +1,125 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the availability zone fallback of the create paths.
+When the requested zone has no capacity a create may be placed in an
+alternate zone, walking an ordered list from the profile or from the
+deployment wide az_fallback_zones config key.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	log "github.com/golang/glog"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// The metadata key a fallback is recorded under, holding the zone the
+// user originally requested.
+const RequestedAvailabilityZoneKey = "requestedAvailabilityZone"
+
+// ApplyAZFallback returns the availability zone a resource of the given
+// profile, storage type and size should be placed in. The requested zone
+// wins whenever it has capacity. When it does not, the fallback zones of
+// the profile (or, when the profile names none, of the deployment) are
+// walked in order and the first one with capacity is returned; with the
+// list exhausted the create fails. Without any fallback zones configured
+// the requested zone is returned untouched, which keeps the behavior of
+// failing later in scheduling.
+func ApplyAZFallback(ctx *c.Context, profileId, storageType string, size int64, requested string) (string, error) {
+	pools, err := db.C.ListPools(ctx)
+	if err != nil {
+		return "", err
+	}
+	var typed []*model.StoragePoolSpec
+	for _, pool := range pools {
+		if pool.StorageType == storageType {
+			typed = append(typed, pool)
+		}
+	}
+	if len(typed) == 0 {
+		// No pool of that type registered yet, placement is fully up to
+		// the scheduler.
+		return requested, nil
+	}
+	if azHasCapacity(typed, requested, size) {
+		return requested, nil
+	}
+
+	fallbacks := azFallbackZones(ctx, profileId)
+	if len(fallbacks) == 0 {
+		return requested, nil
+	}
+	for _, zone := range fallbacks {
+		if zone == requested {
+			continue
+		}
+		if azHasCapacity(typed, zone, size) {
+			log.Warningf("event: availability zone %s has no capacity for %d GB, falling back to %s",
+				requested, size, zone)
+			return zone, nil
+		}
+	}
+	return "", fmt.Errorf("no capacity for %d GB in availability zone %s nor in the fallback zones %v",
+		size, requested, fallbacks)
+}
+
+// azFallbackZones returns the ordered fallback zones of the profile,
+// falling back to the deployment wide list when the profile names none.
+// Empty entries, an artifact of the empty config default, are dropped.
+func azFallbackZones(ctx *c.Context, profileId string) []string {
+	zones := CONF.OsdsApiServer.AZFallbackZones
+	if profileId != "" {
+		prf, err := db.C.GetProfile(ctx, profileId)
+		if err != nil {
+			log.Errorf("when getting profile %s for az fallback: %v", profileId, err)
+		} else if len(prf.AvailabilityZoneFallbacks) > 0 {
+			zones = prf.AvailabilityZoneFallbacks
+		}
+	}
+	var cleaned []string
+	for _, zone := range zones {
+		if zone != "" {
+			cleaned = append(cleaned, zone)
+		}
+	}
+	return cleaned
+}
+
+// azHasCapacity reports whether a pool of the given zone can host size
+// GB, honoring staleness and maintenance like ValidatePoolCapacity.
+func azHasCapacity(pools []*model.StoragePoolSpec, zone string, size int64) bool {
+	rejectStale := CONF.OsdsApiServer.RejectStalePools
+	for _, pool := range pools {
+		if pool.AvailabilityZone != zone {
+			continue
+		}
+		if pool.Maintenance {
+			continue
+		}
+		if rejectStale && PoolIsStale(pool) {
+			continue
+		}
+		if poolHasCapacity(pool, size) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func TestApplyAZFallback(t *testing.T) {
+	var azPool = func(zone string, free int64) *model.StoragePoolSpec {
+		return &model.StoragePoolSpec{
+			BaseModel:        &model.BaseModel{},
+			StorageType:      "block",
+			AvailabilityZone: zone,
+			TotalCapacity:    free,
+			FreeCapacity:     free,
+		}
+	}
+	var mockPools = func(prf *model.ProfileSpec, pools ...*model.StoragePoolSpec) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(pools, nil)
+		if prf != nil {
+			mockClient.On("GetProfile", context.NewAdminContext(), prf.Id).Return(prf, nil)
+		}
+		db.C = mockClient
+	}
+	fallbackProfile := &model.ProfileSpec{
+		BaseModel:                 &model.BaseModel{Id: "1106b972-66ef-11e7-b172-db03f3689c9c"},
+		AvailabilityZoneFallbacks: []string{"az-b", "az-c"},
+	}
+
+	t.Run("Should stay in the requested zone when it has capacity", func(t *testing.T) {
+		mockPools(nil, azPool("default", 100), azPool("az-b", 100))
+		zone, err := ApplyAZFallback(context.NewAdminContext(), "", "block", 10, "default")
+		if err != nil {
+			t.Errorf("expected the placement to pass, got %v", err)
+		}
+		assertTestResult(t, zone, "default")
+	})
+
+	t.Run("Should fall back to the first alternate zone with capacity", func(t *testing.T) {
+		mockPools(fallbackProfile, azPool("default", 5), azPool("az-b", 5), azPool("az-c", 100))
+		zone, err := ApplyAZFallback(context.NewAdminContext(), fallbackProfile.Id, "block", 10, "default")
+		if err != nil {
+			t.Errorf("expected the placement to pass, got %v", err)
+		}
+		assertTestResult(t, zone, "az-c")
+	})
+
+	t.Run("Should fail when the fallback zones are exhausted", func(t *testing.T) {
+		mockPools(fallbackProfile, azPool("default", 5), azPool("az-b", 5))
+		_, err := ApplyAZFallback(context.NewAdminContext(), fallbackProfile.Id, "block", 10, "default")
+		expectedError := "no capacity for 10 GB in availability zone default nor in the fallback zones [az-b az-c]"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should keep the requested zone when no fallback is configured", func(t *testing.T) {
+		mockPools(nil, azPool("default", 5))
+		zone, err := ApplyAZFallback(context.NewAdminContext(), "", "block", 10, "default")
+		if err != nil {
+			t.Errorf("expected the placement to pass, got %v", err)
+		}
+		assertTestResult(t, zone, "default")
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// ValidateMaxBandwidth checks the bandwidth limit of a bulk data movement
+// request against the configured policy range. Zero means no limit, which
+// is only acceptable while no cap is configured: with
+// transfer_max_bandwidth_mbps set every request must name a limit within
+// the cap.
+func ValidateMaxBandwidth(mbps int64) error {
+	if mbps < 0 {
+		return fmt.Errorf("maxBandwidthMBps must not be negative, got %d", mbps)
+	}
+	limit := CONF.OsdsApiServer.TransferMaxBandwidthMBps
+	if limit <= 0 {
+		return nil
+	}
+	if mbps == 0 {
+		return fmt.Errorf("maxBandwidthMBps must be set, this deployment caps bulk data movement at %d MB/s", limit)
+	}
+	if mbps > limit {
+		return fmt.Errorf("maxBandwidthMBps %d exceeds the configured cap of %d MB/s", mbps, limit)
+	}
+	return nil
+}
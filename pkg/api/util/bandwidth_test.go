@@ -0,0 +1,47 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+func TestValidateMaxBandwidth(t *testing.T) {
+
+	t.Run("Should accept any non-negative limit without a configured cap", func(t *testing.T) {
+		for _, mbps := range []int64{0, 1, 10000} {
+			if err := ValidateMaxBandwidth(mbps); err != nil {
+				t.Errorf("expected %d MB/s to pass, got %v", mbps, err)
+			}
+		}
+		err := ValidateMaxBandwidth(-1)
+		assertTestResult(t, err.Error(), "maxBandwidthMBps must not be negative, got -1")
+	})
+
+	t.Run("Should enforce the configured cap", func(t *testing.T) {
+		CONF.OsdsApiServer.TransferMaxBandwidthMBps = 100
+		defer func() { CONF.OsdsApiServer.TransferMaxBandwidthMBps = 0 }()
+
+		if err := ValidateMaxBandwidth(100); err != nil {
+			t.Errorf("expected a limit at the cap to pass, got %v", err)
+		}
+		err := ValidateMaxBandwidth(0)
+		assertTestResult(t, err.Error(), "maxBandwidthMBps must be set, this deployment caps bulk data movement at 100 MB/s")
+		err = ValidateMaxBandwidth(101)
+		assertTestResult(t, err.Error(), "maxBandwidthMBps 101 exceeds the configured cap of 100 MB/s")
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the pacing of cascade-style deletions. A cascade
+covering many dependent items works through them in batches with bounded
+parallelism, so a huge cleanup does not overload the backend, and
+checkpoints its progress in a cascade task record after every batch, so a
+cleanup interrupted by an api server restart resumes from the checkpoint
+when it is retried instead of starting over.
+*/
+
+package util
+
+import (
+	"sync"
+
+	log "github.com/golang/glog"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// CascadeRunner paces one cascade: items are processed in batches of
+// BatchSize with at most Parallelism calls in flight.
+type CascadeRunner struct {
+	Parallelism int
+	BatchSize   int
+}
+
+// NewCascadeRunner builds a runner from the configured knobs, falling back
+// to serial single-item batches when a knob is zero or negative.
+func NewCascadeRunner() CascadeRunner {
+	runner := CascadeRunner{
+		Parallelism: CONF.OsdsApiServer.CascadeDeleteParallelism,
+		BatchSize:   CONF.OsdsApiServer.CascadeDeleteBatchSize,
+	}
+	if runner.Parallelism < 1 {
+		runner.Parallelism = 1
+	}
+	if runner.BatchSize < 1 {
+		runner.BatchSize = 1
+	}
+	return runner
+}
+
+// StartCascadeTask returns the task record the cascade checkpoints its
+// progress in. A running task of the same action on the same resource is
+// picked up again, so a retried cascade resumes from its checkpoint. The
+// task is best effort: when the db refuses it the cascade still runs, it
+// just can not be resumed.
+func StartCascadeTask(ctx *c.Context, resourceType, resourceId, action string, total int64) *model.CascadeTaskSpec {
+	tasks, err := db.C.ListCascadeTasks(ctx)
+	if err != nil {
+		log.Error("when listing cascade tasks:", err)
+	}
+	for _, task := range tasks {
+		if task.ResourceType == resourceType && task.ResourceId == resourceId &&
+			task.Action == action && task.Status == model.CascadeTaskRunning {
+			return task
+		}
+	}
+
+	task := &model.CascadeTaskSpec{
+		BaseModel:    &model.BaseModel{},
+		TenantId:     ctx.TenantId,
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		Action:       action,
+		Status:       model.CascadeTaskRunning,
+		TotalItems:   total,
+	}
+	result, err := db.C.CreateCascadeTask(ctx, task)
+	if err != nil {
+		log.Error("when creating cascade task:", err)
+		return task
+	}
+	return result
+}
+
+// Run works through the items of a cascade. Items up to and including the
+// checkpoint of the task are skipped, the rest are processed in batches of
+// BatchSize with at most Parallelism calls of fn in flight. Failed items
+// are counted and logged but do not stop the cascade, a cleanup makes
+// whatever progress it can. The task is checkpointed after every batch and
+// closed at the end.
+func (r CascadeRunner) Run(ctx *c.Context, task *model.CascadeTaskSpec, ids []string, fn func(id string) error) {
+	if task.Checkpoint != "" {
+		for i, id := range ids {
+			if id == task.Checkpoint {
+				log.Infof("cascade task %s resumes after checkpoint %s, %d of %d items left",
+					task.Id, task.Checkpoint, len(ids)-i-1, len(ids))
+				ids = ids[i+1:]
+				break
+			}
+		}
+	}
+
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > r.BatchSize {
+			batch = batch[:r.BatchSize]
+		}
+		ids = ids[len(batch):]
+
+		var wg sync.WaitGroup
+		var lock sync.Mutex
+		slots := make(chan struct{}, r.Parallelism)
+		for _, id := range batch {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(id string) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				if err := fn(id); err != nil {
+					log.Errorf("cascade task %s failed on item %s: %v", task.Id, id, err)
+					lock.Lock()
+					task.FailedItems++
+					lock.Unlock()
+				}
+			}(id)
+		}
+		wg.Wait()
+
+		task.CompletedItems += int64(len(batch))
+		task.Checkpoint = batch[len(batch)-1]
+		if _, err := db.C.UpdateCascadeTask(ctx, task); err != nil {
+			log.Error("when checkpointing cascade task:", err)
+		}
+	}
+
+	task.Status = model.CascadeTaskDone
+	if task.FailedItems > 0 {
+		task.Status = model.CascadeTaskError
+	}
+	if _, err := db.C.UpdateCascadeTask(ctx, task); err != nil {
+		log.Error("when closing cascade task:", err)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func TestStartCascadeTask(t *testing.T) {
+
+	t.Run("Should create a fresh task when none is running", func(t *testing.T) {
+		in := &model.CascadeTaskSpec{
+			BaseModel:    &model.BaseModel{},
+			ResourceType: "volume",
+			ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			Action:       "detachAll",
+			Status:       model.CascadeTaskRunning,
+			TotalItems:   3,
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListCascadeTasks", context.NewAdminContext()).Return(nil, nil)
+		mockClient.On("CreateCascadeTask", context.NewAdminContext(), in).Return(in, nil)
+		db.C = mockClient
+
+		task := StartCascadeTask(context.NewAdminContext(), "volume",
+			"bd5b12a8-a101-11e7-941e-d77981b584d8", "detachAll", 3)
+		assertTestResult(t, task, in)
+	})
+
+	t.Run("Should pick up a running task of the same cascade again", func(t *testing.T) {
+		running := &model.CascadeTaskSpec{
+			BaseModel:    &model.BaseModel{Id: "3c9bbcfa-5b64-4058-b745-6ed85a0e2a0b"},
+			ResourceType: "volume",
+			ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			Action:       "detachAll",
+			Status:       model.CascadeTaskRunning,
+			TotalItems:   3,
+			Checkpoint:   "f2dda3d2-bf79-11e7-8665-f750b088f63e",
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListCascadeTasks", context.NewAdminContext()).Return(
+			[]*model.CascadeTaskSpec{running}, nil)
+		db.C = mockClient
+
+		task := StartCascadeTask(context.NewAdminContext(), "volume",
+			"bd5b12a8-a101-11e7-941e-d77981b584d8", "detachAll", 3)
+		assertTestResult(t, task, running)
+	})
+}
+
+func TestCascadeRunnerRun(t *testing.T) {
+
+	t.Run("Should work through every item and checkpoint after each batch", func(t *testing.T) {
+		task := &model.CascadeTaskSpec{BaseModel: &model.BaseModel{}, TotalItems: 5,
+			Status: model.CascadeTaskRunning}
+		mockClient := new(dbtest.Client)
+		mockClient.On("UpdateCascadeTask", context.NewAdminContext(), task).Return(task, nil)
+		db.C = mockClient
+
+		var lock sync.Mutex
+		var processed []string
+		runner := CascadeRunner{Parallelism: 2, BatchSize: 2}
+		runner.Run(context.NewAdminContext(), task, []string{"a", "b", "c", "d", "e"},
+			func(id string) error {
+				lock.Lock()
+				defer lock.Unlock()
+				processed = append(processed, id)
+				return nil
+			})
+
+		sort.Strings(processed)
+		assertTestResult(t, processed, []string{"a", "b", "c", "d", "e"})
+		assertTestResult(t, task.CompletedItems, int64(5))
+		assertTestResult(t, task.Checkpoint, "e")
+		assertTestResult(t, task.Status, model.CascadeTaskDone)
+		// One checkpoint per batch of two plus the closing update.
+		mockClient.AssertNumberOfCalls(t, "UpdateCascadeTask", 4)
+	})
+
+	t.Run("Should count failed items and close the task with error", func(t *testing.T) {
+		task := &model.CascadeTaskSpec{BaseModel: &model.BaseModel{}, TotalItems: 2,
+			Status: model.CascadeTaskRunning}
+		mockClient := new(dbtest.Client)
+		mockClient.On("UpdateCascadeTask", context.NewAdminContext(), task).Return(task, nil)
+		db.C = mockClient
+
+		runner := CascadeRunner{Parallelism: 1, BatchSize: 10}
+		runner.Run(context.NewAdminContext(), task, []string{"a", "b"},
+			func(id string) error {
+				if id == "b" {
+					return errors.New("dock is gone")
+				}
+				return nil
+			})
+
+		assertTestResult(t, task.CompletedItems, int64(2))
+		assertTestResult(t, task.FailedItems, int64(1))
+		assertTestResult(t, task.Status, model.CascadeTaskError)
+	})
+
+	t.Run("Should resume after the checkpoint of an interrupted task", func(t *testing.T) {
+		task := &model.CascadeTaskSpec{BaseModel: &model.BaseModel{}, TotalItems: 4,
+			Status: model.CascadeTaskRunning, CompletedItems: 2, Checkpoint: "b"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("UpdateCascadeTask", context.NewAdminContext(), task).Return(task, nil)
+		db.C = mockClient
+
+		var lock sync.Mutex
+		var processed []string
+		runner := CascadeRunner{Parallelism: 1, BatchSize: 10}
+		runner.Run(context.NewAdminContext(), task, []string{"a", "b", "c", "d"},
+			func(id string) error {
+				lock.Lock()
+				defer lock.Unlock()
+				processed = append(processed, id)
+				return nil
+			})
+
+		sort.Strings(processed)
+		assertTestResult(t, processed, []string{"c", "d"})
+		assertTestResult(t, task.CompletedItems, int64(4))
+		assertTestResult(t, task.Checkpoint, "d")
+		assertTestResult(t, task.Status, model.CascadeTaskDone)
+	})
+}
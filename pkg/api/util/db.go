@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
 	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
 	"github.com/sodafoundation/api/pkg/utils/constants"
 	uuid "github.com/satori/go.uuid"
 )
@@ -41,10 +43,10 @@ func CreateFileShareAclDBEntry(ctx *c.Context, in *model.FileShareAclSpec) (*mod
 		in.Id = uuid.NewV4().String()
 	}
 	if in.CreatedAt == "" {
-		in.CreatedAt = time.Now().Format(constants.TimeFormat)
+		in.CreatedAt = utils.FormatTime(time.Now())
 	}
 	if in.UpdatedAt == "" {
-		in.UpdatedAt = time.Now().Format(constants.TimeFormat)
+		in.UpdatedAt = utils.FormatTime(time.Now())
 	}
 	in.Status = model.FileShareAclAvailable
 	// validate profileId
@@ -54,8 +56,8 @@ func CreateFileShareAclDBEntry(ctx *c.Context, in *model.FileShareAclSpec) (*mod
 		return nil, errors.New(errMsg)
 	}
 	// validate type
-	if in.Type != "ip" {
-		errMsg := fmt.Sprintf("invalid fileshare type: %v. Supported type is: ip", in.Type)
+	if !utils.Contained(in.Type, model.FileShareAclTypes) {
+		errMsg := fmt.Sprintf("invalid fileshare acl type: %v. Supported types are: %v", in.Type, model.FileShareAclTypes)
 		log.Error(errMsg)
 		return nil, errors.New(errMsg)
 	}
@@ -65,22 +67,42 @@ func CreateFileShareAclDBEntry(ctx *c.Context, in *model.FileShareAclSpec) (*mod
 		errMsg := fmt.Sprintf("accessTo is empty. Please give valid ip segment")
 		log.Error(errMsg)
 		return nil, errors.New(errMsg)
-	} else if strings.Contains(accessto, "/") {
-		first, cidr, bool := net.ParseCIDR(accessto)
-		log.Info(first, cidr)
-		if bool != nil {
-			errMsg := fmt.Sprintf("invalid IP segment %v", accessto)
-			log.Error(errMsg)
-			return nil, errors.New(errMsg)
-		}
-	} else {
-		server := net.ParseIP(in.AccessTo)
-		if server == nil {
-			errMsg := fmt.Sprintf("%v is not a valid ip. Please give the proper ip", in.AccessTo)
-			log.Error(errMsg)
-			return nil, errors.New(errMsg)
+	} else if in.Type == model.FileShareAclTypeIp {
+		if strings.Contains(accessto, "/") {
+			first, cidr, bool := net.ParseCIDR(accessto)
+			log.Info(first, cidr)
+			if bool != nil {
+				errMsg := fmt.Sprintf("invalid IP segment %v", accessto)
+				log.Error(errMsg)
+				return nil, errors.New(errMsg)
+			}
+		} else {
+			server := net.ParseIP(in.AccessTo)
+			if server == nil {
+				errMsg := fmt.Sprintf("%v is not a valid ip. Please give the proper ip", in.AccessTo)
+				log.Error(errMsg)
+				return nil, errors.New(errMsg)
+			}
 		}
 	}
+	// validate the security flavor; the kerberos flavors authenticate a
+	// principal, so they only combine with user based access
+	in.SecurityFlavor = strings.ToLower(in.SecurityFlavor)
+	if in.SecurityFlavor == "" {
+		in.SecurityFlavor = model.FileShareAclSecuritySys
+	}
+	if !utils.Contained(in.SecurityFlavor, model.FileShareAclSecurityFlavors) {
+		errMsg := fmt.Sprintf("invalid fileshare acl securityFlavor: %v. Supported flavors are: %v",
+			in.SecurityFlavor, model.FileShareAclSecurityFlavors)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	if in.SecurityFlavor != model.FileShareAclSecuritySys && in.Type != model.FileShareAclTypeUser {
+		errMsg := fmt.Sprintf("security flavor %s authenticates principals and requires acl type %s, got %s",
+			in.SecurityFlavor, model.FileShareAclTypeUser, in.Type)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
 	// validate accesscapability
 	accessCapability := in.AccessCapability
 	if len(accessCapability) == 0 {
@@ -97,6 +119,16 @@ func CreateFileShareAclDBEntry(ctx *c.Context, in *model.FileShareAclSpec) (*mod
 			return nil, errors.New(errMsg)
 		}
 	}
+	// validate expiresAt against the policy limit
+	if err := ValidateAclExpiresAt(in.ExpiresAt); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	// the system metadata section is recorded by the server
+	if err := ValidateSystemMetadata(in.Metadata); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
 	// get fileshare details
 	fileshare, err := db.C.GetFileShare(ctx, in.FileShareId)
 	if err != nil {
@@ -109,6 +141,49 @@ func CreateFileShareAclDBEntry(ctx *c.Context, in *model.FileShareAclSpec) (*mod
 		return nil, errors.New(errMsg)
 	}
 
+	// validate the access type and security flavor against the
+	// capabilities of the backend, so an acl the driver can not enforce is
+	// refused here instead of failing silently later. Pools that advertise
+	// no supported acl types are assumed to support ip based access only,
+	// pools that advertise no security flavors to support sys only.
+	supportedTypes := []string{model.FileShareAclTypeIp}
+	supportedFlavors := []string{model.FileShareAclSecuritySys}
+	if pool, err := db.C.GetPool(ctx, fileshare.PoolId); err != nil {
+		log.Warningf("can not read pool %s of fileshare %s, assuming ip based access only: %v",
+			fileshare.PoolId, fileshare.Id, err)
+	} else {
+		if len(pool.Extras.SupportedAclTypes) != 0 {
+			supportedTypes = pool.Extras.SupportedAclTypes
+		}
+		if len(pool.Extras.SupportedSecurityFlavors) != 0 {
+			supportedFlavors = pool.Extras.SupportedSecurityFlavors
+		}
+	}
+	if !utils.Contained(in.Type, supportedTypes) {
+		errMsg := fmt.Sprintf("access type %s is not supported by the backend of fileshare %s, supported types are: %v",
+			in.Type, fileshare.Id, supportedTypes)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	if !utils.Contained(in.SecurityFlavor, supportedFlavors) {
+		errMsg := fmt.Sprintf("security flavor %s is not supported by the backend of fileshare %s, supported flavors are: %v",
+			in.SecurityFlavor, fileshare.Id, supportedFlavors)
+		log.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+
+	// the acl starts pending on every export location of the fileshare;
+	// the controller flips the entries to applied or failed as the
+	// locations report back
+	in.ExportStatuses = nil
+	for _, location := range fileshare.ExportLocations {
+		in.ExportStatuses = append(in.ExportStatuses, &model.AclExportStatusSpec{
+			ExportLocation: location,
+			Status:         model.AclExportPending,
+		})
+	}
+
+	in.Metadata = ApplySystemMetadata(ctx, in.Metadata)
 	return db.C.CreateFileShareAcl(ctx, in)
 }
 
@@ -165,10 +240,22 @@ func CreateFileShareDBEntry(ctx *c.Context, in *model.FileShareSpec) (*model.Fil
 		in.AvailabilityZone = "default"
 	}
 	if in.CreatedAt == "" {
-		in.CreatedAt = time.Now().Format(constants.TimeFormat)
+		in.CreatedAt = utils.FormatTime(time.Now())
+	}
+
+	// Enforce the validation rules of the resource class of the tenant.
+	if err := ValidateWithResourceClass(ctx, in.Name, in.AvailabilityZone, in.Size, in.Metadata); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	// Enforce the gb quota of the tenant, emitting warning events when a
+	// soft threshold is crossed.
+	if err := CheckQuota(ctx, in.Size); err != nil {
+		log.Error(err.Error())
+		return nil, err
 	}
 	if in.UpdatedAt == "" {
-		in.UpdatedAt = time.Now().Format(constants.TimeFormat)
+		in.UpdatedAt = utils.FormatTime(time.Now())
 	}
 	//validate the name
 	if in.Name == "" {
@@ -194,12 +281,224 @@ func CreateFileShareDBEntry(ctx *c.Context, in *model.FileShareSpec) (*model.Fil
 		return nil, errors.New(errMsg)
 	}
 
+	// validate expiresAt
+	if err := ValidateExpiresAt(in.ExpiresAt); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// the system metadata section is recorded by the server
+	if err := ValidateSystemMetadata(in.Metadata); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// fall back to an alternate availability zone when the requested one
+	// has no capacity, recording the requested zone in the metadata
+	if zone, err := ApplyAZFallback(ctx, in.ProfileId, constants.File, in.Size, in.AvailabilityZone); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	} else if zone != in.AvailabilityZone {
+		if in.Metadata == nil {
+			in.Metadata = map[string]string{}
+		}
+		in.Metadata[RequestedAvailabilityZoneKey] = in.AvailabilityZone
+		in.AvailabilityZone = zone
+	}
+
+	// validate the requested size against the registered file pools
+	if err := ValidatePoolCapacity(ctx, constants.File, in.Size); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// let the configured validation webhook veto or mutate the fileshare
+	if err := ValidateWithWebhook(ctx, "fileshare", WebhookOperationCreate, in); err != nil {
+		return nil, err
+	}
+
 	in.UserId = ctx.UserId
 	in.Status = model.FileShareCreating
+	in.Metadata = ApplySystemMetadata(ctx, in.Metadata)
 	// Store the fileshare meadata into database.
 	return db.C.CreateFileShare(ctx, in)
 }
 
+// ValidateExpiresAt checks that an expiresAt value given by the user parses
+// in the api time format and lies in the future. An empty value is valid and
+// means the resource never expires.
+func ValidateExpiresAt(expiresAt string) error {
+	if expiresAt == "" {
+		return nil
+	}
+	t, err := utils.ParseTime(expiresAt)
+	if err != nil {
+		return err
+	}
+	if !t.After(time.Now()) {
+		return fmt.Errorf("expiresAt %s is already in the past", expiresAt)
+	}
+	return nil
+}
+
+// ValidateAclExpiresAt checks an acl expiresAt like ValidateExpiresAt and
+// additionally refuses access grants reaching further into the future than
+// the acl_max_lifetime policy limit allows.
+func ValidateAclExpiresAt(expiresAt string) error {
+	if err := ValidateExpiresAt(expiresAt); err != nil {
+		return err
+	}
+	maxLifetime := CONF.OsdsApiServer.AclMaxLifetime
+	if expiresAt == "" || maxLifetime <= 0 {
+		return nil
+	}
+	t, err := utils.ParseTime(expiresAt)
+	if err != nil {
+		return err
+	}
+	if t.After(time.Now().Add(maxLifetime)) {
+		return fmt.Errorf("expiresAt %s is beyond the acl lifetime limit of %v", expiresAt, maxLifetime)
+	}
+	return nil
+}
+
+// SnapshotChainDepthKey is the metadata key under which the depth of the
+// snapshot/clone chain a resource belongs to is tracked. A freshly created
+// resource sits at depth zero, each snapshot taken adds one level and a
+// resource created from a snapshot stays at the depth of that snapshot.
+const SnapshotChainDepthKey = "snapshotChainDepth"
+
+// ChainDepth reads the tracked chain depth out of a metadata map. It is
+// zero for resources that are not part of a chain or whose tracked value
+// does not parse.
+func ChainDepth(metadata map[string]string) int {
+	depth, err := strconv.Atoi(metadata[SnapshotChainDepthKey])
+	if err != nil || depth < 0 {
+		return 0
+	}
+	return depth
+}
+
+// validateChainDepth checks the depth a new snapshot would sit at against
+// the configured limit, so snapshot/clone chains can not grow past what
+// the backends support. A zero limit leaves the depth unbounded.
+func validateChainDepth(depth int) error {
+	if limit := CONF.OsdsApiServer.MaxSnapshotChainDepth; limit > 0 && depth > limit {
+		return fmt.Errorf("snapshot chain depth %d exceeds the configured limit of %d", depth, limit)
+	}
+	return nil
+}
+
+// ValidatePoolCapacity checks that at least one pool of the given storage
+// type can host size GB, honoring each pool's reserved capacity and its over
+// subscription limit. When no pool of that type is registered yet the check
+// is skipped, placement is then fully up to the scheduler. With
+// reject_stale_pools enabled a pool whose record went stale no longer
+// counts, its reported free capacity can not be trusted. A pool in
+// maintenance never counts, it is unschedulable by definition.
+func ValidatePoolCapacity(ctx *c.Context, storageType string, size int64) error {
+	pools, err := db.C.ListPools(ctx)
+	if err != nil {
+		return err
+	}
+	rejectStale := CONF.OsdsApiServer.RejectStalePools
+	var found, stale bool
+	for _, pool := range pools {
+		if pool.StorageType != storageType {
+			continue
+		}
+		if pool.Maintenance {
+			continue
+		}
+		if rejectStale && PoolIsStale(pool) {
+			stale = true
+			continue
+		}
+		found = true
+		if poolHasCapacity(pool, size) {
+			return nil
+		}
+	}
+	if !found {
+		if stale {
+			return fmt.Errorf("all %s pools have gone stale, their docks stopped reporting", storageType)
+		}
+		return nil
+	}
+	return fmt.Errorf("no %s pool has enough free capacity to host %d GB", storageType, size)
+}
+
+// poolHasCapacity reports whether one pool can host size GB. Thin
+// provisioned pools may promise more than their free capacity, but never
+// more than their usable capacity times the over subscription ratio.
+func poolHasCapacity(pool *model.StoragePoolSpec, size int64) bool {
+	if size <= pool.FreeCapacity-pool.ReservedCapacity {
+		return true
+	}
+	if pool.MaxOverSubscriptionRatio > 1 {
+		virtual := float64(pool.TotalCapacity-pool.ReservedCapacity) * pool.MaxOverSubscriptionRatio
+		return float64(pool.ConsumedCapacity+size) <= virtual
+	}
+	return false
+}
+
+// ValidateFileShareProtocols normalizes the protocols requested for a new
+// fileshare and checks them against the profile constraint and the protocols
+// advertised by the registered file pools. When the user requests nothing and
+// the profile suggests an access protocol, that protocol becomes the default.
+func ValidateFileShareProtocols(ctx *c.Context, prf *model.ProfileSpec, in *model.FileShareSpec) error {
+	if len(in.Protocols) == 0 {
+		if protocol := prf.ProvisioningProperties.IOConnectivity.AccessProtocol; protocol != "" {
+			in.Protocols = []string{strings.ToUpper(protocol)}
+		}
+		return nil
+	}
+
+	var protocols []string
+	for _, protocol := range in.Protocols {
+		protocol = strings.ToUpper(protocol)
+		if protocol != model.FileShareProtocolNFS && protocol != model.FileShareProtocolCIFS {
+			return fmt.Errorf("invalid fileshare protocol %s, supported protocols are %s and %s",
+				protocol, model.FileShareProtocolNFS, model.FileShareProtocolCIFS)
+		}
+		if constraint := prf.ProvisioningProperties.IOConnectivity.AccessProtocol; constraint != "" &&
+			!strings.EqualFold(constraint, protocol) {
+			return fmt.Errorf("protocol %s is not allowed by profile %s, it only provisions %s",
+				protocol, prf.Name, constraint)
+		}
+		if !utils.Contained(protocol, protocols) {
+			protocols = append(protocols, protocol)
+		}
+	}
+	in.Protocols = protocols
+
+	// Each requested protocol must be offered by at least one registered file
+	// pool. When no file pool advertises a protocol yet the check is skipped,
+	// placement is then fully up to the scheduler.
+	pools, err := db.C.ListPools(ctx)
+	if err != nil {
+		return err
+	}
+	var poolProtocols []string
+	for _, pool := range pools {
+		if pool.StorageType != constants.File {
+			continue
+		}
+		if protocol := pool.Extras.IOConnectivity.AccessProtocol; protocol != "" {
+			poolProtocols = append(poolProtocols, strings.ToUpper(protocol))
+		}
+	}
+	if len(poolProtocols) == 0 {
+		return nil
+	}
+	for _, protocol := range protocols {
+		if !utils.Contained(protocol, poolProtocols) {
+			return fmt.Errorf("no registered file pool supports protocol %s", protocol)
+		}
+	}
+	return nil
+}
+
 // DeleteFileShareDBEntry just modifies the state of the fileshare to be deleting in
 // the DB, the real deletion operation would be executed in another new thread.
 func DeleteFileShareDBEntry(ctx *c.Context, in *model.FileShareSpec) error {
@@ -251,6 +550,13 @@ func CreateFileShareSnapshotDBEntry(ctx *c.Context, in *model.FileShareSnapshotS
 		log.Error(errMsg)
 		return nil, errors.New(errMsg)
 	}
+
+	// the snapshot sits one level deeper in the chain than its fileshare
+	depth := ChainDepth(fshare.Metadata) + 1
+	if err := validateChainDepth(depth); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
 	// validate profileId
 	if in.ProfileId == "" {
 		errMsg := "profile id can not be empty when creating fileshare snapshot in db!"
@@ -278,7 +584,7 @@ func CreateFileShareSnapshotDBEntry(ctx *c.Context, in *model.FileShareSnapshotS
 		in.Id = uuid.NewV4().String()
 	}
 	if in.CreatedAt == "" {
-		in.CreatedAt = time.Now().Format(constants.TimeFormat)
+		in.CreatedAt = utils.FormatTime(time.Now())
 	}
 
 	//validate the snapshot name
@@ -303,8 +609,24 @@ func CreateFileShareSnapshotDBEntry(ctx *c.Context, in *model.FileShareSnapshotS
 		log.Error(errMsg)
 		return nil, errors.New(errMsg)
 	}
+	// validate expiresAt
+	if err := ValidateExpiresAt(in.ExpiresAt); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	// the system metadata section is recorded by the server
+	if err := ValidateSystemMetadata(in.Metadata); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	in.UserId = ctx.UserId
 	in.Status = model.FileShareSnapCreating
-	in.Metadata = fshare.Metadata
+	in.Metadata = utils.MergeStringMaps(fshare.Metadata, map[string]string{
+		SnapshotChainDepthKey: strconv.Itoa(depth),
+	})
+	// recorded after the merge, so the section describes this create and
+	// not the parent fileshare's
+	in.Metadata = ApplySystemMetadata(ctx, in.Metadata)
 	return db.C.CreateFileShareSnapshot(ctx, in)
 }
 
@@ -372,6 +694,12 @@ func CreateVolumeDBEntry(ctx *c.Context, in *model.VolumeSpec) (*model.VolumeSpe
 		return nil, errors.New(errMsg)
 	}
 
+	// the system metadata section is recorded by the server
+	if err := ValidateSystemMetadata(in.Metadata); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
 	if in.SnapshotId != "" {
 		snap, err := db.C.GetVolumeSnapshot(ctx, in.SnapshotId)
 		if err != nil {
@@ -388,17 +716,62 @@ func CreateVolumeDBEntry(ctx *c.Context, in *model.VolumeSpec) (*model.VolumeSpe
 			log.Error(errMsg)
 			return nil, errors.New(errMsg)
 		}
+		// the new volume stays at the chain depth of the snapshot, so
+		// re-snapshotting it keeps deepening the chain
+		if depth := ChainDepth(snap.Metadata); depth > 0 {
+			if in.Metadata == nil {
+				in.Metadata = map[string]string{}
+			}
+			in.Metadata[SnapshotChainDepthKey] = strconv.Itoa(depth)
+		}
 	}
 	if in.AvailabilityZone == "" {
 		log.Warning("Use default availability zone when user doesn't specify availabilityZone.")
 		in.AvailabilityZone = "default"
 	}
 	if in.CreatedAt == "" {
-		in.CreatedAt = time.Now().Format(constants.TimeFormat)
+		in.CreatedAt = utils.FormatTime(time.Now())
+	}
+
+	// Enforce the validation rules of the resource class of the tenant.
+	if err := ValidateWithResourceClass(ctx, in.Name, in.AvailabilityZone, in.Size, in.Metadata); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	// Enforce the gb quota of the tenant, emitting warning events when a
+	// soft threshold is crossed.
+	if err := CheckQuota(ctx, in.Size); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// fall back to an alternate availability zone when the requested one
+	// has no capacity, recording the requested zone in the metadata
+	if zone, err := ApplyAZFallback(ctx, in.ProfileId, constants.Block, in.Size, in.AvailabilityZone); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	} else if zone != in.AvailabilityZone {
+		if in.Metadata == nil {
+			in.Metadata = map[string]string{}
+		}
+		in.Metadata[RequestedAvailabilityZoneKey] = in.AvailabilityZone
+		in.AvailabilityZone = zone
+	}
+
+	// validate the requested size against the registered block pools
+	if err := ValidatePoolCapacity(ctx, constants.Block, in.Size); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	// let the configured validation webhook veto or mutate the volume
+	if err := ValidateWithWebhook(ctx, "volume", WebhookOperationCreate, in); err != nil {
+		return nil, err
 	}
 
 	in.UserId = ctx.UserId
 	in.Status = model.VolumeCreating
+	in.Metadata = ApplySystemMetadata(ctx, in.Metadata)
 	// Store the volume data into database.
 	return db.C.CreateVolume(ctx, in)
 }
@@ -459,8 +832,15 @@ func ExtendVolumeDBEntry(ctx *c.Context, volID string, in *model.ExtendVolumeSpe
 		log.Error(errMsg)
 		return nil, errors.New(errMsg)
 	}
+	if err := CheckResizeAllowed(volume.Metadata, volume.Size, in.NewSize); err != nil {
+		log.Error("extend volume rejected by resize protection: ", err)
+		return nil, err
+	}
 
 	volume.Status = model.VolumeExtending
+	if CONF.OsdsApiServer.ResizeCooldown > 0 {
+		volume.Metadata = MarkResized(volume.Metadata)
+	}
 	// Store the volume data into database.
 	return db.C.ExtendVolume(ctx, volume)
 }
@@ -485,15 +865,32 @@ func CreateVolumeSnapshotDBEntry(ctx *c.Context, in *model.VolumeSnapshotSpec) (
 		return nil, errors.New(errMsg)
 	}
 
+	// the snapshot sits one level deeper in the chain than its volume
+	depth := ChainDepth(vol.Metadata) + 1
+	if err := validateChainDepth(depth); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
 	if in.Id == "" {
 		in.Id = uuid.NewV4().String()
 	}
 	if in.CreatedAt == "" {
-		in.CreatedAt = time.Now().Format(constants.TimeFormat)
+		in.CreatedAt = utils.FormatTime(time.Now())
 	}
 
+	// the system metadata section is recorded by the server
+	if err := ValidateSystemMetadata(in.Metadata); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	in.UserId = ctx.UserId
 	in.Status = model.VolumeSnapCreating
 	in.Metadata = utils.MergeStringMaps(in.Metadata, vol.Metadata)
+	in.Metadata[SnapshotChainDepthKey] = strconv.Itoa(depth)
+	// recorded after the merge, so the section describes this create and
+	// not the parent volume's
+	in.Metadata = ApplySystemMetadata(ctx, in.Metadata)
 	return db.C.CreateVolumeSnapshot(ctx, in)
 }
 
@@ -592,9 +989,15 @@ func CreateReplicationDBEntry(ctx *c.Context, in *model.ReplicationSpec) (*model
 		in.Id = uuid.NewV4().String()
 	}
 	if in.CreatedAt == "" {
-		in.CreatedAt = time.Now().Format(constants.TimeFormat)
+		in.CreatedAt = utils.FormatTime(time.Now())
 	}
 
+	// the system metadata section is recorded by the server
+	if err := ValidateSystemMetadata(in.Metadata); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	in.Metadata = ApplySystemMetadata(ctx, in.Metadata)
 	in.ReplicationStatus = model.ReplicationCreating
 	return db.C.CreateReplication(ctx, in)
 }
@@ -698,7 +1101,7 @@ func CreateVolumeGroupDBEntry(ctx *c.Context, in *model.VolumeGroupSpec) (*model
 		in.Id = uuid.NewV4().String()
 	}
 	if in.CreatedAt == "" {
-		in.CreatedAt = time.Now().Format(constants.TimeFormat)
+		in.CreatedAt = utils.FormatTime(time.Now())
 	}
 	if in.AvailabilityZone == "" {
 		log.Warning("Use default availability zone when user doesn't specify availabilityZone.")
@@ -777,7 +1180,7 @@ func UpdateVolumeGroupDBEntry(ctx *c.Context, vgUpdate *model.VolumeGroupSpec) (
 		},
 	}
 
-	vgNew.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	vgNew.UpdatedAt = utils.FormatTime(time.Now())
 	// Only update name or description. No need to send them over through an RPC call and set status to available.
 	if name != "" {
 		vgNew.Name = name
@@ -19,12 +19,14 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/sodafoundation/api/pkg/utils"
 
 	"github.com/sodafoundation/api/pkg/context"
 	"github.com/sodafoundation/api/pkg/db"
 	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
 	. "github.com/sodafoundation/api/testutils/collection"
 	dbtest "github.com/sodafoundation/api/testutils/db/testing"
 )
@@ -49,6 +51,7 @@ func TestCreateVolumeDBEntry(t *testing.T) {
 	t.Run("Everything should work well", func(t *testing.T) {
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateVolume", context.NewAdminContext(), in).Return(&SampleVolumes[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		var expected = &SampleVolumes[0]
@@ -63,6 +66,7 @@ func TestCreateVolumeDBEntry(t *testing.T) {
 		in.Size = int64(-2)
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateVolume", context.NewAdminContext(), in).Return(&SampleVolumes[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		_, err := CreateVolumeDBEntry(context.NewAdminContext(), in)
@@ -74,6 +78,7 @@ func TestCreateVolumeDBEntry(t *testing.T) {
 		in.Size, in.ProfileId = int64(1), ""
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateVolume", context.NewAdminContext(), in).Return(&SampleVolumes[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		_, err := CreateVolumeDBEntry(context.NewAdminContext(), in)
@@ -103,6 +108,7 @@ func TestCreateVolumeFromSnapshotDBEntry(t *testing.T) {
 	t.Run("Everything should work well", func(t *testing.T) {
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateVolume", context.NewAdminContext(), in).Return(&SampleVolumes[1], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), "3769855c-a102-11e7-b772-17b880d2f537").Return(snap, nil)
 		db.C = mockClient
 
@@ -118,6 +124,7 @@ func TestCreateVolumeFromSnapshotDBEntry(t *testing.T) {
 		snap.Status = model.VolumeSnapError
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateVolume", context.NewAdminContext(), in).Return(&SampleVolumes[1], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), "3769855c-a102-11e7-b772-17b880d2f537").Return(snap, nil)
 		db.C = mockClient
 
@@ -130,6 +137,7 @@ func TestCreateVolumeFromSnapshotDBEntry(t *testing.T) {
 		snap.Status, snap.Size = model.VolumeSnapAvailable, 10
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateVolume", context.NewAdminContext(), in).Return(&SampleVolumes[1], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		mockClient.On("GetVolumeSnapshot", context.NewAdminContext(), "3769855c-a102-11e7-b772-17b880d2f537").Return(snap, nil)
 		db.C = mockClient
 
@@ -259,6 +267,23 @@ func TestExtendVolumeDBEntry(t *testing.T) {
 			"(current: 20 GB, extended: 2 GB)."
 		assertTestResult(t, err.Error(), expectedError)
 	})
+
+	t.Run("A resize inside the cooldown window should be rejected", func(t *testing.T) {
+		CONF.OsdsApiServer.ResizeCooldown = time.Hour
+		defer func() { CONF.OsdsApiServer.ResizeCooldown = 0 }()
+		vol.Metadata = map[string]string{
+			SystemMetadataLastResizedAtKey: utils.FormatTime(time.Now()),
+		}
+		defer func() { vol.Metadata = nil }()
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", context.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8").Return(vol, nil)
+		db.C = mockClient
+
+		_, err := ExtendVolumeDBEntry(context.NewAdminContext(), vol.Id, &model.ExtendVolumeSpec{NewSize: 30})
+		if _, ok := err.(*ResizeCooldownError); !ok {
+			t.Errorf("expected a *ResizeCooldownError, got %v\n", err)
+		}
+	})
 }
 
 func TestCreateVolumeSnapshotDBEntry(t *testing.T) {
@@ -305,6 +330,51 @@ func TestCreateVolumeSnapshotDBEntry(t *testing.T) {
 		expectedError := "profile id can not be empty when creating volume snapshot in db"
 		assertTestResult(t, err.Error(), expectedError)
 	})
+
+	t.Run("The snapshot should record its chain depth", func(t *testing.T) {
+		var in = &model.VolumeSnapshotSpec{
+			BaseModel: &model.BaseModel{},
+			VolumeId:  vol.Id,
+			Name:      "sample-snapshot-depth",
+			Size:      int64(1),
+			ProfileId: "3769855c-a102-11e7-b772-17b880d2f537",
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(vol, nil)
+		mockClient.On("CreateVolumeSnapshot", context.NewAdminContext(), in).Return(in, nil)
+		db.C = mockClient
+
+		if _, err := CreateVolumeSnapshotDBEntry(context.NewAdminContext(), in); err != nil {
+			t.Errorf("failed to create volume snapshot, err is %v\n", err)
+		}
+		assertTestResult(t, in.Metadata[SnapshotChainDepthKey], "1")
+	})
+
+	t.Run("A snapshot beyond the chain depth limit should be rejected", func(t *testing.T) {
+		CONF.OsdsApiServer.MaxSnapshotChainDepth = 1
+		defer func() { CONF.OsdsApiServer.MaxSnapshotChainDepth = 0 }()
+
+		var deepVol = &model.VolumeSpec{
+			BaseModel: &model.BaseModel{Id: vol.Id},
+			Size:      1,
+			Status:    "available",
+			Metadata:  map[string]string{SnapshotChainDepthKey: "1"},
+		}
+		var in = &model.VolumeSnapshotSpec{
+			BaseModel: &model.BaseModel{},
+			VolumeId:  vol.Id,
+			Name:      "sample-snapshot-too-deep",
+			Size:      int64(1),
+			ProfileId: "3769855c-a102-11e7-b772-17b880d2f537",
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetVolume", context.NewAdminContext(), vol.Id).Return(deepVol, nil)
+		db.C = mockClient
+
+		_, err := CreateVolumeSnapshotDBEntry(context.NewAdminContext(), in)
+		expectedError := "snapshot chain depth 2 exceeds the configured limit of 1"
+		assertTestResult(t, err.Error(), expectedError)
+	})
 }
 
 func TestDeleteVolumeSnapshotDBEntry(t *testing.T) {
@@ -390,6 +460,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 	t.Run("Everything should work well", func(t *testing.T) {
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		var expected = &SampleFileShares[0]
@@ -404,6 +475,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 		in.Size = int64(-2)
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		_, err := CreateFileShareDBEntry(context.NewAdminContext(), in)
@@ -415,6 +487,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 		in.ProfileId = ""
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		_, err := CreateFileShareDBEntry(context.NewAdminContext(), in)
@@ -426,6 +499,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 		in.Size, in.Name, in.ProfileId = int64(1), "", "b3585ebe-c42c-120g-b28e-f373746a71ca"
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		_, err := CreateFileShareDBEntry(context.NewAdminContext(), in)
@@ -438,6 +512,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 		in.Size, in.ProfileId = int64(1), "b3585ebe-c42c-120g-b28e-f373746a71ca"
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		_, err := CreateFileShareDBEntry(context.NewAdminContext(), in)
@@ -450,6 +525,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 		in.Size, in.ProfileId = int64(1), "b3585ebe-c42c-120g-b28e-f373746a71ca"
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		var expected = &SampleFileShares[0]
@@ -465,6 +541,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 		in.Size, in.ProfileId = int64(1), "b3585ebe-c42c-120g-b28e-f373746a71ca"
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		var expected = &SampleFileShares[0]
@@ -480,6 +557,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 		in.Size, in.ProfileId = int64(1), "b3585ebe-c42c-120g-b28e-f373746a71ca"
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		var expected = &SampleFileShares[0]
@@ -495,6 +573,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 		in.Size, in.ProfileId = int64(1), "b3585ebe-c42c-120g-b28e-f373746a71ca"
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		var expected = &SampleFileShares[0]
@@ -510,6 +589,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 		in.Size, in.ProfileId = int64(1), "b3585ebe-c42c-120g-b28e-f373746a71ca"
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		_, err := CreateFileShareDBEntry(context.NewAdminContext(), in)
@@ -522,6 +602,7 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 		in.Size, in.ProfileId = int64(1), "b3585ebe-c42c-120g-b28e-f373746a71ca"
 		mockClient := new(dbtest.Client)
 		mockClient.On("CreateFileShare", context.NewAdminContext(), in).Return(&SampleFileShares[0], nil)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(nil, nil)
 		db.C = mockClient
 
 		_, err := CreateFileShareDBEntry(context.NewAdminContext(), in)
@@ -530,6 +611,76 @@ func TestCreateFileShareDBEntry(t *testing.T) {
 	})
 }
 
+func TestValidateFileShareProtocols(t *testing.T) {
+	var fakeFilePools = []*model.StoragePoolSpec{&SamplePools[2]}
+	var prf = &model.ProfileSpec{
+		BaseModel:   &model.BaseModel{},
+		Name:        "silver",
+		StorageType: "file",
+	}
+
+	t.Run("Requested protocols should be normalized to upper case", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(fakeFilePools, nil)
+		db.C = mockClient
+
+		in := &model.FileShareSpec{BaseModel: &model.BaseModel{}, Protocols: []string{"nfs"}}
+		err := ValidateFileShareProtocols(context.NewAdminContext(), prf, in)
+		if err != nil {
+			t.Errorf("failed to validate fileshare protocols, err is %v\n", err)
+		}
+		assertTestResult(t, in.Protocols, []string{model.FileShareProtocolNFS})
+	})
+
+	t.Run("Profile access protocol should be used as the default", func(t *testing.T) {
+		prfWithProtocol := &model.ProfileSpec{
+			BaseModel:   &model.BaseModel{},
+			Name:        "silver",
+			StorageType: "file",
+		}
+		prfWithProtocol.ProvisioningProperties.IOConnectivity.AccessProtocol = "nfs"
+
+		in := &model.FileShareSpec{BaseModel: &model.BaseModel{}}
+		err := ValidateFileShareProtocols(context.NewAdminContext(), prfWithProtocol, in)
+		if err != nil {
+			t.Errorf("failed to validate fileshare protocols, err is %v\n", err)
+		}
+		assertTestResult(t, in.Protocols, []string{model.FileShareProtocolNFS})
+	})
+
+	t.Run("Unsupported protocols should be rejected", func(t *testing.T) {
+		in := &model.FileShareSpec{BaseModel: &model.BaseModel{}, Protocols: []string{"ftp"}}
+		err := ValidateFileShareProtocols(context.NewAdminContext(), prf, in)
+		expectedError := "invalid fileshare protocol FTP, supported protocols are NFS and CIFS"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Protocols outside the profile constraint should be rejected", func(t *testing.T) {
+		prfWithProtocol := &model.ProfileSpec{
+			BaseModel:   &model.BaseModel{},
+			Name:        "silver",
+			StorageType: "file",
+		}
+		prfWithProtocol.ProvisioningProperties.IOConnectivity.AccessProtocol = "nfs"
+
+		in := &model.FileShareSpec{BaseModel: &model.BaseModel{}, Protocols: []string{"cifs"}}
+		err := ValidateFileShareProtocols(context.NewAdminContext(), prfWithProtocol, in)
+		expectedError := "protocol CIFS is not allowed by profile silver, it only provisions nfs"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Protocols no file pool offers should be rejected", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(fakeFilePools, nil)
+		db.C = mockClient
+
+		in := &model.FileShareSpec{BaseModel: &model.BaseModel{}, Protocols: []string{"cifs"}}
+		err := ValidateFileShareProtocols(context.NewAdminContext(), prf, in)
+		expectedError := "no registered file pool supports protocol CIFS"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+}
+
 func TestDeleteFileShareDBEntry(t *testing.T) {
 	var fileshare = &model.FileShareSpec{
 		BaseModel: &model.BaseModel{
@@ -673,6 +824,7 @@ func TestCreateFileShareAclDBEntry(t *testing.T) {
 	t.Run("Everything should work well", func(t *testing.T) {
 		mockClient := new(dbtest.Client)
 		mockClient.On("GetFileShare", context.NewAdminContext(), in.FileShareId).Return(&SampleFileShares[0], nil)
+		mockClient.On("GetPool", context.NewAdminContext(), SampleFileShares[0].PoolId).Return(&SamplePools[0], nil)
 		mockClient.On("CreateFileShareAcl", context.NewAdminContext(), in).Return(&SampleFileSharesAcl[2], nil)
 		db.C = mockClient
 
@@ -682,6 +834,10 @@ func TestCreateFileShareAclDBEntry(t *testing.T) {
 			t.Errorf("failed to create fileshare err is %v\n", err)
 		}
 		assertTestResult(t, result, expected)
+		// the acl starts pending on every export location of the fileshare
+		assertTestResult(t, in.ExportStatuses, []*model.AclExportStatusSpec{
+			{ExportLocation: "192.168.100.100", Status: model.AclExportPending},
+		})
 	})
 
 	t.Run("If profile id is empty", func(t *testing.T) {
@@ -704,7 +860,7 @@ func TestCreateFileShareAclDBEntry(t *testing.T) {
 		db.C = mockClient
 
 		_, err := CreateFileShareAclDBEntry(context.NewAdminContext(), in)
-		expectedError := fmt.Sprintf("invalid fileshare type: %v. Supported type is: ip", in.Type)
+		expectedError := fmt.Sprintf("invalid fileshare acl type: %v. Supported types are: %v", in.Type, model.FileShareAclTypes)
 		assertTestResult(t, err.Error(), expectedError)
 	})
 
@@ -769,6 +925,31 @@ func TestCreateFileShareAclDBEntry(t *testing.T) {
 		assertTestResult(t, err.Error(), expectedError)
 	})
 
+	t.Run("Invalid security flavor", func(t *testing.T) {
+		in.ProfileId, in.AccessCapability, in.SecurityFlavor = "d2975ebe-d82c-430f-b28e-f373746a71ca", []string{"read"}, "ntlm"
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", context.NewAdminContext(), in.FileShareId).Return(&SampleFileShares[0], nil)
+		mockClient.On("CreateFileShareAcl", context.NewAdminContext(), in).Return(&SampleFileSharesAcl[2], nil)
+		db.C = mockClient
+
+		_, err := CreateFileShareAclDBEntry(context.NewAdminContext(), in)
+		expectedError := fmt.Sprintf("invalid fileshare acl securityFlavor: ntlm. Supported flavors are: %v", model.FileShareAclSecurityFlavors)
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Kerberos flavor with ip based access", func(t *testing.T) {
+		in.SecurityFlavor = "krb5"
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", context.NewAdminContext(), in.FileShareId).Return(&SampleFileShares[0], nil)
+		mockClient.On("CreateFileShareAcl", context.NewAdminContext(), in).Return(&SampleFileSharesAcl[2], nil)
+		db.C = mockClient
+
+		_, err := CreateFileShareAclDBEntry(context.NewAdminContext(), in)
+		expectedError := "security flavor krb5 authenticates principals and requires acl type user, got ip"
+		assertTestResult(t, err.Error(), expectedError)
+		in.SecurityFlavor = ""
+	})
+
 	t.Run("Invalid fileshare id given", func(t *testing.T) {
 		in.ProfileId, in.Type, in.AccessTo, in.AccessCapability = "d2975ebe-d82c-430f-b28e-f373746a71ca", "ip", "201.100.101.9", []string{"read"}
 		mockClient := new(dbtest.Client)
@@ -781,6 +962,56 @@ func TestCreateFileShareAclDBEntry(t *testing.T) {
 		expectedError := "only the status of file share is available, the acl can be created"
 		assertTestResult(t, err.Error(), expectedError)
 	})
+
+	t.Run("ExpiresAt in the past", func(t *testing.T) {
+		in.ExpiresAt = utils.FormatTime(time.Now().Add(-time.Hour))
+		db.C = new(dbtest.Client)
+
+		_, err := CreateFileShareAclDBEntry(context.NewAdminContext(), in)
+		expectedError := fmt.Sprintf("expiresAt %s is already in the past", in.ExpiresAt)
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("ExpiresAt beyond the acl lifetime limit", func(t *testing.T) {
+		CONF.OsdsApiServer.AclMaxLifetime = time.Hour
+		defer func() { CONF.OsdsApiServer.AclMaxLifetime = 0 }()
+		in.ExpiresAt = utils.FormatTime(time.Now().Add(24 * time.Hour))
+		db.C = new(dbtest.Client)
+
+		_, err := CreateFileShareAclDBEntry(context.NewAdminContext(), in)
+		expectedError := fmt.Sprintf("expiresAt %s is beyond the acl lifetime limit of 1h0m0s", in.ExpiresAt)
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Access type not supported by the backend", func(t *testing.T) {
+		SampleFileShares[0].Status = model.FileShareAvailable
+		in.ExpiresAt = ""
+		in.Type, in.AccessTo = model.FileShareAclTypeUser, "alice"
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", context.NewAdminContext(), in.FileShareId).Return(&SampleFileShares[0], nil)
+		mockClient.On("GetPool", context.NewAdminContext(), SampleFileShares[0].PoolId).Return(&SamplePools[0], nil)
+		db.C = mockClient
+
+		_, err := CreateFileShareAclDBEntry(context.NewAdminContext(), in)
+		expectedError := fmt.Sprintf("access type user is not supported by the backend of fileshare %s, supported types are: [ip]",
+			SampleFileShares[0].Id)
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Access type advertised by the pool", func(t *testing.T) {
+		pool := SamplePools[0]
+		pool.Extras.SupportedAclTypes = []string{"ip", "user"}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetFileShare", context.NewAdminContext(), in.FileShareId).Return(&SampleFileShares[0], nil)
+		mockClient.On("GetPool", context.NewAdminContext(), SampleFileShares[0].PoolId).Return(&pool, nil)
+		mockClient.On("CreateFileShareAcl", context.NewAdminContext(), in).Return(&SampleFileSharesAcl[2], nil)
+		db.C = mockClient
+
+		_, err := CreateFileShareAclDBEntry(context.NewAdminContext(), in)
+		if err != nil {
+			t.Errorf("expected the user type acl to pass, got %v", err)
+		}
+	})
 }
 
 func TestDeleteFileShareSnapshotDBEntry(t *testing.T) {
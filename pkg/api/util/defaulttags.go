@@ -0,0 +1,44 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the merge of the tenant default tags into the
+metadata of created resources.
+*/
+
+package util
+
+import (
+	log "github.com/golang/glog"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+// ApplyDefaultTags merges the default tags configured for the tenant of the
+// given context into metadata. A value the user set explicitly wins over
+// the configured default. A tenant without default tags, or a failing
+// lookup, leaves the metadata untouched; missing defaults must never fail
+// a create.
+func ApplyDefaultTags(ctx *c.Context, metadata map[string]string) map[string]string {
+	defaults, err := db.C.GetDefaultTags(ctx, ctx.TenantId)
+	if err != nil {
+		log.V(8).Infof("no default tags for tenant %s: %v", ctx.TenantId, err)
+		return metadata
+	}
+	if len(defaults.Tags) == 0 {
+		return metadata
+	}
+	return utils.MergeStringMaps(defaults.Tags, metadata)
+}
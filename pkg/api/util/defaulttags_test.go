@@ -0,0 +1,66 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func TestApplyDefaultTags(t *testing.T) {
+	var ctx = &c.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96"}
+	var defaults = &model.DefaultTagsSpec{
+		BaseModel: &model.BaseModel{},
+		TenantId:  ctx.TenantId,
+		Tags: map[string]string{
+			"costCenter":  "cc-1042",
+			"environment": "staging",
+		},
+	}
+
+	t.Run("Should merge the defaults with user values winning", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetDefaultTags", ctx, ctx.TenantId).Return(defaults, nil)
+		db.C = mockClient
+
+		got := ApplyDefaultTags(ctx, map[string]string{"environment": "production"})
+		expected := map[string]string{
+			"costCenter":  "cc-1042",
+			"environment": "production",
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("Should leave the metadata untouched when the tenant has no default tags", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetDefaultTags", ctx, ctx.TenantId).
+			Return(nil, errors.New("Wrong resource uuid provided!"))
+		db.C = mockClient
+
+		metadata := map[string]string{"environment": "production"}
+		got := ApplyDefaultTags(ctx, metadata)
+		if !reflect.DeepEqual(got, metadata) {
+			t.Errorf("expected %v, got %v", metadata, got)
+		}
+	})
+}
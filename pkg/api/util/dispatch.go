@@ -0,0 +1,56 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the retry policy of the async dock dispatches. A
+dispatch that fails transiently, e.g. a create issued while the controller
+restarts, is retried with backoff instead of failing the resource on the
+first error.
+*/
+
+package util
+
+import (
+	"time"
+
+	log "github.com/golang/glog"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// DispatchWithRetry runs one async dock dispatch, retrying a failed call
+// up to the configured async_dispatch_max_attempts with an exponentially
+// doubling backoff starting at async_dispatch_retry_backoff. It returns
+// the number of attempts made together with the error of the last one,
+// so the caller can record both when finally marking the resource error.
+// The operation name only labels the retry logs.
+func DispatchWithRetry(operation string, call func() error) (int, error) {
+	maxAttempts := CONF.OsdsApiServer.AsyncDispatchMaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	backoff := CONF.OsdsApiServer.AsyncDispatchRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil {
+			return attempt, nil
+		}
+		if attempt >= maxAttempts {
+			return attempt, err
+		}
+		log.Warningf("attempt %d of %d of dispatching %s failed, retrying in %v: %v",
+			attempt, maxAttempts, operation, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+func TestDispatchWithRetry(t *testing.T) {
+	setPolicy := func(maxAttempts int) func() {
+		CONF.OsdsApiServer.AsyncDispatchMaxAttempts = maxAttempts
+		CONF.OsdsApiServer.AsyncDispatchRetryBackoff = time.Millisecond
+		return func() {
+			CONF.OsdsApiServer.AsyncDispatchMaxAttempts = 0
+			CONF.OsdsApiServer.AsyncDispatchRetryBackoff = 0
+		}
+	}
+
+	t.Run("Should not retry without a configured policy", func(t *testing.T) {
+		var calls int
+		attempts, err := DispatchWithRetry("volume:create", func() error {
+			calls++
+			return errors.New("connection refused")
+		})
+		if err == nil {
+			t.Errorf("expected the dispatch to fail")
+		}
+		assertTestResult(t, calls, 1)
+		assertTestResult(t, attempts, 1)
+	})
+
+	t.Run("Should stop retrying once a call succeeds", func(t *testing.T) {
+		defer setPolicy(5)()
+		var calls int
+		attempts, err := DispatchWithRetry("volume:create", func() error {
+			if calls++; calls < 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("expected the dispatch to pass, got %v", err)
+		}
+		assertTestResult(t, attempts, 3)
+	})
+
+	t.Run("Should give up after the configured attempts", func(t *testing.T) {
+		defer setPolicy(3)()
+		var calls int
+		attempts, err := DispatchWithRetry("volume:create", func() error {
+			calls++
+			return errors.New("connection refused")
+		})
+		assertTestResult(t, err.Error(), "connection refused")
+		assertTestResult(t, calls, 3)
+		assertTestResult(t, attempts, 3)
+	})
+}
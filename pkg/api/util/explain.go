@@ -0,0 +1,129 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the placement dry run behind ?explain=true on the
+create paths. The dry run replays the filters the create path applies to
+the registered pools and records a verdict for every pool, so a "why did
+this land on the wrong backend" investigation does not need to create
+anything.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// ExplainPlacement judges every registered pool for a hypothetical
+// resource of the given profile, zone and size, applying the filters in
+// the order of the create path: storage type, availability zone,
+// maintenance, staleness, the provisioning properties of the profile and
+// finally capacity. A pool is charged to the first filter that rejects
+// it. The candidate is the eligible pool with the most free capacity,
+// which the scheduler is most likely, though not guaranteed, to pick.
+func ExplainPlacement(ctx *c.Context, resourceType string, prf *model.ProfileSpec, zone string, size int64) (*model.ScheduleExplainSpec, error) {
+	pools, err := db.C.ListPools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	explain := &model.ScheduleExplainSpec{
+		ResourceType:     resourceType,
+		ProfileId:        prf.Id,
+		AvailabilityZone: zone,
+		Size:             size,
+		Pools:            []*model.PoolExplainSpec{},
+	}
+	rejectStale := CONF.OsdsApiServer.RejectStalePools
+	var candidate *model.StoragePoolSpec
+	for _, pool := range pools {
+		verdict := &model.PoolExplainSpec{PoolId: pool.Id, Name: pool.Name}
+		explain.Pools = append(explain.Pools, verdict)
+
+		switch {
+		case pool.StorageType != prf.StorageType:
+			verdict.RejectedBy = "storageType"
+			verdict.Reason = fmt.Sprintf("the pool serves %s, not %s", pool.StorageType, prf.StorageType)
+		case pool.AvailabilityZone != zone:
+			verdict.RejectedBy = "availabilityZone"
+			verdict.Reason = fmt.Sprintf("the pool is in availability zone %s, not %s", pool.AvailabilityZone, zone)
+		case pool.Maintenance:
+			verdict.RejectedBy = "maintenance"
+			verdict.Reason = "the pool is in maintenance"
+		case rejectStale && PoolIsStale(pool):
+			verdict.RejectedBy = "staleness"
+			verdict.Reason = "the pool record went stale, its dock stopped reporting"
+		case !poolMatchesProfile(verdict, pool, prf):
+			// the verdict is filled in by the matcher
+		case !poolHasCapacity(pool, size):
+			verdict.RejectedBy = "capacity"
+			verdict.Reason = fmt.Sprintf("the pool can not host %d GB", size)
+		default:
+			verdict.Eligible = true
+			if candidate == nil || pool.FreeCapacity > candidate.FreeCapacity {
+				candidate = pool
+			}
+		}
+	}
+	if candidate != nil {
+		explain.CandidateId = candidate.Id
+	}
+	return explain, nil
+}
+
+// poolMatchesProfile checks the provisioning properties of the profile
+// against the extras the pool advertises, recording the first mismatch in
+// the verdict. A property only constrains the pool when both sides state
+// it, mirroring the lenient matching of the profile selector.
+func poolMatchesProfile(verdict *model.PoolExplainSpec, pool *model.StoragePoolSpec, prf *model.ProfileSpec) bool {
+	want, got := prf.ProvisioningProperties, pool.Extras
+	if policy := want.DataStorage.ProvisioningPolicy; policy != "" &&
+		got.DataStorage.ProvisioningPolicy != "" &&
+		!strings.EqualFold(got.DataStorage.ProvisioningPolicy, policy) {
+		verdict.RejectedBy = "provisioningPolicy"
+		verdict.Reason = fmt.Sprintf("the profile asks for %s provisioning, the pool offers %s",
+			policy, got.DataStorage.ProvisioningPolicy)
+		return false
+	}
+	if protocol := want.IOConnectivity.AccessProtocol; protocol != "" &&
+		got.IOConnectivity.AccessProtocol != "" &&
+		!strings.EqualFold(got.IOConnectivity.AccessProtocol, protocol) {
+		verdict.RejectedBy = "accessProtocol"
+		verdict.Reason = fmt.Sprintf("the profile asks for the %s protocol, the pool offers %s",
+			protocol, got.IOConnectivity.AccessProtocol)
+		return false
+	}
+	if iops := want.IOConnectivity.MaxIOPS; iops > 0 &&
+		got.IOConnectivity.MaxIOPS > 0 && got.IOConnectivity.MaxIOPS < iops {
+		verdict.RejectedBy = "maxIOPS"
+		verdict.Reason = fmt.Sprintf("the profile asks for %d IOPS, the pool offers %d",
+			iops, got.IOConnectivity.MaxIOPS)
+		return false
+	}
+	if bws := want.IOConnectivity.MaxBWS; bws > 0 &&
+		got.IOConnectivity.MaxBWS > 0 && got.IOConnectivity.MaxBWS < bws {
+		verdict.RejectedBy = "maxBWS"
+		verdict.Reason = fmt.Sprintf("the profile asks for %d MB/s, the pool offers %d",
+			bws, got.IOConnectivity.MaxBWS)
+		return false
+	}
+	return true
+}
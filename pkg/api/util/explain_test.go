@@ -0,0 +1,141 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func TestExplainPlacement(t *testing.T) {
+	var mockPools = func(pools ...*model.StoragePoolSpec) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListPools", context.NewAdminContext()).Return(pools, nil)
+		db.C = mockClient
+	}
+	blockProfile := &model.ProfileSpec{
+		BaseModel:   &model.BaseModel{Id: "1106b972-66ef-11e7-b172-db03f3689c9c"},
+		StorageType: "block",
+	}
+
+	t.Run("Should charge each pool to the first filter that rejects it", func(t *testing.T) {
+		mockPools(
+			&model.StoragePoolSpec{
+				BaseModel:        &model.BaseModel{Id: "file-pool"},
+				Name:             "file-pool",
+				StorageType:      "file",
+				AvailabilityZone: "default",
+			},
+			&model.StoragePoolSpec{
+				BaseModel:        &model.BaseModel{Id: "other-zone"},
+				Name:             "other-zone",
+				StorageType:      "block",
+				AvailabilityZone: "az-b",
+			},
+			&model.StoragePoolSpec{
+				BaseModel:        &model.BaseModel{Id: "under-maintenance"},
+				Name:             "under-maintenance",
+				StorageType:      "block",
+				AvailabilityZone: "default",
+				Maintenance:      true,
+				FreeCapacity:     100,
+			},
+			&model.StoragePoolSpec{
+				BaseModel:        &model.BaseModel{Id: "too-small"},
+				Name:             "too-small",
+				StorageType:      "block",
+				AvailabilityZone: "default",
+				FreeCapacity:     5,
+			},
+			&model.StoragePoolSpec{
+				BaseModel:        &model.BaseModel{Id: "eligible"},
+				Name:             "eligible",
+				StorageType:      "block",
+				AvailabilityZone: "default",
+				FreeCapacity:     100,
+			},
+		)
+		explain, err := ExplainPlacement(context.NewAdminContext(), "volume", blockProfile, "default", 10)
+		if err != nil {
+			t.Errorf("expected the dry run to pass, got %v", err)
+		}
+		var rejections = map[string]string{}
+		for _, verdict := range explain.Pools {
+			rejections[verdict.PoolId] = verdict.RejectedBy
+		}
+		assertTestResult(t, len(explain.Pools), 5)
+		assertTestResult(t, rejections["file-pool"], "storageType")
+		assertTestResult(t, rejections["other-zone"], "availabilityZone")
+		assertTestResult(t, rejections["under-maintenance"], "maintenance")
+		assertTestResult(t, rejections["too-small"], "capacity")
+		assertTestResult(t, rejections["eligible"], "")
+		assertTestResult(t, explain.CandidateId, "eligible")
+	})
+
+	t.Run("Should reject a pool whose extras contradict the profile", func(t *testing.T) {
+		iscsiProfile := &model.ProfileSpec{
+			BaseModel:   &model.BaseModel{Id: "2f9c0a04-66ef-11e7-ada2-43158893e017"},
+			StorageType: "block",
+			ProvisioningProperties: model.ProvisioningPropertiesSpec{
+				IOConnectivity: model.IOConnectivityLoS{AccessProtocol: "iscsi"},
+			},
+		}
+		mockPools(&model.StoragePoolSpec{
+			BaseModel:        &model.BaseModel{Id: "rbd-pool"},
+			Name:             "rbd-pool",
+			StorageType:      "block",
+			AvailabilityZone: "default",
+			FreeCapacity:     100,
+			Extras: model.StoragePoolExtraSpec{
+				IOConnectivity: model.IOConnectivityLoS{AccessProtocol: "rbd"},
+			},
+		})
+		explain, err := ExplainPlacement(context.NewAdminContext(), "volume", iscsiProfile, "default", 10)
+		if err != nil {
+			t.Errorf("expected the dry run to pass, got %v", err)
+		}
+		assertTestResult(t, explain.Pools[0].RejectedBy, "accessProtocol")
+		assertTestResult(t, explain.Pools[0].Reason, "the profile asks for the iscsi protocol, the pool offers rbd")
+		assertTestResult(t, explain.CandidateId, "")
+	})
+
+	t.Run("Should pick the eligible pool with the most free capacity", func(t *testing.T) {
+		mockPools(
+			&model.StoragePoolSpec{
+				BaseModel:        &model.BaseModel{Id: "smaller"},
+				Name:             "smaller",
+				StorageType:      "block",
+				AvailabilityZone: "default",
+				FreeCapacity:     50,
+			},
+			&model.StoragePoolSpec{
+				BaseModel:        &model.BaseModel{Id: "larger"},
+				Name:             "larger",
+				StorageType:      "block",
+				AvailabilityZone: "default",
+				FreeCapacity:     200,
+			},
+		)
+		explain, err := ExplainPlacement(context.NewAdminContext(), "volume", blockProfile, "default", 10)
+		if err != nil {
+			t.Errorf("expected the dry run to pass, got %v", err)
+		}
+		assertTestResult(t, explain.CandidateId, "larger")
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+// Roles that may read sensitive response fields such as connection data
+// and credentials. These are the attaching host and service roles, a
+// read-only role like auditor is not among them.
+var secretViewerRoles = []string{"admin", "service", "attacher"}
+
+// CanViewSecrets reports whether the context may read sensitive response
+// fields. Admins and the roles used by attaching hosts and services can,
+// every other role gets a masked response.
+func CanViewSecrets(ctx *c.Context) bool {
+	if ctx.IsAdmin {
+		return true
+	}
+	for _, role := range ctx.Roles {
+		if utils.Contained(strings.ToLower(role), secretViewerRoles) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskVolumeAttachment returns a copy of the attachment with the secret
+// carrying connection fields removed when the context may not read them.
+// The original is left untouched so nothing masked leaks back into the db.
+func MaskVolumeAttachment(ctx *c.Context, in *model.VolumeAttachmentSpec) *model.VolumeAttachmentSpec {
+	if CanViewSecrets(ctx) {
+		return in
+	}
+	masked := *in
+	masked.ConnectionInfo = model.ConnectionInfo{
+		DriverVolumeType: in.ConnectionInfo.DriverVolumeType,
+	}
+	return &masked
+}
+
+// MaskVolumeAttachments masks a list of attachments, see
+// MaskVolumeAttachment.
+func MaskVolumeAttachments(ctx *c.Context, in []*model.VolumeAttachmentSpec) []*model.VolumeAttachmentSpec {
+	if CanViewSecrets(ctx) {
+		return in
+	}
+	var masked []*model.VolumeAttachmentSpec
+	for _, attachment := range in {
+		masked = append(masked, MaskVolumeAttachment(ctx, attachment))
+	}
+	return masked
+}
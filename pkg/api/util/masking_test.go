@@ -0,0 +1,56 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/context"
+	. "github.com/sodafoundation/api/testutils/collection"
+)
+
+func TestCanViewSecrets(t *testing.T) {
+	t.Run("Admin context should view secrets", func(t *testing.T) {
+		assertTestResult(t, CanViewSecrets(context.NewAdminContext()), true)
+	})
+
+	t.Run("Service role should view secrets", func(t *testing.T) {
+		ctx := &context.Context{Roles: []string{"service"}}
+		assertTestResult(t, CanViewSecrets(ctx), true)
+	})
+
+	t.Run("Auditor role should not view secrets", func(t *testing.T) {
+		ctx := &context.Context{Roles: []string{"auditor"}}
+		assertTestResult(t, CanViewSecrets(ctx), false)
+	})
+}
+
+func TestMaskVolumeAttachment(t *testing.T) {
+	t.Run("Attachment should be untouched for the service role", func(t *testing.T) {
+		ctx := &context.Context{Roles: []string{"service"}}
+		result := MaskVolumeAttachment(ctx, &SampleAttachments[0])
+		assertTestResult(t, result, &SampleAttachments[0])
+	})
+
+	t.Run("Connection data should be masked for an auditor", func(t *testing.T) {
+		ctx := &context.Context{Roles: []string{"auditor"}}
+		result := MaskVolumeAttachment(ctx, &SampleAttachments[0])
+		assertTestResult(t, len(result.ConnectionInfo.ConnectionData), 0)
+		assertTestResult(t, result.ConnectionInfo.DriverVolumeType,
+			SampleAttachments[0].ConnectionInfo.DriverVolumeType)
+		// The stored attachment keeps its connection data.
+		assertTestResult(t, len(SampleAttachments[0].ConnectionInfo.ConnectionData), 4)
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BackendMetadataPrefix marks the metadata keys that are handed through to
+// the backend driver at create time, for example backend.qos:gold. All other
+// metadata keys stay api-side only.
+const BackendMetadataPrefix = "backend."
+
+// The largest combined size of all backend.* keys and values, so driver
+// options can not blow up the controller opts.
+const backendMetadataMaxSize = 4096
+
+// BackendMetadata extracts the metadata entries under the backend. prefix
+// and returns them with the prefix stripped, the form backend drivers see
+// them in. Nil is returned when the resource carries no driver options.
+func BackendMetadata(metadata map[string]string) map[string]string {
+	var options map[string]string
+	for key, value := range metadata {
+		if !strings.HasPrefix(key, BackendMetadataPrefix) {
+			continue
+		}
+		if options == nil {
+			options = map[string]string{}
+		}
+		options[strings.TrimPrefix(key, BackendMetadataPrefix)] = value
+	}
+	return options
+}
+
+// ValidateBackendMetadata checks the driver options of a new resource: a
+// backend. key must name an option and the combined size of all options is
+// limited.
+func ValidateBackendMetadata(metadata map[string]string) error {
+	var size int
+	for key, value := range metadata {
+		if !strings.HasPrefix(key, BackendMetadataPrefix) {
+			continue
+		}
+		if len(key) == len(BackendMetadataPrefix) {
+			return fmt.Errorf("invalid metadata key %s, expected %s<option>", key, BackendMetadataPrefix)
+		}
+		size += len(key) - len(BackendMetadataPrefix) + len(value)
+	}
+	if size > backendMetadataMaxSize {
+		return fmt.Errorf("backend metadata is %d bytes, which exceeds the %d byte limit", size, backendMetadataMaxSize)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBackendMetadata(t *testing.T) {
+	t.Run("Should return nil when there are no backend entries", func(t *testing.T) {
+		options := BackendMetadata(map[string]string{"owner": "team-a"})
+		assertTestResult(t, options == nil, true)
+	})
+
+	t.Run("Should strip the prefix and drop the other entries", func(t *testing.T) {
+		options := BackendMetadata(map[string]string{
+			"owner":           "team-a",
+			"backend.qos":     "gold",
+			"backend.stripes": "4",
+		})
+		assertTestResult(t, options, map[string]string{"qos": "gold", "stripes": "4"})
+	})
+}
+
+func TestValidateBackendMetadata(t *testing.T) {
+	t.Run("Should accept metadata without backend entries", func(t *testing.T) {
+		err := ValidateBackendMetadata(map[string]string{"owner": "team-a"})
+		assertTestResult(t, err, nil)
+	})
+
+	t.Run("Should reject a bare backend. key", func(t *testing.T) {
+		err := ValidateBackendMetadata(map[string]string{"backend.": "gold"})
+		expectedError := "invalid metadata key backend., expected backend.<option>"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should reject oversized driver options", func(t *testing.T) {
+		err := ValidateBackendMetadata(map[string]string{
+			"backend.blob": strings.Repeat("x", backendMetadataMaxSize+1),
+		})
+		assertTestResult(t, err != nil, true)
+	})
+}
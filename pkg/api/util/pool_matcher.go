@@ -0,0 +1,133 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+// PoolCapabilityFilter matches storage pools against capability
+// expressions, so capacity dashboards and external schedulers can query
+// candidate pools with the same semantics the profile selector applies.
+type PoolCapabilityFilter struct {
+	// The requested capabilities, every entry must match the pool.
+	Capabilities map[string]string
+
+	// The smallest free capacity a pool may report, in GB. Zero disables
+	// the capacity check.
+	MinFreeCapacity int64
+
+	// The smallest iops density a pool may report, in IOPS per GB. Zero
+	// disables the density check.
+	MinIopsDensity float64
+}
+
+// ParsePoolCapabilityFilter builds a filter from the capability,
+// minFreeCapacity and minIopsDensity query parameters, for example
+// ?capability=thin:true,compression:true&minFreeCapacity=500. The handled
+// parameters are removed from m so the generic parameter filter does not
+// see them. Nil is returned when none of the parameters is present.
+func ParsePoolCapabilityFilter(m map[string][]string) (*PoolCapabilityFilter, error) {
+	capability, hasCapability := m["capability"]
+	minFree, hasMinFree := m["minFreeCapacity"]
+	minDensity, hasMinDensity := m["minIopsDensity"]
+	if !hasCapability && !hasMinFree && !hasMinDensity {
+		return nil, nil
+	}
+	delete(m, "capability")
+	delete(m, "minFreeCapacity")
+	delete(m, "minIopsDensity")
+
+	var filter = &PoolCapabilityFilter{Capabilities: map[string]string{}}
+	if hasCapability {
+		for _, expr := range strings.Split(capability[0], ",") {
+			kv := strings.SplitN(expr, ":", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return nil, fmt.Errorf("invalid capability expression %s, expected key:value", expr)
+			}
+			filter.Capabilities[kv[0]] = kv[1]
+		}
+	}
+	if hasMinFree {
+		size, err := strconv.ParseInt(minFree[0], 10, 64)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid minFreeCapacity %s, expected a non-negative integer", minFree[0])
+		}
+		filter.MinFreeCapacity = size
+	}
+	if hasMinDensity {
+		density, err := strconv.ParseFloat(minDensity[0], 64)
+		if err != nil || density < 0 {
+			return nil, fmt.Errorf("invalid minIopsDensity %s, expected a non-negative number", minDensity[0])
+		}
+		filter.MinIopsDensity = density
+	}
+	return filter, nil
+}
+
+// Match reports whether the pool satisfies every capability expression
+// and the free capacity and iops density floors. Capability keys are
+// looked up by their json name anywhere in the pool spec, including the
+// advanced extras.
+func (filter *PoolCapabilityFilter) Match(pool *model.StoragePoolSpec) bool {
+	if pool.FreeCapacity < filter.MinFreeCapacity {
+		return false
+	}
+	if pool.IopsDensity < filter.MinIopsDensity {
+		return false
+	}
+	poolMap, err := utils.StructToMap(pool)
+	if err != nil {
+		return false
+	}
+	for key, want := range filter.Capabilities {
+		got, found := findCapability(poolMap, key)
+		if !found || !strings.EqualFold(fmt.Sprintf("%v", got), want) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterPools returns the pools that match the filter.
+func (filter *PoolCapabilityFilter) FilterPools(pools []*model.StoragePoolSpec) []*model.StoragePoolSpec {
+	var matched = []*model.StoragePoolSpec{}
+	for _, pool := range pools {
+		if filter.Match(pool) {
+			matched = append(matched, pool)
+		}
+	}
+	return matched
+}
+
+// findCapability looks the key up in the nested pool map, depth first.
+func findCapability(m map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for _, v := range m {
+		if nested, ok := v.(map[string]interface{}); ok {
+			if got, found := findCapability(nested, key); found {
+				return got, true
+			}
+		}
+	}
+	return nil, false
+}
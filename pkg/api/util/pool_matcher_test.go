@@ -0,0 +1,130 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+)
+
+func TestParsePoolCapabilityFilter(t *testing.T) {
+	t.Run("Should return nil when no capability parameters are present", func(t *testing.T) {
+		filter, err := ParsePoolCapabilityFilter(map[string][]string{"name": {"sample-pool-01"}})
+		assertTestResult(t, err, nil)
+		assertTestResult(t, filter == nil, true)
+	})
+
+	t.Run("Should parse expressions and remove the handled parameters", func(t *testing.T) {
+		m := map[string][]string{
+			"capability":      {"diskType:SSD,compression:true"},
+			"minFreeCapacity": {"500"},
+			"name":            {"sample-pool-01"},
+		}
+		filter, err := ParsePoolCapabilityFilter(m)
+		if err != nil {
+			t.Errorf("failed to parse pool capability filter, err is %v\n", err)
+		}
+		assertTestResult(t, filter.Capabilities["diskType"], "SSD")
+		assertTestResult(t, filter.Capabilities["compression"], "true")
+		assertTestResult(t, filter.MinFreeCapacity, int64(500))
+		assertTestResult(t, len(m), 1)
+	})
+
+	t.Run("Should reject an expression without a value", func(t *testing.T) {
+		_, err := ParsePoolCapabilityFilter(map[string][]string{"capability": {"thin"}})
+		expectedError := "invalid capability expression thin, expected key:value"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should reject a non-numeric capacity floor", func(t *testing.T) {
+		_, err := ParsePoolCapabilityFilter(map[string][]string{"minFreeCapacity": {"lots"}})
+		expectedError := "invalid minFreeCapacity lots, expected a non-negative integer"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should parse the iops density floor", func(t *testing.T) {
+		filter, err := ParsePoolCapabilityFilter(map[string][]string{"minIopsDensity": {"2.5"}})
+		if err != nil {
+			t.Errorf("failed to parse pool capability filter, err is %v\n", err)
+		}
+		assertTestResult(t, filter.MinIopsDensity, 2.5)
+	})
+
+	t.Run("Should reject a non-numeric iops density floor", func(t *testing.T) {
+		_, err := ParsePoolCapabilityFilter(map[string][]string{"minIopsDensity": {"fast"}})
+		expectedError := "invalid minIopsDensity fast, expected a non-negative number"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+}
+
+func TestPoolCapabilityFilterMatch(t *testing.T) {
+	pools := []*model.StoragePoolSpec{&SamplePools[0], &SamplePools[1]}
+
+	t.Run("Advanced capability should select the matching pool", func(t *testing.T) {
+		filter := &PoolCapabilityFilter{Capabilities: map[string]string{"diskType": "SSD"}}
+		result := filter.FilterPools(pools)
+		assertTestResult(t, len(result), 1)
+		assertTestResult(t, result[0].Name, "sample-pool-01")
+	})
+
+	t.Run("Capability values should match case insensitively", func(t *testing.T) {
+		filter := &PoolCapabilityFilter{Capabilities: map[string]string{"provisioningPolicy": "thin"}}
+		result := filter.FilterPools(pools)
+		assertTestResult(t, len(result), 2)
+	})
+
+	t.Run("Capacity floor should drop pools with too little free capacity", func(t *testing.T) {
+		filter := &PoolCapabilityFilter{Capabilities: map[string]string{}, MinFreeCapacity: 100}
+		result := filter.FilterPools(pools)
+		assertTestResult(t, len(result), 1)
+		assertTestResult(t, result[0].Name, "sample-pool-02")
+	})
+
+	t.Run("Unknown capability should match nothing", func(t *testing.T) {
+		filter := &PoolCapabilityFilter{Capabilities: map[string]string{"encryption": "true"}}
+		result := filter.FilterPools(pools)
+		assertTestResult(t, len(result), 0)
+	})
+
+	t.Run("Performance tiers should be selectable", func(t *testing.T) {
+		tiered := []*model.StoragePoolSpec{
+			{
+				BaseModel:    &model.BaseModel{},
+				Name:         "nvme-pool",
+				LatencyClass: model.PoolLatencyClassLow,
+				MediaType:    model.PoolMediaTypeNVMe,
+				IopsDensity:  10,
+			},
+			{
+				BaseModel:    &model.BaseModel{},
+				Name:         "hdd-pool",
+				LatencyClass: model.PoolLatencyClassHigh,
+				MediaType:    model.PoolMediaTypeHDD,
+				IopsDensity:  0.5,
+			},
+		}
+		filter := &PoolCapabilityFilter{Capabilities: map[string]string{"mediaType": "nvme"}}
+		result := filter.FilterPools(tiered)
+		assertTestResult(t, len(result), 1)
+		assertTestResult(t, result[0].Name, "nvme-pool")
+
+		filter = &PoolCapabilityFilter{Capabilities: map[string]string{}, MinIopsDensity: 1}
+		result = filter.FilterPools(tiered)
+		assertTestResult(t, len(result), 1)
+		assertTestResult(t, result[0].Name, "nvme-pool")
+	})
+}
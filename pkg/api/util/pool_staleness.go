@@ -0,0 +1,51 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"time"
+
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// PoolIsStale reports whether the pool record has not been refreshed by its
+// dock for longer than the configured pool_stale_threshold. A pool that was
+// never updated is judged by its creation time instead; a record without any
+// parseable timestamp is never reported stale. A zero threshold disables
+// staleness tracking entirely.
+func PoolIsStale(pool *model.StoragePoolSpec) bool {
+	threshold := CONF.OsdsApiServer.PoolStaleThreshold
+	if threshold <= 0 {
+		return false
+	}
+	stamp := pool.UpdatedAt
+	if stamp == "" {
+		stamp = pool.CreatedAt
+	}
+	t, err := utils.ParseTime(stamp)
+	if err != nil {
+		return false
+	}
+	return time.Since(t) > threshold
+}
+
+// MarkStalePools computes the stale flag of every pool in the list.
+func MarkStalePools(pools []*model.StoragePoolSpec) {
+	for _, pool := range pools {
+		pool.Stale = PoolIsStale(pool)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+// profileChainLimit bounds the inheritance depth of a profile, so a cycle
+// that slipped past validation can not loop the resolution forever.
+const profileChainLimit = 10
+
+// ValidateBaseProfile checks that the base a profile declares exists,
+// stores the same storage type and does not close an inheritance cycle.
+func ValidateBaseProfile(ctx *c.Context, prf *model.ProfileSpec) error {
+	seen := map[string]bool{}
+	if prf.Id != "" {
+		seen[prf.Id] = true
+	}
+	baseId := prf.BaseProfileId
+	for depth := 0; baseId != ""; depth++ {
+		if depth >= profileChainLimit {
+			return fmt.Errorf("profile inheritance deeper than %d levels", profileChainLimit)
+		}
+		if seen[baseId] {
+			return fmt.Errorf("profile %s closes an inheritance cycle", baseId)
+		}
+		seen[baseId] = true
+		base, err := db.C.GetProfile(ctx, baseId)
+		if err != nil {
+			return fmt.Errorf("base profile %s not found: %v", baseId, err)
+		}
+		if base.StorageType != prf.StorageType {
+			return fmt.Errorf("base profile %s stores %s, the profile stores %s",
+				baseId, base.StorageType, prf.StorageType)
+		}
+		baseId = base.BaseProfileId
+	}
+	return nil
+}
+
+// ResolveProfile returns a copy of the profile whose custom properties are
+// the overlay of its whole inheritance chain, entries of the profile
+// winning over the ones of its bases. The stored record is not modified.
+func ResolveProfile(ctx *c.Context, prf *model.ProfileSpec) (*model.ProfileSpec, error) {
+	// Collect the chain leaf-first, then overlay it root-first so the
+	// child always wins on shared keys.
+	chain := []*model.ProfileSpec{prf}
+	seen := map[string]bool{prf.Id: true}
+	for baseId := prf.BaseProfileId; baseId != ""; {
+		if len(chain) > profileChainLimit || seen[baseId] {
+			return nil, fmt.Errorf("profile %s closes an inheritance cycle", baseId)
+		}
+		seen[baseId] = true
+		base, err := db.C.GetProfile(ctx, baseId)
+		if err != nil {
+			return nil, fmt.Errorf("base profile %s not found: %v", baseId, err)
+		}
+		chain = append(chain, base)
+		baseId = base.BaseProfileId
+	}
+
+	resolved := *prf
+	resolved.CustomProperties = model.CustomPropertiesSpec{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		for key, value := range chain[i].CustomProperties {
+			resolved.CustomProperties[key] = value
+		}
+	}
+	return &resolved, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func TestValidateBaseProfile(t *testing.T) {
+	base := &model.ProfileSpec{
+		BaseModel:   &model.BaseModel{Id: "1106b972-66ef-11e7-b172-db03f3689c9c"},
+		Name:        "gold",
+		StorageType: "block",
+	}
+
+	t.Run("A valid base passes", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetProfile", context.NewAdminContext(), base.Id).Return(base, nil)
+		db.C = mockClient
+
+		child := &model.ProfileSpec{
+			BaseModel:     &model.BaseModel{},
+			StorageType:   "block",
+			BaseProfileId: base.Id,
+		}
+		assertTestResult(t, ValidateBaseProfile(context.NewAdminContext(), child), nil)
+	})
+
+	t.Run("A base of another storage type is refused", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetProfile", context.NewAdminContext(), base.Id).Return(base, nil)
+		db.C = mockClient
+
+		child := &model.ProfileSpec{
+			BaseModel:     &model.BaseModel{},
+			StorageType:   "file",
+			BaseProfileId: base.Id,
+		}
+		err := ValidateBaseProfile(context.NewAdminContext(), child)
+		if err == nil {
+			t.Error("Expected a storage type mismatch error, got none")
+		}
+	})
+
+	t.Run("A cycle is refused", func(t *testing.T) {
+		left := &model.ProfileSpec{
+			BaseModel:     &model.BaseModel{Id: "2f9c0a04-66ef-11e7-ade2-43158893e017"},
+			StorageType:   "block",
+			BaseProfileId: base.Id,
+		}
+		looped := &model.ProfileSpec{
+			BaseModel:     base.BaseModel,
+			StorageType:   "block",
+			BaseProfileId: left.Id,
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetProfile", context.NewAdminContext(), left.Id).Return(left, nil)
+		mockClient.On("GetProfile", context.NewAdminContext(), base.Id).Return(looped, nil)
+		db.C = mockClient
+
+		err := ValidateBaseProfile(context.NewAdminContext(), left)
+		if err == nil {
+			t.Error("Expected a cycle error, got none")
+		}
+	})
+}
+
+func TestResolveProfile(t *testing.T) {
+	base := &model.ProfileSpec{
+		BaseModel:   &model.BaseModel{Id: "1106b972-66ef-11e7-b172-db03f3689c9c"},
+		StorageType: "block",
+		CustomProperties: model.CustomPropertiesSpec{
+			"diskType": "SSD",
+			"latency":  "3ms",
+		},
+	}
+	child := &model.ProfileSpec{
+		BaseModel:     &model.BaseModel{Id: "2f9c0a04-66ef-11e7-ade2-43158893e017"},
+		StorageType:   "block",
+		BaseProfileId: base.Id,
+		CustomProperties: model.CustomPropertiesSpec{
+			"latency": "5ms",
+		},
+	}
+
+	t.Run("The child wins on shared keys", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetProfile", context.NewAdminContext(), base.Id).Return(base, nil)
+		db.C = mockClient
+
+		resolved, err := ResolveProfile(context.NewAdminContext(), child)
+		assertTestResult(t, err, nil)
+		assertTestResult(t, resolved.CustomProperties, model.CustomPropertiesSpec{
+			"diskType": "SSD",
+			"latency":  "5ms",
+		})
+		// The stored record keeps only the local entries.
+		assertTestResult(t, child.CustomProperties, model.CustomPropertiesSpec{
+			"latency": "5ms",
+		})
+	})
+
+	t.Run("A profile without a base resolves to itself", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+
+		resolved, err := ResolveProfile(context.NewAdminContext(), base)
+		assertTestResult(t, err, nil)
+		assertTestResult(t, resolved.CustomProperties, base.CustomProperties)
+	})
+}
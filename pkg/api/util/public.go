@@ -0,0 +1,42 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+// PublicFileShareView returns the redacted form of a public fileshare that
+// is served to tenants other than its owner. It keeps what is needed to
+// discover the shared dataset and drops everything a consumer could mount
+// or authenticate with: export locations, export options, metadata and the
+// lock detail.
+func PublicFileShareView(fshare *model.FileShareSpec) *model.FileShareSpec {
+	return &model.FileShareSpec{
+		BaseModel: &model.BaseModel{
+			Id:        fshare.Id,
+			CreatedAt: fshare.CreatedAt,
+			UpdatedAt: fshare.UpdatedAt,
+		},
+		TenantId:         fshare.TenantId,
+		Name:             fshare.Name,
+		Description:      fshare.Description,
+		Protocols:        fshare.Protocols,
+		Size:             fshare.Size,
+		AvailabilityZone: fshare.AvailabilityZone,
+		Status:           fshare.Status,
+		IsPublic:         true,
+	}
+}
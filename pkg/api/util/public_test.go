@@ -0,0 +1,65 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+func TestPublicFileShareView(t *testing.T) {
+
+	t.Run("Should keep the discovery fields and drop everything mountable", func(t *testing.T) {
+		fshare := &model.FileShareSpec{
+			BaseModel: &model.BaseModel{
+				Id:        "d2975ebe-d82c-430f-b28e-f373746a71ca",
+				CreatedAt: "2019-03-20T12:49:00.497Z",
+			},
+			TenantId:         "e93b4c0934da416eb9c8d120c5d04d96",
+			UserId:           "2f9c0a04",
+			Name:             "shared-dataset",
+			Description:      "training data",
+			Protocols:        []string{"NFS"},
+			Size:             int64(10),
+			AvailabilityZone: "default",
+			Status:           "available",
+			PoolId:           "a5965ebe-dg2c-434t-b28e-f373746a71ca",
+			ProfileId:        "b3585ebe-c42c-120g-b28e-f373746a71ca",
+			ExportLocations:  []string{"192.168.100.100"},
+			Metadata:         map[string]string{"nfsKey": "secret"},
+			Lock:             &model.FileShareLockSpec{Owner: "2f9c0a04"},
+			IsPublic:         true,
+		}
+
+		view := PublicFileShareView(fshare)
+
+		expected := &model.FileShareSpec{
+			BaseModel: &model.BaseModel{
+				Id:        "d2975ebe-d82c-430f-b28e-f373746a71ca",
+				CreatedAt: "2019-03-20T12:49:00.497Z",
+			},
+			TenantId:         "e93b4c0934da416eb9c8d120c5d04d96",
+			Name:             "shared-dataset",
+			Description:      "training data",
+			Protocols:        []string{"NFS"},
+			Size:             int64(10),
+			AvailabilityZone: "default",
+			Status:           "available",
+			IsPublic:         true,
+		}
+		assertTestResult(t, view, expected)
+	})
+}
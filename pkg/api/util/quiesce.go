@@ -0,0 +1,81 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the quiesce webhook called around application
+consistent snapshots, so the workload can flush and freeze its writes
+before the snapshot is cut and resume afterwards.
+*/
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// The phases the quiesce webhook is called with, "pre" right before the
+// snapshot is cut and "post" right after.
+const (
+	QuiescePhasePre  = "pre"
+	QuiescePhasePost = "post"
+)
+
+// QuiesceRequest is the body posted to the quiesce webhook.
+type QuiesceRequest struct {
+	// The phase of the snapshot, "pre" before it is cut, "post" after.
+	Phase string `json:"phase"`
+	// The uuid of the tenant the snapshot belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+	// The snapshot record being created.
+	Snapshot *model.VolumeSnapshotSpec `json:"snapshot"`
+}
+
+// QuiesceWorkload posts the snapshot record to the configured quiesce
+// webhook for the given phase. Any answer other than 200 within the
+// configured timeout is an error; the caller decides whether that fails
+// the snapshot (pre) or is only logged (post). When no webhook url is
+// configured this is a no-op.
+func QuiesceWorkload(ctx *c.Context, phase string, snapshot *model.VolumeSnapshotSpec) error {
+	url := CONF.OsdsApiServer.SnapshotQuiesceWebhookUrl
+	if url == "" {
+		return nil
+	}
+
+	reqBody, err := json.Marshal(&QuiesceRequest{
+		Phase:    phase,
+		TenantId: ctx.TenantId,
+		Snapshot: snapshot,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal quiesce webhook request failed: %v", err)
+	}
+
+	client := &http.Client{Timeout: CONF.OsdsApiServer.SnapshotQuiesceWebhookTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("quiesce webhook unreachable in phase %s: %v", phase, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("quiesce webhook returned status %d in phase %s", resp.StatusCode, phase)
+	}
+	return nil
+}
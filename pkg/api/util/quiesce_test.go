@@ -0,0 +1,82 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+func TestQuiesceWorkload(t *testing.T) {
+	var snapshot = &model.VolumeSnapshotSpec{
+		BaseModel:   &model.BaseModel{Id: "3769855c-a102-11e7-b772-17b880d2f537"},
+		Name:        "snapshot sample",
+		VolumeId:    "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		Consistency: constants.SnapshotApplicationConsistent,
+	}
+
+	t.Run("Should do nothing if no webhook is configured", func(t *testing.T) {
+		CONF.OsdsApiServer.SnapshotQuiesceWebhookUrl = ""
+
+		if err := QuiesceWorkload(context.NewAdminContext(), QuiescePhasePre, snapshot); err != nil {
+			t.Errorf("expected no error without a webhook, got %v\n", err)
+		}
+	})
+
+	t.Run("Should post the snapshot record with the phase", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req QuiesceRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			assertTestResult(t, req.Phase, QuiescePhasePre)
+			assertTestResult(t, req.Snapshot.Id, snapshot.Id)
+		}))
+		defer ts.Close()
+		CONF.OsdsApiServer.SnapshotQuiesceWebhookUrl = ts.URL
+		defer func() { CONF.OsdsApiServer.SnapshotQuiesceWebhookUrl = "" }()
+
+		if err := QuiesceWorkload(context.NewAdminContext(), QuiescePhasePre, snapshot); err != nil {
+			t.Errorf("expected the quiesce to succeed, got %v\n", err)
+		}
+	})
+
+	t.Run("Should fail if the webhook answers with an error status", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+		CONF.OsdsApiServer.SnapshotQuiesceWebhookUrl = ts.URL
+		defer func() { CONF.OsdsApiServer.SnapshotQuiesceWebhookUrl = "" }()
+
+		err := QuiesceWorkload(context.NewAdminContext(), QuiescePhasePost, snapshot)
+		expectedError := "quiesce webhook returned status 500 in phase post"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should fail if the webhook is unreachable", func(t *testing.T) {
+		CONF.OsdsApiServer.SnapshotQuiesceWebhookUrl = "http://127.0.0.1:1/quiesce"
+		defer func() { CONF.OsdsApiServer.SnapshotQuiesceWebhookUrl = "" }()
+
+		if err := QuiesceWorkload(context.NewAdminContext(), QuiescePhasePre, snapshot); err == nil {
+			t.Errorf("expected an error with an unreachable webhook, got none\n")
+		}
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the gb quota check of the create paths, including
+the warning events emitted when a tenant crosses a soft threshold.
+*/
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/golang/glog"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// QuotaWarning is the body posted to the quota webhook when a tenant
+// crosses one of the soft thresholds.
+type QuotaWarning struct {
+	// The uuid of the tenant that crossed the threshold.
+	TenantId string `json:"tenantId"`
+	// The threshold crossed, percent of the gb quota.
+	Threshold int `json:"threshold"`
+	// The GB the tenant has provisioned after the triggering create.
+	UsedGb int64 `json:"usedGb"`
+	// The gb quota of the tenant.
+	GbQuota int64 `json:"gbQuota"`
+}
+
+// UsedGbOfTenant sums the sizes of the volumes and file shares the given
+// context can see, which for a tenant context is its own provisioned GB.
+func UsedGbOfTenant(ctx *c.Context) (int64, error) {
+	var used int64
+	volumes, err := db.C.ListVolumes(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, volume := range volumes {
+		used += volume.Size
+	}
+	shares, err := db.C.ListFileShares(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, share := range shares {
+		used += share.Size
+	}
+	return used, nil
+}
+
+// CheckQuota enforces the gb quota of the tenant issuing a create. It
+// returns an error when the new resource would push the tenant over its
+// quota, and emits a warning event for every soft threshold the create
+// crosses.
+func CheckQuota(ctx *c.Context, size int64) error {
+	// Administrators and internal calls are not subject to quotas, in line
+	// with the other per-tenant create rules.
+	if ctx.IsAdmin || ctx.TenantId == "" {
+		return nil
+	}
+	quota, err := db.C.GetQuota(c.NewAdminContext(), ctx.TenantId)
+	if err != nil || quota == nil || quota.GbQuota <= 0 {
+		// No quota configured for the tenant.
+		return nil
+	}
+	used, err := UsedGbOfTenant(ctx)
+	if err != nil {
+		// The create itself is about to hit the same database; a usage
+		// computation failure only costs the quota check.
+		log.Error("when computing quota usage:", err)
+		return nil
+	}
+	if used+size > quota.GbQuota {
+		return fmt.Errorf("gb quota of tenant %s exceeded: %d of %d GB used, requested %d GB more",
+			ctx.TenantId, used, quota.GbQuota, size)
+	}
+	warnQuotaThresholds(ctx.TenantId, quota.GbQuota, used, used+size)
+	return nil
+}
+
+// warnQuotaThresholds emits one warning per soft threshold the create
+// crosses, as an event log line and, when configured, to the quota
+// webhook.
+func warnQuotaThresholds(tenantId string, gbQuota, before, after int64) {
+	for _, threshold := range CONF.OsdsApiServer.QuotaWarnThresholds {
+		limit := gbQuota * int64(threshold) / 100
+		if before <= limit && after > limit {
+			log.Warningf("event: tenant %s crossed %d%% of its gb quota (%d of %d GB used)",
+				tenantId, threshold, after, gbQuota)
+			postQuotaWarning(&QuotaWarning{
+				TenantId:  tenantId,
+				Threshold: threshold,
+				UsedGb:    after,
+				GbQuota:   gbQuota,
+			})
+		}
+	}
+}
+
+// postQuotaWarning delivers a warning to the quota webhook. It is best
+// effort, a failed delivery only logs: the create that triggered the
+// warning is within quota and must not be reported as failed.
+func postQuotaWarning(warning *QuotaWarning) {
+	url := CONF.OsdsApiServer.QuotaWebhookUrl
+	if url == "" {
+		return
+	}
+	body, err := json.Marshal(warning)
+	if err != nil {
+		log.Errorf("when marshaling quota warning of tenant %s: %v", warning.TenantId, err)
+		return
+	}
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Errorf("when posting quota warning of tenant %s to %s: %v", warning.TenantId, url, err)
+		return
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,71 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func TestCheckQuota(t *testing.T) {
+	var tenantCtx = func() *context.Context {
+		return &context.Context{TenantId: "e93b4c0934da416eb9c8d120c5d04d96"}
+	}
+	var mockQuota = func(gbQuota int64, usedVolumes []*model.VolumeSpec) {
+		quota := SampleQuotas[0]
+		quota.GbQuota = gbQuota
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetQuota", context.NewAdminContext(), "e93b4c0934da416eb9c8d120c5d04d96").
+			Return(&quota, nil)
+		mockClient.On("ListVolumes", tenantCtx()).Return(usedVolumes, nil)
+		mockClient.On("ListFileShares", tenantCtx()).Return(nil, nil)
+		db.C = mockClient
+	}
+
+	t.Run("Should pass a create within the quota", func(t *testing.T) {
+		mockQuota(100, []*model.VolumeSpec{{BaseModel: &model.BaseModel{}, Size: 50}})
+		if err := CheckQuota(tenantCtx(), 10); err != nil {
+			t.Errorf("expected the create to pass, got %v", err)
+		}
+	})
+
+	t.Run("Should reject a create beyond the quota", func(t *testing.T) {
+		mockQuota(100, []*model.VolumeSpec{{BaseModel: &model.BaseModel{}, Size: 95}})
+		err := CheckQuota(tenantCtx(), 10)
+		expectedError := "gb quota of tenant e93b4c0934da416eb9c8d120c5d04d96 exceeded: 95 of 100 GB used, requested 10 GB more"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should pass a create that only crosses a soft threshold", func(t *testing.T) {
+		// 75 used of 100, plus 10 crosses the 80% threshold but stays
+		// within the quota, so the create passes with a warning.
+		mockQuota(100, []*model.VolumeSpec{{BaseModel: &model.BaseModel{}, Size: 75}})
+		if err := CheckQuota(tenantCtx(), 10); err != nil {
+			t.Errorf("expected the create to pass, got %v", err)
+		}
+	})
+
+	t.Run("Should not apply to administrators", func(t *testing.T) {
+		db.C = new(dbtest.Client)
+		if err := CheckQuota(context.NewAdminContext(), 10000); err != nil {
+			t.Errorf("expected the create to pass, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// SystemMetadataLastResizedAtKey records when a resource was last resized,
+// in the system metadata section like the create attribution keys. The
+// resize cooldown is measured from it.
+const SystemMetadataLastResizedAtKey = SystemMetadataPrefix + "lastResizedAt"
+
+// ResizeCooldownError tells a caller that a resize was rejected because the
+// resource was resized too recently, and when the next attempt is allowed.
+// Controllers map it to 429 rather than the 400 other validation errors get.
+type ResizeCooldownError struct {
+	NextAllowedAt string
+}
+
+func (e *ResizeCooldownError) Error() string {
+	return fmt.Sprintf("the resource was resized recently, next resize allowed at %s (nextAllowedAt)",
+		e.NextAllowedAt)
+}
+
+// CheckResizeAllowed enforces the configured resize protection policies
+// against a resize from currentSize to newSize GB. A step over
+// max_resize_step returns a plain validation error; a resize inside the
+// resize_cooldown window since the resource's last recorded resize returns
+// a *ResizeCooldownError carrying the nextAllowedAt timestamp. Both checks
+// are disabled by their zero config defaults.
+func CheckResizeAllowed(metadata map[string]string, currentSize, newSize int64) error {
+	if step := CONF.OsdsApiServer.MaxResizeStep; step > 0 && newSize-currentSize > step {
+		return fmt.Errorf("resize from %d GB to %d GB exceeds the maximum resize step of %d GB",
+			currentSize, newSize, step)
+	}
+
+	cooldown := CONF.OsdsApiServer.ResizeCooldown
+	if cooldown <= 0 {
+		return nil
+	}
+	value, ok := metadata[SystemMetadataLastResizedAtKey]
+	if !ok {
+		return nil
+	}
+	last, err := utils.ParseTime(value)
+	if err != nil {
+		// An unreadable timestamp never blocks a resize, the record is
+		// simply rewritten by MarkResized on the way through.
+		return nil
+	}
+	if next := last.Add(cooldown); time.Now().Before(next) {
+		return &ResizeCooldownError{NextAllowedAt: utils.FormatTime(next)}
+	}
+	return nil
+}
+
+// MarkResized records the current time as the resource's last resize in its
+// system metadata section and returns the enriched map.
+func MarkResized(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata[SystemMetadataLastResizedAtKey] = utils.FormatTime(time.Now())
+	return metadata
+}
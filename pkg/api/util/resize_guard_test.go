@@ -0,0 +1,93 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sodafoundation/api/pkg/utils"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+func TestCheckResizeAllowed(t *testing.T) {
+	t.Run("Disabled checks should allow any resize", func(t *testing.T) {
+		metadata := map[string]string{
+			SystemMetadataLastResizedAtKey: utils.FormatTime(time.Now()),
+		}
+		if err := CheckResizeAllowed(metadata, 1, 100); err != nil {
+			t.Errorf("expected resize to be allowed, got %v\n", err)
+		}
+	})
+
+	t.Run("A step over max_resize_step should be rejected", func(t *testing.T) {
+		CONF.OsdsApiServer.MaxResizeStep = 10
+		defer func() { CONF.OsdsApiServer.MaxResizeStep = 0 }()
+
+		err := CheckResizeAllowed(nil, 5, 20)
+		expectedError := "resize from 5 GB to 20 GB exceeds the maximum resize step of 10 GB"
+		assertTestResult(t, err.Error(), expectedError)
+
+		if err := CheckResizeAllowed(nil, 5, 15); err != nil {
+			t.Errorf("expected resize at the step limit to be allowed, got %v\n", err)
+		}
+	})
+
+	t.Run("A resize inside the cooldown window should get a cooldown error", func(t *testing.T) {
+		CONF.OsdsApiServer.ResizeCooldown = time.Hour
+		defer func() { CONF.OsdsApiServer.ResizeCooldown = 0 }()
+
+		last := time.Now().Add(-10 * time.Minute)
+		metadata := map[string]string{
+			SystemMetadataLastResizedAtKey: utils.FormatTime(last),
+		}
+		err := CheckResizeAllowed(metadata, 1, 2)
+		cooldownErr, ok := err.(*ResizeCooldownError)
+		if !ok {
+			t.Errorf("expected a *ResizeCooldownError, got %v\n", err)
+			return
+		}
+		assertTestResult(t, cooldownErr.NextAllowedAt, utils.FormatTime(last.Add(time.Hour)))
+	})
+
+	t.Run("A resize after the cooldown elapsed should be allowed", func(t *testing.T) {
+		CONF.OsdsApiServer.ResizeCooldown = time.Hour
+		defer func() { CONF.OsdsApiServer.ResizeCooldown = 0 }()
+
+		metadata := map[string]string{
+			SystemMetadataLastResizedAtKey: utils.FormatTime(time.Now().Add(-2 * time.Hour)),
+		}
+		if err := CheckResizeAllowed(metadata, 1, 2); err != nil {
+			t.Errorf("expected resize to be allowed, got %v\n", err)
+		}
+
+		if err := CheckResizeAllowed(nil, 1, 2); err != nil {
+			t.Errorf("expected resize of a never resized resource to be allowed, got %v\n", err)
+		}
+	})
+}
+
+func TestMarkResized(t *testing.T) {
+	metadata := MarkResized(nil)
+	if _, ok := metadata[SystemMetadataLastResizedAtKey]; !ok {
+		t.Errorf("expected %s to be recorded\n", SystemMetadataLastResizedAtKey)
+	}
+
+	metadata = MarkResized(map[string]string{"disk": "ssd"})
+	assertTestResult(t, metadata["disk"], "ssd")
+	if _, ok := metadata[SystemMetadataLastResizedAtKey]; !ok {
+		t.Errorf("expected %s to be recorded\n", SystemMetadataLastResizedAtKey)
+	}
+}
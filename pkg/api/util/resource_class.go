@@ -0,0 +1,85 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+// ResourceClassForTenant returns the resource class attached to the
+// tenant, or nil when the tenant has none.
+func ResourceClassForTenant(tenantId string) (*model.ResourceClassSpec, error) {
+	classes, err := db.C.ListResourceClasses(c.NewAdminContext())
+	if err != nil {
+		return nil, err
+	}
+	for _, class := range classes {
+		if utils.Contains(class.Tenants, tenantId) {
+			return class, nil
+		}
+	}
+	return nil, nil
+}
+
+// ValidateWithResourceClass checks a create request against the resource
+// class of its tenant, returning the first violated rule. Administrators
+// are not restricted by resource classes.
+func ValidateWithResourceClass(ctx *c.Context, name, availabilityZone string, size int64, metadata map[string]string) error {
+	if ctx.IsAdmin || ctx.TenantId == "" {
+		return nil
+	}
+	class, err := ResourceClassForTenant(ctx.TenantId)
+	if err != nil {
+		return err
+	}
+	if class == nil {
+		return nil
+	}
+	if class.NamingPattern != "" {
+		matched, err := regexp.MatchString(class.NamingPattern, name)
+		if err != nil {
+			return fmt.Errorf("invalid naming pattern %q of resource class %s: %v",
+				class.NamingPattern, class.Name, err)
+		}
+		if !matched {
+			return fmt.Errorf("name %q does not match the naming pattern %q of resource class %s",
+				name, class.NamingPattern, class.Name)
+		}
+	}
+	for _, tag := range class.RequiredTags {
+		if _, ok := metadata[tag]; !ok {
+			return fmt.Errorf("metadata key %q is required by resource class %s", tag, class.Name)
+		}
+	}
+	if class.MinSize > 0 && size < class.MinSize {
+		return fmt.Errorf("size %dGB is below the minimum of %dGB set by resource class %s",
+			size, class.MinSize, class.Name)
+	}
+	if class.MaxSize > 0 && size > class.MaxSize {
+		return fmt.Errorf("size %dGB exceeds the maximum of %dGB set by resource class %s",
+			size, class.MaxSize, class.Name)
+	}
+	if len(class.AllowedAvailabilityZones) > 0 && !utils.Contains(class.AllowedAvailabilityZones, availabilityZone) {
+		return fmt.Errorf("availability zone %s is not allowed by resource class %s",
+			availabilityZone, class.Name)
+	}
+	return nil
+}
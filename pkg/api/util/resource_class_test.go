@@ -0,0 +1,87 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/testutils/collection"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func TestValidateWithResourceClass(t *testing.T) {
+	var classes = []*model.ResourceClassSpec{&SampleResourceClasses[0]}
+	var tenantCtx = func() *context.Context {
+		return &context.Context{TenantId: "x7a91c0a2ab41b9cf76f71a91b"}
+	}
+	var mockListClasses = func() {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListResourceClasses", context.NewAdminContext()).Return(classes, nil)
+		db.C = mockClient
+	}
+
+	t.Run("Should pass a request satisfying every rule", func(t *testing.T) {
+		mockListClasses()
+		err := ValidateWithResourceClass(tenantCtx(), "db-volume", "default", 10,
+			map[string]string{"cost-center": "42"})
+		if err != nil {
+			t.Errorf("expected the request to pass, got %v", err)
+		}
+	})
+
+	t.Run("Should reject a name not matching the naming pattern", func(t *testing.T) {
+		mockListClasses()
+		err := ValidateWithResourceClass(tenantCtx(), "DB Volume", "default", 10,
+			map[string]string{"cost-center": "42"})
+		expectedError := `name "DB Volume" does not match the naming pattern "^[a-z][a-z0-9-]*$" of resource class sample-resource-class`
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should reject a request missing a required tag", func(t *testing.T) {
+		mockListClasses()
+		err := ValidateWithResourceClass(tenantCtx(), "db-volume", "default", 10, nil)
+		expectedError := `metadata key "cost-center" is required by resource class sample-resource-class`
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should reject a size outside the bounds", func(t *testing.T) {
+		mockListClasses()
+		err := ValidateWithResourceClass(tenantCtx(), "db-volume", "default", 500,
+			map[string]string{"cost-center": "42"})
+		expectedError := "size 500GB exceeds the maximum of 100GB set by resource class sample-resource-class"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should reject an availability zone that is not allowed", func(t *testing.T) {
+		mockListClasses()
+		err := ValidateWithResourceClass(tenantCtx(), "db-volume", "secondary", 10,
+			map[string]string{"cost-center": "42"})
+		expectedError := "availability zone secondary is not allowed by resource class sample-resource-class"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should not restrict administrators or unattached tenants", func(t *testing.T) {
+		mockListClasses()
+		if err := ValidateWithResourceClass(context.NewAdminContext(), "DB Volume", "secondary", 500, nil); err != nil {
+			t.Errorf("expected administrators to be unrestricted, got %v", err)
+		}
+		if err := ValidateWithResourceClass(&context.Context{TenantId: "another-tenant"}, "DB Volume", "secondary", 500, nil); err != nil {
+			t.Errorf("expected an unattached tenant to be unrestricted, got %v", err)
+		}
+	})
+}
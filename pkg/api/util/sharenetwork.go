@@ -0,0 +1,61 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+)
+
+// ShareNetworkIdKey is the profile custom property naming the share
+// network the fileshares of the profile are exported on by default.
+const ShareNetworkIdKey = "shareNetworkId"
+
+// ResolveShareNetwork picks the export network of a new fileshare: an
+// explicit shareNetworkId on the request wins, then the shareNetworkId
+// custom property of its profile, then the network registered as default.
+// Nil without error means no network is registered for the case and the
+// driver keeps picking one.
+func ResolveShareNetwork(ctx *c.Context, prf *model.ProfileSpec, in *model.FileShareSpec) (*model.ShareNetworkSpec, error) {
+	id := in.ShareNetworkId
+	if id == "" {
+		if v, ok := prf.CustomProperties[ShareNetworkIdKey].(string); ok {
+			id = v
+		}
+	}
+	if id != "" {
+		network, err := db.C.GetShareNetwork(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("share network %s not found: %v", id, err)
+		}
+		in.ShareNetworkId = network.Id
+		return network, nil
+	}
+
+	networks, err := db.C.ListShareNetworks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range networks {
+		if network.Default {
+			in.ShareNetworkId = network.Id
+			return network, nil
+		}
+	}
+	return nil, nil
+}
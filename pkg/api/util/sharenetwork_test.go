@@ -0,0 +1,95 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	dbtest "github.com/sodafoundation/api/testutils/db/testing"
+)
+
+func TestResolveShareNetwork(t *testing.T) {
+	network := &model.ShareNetworkSpec{
+		BaseModel: &model.BaseModel{Id: "8f5f9c52-5e73-4cf5-a1d5-1e1086b20acd"},
+		Name:      "prod-nas",
+		Cidr:      "192.168.100.0/24",
+	}
+
+	t.Run("An explicit share network on the request wins", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetShareNetwork", context.NewAdminContext(), network.Id).
+			Return(network, nil)
+		db.C = mockClient
+
+		prf := &model.ProfileSpec{BaseModel: &model.BaseModel{}}
+		in := &model.FileShareSpec{BaseModel: &model.BaseModel{}, ShareNetworkId: network.Id}
+		result, err := ResolveShareNetwork(context.NewAdminContext(), prf, in)
+		assertTestResult(t, err, nil)
+		assertTestResult(t, result, network)
+	})
+
+	t.Run("The profile picks the network when the request does not", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("GetShareNetwork", context.NewAdminContext(), network.Id).
+			Return(network, nil)
+		db.C = mockClient
+
+		prf := &model.ProfileSpec{
+			BaseModel:        &model.BaseModel{},
+			CustomProperties: model.CustomPropertiesSpec{ShareNetworkIdKey: network.Id},
+		}
+		in := &model.FileShareSpec{BaseModel: &model.BaseModel{}}
+		result, err := ResolveShareNetwork(context.NewAdminContext(), prf, in)
+		assertTestResult(t, err, nil)
+		assertTestResult(t, result, network)
+		assertTestResult(t, in.ShareNetworkId, network.Id)
+	})
+
+	t.Run("The default network is the fallback", func(t *testing.T) {
+		standby := &model.ShareNetworkSpec{
+			BaseModel: &model.BaseModel{Id: "2b8c2431-6a82-4a04-b9ae-dbb4c6a01152"},
+			Name:      "dev-nas",
+			Default:   true,
+		}
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListShareNetworks", context.NewAdminContext()).
+			Return([]*model.ShareNetworkSpec{network, standby}, nil)
+		db.C = mockClient
+
+		prf := &model.ProfileSpec{BaseModel: &model.BaseModel{}}
+		in := &model.FileShareSpec{BaseModel: &model.BaseModel{}}
+		result, err := ResolveShareNetwork(context.NewAdminContext(), prf, in)
+		assertTestResult(t, err, nil)
+		assertTestResult(t, result, standby)
+		assertTestResult(t, in.ShareNetworkId, standby.Id)
+	})
+
+	t.Run("Without any match the driver keeps picking the network", func(t *testing.T) {
+		mockClient := new(dbtest.Client)
+		mockClient.On("ListShareNetworks", context.NewAdminContext()).
+			Return([]*model.ShareNetworkSpec{network}, nil)
+		db.C = mockClient
+
+		prf := &model.ProfileSpec{BaseModel: &model.BaseModel{}}
+		in := &model.FileShareSpec{BaseModel: &model.BaseModel{}}
+		result, err := ResolveShareNetwork(context.NewAdminContext(), prf, in)
+		assertTestResult(t, err, nil)
+		assertTestResult(t, result == nil, true)
+		assertTestResult(t, in.ShareNetworkId, "")
+	})
+}
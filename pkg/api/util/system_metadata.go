@@ -0,0 +1,72 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	c "github.com/sodafoundation/api/pkg/context"
+)
+
+// SystemMetadataPrefix marks the metadata section the server records about
+// a resource. Keys under it describe who created the resource and come back
+// in GET responses like any other metadata, but they are read only: requests
+// carrying keys with this prefix are rejected.
+const SystemMetadataPrefix = "system/"
+
+// The keys the server records under the system metadata section at create.
+const (
+	SystemMetadataCreatedByKey = SystemMetadataPrefix + "createdBy"
+	SystemMetadataSourceIpKey  = SystemMetadataPrefix + "sourceIp"
+	SystemMetadataUserAgentKey = SystemMetadataPrefix + "userAgent"
+)
+
+// ValidateSystemMetadata rejects request metadata that tries to write into
+// the read only system section.
+func ValidateSystemMetadata(metadata map[string]string) error {
+	for key := range metadata {
+		if strings.HasPrefix(key, SystemMetadataPrefix) {
+			return fmt.Errorf("metadata key %s is not allowed, the %s metadata section is read only and recorded by the server",
+				key, SystemMetadataPrefix)
+		}
+	}
+	return nil
+}
+
+// ApplySystemMetadata records who created a resource in the system metadata
+// section and returns the enriched map. It runs after any metadata
+// inherited from a parent resource was merged in, so the section always
+// describes this create and not the parent's. Contexts created outside a
+// request, like the reaper's, carry none of the attributes and leave the
+// metadata untouched.
+func ApplySystemMetadata(ctx *c.Context, metadata map[string]string) map[string]string {
+	if ctx.UserId == "" && ctx.SourceIp == "" && ctx.UserAgent == "" {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	if ctx.UserId != "" {
+		metadata[SystemMetadataCreatedByKey] = ctx.UserId
+	}
+	if ctx.SourceIp != "" {
+		metadata[SystemMetadataSourceIpKey] = ctx.SourceIp
+	}
+	if ctx.UserAgent != "" {
+		metadata[SystemMetadataUserAgentKey] = ctx.UserAgent
+	}
+	return metadata
+}
@@ -0,0 +1,61 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	c "github.com/sodafoundation/api/pkg/context"
+)
+
+func TestValidateSystemMetadata(t *testing.T) {
+	if err := ValidateSystemMetadata(map[string]string{"app": "db"}); err != nil {
+		t.Errorf("expected plain metadata to validate, got %v", err)
+	}
+	if err := ValidateSystemMetadata(nil); err != nil {
+		t.Errorf("expected nil metadata to validate, got %v", err)
+	}
+	if err := ValidateSystemMetadata(map[string]string{SystemMetadataCreatedByKey: "me"}); err == nil {
+		t.Error("expected metadata writing into the system section to be rejected")
+	}
+}
+
+func TestApplySystemMetadata(t *testing.T) {
+	t.Run("The request attributes should be recorded under the system section", func(t *testing.T) {
+		ctx := &c.Context{
+			UserId:    "558057c4-ee14-4de4-961f-eda426c5fd57",
+			SourceIp:  "192.168.56.10",
+			UserAgent: "python-cinderclient/4.0.1",
+		}
+		got := ApplySystemMetadata(ctx, map[string]string{"app": "db"})
+		assertTestResult(t, got[SystemMetadataCreatedByKey], "558057c4-ee14-4de4-961f-eda426c5fd57")
+		assertTestResult(t, got[SystemMetadataSourceIpKey], "192.168.56.10")
+		assertTestResult(t, got[SystemMetadataUserAgentKey], "python-cinderclient/4.0.1")
+		assertTestResult(t, got["app"], "db")
+	})
+
+	t.Run("Contexts created outside a request should leave the metadata untouched", func(t *testing.T) {
+		got := ApplySystemMetadata(c.NewAdminContext(), nil)
+		if got != nil {
+			t.Errorf("expected nil metadata to stay nil, got %v", got)
+		}
+	})
+
+	t.Run("The section of an inherited parent should be overwritten", func(t *testing.T) {
+		ctx := &c.Context{UserId: "2f9c0a04-66ef-4d02-a9d8-133b2a92c185"}
+		got := ApplySystemMetadata(ctx, map[string]string{SystemMetadataCreatedByKey: "parent-creator"})
+		assertTestResult(t, got[SystemMetadataCreatedByKey], "2f9c0a04-66ef-4d02-a9d8-133b2a92c185")
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the task records of asynchronous operations. Every
+operation a portal answers with 202 gets a task tracking its dispatch to the
+controller, so clients follow the operation through its task instead of
+polling the resource status field and guessing why it went to error.
+*/
+
+package util
+
+import (
+	"time"
+
+	log "github.com/golang/glog"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+// StartTask records the start of an asynchronous operation on a resource.
+// The task is best effort: when the db refuses it the operation still runs,
+// it just can not be followed.
+func StartTask(ctx *c.Context, resourceType, resourceId, operation string) *model.TaskSpec {
+	task := &model.TaskSpec{
+		BaseModel:    &model.BaseModel{},
+		TenantId:     ctx.TenantId,
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		Operation:    operation,
+		Status:       model.TaskRunning,
+		Transitions: []*model.TaskTransitionSpec{{
+			Status:    model.TaskRunning,
+			Timestamp: utils.FormatTime(time.Now()),
+		}},
+	}
+	result, err := db.C.CreateTask(ctx, task)
+	if err != nil {
+		log.Error("when creating task:", err)
+		return task
+	}
+	return result
+}
+
+// CloseTask records the outcome of the operation a task tracks: succeeded
+// when err is nil, otherwise failed with the error kept on the task.
+func CloseTask(ctx *c.Context, task *model.TaskSpec, err error) {
+	task.Status = model.TaskSucceeded
+	transition := &model.TaskTransitionSpec{
+		Status:    model.TaskSucceeded,
+		Timestamp: utils.FormatTime(time.Now()),
+	}
+	if err != nil {
+		task.Status = model.TaskFailed
+		task.ErrorMessage = err.Error()
+		transition.Status = model.TaskFailed
+		transition.Message = err.Error()
+	}
+	task.Transitions = append(task.Transitions, transition)
+	if _, err := db.C.UpdateTask(ctx, task); err != nil {
+		log.Error("when closing task:", err)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"time"
+
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+// CreatedAtFilter keeps the resources created at or after a point in time.
+type CreatedAtFilter struct {
+	Since time.Time
+}
+
+// ParseCreatedAtFilter builds a filter from the createdAt>=<timestamp> query
+// parameter, for example ?createdAt>=2019-03-20T12:00:00Z. The timestamp is
+// accepted in both the RFC 3339 and the historical v1beta format. The
+// parameter is removed from m so the generic parameter filter does not see
+// it. Nil is returned when the parameter is not present.
+func ParseCreatedAtFilter(m map[string][]string) (*CreatedAtFilter, error) {
+	// The query parser splits createdAt>=<timestamp> at the first '=', so
+	// the '>' ends up in the key.
+	v, ok := m["createdAt>"]
+	if !ok {
+		return nil, nil
+	}
+	delete(m, "createdAt>")
+
+	since, err := utils.ParseTime(v[0])
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedAtFilter{Since: since}, nil
+}
+
+// Match reports whether a resource with the given creation time passes the
+// filter. Resources without a parseable creation time are filtered out, it
+// can not be proven that they are recent enough.
+func (filter *CreatedAtFilter) Match(createdAt string) bool {
+	t, err := utils.ParseTime(createdAt)
+	if err != nil {
+		return false
+	}
+	return !t.Before(filter.Since)
+}
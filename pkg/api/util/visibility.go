@@ -0,0 +1,68 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the creator visibility scoping of the read paths.
+With a tenant admin role configured, a tenant user without that role only
+sees the resources their own user created, while users holding the role
+keep seeing everything in the tenant.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	c "github.com/sodafoundation/api/pkg/context"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// CanSeeTenantResources reports whether the caller may see resources
+// created by other users of its tenant: always without a configured
+// tenant_admin_role, otherwise only for admins and callers holding the
+// role.
+func CanSeeTenantResources(ctx *c.Context) bool {
+	role := CONF.OsdsApiServer.TenantAdminRole
+	if role == "" || ctx.IsAdmin {
+		return true
+	}
+	for _, r := range ctx.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeListToCreator narrows the list parameters to the resources the
+// caller created when creator visibility applies to the caller. A userId
+// parameter the caller supplied is overridden, a member can not widen its
+// own scope.
+func ScopeListToCreator(ctx *c.Context, m map[string][]string) {
+	if CanSeeTenantResources(ctx) {
+		return
+	}
+	m["userId"] = []string{ctx.UserId}
+}
+
+// CheckCreatorVisibility returns an error when creator visibility applies
+// to the caller and the resource was created by another user. The read
+// handlers turn the error into a not found response, so the scoping does
+// not leak that the resource exists.
+func CheckCreatorVisibility(ctx *c.Context, creatorId string) error {
+	if CanSeeTenantResources(ctx) || creatorId == "" || creatorId == ctx.UserId {
+		return nil
+	}
+	return fmt.Errorf("the resource was created by another user")
+}
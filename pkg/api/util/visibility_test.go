@@ -0,0 +1,76 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	c "github.com/sodafoundation/api/pkg/context"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+func TestCreatorVisibility(t *testing.T) {
+	setRole := func(role string) func() {
+		CONF.OsdsApiServer.TenantAdminRole = role
+		return func() { CONF.OsdsApiServer.TenantAdminRole = "" }
+	}
+	member := &c.Context{UserId: "member-user", Roles: []string{"member"}}
+	tenantAdmin := &c.Context{UserId: "admin-user", Roles: []string{"tenant-admin"}}
+
+	t.Run("Should not scope without a configured tenant admin role", func(t *testing.T) {
+		m := map[string][]string{}
+		ScopeListToCreator(member, m)
+		assertTestResult(t, len(m), 0)
+		if err := CheckCreatorVisibility(member, "someone-else"); err != nil {
+			t.Errorf("expected the resource to be visible, got %v", err)
+		}
+	})
+
+	t.Run("Should scope a member to their own resources", func(t *testing.T) {
+		defer setRole("tenant-admin")()
+		m := map[string][]string{"userId": {"someone-else"}}
+		ScopeListToCreator(member, m)
+		assertTestResult(t, m["userId"][0], "member-user")
+		if err := CheckCreatorVisibility(member, "member-user"); err != nil {
+			t.Errorf("expected the own resource to be visible, got %v", err)
+		}
+		err := CheckCreatorVisibility(member, "someone-else")
+		assertTestResult(t, err.Error(), "the resource was created by another user")
+	})
+
+	t.Run("Should keep the tenant admin role seeing everything", func(t *testing.T) {
+		defer setRole("tenant-admin")()
+		m := map[string][]string{}
+		ScopeListToCreator(tenantAdmin, m)
+		assertTestResult(t, len(m), 0)
+		if err := CheckCreatorVisibility(tenantAdmin, "someone-else"); err != nil {
+			t.Errorf("expected the resource to be visible, got %v", err)
+		}
+	})
+
+	t.Run("Should keep an admin context seeing everything", func(t *testing.T) {
+		defer setRole("tenant-admin")()
+		if err := CheckCreatorVisibility(c.NewAdminContext(), "someone-else"); err != nil {
+			t.Errorf("expected the resource to be visible, got %v", err)
+		}
+	})
+
+	t.Run("Should keep legacy resources without a creator visible", func(t *testing.T) {
+		defer setRole("tenant-admin")()
+		if err := CheckCreatorVisibility(member, ""); err != nil {
+			t.Errorf("expected the resource to be visible, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the external validation webhook which is called with
+the candidate resource before it is persisted, so that deployments can
+enforce their own naming, size or compliance rules.
+*/
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	log "github.com/golang/glog"
+	c "github.com/sodafoundation/api/pkg/context"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+// The failure policies which decide what happens with a request when the
+// validation webhook itself can not be reached or answers garbage.
+const (
+	WebhookFailurePolicyFail   = "fail"
+	WebhookFailurePolicyIgnore = "ignore"
+)
+
+// The operations a resource is submitted to the validation webhook for.
+const (
+	WebhookOperationCreate = "create"
+	WebhookOperationUpdate = "update"
+)
+
+// ValidationRequest is the body posted to the validation webhook.
+type ValidationRequest struct {
+	// The kind of the resource, e.g. "volume" or "fileshare".
+	Kind string `json:"kind"`
+	// The operation the resource is submitted for, "create" or "update".
+	Operation string `json:"operation"`
+	// The uuid of the tenant issuing the request.
+	TenantId string `json:"tenantId,omitempty"`
+	// The uuid of the user issuing the request.
+	UserId string `json:"userId,omitempty"`
+	// The candidate resource as it would be persisted.
+	Resource json.RawMessage `json:"resource"`
+}
+
+// ValidationResponse is the answer expected from the validation webhook.
+type ValidationResponse struct {
+	// Whether the request may proceed.
+	Allowed bool `json:"allowed"`
+	// The reason of the rejection, returned to the user.
+	Message string `json:"message,omitempty"`
+	// An optional mutated version of the resource which replaces the
+	// candidate before it is persisted.
+	Resource json.RawMessage `json:"resource,omitempty"`
+}
+
+// ValidateWithWebhook posts the candidate resource to the configured
+// validation webhook and applies its verdict. The webhook can reject the
+// request with a message or return a mutated resource, which is decoded
+// back into in. When no webhook url is configured this is a no-op.
+func ValidateWithWebhook(ctx *c.Context, kind, operation string, in interface{}) error {
+	url := CONF.OsdsApiServer.ValidationWebhookUrl
+	if url == "" {
+		return nil
+	}
+
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return webhookFailure(fmt.Errorf("marshal resource for validation webhook failed: %v", err))
+	}
+	reqBody, err := json.Marshal(&ValidationRequest{
+		Kind:      kind,
+		Operation: operation,
+		TenantId:  ctx.TenantId,
+		UserId:    ctx.UserId,
+		Resource:  raw,
+	})
+	if err != nil {
+		return webhookFailure(fmt.Errorf("marshal validation webhook request failed: %v", err))
+	}
+
+	client := &http.Client{Timeout: CONF.OsdsApiServer.ValidationWebhookTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return webhookFailure(fmt.Errorf("validation webhook unreachable: %v", err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return webhookFailure(fmt.Errorf("validation webhook returned status %d", resp.StatusCode))
+	}
+
+	var review ValidationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		return webhookFailure(fmt.Errorf("decode validation webhook response failed: %v", err))
+	}
+
+	if !review.Allowed {
+		errMsg := fmt.Sprintf("request rejected by validation webhook: %s", review.Message)
+		log.Error(errMsg)
+		return errors.New(errMsg)
+	}
+	if len(review.Resource) != 0 {
+		if err := json.Unmarshal(review.Resource, in); err != nil {
+			return webhookFailure(fmt.Errorf("decode mutated resource from validation webhook failed: %v", err))
+		}
+	}
+	return nil
+}
+
+// webhookFailure applies the configured failure policy to an error talking
+// to the validation webhook: with "ignore" the request proceeds as if no
+// webhook was configured, with "fail" the request is rejected.
+func webhookFailure(err error) error {
+	if CONF.OsdsApiServer.ValidationWebhookFailurePolicy == WebhookFailurePolicyIgnore {
+		log.Warning("ignoring validation webhook failure:", err)
+		return nil
+	}
+	log.Error(err.Error())
+	return err
+}
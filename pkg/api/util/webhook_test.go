@@ -0,0 +1,110 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	. "github.com/sodafoundation/api/pkg/utils/config"
+)
+
+func TestValidateWithWebhook(t *testing.T) {
+	var in = &model.VolumeSpec{
+		BaseModel: &model.BaseModel{},
+		Name:      "volume sample",
+		Size:      int64(1),
+	}
+
+	t.Run("Should do nothing if no webhook is configured", func(t *testing.T) {
+		CONF.OsdsApiServer.ValidationWebhookUrl = ""
+
+		if err := ValidateWithWebhook(context.NewAdminContext(), "volume", WebhookOperationCreate, in); err != nil {
+			t.Errorf("expected no error without a webhook, got %v\n", err)
+		}
+	})
+
+	t.Run("Should pass the request through if the webhook allows it", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req ValidationRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			assertTestResult(t, req.Kind, "volume")
+			assertTestResult(t, req.Operation, WebhookOperationCreate)
+			json.NewEncoder(w).Encode(&ValidationResponse{Allowed: true})
+		}))
+		defer ts.Close()
+		CONF.OsdsApiServer.ValidationWebhookUrl = ts.URL
+		defer func() { CONF.OsdsApiServer.ValidationWebhookUrl = "" }()
+
+		if err := ValidateWithWebhook(context.NewAdminContext(), "volume", WebhookOperationCreate, in); err != nil {
+			t.Errorf("expected the request to be allowed, got %v\n", err)
+		}
+	})
+
+	t.Run("Should reject the request if the webhook denies it", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(&ValidationResponse{Allowed: false, Message: "name violates naming policy"})
+		}))
+		defer ts.Close()
+		CONF.OsdsApiServer.ValidationWebhookUrl = ts.URL
+		defer func() { CONF.OsdsApiServer.ValidationWebhookUrl = "" }()
+
+		err := ValidateWithWebhook(context.NewAdminContext(), "volume", WebhookOperationCreate, in)
+		expectedError := "request rejected by validation webhook: name violates naming policy"
+		assertTestResult(t, err.Error(), expectedError)
+	})
+
+	t.Run("Should apply the mutated resource returned by the webhook", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req ValidationRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			var vol model.VolumeSpec
+			json.Unmarshal(req.Resource, &vol)
+			vol.Description = "annotated by webhook"
+			mutated, _ := json.Marshal(&vol)
+			json.NewEncoder(w).Encode(&ValidationResponse{Allowed: true, Resource: mutated})
+		}))
+		defer ts.Close()
+		CONF.OsdsApiServer.ValidationWebhookUrl = ts.URL
+		defer func() { CONF.OsdsApiServer.ValidationWebhookUrl = "" }()
+
+		if err := ValidateWithWebhook(context.NewAdminContext(), "volume", WebhookOperationCreate, in); err != nil {
+			t.Errorf("expected the request to be allowed, got %v\n", err)
+		}
+		assertTestResult(t, in.Description, "annotated by webhook")
+	})
+
+	t.Run("Should honor the failure policy if the webhook is unreachable", func(t *testing.T) {
+		CONF.OsdsApiServer.ValidationWebhookUrl = "http://127.0.0.1:1/validate"
+		defer func() {
+			CONF.OsdsApiServer.ValidationWebhookUrl = ""
+			CONF.OsdsApiServer.ValidationWebhookFailurePolicy = WebhookFailurePolicyFail
+		}()
+
+		CONF.OsdsApiServer.ValidationWebhookFailurePolicy = WebhookFailurePolicyFail
+		if err := ValidateWithWebhook(context.NewAdminContext(), "volume", WebhookOperationCreate, in); err == nil {
+			t.Errorf("expected an error with the fail policy, got none\n")
+		}
+
+		CONF.OsdsApiServer.ValidationWebhookFailurePolicy = WebhookFailurePolicyIgnore
+		if err := ValidateWithWebhook(context.NewAdminContext(), "volume", WebhookOperationCreate, in); err != nil {
+			t.Errorf("expected no error with the ignore policy, got %v\n", err)
+		}
+	})
+}
@@ -0,0 +1,226 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the status watchdog which fails resources that stay
+in a transitional status for too long. When a controller dispatch is lost a
+resource would otherwise be stuck in a status like creating, deleting,
+extending or attaching forever; the watchdog transitions it to the matching
+error status of its kind with a fault record,
+so users and automation see a final state they can act on.
+*/
+
+package watchdog
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/golang/glog"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+// Watchdog periodically scans the database for resources stuck in a
+// transitional status and marks them failed.
+type Watchdog struct {
+	interval time.Duration
+	timeout  time.Duration
+}
+
+func NewWatchdog(interval, timeout time.Duration) *Watchdog {
+	return &Watchdog{
+		interval: interval,
+		timeout:  timeout,
+	}
+}
+
+// Run starts the scan loop. It blocks, so callers usually run it in its own
+// goroutine.
+func (w *Watchdog) Run() {
+	for {
+		time.Sleep(w.interval)
+		w.scanOnce()
+	}
+}
+
+// The transitional statuses the watchdog guards, per resource kind, each
+// mapped to the error status a stuck resource is failed into. A status
+// missing here is either final or intentionally open ended, like a reserved
+// attachment waiting for the orchestrator, and is left alone.
+var (
+	stuckVolumeStatus = map[string]string{
+		model.VolumeCreating:  model.VolumeError,
+		model.VolumeDeleting:  model.VolumeErrorDeleting,
+		model.VolumeExtending: model.VolumeErrorExtending,
+		model.VolumeAttaching: model.VolumeErrorAttaching,
+	}
+	stuckVolumeSnapshotStatus = map[string]string{
+		model.VolumeSnapCreating: model.VolumeSnapError,
+		model.VolumeSnapDeleting: model.VolumeSnapErrorDeleting,
+	}
+	stuckVolumeAttachmentStatus = map[string]string{
+		model.VolumeAttachCreating: model.VolumeAttachError,
+		model.VolumeAttachDeleting: model.VolumeAttachErrorDeleting,
+	}
+	stuckFileShareStatus = map[string]string{
+		model.FileShareCreating: model.FileShareError,
+		model.FileShareDeleting: model.FileShareErrorDeleting,
+	}
+	stuckFileShareSnapshotStatus = map[string]string{
+		model.FileShareSnapCreating: model.FileShareSnapError,
+		model.FileShareSnapDeleting: model.FileShareSnapErrorDeleting,
+	}
+)
+
+// isStuck tells whether a resource in a guarded transitional status has not
+// changed since the configured timeout, and returns the error status it
+// should be failed into. Resources without a parseable timestamp are left
+// alone.
+func (w *Watchdog) isStuck(stuck map[string]string, status, updatedAt, createdAt string) (string, bool) {
+	newStatus, ok := stuck[status]
+	if !ok {
+		return "", false
+	}
+	stamp := updatedAt
+	if stamp == "" {
+		stamp = createdAt
+	}
+	t, err := utils.ParseTime(stamp)
+	if err != nil {
+		return "", false
+	}
+	if time.Since(t) <= w.timeout {
+		return "", false
+	}
+	return newStatus, true
+}
+
+// faultFor returns the fault recorded on a stuck resource.
+func (w *Watchdog) faultFor(status string) *model.FaultSpec {
+	return model.NewFault(fmt.Sprintf(
+		"operation timed out: the resource stayed in status %s for more than %v, the controller dispatch was probably lost",
+		status, w.timeout))
+}
+
+func (w *Watchdog) scanOnce() {
+	ctx := c.NewAdminContext()
+
+	volumes, err := db.C.ListVolumes(ctx)
+	if err != nil {
+		log.Error("watchdog failed to list volumes:", err)
+	}
+	for _, volume := range volumes {
+		newStatus, stuck := w.isStuck(stuckVolumeStatus, volume.Status, volume.UpdatedAt, volume.CreatedAt)
+		if !stuck {
+			continue
+		}
+		fault := w.faultFor(volume.Status)
+		log.Warningf("event: volume %s of tenant %s stuck in status %s, marking %s",
+			volume.Id, volume.TenantId, volume.Status, newStatus)
+		if _, err := db.C.UpdateVolume(ctx, &model.VolumeSpec{
+			BaseModel: &model.BaseModel{Id: volume.Id},
+			Status:    newStatus,
+			Fault:     fault,
+		}); err != nil {
+			log.Errorf("watchdog failed to fail volume %s: %v", volume.Id, err)
+		}
+	}
+
+	snapshots, err := db.C.ListVolumeSnapshots(ctx)
+	if err != nil {
+		log.Error("watchdog failed to list volume snapshots:", err)
+	}
+	for _, snapshot := range snapshots {
+		newStatus, stuck := w.isStuck(stuckVolumeSnapshotStatus, snapshot.Status, snapshot.UpdatedAt, snapshot.CreatedAt)
+		if !stuck {
+			continue
+		}
+		fault := w.faultFor(snapshot.Status)
+		log.Warningf("event: volume snapshot %s of tenant %s stuck in status %s, marking %s",
+			snapshot.Id, snapshot.TenantId, snapshot.Status, newStatus)
+		if _, err := db.C.UpdateVolumeSnapshot(ctx, snapshot.Id, &model.VolumeSnapshotSpec{
+			BaseModel: &model.BaseModel{},
+			Status:    newStatus,
+			Fault:     fault,
+		}); err != nil {
+			log.Errorf("watchdog failed to fail volume snapshot %s: %v", snapshot.Id, err)
+		}
+	}
+
+	attachments, err := db.C.ListVolumeAttachments(ctx, "")
+	if err != nil {
+		log.Error("watchdog failed to list volume attachments:", err)
+	}
+	for _, attachment := range attachments {
+		newStatus, stuck := w.isStuck(stuckVolumeAttachmentStatus, attachment.Status, attachment.UpdatedAt, attachment.CreatedAt)
+		if !stuck {
+			continue
+		}
+		fault := w.faultFor(attachment.Status)
+		log.Warningf("event: volume attachment %s of tenant %s stuck in status %s, marking %s",
+			attachment.Id, attachment.TenantId, attachment.Status, newStatus)
+		if _, err := db.C.UpdateVolumeAttachment(ctx, attachment.Id, &model.VolumeAttachmentSpec{
+			BaseModel: &model.BaseModel{},
+			Status:    newStatus,
+			Fault:     fault,
+		}); err != nil {
+			log.Errorf("watchdog failed to fail volume attachment %s: %v", attachment.Id, err)
+		}
+	}
+
+	shares, err := db.C.ListFileShares(ctx)
+	if err != nil {
+		log.Error("watchdog failed to list fileshares:", err)
+	}
+	for _, share := range shares {
+		newStatus, stuck := w.isStuck(stuckFileShareStatus, share.Status, share.UpdatedAt, share.CreatedAt)
+		if !stuck {
+			continue
+		}
+		fault := w.faultFor(share.Status)
+		log.Warningf("event: fileshare %s of tenant %s stuck in status %s, marking %s",
+			share.Id, share.TenantId, share.Status, newStatus)
+		if _, err := db.C.UpdateFileShare(ctx, &model.FileShareSpec{
+			BaseModel: &model.BaseModel{Id: share.Id},
+			Status:    newStatus,
+			Fault:     fault,
+		}); err != nil {
+			log.Errorf("watchdog failed to fail fileshare %s: %v", share.Id, err)
+		}
+	}
+
+	shareSnapshots, err := db.C.ListFileShareSnapshots(ctx)
+	if err != nil {
+		log.Error("watchdog failed to list fileshare snapshots:", err)
+	}
+	for _, snapshot := range shareSnapshots {
+		newStatus, stuck := w.isStuck(stuckFileShareSnapshotStatus, snapshot.Status, snapshot.UpdatedAt, snapshot.CreatedAt)
+		if !stuck {
+			continue
+		}
+		fault := w.faultFor(snapshot.Status)
+		log.Warningf("event: fileshare snapshot %s of tenant %s stuck in status %s, marking %s",
+			snapshot.Id, snapshot.TenantId, snapshot.Status, newStatus)
+		if _, err := db.C.UpdateFileShareSnapshot(ctx, snapshot.Id, &model.FileShareSnapshotSpec{
+			BaseModel: &model.BaseModel{},
+			Status:    newStatus,
+			Fault:     fault,
+		}); err != nil {
+			log.Errorf("watchdog failed to fail fileshare snapshot %s: %v", snapshot.Id, err)
+		}
+	}
+}
@@ -18,6 +18,7 @@
 package context
 
 import (
+	gocontext "context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -29,15 +30,17 @@ import (
 
 func NewAdminContext() *Context {
 	return &Context{
-		IsAdmin: true,
+		IsAdmin:    true,
+		AllTenants: true,
 	}
 }
 
 func NewInternalTenantContext(tenantId, userId string) *Context {
 	return &Context{
-		TenantId: tenantId,
-		UserId:   userId,
-		IsAdmin:  true,
+		TenantId:   tenantId,
+		UserId:     userId,
+		IsAdmin:    true,
+		AllTenants: true,
 	}
 }
 
@@ -50,11 +53,24 @@ func NewContextFromJson(s string) *Context {
 	return ctx
 }
 
+// RequestStartKey is the beego input data key under which the context
+// filter records the time the request entered the filter chain.
+const RequestStartKey = "requestStart"
+
 func GetContext(httpCtx *context.Context) *Context {
 	ctx, _ := httpCtx.Input.GetData("context").(*Context)
 	if ctx == nil {
 		ctx = &Context{}
 	}
+	// The standard library context of the request rides along, so layers
+	// below (like the db driver) stop working for a request that was
+	// canceled or timed out. Only a context that can actually be canceled
+	// is worth carrying.
+	if httpCtx.Request != nil {
+		if reqCtx := httpCtx.Request.Context(); reqCtx.Done() != nil {
+			ctx.StdCtx = reqCtx
+		}
+	}
 	return ctx
 }
 
@@ -112,6 +128,32 @@ type Context struct {
 	ServiceRoles             string   `policy:"true" json:"service_roles"`
 	Token                    string   `policy:"false" json:"token"`
 	Uri                      string   `policy:"false" json:"uri"`
+	AllTenants               bool     `policy:"false" json:"all_tenants"`
+
+	// SourceIp and UserAgent describe the client that sent the request.
+	// They are recorded in the system metadata section of created
+	// resources and stay empty for contexts created outside a request.
+	SourceIp  string `policy:"false" json:"source_ip"`
+	UserAgent string `policy:"false" json:"user_agent"`
+
+	// TokenScope restricts the request to the listed "resource:action"
+	// policy actions when it was authenticated by a scoped token. It stays
+	// empty for requests carrying full credentials.
+	TokenScope []string `policy:"false" json:"token_scope"`
+
+	// StdCtx is the standard library context of the request this Context
+	// belongs to. It is never serialized and stays empty for contexts
+	// created outside a request.
+	StdCtx gocontext.Context `policy:"false" json:"-"`
+}
+
+// Std returns the standard library context of the request, falling back to
+// context.Background() for contexts created outside a request.
+func (ctx *Context) Std() gocontext.Context {
+	if ctx.StdCtx == nil {
+		return gocontext.Background()
+	}
+	return ctx.StdCtx
 }
 
 func (ctx *Context) ToPolicyValue() map[string]interface{} {
@@ -0,0 +1,73 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// This module defines how the auth context rides along on the controller
+// gRPC channel as standard metadata. Historically the whole context is
+// serialized as a JSON string inside every opts message; carrying tenancy,
+// user and roles as metadata as well lets the controller side act on them
+// in interceptors without deserializing the opts. The JSON field stays
+// populated during the deprecation window.
+
+package context
+
+import (
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// The metadata keys of the auth context. Keys must be lowercase in gRPC
+// metadata; the roles key holds one value per role.
+const (
+	AuthMetadataTenantIdKey = "x-opensds-auth-tenant-id"
+	AuthMetadataUserIdKey   = "x-opensds-auth-user-id"
+	AuthMetadataIsAdminKey  = "x-opensds-auth-is-admin"
+	AuthMetadataRolesKey    = "x-opensds-auth-roles"
+)
+
+// ToGrpcMetadata returns the tenancy, user and roles of the context as gRPC
+// metadata following the schema above. The admin flag is always present,
+// the other keys only when the context carries them.
+func (ctx *Context) ToGrpcMetadata() metadata.MD {
+	md := metadata.MD{}
+	md.Set(AuthMetadataIsAdminKey, strconv.FormatBool(ctx.IsAdmin))
+	if ctx.TenantId != "" {
+		md.Set(AuthMetadataTenantIdKey, ctx.TenantId)
+	}
+	if ctx.UserId != "" {
+		md.Set(AuthMetadataUserIdKey, ctx.UserId)
+	}
+	if len(ctx.Roles) > 0 {
+		md.Set(AuthMetadataRolesKey, ctx.Roles...)
+	}
+	return md
+}
+
+// NewContextFromGrpcMetadata is the receiving half of the schema: it builds
+// a context from the auth metadata of an incoming call. Keys outside the
+// schema are ignored, so the metadata may share the channel with others.
+func NewContextFromGrpcMetadata(md metadata.MD) *Context {
+	ctx := &Context{}
+	if vals := md.Get(AuthMetadataIsAdminKey); len(vals) > 0 {
+		ctx.IsAdmin, _ = strconv.ParseBool(vals[0])
+	}
+	if vals := md.Get(AuthMetadataTenantIdKey); len(vals) > 0 {
+		ctx.TenantId = vals[0]
+	}
+	if vals := md.Get(AuthMetadataUserIdKey); len(vals) > 0 {
+		ctx.UserId = vals[0]
+	}
+	ctx.Roles = md.Get(AuthMetadataRolesKey)
+	return ctx
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package context
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestToGrpcMetadata(t *testing.T) {
+	ctx := Context{
+		TenantId: "586d0a9f-0d5b-48cc-8575-97e80e63b8bf",
+		UserId:   "ebf133af8beb474f962869ec0d362b1e",
+		IsAdmin:  true,
+		Roles:    []string{"admin", "member"},
+	}
+	expect := metadata.MD{
+		AuthMetadataTenantIdKey: []string{"586d0a9f-0d5b-48cc-8575-97e80e63b8bf"},
+		AuthMetadataUserIdKey:   []string{"ebf133af8beb474f962869ec0d362b1e"},
+		AuthMetadataIsAdminKey:  []string{"true"},
+		AuthMetadataRolesKey:    []string{"admin", "member"},
+	}
+	result := ctx.ToGrpcMetadata()
+	if !reflect.DeepEqual(expect, result) {
+		t.Errorf("Test Context ToGrpcMetadata failed, expected:%v, get:%v", expect, result)
+	}
+}
+
+func TestNewContextFromGrpcMetadata(t *testing.T) {
+	ctx := Context{
+		TenantId: "586d0a9f-0d5b-48cc-8575-97e80e63b8bf",
+		UserId:   "ebf133af8beb474f962869ec0d362b1e",
+		IsAdmin:  true,
+		Roles:    []string{"admin", "member"},
+	}
+	// Keys outside the schema share the channel and are ignored.
+	md := metadata.Join(ctx.ToGrpcMetadata(), metadata.Pairs("x-other-key", "other"))
+	result := NewContextFromGrpcMetadata(md)
+	expect := &Context{
+		TenantId: ctx.TenantId,
+		UserId:   ctx.UserId,
+		IsAdmin:  true,
+		Roles:    ctx.Roles,
+	}
+	if !reflect.DeepEqual(expect, result) {
+		t.Errorf("Test NewContextFromGrpcMetadata failed, expected:%v, get:%v", expect, result)
+	}
+}
@@ -78,6 +78,10 @@ type Client interface {
 
 	UpdateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*model.FileShareSpec, error)
 
+	UpdateFileSharePublic(ctx *c.Context, fshareID string, isPublic bool) (*model.FileShareSpec, error)
+
+	UpdateFileShareMetadata(ctx *c.Context, fshareID string, update *model.MetadataUpdateSpec) (*model.FileShareSpec, error)
+
 	DeleteFileShare(ctx *c.Context, fshareID string) error
 
 	DeleteFileShareAcl(ctx *c.Context, aclID string) error
@@ -96,6 +100,150 @@ type Client interface {
 
 	DeleteFileShareSnapshot(ctx *c.Context, snapshotID string) error
 
+	CreateFileShareSnapshotCopy(ctx *c.Context, copy *model.FileShareSnapshotCopySpec) (*model.FileShareSnapshotCopySpec, error)
+
+	GetFileShareSnapshotCopy(ctx *c.Context, copyID string) (*model.FileShareSnapshotCopySpec, error)
+
+	ListFileShareSnapshotCopies(ctx *c.Context) ([]*model.FileShareSnapshotCopySpec, error)
+
+	UpdateFileShareSnapshotCopy(ctx *c.Context, copyID string, copy *model.FileShareSnapshotCopySpec) (*model.FileShareSnapshotCopySpec, error)
+
+	CreateBackupTarget(ctx *c.Context, target *model.BackupTargetSpec) (*model.BackupTargetSpec, error)
+
+	GetBackupTarget(ctx *c.Context, targetID string) (*model.BackupTargetSpec, error)
+
+	ListBackupTargets(ctx *c.Context) ([]*model.BackupTargetSpec, error)
+
+	UpdateBackupTarget(ctx *c.Context, targetID string, target *model.BackupTargetSpec) (*model.BackupTargetSpec, error)
+
+	DeleteBackupTarget(ctx *c.Context, targetID string) error
+
+	CreateShareNetwork(ctx *c.Context, network *model.ShareNetworkSpec) (*model.ShareNetworkSpec, error)
+
+	GetShareNetwork(ctx *c.Context, networkID string) (*model.ShareNetworkSpec, error)
+
+	ListShareNetworks(ctx *c.Context) ([]*model.ShareNetworkSpec, error)
+
+	UpdateShareNetwork(ctx *c.Context, networkID string, network *model.ShareNetworkSpec) (*model.ShareNetworkSpec, error)
+
+	DeleteShareNetwork(ctx *c.Context, networkID string) error
+
+	CreateFileShareSnapshotBackup(ctx *c.Context, backup *model.FileShareSnapshotBackupSpec) (*model.FileShareSnapshotBackupSpec, error)
+
+	GetFileShareSnapshotBackup(ctx *c.Context, backupID string) (*model.FileShareSnapshotBackupSpec, error)
+
+	ListFileShareSnapshotBackups(ctx *c.Context) ([]*model.FileShareSnapshotBackupSpec, error)
+
+	UpdateFileShareSnapshotBackup(ctx *c.Context, backupID string, backup *model.FileShareSnapshotBackupSpec) (*model.FileShareSnapshotBackupSpec, error)
+
+	DeleteFileShareSnapshotBackup(ctx *c.Context, backupID string) error
+
+	CreateAnnotation(ctx *c.Context, anno *model.AnnotationSpec) (*model.AnnotationSpec, error)
+
+	ListAnnotations(ctx *c.Context, resourceType, resourceID string) ([]*model.AnnotationSpec, error)
+
+	AcquirePendingOperation(ctx *c.Context, op *model.PendingOperationSpec) (*model.PendingOperationSpec, error)
+
+	ReleasePendingOperation(ctx *c.Context, resourceType, resourceID, token string) error
+
+	CreateApiKey(ctx *c.Context, key *model.ApiKeySpec) (*model.ApiKeySpec, error)
+
+	GetApiKey(ctx *c.Context, keyID string) (*model.ApiKeySpec, error)
+
+	ListApiKeys(ctx *c.Context) ([]*model.ApiKeySpec, error)
+
+	UpdateApiKey(ctx *c.Context, key *model.ApiKeySpec) (*model.ApiKeySpec, error)
+
+	DeleteApiKey(ctx *c.Context, keyID string) error
+
+	CreateScopedToken(ctx *c.Context, token *model.ScopedTokenSpec) (*model.ScopedTokenSpec, error)
+
+	GetScopedToken(ctx *c.Context, tokenID string) (*model.ScopedTokenSpec, error)
+
+	ListScopedTokens(ctx *c.Context) ([]*model.ScopedTokenSpec, error)
+
+	DeleteScopedToken(ctx *c.Context, tokenID string) error
+
+	CreateNetworkPolicy(ctx *c.Context, policy *model.NetworkPolicySpec) (*model.NetworkPolicySpec, error)
+
+	GetNetworkPolicy(ctx *c.Context, policyID string) (*model.NetworkPolicySpec, error)
+
+	ListNetworkPolicies(ctx *c.Context) ([]*model.NetworkPolicySpec, error)
+
+	UpdateNetworkPolicy(ctx *c.Context, policyID string, policy *model.NetworkPolicySpec) (*model.NetworkPolicySpec, error)
+
+	DeleteNetworkPolicy(ctx *c.Context, policyID string) error
+
+	CreateResourceClass(ctx *c.Context, class *model.ResourceClassSpec) (*model.ResourceClassSpec, error)
+
+	GetResourceClass(ctx *c.Context, classID string) (*model.ResourceClassSpec, error)
+
+	ListResourceClasses(ctx *c.Context) ([]*model.ResourceClassSpec, error)
+
+	UpdateResourceClass(ctx *c.Context, classID string, class *model.ResourceClassSpec) (*model.ResourceClassSpec, error)
+
+	DeleteResourceClass(ctx *c.Context, classID string) error
+
+	ListTombstones(ctx *c.Context) ([]*model.TombstoneSpec, error)
+
+	DeleteTombstone(ctx *c.Context, tombstoneID string) error
+
+	CreateCascadeTask(ctx *c.Context, task *model.CascadeTaskSpec) (*model.CascadeTaskSpec, error)
+
+	GetCascadeTask(ctx *c.Context, taskID string) (*model.CascadeTaskSpec, error)
+
+	ListCascadeTasks(ctx *c.Context) ([]*model.CascadeTaskSpec, error)
+
+	UpdateCascadeTask(ctx *c.Context, task *model.CascadeTaskSpec) (*model.CascadeTaskSpec, error)
+
+	DeleteCascadeTask(ctx *c.Context, taskID string) error
+
+	CreateTask(ctx *c.Context, task *model.TaskSpec) (*model.TaskSpec, error)
+
+	GetTask(ctx *c.Context, taskID string) (*model.TaskSpec, error)
+
+	ListTasks(ctx *c.Context) ([]*model.TaskSpec, error)
+
+	UpdateTask(ctx *c.Context, task *model.TaskSpec) (*model.TaskSpec, error)
+
+	DeleteTask(ctx *c.Context, taskID string) error
+
+	CreateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error)
+
+	GetJob(ctx *c.Context, jobID string) (*model.JobSpec, error)
+
+	ListJobs(ctx *c.Context) ([]*model.JobSpec, error)
+
+	UpdateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error)
+
+	DeleteJob(ctx *c.Context, jobID string) error
+
+	RebuildSearchIndexes(ctx *c.Context) (int, error)
+
+	CreateRegion(ctx *c.Context, region *model.RegionSpec) (*model.RegionSpec, error)
+
+	GetRegion(ctx *c.Context, regionID string) (*model.RegionSpec, error)
+
+	ListRegions(ctx *c.Context) ([]*model.RegionSpec, error)
+
+	UpdateRegion(ctx *c.Context, regionID string, region *model.RegionSpec) (*model.RegionSpec, error)
+
+	DeleteRegion(ctx *c.Context, regionID string) error
+
+	GetQuota(ctx *c.Context, tenantID string) (*model.QuotaSpec, error)
+
+	ListQuotas(ctx *c.Context) ([]*model.QuotaSpec, error)
+
+	SetQuota(ctx *c.Context, quota *model.QuotaSpec) (*model.QuotaSpec, error)
+
+	DeleteQuota(ctx *c.Context, tenantID string) error
+
+	GetDefaultTags(ctx *c.Context, tenantID string) (*model.DefaultTagsSpec, error)
+
+	SetDefaultTags(ctx *c.Context, tags *model.DefaultTagsSpec) (*model.DefaultTagsSpec, error)
+
+	DeleteDefaultTags(ctx *c.Context, tenantID string) error
+
 	CreateDock(ctx *c.Context, dck *model.DockSpec) (*model.DockSpec, error)
 
 	GetDock(ctx *c.Context, dckID string) (*model.DockSpec, error)
@@ -122,6 +270,10 @@ type Client interface {
 
 	UpdatePool(ctx *c.Context, polID, name, desp string, usedCapacity int64, used bool) (*model.StoragePoolSpec, error)
 
+	UpdatePoolCapacitySettings(ctx *c.Context, polID string, reservedCapacity int64, maxOverSubscriptionRatio float64) (*model.StoragePoolSpec, error)
+
+	UpdatePoolMaintenance(ctx *c.Context, polID string, maintenance bool) (*model.StoragePoolSpec, error)
+
 	DeletePool(ctx *c.Context, polID string) error
 
 	CreateProfile(ctx *c.Context, prf *model.ProfileSpec) (*model.ProfileSpec, error)
@@ -158,6 +310,8 @@ type Client interface {
 
 	UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.VolumeSpec, error)
 
+	UpdateVolumeMetadata(ctx *c.Context, volID string, update *model.MetadataUpdateSpec) (*model.VolumeSpec, error)
+
 	DeleteVolume(ctx *c.Context, volID string) error
 
 	ExtendVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.VolumeSpec, error)
@@ -233,6 +387,16 @@ type Client interface {
 	GetHost(ctx *c.Context, hostId string) (*model.HostSpec, error)
 
 	DeleteHost(ctx *c.Context, hostId string) error
+
+	CreateHostGroup(ctx *c.Context, hg *model.HostGroupSpec) (*model.HostGroupSpec, error)
+
+	GetHostGroup(ctx *c.Context, hgId string) (*model.HostGroupSpec, error)
+
+	ListHostGroups(ctx *c.Context) ([]*model.HostGroupSpec, error)
+
+	UpdateHostGroup(ctx *c.Context, hg *model.HostGroupSpec) (*model.HostGroupSpec, error)
+
+	DeleteHostGroup(ctx *c.Context, hgId string) error
 }
 
 func UpdateFileShareStatus(ctx *c.Context, client Client, fileID, status string) error {
@@ -250,6 +414,32 @@ func UpdateFileShareAclStatus(ctx *c.Context, client Client, fileID, status stri
 	return client.UpdateStatus(ctx, file, status)
 }
 
+// UpdateFileShareAclExportStatus records the application result of an acl
+// on one export location, reported back by the controller. A location the
+// acl record does not know yet is appended, so acls created before the
+// fileshare grew the location stay trackable.
+func UpdateFileShareAclExportStatus(ctx *c.Context, client Client, aclID, exportLocation, status, reason string) error {
+	acl, err := client.GetFileShareAcl(ctx, aclID)
+	if err != nil {
+		return err
+	}
+	var entry *model.AclExportStatusSpec
+	for _, candidate := range acl.ExportStatuses {
+		if candidate.ExportLocation == exportLocation {
+			entry = candidate
+			break
+		}
+	}
+	if entry == nil {
+		entry = &model.AclExportStatusSpec{ExportLocation: exportLocation}
+		acl.ExportStatuses = append(acl.ExportStatuses, entry)
+	}
+	entry.Status = status
+	entry.Reason = reason
+	_, err = client.UpdateFileShareAcl(ctx, acl)
+	return err
+}
+
 func UpdateVolumeStatus(ctx *c.Context, client Client, volID, status string) error {
 	vol, _ := client.GetVolume(ctx, volID)
 	return client.UpdateStatus(ctx, vol, status)
@@ -274,3 +464,99 @@ func UpdateVolumeGroupStatus(ctx *c.Context, client Client, vgID, status string)
 	vg, _ := client.GetVolumeGroup(ctx, vgID)
 	return client.UpdateStatus(ctx, vg, status)
 }
+
+// The fault variants below record the sanitized reason a resource entered
+// an error status together with the status itself, so the failure can be
+// read back from a GET response.
+
+func UpdateFileShareFault(ctx *c.Context, client Client, fileID, status, reason string) error {
+	file, _ := client.GetFileShare(ctx, fileID)
+	if file != nil {
+		file.Fault = model.NewFault(reason)
+	}
+	return client.UpdateStatus(ctx, file, status)
+}
+
+func UpdateFileShareSnapshotFault(ctx *c.Context, client Client, snapID, status, reason string) error {
+	snap, _ := client.GetFileShareSnapshot(ctx, snapID)
+	if snap != nil {
+		snap.Fault = model.NewFault(reason)
+	}
+	return client.UpdateStatus(ctx, snap, status)
+}
+
+func UpdateFileShareAclFault(ctx *c.Context, client Client, fileID, status, reason string) error {
+	file, _ := client.GetFileShareAcl(ctx, fileID)
+	if file != nil {
+		file.Fault = model.NewFault(reason)
+	}
+	return client.UpdateStatus(ctx, file, status)
+}
+
+func UpdateVolumeFault(ctx *c.Context, client Client, volID, status, reason string) error {
+	vol, _ := client.GetVolume(ctx, volID)
+	if vol != nil {
+		vol.Fault = model.NewFault(reason)
+	}
+	return client.UpdateStatus(ctx, vol, status)
+}
+
+func UpdateVolumeAttachmentFault(ctx *c.Context, client Client, atcID, status, reason string) error {
+	atc, _ := client.GetVolumeAttachment(ctx, atcID)
+	if atc != nil {
+		atc.Fault = model.NewFault(reason)
+	}
+	return client.UpdateStatus(ctx, atc, status)
+}
+
+func UpdateVolumeSnapshotFault(ctx *c.Context, client Client, snapID, status, reason string) error {
+	snap, _ := client.GetVolumeSnapshot(ctx, snapID)
+	if snap != nil {
+		snap.Fault = model.NewFault(reason)
+	}
+	return client.UpdateStatus(ctx, snap, status)
+}
+
+func UpdateReplicationFault(ctx *c.Context, client Client, replicaID, status, reason string) error {
+	replica, _ := client.GetReplication(ctx, replicaID)
+	if replica != nil {
+		replica.Fault = model.NewFault(reason)
+	}
+	return client.UpdateStatus(ctx, replica, status)
+}
+
+// The dispatch fault variants additionally record how many dispatch
+// attempts were made, used when an async operation exhausted its
+// configured retries.
+
+func UpdateFileShareDispatchFault(ctx *c.Context, client Client, fileID, status, reason string, attempts int) error {
+	file, _ := client.GetFileShare(ctx, fileID)
+	if file != nil {
+		file.Fault = model.NewFaultWithAttempts(reason, attempts)
+	}
+	return client.UpdateStatus(ctx, file, status)
+}
+
+func UpdateVolumeDispatchFault(ctx *c.Context, client Client, volID, status, reason string, attempts int) error {
+	vol, _ := client.GetVolume(ctx, volID)
+	if vol != nil {
+		vol.Fault = model.NewFaultWithAttempts(reason, attempts)
+	}
+	return client.UpdateStatus(ctx, vol, status)
+}
+
+func UpdateVolumeSnapshotDispatchFault(ctx *c.Context, client Client, snapID, status, reason string, attempts int) error {
+	snap, _ := client.GetVolumeSnapshot(ctx, snapID)
+	if snap != nil {
+		snap.Fault = model.NewFaultWithAttempts(reason, attempts)
+	}
+	return client.UpdateStatus(ctx, snap, status)
+}
+
+func UpdateVolumeGroupFault(ctx *c.Context, client Client, vgID, status, reason string) error {
+	vg, _ := client.GetVolumeGroup(ctx, vgID)
+	if vg != nil {
+		vg.Fault = model.NewFault(reason)
+	}
+	return client.UpdateStatus(ctx, vg, status)
+}
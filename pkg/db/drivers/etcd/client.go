@@ -17,10 +17,10 @@ package etcd
 import (
 	"context"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/coreos/etcd/pkg/transport"
+	"github.com/sodafoundation/api/pkg/db/health"
 	"github.com/sodafoundation/api/pkg/utils/config"
 	"github.com/sodafoundation/api/pkg/utils/pwd"
 
@@ -40,6 +40,11 @@ type Request struct {
 	Url        string `json:"url"`
 	Content    string `json:"content"`
 	NewContent string `json:"newContent"`
+
+	// Parent, when set, is the context of the api request the operation
+	// belongs to; a canceled request stops waiting for the db. It is not
+	// part of the serialized form.
+	Parent context.Context `json:"-"`
 }
 
 // Response
@@ -47,27 +52,56 @@ type Response struct {
 	Status  string   `json:"status"`
 	Message []string `json:"message"`
 	Error   string   `json:"error"`
+
+	// Keys holds the db keys of the records in Message, in the same order.
+	// Only list responses fill it, so corrupt records can be reported by
+	// key.
+	Keys []string `json:"keys,omitempty"`
 }
 
 type clientInterface interface {
 	Create(req *Request) *Response
 
+	// CreateIfNotExists writes Content only when no record exists under the
+	// key yet, for atomic acquisition of marker records. When the record
+	// already exists the response fails with ErrRecordExists and carries
+	// the existing record.
+	CreateIfNotExists(req *Request) *Response
+
 	Get(req *Request) *Response
 
 	List(req *Request) *Response
 
 	Update(req *Request) *Response
 
+	// UpdateIfUnchanged writes NewContent only when the record still holds
+	// Content, for atomic read-modify-write of single records.
+	UpdateIfUnchanged(req *Request) *Response
+
 	Delete(req *Request) *Response
 }
 
+// ErrRecordChanged is the error of an UpdateIfUnchanged response whose
+// record was modified between the read and the write; the caller should
+// re-read and retry.
+const ErrRecordChanged = "record changed since it was read"
+
+// ErrRecordExists is the error of a CreateIfNotExists response whose key
+// already holds a record; the existing record is left untouched.
+const ErrRecordExists = "record already exists"
+
 // Init
 func Init(etcd *config.Database) *client {
 	var cliv3 *clientv3.Client
 
 	clientV3Config := clientv3.Config{
-		Endpoints:   strings.Split(etcd.Endpoint, ","),
-		DialTimeout: timeOut,
+		Endpoints:            strings.Split(etcd.Endpoint, ","),
+		DialTimeout:          timeOut,
+		DialKeepAliveTime:    etcd.KeepaliveTime,
+		DialKeepAliveTimeout: etcd.KeepaliveTimeout,
+		// Refresh the endpoint list from the cluster so requests rotate to
+		// healthy members during leader elections.
+		AutoSyncInterval: etcd.EndpointAutoSyncInterval,
 	}
 
 	if etcd.EnableTLS {
@@ -105,20 +139,67 @@ func Init(etcd *config.Database) *client {
 	if err != nil {
 		panic(err)
 	}
-	return &client{cli: cliv3}
+
+	requestTimeout := etcd.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = timeOut
+	}
+	inflight := etcd.MaxInflightRequests
+	if inflight <= 0 {
+		inflight = 1
+	}
+	return &client{
+		cli:     cliv3,
+		timeout: requestTimeout,
+		sem:     make(chan struct{}, inflight),
+	}
 }
 
 type client struct {
-	cli  *clientv3.Client
-	lock sync.Mutex
+	cli     *clientv3.Client
+	timeout time.Duration
+	// sem bounds the number of in-flight etcd requests; callers over the
+	// limit wait here instead of piling up inside the etcd client.
+	sem chan struct{}
+}
+
+// acquire waits for an in-flight slot, giving up when ctx is canceled.
+func (c *client) acquire(ctx context.Context) error {
+	select {
+	case c.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *client) release() {
+	<-c.sem
+}
+
+// opContext derives the context of one operation, bounded by the client
+// timeout and canceled together with the api request it belongs to.
+func (c *client) opContext(req *Request) (context.Context, context.CancelFunc) {
+	parent := req.Parent
+	if parent == nil {
+		parent = context.Background()
+	}
+	return context.WithTimeout(parent, c.timeout)
 }
 
 func (c *client) Create(req *Request) *Response {
-	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+	ctx, cancel := c.opContext(req)
 	defer cancel()
 
-	c.lock.Lock()
-	defer c.lock.Unlock()
+	if err := c.acquire(ctx); err != nil {
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+	defer c.release()
+	start := time.Now()
+	defer func() { health.Observe(time.Since(start), len(c.sem)) }()
 
 	err := utils.Retry(retryNum, "Etcd put", false, func(retryIdx int, lastErr error) error {
 		_, err := c.cli.Put(ctx, req.Url, req.Content)
@@ -139,12 +220,65 @@ func (c *client) Create(req *Request) *Response {
 	}
 }
 
+func (c *client) CreateIfNotExists(req *Request) *Response {
+	ctx, cancel := c.opContext(req)
+	defer cancel()
+
+	if err := c.acquire(ctx); err != nil {
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+	defer c.release()
+	start := time.Now()
+	defer func() { health.Observe(time.Since(start), len(c.sem)) }()
+
+	resp, err := c.cli.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(req.Url), "=", 0)).
+		Then(clientv3.OpPut(req.Url, req.Content)).
+		Else(clientv3.OpGet(req.Url)).
+		Commit()
+	if err != nil {
+		log.Error("When conditionally create db request:", err)
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+	if !resp.Succeeded {
+		var existing []string
+		if len(resp.Responses) > 0 {
+			if kvs := resp.Responses[0].GetResponseRange().Kvs; len(kvs) > 0 {
+				existing = []string{string(kvs[0].Value)}
+			}
+		}
+		return &Response{
+			Status:  "Failure",
+			Message: existing,
+			Error:   ErrRecordExists,
+		}
+	}
+
+	return &Response{
+		Status:  "Success",
+		Message: []string{req.Content},
+	}
+}
+
 func (c *client) Get(req *Request) *Response {
-	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+	ctx, cancel := c.opContext(req)
 	defer cancel()
 
-	c.lock.Lock()
-	defer c.lock.Unlock()
+	if err := c.acquire(ctx); err != nil {
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+	defer c.release()
+	start := time.Now()
+	defer func() { health.Observe(time.Since(start), len(c.sem)) }()
 
 	resp, err := c.cli.Get(ctx, req.Url)
 	if err != nil {
@@ -168,11 +302,18 @@ func (c *client) Get(req *Request) *Response {
 }
 
 func (c *client) List(req *Request) *Response {
-	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+	ctx, cancel := c.opContext(req)
 	defer cancel()
 
-	c.lock.Lock()
-	defer c.lock.Unlock()
+	if err := c.acquire(ctx); err != nil {
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+	defer c.release()
+	start := time.Now()
+	defer func() { health.Observe(time.Since(start), len(c.sem)) }()
 
 	resp, err := c.cli.Get(ctx, req.Url, clientv3.WithPrefix())
 	if err != nil {
@@ -184,21 +325,31 @@ func (c *client) List(req *Request) *Response {
 	}
 
 	var message = []string{}
+	var keys = []string{}
 	for _, v := range resp.Kvs {
 		message = append(message, string(v.Value))
+		keys = append(keys, string(v.Key))
 	}
 	return &Response{
 		Status:  "Success",
 		Message: message,
+		Keys:    keys,
 	}
 }
 
 func (c *client) Update(req *Request) *Response {
-	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+	ctx, cancel := c.opContext(req)
 	defer cancel()
 
-	c.lock.Lock()
-	defer c.lock.Unlock()
+	if err := c.acquire(ctx); err != nil {
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+	defer c.release()
+	start := time.Now()
+	defer func() { health.Observe(time.Since(start), len(c.sem)) }()
 
 	_, err := c.cli.Put(ctx, req.Url, req.NewContent)
 	if err != nil {
@@ -215,12 +366,57 @@ func (c *client) Update(req *Request) *Response {
 	}
 }
 
+func (c *client) UpdateIfUnchanged(req *Request) *Response {
+	ctx, cancel := c.opContext(req)
+	defer cancel()
+
+	if err := c.acquire(ctx); err != nil {
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+	defer c.release()
+	start := time.Now()
+	defer func() { health.Observe(time.Since(start), len(c.sem)) }()
+
+	resp, err := c.cli.Txn(ctx).
+		If(clientv3.Compare(clientv3.Value(req.Url), "=", req.Content)).
+		Then(clientv3.OpPut(req.Url, req.NewContent)).
+		Commit()
+	if err != nil {
+		log.Error("When conditionally update db request:", err)
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+	if !resp.Succeeded {
+		return &Response{
+			Status: "Failure",
+			Error:  ErrRecordChanged,
+		}
+	}
+
+	return &Response{
+		Status:  "Success",
+		Message: []string{req.NewContent},
+	}
+}
+
 func (c *client) Delete(req *Request) *Response {
-	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
+	ctx, cancel := c.opContext(req)
 	defer cancel()
 
-	c.lock.Lock()
-	defer c.lock.Unlock()
+	if err := c.acquire(ctx); err != nil {
+		return &Response{
+			Status: "Failure",
+			Error:  err.Error(),
+		}
+	}
+	defer c.release()
+	start := time.Now()
+	defer func() { health.Observe(time.Since(start), len(c.sem)) }()
 
 	_, err := c.cli.Delete(ctx, req.Url)
 	if err != nil {
@@ -0,0 +1,106 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	log "github.com/golang/glog"
+	uuid "github.com/satori/go.uuid"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	"github.com/sodafoundation/api/pkg/utils/urls"
+)
+
+// Default tags are admin-managed records, one per tenant, stored outside
+// the tenant namespaces and keyed by the tenant they apply to, like quotas.
+
+// GetDefaultTags
+func (c *Client) GetDefaultTags(ctx *c.Context, tenantID string) (*model.DefaultTagsSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateDefaultTagsURL(urls.Etcd, "", tenantID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get default tags in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var tags = &model.DefaultTagsSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), tags); err != nil {
+		log.Error("when parsing default tags in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return tags, nil
+}
+
+// SetDefaultTags writes the default tags of a tenant, creating or replacing
+// the record.
+func (c *Client) SetDefaultTags(ctx *c.Context, tags *model.DefaultTagsSpec) (*model.DefaultTagsSpec, error) {
+	existing, err := c.GetDefaultTags(ctx, tags.TenantId)
+	if err == nil {
+		tags.BaseModel = existing.BaseModel
+		tags.UpdatedAt = utils.FormatTime(time.Now())
+	} else {
+		tags.BaseModel = &model.BaseModel{
+			Id:        uuid.NewV4().String(),
+			CreatedAt: utils.FormatTime(time.Now()),
+		}
+	}
+
+	tagsBody, marshalErr := json.Marshal(tags)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateDefaultTagsURL(urls.Etcd, "", tags.TenantId),
+		Content: string(tagsBody),
+	}
+	if err == nil {
+		dbReq.NewContent = string(tagsBody)
+		dbRes := c.Update(dbReq)
+		if dbRes.Status != "Success" {
+			log.Error("when update default tags in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		return tags, nil
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create default tags in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return tags, nil
+}
+
+// DeleteDefaultTags
+func (c *Client) DeleteDefaultTags(ctx *c.Context, tenantID string) error {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateDefaultTagsURL(urls.Etcd, "", tenantID),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete default tags in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
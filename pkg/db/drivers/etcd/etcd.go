@@ -34,6 +34,7 @@ import (
 
 	log "github.com/golang/glog"
 	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db/integrity"
 	"github.com/sodafoundation/api/pkg/model"
 	"github.com/sodafoundation/api/pkg/utils"
 	"github.com/sodafoundation/api/pkg/utils/constants"
@@ -49,7 +50,42 @@ const (
 	typeFile                = "file"
 )
 
-var validKey = []string{"limit", "offset", "sortDir", "sortKey"}
+var validKey = []string{"limit", "offset", "sortDir", "sortKey", "marker"}
+
+// recordId is the part of a record every spec shares, used to detect
+// duplicates without knowing the record type.
+type recordId struct {
+	Id string `json:"id"`
+}
+
+// filterListRecords drops the records of a list response that do not parse
+// as json or repeat an id already seen. A corrupt record is reported
+// through the integrity registry instead of failing the whole listing, so
+// one bad key can not take down a list call.
+func filterListRecords(dbRes *Response) []string {
+	var msgs []string
+	seen := map[string]bool{}
+	for i, msg := range dbRes.Message {
+		var rid recordId
+		if err := json.Unmarshal([]byte(msg), &rid); err != nil {
+			key := fmt.Sprintf("record %d", i)
+			if i < len(dbRes.Keys) {
+				key = dbRes.Keys[i]
+			}
+			log.Errorf("skipping corrupt db record %s: %v", key, err)
+			integrity.RecordCorruption(key, err)
+			continue
+		}
+		if rid.Id != "" {
+			if seen[rid.Id] {
+				continue
+			}
+			seen[rid.Id] = true
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
 
 const (
 	typeFileShares         string = "FileShares"
@@ -77,6 +113,13 @@ func IsAdminContext(ctx *c.Context) bool {
 	return ctx.IsAdmin
 }
 
+// IsAllTenantsContext returns true if the query may span all tenants. Only
+// an admin that explicitly asked for all tenants gets the unscoped view,
+// every other query is limited to the tenant of the context.
+func IsAllTenantsContext(ctx *c.Context) bool {
+	return ctx.IsAdmin && ctx.AllTenants
+}
+
 func AuthorizeProjectContext(ctx *c.Context, tenantId string) bool {
 	return ctx.TenantId == tenantId
 }
@@ -91,6 +134,26 @@ func NewClient(etcd *config.Database) *Client {
 // Client
 type Client struct {
 	clientInterface
+
+	// In-memory search indexes backing the ?q= parameter of the volume and
+	// fileshare list endpoints, maintained incrementally as records change.
+	volumeIndex    searchIndex
+	fileShareIndex searchIndex
+}
+
+// RebuildSearchIndexes rebuilds the in-memory search indexes from the
+// records in the store, repairing them when they drifted from the record
+// set. The number of records indexed is returned.
+func (c *Client) RebuildSearchIndexes(ctx *c.Context) (int, error) {
+	volumes, err := c.volumeIndex.rebuild(c.loadVolumeSearchText)
+	if err != nil {
+		return 0, err
+	}
+	fileshares, err := c.fileShareIndex.rebuild(c.loadFileShareSearchText)
+	if err != nil {
+		return volumes, err
+	}
+	return volumes + fileshares, nil
 }
 
 //Parameter
@@ -204,6 +267,12 @@ func (c *Client) FilterAndSort(src interface{}, params map[string][]string, sort
 	if len(params["sortKey"]) > 0 && utils.ContainsIgnoreCase(sortableKeys, params["sortKey"][0]) {
 		ret = utils.Sort(ret, params["sortKey"][0], c.GetSortDir(params))
 	}
+	// A marker replaces the offset: the page starts right after the named
+	// record no matter how the listing shifted since the previous page.
+	if len(params["marker"]) > 0 {
+		ret = utils.SliceAfterMarker(ret, params["marker"][0])
+		return utils.Slice(ret, 0, c.GetLimit(params))
+	}
 	ret = utils.Slice(ret, c.GetOffset(params, reflect.ValueOf(src).Len()), c.GetLimit(params))
 	return ret
 }
@@ -317,6 +386,7 @@ func (c *Client) CreateFileShareAcl(ctx *c.Context, fshare *model.FileShareAclSp
 	}
 
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateFileShareAclURL(urls.Etcd, ctx.TenantId, fshare.Id),
 		Content: string(fshareBody),
 	}
@@ -337,8 +407,14 @@ func (c *Client) UpdateFileShareAcl(ctx *c.Context, acl *model.FileShareAclSpec)
 	}
 
 	// Set update time
-	result.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	result.UpdatedAt = utils.FormatTime(time.Now())
 	result.Metadata = acl.Metadata
+	if acl.Fault != nil {
+		result.Fault = acl.Fault
+	}
+	if acl.ExportStatuses != nil {
+		result.ExportStatuses = acl.ExportStatuses
+	}
 
 	jsonBody, err := json.Marshal(result)
 	if err != nil {
@@ -351,6 +427,7 @@ func (c *Client) UpdateFileShareAcl(ctx *c.Context, acl *model.FileShareAclSpec)
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateFileShareAclURL(urls.Etcd, result.TenantId, acl.Id),
 		NewContent: string(jsonBody),
 	}
@@ -370,6 +447,7 @@ func (c *Client) CreateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*
 	}
 
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateFileShareURL(urls.Etcd, ctx.TenantId, fshare.Id),
 		Content: string(fshareBody),
 	}
@@ -378,6 +456,7 @@ func (c *Client) CreateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*
 		log.Error("when create fileshare in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	c.fileShareIndex.index(fshare.Id, searchText(fshare.Name, fshare.Description, fshare.Metadata)...)
 
 	return fshare, nil
 }
@@ -400,16 +479,42 @@ func (c *Client) ListFileSharesAclWithFilter(ctx *c.Context, m map[string][]stri
 		log.Error("list fileshare failed: ", err)
 		return nil, err
 	}
+
+	// Filter by the host the rule grants access to, e.g. ?accessTo=10.32.109.15
+	if vs, ok := m["accessTo"]; ok {
+		var filtered []*model.FileShareAclSpec
+		for _, acl := range fileshares {
+			if utils.Contains(vs, acl.AccessTo) {
+				filtered = append(filtered, acl)
+			}
+		}
+		fileshares = filtered
+	}
+	// Filter by granted capability, e.g. ?accessCapability=Write. An acl
+	// matches if it grants at least one of the requested capabilities.
+	if vs, ok := m["accessCapability"]; ok {
+		var filtered []*model.FileShareAclSpec
+		for _, acl := range fileshares {
+			for _, v := range vs {
+				if utils.ContainsIgnoreCase(acl.AccessCapability, v) {
+					filtered = append(filtered, acl)
+					break
+				}
+			}
+		}
+		fileshares = filtered
+	}
 	return fileshares, nil
 }
 
 func (c *Client) ListFileSharesAcl(ctx *c.Context) ([]*model.FileShareAclSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateFileShareAclURL(urls.Etcd, ctx.TenantId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareAclURL(urls.Etcd, ctx.TenantId),
 	}
 
-	// Admin user should get all fileshares including the fileshares whose tenant is not admin.
-	if IsAdminContext(ctx) {
+	// An admin that asked for all tenants gets every fileshares, not just its own.
+	if IsAllTenantsContext(ctx) {
 		dbReq.Url = urls.GenerateFileShareAclURL(urls.Etcd, "")
 	}
 
@@ -423,7 +528,7 @@ func (c *Client) ListFileSharesAcl(ctx *c.Context) ([]*model.FileShareAclSpec, e
 	if len(dbRes.Message) == 0 {
 		return fileshares, nil
 	}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var share = &model.FileShareAclSpec{}
 		if err := json.Unmarshal([]byte(msg), share); err != nil {
 			log.Error("when parsing fileshare in db:", dbRes.Error)
@@ -471,6 +576,23 @@ func (c *Client) ListFileSharesWithFilter(ctx *c.Context, m map[string][]string)
 		return nil, err
 	}
 
+	if q, ok := m["q"]; ok {
+		delete(m, "q")
+		if err := c.fileShareIndex.ensure(c.loadFileShareSearchText); err != nil {
+			log.Error("building fileshare search index failed: ", err)
+			return nil, err
+		}
+		if ids, restricted := c.fileShareIndex.search(q[0]); restricted {
+			var matched = []*model.FileShareSpec{}
+			for _, fshare := range fileshares {
+				if _, ok := ids[fshare.Id]; ok {
+					matched = append(matched, fshare)
+				}
+			}
+			fileshares = matched
+		}
+	}
+
 	tmpFileshares := c.FilterAndSort(fileshares, m, sortableKeysMap[typeFileShares])
 	var res = []*model.FileShareSpec{}
 	for _, data := range tmpFileshares.([]interface{}) {
@@ -479,14 +601,33 @@ func (c *Client) ListFileSharesWithFilter(ctx *c.Context, m map[string][]string)
 	return res, nil
 }
 
+// loadFileShareSearchText reads the searchable text of every fileshare in
+// the store, across all tenants, for the initial index build.
+func (c *Client) loadFileShareSearchText() (map[string][]string, error) {
+	dbRes := c.List(&Request{Url: urls.GenerateFileShareURL(urls.Etcd, "")})
+	if dbRes.Status != "Success" {
+		return nil, errors.New(dbRes.Error)
+	}
+	var records = map[string][]string{}
+	for _, msg := range filterListRecords(dbRes) {
+		var fshare = &model.FileShareSpec{}
+		if err := json.Unmarshal([]byte(msg), fshare); err != nil {
+			return nil, err
+		}
+		records[fshare.Id] = searchText(fshare.Name, fshare.Description, fshare.Metadata)
+	}
+	return records, nil
+}
+
 // ListFileShares
 func (c *Client) ListFileShares(ctx *c.Context) ([]*model.FileShareSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateFileShareURL(urls.Etcd, ctx.TenantId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareURL(urls.Etcd, ctx.TenantId),
 	}
 
-	// Admin user should get all fileshares including the fileshares whose tenant is not admin.
-	if IsAdminContext(ctx) {
+	// An admin that asked for all tenants gets every fileshares, not just its own.
+	if IsAllTenantsContext(ctx) {
 		dbReq.Url = urls.GenerateFileShareURL(urls.Etcd, "")
 	}
 
@@ -500,7 +641,7 @@ func (c *Client) ListFileShares(ctx *c.Context) ([]*model.FileShareSpec, error)
 	if len(dbRes.Message) == 0 {
 		return fileshares, nil
 	}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var share = &model.FileShareSpec{}
 		if err := json.Unmarshal([]byte(msg), share); err != nil {
 			log.Error("when parsing fileshare in db:", dbRes.Error)
@@ -546,7 +687,8 @@ func (c *Client) GetFileShareAcl(ctx *c.Context, aclID string) (*model.FileShare
 
 func (c *Client) getFileShareAcl(ctx *c.Context, aclID string) (*model.FileShareAclSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateFileShareAclURL(urls.Etcd, ctx.TenantId, aclID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareAclURL(urls.Etcd, ctx.TenantId, aclID),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
@@ -582,7 +724,8 @@ func (c *Client) GetFileShare(ctx *c.Context, fshareID string) (*model.FileShare
 
 func (c *Client) getFileShare(ctx *c.Context, fshareID string) (*model.FileShareSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateFileShareURL(urls.Etcd, ctx.TenantId, fshareID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareURL(urls.Etcd, ctx.TenantId, fshareID),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
@@ -622,12 +765,26 @@ func (c *Client) UpdateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*
 	if fshare.Status != "" {
 		result.Status = fshare.Status
 	}
+	if fshare.Fault != nil {
+		result.Fault = fshare.Fault
+	}
 	if fshare.PoolId != "" {
 		result.PoolId = fshare.PoolId
 	}
+	if fshare.ExpiresAt != "" {
+		result.ExpiresAt = fshare.ExpiresAt
+	}
+	if fshare.Lock != nil {
+		// An empty lock releases the lock, see the unlock endpoint.
+		if fshare.Lock.Owner == "" {
+			result.Lock = nil
+		} else {
+			result.Lock = fshare.Lock
+		}
+	}
 
 	// Set update time
-	result.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	result.UpdatedAt = utils.FormatTime(time.Now())
 
 	log.V(5).Infof("update file share object %+v into db", result)
 
@@ -642,6 +799,7 @@ func (c *Client) UpdateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateFileShareURL(urls.Etcd, result.TenantId, fshare.Id),
 		NewContent: string(body),
 	}
@@ -651,9 +809,37 @@ func (c *Client) UpdateFileShare(ctx *c.Context, fshare *model.FileShareSpec) (*
 		log.Error("when update fileshare in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	c.fileShareIndex.index(result.Id, searchText(result.Name, result.Description, result.Metadata)...)
 	return result, nil
 }
 
+// UpdateFileSharePublic
+func (c *Client) UpdateFileSharePublic(ctx *c.Context, fshareID string, isPublic bool) (*model.FileShareSpec, error) {
+	fshare, err := c.GetFileShare(ctx, fshareID)
+	if err != nil {
+		return nil, err
+	}
+	fshare.IsPublic = isPublic
+	fshare.UpdatedAt = utils.FormatTime(time.Now())
+
+	body, err := json.Marshal(fshare)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateFileShareURL(urls.Etcd, fshare.TenantId, fshareID),
+		NewContent: string(body),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update fileshare public flag in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return fshare, nil
+}
+
 // DeleteFileShareAcl
 func (c *Client) DeleteFileShareAcl(ctx *c.Context, aclID string) error {
 	// If an admin want to access other tenant's resource just fake other's tenantId.
@@ -667,7 +853,8 @@ func (c *Client) DeleteFileShareAcl(ctx *c.Context, aclID string) error {
 		tenantId = fshare.TenantId
 	}
 	dbReq := &Request{
-		Url: urls.GenerateFileShareAclURL(urls.Etcd, tenantId, aclID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareAclURL(urls.Etcd, tenantId, aclID),
 	}
 
 	dbRes := c.Delete(dbReq)
@@ -682,16 +869,23 @@ func (c *Client) DeleteFileShareAcl(ctx *c.Context, aclID string) error {
 func (c *Client) DeleteFileShare(ctx *c.Context, fileshareID string) error {
 	// If an admin want to access other tenant's resource just fake other's tenantId.
 	tenantId := ctx.TenantId
+	var fshare *model.FileShareSpec
 	if IsAdminContext(ctx) {
-		fshare, err := c.GetFileShare(ctx, fileshareID)
+		var err error
+		fshare, err = c.GetFileShare(ctx, fileshareID)
 		if err != nil {
 			log.Error(err)
 			return err
 		}
 		tenantId = fshare.TenantId
+	} else if tombstonesEnabled() {
+		// The tombstone needs the record before it is gone; a failed read
+		// only costs the tombstone, not the deletion.
+		fshare, _ = c.GetFileShare(ctx, fileshareID)
 	}
 	dbReq := &Request{
-		Url: urls.GenerateFileShareURL(urls.Etcd, tenantId, fileshareID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareURL(urls.Etcd, tenantId, fileshareID),
 	}
 
 	dbRes := c.Delete(dbReq)
@@ -699,6 +893,16 @@ func (c *Client) DeleteFileShare(ctx *c.Context, fileshareID string) error {
 		log.Error("when delete fileshare in db:", dbRes.Error)
 		return errors.New(dbRes.Error)
 	}
+	c.fileShareIndex.remove(fileshareID)
+	if tombstonesEnabled() && fshare != nil {
+		c.writeTombstone(ctx, &model.TombstoneSpec{
+			ResourceType: model.TombstoneResourceFileShare,
+			ResourceId:   fshare.Id,
+			Name:         fshare.Name,
+			TenantId:     fshare.TenantId,
+			Size:         fshare.Size,
+		})
+	}
 	return nil
 }
 
@@ -711,6 +915,7 @@ func (c *Client) CreateFileShareSnapshot(ctx *c.Context, snp *model.FileShareSna
 	}
 
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateFileShareSnapshotURL(urls.Etcd, ctx.TenantId, snp.Id),
 		Content: string(snpBody),
 	}
@@ -732,150 +937,1490 @@ func (c *Client) GetFileShareSnapshot(ctx *c.Context, snpID string) (*model.File
 	if err != nil {
 		return nil, err
 	}
-	for _, v := range snaps {
-		if v.Id == snpID {
-			return v, nil
-		}
+	for _, v := range snaps {
+		if v.Id == snpID {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("specified fileshare snapshot(%s) can't find", snpID)
+}
+
+// GetFileShareSnapshot
+func (c *Client) getFileShareSnapshot(ctx *c.Context, snpID string) (*model.FileShareSnapshotSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareSnapshotURL(urls.Etcd, ctx.TenantId, snpID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get fileshare attachment in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var fs = &model.FileShareSnapshotSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), fs); err != nil {
+		log.Error("when parsing fileshare snapshot in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return fs, nil
+}
+
+// ListFileShareSnapshots
+func (c *Client) ListFileShareSnapshots(ctx *c.Context) ([]*model.FileShareSnapshotSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareSnapshotURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateFileShareSnapshotURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list fileshare snapshots in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var fss = []*model.FileShareSnapshotSpec{}
+	if len(dbRes.Message) == 0 {
+		return fss, nil
+	}
+	for _, msg := range filterListRecords(dbRes) {
+		var fs = &model.FileShareSnapshotSpec{}
+		if err := json.Unmarshal([]byte(msg), fs); err != nil {
+			log.Error("When parsing fileshare snapshot in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		fss = append(fss, fs)
+	}
+	return fss, nil
+}
+
+func (c *Client) ListFileShareSnapshotsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.FileShareSnapshotSpec, error) {
+	fileshareSnapshots, err := c.ListFileShareSnapshots(ctx)
+	if err != nil {
+		log.Error("list fileshareSnapshots failed: ", err)
+		return nil, err
+	}
+
+	tmpFileshareSnapshots := c.FilterAndSort(fileshareSnapshots, m, sortableKeysMap[typeFileShareSnapshots])
+	var res = []*model.FileShareSnapshotSpec{}
+	for _, data := range tmpFileshareSnapshots.([]interface{}) {
+		res = append(res, data.(*model.FileShareSnapshotSpec))
+	}
+	return res, nil
+}
+
+// UpdateFileShareSnapshot
+func (c *Client) UpdateFileShareSnapshot(ctx *c.Context, snpID string, snp *model.FileShareSnapshotSpec) (*model.FileShareSnapshotSpec, error) {
+	result, err := c.GetFileShareSnapshot(ctx, snpID)
+	if err != nil {
+		return nil, err
+	}
+	if snp.Name != "" {
+		result.Name = snp.Name
+	}
+	if snp.Description != "" {
+		result.Description = snp.Description
+	}
+	if snp.Status != "" {
+		result.Status = snp.Status
+	}
+
+	if snp.SnapshotSize > 0 {
+		result.SnapshotSize = snp.SnapshotSize
+	}
+	if snp.ExpiresAt != "" {
+		result.ExpiresAt = snp.ExpiresAt
+	}
+	if snp.Fault != nil {
+		result.Fault = snp.Fault
+	}
+
+	// Set update time
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	result.Metadata = snp.Metadata
+
+	atcBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	if !IsAdminContext(ctx) && !AuthorizeProjectContext(ctx, result.TenantId) {
+		return nil, fmt.Errorf("opertaion is not permitted")
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateFileShareSnapshotURL(urls.Etcd, result.TenantId, snpID),
+		NewContent: string(atcBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update fileshare snapshot in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+// DeleteFileShareSnapshot
+func (c *Client) DeleteFileShareSnapshot(ctx *c.Context, snpID string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		snap, err := c.GetFileShareSnapshot(ctx, snpID)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = snap.TenantId
+	}
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareSnapshotURL(urls.Etcd, tenantId, snpID),
+	}
+
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete fileshare snapshot in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// CreateFileShareSnapshotCopy
+func (c *Client) CreateFileShareSnapshotCopy(ctx *c.Context, snpCopy *model.FileShareSnapshotCopySpec) (*model.FileShareSnapshotCopySpec, error) {
+	if snpCopy.Id == "" {
+		snpCopy.Id = uuid.NewV4().String()
+	}
+	if snpCopy.CreatedAt == "" {
+		snpCopy.CreatedAt = utils.FormatTime(time.Now())
+	}
+	snpCopy.TenantId = ctx.TenantId
+
+	copyBody, err := json.Marshal(snpCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateFileShareSnapshotCopyURL(urls.Etcd, ctx.TenantId, snpCopy.Id),
+		Content: string(copyBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create fileshare snapshot copy in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return snpCopy, nil
+}
+
+func (c *Client) GetFileShareSnapshotCopy(ctx *c.Context, copyID string) (*model.FileShareSnapshotCopySpec, error) {
+	snpCopy, err := c.getFileShareSnapshotCopy(ctx, copyID)
+	if !IsAdminContext(ctx) || err == nil {
+		return snpCopy, err
+	}
+	copies, err := c.ListFileShareSnapshotCopies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range copies {
+		if v.Id == copyID {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("specified fileshare snapshot copy(%s) can't find", copyID)
+}
+
+// getFileShareSnapshotCopy
+func (c *Client) getFileShareSnapshotCopy(ctx *c.Context, copyID string) (*model.FileShareSnapshotCopySpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareSnapshotCopyURL(urls.Etcd, ctx.TenantId, copyID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get fileshare snapshot copy in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var fsc = &model.FileShareSnapshotCopySpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), fsc); err != nil {
+		log.Error("when parsing fileshare snapshot copy in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return fsc, nil
+}
+
+// ListFileShareSnapshotCopies
+func (c *Client) ListFileShareSnapshotCopies(ctx *c.Context) ([]*model.FileShareSnapshotCopySpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareSnapshotCopyURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateFileShareSnapshotCopyURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list fileshare snapshot copies in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var copies = []*model.FileShareSnapshotCopySpec{}
+	if len(dbRes.Message) == 0 {
+		return copies, nil
+	}
+	for _, msg := range filterListRecords(dbRes) {
+		var fsc = &model.FileShareSnapshotCopySpec{}
+		if err := json.Unmarshal([]byte(msg), fsc); err != nil {
+			log.Error("when parsing fileshare snapshot copy in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		copies = append(copies, fsc)
+	}
+	return copies, nil
+}
+
+func (c *Client) UpdateFileShareSnapshotCopy(ctx *c.Context, copyID string, snpCopy *model.FileShareSnapshotCopySpec) (*model.FileShareSnapshotCopySpec, error) {
+	result, err := c.GetFileShareSnapshotCopy(ctx, copyID)
+	if err != nil {
+		return nil, err
+	}
+	if snpCopy.Description != "" {
+		result.Description = snpCopy.Description
+	}
+	if snpCopy.Status != "" {
+		result.Status = snpCopy.Status
+	}
+	if snpCopy.Fault != nil {
+		result.Fault = snpCopy.Fault
+	}
+
+	// Set update time
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	copyBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	if !IsAdminContext(ctx) && !AuthorizeProjectContext(ctx, result.TenantId) {
+		return nil, fmt.Errorf("opertaion is not permitted")
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateFileShareSnapshotCopyURL(urls.Etcd, result.TenantId, copyID),
+		NewContent: string(copyBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update fileshare snapshot copy in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+// CreateBackupTarget
+func (c *Client) CreateBackupTarget(ctx *c.Context, target *model.BackupTargetSpec) (*model.BackupTargetSpec, error) {
+	if target.Id == "" {
+		target.Id = uuid.NewV4().String()
+	}
+	if target.CreatedAt == "" {
+		target.CreatedAt = utils.FormatTime(time.Now())
+	}
+	target.TenantId = ctx.TenantId
+
+	targetBody, err := json.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateBackupTargetURL(urls.Etcd, ctx.TenantId, target.Id),
+		Content: string(targetBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create backup target in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return target, nil
+}
+
+func (c *Client) GetBackupTarget(ctx *c.Context, targetID string) (*model.BackupTargetSpec, error) {
+	target, err := c.getBackupTarget(ctx, targetID)
+	if !IsAdminContext(ctx) || err == nil {
+		return target, err
+	}
+	targets, err := c.ListBackupTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range targets {
+		if v.Id == targetID {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("specified backup target(%s) can't find", targetID)
+}
+
+// getBackupTarget
+func (c *Client) getBackupTarget(ctx *c.Context, targetID string) (*model.BackupTargetSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateBackupTargetURL(urls.Etcd, ctx.TenantId, targetID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get backup target in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var bt = &model.BackupTargetSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), bt); err != nil {
+		log.Error("when parsing backup target in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return bt, nil
+}
+
+// ListBackupTargets
+func (c *Client) ListBackupTargets(ctx *c.Context) ([]*model.BackupTargetSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateBackupTargetURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateBackupTargetURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list backup targets in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var targets = []*model.BackupTargetSpec{}
+	if len(dbRes.Message) == 0 {
+		return targets, nil
+	}
+	for _, msg := range filterListRecords(dbRes) {
+		var bt = &model.BackupTargetSpec{}
+		if err := json.Unmarshal([]byte(msg), bt); err != nil {
+			log.Error("when parsing backup target in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		targets = append(targets, bt)
+	}
+	return targets, nil
+}
+
+func (c *Client) UpdateBackupTarget(ctx *c.Context, targetID string, target *model.BackupTargetSpec) (*model.BackupTargetSpec, error) {
+	result, err := c.GetBackupTarget(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Name != "" {
+		result.Name = target.Name
+	}
+	if target.Description != "" {
+		result.Description = target.Description
+	}
+	if target.Endpoint != "" {
+		result.Endpoint = target.Endpoint
+	}
+	if target.Region != "" {
+		result.Region = target.Region
+	}
+	if target.Bucket != "" {
+		result.Bucket = target.Bucket
+	}
+	if target.AccessKey != "" {
+		result.AccessKey = target.AccessKey
+	}
+	if target.SecretKey != "" {
+		result.SecretKey = target.SecretKey
+	}
+
+	// Set update time
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	targetBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	if !IsAdminContext(ctx) && !AuthorizeProjectContext(ctx, result.TenantId) {
+		return nil, fmt.Errorf("opertaion is not permitted")
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateBackupTargetURL(urls.Etcd, result.TenantId, targetID),
+		NewContent: string(targetBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update backup target in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+func (c *Client) DeleteBackupTarget(ctx *c.Context, targetID string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		target, err := c.GetBackupTarget(ctx, targetID)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = target.TenantId
+	}
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateBackupTargetURL(urls.Etcd, tenantId, targetID),
+	}
+
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete backup target in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// CreateFileShareSnapshotBackup
+func (c *Client) CreateFileShareSnapshotBackup(ctx *c.Context, backup *model.FileShareSnapshotBackupSpec) (*model.FileShareSnapshotBackupSpec, error) {
+	if backup.Id == "" {
+		backup.Id = uuid.NewV4().String()
+	}
+	if backup.CreatedAt == "" {
+		backup.CreatedAt = utils.FormatTime(time.Now())
+	}
+	backup.TenantId = ctx.TenantId
+
+	backupBody, err := json.Marshal(backup)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateFileShareSnapshotBackupURL(urls.Etcd, ctx.TenantId, backup.Id),
+		Content: string(backupBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create fileshare snapshot backup in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return backup, nil
+}
+
+func (c *Client) GetFileShareSnapshotBackup(ctx *c.Context, backupID string) (*model.FileShareSnapshotBackupSpec, error) {
+	backup, err := c.getFileShareSnapshotBackup(ctx, backupID)
+	if !IsAdminContext(ctx) || err == nil {
+		return backup, err
+	}
+	backups, err := c.ListFileShareSnapshotBackups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range backups {
+		if v.Id == backupID {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("specified fileshare snapshot backup(%s) can't find", backupID)
+}
+
+// getFileShareSnapshotBackup
+func (c *Client) getFileShareSnapshotBackup(ctx *c.Context, backupID string) (*model.FileShareSnapshotBackupSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareSnapshotBackupURL(urls.Etcd, ctx.TenantId, backupID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get fileshare snapshot backup in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var fsb = &model.FileShareSnapshotBackupSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), fsb); err != nil {
+		log.Error("when parsing fileshare snapshot backup in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return fsb, nil
+}
+
+// ListFileShareSnapshotBackups
+func (c *Client) ListFileShareSnapshotBackups(ctx *c.Context) ([]*model.FileShareSnapshotBackupSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareSnapshotBackupURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateFileShareSnapshotBackupURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list fileshare snapshot backups in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var backups = []*model.FileShareSnapshotBackupSpec{}
+	if len(dbRes.Message) == 0 {
+		return backups, nil
+	}
+	for _, msg := range filterListRecords(dbRes) {
+		var fsb = &model.FileShareSnapshotBackupSpec{}
+		if err := json.Unmarshal([]byte(msg), fsb); err != nil {
+			log.Error("when parsing fileshare snapshot backup in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		backups = append(backups, fsb)
+	}
+	return backups, nil
+}
+
+func (c *Client) UpdateFileShareSnapshotBackup(ctx *c.Context, backupID string, backup *model.FileShareSnapshotBackupSpec) (*model.FileShareSnapshotBackupSpec, error) {
+	result, err := c.GetFileShareSnapshotBackup(ctx, backupID)
+	if err != nil {
+		return nil, err
+	}
+	if backup.Description != "" {
+		result.Description = backup.Description
+	}
+	if backup.Status != "" {
+		result.Status = backup.Status
+	}
+	if backup.Location != "" {
+		result.Location = backup.Location
+	}
+	if backup.Size != 0 {
+		result.Size = backup.Size
+	}
+	if backup.Fault != nil {
+		result.Fault = backup.Fault
+	}
+
+	// Set update time
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	backupBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	if !IsAdminContext(ctx) && !AuthorizeProjectContext(ctx, result.TenantId) {
+		return nil, fmt.Errorf("opertaion is not permitted")
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateFileShareSnapshotBackupURL(urls.Etcd, result.TenantId, backupID),
+		NewContent: string(backupBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update fileshare snapshot backup in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+func (c *Client) DeleteFileShareSnapshotBackup(ctx *c.Context, backupID string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		backup, err := c.GetFileShareSnapshotBackup(ctx, backupID)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = backup.TenantId
+	}
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateFileShareSnapshotBackupURL(urls.Etcd, tenantId, backupID),
+	}
+
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete fileshare snapshot backup in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// ********************** End Of FileShare *********************
+
+// CreateAnnotation appends an operator note to a resource. The timestamp of
+// the note is its CreatedAt field.
+func (c *Client) CreateAnnotation(ctx *c.Context, anno *model.AnnotationSpec) (*model.AnnotationSpec, error) {
+	if anno.Id == "" {
+		anno.Id = uuid.NewV4().String()
+	}
+	if anno.CreatedAt == "" {
+		anno.CreatedAt = utils.FormatTime(time.Now())
+	}
+	anno.TenantId = ctx.TenantId
+
+	annoBody, err := json.Marshal(anno)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateAnnotationURL(urls.Etcd, ctx.TenantId, anno.Id),
+		Content: string(annoBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create annotation in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return anno, nil
+}
+
+// ListAnnotations lists the notes appended to the given resource, oldest
+// first in the order they were created.
+func (c *Client) ListAnnotations(ctx *c.Context, resourceType, resourceID string) ([]*model.AnnotationSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateAnnotationURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateAnnotationURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list annotations in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var annos = []*model.AnnotationSpec{}
+	for _, msg := range filterListRecords(dbRes) {
+		var anno = &model.AnnotationSpec{}
+		if err := json.Unmarshal([]byte(msg), anno); err != nil {
+			log.Error("when parsing annotation in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		if anno.ResourceType == resourceType && anno.ResourceId == resourceID {
+			annos = append(annos, anno)
+		}
+	}
+	sort.Slice(annos, func(i, j int) bool {
+		return annos[i].CreatedAt < annos[j].CreatedAt
+	})
+	return annos, nil
+}
+
+// AcquirePendingOperation stores the marker of a mutating operation that is
+// about to run against a resource. The marker is written with an atomic
+// create-if-absent transaction, so of two concurrent acquisitions exactly
+// one wins; the loser is told which operation is already running and the
+// existing marker stays untouched.
+func (c *Client) AcquirePendingOperation(ctx *c.Context, op *model.PendingOperationSpec) (*model.PendingOperationSpec, error) {
+	url := urls.GeneratePendingOperationURL(urls.Etcd, ctx.TenantId, op.ResourceType, op.ResourceId)
+
+	if op.Id == "" {
+		op.Id = uuid.NewV4().String()
+	}
+	if op.CreatedAt == "" {
+		op.CreatedAt = utils.FormatTime(time.Now())
+	}
+	if op.Token == "" {
+		op.Token = uuid.NewV4().String()
+	}
+	op.TenantId = ctx.TenantId
+
+	opBody, err := json.Marshal(op)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRes := c.CreateIfNotExists(&Request{Parent: ctx.Std(), Url: url, Content: string(opBody)})
+	if dbRes.Status != "Success" {
+		if dbRes.Error == ErrRecordExists {
+			var running = &model.PendingOperationSpec{}
+			if len(dbRes.Message) > 0 {
+				if err := json.Unmarshal([]byte(dbRes.Message[0]), running); err != nil {
+					log.Error("when parsing pending operation in db:", err)
+				}
+			}
+			return nil, fmt.Errorf("operation %s is already running on %s %s",
+				running.Operation, op.ResourceType, op.ResourceId)
+		}
+		log.Error("when create pending operation in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return op, nil
+}
+
+// ReleasePendingOperation removes the marker of a finished operation. The
+// token fences the release, a caller holding a stale token can not release
+// a marker acquired by somebody else. Releasing a resource without marker
+// is a no-op.
+func (c *Client) ReleasePendingOperation(ctx *c.Context, resourceType, resourceID, token string) error {
+	url := urls.GeneratePendingOperationURL(urls.Etcd, ctx.TenantId, resourceType, resourceID)
+
+	dbRes := c.Get(&Request{Parent: ctx.Std(), Url: url})
+	if dbRes.Status != "Success" || len(dbRes.Message) == 0 {
+		return nil
+	}
+	var running = &model.PendingOperationSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), running); err != nil {
+		log.Error("when parsing pending operation in db:", err)
+		return err
+	}
+	if running.Token != token {
+		return fmt.Errorf("pending operation on %s %s is held by another caller",
+			resourceType, resourceID)
+	}
+
+	dbRes = c.Delete(&Request{Parent: ctx.Std(), Url: url})
+	if dbRes.Status != "Success" {
+		log.Error("when delete pending operation in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// CreateApiKey stores a new api key. Only the hash of the key secret ends
+// up in the database, the secret itself is never kept at rest.
+func (c *Client) CreateApiKey(ctx *c.Context, key *model.ApiKeySpec) (*model.ApiKeySpec, error) {
+	if key.Id == "" {
+		key.Id = uuid.NewV4().String()
+	}
+	if key.CreatedAt == "" {
+		key.CreatedAt = utils.FormatTime(time.Now())
+	}
+	key.TenantId = ctx.TenantId
+	key.Secret = ""
+
+	keyBody, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateApiKeyURL(urls.Etcd, ctx.TenantId, key.Id),
+		Content: string(keyBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create api key in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return key, nil
+}
+
+// GetApiKey
+func (c *Client) GetApiKey(ctx *c.Context, keyID string) (*model.ApiKeySpec, error) {
+	key, err := c.getApiKey(ctx, keyID)
+	if !IsAdminContext(ctx) || err == nil {
+		return key, err
+	}
+	keys, err := c.ListApiKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if k.Id == keyID {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("specified api key(%s) can't find", keyID)
+}
+
+func (c *Client) getApiKey(ctx *c.Context, keyID string) (*model.ApiKeySpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateApiKeyURL(urls.Etcd, ctx.TenantId, keyID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get api key in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var key = &model.ApiKeySpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), key); err != nil {
+		log.Error("when parsing api key in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return key, nil
+}
+
+// ListApiKeys
+func (c *Client) ListApiKeys(ctx *c.Context) ([]*model.ApiKeySpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateApiKeyURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateApiKeyURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list api keys in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var keys = []*model.ApiKeySpec{}
+	for _, msg := range filterListRecords(dbRes) {
+		var key = &model.ApiKeySpec{}
+		if err := json.Unmarshal([]byte(msg), key); err != nil {
+			log.Error("when parsing api key in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// UpdateApiKey
+func (c *Client) UpdateApiKey(ctx *c.Context, key *model.ApiKeySpec) (*model.ApiKeySpec, error) {
+	result, err := c.GetApiKey(ctx, key.Id)
+	if err != nil {
+		return nil, err
+	}
+	if key.Name != "" {
+		result.Name = key.Name
+	}
+	if key.Description != "" {
+		result.Description = key.Description
+	}
+	if key.HashedSecret != "" {
+		result.HashedSecret = key.HashedSecret
+	}
+
+	// Set update time
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	keyBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	if !IsAdminContext(ctx) && !AuthorizeProjectContext(ctx, result.TenantId) {
+		return nil, fmt.Errorf("opertaion is not permitted")
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateApiKeyURL(urls.Etcd, result.TenantId, key.Id),
+		NewContent: string(keyBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update api key in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+// DeleteApiKey
+func (c *Client) DeleteApiKey(ctx *c.Context, keyID string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		key, err := c.GetApiKey(ctx, keyID)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = key.TenantId
+	}
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateApiKeyURL(urls.Etcd, tenantId, keyID),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete api key in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// CreateScopedToken stores a new scoped token. Only the hash of the token
+// secret ends up in the database, the secret itself is never kept at rest.
+func (c *Client) CreateScopedToken(ctx *c.Context, token *model.ScopedTokenSpec) (*model.ScopedTokenSpec, error) {
+	if token.Id == "" {
+		token.Id = uuid.NewV4().String()
+	}
+	if token.CreatedAt == "" {
+		token.CreatedAt = utils.FormatTime(time.Now())
+	}
+	token.TenantId = ctx.TenantId
+	token.Secret = ""
+
+	tokenBody, err := json.Marshal(token)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateScopedTokenURL(urls.Etcd, ctx.TenantId, token.Id),
+		Content: string(tokenBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create scoped token in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return token, nil
+}
+
+// GetScopedToken
+func (c *Client) GetScopedToken(ctx *c.Context, tokenID string) (*model.ScopedTokenSpec, error) {
+	token, err := c.getScopedToken(ctx, tokenID)
+	if !IsAdminContext(ctx) || err == nil {
+		return token, err
+	}
+	tokens, err := c.ListScopedTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if t.Id == tokenID {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("specified scoped token(%s) can't find", tokenID)
+}
+
+func (c *Client) getScopedToken(ctx *c.Context, tokenID string) (*model.ScopedTokenSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateScopedTokenURL(urls.Etcd, ctx.TenantId, tokenID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get scoped token in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var token = &model.ScopedTokenSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), token); err != nil {
+		log.Error("when parsing scoped token in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return token, nil
+}
+
+// ListScopedTokens
+func (c *Client) ListScopedTokens(ctx *c.Context) ([]*model.ScopedTokenSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateScopedTokenURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateScopedTokenURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list scoped tokens in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var tokens = []*model.ScopedTokenSpec{}
+	for _, msg := range filterListRecords(dbRes) {
+		var token = &model.ScopedTokenSpec{}
+		if err := json.Unmarshal([]byte(msg), token); err != nil {
+			log.Error("when parsing scoped token in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// DeleteScopedToken
+func (c *Client) DeleteScopedToken(ctx *c.Context, tokenID string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		token, err := c.GetScopedToken(ctx, tokenID)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = token.TenantId
+	}
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateScopedTokenURL(urls.Etcd, tenantId, tokenID),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete scoped token in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// CreateNetworkPolicy
+func (c *Client) CreateNetworkPolicy(ctx *c.Context, policy *model.NetworkPolicySpec) (*model.NetworkPolicySpec, error) {
+	if policy.Id == "" {
+		policy.Id = uuid.NewV4().String()
+	}
+	if policy.CreatedAt == "" {
+		policy.CreatedAt = utils.FormatTime(time.Now())
+	}
+	policy.TenantId = ctx.TenantId
+
+	policyBody, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateNetworkPolicyURL(urls.Etcd, ctx.TenantId, policy.Id),
+		Content: string(policyBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create network policy in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return policy, nil
+}
+
+func (c *Client) GetNetworkPolicy(ctx *c.Context, policyID string) (*model.NetworkPolicySpec, error) {
+	policy, err := c.getNetworkPolicy(ctx, policyID)
+	if !IsAdminContext(ctx) || err == nil {
+		return policy, err
+	}
+	policies, err := c.ListNetworkPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range policies {
+		if v.Id == policyID {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("specified network policy(%s) can't find", policyID)
+}
+
+// getNetworkPolicy
+func (c *Client) getNetworkPolicy(ctx *c.Context, policyID string) (*model.NetworkPolicySpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateNetworkPolicyURL(urls.Etcd, ctx.TenantId, policyID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get network policy in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var np = &model.NetworkPolicySpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), np); err != nil {
+		log.Error("when parsing network policy in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return np, nil
+}
+
+// ListNetworkPolicies
+func (c *Client) ListNetworkPolicies(ctx *c.Context) ([]*model.NetworkPolicySpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateNetworkPolicyURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateNetworkPolicyURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list network policies in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var policies = []*model.NetworkPolicySpec{}
+	if len(dbRes.Message) == 0 {
+		return policies, nil
+	}
+	for _, msg := range filterListRecords(dbRes) {
+		var np = &model.NetworkPolicySpec{}
+		if err := json.Unmarshal([]byte(msg), np); err != nil {
+			log.Error("when parsing network policy in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		policies = append(policies, np)
+	}
+	return policies, nil
+}
+
+func (c *Client) UpdateNetworkPolicy(ctx *c.Context, policyID string, policy *model.NetworkPolicySpec) (*model.NetworkPolicySpec, error) {
+	result, err := c.GetNetworkPolicy(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+	if policy.Description != "" {
+		result.Description = policy.Description
+	}
+	if policy.AllowedCidrs != nil {
+		result.AllowedCidrs = policy.AllowedCidrs
+	}
+	if policy.DeniedCidrs != nil {
+		result.DeniedCidrs = policy.DeniedCidrs
+	}
+
+	// Set update time
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	policyBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	if !IsAdminContext(ctx) && !AuthorizeProjectContext(ctx, result.TenantId) {
+		return nil, fmt.Errorf("opertaion is not permitted")
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateNetworkPolicyURL(urls.Etcd, result.TenantId, policyID),
+		NewContent: string(policyBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update network policy in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+func (c *Client) DeleteNetworkPolicy(ctx *c.Context, policyID string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		policy, err := c.GetNetworkPolicy(ctx, policyID)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = policy.TenantId
+	}
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateNetworkPolicyURL(urls.Etcd, tenantId, policyID),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete network policy in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// CreateResourceClass. Resource classes are admin-managed global records,
+// they are stored outside the tenant namespaces.
+func (c *Client) CreateResourceClass(ctx *c.Context, class *model.ResourceClassSpec) (*model.ResourceClassSpec, error) {
+	if class.Id == "" {
+		class.Id = uuid.NewV4().String()
+	}
+	if class.CreatedAt == "" {
+		class.CreatedAt = utils.FormatTime(time.Now())
+	}
+
+	classBody, err := json.Marshal(class)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateResourceClassURL(urls.Etcd, "", class.Id),
+		Content: string(classBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create resource class in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return class, nil
+}
+
+// GetResourceClass
+func (c *Client) GetResourceClass(ctx *c.Context, classID string) (*model.ResourceClassSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateResourceClassURL(urls.Etcd, "", classID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get resource class in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var class = &model.ResourceClassSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), class); err != nil {
+		log.Error("when parsing resource class in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return class, nil
+}
+
+// ListResourceClasses
+func (c *Client) ListResourceClasses(ctx *c.Context) ([]*model.ResourceClassSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateResourceClassURL(urls.Etcd, ""),
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list resource classes in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var classes = []*model.ResourceClassSpec{}
+	if len(dbRes.Message) == 0 {
+		return classes, nil
+	}
+	for _, msg := range filterListRecords(dbRes) {
+		var class = &model.ResourceClassSpec{}
+		if err := json.Unmarshal([]byte(msg), class); err != nil {
+			log.Error("when parsing resource class in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		classes = append(classes, class)
+	}
+	return classes, nil
+}
+
+// UpdateResourceClass
+func (c *Client) UpdateResourceClass(ctx *c.Context, classID string, class *model.ResourceClassSpec) (*model.ResourceClassSpec, error) {
+	result, err := c.GetResourceClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	if class.Name != "" {
+		result.Name = class.Name
+	}
+	if class.Description != "" {
+		result.Description = class.Description
+	}
+	if class.Tenants != nil {
+		result.Tenants = class.Tenants
+	}
+	if class.RequiredTags != nil {
+		result.RequiredTags = class.RequiredTags
+	}
+	if class.NamingPattern != "" {
+		result.NamingPattern = class.NamingPattern
+	}
+	if class.MinSize != 0 {
+		result.MinSize = class.MinSize
+	}
+	if class.MaxSize != 0 {
+		result.MaxSize = class.MaxSize
+	}
+	if class.AllowedAvailabilityZones != nil {
+		result.AllowedAvailabilityZones = class.AllowedAvailabilityZones
+	}
+
+	// Set update time
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	classBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateResourceClassURL(urls.Etcd, "", classID),
+		NewContent: string(classBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update resource class in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+// DeleteResourceClass
+func (c *Client) DeleteResourceClass(ctx *c.Context, classID string) error {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateResourceClassURL(urls.Etcd, "", classID),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete resource class in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
+
+// CreateRegion. Regions are admin-managed global records, they are stored
+// outside the tenant namespaces.
+func (c *Client) CreateRegion(ctx *c.Context, region *model.RegionSpec) (*model.RegionSpec, error) {
+	if region.Id == "" {
+		region.Id = uuid.NewV4().String()
+	}
+	if region.CreatedAt == "" {
+		region.CreatedAt = utils.FormatTime(time.Now())
+	}
+	if region.Status == "" {
+		region.Status = model.RegionStatusUnknown
+	}
+
+	regionBody, err := json.Marshal(region)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateRegionURL(urls.Etcd, "", region.Id),
+		Content: string(regionBody),
 	}
-	return nil, fmt.Errorf("specified fileshare snapshot(%s) can't find", snpID)
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create region in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return region, nil
 }
 
-// GetFileShareSnapshot
-func (c *Client) getFileShareSnapshot(ctx *c.Context, snpID string) (*model.FileShareSnapshotSpec, error) {
+// GetRegion
+func (c *Client) GetRegion(ctx *c.Context, regionID string) (*model.RegionSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateFileShareSnapshotURL(urls.Etcd, ctx.TenantId, snpID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateRegionURL(urls.Etcd, "", regionID),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
-		log.Error("when get fileshare attachment in db:", dbRes.Error)
+		log.Error("when get region in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
 
-	var fs = &model.FileShareSnapshotSpec{}
-	if err := json.Unmarshal([]byte(dbRes.Message[0]), fs); err != nil {
-		log.Error("when parsing fileshare snapshot in db:", dbRes.Error)
+	var region = &model.RegionSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), region); err != nil {
+		log.Error("when parsing region in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
-	return fs, nil
+	return region, nil
 }
 
-// ListFileShareSnapshots
-func (c *Client) ListFileShareSnapshots(ctx *c.Context) ([]*model.FileShareSnapshotSpec, error) {
+// ListRegions
+func (c *Client) ListRegions(ctx *c.Context) ([]*model.RegionSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateFileShareSnapshotURL(urls.Etcd, ctx.TenantId),
-	}
-	if IsAdminContext(ctx) {
-		dbReq.Url = urls.GenerateFileShareSnapshotURL(urls.Etcd, "")
+		Parent: ctx.Std(),
+		Url:    urls.GenerateRegionURL(urls.Etcd, ""),
 	}
 	dbRes := c.List(dbReq)
 	if dbRes.Status != "Success" {
-		log.Error("when list fileshare snapshots in db:", dbRes.Error)
+		log.Error("when list regions in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
 
-	var fss = []*model.FileShareSnapshotSpec{}
+	var regions = []*model.RegionSpec{}
 	if len(dbRes.Message) == 0 {
-		return fss, nil
+		return regions, nil
 	}
-	for _, msg := range dbRes.Message {
-		var fs = &model.FileShareSnapshotSpec{}
-		if err := json.Unmarshal([]byte(msg), fs); err != nil {
-			log.Error("When parsing fileshare snapshot in db:", dbRes.Error)
+	for _, msg := range filterListRecords(dbRes) {
+		var region = &model.RegionSpec{}
+		if err := json.Unmarshal([]byte(msg), region); err != nil {
+			log.Error("when parsing region in db:", dbRes.Error)
 			return nil, errors.New(dbRes.Error)
 		}
-		fss = append(fss, fs)
+		regions = append(regions, region)
 	}
-	return fss, nil
+	return regions, nil
 }
 
-func (c *Client) ListFileShareSnapshotsWithFilter(ctx *c.Context, m map[string][]string) ([]*model.FileShareSnapshotSpec, error) {
-	fileshareSnapshots, err := c.ListFileShareSnapshots(ctx)
+// UpdateRegion
+func (c *Client) UpdateRegion(ctx *c.Context, regionID string, region *model.RegionSpec) (*model.RegionSpec, error) {
+	result, err := c.GetRegion(ctx, regionID)
 	if err != nil {
-		log.Error("list fileshareSnapshots failed: ", err)
 		return nil, err
 	}
-
-	tmpFileshareSnapshots := c.FilterAndSort(fileshareSnapshots, m, sortableKeysMap[typeFileShareSnapshots])
-	var res = []*model.FileShareSnapshotSpec{}
-	for _, data := range tmpFileshareSnapshots.([]interface{}) {
-		res = append(res, data.(*model.FileShareSnapshotSpec))
-	}
-	return res, nil
-}
-
-// UpdateFileShareSnapshot
-func (c *Client) UpdateFileShareSnapshot(ctx *c.Context, snpID string, snp *model.FileShareSnapshotSpec) (*model.FileShareSnapshotSpec, error) {
-	result, err := c.GetFileShareSnapshot(ctx, snpID)
-	if err != nil {
-		return nil, err
+	if region.Name != "" {
+		result.Name = region.Name
 	}
-	if snp.Name != "" {
-		result.Name = snp.Name
+	if region.Description != "" {
+		result.Description = region.Description
 	}
-	if snp.Description != "" {
-		result.Description = snp.Description
+	if region.Url != "" {
+		result.Url = region.Url
 	}
-	if snp.Status != "" {
-		result.Status = snp.Status
+	if region.Capabilities != nil {
+		result.Capabilities = region.Capabilities
 	}
-
-	if snp.SnapshotSize > 0 {
-		result.SnapshotSize = snp.SnapshotSize
+	if region.Status != "" {
+		result.Status = region.Status
 	}
 
 	// Set update time
-	result.UpdatedAt = time.Now().Format(constants.TimeFormat)
-
-	result.Metadata = snp.Metadata
+	result.UpdatedAt = utils.FormatTime(time.Now())
 
-	atcBody, err := json.Marshal(result)
+	regionBody, err := json.Marshal(result)
 	if err != nil {
 		return nil, err
 	}
 
-	// If an admin want to access other tenant's resource just fake other's tenantId.
-	if !IsAdminContext(ctx) && !AuthorizeProjectContext(ctx, result.TenantId) {
-		return nil, fmt.Errorf("opertaion is not permitted")
-	}
-
 	dbReq := &Request{
-		Url:        urls.GenerateFileShareSnapshotURL(urls.Etcd, result.TenantId, snpID),
-		NewContent: string(atcBody),
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateRegionURL(urls.Etcd, "", regionID),
+		NewContent: string(regionBody),
 	}
 	dbRes := c.Update(dbReq)
 	if dbRes.Status != "Success" {
-		log.Error("when update fileshare snapshot in db:", dbRes.Error)
+		log.Error("when update region in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
 	return result, nil
 }
 
-// DeleteFileShareSnapshot
-func (c *Client) DeleteFileShareSnapshot(ctx *c.Context, snpID string) error {
-	// If an admin want to access other tenant's resource just fake other's tenantId.
-	tenantId := ctx.TenantId
-	if IsAdminContext(ctx) {
-		snap, err := c.GetFileShareSnapshot(ctx, snpID)
-		if err != nil {
-			log.Error(err)
-			return err
-		}
-		tenantId = snap.TenantId
-	}
+// DeleteRegion
+func (c *Client) DeleteRegion(ctx *c.Context, regionID string) error {
 	dbReq := &Request{
-		Url: urls.GenerateFileShareSnapshotURL(urls.Etcd, tenantId, snpID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateRegionURL(urls.Etcd, "", regionID),
 	}
-
 	dbRes := c.Delete(dbReq)
 	if dbRes.Status != "Success" {
-		log.Error("when delete fileshare snapshot in db:", dbRes.Error)
+		log.Error("when delete region in db:", dbRes.Error)
 		return errors.New(dbRes.Error)
 	}
 	return nil
 }
 
-// ********************** End Of FileShare *********************
-
 // CreateDock
 func (c *Client) CreateDock(ctx *c.Context, dck *model.DockSpec) (*model.DockSpec, error) {
 	if dck.Id == "" {
@@ -883,7 +2428,7 @@ func (c *Client) CreateDock(ctx *c.Context, dck *model.DockSpec) (*model.DockSpe
 	}
 
 	if dck.CreatedAt == "" {
-		dck.CreatedAt = time.Now().Format(constants.TimeFormat)
+		dck.CreatedAt = utils.FormatTime(time.Now())
 	}
 
 	dckBody, err := json.Marshal(dck)
@@ -892,6 +2437,7 @@ func (c *Client) CreateDock(ctx *c.Context, dck *model.DockSpec) (*model.DockSpe
 	}
 
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateDockURL(urls.Etcd, "", dck.Id),
 		Content: string(dckBody),
 	}
@@ -907,7 +2453,8 @@ func (c *Client) CreateDock(ctx *c.Context, dck *model.DockSpec) (*model.DockSpe
 // GetDock
 func (c *Client) GetDock(ctx *c.Context, dckID string) (*model.DockSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateDockURL(urls.Etcd, "", dckID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateDockURL(urls.Etcd, "", dckID),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
@@ -947,7 +2494,8 @@ func (c *Client) GetDockByPoolId(ctx *c.Context, poolId string) (*model.DockSpec
 // ListDocks
 func (c *Client) ListDocks(ctx *c.Context) ([]*model.DockSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateDockURL(urls.Etcd, ""),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateDockURL(urls.Etcd, ""),
 	}
 	dbRes := c.List(dbReq)
 	if dbRes.Status != "Success" {
@@ -959,7 +2507,7 @@ func (c *Client) ListDocks(ctx *c.Context) ([]*model.DockSpec, error) {
 	if len(dbRes.Message) == 0 {
 		return dcks, nil
 	}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var dck = &model.DockSpec{}
 		if err := json.Unmarshal([]byte(msg), dck); err != nil {
 			log.Error("When parsing dock in db:", dbRes.Error)
@@ -997,7 +2545,7 @@ func (c *Client) UpdateDock(ctx *c.Context, dckID, name, desp string) (*model.Do
 	if desp != "" {
 		dck.Description = desp
 	}
-	dck.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	dck.UpdatedAt = utils.FormatTime(time.Now())
 
 	dckBody, err := json.Marshal(dck)
 	if err != nil {
@@ -1005,6 +2553,7 @@ func (c *Client) UpdateDock(ctx *c.Context, dckID, name, desp string) (*model.Do
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateDockURL(urls.Etcd, "", dckID),
 		NewContent: string(dckBody),
 	}
@@ -1019,7 +2568,8 @@ func (c *Client) UpdateDock(ctx *c.Context, dckID, name, desp string) (*model.Do
 // DeleteDock
 func (c *Client) DeleteDock(ctx *c.Context, dckID string) error {
 	dbReq := &Request{
-		Url: urls.GenerateDockURL(urls.Etcd, "", dckID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateDockURL(urls.Etcd, "", dckID),
 	}
 	dbRes := c.Delete(dbReq)
 	if dbRes.Status != "Success" {
@@ -1036,7 +2586,7 @@ func (c *Client) CreatePool(ctx *c.Context, pol *model.StoragePoolSpec) (*model.
 	}
 
 	if pol.CreatedAt == "" {
-		pol.CreatedAt = time.Now().Format(constants.TimeFormat)
+		pol.CreatedAt = utils.FormatTime(time.Now())
 	}
 	polBody, err := json.Marshal(pol)
 	if err != nil {
@@ -1044,6 +2594,7 @@ func (c *Client) CreatePool(ctx *c.Context, pol *model.StoragePoolSpec) (*model.
 	}
 
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GeneratePoolURL(urls.Etcd, "", pol.Id),
 		Content: string(polBody),
 	}
@@ -1074,7 +2625,8 @@ func (c *Client) ListPoolsWithFilter(ctx *c.Context, m map[string][]string) ([]*
 // GetPool
 func (c *Client) GetPool(ctx *c.Context, polID string) (*model.StoragePoolSpec, error) {
 	dbReq := &Request{
-		Url: urls.GeneratePoolURL(urls.Etcd, "", polID),
+		Parent: ctx.Std(),
+		Url:    urls.GeneratePoolURL(urls.Etcd, "", polID),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
@@ -1093,7 +2645,8 @@ func (c *Client) GetPool(ctx *c.Context, polID string) (*model.StoragePoolSpec,
 //ListAvailabilityZones
 func (c *Client) ListAvailabilityZones(ctx *c.Context) ([]string, error) {
 	dbReq := &Request{
-		Url: urls.GeneratePoolURL(urls.Etcd, ""),
+		Parent: ctx.Std(),
+		Url:    urls.GeneratePoolURL(urls.Etcd, ""),
 	}
 	dbRes := c.List(dbReq)
 	if dbRes.Status != "Success" {
@@ -1104,7 +2657,7 @@ func (c *Client) ListAvailabilityZones(ctx *c.Context) ([]string, error) {
 	if len(dbRes.Message) == 0 {
 		return azs, nil
 	}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var pol = &model.StoragePoolSpec{}
 		if err := json.Unmarshal([]byte(msg), pol); err != nil {
 			log.Error("When parsing pool in db:", dbRes.Error)
@@ -1120,7 +2673,8 @@ func (c *Client) ListAvailabilityZones(ctx *c.Context) ([]string, error) {
 // ListPools
 func (c *Client) ListPools(ctx *c.Context) ([]*model.StoragePoolSpec, error) {
 	dbReq := &Request{
-		Url: urls.GeneratePoolURL(urls.Etcd, ""),
+		Parent: ctx.Std(),
+		Url:    urls.GeneratePoolURL(urls.Etcd, ""),
 	}
 	dbRes := c.List(dbReq)
 	if dbRes.Status != "Success" {
@@ -1132,7 +2686,7 @@ func (c *Client) ListPools(ctx *c.Context) ([]*model.StoragePoolSpec, error) {
 	if len(dbRes.Message) == 0 {
 		return pols, nil
 	}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var pol = &model.StoragePoolSpec{}
 		if err := json.Unmarshal([]byte(msg), pol); err != nil {
 			log.Error("When parsing pool in db:", dbRes.Error)
@@ -1155,7 +2709,7 @@ func (c *Client) UpdatePool(ctx *c.Context, polID, name, desp string, usedCapaci
 	if desp != "" {
 		pol.Description = desp
 	}
-	pol.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	pol.UpdatedAt = utils.FormatTime(time.Now())
 
 	polBody, err := json.Marshal(pol)
 	if err != nil {
@@ -1163,6 +2717,7 @@ func (c *Client) UpdatePool(ctx *c.Context, polID, name, desp string, usedCapaci
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GeneratePoolURL(urls.Etcd, "", polID),
 		NewContent: string(polBody),
 	}
@@ -1174,10 +2729,66 @@ func (c *Client) UpdatePool(ctx *c.Context, polID, name, desp string, usedCapaci
 	return pol, nil
 }
 
+// UpdatePoolCapacitySettings
+func (c *Client) UpdatePoolCapacitySettings(ctx *c.Context, polID string, reservedCapacity int64, maxOverSubscriptionRatio float64) (*model.StoragePoolSpec, error) {
+	pol, err := c.GetPool(ctx, polID)
+	if err != nil {
+		return nil, err
+	}
+	pol.ReservedCapacity = reservedCapacity
+	pol.MaxOverSubscriptionRatio = maxOverSubscriptionRatio
+	pol.UpdatedAt = utils.FormatTime(time.Now())
+
+	polBody, err := json.Marshal(pol)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GeneratePoolURL(urls.Etcd, "", polID),
+		NewContent: string(polBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When update pool capacity settings in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return pol, nil
+}
+
+// UpdatePoolMaintenance
+func (c *Client) UpdatePoolMaintenance(ctx *c.Context, polID string, maintenance bool) (*model.StoragePoolSpec, error) {
+	pol, err := c.GetPool(ctx, polID)
+	if err != nil {
+		return nil, err
+	}
+	pol.Maintenance = maintenance
+	pol.UpdatedAt = utils.FormatTime(time.Now())
+
+	polBody, err := json.Marshal(pol)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GeneratePoolURL(urls.Etcd, "", polID),
+		NewContent: string(polBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When update pool maintenance in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return pol, nil
+}
+
 // DeletePool
 func (c *Client) DeletePool(ctx *c.Context, polID string) error {
 	dbReq := &Request{
-		Url: urls.GeneratePoolURL(urls.Etcd, "", polID),
+		Parent: ctx.Std(),
+		Url:    urls.GeneratePoolURL(urls.Etcd, "", polID),
 	}
 	dbRes := c.Delete(dbReq)
 	if dbRes.Status != "Success" {
@@ -1193,7 +2804,7 @@ func (c *Client) CreateProfile(ctx *c.Context, prf *model.ProfileSpec) (*model.P
 		prf.Id = uuid.NewV4().String()
 	}
 	if prf.CreatedAt == "" {
-		prf.CreatedAt = time.Now().Format(constants.TimeFormat)
+		prf.CreatedAt = utils.FormatTime(time.Now())
 	}
 
 	// profile name must be unique.
@@ -1207,6 +2818,7 @@ func (c *Client) CreateProfile(ctx *c.Context, prf *model.ProfileSpec) (*model.P
 	}
 
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateProfileURL(urls.Etcd, "", prf.Id),
 		Content: string(prfBody),
 	}
@@ -1222,7 +2834,8 @@ func (c *Client) CreateProfile(ctx *c.Context, prf *model.ProfileSpec) (*model.P
 // GetProfile
 func (c *Client) GetProfile(ctx *c.Context, prfID string) (*model.ProfileSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateProfileURL(urls.Etcd, "", prfID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateProfileURL(urls.Etcd, "", prfID),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
@@ -1283,7 +2896,8 @@ func (c *Client) GetDefaultProfileFileShare(ctx *c.Context) (*model.ProfileSpec,
 // ListProfiles
 func (c *Client) ListProfiles(ctx *c.Context) ([]*model.ProfileSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateProfileURL(urls.Etcd, ""),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateProfileURL(urls.Etcd, ""),
 	}
 
 	dbRes := c.List(dbReq)
@@ -1296,7 +2910,7 @@ func (c *Client) ListProfiles(ctx *c.Context) ([]*model.ProfileSpec, error) {
 	if len(dbRes.Message) == 0 {
 		return prfs, nil
 	}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var prf = &model.ProfileSpec{}
 		if err := json.Unmarshal([]byte(msg), prf); err != nil {
 			log.Error("When parsing profile in db:", dbRes.Error)
@@ -1334,7 +2948,22 @@ func (c *Client) UpdateProfile(ctx *c.Context, prfID string, input *model.Profil
 	if desp := input.Description; desp != "" {
 		prf.Description = desp
 	}
-	prf.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	if visibility := input.Visibility; visibility != "" {
+		prf.Visibility = visibility
+	}
+	if tenants := input.AllowedTenants; tenants != nil {
+		prf.AllowedTenants = tenants
+	}
+	if zones := input.AvailabilityZoneFallbacks; zones != nil {
+		prf.AvailabilityZoneFallbacks = zones
+	}
+	if cost := input.CostPerGbMonth; cost != 0 {
+		prf.CostPerGbMonth = cost
+	}
+	if base := input.BaseProfileId; base != "" {
+		prf.BaseProfileId = base
+	}
+	prf.UpdatedAt = utils.FormatTime(time.Now())
 
 	if props := input.CustomProperties; len(props) != 0 {
 		if prf.CustomProperties == nil {
@@ -1345,7 +2974,7 @@ func (c *Client) UpdateProfile(ctx *c.Context, prfID string, input *model.Profil
 		}
 	}
 
-	prf.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	prf.UpdatedAt = utils.FormatTime(time.Now())
 
 	prfBody, err := json.Marshal(prf)
 	if err != nil {
@@ -1353,6 +2982,7 @@ func (c *Client) UpdateProfile(ctx *c.Context, prfID string, input *model.Profil
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateProfileURL(urls.Etcd, "", prfID),
 		NewContent: string(prfBody),
 	}
@@ -1367,7 +2997,8 @@ func (c *Client) UpdateProfile(ctx *c.Context, prfID string, input *model.Profil
 // DeleteProfile
 func (c *Client) DeleteProfile(ctx *c.Context, prfID string) error {
 	dbReq := &Request{
-		Url: urls.GenerateProfileURL(urls.Etcd, "", prfID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateProfileURL(urls.Etcd, "", prfID),
 	}
 	dbRes := c.Delete(dbReq)
 	if dbRes.Status != "Success" {
@@ -1392,7 +3023,7 @@ func (c *Client) AddCustomProperty(ctx *c.Context, prfID string, ext model.Custo
 		prf.CustomProperties[k] = v
 	}
 
-	prf.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	prf.UpdatedAt = utils.FormatTime(time.Now())
 
 	if _, err = c.CreateProfile(ctx, prf); err != nil {
 		return nil, err
@@ -1439,6 +3070,7 @@ func (c *Client) CreateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 	}
 
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateVolumeURL(urls.Etcd, ctx.TenantId, vol.Id),
 		Content: string(volBody),
 	}
@@ -1447,6 +3079,7 @@ func (c *Client) CreateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 		log.Error("When create volume in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	c.volumeIndex.index(vol.Id, searchText(vol.Name, vol.Description, vol.Metadata)...)
 
 	return vol, nil
 }
@@ -1471,7 +3104,8 @@ func (c *Client) GetVolume(ctx *c.Context, volID string) (*model.VolumeSpec, err
 
 func (c *Client) getVolume(ctx *c.Context, volID string) (*model.VolumeSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateVolumeURL(urls.Etcd, ctx.TenantId, volID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateVolumeURL(urls.Etcd, ctx.TenantId, volID),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
@@ -1490,11 +3124,12 @@ func (c *Client) getVolume(ctx *c.Context, volID string) (*model.VolumeSpec, err
 // ListVolumes
 func (c *Client) ListVolumes(ctx *c.Context) ([]*model.VolumeSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateVolumeURL(urls.Etcd, ctx.TenantId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateVolumeURL(urls.Etcd, ctx.TenantId),
 	}
 
-	// Admin user should get all volumes including the volumes whose tenant is not admin.
-	if IsAdminContext(ctx) {
+	// An admin that asked for all tenants gets every volumes, not just its own.
+	if IsAllTenantsContext(ctx) {
 		dbReq.Url = urls.GenerateVolumeURL(urls.Etcd, "")
 	}
 
@@ -1508,7 +3143,7 @@ func (c *Client) ListVolumes(ctx *c.Context) ([]*model.VolumeSpec, error) {
 	if len(dbRes.Message) == 0 {
 		return vols, nil
 	}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var vol = &model.VolumeSpec{}
 		if err := json.Unmarshal([]byte(msg), vol); err != nil {
 			log.Error("When parsing volume in db:", dbRes.Error)
@@ -1519,6 +3154,24 @@ func (c *Client) ListVolumes(ctx *c.Context) ([]*model.VolumeSpec, error) {
 	return vols, nil
 }
 
+// loadVolumeSearchText reads the searchable text of every volume in the
+// store, across all tenants, for the initial index build.
+func (c *Client) loadVolumeSearchText() (map[string][]string, error) {
+	dbRes := c.List(&Request{Url: urls.GenerateVolumeURL(urls.Etcd, "")})
+	if dbRes.Status != "Success" {
+		return nil, errors.New(dbRes.Error)
+	}
+	var records = map[string][]string{}
+	for _, msg := range filterListRecords(dbRes) {
+		var vol = &model.VolumeSpec{}
+		if err := json.Unmarshal([]byte(msg), vol); err != nil {
+			return nil, err
+		}
+		records[vol.Id] = searchText(vol.Name, vol.Description, vol.Metadata)
+	}
+	return records, nil
+}
+
 // ListVolumesByProfileId
 func (c *Client) ListVolumesByProfileId(ctx *c.Context, prfID string) ([]string, error) {
 	vols, err := c.ListVolumes(ctx)
@@ -1640,6 +3293,23 @@ func (c *Client) ListVolumesWithFilter(ctx *c.Context, m map[string][]string) ([
 		return vols, nil
 	}
 
+	if q, ok := m["q"]; ok {
+		delete(m, "q")
+		if err := c.volumeIndex.ensure(c.loadVolumeSearchText); err != nil {
+			log.Error("building volume search index failed: ", err)
+			return nil, err
+		}
+		if ids, restricted := c.volumeIndex.search(q[0]); restricted {
+			var matched = []*model.VolumeSpec{}
+			for _, vol := range volumes {
+				if _, ok := ids[vol.Id]; ok {
+					matched = append(matched, vol)
+				}
+			}
+			volumes = matched
+		}
+	}
+
 	tmpVolumes := c.FilterAndSort(volumes, m, sortableKeysMap[typeVolumes])
 	var res = []*model.VolumeSpec{}
 	for _, data := range tmpVolumes.([]interface{}) {
@@ -1690,9 +3360,12 @@ func (c *Client) UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 	if vol.GroupId != "" {
 		result.GroupId = vol.GroupId
 	}
+	if vol.Fault != nil {
+		result.Fault = vol.Fault
+	}
 
 	// Set update time
-	result.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	result.UpdatedAt = utils.FormatTime(time.Now())
 
 	body, err := json.Marshal(result)
 	if err != nil {
@@ -1705,6 +3378,7 @@ func (c *Client) UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateVolumeURL(urls.Etcd, result.TenantId, vol.Id),
 		NewContent: string(body),
 	}
@@ -1714,6 +3388,7 @@ func (c *Client) UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 		log.Error("When update volume in db:", dbRes.Error)
 		return nil, errors.New(dbRes.Error)
 	}
+	c.volumeIndex.index(result.Id, searchText(result.Name, result.Description, result.Metadata)...)
 	return result, nil
 }
 
@@ -1721,16 +3396,23 @@ func (c *Client) UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 func (c *Client) DeleteVolume(ctx *c.Context, volID string) error {
 	// If an admin want to access other tenant's resource just fake other's tenantId.
 	tenantId := ctx.TenantId
+	var vol *model.VolumeSpec
 	if IsAdminContext(ctx) {
-		vol, err := c.GetVolume(ctx, volID)
+		var err error
+		vol, err = c.GetVolume(ctx, volID)
 		if err != nil {
 			log.Error(err)
 			return err
 		}
 		tenantId = vol.TenantId
+	} else if tombstonesEnabled() {
+		// The tombstone needs the record before it is gone; a failed read
+		// only costs the tombstone, not the deletion.
+		vol, _ = c.GetVolume(ctx, volID)
 	}
 	dbReq := &Request{
-		Url: urls.GenerateVolumeURL(urls.Etcd, tenantId, volID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateVolumeURL(urls.Etcd, tenantId, volID),
 	}
 
 	dbRes := c.Delete(dbReq)
@@ -1738,6 +3420,16 @@ func (c *Client) DeleteVolume(ctx *c.Context, volID string) error {
 		log.Error("When delete volume in db:", dbRes.Error)
 		return errors.New(dbRes.Error)
 	}
+	c.volumeIndex.remove(volID)
+	if tombstonesEnabled() && vol != nil {
+		c.writeTombstone(ctx, &model.TombstoneSpec{
+			ResourceType: model.TombstoneResourceVolume,
+			ResourceId:   vol.Id,
+			Name:         vol.Name,
+			TenantId:     vol.TenantId,
+			Size:         vol.Size,
+		})
+	}
 	return nil
 }
 
@@ -1752,8 +3444,11 @@ func (c *Client) ExtendVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 		result.Size = vol.Size
 	}
 	result.Status = vol.Status
+	if vol.Metadata != nil {
+		result.Metadata = vol.Metadata
+	}
 	// Set update time
-	result.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	result.UpdatedAt = utils.FormatTime(time.Now())
 
 	body, err := json.Marshal(result)
 	if err != nil {
@@ -1761,6 +3456,7 @@ func (c *Client) ExtendVolume(ctx *c.Context, vol *model.VolumeSpec) (*model.Vol
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateVolumeURL(urls.Etcd, ctx.TenantId, vol.Id),
 		NewContent: string(body),
 	}
@@ -1778,7 +3474,7 @@ func (c *Client) CreateVolumeAttachment(ctx *c.Context, attachment *model.Volume
 	if attachment.Id == "" {
 		attachment.Id = uuid.NewV4().String()
 	}
-	attachment.CreatedAt = time.Now().Format(constants.TimeFormat)
+	attachment.CreatedAt = utils.FormatTime(time.Now())
 	attachment.TenantId = ctx.TenantId
 
 	atcBody, err := json.Marshal(attachment)
@@ -1786,6 +3482,7 @@ func (c *Client) CreateVolumeAttachment(ctx *c.Context, attachment *model.Volume
 		return nil, err
 	}
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateAttachmentURL(urls.Etcd, ctx.TenantId, attachment.Id),
 		Content: string(atcBody),
 	}
@@ -1818,7 +3515,8 @@ func (c *Client) GetVolumeAttachment(ctx *c.Context, attachmentId string) (*mode
 // GetVolumeAttachment
 func (c *Client) getVolumeAttachment(ctx *c.Context, attachmentId string) (*model.VolumeAttachmentSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateAttachmentURL(urls.Etcd, ctx.TenantId, attachmentId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateAttachmentURL(urls.Etcd, ctx.TenantId, attachmentId),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
@@ -1837,9 +3535,10 @@ func (c *Client) getVolumeAttachment(ctx *c.Context, attachmentId string) (*mode
 // ListVolumeAttachments
 func (c *Client) ListVolumeAttachments(ctx *c.Context, volumeId string) ([]*model.VolumeAttachmentSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateAttachmentURL(urls.Etcd, ctx.TenantId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateAttachmentURL(urls.Etcd, ctx.TenantId),
 	}
-	if IsAdminContext(ctx) {
+	if IsAllTenantsContext(ctx) {
 		dbReq.Url = urls.GenerateAttachmentURL(urls.Etcd, "")
 	}
 	dbRes := c.List(dbReq)
@@ -1849,7 +3548,7 @@ func (c *Client) ListVolumeAttachments(ctx *c.Context, volumeId string) ([]*mode
 	}
 
 	var atcs = []*model.VolumeAttachmentSpec{}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var atc = &model.VolumeAttachmentSpec{}
 		if err := json.Unmarshal([]byte(msg), atc); err != nil {
 			log.Error("When parsing volume attachment in db:", dbRes.Error)
@@ -1895,6 +3594,12 @@ func (c *Client) UpdateVolumeAttachment(ctx *c.Context, attachmentId string, att
 	if len(attachment.Status) > 0 {
 		result.Status = attachment.Status
 	}
+	if len(attachment.FsType) > 0 {
+		result.FsType = attachment.FsType
+	}
+	if attachment.MountOptions != nil {
+		result.MountOptions = attachment.MountOptions
+	}
 
 	// Update DriverVolumeType
 	if len(attachment.DriverVolumeType) > 0 {
@@ -1914,8 +3619,11 @@ func (c *Client) UpdateVolumeAttachment(ctx *c.Context, attachmentId string, att
 			result.ConnectionData[k] = v
 		}
 	}
+	if attachment.Fault != nil {
+		result.Fault = attachment.Fault
+	}
 	// Set update time
-	result.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	result.UpdatedAt = utils.FormatTime(time.Now())
 
 	atcBody, err := json.Marshal(result)
 	if err != nil {
@@ -1928,6 +3636,7 @@ func (c *Client) UpdateVolumeAttachment(ctx *c.Context, attachmentId string, att
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateAttachmentURL(urls.Etcd, result.TenantId, attachmentId),
 		NewContent: string(atcBody),
 	}
@@ -1953,7 +3662,8 @@ func (c *Client) DeleteVolumeAttachment(ctx *c.Context, attachmentId string) err
 		tenantId = attach.TenantId
 	}
 	dbReq := &Request{
-		Url: urls.GenerateAttachmentURL(urls.Etcd, tenantId, attachmentId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateAttachmentURL(urls.Etcd, tenantId, attachmentId),
 	}
 
 	dbRes := c.Delete(dbReq)
@@ -1973,6 +3683,7 @@ func (c *Client) CreateVolumeSnapshot(ctx *c.Context, snp *model.VolumeSnapshotS
 	}
 
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateSnapshotURL(urls.Etcd, ctx.TenantId, snp.Id),
 		Content: string(snpBody),
 	}
@@ -2005,7 +3716,8 @@ func (c *Client) GetVolumeSnapshot(ctx *c.Context, snpID string) (*model.VolumeS
 // GetVolumeSnapshot
 func (c *Client) getVolumeSnapshot(ctx *c.Context, snpID string) (*model.VolumeSnapshotSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateSnapshotURL(urls.Etcd, ctx.TenantId, snpID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateSnapshotURL(urls.Etcd, ctx.TenantId, snpID),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
@@ -2024,9 +3736,10 @@ func (c *Client) getVolumeSnapshot(ctx *c.Context, snpID string) (*model.VolumeS
 // ListVolumeSnapshots
 func (c *Client) ListVolumeSnapshots(ctx *c.Context) ([]*model.VolumeSnapshotSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateSnapshotURL(urls.Etcd, ctx.TenantId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateSnapshotURL(urls.Etcd, ctx.TenantId),
 	}
-	if IsAdminContext(ctx) {
+	if IsAllTenantsContext(ctx) {
 		dbReq.Url = urls.GenerateSnapshotURL(urls.Etcd, "")
 	}
 	dbRes := c.List(dbReq)
@@ -2039,7 +3752,7 @@ func (c *Client) ListVolumeSnapshots(ctx *c.Context) ([]*model.VolumeSnapshotSpe
 	if len(dbRes.Message) == 0 {
 		return vss, nil
 	}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var vs = &model.VolumeSnapshotSpec{}
 		if err := json.Unmarshal([]byte(msg), vs); err != nil {
 			log.Error("When parsing volume snapshot in db:", dbRes.Error)
@@ -2089,8 +3802,11 @@ func (c *Client) UpdateVolumeSnapshot(ctx *c.Context, snpID string, snp *model.V
 	if snp.Status != "" {
 		result.Status = snp.Status
 	}
+	if snp.Fault != nil {
+		result.Fault = snp.Fault
+	}
 	// Set update time
-	result.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	result.UpdatedAt = utils.FormatTime(time.Now())
 
 	atcBody, err := json.Marshal(result)
 	if err != nil {
@@ -2103,6 +3819,7 @@ func (c *Client) UpdateVolumeSnapshot(ctx *c.Context, snpID string, snp *model.V
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateSnapshotURL(urls.Etcd, result.TenantId, snpID),
 		NewContent: string(atcBody),
 	}
@@ -2128,7 +3845,8 @@ func (c *Client) DeleteVolumeSnapshot(ctx *c.Context, snpID string) error {
 		tenantId = snap.TenantId
 	}
 	dbReq := &Request{
-		Url: urls.GenerateSnapshotURL(urls.Etcd, tenantId, snpID),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateSnapshotURL(urls.Etcd, tenantId, snpID),
 	}
 
 	dbRes := c.Delete(dbReq)
@@ -2145,13 +3863,14 @@ func (c *Client) CreateReplication(ctx *c.Context, r *model.ReplicationSpec) (*m
 	}
 
 	r.TenantId = ctx.TenantId
-	r.CreatedAt = time.Now().Format(constants.TimeFormat)
+	r.CreatedAt = utils.FormatTime(time.Now())
 	rBody, err := json.Marshal(r)
 	if err != nil {
 		return nil, err
 	}
 
 	req := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateReplicationURL(urls.Etcd, ctx.TenantId, r.Id),
 		Content: string(rBody),
 	}
@@ -2197,7 +3916,8 @@ func (c *Client) GetReplicationByVolumeId(ctx *c.Context, volumeId string) (*mod
 
 func (c *Client) getReplication(ctx *c.Context, replicationId string) (*model.ReplicationSpec, error) {
 	req := &Request{
-		Url: urls.GenerateReplicationURL(urls.Etcd, ctx.TenantId, replicationId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateReplicationURL(urls.Etcd, ctx.TenantId, replicationId),
 	}
 	resp := c.Get(req)
 	if resp.Status != "Success" {
@@ -2215,9 +3935,10 @@ func (c *Client) getReplication(ctx *c.Context, replicationId string) (*model.Re
 
 func (c *Client) ListReplication(ctx *c.Context) ([]*model.ReplicationSpec, error) {
 	req := &Request{
-		Url: urls.GenerateReplicationURL(urls.Etcd, ctx.TenantId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateReplicationURL(urls.Etcd, ctx.TenantId),
 	}
-	if IsAdminContext(ctx) {
+	if IsAllTenantsContext(ctx) {
 		req.Url = urls.GenerateReplicationURL(urls.Etcd, "")
 	}
 	resp := c.List(req)
@@ -2364,7 +4085,8 @@ func (c *Client) DeleteReplication(ctx *c.Context, replicationId string) error {
 		tenantId = r.TenantId
 	}
 	req := &Request{
-		Url: urls.GenerateReplicationURL(urls.Etcd, tenantId, replicationId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateReplicationURL(urls.Etcd, tenantId, replicationId),
 	}
 	reps := c.Delete(req)
 	if reps.Status != "Success" {
@@ -2400,8 +4122,11 @@ func (c *Client) UpdateReplication(ctx *c.Context, replicationId string, input *
 	if input.ReplicationStatus != "" {
 		r.ReplicationStatus = input.ReplicationStatus
 	}
+	if input.Fault != nil {
+		r.Fault = input.Fault
+	}
 
-	r.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	r.UpdatedAt = utils.FormatTime(time.Now())
 
 	b, err := json.Marshal(r)
 	if err != nil {
@@ -2412,6 +4137,7 @@ func (c *Client) UpdateReplication(ctx *c.Context, replicationId string, input *
 		tenantId = r.TenantId
 	}
 	req := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateReplicationURL(urls.Etcd, tenantId, replicationId),
 		NewContent: string(b),
 	}
@@ -2430,6 +4156,7 @@ func (c *Client) CreateVolumeGroup(ctx *c.Context, vg *model.VolumeGroupSpec) (*
 	}
 
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateVolumeGroupURL(urls.Etcd, ctx.TenantId, vg.Id),
 		Content: string(vgBody),
 	}
@@ -2444,7 +4171,8 @@ func (c *Client) CreateVolumeGroup(ctx *c.Context, vg *model.VolumeGroupSpec) (*
 
 func (c *Client) GetVolumeGroup(ctx *c.Context, vgId string) (*model.VolumeGroupSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateVolumeGroupURL(urls.Etcd, ctx.TenantId, vgId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateVolumeGroupURL(urls.Etcd, ctx.TenantId, vgId),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
@@ -2480,6 +4208,9 @@ func (c *Client) UpdateVolumeGroup(ctx *c.Context, vgUpdate *model.VolumeGroupSp
 	if vg.Status != "" && vgUpdate.Status != vg.Status {
 		vg.Status = vgUpdate.Status
 	}
+	if vgUpdate.Fault != nil {
+		vg.Fault = vgUpdate.Fault
+	}
 	if vgUpdate.PoolId != "" && vgUpdate.PoolId != vg.PoolId {
 		vg.PoolId = vgUpdate.PoolId
 	}
@@ -2496,6 +4227,7 @@ func (c *Client) UpdateVolumeGroup(ctx *c.Context, vgUpdate *model.VolumeGroupSp
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateVolumeGroupURL(urls.Etcd, ctx.TenantId, vgUpdate.Id),
 		NewContent: string(vgBody),
 	}
@@ -2549,6 +4281,14 @@ func (c *Client) UpdateStatus(ctx *c.Context, in interface{}, status string) err
 			return errUpdate
 		}
 
+	case *model.FileShareSnapshotCopySpec:
+		snpCopy := in.(*model.FileShareSnapshotCopySpec)
+		snpCopy.Status = status
+		if _, errUpdate := c.UpdateFileShareSnapshotCopy(ctx, snpCopy.Id, snpCopy); errUpdate != nil {
+			log.Error("when update fileshare snapshot copy status in db:", errUpdate.Error())
+			return errUpdate
+		}
+
 	case *model.FileShareSnapshotSpec:
 		fsnap := in.(*model.FileShareSnapshotSpec)
 		fsnap.Status = status
@@ -2612,9 +4352,10 @@ func (c *Client) VolumesToUpdate(ctx *c.Context, volumeList []*model.VolumeSpec)
 // ListVolumes
 func (c *Client) ListVolumeGroups(ctx *c.Context) ([]*model.VolumeGroupSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateVolumeGroupURL(urls.Etcd, ctx.TenantId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateVolumeGroupURL(urls.Etcd, ctx.TenantId),
 	}
-	if IsAdminContext(ctx) {
+	if IsAllTenantsContext(ctx) {
 		dbReq.Url = urls.GenerateVolumeGroupURL(urls.Etcd, "")
 	}
 
@@ -2628,7 +4369,7 @@ func (c *Client) ListVolumeGroups(ctx *c.Context) ([]*model.VolumeGroupSpec, err
 	if len(dbRes.Message) == 0 {
 		return groups, nil
 	}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var group = &model.VolumeGroupSpec{}
 		if err := json.Unmarshal([]byte(msg), group); err != nil {
 			log.Error("When parsing volume group in db:", dbRes.Error)
@@ -2651,7 +4392,8 @@ func (c *Client) DeleteVolumeGroup(ctx *c.Context, volumeGroupId string) error {
 		tenantId = group.TenantId
 	}
 	dbReq := &Request{
-		Url: urls.GenerateVolumeGroupURL(urls.Etcd, tenantId, volumeGroupId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateVolumeGroupURL(urls.Etcd, tenantId, volumeGroupId),
 	}
 
 	dbRes := c.Delete(dbReq)
@@ -2763,10 +4505,11 @@ func (c *Client) SelectVolumeGroup(param map[string][]string, vgs []*model.Volum
 
 func (c *Client) ListHosts(ctx *c.Context, m map[string][]string) ([]*model.HostSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateHostURL(urls.Etcd, ctx.TenantId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateHostURL(urls.Etcd, ctx.TenantId),
 	}
 
-	if IsAdminContext(ctx) {
+	if IsAllTenantsContext(ctx) {
 		dbReq.Url = urls.GenerateHostURL(urls.Etcd, "")
 	}
 
@@ -2780,7 +4523,7 @@ func (c *Client) ListHosts(ctx *c.Context, m map[string][]string) ([]*model.Host
 	if len(dbRes.Message) == 0 {
 		return hosts, nil
 	}
-	for _, msg := range dbRes.Message {
+	for _, msg := range filterListRecords(dbRes) {
 		var host = &model.HostSpec{}
 		if err := json.Unmarshal([]byte(msg), host); err != nil {
 			log.Error("When parsing host in db:", dbRes.Error)
@@ -2825,13 +4568,14 @@ func (c *Client) CreateHost(ctx *c.Context, host *model.HostSpec) (*model.HostSp
 	if host.Id == "" {
 		host.Id = uuid.NewV4().String()
 	}
-	host.CreatedAt = time.Now().Format(constants.TimeFormat)
+	host.CreatedAt = utils.FormatTime(time.Now())
 	hostBody, err := json.Marshal(host)
 	if err != nil {
 		return nil, err
 	}
 
 	dbReq := &Request{
+		Parent:  ctx.Std(),
 		Url:     urls.GenerateHostURL(urls.Etcd, ctx.TenantId, host.Id),
 		Content: string(hostBody),
 	}
@@ -2877,7 +4621,7 @@ func (c *Client) UpdateHost(ctx *c.Context, host *model.HostSpec) (*model.HostSp
 		result.Initiators = host.Initiators
 	}
 	// Set update time
-	result.UpdatedAt = time.Now().Format(constants.TimeFormat)
+	result.UpdatedAt = utils.FormatTime(time.Now())
 	body, err := json.Marshal(result)
 	if err != nil {
 		return nil, err
@@ -2889,6 +4633,7 @@ func (c *Client) UpdateHost(ctx *c.Context, host *model.HostSpec) (*model.HostSp
 	}
 
 	dbReq := &Request{
+		Parent:     ctx.Std(),
 		Url:        urls.GenerateHostURL(urls.Etcd, result.TenantId, result.Id),
 		NewContent: string(body),
 	}
@@ -2920,7 +4665,8 @@ func (c *Client) GetHost(ctx *c.Context, hostId string) (*model.HostSpec, error)
 
 func (c *Client) getHost(ctx *c.Context, hostId string) (*model.HostSpec, error) {
 	dbReq := &Request{
-		Url: urls.GenerateHostURL(urls.Etcd, ctx.TenantId, hostId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateHostURL(urls.Etcd, ctx.TenantId, hostId),
 	}
 	dbRes := c.Get(dbReq)
 	if dbRes.Status != "Success" {
@@ -2948,7 +4694,8 @@ func (c *Client) DeleteHost(ctx *c.Context, hostId string) error {
 		tenantId = host.TenantId
 	}
 	dbReq := &Request{
-		Url: urls.GenerateHostURL(urls.Etcd, tenantId, hostId),
+		Parent: ctx.Std(),
+		Url:    urls.GenerateHostURL(urls.Etcd, tenantId, hostId),
 	}
 
 	dbRes := c.Delete(dbReq)
@@ -2958,3 +4705,150 @@ func (c *Client) DeleteHost(ctx *c.Context, hostId string) error {
 	}
 	return nil
 }
+
+func (c *Client) CreateHostGroup(ctx *c.Context, hg *model.HostGroupSpec) (*model.HostGroupSpec, error) {
+	hg.TenantId = ctx.TenantId
+	if hg.Id == "" {
+		hg.Id = uuid.NewV4().String()
+	}
+	hg.CreatedAt = utils.FormatTime(time.Now())
+	hgBody, err := json.Marshal(hg)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateHostGroupURL(urls.Etcd, ctx.TenantId, hg.Id),
+		Content: string(hgBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When create host group in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return hg, nil
+}
+
+func (c *Client) GetHostGroup(ctx *c.Context, hgId string) (*model.HostGroupSpec, error) {
+	hg, err := c.getHostGroup(ctx, hgId)
+	if !IsAdminContext(ctx) || err == nil {
+		return hg, err
+	}
+	hgs, err := c.ListHostGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range hgs {
+		if v.Id == hgId {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("specified host group(%s) can't find", hgId)
+}
+
+func (c *Client) getHostGroup(ctx *c.Context, hgId string) (*model.HostGroupSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateHostGroupURL(urls.Etcd, ctx.TenantId, hgId),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When get host group in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var hg = &model.HostGroupSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), hg); err != nil {
+		log.Error("When parsing host group in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return hg, nil
+}
+
+func (c *Client) ListHostGroups(ctx *c.Context) ([]*model.HostGroupSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateHostGroupURL(urls.Etcd, ctx.TenantId),
+	}
+
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateHostGroupURL(urls.Etcd, "")
+	}
+
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When list host groups in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var hgs = []*model.HostGroupSpec{}
+	for _, msg := range filterListRecords(dbRes) {
+		var hg = &model.HostGroupSpec{}
+		if err := json.Unmarshal([]byte(msg), hg); err != nil {
+			log.Error("When parsing host group in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		hgs = append(hgs, hg)
+	}
+	return hgs, nil
+}
+
+func (c *Client) UpdateHostGroup(ctx *c.Context, hg *model.HostGroupSpec) (*model.HostGroupSpec, error) {
+	result, err := c.GetHostGroup(ctx, hg.Id)
+	if err != nil {
+		return nil, err
+	}
+	if hg.Name != "" {
+		result.Name = hg.Name
+	}
+	if hg.Description != "" {
+		result.Description = hg.Description
+	}
+	if hg.HostIds != nil {
+		result.HostIds = hg.HostIds
+	}
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	hgBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateHostGroupURL(urls.Etcd, result.TenantId, result.Id),
+		NewContent: string(hgBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When update host group in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+func (c *Client) DeleteHostGroup(ctx *c.Context, hgId string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		hg, err := c.GetHostGroup(ctx, hgId)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = hg.TenantId
+	}
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateHostGroupURL(urls.Etcd, tenantId, hgId),
+	}
+
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("When delete host group in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
@@ -26,7 +26,9 @@ import (
 	"testing"
 
 	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/db/integrity"
 	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils/urls"
 	. "github.com/sodafoundation/api/testutils/collection"
 )
 
@@ -38,6 +40,13 @@ func (*fakeClientCaller) Create(req *Request) *Response {
 	}
 }
 
+func (*fakeClientCaller) CreateIfNotExists(req *Request) *Response {
+	return &Response{
+		Status:  "Success",
+		Message: []string{req.Content},
+	}
+}
+
 func (*fakeClientCaller) Get(req *Request) *Response {
 	var resp []string
 
@@ -95,6 +104,9 @@ func (*fakeClientCaller) List(req *Request) *Response {
 	if strings.Contains(req.Url, "replications") {
 		resp = StringSliceReplications
 	}
+	if strings.Contains(req.Url, "acls") {
+		resp = StringSliceFileSharesAcl
+	}
 	return &Response{
 		Status:  "Success",
 		Message: resp,
@@ -107,12 +119,53 @@ func (*fakeClientCaller) Update(req *Request) *Response {
 	}
 }
 
+func (*fakeClientCaller) UpdateIfUnchanged(req *Request) *Response {
+	return &Response{
+		Status:  "Success",
+		Message: []string{req.NewContent},
+	}
+}
+
 func (*fakeClientCaller) Delete(req *Request) *Response {
 	return &Response{
 		Status: "Success",
 	}
 }
 
+// recordingClientCaller remembers the url of the last list query so that
+// tests can check how a query was scoped.
+type recordingClientCaller struct {
+	fakeClientCaller
+	lastListUrl string
+}
+
+func (r *recordingClientCaller) List(req *Request) *Response {
+	r.lastListUrl = req.Url
+	return r.fakeClientCaller.List(req)
+}
+
+// pendingOpClientCaller keeps the stored pending operation marker, so tests
+// can check that a failed acquisition leaves an existing marker untouched.
+type pendingOpClientCaller struct {
+	fakeClientCaller
+	stored string
+}
+
+func (p *pendingOpClientCaller) CreateIfNotExists(req *Request) *Response {
+	if p.stored != "" {
+		return &Response{
+			Status:  "Failure",
+			Message: []string{p.stored},
+			Error:   ErrRecordExists,
+		}
+	}
+	p.stored = req.Content
+	return &Response{
+		Status:  "Success",
+		Message: []string{req.Content},
+	}
+}
+
 var fc = &Client{
 	clientInterface: &fakeClientCaller{},
 }
@@ -336,6 +389,74 @@ func TestListVolumes(t *testing.T) {
 	}
 }
 
+func TestListVolumesTenantScope(t *testing.T) {
+	rc := &recordingClientCaller{}
+	client := &Client{clientInterface: rc}
+
+	// A non-admin query is always scoped to its own tenant.
+	userCtx := &c.Context{TenantId: "tenantA"}
+	if _, err := client.ListVolumes(userCtx); err != nil {
+		t.Error("List volumes failed:", err)
+	}
+	if !strings.Contains(rc.lastListUrl, "tenantA") {
+		t.Errorf("Expected query scoped to tenantA, got %s", rc.lastListUrl)
+	}
+
+	// An admin that did not ask for all tenants is scoped as well.
+	adminCtx := &c.Context{TenantId: "tenantB", IsAdmin: true}
+	if _, err := client.ListVolumes(adminCtx); err != nil {
+		t.Error("List volumes failed:", err)
+	}
+	if !strings.Contains(rc.lastListUrl, "tenantB") {
+		t.Errorf("Expected query scoped to tenantB, got %s", rc.lastListUrl)
+	}
+
+	// Only admin plus allTenants gets the unscoped view.
+	if _, err := client.ListVolumes(c.NewAdminContext()); err != nil {
+		t.Error("List volumes failed:", err)
+	}
+	if expected := urls.GenerateVolumeURL(urls.Etcd, ""); rc.lastListUrl != expected {
+		t.Errorf("Expected unscoped query %s, got %s", expected, rc.lastListUrl)
+	}
+}
+
+func TestAcquirePendingOperation(t *testing.T) {
+	pc := &pendingOpClientCaller{}
+	client := &Client{clientInterface: pc}
+
+	op, err := client.AcquirePendingOperation(c.NewAdminContext(), &model.PendingOperationSpec{
+		BaseModel:    &model.BaseModel{},
+		ResourceType: "volume",
+		ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		Operation:    "extend",
+	})
+	if err != nil {
+		t.Fatal("Acquire pending operation failed:", err)
+	}
+	if op.Token == "" {
+		t.Error("Expected a fencing token on the acquired operation")
+	}
+	firstMarker := pc.stored
+
+	// A second acquisition against the existing marker fails, reporting the
+	// running operation, and must not overwrite the marker.
+	_, err = client.AcquirePendingOperation(c.NewAdminContext(), &model.PendingOperationSpec{
+		BaseModel:    &model.BaseModel{},
+		ResourceType: "volume",
+		ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+		Operation:    "delete",
+	})
+	if err == nil {
+		t.Fatal("Expected the second acquisition to fail, got none")
+	}
+	if !strings.Contains(err.Error(), "extend") {
+		t.Errorf("Expected the error to name the running operation, got %v", err)
+	}
+	if pc.stored != firstMarker {
+		t.Error("Expected the existing marker to stay untouched")
+	}
+}
+
 func TestUpdateVolume(t *testing.T) {
 	var vol = model.VolumeSpec{
 		BaseModel: &model.BaseModel{
@@ -367,6 +488,34 @@ func TestUpdateVolume(t *testing.T) {
 	}
 }
 
+func TestUpdateVolumeMetadata(t *testing.T) {
+	update := &model.MetadataUpdateSpec{
+		Metadata: map[string]string{"owner": "team-a"},
+		IfAbsent: []string{"owner"},
+	}
+
+	result, err := fc.UpdateVolumeMetadata(c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8", update)
+	if err != nil {
+		t.Error("Update volume metadata failed:", err)
+	}
+
+	if result.Metadata["owner"] != "team-a" {
+		t.Errorf("Expected %+v, got %+v\n", "team-a", result.Metadata["owner"])
+	}
+
+	// A condition that does not hold fails the update with a condition
+	// error, so the api can answer with a conflict.
+	bad := &model.MetadataUpdateSpec{
+		Metadata: map[string]string{"owner": "team-b"},
+		IfValue:  map[string]string{"owner": "team-b"},
+	}
+	if _, err := fc.UpdateVolumeMetadata(c.NewAdminContext(), "bd5b12a8-a101-11e7-941e-d77981b584d8", bad); err == nil {
+		t.Error("Expected a condition error, got none")
+	} else if _, ok := err.(*model.MetadataConditionError); !ok {
+		t.Errorf("Expected a condition error, got %v", err)
+	}
+}
+
 func TestListVolumeAttachments(t *testing.T) {
 	m := map[string][]string{
 		"VolumeId": {"bd5b12a8-a101-11e7-941e-d77981b584d8"},
@@ -587,6 +736,23 @@ func TestExtendVolume(t *testing.T) {
 	}
 }
 
+func TestListFileSharesAclWithFilter(t *testing.T) {
+	m := map[string][]string{
+		"accessTo":         {"10.32.109.15"},
+		"accessCapability": {"Write"},
+	}
+	acls, err := fc.ListFileSharesAclWithFilter(c.NewAdminContext(), m)
+	if err != nil {
+		t.Error("List fileshare acls failed:", err)
+	}
+
+	if len(acls) != 1 {
+		t.Errorf("Expected 1 acl, got %d\n", len(acls))
+	} else if acls[0].Id != "6ad25d59-a160-45b2-8920-211be282e2df" {
+		t.Errorf("Expected %+v, got %+v\n", "6ad25d59-a160-45b2-8920-211be282e2df", acls[0].Id)
+	}
+}
+
 func TestUpdateFileShareAcl(t *testing.T) {
 	metadata := make(map[string]string)
 	metadata["manilaShareID"] = "a55156cd-05b7-4e36-9d78-c3cb4006713b"
@@ -688,6 +854,48 @@ func TestFilterAndSort(t *testing.T) {
 				&SamplePools[2],
 			},
 		},
+		// marker pagination starts right after the named record
+		{
+			input: []*model.StoragePoolSpec{
+				&SamplePools[0],
+				&SamplePools[1],
+				&SamplePools[2],
+			},
+			param: map[string][]string{
+				"marker": {SamplePools[0].Id},
+				"limit":  {"1"},
+			},
+			expected: []*model.StoragePoolSpec{
+				&SamplePools[1],
+			},
+		},
+		// a marker takes precedence over an offset
+		{
+			input: []*model.StoragePoolSpec{
+				&SamplePools[0],
+				&SamplePools[1],
+				&SamplePools[2],
+			},
+			param: map[string][]string{
+				"marker": {SamplePools[1].Id},
+				"offset": {"2"},
+			},
+			expected: []*model.StoragePoolSpec{
+				&SamplePools[2],
+			},
+		},
+		// an unknown marker ends the iteration with an empty page
+		{
+			input: []*model.StoragePoolSpec{
+				&SamplePools[0],
+				&SamplePools[1],
+				&SamplePools[2],
+			},
+			param: map[string][]string{
+				"marker": {"no-such-record"},
+			},
+			expected: []*model.StoragePoolSpec{},
+		},
 	}
 	for _, testcase := range tests {
 		ret := fc.FilterAndSort(testcase.input, testcase.param, sortableKeysMap[typePools])
@@ -1414,3 +1622,37 @@ func TestListVolumeSnapshotsWithFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestFilterListRecords(t *testing.T) {
+	dbRes := &Response{
+		Status: "Success",
+		Message: []string{
+			`{"id": "bd5b12a8-a101-11e7-941e-d77981b584d8", "name": "sample"}`,
+			`{"id": "bd5b12a8-a101-11e7-941e-d77981b584d8", "name": "duplicate"}`,
+			`{"id": "corrupt...`,
+			`{"id": "3769855c-a102-11e7-b772-17b880d2f537", "name": "other"}`,
+		},
+		Keys: []string{
+			"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8",
+			"/v1beta/block/volumes/bd5b12a8-a101-11e7-941e-d77981b584d8-copy",
+			"/v1beta/block/volumes/corrupt",
+			"/v1beta/block/volumes/3769855c-a102-11e7-b772-17b880d2f537",
+		},
+	}
+
+	got := filterListRecords(dbRes)
+	expected := []string{dbRes.Message[0], dbRes.Message[3]}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	var foundCorruptKey bool
+	for _, record := range integrity.Records() {
+		if record.Key == "/v1beta/block/volumes/corrupt" {
+			foundCorruptKey = true
+		}
+	}
+	if !foundCorruptKey {
+		t.Errorf("Expected the corrupt record to be reported by key")
+	}
+}
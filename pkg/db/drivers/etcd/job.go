@@ -0,0 +1,185 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/golang/glog"
+	uuid "github.com/satori/go.uuid"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	"github.com/sodafoundation/api/pkg/utils/urls"
+)
+
+// CreateJob
+func (c *Client) CreateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	if job.Id == "" {
+		job.Id = uuid.NewV4().String()
+	}
+	if job.CreatedAt == "" {
+		job.CreatedAt = utils.FormatTime(time.Now())
+	}
+	if job.TenantId == "" {
+		job.TenantId = ctx.TenantId
+	}
+
+	jobBody, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateJobURL(urls.Etcd, job.TenantId, job.Id),
+		Content: string(jobBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create job in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return job, nil
+}
+
+// GetJob
+func (c *Client) GetJob(ctx *c.Context, jobID string) (*model.JobSpec, error) {
+	job, err := c.getJob(ctx, jobID)
+	if !IsAdminContext(ctx) || err == nil {
+		return job, err
+	}
+	jobs, err := c.ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, j := range jobs {
+		if j.Id == jobID {
+			return j, nil
+		}
+	}
+	return nil, fmt.Errorf("specified job(%s) can't find", jobID)
+}
+
+func (c *Client) getJob(ctx *c.Context, jobID string) (*model.JobSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateJobURL(urls.Etcd, ctx.TenantId, jobID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get job in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var job = &model.JobSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), job); err != nil {
+		log.Error("when parsing job in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return job, nil
+}
+
+// ListJobs
+func (c *Client) ListJobs(ctx *c.Context) ([]*model.JobSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateJobURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateJobURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list jobs in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var jobs = []*model.JobSpec{}
+	for _, msg := range filterListRecords(dbRes) {
+		var job = &model.JobSpec{}
+		if err := json.Unmarshal([]byte(msg), job); err != nil {
+			log.Error("when parsing job in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// UpdateJob
+func (c *Client) UpdateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	result, err := c.GetJob(ctx, job.Id)
+	if err != nil {
+		return nil, err
+	}
+	result.Status = job.Status
+	result.ErrorMessage = job.ErrorMessage
+	if job.Result != nil {
+		result.Result = job.Result
+	}
+	if job.StartedAt != "" {
+		result.StartedAt = job.StartedAt
+	}
+	if job.FinishedAt != "" {
+		result.FinishedAt = job.FinishedAt
+	}
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	jobBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateJobURL(urls.Etcd, result.TenantId, result.Id),
+		NewContent: string(jobBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update job in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+// DeleteJob
+func (c *Client) DeleteJob(ctx *c.Context, jobID string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		job, err := c.GetJob(ctx, jobID)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = job.TenantId
+	}
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateJobURL(urls.Etcd, tenantId, jobID),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete job in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	log "github.com/golang/glog"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	"github.com/sodafoundation/api/pkg/utils/urls"
+)
+
+// metadataUpdateRetries bounds how often a metadata merge is retried when
+// a concurrent writer changed the record between the read and the write.
+const metadataUpdateRetries = 3
+
+// UpdateVolumeMetadata merges the update into the metadata of the volume.
+// The read-merge-write is guarded by a compare-and-swap on the record, so
+// concurrent writers never overwrite each other's keys; a lost race is
+// retried against the fresh record.
+func (c *Client) UpdateVolumeMetadata(ctx *c.Context, volID string, update *model.MetadataUpdateSpec) (*model.VolumeSpec, error) {
+	// The tenant owning the record decides the key, so an admin can update
+	// other tenants' volumes too.
+	vol, err := c.GetVolume(ctx, volID)
+	if err != nil {
+		return nil, err
+	}
+	url := urls.GenerateVolumeURL(urls.Etcd, vol.TenantId, volID)
+
+	var result *model.VolumeSpec
+	err = c.casUpdate(ctx, url, func(raw string) (string, error) {
+		result = &model.VolumeSpec{}
+		if err := json.Unmarshal([]byte(raw), result); err != nil {
+			return "", err
+		}
+		merged, err := update.Apply(result.Metadata)
+		if err != nil {
+			return "", err
+		}
+		result.Metadata = merged
+		result.UpdatedAt = utils.FormatTime(time.Now())
+		body, err := json.Marshal(result)
+		return string(body), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateFileShareMetadata merges the update into the metadata of the
+// fileshare, guarded by the same compare-and-swap as the volume variant.
+func (c *Client) UpdateFileShareMetadata(ctx *c.Context, fshareID string, update *model.MetadataUpdateSpec) (*model.FileShareSpec, error) {
+	fshare, err := c.GetFileShare(ctx, fshareID)
+	if err != nil {
+		return nil, err
+	}
+	url := urls.GenerateFileShareURL(urls.Etcd, fshare.TenantId, fshareID)
+
+	var result *model.FileShareSpec
+	err = c.casUpdate(ctx, url, func(raw string) (string, error) {
+		result = &model.FileShareSpec{}
+		if err := json.Unmarshal([]byte(raw), result); err != nil {
+			return "", err
+		}
+		merged, err := update.Apply(result.Metadata)
+		if err != nil {
+			return "", err
+		}
+		result.Metadata = merged
+		result.UpdatedAt = utils.FormatTime(time.Now())
+		body, err := json.Marshal(result)
+		return string(body), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// casUpdate reads the record at url, lets modify derive the new content
+// from the raw stored form, and writes it back only when the record is
+// still unchanged. A lost race re-reads and retries a bounded number of
+// times.
+func (c *Client) casUpdate(ctx *c.Context, url string, modify func(raw string) (string, error)) error {
+	for attempt := 0; attempt < metadataUpdateRetries; attempt++ {
+		getRes := c.Get(&Request{Parent: ctx.Std(), Url: url})
+		if getRes.Status != "Success" {
+			log.Error("when get record for metadata update in db:", getRes.Error)
+			return errors.New(getRes.Error)
+		}
+		raw := getRes.Message[0]
+
+		newContent, err := modify(raw)
+		if err != nil {
+			return err
+		}
+
+		updateRes := c.UpdateIfUnchanged(&Request{
+			Parent:     ctx.Std(),
+			Url:        url,
+			Content:    raw,
+			NewContent: newContent,
+		})
+		if updateRes.Status == "Success" {
+			return nil
+		}
+		if updateRes.Error == ErrRecordChanged {
+			continue
+		}
+		log.Error("when conditionally update record in db:", updateRes.Error)
+		return errors.New(updateRes.Error)
+	}
+	return errors.New(ErrRecordChanged)
+}
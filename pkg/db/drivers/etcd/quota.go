@@ -0,0 +1,132 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	log "github.com/golang/glog"
+	uuid "github.com/satori/go.uuid"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	"github.com/sodafoundation/api/pkg/utils/urls"
+)
+
+// Quotas are admin-managed records, one per tenant, stored outside the
+// tenant namespaces and keyed by the tenant they apply to.
+
+// GetQuota
+func (c *Client) GetQuota(ctx *c.Context, tenantID string) (*model.QuotaSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateQuotaURL(urls.Etcd, "", tenantID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get quota in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var quota = &model.QuotaSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), quota); err != nil {
+		log.Error("when parsing quota in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return quota, nil
+}
+
+// ListQuotas
+func (c *Client) ListQuotas(ctx *c.Context) ([]*model.QuotaSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateQuotaURL(urls.Etcd, ""),
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list quotas in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var quotas = []*model.QuotaSpec{}
+	if len(dbRes.Message) == 0 {
+		return quotas, nil
+	}
+	for _, msg := range filterListRecords(dbRes) {
+		var quota = &model.QuotaSpec{}
+		if err := json.Unmarshal([]byte(msg), quota); err != nil {
+			log.Error("when parsing quota in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		quotas = append(quotas, quota)
+	}
+	return quotas, nil
+}
+
+// SetQuota writes the quota of a tenant, creating or replacing the record.
+func (c *Client) SetQuota(ctx *c.Context, quota *model.QuotaSpec) (*model.QuotaSpec, error) {
+	existing, err := c.GetQuota(ctx, quota.TenantId)
+	if err == nil {
+		quota.BaseModel = existing.BaseModel
+		quota.UpdatedAt = utils.FormatTime(time.Now())
+	} else {
+		quota.BaseModel = &model.BaseModel{
+			Id:        uuid.NewV4().String(),
+			CreatedAt: utils.FormatTime(time.Now()),
+		}
+	}
+
+	quotaBody, marshalErr := json.Marshal(quota)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateQuotaURL(urls.Etcd, "", quota.TenantId),
+		Content: string(quotaBody),
+	}
+	if err == nil {
+		dbReq.NewContent = string(quotaBody)
+		dbRes := c.Update(dbReq)
+		if dbRes.Status != "Success" {
+			log.Error("when update quota in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		return quota, nil
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create quota in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return quota, nil
+}
+
+// DeleteQuota
+func (c *Client) DeleteQuota(ctx *c.Context, tenantID string) error {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateQuotaURL(urls.Etcd, "", tenantID),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete quota in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
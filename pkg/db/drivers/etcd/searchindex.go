@@ -0,0 +1,177 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"strings"
+	"sync"
+)
+
+// searchIndex is an in-memory inverted index over the searchable text of one
+// resource kind, backing the ?q= parameter of the list endpoints. It is
+// maintained incrementally as records are created, updated and deleted, and
+// built lazily from the store on the first search, so records written before
+// this process started are found too.
+type searchIndex struct {
+	lock  sync.RWMutex
+	built bool
+	// One posting list of record ids per token.
+	tokens map[string]map[string]struct{}
+	// The tokens every record contributed, kept for removal.
+	docs map[string][]string
+}
+
+// tokenize lowercases the texts and splits them into alphanumeric tokens.
+func tokenize(texts ...string) []string {
+	var seen = map[string]struct{}{}
+	var tokens []string
+	for _, text := range texts {
+		fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+		})
+		for _, token := range fields {
+			if _, ok := seen[token]; !ok {
+				seen[token] = struct{}{}
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+// searchText flattens the searchable fields of a record: its name, its
+// description and the metadata values.
+func searchText(name, description string, metadata map[string]string) []string {
+	var texts = []string{name, description}
+	for _, value := range metadata {
+		texts = append(texts, value)
+	}
+	return texts
+}
+
+// index (re)indexes one record under the given texts.
+func (i *searchIndex) index(id string, texts ...string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.indexLocked(id, texts)
+}
+
+func (i *searchIndex) indexLocked(id string, texts []string) {
+	if i.tokens == nil {
+		i.tokens = map[string]map[string]struct{}{}
+		i.docs = map[string][]string{}
+	}
+	i.removeLocked(id)
+	tokens := tokenize(texts...)
+	for _, token := range tokens {
+		if i.tokens[token] == nil {
+			i.tokens[token] = map[string]struct{}{}
+		}
+		i.tokens[token][id] = struct{}{}
+	}
+	i.docs[id] = tokens
+}
+
+// remove drops one record from the index.
+func (i *searchIndex) remove(id string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.removeLocked(id)
+}
+
+func (i *searchIndex) removeLocked(id string) {
+	for _, token := range i.docs[id] {
+		delete(i.tokens[token], id)
+		if len(i.tokens[token]) == 0 {
+			delete(i.tokens, token)
+		}
+	}
+	delete(i.docs, id)
+}
+
+// ensure builds the index from the full record set when it has not been
+// built yet. load returns the searchable texts of every record by id.
+func (i *searchIndex) ensure(load func() (map[string][]string, error)) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.built {
+		return nil
+	}
+	records, err := load()
+	if err != nil {
+		return err
+	}
+	for id, texts := range records {
+		i.indexLocked(id, texts)
+	}
+	i.built = true
+	return nil
+}
+
+// rebuild throws the index away and builds it again from the full record
+// set, repairing any drift between the index and the store. The fresh texts
+// are loaded before the swap, so searches keep working on the old index
+// until the new one is ready. The number of indexed records is returned.
+func (i *searchIndex) rebuild(load func() (map[string][]string, error)) (int, error) {
+	records, err := load()
+	if err != nil {
+		return 0, err
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.tokens = map[string]map[string]struct{}{}
+	i.docs = map[string][]string{}
+	for id, texts := range records {
+		i.indexLocked(id, texts)
+	}
+	i.built = true
+	return len(records), nil
+}
+
+// search returns the ids of the records matching every token of the query.
+// A query token matches an indexed token by prefix, so partial words find
+// their records. The second return value is false when the query contains no
+// tokens at all, meaning the result does not restrict anything.
+func (i *searchIndex) search(query string) (map[string]struct{}, bool) {
+	queryTokens := tokenize(query)
+	if len(queryTokens) == 0 {
+		return nil, false
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	var matched map[string]struct{}
+	for _, queryToken := range queryTokens {
+		ids := map[string]struct{}{}
+		for token, postings := range i.tokens {
+			if !strings.HasPrefix(token, queryToken) {
+				continue
+			}
+			for id := range postings {
+				ids[id] = struct{}{}
+			}
+		}
+		if matched == nil {
+			matched = ids
+			continue
+		}
+		for id := range matched {
+			if _, ok := ids[id]; !ok {
+				delete(matched, id)
+			}
+		}
+	}
+	return matched, true
+}
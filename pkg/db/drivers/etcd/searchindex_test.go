@@ -0,0 +1,77 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"testing"
+)
+
+func TestSearchIndex(t *testing.T) {
+	var index searchIndex
+	index.index("share-1", searchText("oracle-migration", "share created for the Oracle migration", nil)...)
+	index.index("share-2", searchText("web-assets", "static assets", map[string]string{"owner": "team-web"})...)
+
+	matched, restricted := index.search("oracle migration")
+	if !restricted {
+		t.Errorf("expected the query to restrict the result")
+	}
+	if _, ok := matched["share-1"]; !ok || len(matched) != 1 {
+		t.Errorf("expected only share-1 to match, got %v", matched)
+	}
+
+	// Partial words match by prefix and metadata values are searchable.
+	matched, _ = index.search("team")
+	if _, ok := matched["share-2"]; !ok || len(matched) != 1 {
+		t.Errorf("expected only share-2 to match, got %v", matched)
+	}
+
+	// An empty query restricts nothing.
+	if _, restricted := index.search("  "); restricted {
+		t.Errorf("expected an empty query not to restrict the result")
+	}
+
+	// Reindexing replaces the old tokens, removal drops the record.
+	index.index("share-1", searchText("postgres-migration", "", nil)...)
+	if matched, _ := index.search("oracle"); len(matched) != 0 {
+		t.Errorf("expected no match after reindexing, got %v", matched)
+	}
+	index.remove("share-2")
+	if matched, _ := index.search("team"); len(matched) != 0 {
+		t.Errorf("expected no match after removal, got %v", matched)
+	}
+}
+
+func TestSearchIndexEnsure(t *testing.T) {
+	var index searchIndex
+	err := index.ensure(func() (map[string][]string, error) {
+		return map[string][]string{
+			"vol-1": searchText("db-volume", "the Oracle data files", nil),
+		}, nil
+	})
+	if err != nil {
+		t.Errorf("failed to build the search index: %v", err)
+	}
+	if matched, _ := index.search("oracle"); len(matched) != 1 {
+		t.Errorf("expected vol-1 to match, got %v", matched)
+	}
+
+	// A built index is not rebuilt.
+	if err := index.ensure(func() (map[string][]string, error) {
+		t.Errorf("the index was rebuilt although it is already built")
+		return nil, nil
+	}); err != nil {
+		t.Errorf("ensure on a built index failed: %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/golang/glog"
+	uuid "github.com/satori/go.uuid"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	"github.com/sodafoundation/api/pkg/utils/urls"
+)
+
+// Share networks are deployment infrastructure like profiles: admins
+// register them once and every tenant references them, so the records are
+// stored globally instead of per tenant.
+
+func (c *Client) CreateShareNetwork(ctx *c.Context, network *model.ShareNetworkSpec) (*model.ShareNetworkSpec, error) {
+	if network.Id == "" {
+		network.Id = uuid.NewV4().String()
+	}
+	if network.CreatedAt == "" {
+		network.CreatedAt = utils.FormatTime(time.Now())
+	}
+
+	// share network name must be unique.
+	if _, err := c.getShareNetworkByName(ctx, network.Name); err == nil {
+		return nil, fmt.Errorf("the share network name '%s' already exists", network.Name)
+	}
+
+	networkBody, err := json.Marshal(network)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateShareNetworkURL(urls.Etcd, "", network.Id),
+		Content: string(networkBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create share network in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return network, nil
+}
+
+func (c *Client) GetShareNetwork(ctx *c.Context, networkID string) (*model.ShareNetworkSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateShareNetworkURL(urls.Etcd, "", networkID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get share network in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var network = &model.ShareNetworkSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), network); err != nil {
+		log.Error("when parsing share network in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return network, nil
+}
+
+func (c *Client) getShareNetworkByName(ctx *c.Context, name string) (*model.ShareNetworkSpec, error) {
+	networks, err := c.ListShareNetworks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range networks {
+		if network.Name == name {
+			return network, nil
+		}
+	}
+	return nil, fmt.Errorf("specified share network(%s) can't find", name)
+}
+
+func (c *Client) ListShareNetworks(ctx *c.Context) ([]*model.ShareNetworkSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateShareNetworkURL(urls.Etcd, ""),
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list share networks in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var networks = []*model.ShareNetworkSpec{}
+	if len(dbRes.Message) == 0 {
+		return networks, nil
+	}
+	for _, msg := range filterListRecords(dbRes) {
+		var network = &model.ShareNetworkSpec{}
+		if err := json.Unmarshal([]byte(msg), network); err != nil {
+			log.Error("when parsing share network in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func (c *Client) UpdateShareNetwork(ctx *c.Context, networkID string, network *model.ShareNetworkSpec) (*model.ShareNetworkSpec, error) {
+	result, err := c.GetShareNetwork(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+	if network.Name != "" {
+		result.Name = network.Name
+	}
+	if network.Description != "" {
+		result.Description = network.Description
+	}
+	if network.Cidr != "" {
+		result.Cidr = network.Cidr
+	}
+	if network.Vlan != 0 {
+		result.Vlan = network.Vlan
+	}
+	// The default mark is taken as given, so an update can both set and
+	// clear it.
+	result.Default = network.Default
+
+	// Set update time
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	networkBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateShareNetworkURL(urls.Etcd, "", networkID),
+		NewContent: string(networkBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update share network in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+func (c *Client) DeleteShareNetwork(ctx *c.Context, networkID string) error {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateShareNetworkURL(urls.Etcd, "", networkID),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete share network in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/golang/glog"
+	uuid "github.com/satori/go.uuid"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	"github.com/sodafoundation/api/pkg/utils/urls"
+)
+
+// CreateTask
+func (c *Client) CreateTask(ctx *c.Context, task *model.TaskSpec) (*model.TaskSpec, error) {
+	if task.Id == "" {
+		task.Id = uuid.NewV4().String()
+	}
+	if task.CreatedAt == "" {
+		task.CreatedAt = utils.FormatTime(time.Now())
+	}
+	if task.TenantId == "" {
+		task.TenantId = ctx.TenantId
+	}
+
+	taskBody, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateTaskURL(urls.Etcd, task.TenantId, task.Id),
+		Content: string(taskBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when create task in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	return task, nil
+}
+
+// GetTask
+func (c *Client) GetTask(ctx *c.Context, taskID string) (*model.TaskSpec, error) {
+	task, err := c.getTask(ctx, taskID)
+	if !IsAdminContext(ctx) || err == nil {
+		return task, err
+	}
+	tasks, err := c.ListTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tasks {
+		if t.Id == taskID {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("specified task(%s) can't find", taskID)
+}
+
+func (c *Client) getTask(ctx *c.Context, taskID string) (*model.TaskSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateTaskURL(urls.Etcd, ctx.TenantId, taskID),
+	}
+	dbRes := c.Get(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when get task in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var task = &model.TaskSpec{}
+	if err := json.Unmarshal([]byte(dbRes.Message[0]), task); err != nil {
+		log.Error("when parsing task in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return task, nil
+}
+
+// ListTasks
+func (c *Client) ListTasks(ctx *c.Context) ([]*model.TaskSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateTaskURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateTaskURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list tasks in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var tasks = []*model.TaskSpec{}
+	for _, msg := range filterListRecords(dbRes) {
+		var task = &model.TaskSpec{}
+		if err := json.Unmarshal([]byte(msg), task); err != nil {
+			log.Error("when parsing task in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// UpdateTask
+func (c *Client) UpdateTask(ctx *c.Context, task *model.TaskSpec) (*model.TaskSpec, error) {
+	result, err := c.GetTask(ctx, task.Id)
+	if err != nil {
+		return nil, err
+	}
+	result.Status = task.Status
+	result.ErrorMessage = task.ErrorMessage
+	if task.Transitions != nil {
+		result.Transitions = task.Transitions
+	}
+	result.UpdatedAt = utils.FormatTime(time.Now())
+
+	taskBody, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReq := &Request{
+		Parent:     ctx.Std(),
+		Url:        urls.GenerateTaskURL(urls.Etcd, result.TenantId, result.Id),
+		NewContent: string(taskBody),
+	}
+	dbRes := c.Update(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when update task in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+	return result, nil
+}
+
+// DeleteTask
+func (c *Client) DeleteTask(ctx *c.Context, taskID string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		task, err := c.GetTask(ctx, taskID)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		tenantId = task.TenantId
+	}
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateTaskURL(urls.Etcd, tenantId, taskID),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete task in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/golang/glog"
+	uuid "github.com/satori/go.uuid"
+	c "github.com/sodafoundation/api/pkg/context"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+	"github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/urls"
+)
+
+// tombstonesEnabled tells whether deleted resources leave tombstone
+// records behind, which is the case when a retention is configured.
+func tombstonesEnabled() bool {
+	return config.CONF.OsdsApiServer.TombstoneRetention > 0
+}
+
+// writeTombstone records the tombstone of a just deleted resource. It is
+// best effort, a failed write only logs: the deletion itself already
+// happened and must not be reported as failed.
+func (c *Client) writeTombstone(ctx *c.Context, tombstone *model.TombstoneSpec) {
+	tombstone.BaseModel = &model.BaseModel{
+		Id:        uuid.NewV4().String(),
+		CreatedAt: utils.FormatTime(time.Now()),
+	}
+	tombstone.DeletedAt = tombstone.CreatedAt
+	tombstone.DeletedBy = ctx.UserId
+
+	tombstoneBody, err := json.Marshal(tombstone)
+	if err != nil {
+		log.Errorf("when marshaling tombstone of %s %s: %v",
+			tombstone.ResourceType, tombstone.ResourceId, err)
+		return
+	}
+
+	dbReq := &Request{
+		Parent:  ctx.Std(),
+		Url:     urls.GenerateTombstoneURL(urls.Etcd, tombstone.TenantId, tombstone.Id),
+		Content: string(tombstoneBody),
+	}
+	dbRes := c.Create(dbReq)
+	if dbRes.Status != "Success" {
+		log.Errorf("when writing tombstone of %s %s: %s",
+			tombstone.ResourceType, tombstone.ResourceId, dbRes.Error)
+	}
+}
+
+// ListTombstones
+func (c *Client) ListTombstones(ctx *c.Context) ([]*model.TombstoneSpec, error) {
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateTombstoneURL(urls.Etcd, ctx.TenantId),
+	}
+	if IsAllTenantsContext(ctx) {
+		dbReq.Url = urls.GenerateTombstoneURL(urls.Etcd, "")
+	}
+	dbRes := c.List(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when list tombstones in db:", dbRes.Error)
+		return nil, errors.New(dbRes.Error)
+	}
+
+	var tombstones = []*model.TombstoneSpec{}
+	if len(dbRes.Message) == 0 {
+		return tombstones, nil
+	}
+	for _, msg := range filterListRecords(dbRes) {
+		var tombstone = &model.TombstoneSpec{}
+		if err := json.Unmarshal([]byte(msg), tombstone); err != nil {
+			log.Error("when parsing tombstone in db:", dbRes.Error)
+			return nil, errors.New(dbRes.Error)
+		}
+		tombstones = append(tombstones, tombstone)
+	}
+	return tombstones, nil
+}
+
+// DeleteTombstone
+func (c *Client) DeleteTombstone(ctx *c.Context, tombstoneID string) error {
+	// If an admin want to access other tenant's resource just fake other's tenantId.
+	tenantId := ctx.TenantId
+	if IsAdminContext(ctx) {
+		tombstones, err := c.ListTombstones(ctx)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		found := false
+		for _, tombstone := range tombstones {
+			if tombstone.Id == tombstoneID {
+				tenantId = tombstone.TenantId
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("specified tombstone(%s) can't find", tombstoneID)
+		}
+	}
+	dbReq := &Request{
+		Parent: ctx.Std(),
+		Url:    urls.GenerateTombstoneURL(urls.Etcd, tenantId, tombstoneID),
+	}
+	dbRes := c.Delete(dbReq)
+	if dbRes.Status != "Success" {
+		log.Error("when delete tombstone in db:", dbRes.Error)
+		return errors.New(dbRes.Error)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health tracks how the database is keeping up. The db drivers
+// report the duration of every operation together with the number of
+// requests waiting for an in-flight slot; the load shedding filter reads
+// the aggregates back to decide when the server is overloaded.
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+// windowSize is the number of recent operations the moving average spans.
+const windowSize = 128
+
+var (
+	mutex  sync.Mutex
+	window [windowSize]time.Duration
+	next   int
+	filled int
+	total  time.Duration
+	depth  int
+)
+
+// Observe records the duration of one db operation and the queue depth the
+// driver saw while running it.
+func Observe(d time.Duration, queueDepth int) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if filled == windowSize {
+		total -= window[next]
+	} else {
+		filled++
+	}
+	window[next] = d
+	total += d
+	next = (next + 1) % windowSize
+	depth = queueDepth
+}
+
+// AvgLatency returns the moving average duration of the recent db
+// operations, zero while nothing was observed yet.
+func AvgLatency() time.Duration {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if filled == 0 {
+		return 0
+	}
+	return total / time.Duration(filled)
+}
+
+// QueueDepth returns the db queue depth of the most recent observation.
+func QueueDepth() int {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return depth
+}
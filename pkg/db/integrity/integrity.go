@@ -0,0 +1,58 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package integrity tracks the corrupt records the db drivers run into.
+// Drivers skip records that fail to parse so one bad key does not take
+// down a whole listing, and report them here; administrators read the
+// registry back through the admin corrupt-records endpoint. The registry
+// lives in memory and starts empty on every restart.
+package integrity
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils"
+)
+
+var (
+	mu      sync.Mutex
+	records = map[string]*model.CorruptRecordSpec{}
+)
+
+// RecordCorruption remembers that the record under the given db key failed
+// to parse. Seeing the same key again refreshes its entry.
+func RecordCorruption(key string, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+	records[key] = &model.CorruptRecordSpec{
+		Key:        key,
+		Error:      err.Error(),
+		DetectedAt: utils.FormatTime(time.Now()),
+	}
+}
+
+// Records returns the corrupt records seen since startup, ordered by key.
+func Records() []*model.CorruptRecordSpec {
+	mu.Lock()
+	defer mu.Unlock()
+	var result = []*model.CorruptRecordSpec{}
+	for _, record := range records {
+		result = append(result, record)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// ImportRequestSpec is a schema of the admin import request, the brownfield
+// adoption tool. It carries a Cinder volume list or a Manila share export
+// together with the mappings into this deployment. Without execute set the
+// importer only reports the diff, the database is left untouched.
+type ImportRequestSpec struct {
+	// Where the export came from, either "cinder" or "manila".
+	Source string `json:"source,omitempty"`
+
+	// If execute is set the importable entries are adopted instead of only
+	// being reported. Default is false, a dry run.
+	Execute bool `json:"execute,omitempty"`
+
+	// TypeToProfile maps the volume type or share type names of the source
+	// to profile ids of this deployment. An entry whose type has no mapping
+	// is skipped.
+	TypeToProfile map[string]string `json:"typeToProfile,omitempty"`
+
+	// ZoneMapping maps availability zone names of the source to zones of
+	// this deployment. An unmapped zone name passes through unchanged.
+	ZoneMapping map[string]string `json:"zoneMapping,omitempty"`
+
+	// HostToPool maps the backend host strings of the source (for Cinder
+	// the full host@backend#pool form) to pool ids of this deployment. An
+	// entry whose host has no mapping is skipped.
+	HostToPool map[string]string `json:"hostToPool,omitempty"`
+
+	// The entries of a Cinder volume list, used when source is "cinder".
+	Volumes []*CinderVolumeSpec `json:"volumes,omitempty"`
+
+	// The entries of a Manila share export, used when source is "manila".
+	Shares []*ManilaShareSpec `json:"shares,omitempty"`
+}
+
+// CinderVolumeSpec is one entry of a Cinder volume list, with the field
+// names the Cinder API serves.
+type CinderVolumeSpec struct {
+	Id               string            `json:"id,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	Size             int64             `json:"size,omitempty"`
+	Status           string            `json:"status,omitempty"`
+	VolumeType       string            `json:"volume_type,omitempty"`
+	AvailabilityZone string            `json:"availability_zone,omitempty"`
+	Host             string            `json:"os-vol-host-attr:host,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// ManilaShareSpec is one entry of a Manila share export, with the field
+// names the Manila API serves.
+type ManilaShareSpec struct {
+	Id               string   `json:"id,omitempty"`
+	Name             string   `json:"name,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	Size             int64    `json:"size,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	ShareType        string   `json:"share_type,omitempty"`
+	ShareProto       string   `json:"share_proto,omitempty"`
+	AvailabilityZone string   `json:"availability_zone,omitempty"`
+	Host             string   `json:"host,omitempty"`
+	ExportLocations  []string `json:"export_locations,omitempty"`
+}
+
+// ImportItemSpec describes one entry of the export and what the importer
+// did, or would do, with it.
+type ImportItemSpec struct {
+	// The id the entry has in the source deployment.
+	SourceId string `json:"sourceId,omitempty"`
+
+	// The name of the entry.
+	Name string `json:"name,omitempty"`
+
+	// What the entry becomes here, either "volume" or "fileshare".
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The uuid the adopted record was assigned. Empty in a dry run.
+	Id string `json:"id,omitempty"`
+
+	// The profile the source type was mapped to.
+	ProfileId string `json:"profileId,omitempty"`
+
+	// The pool the source host was mapped to.
+	PoolId string `json:"poolId,omitempty"`
+
+	// The availability zone the source zone was mapped to.
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// The provisioned size of the entry.
+	// Default unit of Size is GB.
+	Size int64 `json:"size,omitempty"`
+
+	// Why the entry was skipped. Empty for adopted entries.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ImportPlanSpec is the response body of the admin import request, the diff
+// between the export and this deployment.
+type ImportPlanSpec struct {
+	// If the request was a dry run, the plan only describes what would be
+	// adopted.
+	DryRun bool `json:"dryRun"`
+
+	// The entries that were adopted, or would be in a dry run.
+	Created []*ImportItemSpec `json:"created"`
+
+	// The entries the importer cannot adopt, each with the reason.
+	Skipped []*ImportItemSpec `json:"skipped"`
+}
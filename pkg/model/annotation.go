@@ -0,0 +1,50 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// The kind of the resource an annotation is attached to.
+const (
+	AnnotationResourceFileShare = "fileshare"
+	AnnotationResourceVolume    = "volume"
+)
+
+// AnnotationSpec is a timestamped note an operator appends to a resource,
+// e.g. "extended per ticket 4521". Annotations are kept separate from the
+// machine generated metadata of the resource and are never interpreted by
+// the system.
+type AnnotationSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the annotation belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that wrote the annotation.
+	// +optional
+	UserId string `json:"userId,omitempty"`
+
+	// The kind of the resource the annotation is attached to, one of:
+	// "fileshare", "volume".
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The uuid of the resource the annotation is attached to.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// The note written by the operator.
+	Note string `json:"note,omitempty"`
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Roles an api key can be bound to.
+const (
+	ApiKeyRoleAdmin = "admin"
+	ApiKeyRoleUser  = "user"
+)
+
+// ApiKeySpec is a long lived credential for service accounts. A key is
+// bound to a tenant and a role, so automation can authenticate with the
+// X-Auth-ApiKey header instead of driving the keystone password flow.
+type ApiKeySpec struct {
+	*BaseModel
+
+	// The uuid of the project that the api key belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that created the api key.
+	UserId string `json:"userId,omitempty"`
+
+	// The name of the api key.
+	Name string `json:"name,omitempty"`
+
+	// The description of the api key.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The role the api key authenticates as.
+	// One of: "admin" or "user".
+	Role string `json:"role,omitempty"`
+
+	// HashedSecret is the sha256 of the key secret, the secret itself is
+	// never kept at rest.
+	HashedSecret string `json:"hashedSecret,omitempty"`
+
+	// Secret is only returned once, in the response of a create or a
+	// rotate, and is never stored.
+	Secret string `json:"secret,omitempty"`
+}
+
+// HashApiKeySecret returns the hex encoded sha256 of an api key secret,
+// which is the only form the secret is kept in at rest.
+func HashApiKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -14,7 +14,10 @@
 
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // VolumeAttachmentSpec is a description of volume attached resource.
 type VolumeAttachmentSpec struct {
@@ -33,10 +36,19 @@ type VolumeAttachmentSpec struct {
 	// The uuid of the volume which the attachment belongs to.
 	VolumeId string `json:"volumeId,omitempty"`
 
+	// The uuid of the host group the attachment was created through, empty
+	// for attachments created directly for one host.
+	// +optional
+	HostGroupId string `json:"hostGroupId,omitempty"`
+
 	// The status of the attachment.
 	// One of: "attaching", "attached", "error", etc.
 	Status string `json:"status,omitempty"`
 
+	// The reason the attachment last entered an error status.
+	// +readOnly
+	Fault *FaultSpec `json:"fault,omitempty"`
+
 	// The locaility when the volume was attached to a host.
 	Mountpoint string `json:"mountpoint,omitempty"`
 
@@ -46,10 +58,64 @@ type VolumeAttachmentSpec struct {
 	// The protocol
 	AccessProtocol string `json:"accessProtocol,omitempty"`
 
+	// The filesystem the host agent should create or expect on the volume,
+	// one of SupportedFsTypes.
+	// +optional
+	FsType string `json:"fsType,omitempty"`
+
+	// The options the host agent should mount the filesystem with, each
+	// one of SupportedMountOptions.
+	// +optional
+	MountOptions []string `json:"mountOptions,omitempty"`
+
 	// See details in `ConnectionInfo`
 	ConnectionInfo `json:"connectionInfo,omitempty"`
 }
 
+// The filesystems respectively mount options a host agent is expected to
+// handle. The values end up on a mount command line on the host, so
+// anything outside the whitelists is rejected.
+var (
+	SupportedFsTypes = []string{
+		"ext2", "ext3", "ext4", "xfs", "btrfs",
+	}
+	SupportedMountOptions = []string{
+		"defaults", "ro", "rw", "noatime", "nodiratime", "relatime",
+		"sync", "dirsync", "discard", "noexec", "nosuid", "nodev", "_netdev",
+	}
+)
+
+// ValidateMountAutomation checks the optional mount automation fields
+// against their whitelists. Empty fields are valid, the host agent then
+// falls back to its own defaults.
+func (attachment *VolumeAttachmentSpec) ValidateMountAutomation() error {
+	if attachment.FsType != "" {
+		supported := false
+		for _, fsType := range SupportedFsTypes {
+			if fsType == attachment.FsType {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("fsType %s is not one of %v", attachment.FsType, SupportedFsTypes)
+		}
+	}
+	for _, option := range attachment.MountOptions {
+		supported := false
+		for _, supportedOption := range SupportedMountOptions {
+			if supportedOption == option {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("mount option %s is not one of %v", option, SupportedMountOptions)
+		}
+	}
+	return nil
+}
+
 // ConnectionInfo is a structure for all properties of connection when
 // create a volume attachment.
 type ConnectionInfo struct {
@@ -0,0 +1,117 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+// BackupTargetTypes lists the object storage backends a backup target can
+// point at.
+var BackupTargetTypes = []string{"s3", "swift"}
+
+// BackupTargetSpec describes an object storage location that fileshare
+// snapshots can be backed up to.
+type BackupTargetSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the backup target belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The name of the backup target.
+	Name string `json:"name,omitempty"`
+
+	// The description of the backup target.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The type of the object storage backend.
+	// One of: "s3", "swift".
+	Type string `json:"type,omitempty"`
+
+	// The endpoint of the object storage backend.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// The region of the object storage backend.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// The bucket (or swift container) backups are written into.
+	Bucket string `json:"bucket,omitempty"`
+
+	// The access key used to authenticate against the backend.
+	AccessKey string `json:"accessKey,omitempty"`
+
+	// The secret key used to authenticate against the backend. It is
+	// accepted as request input but never serialized in responses.
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
+// FileShareSnapshotBackupSpec is the record tracking one backup of a
+// fileshare snapshot to an object storage backup target.
+type FileShareSnapshotBackupSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the backup belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that the backup belongs to.
+	// +optional
+	UserId string `json:"userId,omitempty"`
+
+	// The uuid of the snapshot that is backed up.
+	SnapshotId string `json:"snapshotId,omitempty"`
+
+	// The uuid of the fileshare which the snapshot belongs to.
+	FileShareId string `json:"fileshareId,omitempty"`
+
+	// The uuid of the backup target the snapshot is backed up to.
+	TargetId string `json:"targetId,omitempty"`
+
+	// Whether the backup only contains the changes since its base backup.
+	// Backends that cannot back up incrementally fall back to a full backup.
+	// +optional
+	Incremental bool `json:"incremental,omitempty"`
+
+	// The uuid of the backup this incremental backup is based on.
+	// +optional
+	BaseBackupId string `json:"baseBackupId,omitempty"`
+
+	// The most bandwidth the backup may use in MB/s, so bulk data
+	// movement does not saturate production links. Zero leaves it
+	// unlimited.
+	// +optional
+	MaxBandwidthMBps int64 `json:"maxBandwidthMBps,omitempty"`
+
+	// The location of the backup on the backup target, set by the dock
+	// once the backup completes.
+	// +readOnly
+	Location string `json:"location,omitempty"`
+
+	// The size of the backup, in GiB.
+	// +readOnly
+	Size int64 `json:"size,omitempty"`
+
+	// The status of the backup.
+	// One of: "backingUp", "available", "restoring", "error".
+	Status string `json:"status,omitempty"`
+
+	// The reason the backup last entered an error status.
+	// +readOnly
+	Fault *FaultSpec `json:"fault,omitempty"`
+
+	// The description of the backup.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,61 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// CapabilitySpec describes what the current deployment supports. It is
+// aggregated from the registered docks and pools plus the api server
+// configuration, so that clients can adapt their behavior without
+// trial-and-error requests.
+type CapabilitySpec struct {
+	// The storage types served by at least one registered pool.
+	// Subset of: "block", "file".
+	StorageTypes []string `json:"storageTypes"`
+
+	// The access protocols advertised by the registered pools.
+	Protocols []string `json:"protocols"`
+
+	// The availability zones covered by the registered pools.
+	AvailabilityZones []string `json:"availabilityZones"`
+
+	// True if at least one pool supports replication.
+	Replication bool `json:"replication"`
+
+	// True if at least one pool advertises IO connectivity limits, which is
+	// required for QoS enforcement.
+	QoS bool `json:"qos"`
+
+	// True if the deployment can take snapshots. Snapshotting is handled by
+	// every storage driver, so this is true whenever a dock is registered.
+	Snapshot bool `json:"snapshot"`
+
+	// True if volumes can be migrated between pools. This requires more than
+	// one registered pool of the same storage type.
+	Migration bool `json:"migration"`
+
+	// True if volumes can be retyped to another profile. Like migration this
+	// requires more than one registered pool of the same storage type.
+	Retype bool `json:"retype"`
+
+	// The authentication strategy of the api server, one of "noauth" and
+	// "keystone".
+	AuthStrategy string `json:"authStrategy,omitempty"`
+
+	// True if the api server is serving via https.
+	HTTPSEnabled bool `json:"httpsEnabled"`
+}
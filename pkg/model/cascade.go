@@ -0,0 +1,63 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+// The states a cascade task moves through.
+const (
+	CascadeTaskRunning = "running"
+	CascadeTaskDone    = "done"
+	CascadeTaskError   = "error"
+)
+
+// CascadeTaskSpec is the task record of one cascade-style deletion, like a
+// cascade host delete or a detach-all. The checkpoint is updated after every
+// finished batch, so a cleanup interrupted by an api server restart resumes
+// from the last checkpoint instead of starting over.
+type CascadeTaskSpec struct {
+	*BaseModel
+
+	// The uuid of the project the task belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The kind of the resource the cascade runs on, e.g. "host" or
+	// "volume".
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The uuid of the resource the cascade runs on.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// What the cascade does, e.g. "cascadeDelete", "detachAll" or
+	// "revokeAllAccess".
+	Action string `json:"action,omitempty"`
+
+	// One of the CascadeTask status constants.
+	Status string `json:"status,omitempty"`
+
+	// How many dependent items the cascade covers in total.
+	TotalItems int64 `json:"totalItems"`
+
+	// How many dependent items finished, including failed ones.
+	CompletedItems int64 `json:"completedItems"`
+
+	// How many dependent items failed; the cascade keeps going past them.
+	FailedItems int64 `json:"failedItems"`
+
+	// The id of the last item of the last finished batch. Items up to and
+	// including it are skipped when the task is resumed.
+	Checkpoint string `json:"checkpoint,omitempty"`
+}
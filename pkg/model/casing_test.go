@@ -0,0 +1,86 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// isCamelCase reports whether a json field name follows the camelCase
+// convention of the API: it starts with a lowercase letter and contains
+// neither underscores nor hyphens.
+func isCamelCase(name string) bool {
+	if name == "" {
+		return false
+	}
+	if !unicode.IsLower(rune(name[0])) {
+		return false
+	}
+	return !strings.ContainsAny(name, "_-")
+}
+
+// externalSchemaTypes mirror the wire format of a foreign API instead of
+// ours, so their field names follow that API. Currently these are the
+// OpenStack export entries the admin importer accepts.
+var externalSchemaTypes = map[string]bool{
+	"CinderVolumeSpec": true,
+	"ManilaShareSpec":  true,
+}
+
+// TestModelFieldCasing parses every file of the package and checks that
+// each json tag uses a camelCase field name. The API standardized on
+// camelCase; snake_case input is only tolerated through the casing
+// compatibility filter during the deprecation window, so a snake_case tag
+// sneaking into a model would silently fork the wire format again.
+func TestModelFieldCasing(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse the model package: %v", err)
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				if spec, ok := n.(*ast.TypeSpec); ok && externalSchemaTypes[spec.Name.Name] {
+					return false
+				}
+				field, ok := n.(*ast.Field)
+				if !ok || field.Tag == nil {
+					return true
+				}
+				tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+				jsonTag, ok := tag.Lookup("json")
+				if !ok {
+					return true
+				}
+				name := strings.Split(jsonTag, ",")[0]
+				if name == "" || name == "-" {
+					return true
+				}
+				if !isCamelCase(name) {
+					t.Errorf("%s: json field name %q is not camelCase",
+						fset.Position(field.Pos()), name)
+				}
+				return true
+			})
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// ServiceEntrySpec is one sub-API of the service catalog.
+type ServiceEntrySpec struct {
+	// The name of the sub-API, e.g. "block" or "file".
+	Name string `json:"name"`
+
+	// The path prefix the sub-API is served under. The ":tenantId"
+	// placeholder stands for the tenant of the caller.
+	Prefix string `json:"prefix"`
+}
+
+// ServiceCatalogSpec is the discovery document served at the root of the
+// api version. It lists the sub-APIs this deployment routes and the
+// optional features its configuration enables, so clients can
+// feature-detect before issuing requests. The name and status fields keep
+// the document compatible with the version record historically served on
+// this path.
+type ServiceCatalogSpec struct {
+	// The name of the api version serving the catalog.
+	Name string `json:"name"`
+
+	// The status of the api version, one of: "CURRENT", "SUPPORTED",
+	// "DEPRECATED".
+	Status string `json:"status"`
+
+	// The sub-APIs this deployment serves.
+	Services []*ServiceEntrySpec `json:"services"`
+
+	// The optional features enabled by the configuration of this
+	// deployment, keyed by feature name.
+	Features map[string]bool `json:"features"`
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+
+*/
+
+package model
+
+// The kind of a consistency discrepancy. A missing backend resource is a
+// database record whose backend can no longer be located; an unknown
+// backend resource is one the backend reports without a matching database
+// record.
+const (
+	ConsistencyMissingBackendResource = "missingBackendResource"
+	ConsistencyUnknownBackendResource = "unknownBackendResource"
+)
+
+// BackendInventorySpec is the listing of the resources a backend actually
+// hosts on one pool, as reported by the dock through the controller. The
+// consistency checker compares it against the database records of the pool.
+type BackendInventorySpec struct {
+	// The uuid of the pool the listing is about.
+	PoolId string `json:"poolId,omitempty"`
+
+	// The uuids of the volumes present on the backend.
+	VolumeIds []string `json:"volumeIds,omitempty"`
+
+	// The uuids of the fileshares present on the backend.
+	FileShareIds []string `json:"fileShareIds,omitempty"`
+}
+
+// ConsistencyDiscrepancySpec describes one mismatch between a database
+// record and the backend inventory found by the consistency checker.
+type ConsistencyDiscrepancySpec struct {
+	// The type of the resource the discrepancy is about, e.g. "volume" or
+	// "fileshare".
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The uuid of the resource.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// The uuid of the project that the resource belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the pool the resource claims to live on.
+	PoolId string `json:"poolId,omitempty"`
+
+	// The kind of the discrepancy. One of: "missingBackendResource" or
+	// "unknownBackendResource".
+	Kind string `json:"kind,omitempty"`
+
+	// A human readable explanation of what does not line up.
+	Detail string `json:"detail,omitempty"`
+}
+
+// ConsistencyReportSpec is the outcome of one run of the consistency
+// checker. It is kept in memory by the checker and served through the
+// admin consistency-report endpoint, never persisted.
+type ConsistencyReportSpec struct {
+	// The server time the run completed, as a time string in RFC8601
+	// format.
+	CompletedAt string `json:"completedAt,omitempty"`
+
+	// The number of database records the run inspected.
+	CheckedResources int64 `json:"checkedResources"`
+
+	// The discrepancies the run found, empty when everything lines up.
+	Discrepancies []*ConsistencyDiscrepancySpec `json:"discrepancies"`
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+
+*/
+
+package model
+
+// CostEstimateSpec is the showback cost estimate of a single resource,
+// derived from its size and the costPerGbMonth of its profile. It is
+// computed when served and never persisted.
+type CostEstimateSpec struct {
+	// The uuid of the resource the estimate is for.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// The type of the resource. One of: "volume" or "fileshare".
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The uuid of the profile the price was taken from.
+	ProfileId string `json:"profileId,omitempty"`
+
+	// The provisioned size of the resource.
+	// Default unit of Size is GB.
+	Size int64 `json:"size,omitempty"`
+
+	// The price of one GB for one month, from the profile.
+	CostPerGbMonth float64 `json:"costPerGbMonth"`
+
+	// The estimated cost of the resource for one month.
+	CostPerMonth float64 `json:"costPerMonth"`
+}
+
+// TenantCostEstimateSpec aggregates the cost estimates of every volume and
+// fileshare of a tenant, for showback reporting.
+type TenantCostEstimateSpec struct {
+	// The uuid of the tenant the report is for.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The estimated cost of all resources of the tenant for one month.
+	CostPerMonth float64 `json:"costPerMonth"`
+
+	// The per resource estimates the total is made of.
+	Resources []*CostEstimateSpec `json:"resources"`
+}
@@ -0,0 +1,33 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+// DefaultTagsSpec holds the tags an administrator configured for one
+// tenant, e.g. a cost center or an environment label. The tags are merged
+// into the metadata of every resource the tenant creates, so downstream
+// chargeback can rely on them being present. A value the user sets
+// explicitly wins over the configured default.
+type DefaultTagsSpec struct {
+	*BaseModel
+
+	// The uuid of the project the default tags apply to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The tags merged into the metadata of every created resource.
+	Tags map[string]string `json:"tags,omitempty"`
+}
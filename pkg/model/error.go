@@ -25,12 +25,16 @@ import (
 )
 
 const (
-	ErrorBadRequest     = http.StatusBadRequest
-	ErrorUnauthorized   = http.StatusUnauthorized
-	ErrorForbidden      = http.StatusForbidden
-	ErrorNotFound       = http.StatusNotFound
-	ErrorInternalServer = http.StatusInternalServerError
-	ErrorNotImplemented = http.StatusNotImplemented
+	ErrorBadRequest      = http.StatusBadRequest
+	ErrorUnauthorized    = http.StatusUnauthorized
+	ErrorForbidden       = http.StatusForbidden
+	ErrorNotFound        = http.StatusNotFound
+	ErrorConflict        = http.StatusConflict
+	ErrorLocked          = http.StatusLocked
+	ErrorEntityTooLarge  = http.StatusRequestEntityTooLarge
+	ErrorTooManyRequests = http.StatusTooManyRequests
+	ErrorInternalServer  = http.StatusInternalServerError
+	ErrorNotImplemented  = http.StatusNotImplemented
 )
 
 // ErrorSpec describes Detailed HTTP error response, which consists of a HTTP
@@ -60,6 +64,26 @@ func ErrorNotFoundStatus(message string) []byte {
 	return errorStatus(ErrorNotFound, message)
 }
 
+// ErrorConflictStatus
+func ErrorConflictStatus(message string) []byte {
+	return errorStatus(ErrorConflict, message)
+}
+
+// ErrorLockedStatus
+func ErrorLockedStatus(message string) []byte {
+	return errorStatus(ErrorLocked, message)
+}
+
+// ErrorEntityTooLargeStatus
+func ErrorEntityTooLargeStatus(message string) []byte {
+	return errorStatus(ErrorEntityTooLarge, message)
+}
+
+// ErrorTooManyRequestsStatus
+func ErrorTooManyRequestsStatus(message string) []byte {
+	return errorStatus(ErrorTooManyRequests, message)
+}
+
 // ErrorInternalServerStatus
 func ErrorInternalServerStatus(message string) []byte {
 	return errorStatus(ErrorInternalServer, message)
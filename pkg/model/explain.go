@@ -0,0 +1,64 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// PoolExplainSpec is the verdict of a placement dry run for one pool: the
+// name of the first filter that rejected it with the reason, or its
+// eligibility.
+type PoolExplainSpec struct {
+	// The uuid of the judged pool.
+	PoolId string `json:"poolId"`
+
+	// The name of the judged pool.
+	Name string `json:"name"`
+
+	// Whether the pool survived every filter.
+	Eligible bool `json:"eligible"`
+
+	// The name of the filter that rejected the pool, e.g. "storageType"
+	// or "capacity". Empty when the pool is eligible.
+	RejectedBy string `json:"rejectedBy,omitempty"`
+
+	// The human readable reason of the rejection.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ScheduleExplainSpec is the decision trace a create request with
+// ?explain=true returns instead of creating the resource: one verdict per
+// registered pool plus the pool the request would most likely land on.
+type ScheduleExplainSpec struct {
+	// The kind of resource the dry run was for, "volume" or "fileshare".
+	ResourceType string `json:"resourceType"`
+
+	// The profile the dry run matched the pools against.
+	ProfileId string `json:"profileId"`
+
+	// The availability zone the dry run placed in.
+	AvailabilityZone string `json:"availabilityZone"`
+
+	// The requested size in GB.
+	Size int64 `json:"size"`
+
+	// The per-pool verdicts, one entry per registered pool.
+	Pools []*PoolExplainSpec `json:"pools"`
+
+	// The id of the eligible pool with the most free capacity, the most
+	// likely placement. Empty when every pool was rejected.
+	CandidateId string `json:"candidateId,omitempty"`
+}
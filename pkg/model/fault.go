@@ -0,0 +1,74 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the fault record kept on resources that entered an
+error status.
+
+*/
+
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+// The longest message kept in a fault record, longer reasons are truncated.
+const faultMessageMaxLength = 256
+
+// FaultSpec records why a resource last entered an error status, so users
+// can read the reason from a GET response instead of asking an operator.
+// The record is kept until the next failure overwrites it, the current
+// status of the resource tells whether it still applies.
+type FaultSpec struct {
+	// The sanitized reason of the failure.
+	Message string `json:"message,omitempty"`
+
+	// The time the failure occurred.
+	OccurredAt string `json:"occurredAt,omitempty"`
+
+	// The number of dispatch attempts made before the failure was
+	// recorded. Zero when the failed operation was not retried.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// NewFault builds a fault record from a raw failure reason. The reason is
+// sanitized before it is stored: it is collapsed to a single line and
+// truncated, so internal stack traces do not end up in user responses.
+func NewFault(reason string) *FaultSpec {
+	message := strings.Join(strings.Fields(reason), " ")
+	if len(message) > faultMessageMaxLength {
+		message = message[:faultMessageMaxLength]
+	}
+	occurredAt := time.Now().Format(constants.TimeFormat)
+	if config.CONF.OsdsApiServer.TimestampFormat == constants.TimestampFormatRFC3339 {
+		occurredAt = time.Now().UTC().Format(constants.TimeFormatRFC3339)
+	}
+	return &FaultSpec{
+		Message:    message,
+		OccurredAt: occurredAt,
+	}
+}
+
+// NewFaultWithAttempts builds a fault record that also carries how many
+// dispatch attempts were made before the failure was recorded.
+func NewFaultWithAttempts(reason string, attempts int) *FaultSpec {
+	fault := NewFault(reason)
+	fault.Attempts = attempts
+	return fault
+}
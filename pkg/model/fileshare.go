@@ -17,6 +17,33 @@ This module implements the common data structure.
 */
 package model
 
+import "fmt"
+
+// The type of access an acl grants. Backends advertise the types they
+// support through the supportedAclTypes capability of their pools; a pool
+// that advertises none is assumed to support ip based access only.
+const (
+	FileShareAclTypeIp   = "ip"
+	FileShareAclTypeUser = "user"
+)
+
+var FileShareAclTypes = []string{FileShareAclTypeIp, FileShareAclTypeUser}
+
+// The NFS security flavor an acl requires. The kerberos flavors add
+// authentication (krb5), integrity protection (krb5i) and privacy (krb5p)
+// on top of the traditional sys flavor. Backends advertise the flavors
+// they support through the supportedSecurityFlavors capability of their
+// pools; a pool that advertises none is assumed to support sys only.
+const (
+	FileShareAclSecuritySys   = "sys"
+	FileShareAclSecurityKrb5  = "krb5"
+	FileShareAclSecurityKrb5i = "krb5i"
+	FileShareAclSecurityKrb5p = "krb5p"
+)
+
+var FileShareAclSecurityFlavors = []string{FileShareAclSecuritySys,
+	FileShareAclSecurityKrb5, FileShareAclSecurityKrb5i, FileShareAclSecurityKrb5p}
+
 type FileShareAclSpec struct {
 	*BaseModel
 
@@ -26,6 +53,11 @@ type FileShareAclSpec struct {
 	// The uuid of the fileshare.
 	FileShareId string `json:"fileshareId,omitempty"`
 
+	// The uuid of the host group the acl was created through, empty for
+	// acls created directly.
+	// +optional
+	HostGroupId string `json:"hostGroupId,omitempty"`
+
 	// The type of access. Ex: IP based.
 	Type string `json:"type,omitempty"`
 
@@ -35,6 +67,12 @@ type FileShareAclSpec struct {
 	// accessTo of the fileshare.
 	AccessTo string `json:"accessTo,omitempty"`
 
+	// The NFS security flavor the export enforces for this acl, one of:
+	// "sys", "krb5", "krb5i", "krb5p". Defaults to "sys". The kerberos
+	// flavors authenticate principals, so they require user based access.
+	// +optional
+	SecurityFlavor string `json:"securityFlavor,omitempty"`
+
 	// The description of the fileshare acl.
 	Description string `json:"description,omitempty"`
 
@@ -42,6 +80,16 @@ type FileShareAclSpec struct {
 	// One of: "available", "error" etc.
 	Status string `json:"status,omitempty"`
 
+	// The reason the fileshare acl last entered an error status.
+	// +readOnly
+	Fault *FaultSpec `json:"fault,omitempty"`
+
+	// The server time after which the acl will be revoked automatically,
+	// for temporary access grants. It's represented as a time string in
+	// RFC8601 format. If empty, the acl never expires.
+	// +optional
+	ExpiresAt string `json:"expiresAt,omitempty"`
+
 	// The uuid of the profile which the fileshare belongs to.
 	ProfileId string `json:"profileId,omitempty"`
 
@@ -49,6 +97,78 @@ type FileShareAclSpec struct {
 	// and backend storage resouce description are clear.
 	// +optional
 	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// The application status of the acl on each export location of the
+	// fileshare. An acl on a fileshare exported on several paths may be
+	// applied on some of them and fail on others; the per-location entries
+	// record which, updated by the controller as the locations report back.
+	// +readOnly
+	ExportStatuses []*AclExportStatusSpec `json:"exportStatuses,omitempty"`
+}
+
+// AclExportStatusSpec records whether an acl has been applied on one
+// export location of its fileshare.
+type AclExportStatusSpec struct {
+	// The export location the entry is about.
+	ExportLocation string `json:"exportLocation,omitempty"`
+
+	// One of: "pending", "applied", "failed".
+	Status string `json:"status,omitempty"`
+
+	// The reason the application on this location failed, empty otherwise.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ExportOptionsSpec holds the NFS export settings of a fileshare that CSI
+// consumers need for uid/gid mapping. They are passed to the backend driver
+// at create time instead of being shoehorned into metadata.
+type ExportOptionsSpec struct {
+	// Map requests from the root user to the anonymous uid/gid.
+	RootSquash bool `json:"rootSquash,omitempty"`
+
+	// The uid squashed requests are mapped to.
+	// +optional
+	AnonUid int64 `json:"anonUid,omitempty"`
+
+	// The gid squashed requests are mapped to.
+	// +optional
+	AnonGid int64 `json:"anonGid,omitempty"`
+
+	// Require requests to originate from a privileged source port.
+	Secure bool `json:"secure,omitempty"`
+}
+
+// The largest uid/gid an export option may map to.
+const maxAnonId = 4294967294
+
+// Validate checks that the anonymous uid and gid lie in the valid id range.
+func (eo *ExportOptionsSpec) Validate() error {
+	if eo.AnonUid < 0 || eo.AnonUid > maxAnonId {
+		return fmt.Errorf("invalid anonUid %d, expected a value between 0 and %d", eo.AnonUid, int64(maxAnonId))
+	}
+	if eo.AnonGid < 0 || eo.AnonGid > maxAnonId {
+		return fmt.Errorf("invalid anonGid %d, expected a value between 0 and %d", eo.AnonGid, int64(maxAnonId))
+	}
+	return nil
+}
+
+// FileShareLockSpec marks a fileshare as protected against accidental
+// modification. While the lock is held, update and delete requests
+// are rejected with 423 unless they come from the lock owner or an
+// administrator.
+type FileShareLockSpec struct {
+	// The uuid of the user holding the lock.
+	Owner string `json:"owner,omitempty"`
+
+	// Why the fileshare is locked, shown to users whose requests are
+	// rejected.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// The server time the lock was taken, as a time string in RFC8601
+	// format.
+	// +readOnly
+	LockedAt string `json:"lockedAt,omitempty"`
 }
 
 // FileShareSpec is a schema for fileshare API. Fileshare will be created on some backend
@@ -84,6 +204,10 @@ type FileShareSpec struct {
 	// One of: "available", "error" etc.
 	Status string `json:"status,omitempty"`
 
+	// The reason the fileshare last entered an error status.
+	// +readOnly
+	Fault *FaultSpec `json:"fault,omitempty"`
+
 	// The uuid of the pool which the fileshare belongs to.
 	// +readOnly
 	PoolId string `json:"poolId,omitempty"`
@@ -100,6 +224,36 @@ type FileShareSpec struct {
 	// ExportLocations of the fileshare.
 	ExportLocations []string `json:"exportLocations,omitempty"`
 
+	// The export options of the fileshare. When not set at create time the
+	// defaults suggested by the profile apply.
+	// +optional
+	ExportOptions *ExportOptionsSpec `json:"exportOptions,omitempty"`
+
+	// The share network the fileshare is exported on. When not set at
+	// create time the profile or the default network decides; empty means
+	// the driver picked the network.
+	// +optional
+	ShareNetworkId string `json:"shareNetworkId,omitempty"`
+
+	// The lock protecting the fileshare against accidental modification,
+	// taken and released through the lock endpoints. Empty when the
+	// fileshare is not locked.
+	// +optional
+	Lock *FileShareLockSpec `json:"lock,omitempty"`
+
+	// Whether the fileshare is discoverable by other tenants through the
+	// public listing, in a read-only form without export locations or
+	// options. Toggled through the publish and unpublish endpoints, which
+	// are controlled by the fileshare:publish policy rule.
+	// +optional
+	IsPublic bool `json:"isPublic,omitempty"`
+
+	// The server time after which the fileshare will be deleted automatically.
+	// It's represented as a time string in RFC8601 format. If empty, the
+	// fileshare never expires.
+	// +optional
+	ExpiresAt string `json:"expiresAt,omitempty"`
+
 	// Metadata should be kept until the scemantics between opensds fileshare
 	// and backend storage resouce description are clear.
 	// +optional
@@ -138,11 +292,107 @@ type FileShareSnapshotSpec struct {
 	// One of: "available", "error", etc.
 	Status string `json:"status,omitempty"`
 
+	// The reason the snapshot last entered an error status.
+	// +readOnly
+	Fault *FaultSpec `json:"fault,omitempty"`
+
 	// The uuid of the profile which the fileshare belongs to.
 	ProfileId string `json:"profileId,omitempty"`
 
+	// The server time after which the snapshot will be deleted automatically.
+	// It's represented as a time string in RFC8601 format. If empty, the
+	// snapshot never expires.
+	// +optional
+	ExpiresAt string `json:"expiresAt,omitempty"`
+
 	// Metadata should be kept until the scemantics between opensds fileshare
 	// and backend storage resouce description are clear.
 	// +optional
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
+
+// FileShareSnapshotCopySpec is the record tracking one copy of a fileshare
+// snapshot to a remote SODA endpoint.
+type FileShareSnapshotCopySpec struct {
+	*BaseModel
+
+	// The uuid of the project that the copy belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that the copy belongs to.
+	// +optional
+	UserId string `json:"userId,omitempty"`
+
+	// The uuid of the snapshot that is copied.
+	SnapshotId string `json:"snapshotId,omitempty"`
+
+	// The uuid of the fileshare which the snapshot belongs to.
+	FileShareId string `json:"fileshareId,omitempty"`
+
+	// The remote SODA endpoint the snapshot is copied to.
+	TargetEndpoint string `json:"targetEndpoint,omitempty"`
+
+	// The tenant on the remote endpoint that owns the copy.
+	// +optional
+	TargetTenantId string `json:"targetTenantId,omitempty"`
+
+	// The token used to authenticate against the remote endpoint. It is only
+	// accepted as request input and never stored in the tracking record.
+	// +optional
+	AuthToken string `json:"authToken,omitempty"`
+
+	// The most bandwidth the copy may use in MB/s, so bulk data movement
+	// does not saturate production links. Zero leaves it unlimited.
+	// +optional
+	MaxBandwidthMBps int64 `json:"maxBandwidthMBps,omitempty"`
+
+	// The status of the copy.
+	// One of: "copying", "available", "error".
+	Status string `json:"status,omitempty"`
+
+	// The reason the copy last entered an error status.
+	// +readOnly
+	Fault *FaultSpec `json:"fault,omitempty"`
+
+	// The description of the copy.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// FileShareLineageNode is one node in the lineage tree of a fileshare. A
+// node refers either to a fileshare or to one of its snapshots, and its
+// children are the resources that have been created from it.
+type FileShareLineageNode struct {
+	// The uuid of the fileshare or fileshare snapshot the node refers to.
+	Id string `json:"id,omitempty"`
+
+	// The name of the fileshare or fileshare snapshot.
+	Name string `json:"name,omitempty"`
+
+	// The kind of the resource, one of: "share", "snapshot".
+	Kind string `json:"kind,omitempty"`
+
+	// The time the resource was created, which is the timestamp of the
+	// edge between this node and its parent.
+	CreatedAt string `json:"createdAt,omitempty"`
+
+	// The status of the fileshare or fileshare snapshot.
+	Status string `json:"status,omitempty"`
+
+	// The resources created from this node, such as the snapshots taken
+	// from a fileshare or the fileshares created from a snapshot.
+	Children []*FileShareLineageNode `json:"children,omitempty"`
+}
+
+// The kind of the resource a FileShareLineageNode refers to.
+const (
+	FileShareLineageKindShare    = "share"
+	FileShareLineageKindSnapshot = "snapshot"
+)
+
+// Protocols a fileshare can be exported over. A fileshare may request
+// several of them at once, for example both NFS and CIFS.
+const (
+	FileShareProtocolNFS  = "NFS"
+	FileShareProtocolCIFS = "CIFS"
+)
@@ -89,3 +89,61 @@ func (m HostSpec) MarshalJSON() ([]byte, error) {
 	resp.Password = ""
 	return json.Marshal(resp)
 }
+
+// The outcome a host import row can end in.
+const (
+	HostImportRowCreated = "created"
+	HostImportRowError   = "error"
+)
+
+// HostImportRowSpec is the outcome of one row of a bulk host import.
+type HostImportRowSpec struct {
+	// The position of the row in the submitted list, starting at 1.
+	Row int `json:"row"`
+
+	// The hostName the row carried.
+	HostName string `json:"hostName,omitempty"`
+
+	// One of the HostImportRow constants.
+	Status string `json:"status"`
+
+	// The uuid the host was created with, set for created rows.
+	HostId string `json:"hostId,omitempty"`
+
+	// What was wrong with the row, set for error rows.
+	Error string `json:"error,omitempty"`
+}
+
+// HostImportResultSpec is the per-row report of a bulk host import.
+type HostImportResultSpec struct {
+	// The number of hosts created respectively rows rejected.
+	Created int `json:"created"`
+	Failed  int `json:"failed"`
+
+	Rows []HostImportRowSpec `json:"rows"`
+}
+
+// HostGroupSpec names a set of registered hosts, so a share can be granted
+// or a volume attached to all of them at once. Acls and attachments created
+// through a group carry its id and are kept in sync with the membership by
+// the api server when hosts are added to or removed from the group.
+type HostGroupSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the host group belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that the host group belongs to.
+	// +optional
+	UserId string `json:"userId,omitempty"`
+
+	// The name of the host group.
+	Name string `json:"name,omitempty"`
+
+	// The description of the host group.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The uuids of the registered hosts that are members of the group.
+	HostIds []string `json:"hostIds"`
+}
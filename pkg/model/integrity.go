@@ -0,0 +1,34 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// CorruptRecordSpec describes a database record that failed to parse. List
+// calls skip such records instead of failing, and report them here so an
+// administrator can repair or remove the key.
+type CorruptRecordSpec struct {
+	// The database key of the corrupt record.
+	Key string `json:"key,omitempty"`
+
+	// The parse error the record failed with.
+	Error string `json:"error,omitempty"`
+
+	// The server time the corruption was last seen, as a time string in
+	// RFC8601 format.
+	DetectedAt string `json:"detectedAt,omitempty"`
+}
@@ -0,0 +1,59 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+// The states an admin job moves through.
+const (
+	JobRunning    = "running"
+	JobSucceeded  = "succeeded"
+	JobFailed     = "failed"
+	JobCancelling = "cancelling"
+	JobCancelled  = "cancelled"
+)
+
+// JobSpec is the record of one administrative background operation, like a
+// tombstone purge. A job is submitted with its type and parameters, runs in
+// the background with bounded concurrency and keeps its outcome in the
+// record, so finished jobs form a browsable history until the configured
+// retention removes them.
+type JobSpec struct {
+	*BaseModel
+
+	// The uuid of the project the job was submitted in.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// What the job does, one of the registered job types.
+	Type string `json:"type,omitempty"`
+
+	// The parameters of the job, interpreted by its type.
+	Params map[string]string `json:"params,omitempty"`
+
+	// One of the Job status constants.
+	Status string `json:"status,omitempty"`
+
+	// Why the job failed, empty otherwise.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// What the job did, filled by its type when it finishes, e.g. how many
+	// records a purge removed.
+	Result map[string]string `json:"result,omitempty"`
+
+	// When the job started and finished running.
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+}
@@ -0,0 +1,80 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+import "fmt"
+
+// MetadataUpdateSpec is the request body of the metadata merge endpoint.
+// Unlike a PUT of the resource, only the listed keys are touched, so
+// concurrent writers that own different keys do not overwrite each other.
+type MetadataUpdateSpec struct {
+	// The entries to merge into the metadata of the resource.
+	Metadata map[string]string `json:"metadata"`
+
+	// Keys of Metadata that may only be written when they do not exist
+	// yet; a key that is already present fails the whole update.
+	// +optional
+	IfAbsent []string `json:"ifAbsent,omitempty"`
+
+	// The value each listed key must currently hold for the update to go
+	// through, for read-modify-write of single keys.
+	// +optional
+	IfValue map[string]string `json:"ifValue,omitempty"`
+}
+
+// MetadataConditionError reports the first condition of a metadata update
+// that does not hold, so the caller can answer with a conflict instead of
+// an internal error.
+type MetadataConditionError struct {
+	Key    string
+	Reason string
+}
+
+func (e *MetadataConditionError) Error() string {
+	return fmt.Sprintf("metadata condition on key %q failed: %s", e.Key, e.Reason)
+}
+
+// Apply checks the conditions of the update against the current metadata
+// and returns the merged result. The current map is not modified.
+func (m *MetadataUpdateSpec) Apply(current map[string]string) (map[string]string, error) {
+	for _, key := range m.IfAbsent {
+		if value, ok := current[key]; ok {
+			return nil, &MetadataConditionError{
+				Key:    key,
+				Reason: fmt.Sprintf("key already exists with value %q", value),
+			}
+		}
+	}
+	for key, expected := range m.IfValue {
+		if value, ok := current[key]; !ok || value != expected {
+			return nil, &MetadataConditionError{
+				Key:    key,
+				Reason: fmt.Sprintf("expected current value %q, got %q", expected, value),
+			}
+		}
+	}
+	merged := map[string]string{}
+	for key, value := range current {
+		merged[key] = value
+	}
+	for key, value := range m.Metadata {
+		merged[key] = value
+	}
+	return merged, nil
+}
@@ -40,6 +40,16 @@ type GetMetricSpec struct {
 	EndTime string `json:"endTime,omitempty"`
 }
 
+// MetricsBatchSpec is a batch of telemetry samples pushed by a dock
+// through the metrics ingestion endpoint.
+type MetricsBatchSpec struct {
+	// The uuid of the registered dock that collected the samples.
+	DockId string `json:"dockId,omitempty"`
+
+	// The pushed samples.
+	Samples []*MetricSpec `json:"samples,omitempty"`
+}
+
 type MetricSpec struct {
 	/* Following are the fields used to form name and labels associated with a Metric, same as Prometheus guage name and labels
 	Example: node_disk_read_bytes_total{device="dm-0",instance="121.244.95.60:12419",job="prometheus"}
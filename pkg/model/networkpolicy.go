@@ -0,0 +1,41 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+// NetworkPolicySpec restricts the addresses from which the tokens of a
+// tenant may call mutating endpoints. A tenant has at most one policy.
+type NetworkPolicySpec struct {
+	*BaseModel
+
+	// The uuid of the project that the network policy belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The description of the network policy.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Mutating requests are only accepted from these CIDRs. An empty list
+	// allows every address that is not denied.
+	// +optional
+	AllowedCidrs []string `json:"allowedCidrs,omitempty"`
+
+	// Mutating requests from these CIDRs are rejected. The deny list wins
+	// over the allow list.
+	// +optional
+	DeniedCidrs []string `json:"deniedCidrs,omitempty"`
+}
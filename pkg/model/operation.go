@@ -0,0 +1,43 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// PendingOperationSpec marks a mutating operation that is currently running
+// against a resource. At most one marker exists per resource, so concurrent
+// mutating requests are rejected with a conflict until the running operation
+// completes and releases the marker.
+type PendingOperationSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the resource belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The type of the resource the operation runs against, e.g. "volume".
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The uuid of the resource the operation runs against.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// The name of the running operation, e.g. "delete".
+	Operation string `json:"operation,omitempty"`
+
+	// Token fences the release of the marker, only the caller that acquired
+	// the marker can release it.
+	Token string `json:"token,omitempty"`
+}
@@ -24,6 +24,26 @@ const (
 	ReplicationTypeArray = "ArrayBased"
 )
 
+// The latency class of a pool, a coarse performance tier reported by the
+// dock.
+const (
+	PoolLatencyClassLow    = "low"
+	PoolLatencyClassMedium = "medium"
+	PoolLatencyClassHigh   = "high"
+)
+
+var PoolLatencyClasses = []string{PoolLatencyClassLow, PoolLatencyClassMedium, PoolLatencyClassHigh}
+
+// The media type a pool is backed by.
+const (
+	PoolMediaTypeNVMe   = "nvme"
+	PoolMediaTypeSSD    = "ssd"
+	PoolMediaTypeHDD    = "hdd"
+	PoolMediaTypeHybrid = "hybrid"
+)
+
+var PoolMediaTypes = []string{PoolMediaTypeNVMe, PoolMediaTypeSSD, PoolMediaTypeHDD, PoolMediaTypeHybrid}
+
 // A pool is discoveried and updated by a dock service. Each pool can be regarded
 // as a physical storage pool or a virtual storage pool. It's a logical and
 // atomic pool and can be abstracted from any storage platform.
@@ -59,6 +79,17 @@ type StoragePoolSpec struct {
       // Default unit of ConsumedCapacity is GB.
       ConsumedCapacity int64 `json:"consumedCapacity,omitempty"`
 
+	// The capacity kept in reserve and never handed out to new resources.
+	// Default unit of ReservedCapacity is GB.
+	// +optional
+	ReservedCapacity int64 `json:"reservedCapacity,omitempty"`
+
+	// The maximum ratio of provisioned capacity to usable capacity for
+	// thin provisioned pools. Values below or equal to 1 disable over
+	// subscription.
+	// +optional
+	MaxOverSubscriptionRatio float64 `json:"maxOverSubscriptionRatio,omitempty"`
+
 	// MultiAttach
 	// If true, this volume can attach to more than one instance. Default will be multiattach:False
 	MultiAttach bool `json:"multiAttach"`
@@ -72,11 +103,46 @@ type StoragePoolSpec struct {
 	// +optional
 	Extras StoragePoolExtraSpec `json:"extras,omitempty"`
 
+	// The latency class of the pool, reported by its dock. One of: "low",
+	// "medium" or "high". Profiles can target performance tiers by
+	// filtering the pools list on it.
+	// +optional
+	LatencyClass string `json:"latencyClass,omitempty"`
+
+	// The media type backing the pool, reported by its dock. One of:
+	// "nvme", "ssd", "hdd" or "hybrid".
+	// +optional
+	MediaType string `json:"mediaType,omitempty"`
+
+	// The IOPS the pool sustains per GB of usable capacity, reported by
+	// its dock. Zero means the dock does not report it.
+	// +optional
+	IopsDensity float64 `json:"iopsDensity,omitempty"`
+
 	//Replication type host based or array based
 	ReplicationType string `json:"replicationType,omitempty"`
 
 	//Replication driver name
 	ReplicationDriverName string `json:"replicationDriverName,omitempty"`
+
+	// Whether the pool record has not been refreshed by its dock for longer
+	// than the configured staleness threshold. Computed when the pool is
+	// served, never persisted.
+	Stale bool `json:"stale,omitempty"`
+
+	// Whether the pool is in maintenance mode and unschedulable: in-flight
+	// resources keep running but new create requests skip the pool, so the
+	// backing controller can be upgraded. Toggled by an admin through the
+	// pool action endpoint.
+	// +optional
+	Maintenance bool `json:"maintenance,omitempty"`
+}
+
+// StoragePoolActionSpec is the body of a pool action request.
+type StoragePoolActionSpec struct {
+	// The desired maintenance state of the pool. The pointer tells an
+	// explicit false apart from an absent flag.
+	Maintenance *bool `json:"maintenance,omitempty"`
 }
 
 type StoragePoolExtraSpec struct {
@@ -87,6 +153,17 @@ type StoragePoolExtraSpec struct {
 	// DataProtection represents some suggested data protection capabilities.
 	DataProtection DataProtectionLoS `json:"dataProtection,omitempty" yaml:"dataProtection,omitempty"`
 
+	// SupportedAclTypes lists the fileshare acl access types the backend of
+	// the pool can enforce, e.g. "ip" and "user". A pool that advertises
+	// none is assumed to support ip based access only.
+	// +optional
+	SupportedAclTypes []string `json:"supportedAclTypes,omitempty" yaml:"supportedAclTypes,omitempty"`
+
+	// SupportedSecurityFlavors lists the NFS security flavors the backend
+	// of the pool can enforce on fileshare acls. Pools that advertise none
+	// are assumed to support the sys flavor only.
+	SupportedSecurityFlavors []string `json:"supportedSecurityFlavors,omitempty" yaml:"supportedSecurityFlavors,omitempty"`
+
 	// Besides those basic suggested pool properties above, vendors can configure
 	// some advanced features (diskType, IOPS, throughout, latency, etc)
 	// themselves, all these properties can be exposed to controller scheduler
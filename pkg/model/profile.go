@@ -27,6 +27,15 @@ import (
 	"github.com/golang/glog"
 )
 
+// Profile visibility. A public profile is visible to every tenant, a
+// restricted one only to the tenants listed in its allowed tenants.
+const (
+	ProfileVisibilityPublic     = "public"
+	ProfileVisibilityRestricted = "restricted"
+)
+
+var ProfileVisibilities = []string{ProfileVisibilityPublic, ProfileVisibilityRestricted}
+
 // An OpenSDS profile is identified by a unique name and ID. With additional
 // profile properties, each profile contains a set of tags of storage
 // capabilities which are desirable features for a class of applications.
@@ -69,6 +78,51 @@ type ProfileSpec struct {
 	// including diskType, latency, deduplication, compression and so forth.
 	// +optional
 	CustomProperties CustomPropertiesSpec `json:"customProperties,omitempty"`
+
+	// BaseProfileId points at a profile this one inherits from. The
+	// effective custom properties are the ones of the base overlaid with
+	// the profile's own, the profile winning on shared keys; they are
+	// reported by GET ?resolved=true while the stored record keeps only
+	// the local entries.
+	// +optional
+	BaseProfileId string `json:"baseProfileId,omitempty"`
+
+	// Visibility controls which tenants can see and use the profile. One of:
+	// public (the default) or restricted.
+	// +optional
+	Visibility string `json:"visibility,omitempty"`
+
+	// AllowedTenants lists the tenants a restricted profile is visible to.
+	// It is ignored when the profile is public.
+	// +optional
+	AllowedTenants []string `json:"allowedTenants,omitempty"`
+
+	// AvailabilityZoneFallbacks lists alternate zones, in order of
+	// preference, a resource may be placed in when the requested zone has
+	// no capacity. An empty list keeps the default behavior of the
+	// deployment, see the az_fallback_zones config key.
+	// +optional
+	AvailabilityZoneFallbacks []string `json:"availabilityZoneFallbacks,omitempty"`
+
+	// CostPerGbMonth is the showback price of one GB provisioned with the
+	// profile for one month, in the currency of the deployment. Zero means
+	// no pricing is configured and cost estimates report zero.
+	// +optional
+	CostPerGbMonth float64 `json:"costPerGbMonth,omitempty"`
+}
+
+// VisibleTo reports whether the profile can be seen and used by the given
+// tenant. Profiles without an explicit visibility are public.
+func (p *ProfileSpec) VisibleTo(tenantId string) bool {
+	if p.Visibility != ProfileVisibilityRestricted {
+		return true
+	}
+	for _, t := range p.AllowedTenants {
+		if t == tenantId {
+			return true
+		}
+	}
+	return false
 }
 
 func NewProfileFromJson(s string) *ProfileSpec {
@@ -93,6 +147,10 @@ type ProvisioningPropertiesSpec struct {
 	DataStorage DataStorageLoS `json:"dataStorage,omitempty"`
 	// IOConnectivity represents some suggested IO connectivity capabilities.
 	IOConnectivity IOConnectivityLoS `json:"ioConnectivity,omitempty"`
+	// ExportOptions represents the suggested export options of fileshares
+	// created with the profile, applied when the create request sets none.
+	// +optional
+	ExportOptions *ExportOptionsSpec `json:"exportOptions,omitempty"`
 }
 
 func (pps ProvisioningPropertiesSpec) IsEmpty() bool {
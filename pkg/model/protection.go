@@ -0,0 +1,73 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// ProtectionResourceSpec describes the protection state of one volume or
+// fileshare in the protection report.
+type ProtectionResourceSpec struct {
+	// The uuid of the volume or fileshare.
+	Id string `json:"id,omitempty"`
+
+	// The name of the volume or fileshare.
+	Name string `json:"name,omitempty"`
+
+	// Either "volume" or "fileshare".
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The most recent snapshot of the resource, empty when it has none.
+	LastSnapshotId string `json:"lastSnapshotId,omitempty"`
+
+	// When the most recent snapshot was taken.
+	LastSnapshotAt string `json:"lastSnapshotAt,omitempty"`
+
+	// Whether the most recent snapshot is younger than the snapshot age
+	// limit that applies to the resource.
+	SnapshotFresh bool `json:"snapshotFresh"`
+
+	// The enabled replication the volume takes part in, empty when there
+	// is none. Fileshares never carry one.
+	ReplicationId string `json:"replicationId,omitempty"`
+
+	// Protected resources have a fresh snapshot or an enabled replication.
+	Protected bool `json:"protected"`
+}
+
+// ProtectionReportSpec is the response body of the protection report, the
+// input of SLA and compliance reviews.
+type ProtectionReportSpec struct {
+	// The tenant the report covers.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// When the report was generated.
+	GeneratedAt string `json:"generatedAt,omitempty"`
+
+	// The default snapshot age limit of the report. A profile may tighten
+	// or loosen it for its resources through the snapshotMaxAge custom
+	// property.
+	SnapshotMaxAge string `json:"snapshotMaxAge,omitempty"`
+
+	// How many resources the report covers and how many of them are
+	// protected.
+	TotalResources       int `json:"totalResources"`
+	ProtectedResources   int `json:"protectedResources"`
+	UnprotectedResources int `json:"unprotectedResources"`
+
+	// The protection state of every resource, the unprotected ones first.
+	Resources []*ProtectionResourceSpec `json:"resources"`
+}
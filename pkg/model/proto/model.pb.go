@@ -2868,10 +2868,12 @@ type CreateFileShareAclOpts struct {
 	// The Name
 	Name string `protobuf:"bytes,10,opt,name=Name,json=name,proto3" json:"Name,omitempty"`
 	// The metadata of the file share, optional.
-	Metadata             map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Metadata map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The NFS security flavor the export enforces for this acl, optional.
+	SecurityFlavor       string   `protobuf:"bytes,12,opt,name=securityFlavor,proto3" json:"securityFlavor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CreateFileShareAclOpts) Reset()         { *m = CreateFileShareAclOpts{} }
@@ -2976,6 +2978,13 @@ func (m *CreateFileShareAclOpts) GetMetadata() map[string]string {
 	return nil
 }
 
+func (m *CreateFileShareAclOpts) GetSecurityFlavor() string {
+	if m != nil {
+		return m.SecurityFlavor
+	}
+	return ""
+}
+
 // CreateFileShareOpts is a structure which indicates all required properties for creating a file share.
 type CreateFileShareOpts struct {
 	// The uuid of the file share, optional when creating.
@@ -3005,7 +3014,14 @@ type CreateFileShareOpts struct {
 	// The snapshots
 	SnapshotId string `protobuf:"bytes,13,opt,name=snapshotId,proto3" json:"snapshotId,omitempty"`
 	// The snapshots
-	SnapshotName         string   `protobuf:"bytes,14,opt,name=snapshotName,proto3" json:"snapshotName,omitempty"`
+	SnapshotName string `protobuf:"bytes,14,opt,name=snapshotName,proto3" json:"snapshotName,omitempty"`
+	// The protocols the fileshare should be exported over, the driver
+	// creates one export location per protocol.
+	Protocols []string `protobuf:"bytes,15,rep,name=protocols,proto3" json:"protocols,omitempty"`
+	// The serialized export options (root squash, anonymous uid/gid)
+	ExportOptions string `protobuf:"bytes,16,opt,name=exportOptions,proto3" json:"exportOptions,omitempty"`
+	// The serialized share network (subnet, vlan) the file share is exported on
+	ShareNetwork         string   `protobuf:"bytes,17,opt,name=shareNetwork,proto3" json:"shareNetwork,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3134,6 +3150,27 @@ func (m *CreateFileShareOpts) GetSnapshotName() string {
 	return ""
 }
 
+func (m *CreateFileShareOpts) GetProtocols() []string {
+	if m != nil {
+		return m.Protocols
+	}
+	return nil
+}
+
+func (m *CreateFileShareOpts) GetExportOptions() string {
+	if m != nil {
+		return m.ExportOptions
+	}
+	return ""
+}
+
+func (m *CreateFileShareOpts) GetShareNetwork() string {
+	if m != nil {
+		return m.ShareNetwork
+	}
+	return ""
+}
+
 // DeleteFileShareOpts is a structure which indicates all required properties
 // for deleting a file share.
 type DeleteFileShareOpts struct {
@@ -3407,52 +3444,645 @@ func (m *DeleteFileShareSnapshotOpts) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeleteFileShareSnapshotOpts proto.InternalMessageInfo
 
-func (m *DeleteFileShareSnapshotOpts) GetId() string {
+func (m *DeleteFileShareSnapshotOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteFileShareSnapshotOpts) GetFileshareId() string {
+	if m != nil {
+		return m.FileshareId
+	}
+	return ""
+}
+
+func (m *DeleteFileShareSnapshotOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *DeleteFileShareSnapshotOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+func (m *DeleteFileShareSnapshotOpts) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *DeleteFileShareSnapshotOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// Generic response, it return:
+// 1. Return result with message when create/update resource successfully.
+// 2. Return result without message when delete resource successfully.
+// 3. Return Error with error code and message when operate unsuccessfully.
+// CopyFileShareSnapshotOpts is a structure which indicates all the properties
+// for copying a file share snapshot to a remote endpoint.
+type CopyFileShareSnapshotOpts struct {
+	// The uuid of the file share snapshot, required.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The uuid of the file share that snapshot belongs to, required.
+	FileshareId string `protobuf:"bytes,2,opt,name=fileshareId,proto3" json:"fileshareId,omitempty"`
+	// The remote endpoint the snapshot is copied to, required.
+	TargetEndpoint string `protobuf:"bytes,3,opt,name=targetEndpoint,proto3" json:"targetEndpoint,omitempty"`
+	// The tenant on the remote endpoint that owns the copy, optional.
+	TargetTenantId string `protobuf:"bytes,4,opt,name=targetTenantId,proto3" json:"targetTenantId,omitempty"`
+	// The token used to authenticate against the remote endpoint, required.
+	AuthToken string `protobuf:"bytes,5,opt,name=authToken,proto3" json:"authToken,omitempty"`
+	// The uuid of the record tracking this copy, required.
+	TrackingId string `protobuf:"bytes,6,opt,name=trackingId,proto3" json:"trackingId,omitempty"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,7,opt,name=driverName,proto3" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,8,opt,name=context,proto3" json:"context,omitempty"`
+	// The Serialized profile
+	Profile string `protobuf:"bytes,9,opt,name=profile,proto3" json:"profile,omitempty"`
+	// The metadata of the fileshare snapshot, optional.
+	Metadata map[string]string `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The most bandwidth the copy may use in MB/s, 0 leaves it unlimited.
+	MaxBandwidthMBps     int64    `protobuf:"varint,11,opt,name=maxBandwidthMBps,proto3" json:"maxBandwidthMBps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyFileShareSnapshotOpts) Reset()         { *m = CopyFileShareSnapshotOpts{} }
+func (m *CopyFileShareSnapshotOpts) String() string { return proto.CompactTextString(m) }
+func (*CopyFileShareSnapshotOpts) ProtoMessage()    {}
+
+func (m *CopyFileShareSnapshotOpts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyFileShareSnapshotOpts.Unmarshal(m, b)
+}
+func (m *CopyFileShareSnapshotOpts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyFileShareSnapshotOpts.Marshal(b, m, deterministic)
+}
+func (m *CopyFileShareSnapshotOpts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyFileShareSnapshotOpts.Merge(m, src)
+}
+func (m *CopyFileShareSnapshotOpts) XXX_Size() int {
+	return xxx_messageInfo_CopyFileShareSnapshotOpts.Size(m)
+}
+func (m *CopyFileShareSnapshotOpts) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyFileShareSnapshotOpts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyFileShareSnapshotOpts proto.InternalMessageInfo
+
+func (m *CopyFileShareSnapshotOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CopyFileShareSnapshotOpts) GetFileshareId() string {
+	if m != nil {
+		return m.FileshareId
+	}
+	return ""
+}
+
+func (m *CopyFileShareSnapshotOpts) GetTargetEndpoint() string {
+	if m != nil {
+		return m.TargetEndpoint
+	}
+	return ""
+}
+
+func (m *CopyFileShareSnapshotOpts) GetTargetTenantId() string {
+	if m != nil {
+		return m.TargetTenantId
+	}
+	return ""
+}
+
+func (m *CopyFileShareSnapshotOpts) GetAuthToken() string {
+	if m != nil {
+		return m.AuthToken
+	}
+	return ""
+}
+
+func (m *CopyFileShareSnapshotOpts) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+func (m *CopyFileShareSnapshotOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *CopyFileShareSnapshotOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+func (m *CopyFileShareSnapshotOpts) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *CopyFileShareSnapshotOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *CopyFileShareSnapshotOpts) GetMaxBandwidthMBps() int64 {
+	if m != nil {
+		return m.MaxBandwidthMBps
+	}
+	return 0
+}
+
+// BackupFileShareSnapshotOpts is a structure which indicates all the properties
+// for backing up a file share snapshot to an object storage backup target.
+type BackupFileShareSnapshotOpts struct {
+	// The uuid of the file share snapshot, required.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The uuid of the file share that snapshot belongs to, required.
+	FileshareId string `protobuf:"bytes,2,opt,name=fileshareId,proto3" json:"fileshareId,omitempty"`
+	// The type of the object storage backend, "s3" or "swift", required.
+	TargetType string `protobuf:"bytes,3,opt,name=targetType,proto3" json:"targetType,omitempty"`
+	// The endpoint of the object storage backend, required.
+	TargetEndpoint string `protobuf:"bytes,4,opt,name=targetEndpoint,proto3" json:"targetEndpoint,omitempty"`
+	// The region of the object storage backend, optional.
+	TargetRegion string `protobuf:"bytes,5,opt,name=targetRegion,proto3" json:"targetRegion,omitempty"`
+	// The bucket (or swift container) the backup is written into, required.
+	TargetBucket string `protobuf:"bytes,6,opt,name=targetBucket,proto3" json:"targetBucket,omitempty"`
+	// The access key used to authenticate against the backend, required.
+	AccessKey string `protobuf:"bytes,7,opt,name=accessKey,proto3" json:"accessKey,omitempty"`
+	// The secret key used to authenticate against the backend, required.
+	SecretKey string `protobuf:"bytes,8,opt,name=secretKey,proto3" json:"secretKey,omitempty"`
+	// Whether only the changes since the base backup are backed up, optional.
+	Incremental bool `protobuf:"varint,9,opt,name=incremental,proto3" json:"incremental,omitempty"`
+	// The uuid of the backup the incremental backup is based on, optional.
+	BaseBackupId string `protobuf:"bytes,10,opt,name=baseBackupId,proto3" json:"baseBackupId,omitempty"`
+	// The uuid of the record tracking this backup, required.
+	TrackingId string `protobuf:"bytes,11,opt,name=trackingId,proto3" json:"trackingId,omitempty"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,12,opt,name=driverName,proto3" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,13,opt,name=context,proto3" json:"context,omitempty"`
+	// The Serialized profile
+	Profile string `protobuf:"bytes,14,opt,name=profile,proto3" json:"profile,omitempty"`
+	// The metadata of the fileshare snapshot, optional.
+	Metadata map[string]string `protobuf:"bytes,15,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The most bandwidth the backup may use in MB/s, 0 leaves it unlimited.
+	MaxBandwidthMBps     int64    `protobuf:"varint,16,opt,name=maxBandwidthMBps,proto3" json:"maxBandwidthMBps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupFileShareSnapshotOpts) Reset()         { *m = BackupFileShareSnapshotOpts{} }
+func (m *BackupFileShareSnapshotOpts) String() string { return proto.CompactTextString(m) }
+func (*BackupFileShareSnapshotOpts) ProtoMessage()    {}
+
+func (m *BackupFileShareSnapshotOpts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupFileShareSnapshotOpts.Unmarshal(m, b)
+}
+func (m *BackupFileShareSnapshotOpts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupFileShareSnapshotOpts.Marshal(b, m, deterministic)
+}
+func (m *BackupFileShareSnapshotOpts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupFileShareSnapshotOpts.Merge(m, src)
+}
+func (m *BackupFileShareSnapshotOpts) XXX_Size() int {
+	return xxx_messageInfo_BackupFileShareSnapshotOpts.Size(m)
+}
+func (m *BackupFileShareSnapshotOpts) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupFileShareSnapshotOpts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupFileShareSnapshotOpts proto.InternalMessageInfo
+
+func (m *BackupFileShareSnapshotOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetFileshareId() string {
+	if m != nil {
+		return m.FileshareId
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetTargetType() string {
+	if m != nil {
+		return m.TargetType
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetTargetEndpoint() string {
+	if m != nil {
+		return m.TargetEndpoint
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetTargetRegion() string {
+	if m != nil {
+		return m.TargetRegion
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetTargetBucket() string {
+	if m != nil {
+		return m.TargetBucket
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetAccessKey() string {
+	if m != nil {
+		return m.AccessKey
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetSecretKey() string {
+	if m != nil {
+		return m.SecretKey
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetIncremental() bool {
+	if m != nil {
+		return m.Incremental
+	}
+	return false
+}
+
+func (m *BackupFileShareSnapshotOpts) GetBaseBackupId() string {
+	if m != nil {
+		return m.BaseBackupId
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetTrackingId() string {
+	if m != nil {
+		return m.TrackingId
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *BackupFileShareSnapshotOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *BackupFileShareSnapshotOpts) GetMaxBandwidthMBps() int64 {
+	if m != nil {
+		return m.MaxBandwidthMBps
+	}
+	return 0
+}
+
+// RestoreFileShareSnapshotBackupOpts is a structure which indicates all the
+// properties for restoring a file share snapshot backup into a file share.
+type RestoreFileShareSnapshotBackupOpts struct {
+	// The uuid of the backup that is restored, required.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The uuid of the snapshot the backup was taken from, required.
+	SnapshotId string `protobuf:"bytes,2,opt,name=snapshotId,proto3" json:"snapshotId,omitempty"`
+	// The uuid of the file share the backup is restored into, required.
+	FileshareId string `protobuf:"bytes,3,opt,name=fileshareId,proto3" json:"fileshareId,omitempty"`
+	// The type of the object storage backend, "s3" or "swift", required.
+	TargetType string `protobuf:"bytes,4,opt,name=targetType,proto3" json:"targetType,omitempty"`
+	// The endpoint of the object storage backend, required.
+	TargetEndpoint string `protobuf:"bytes,5,opt,name=targetEndpoint,proto3" json:"targetEndpoint,omitempty"`
+	// The region of the object storage backend, optional.
+	TargetRegion string `protobuf:"bytes,6,opt,name=targetRegion,proto3" json:"targetRegion,omitempty"`
+	// The bucket (or swift container) the backup lives in, required.
+	TargetBucket string `protobuf:"bytes,7,opt,name=targetBucket,proto3" json:"targetBucket,omitempty"`
+	// The access key used to authenticate against the backend, required.
+	AccessKey string `protobuf:"bytes,8,opt,name=accessKey,proto3" json:"accessKey,omitempty"`
+	// The secret key used to authenticate against the backend, required.
+	SecretKey string `protobuf:"bytes,9,opt,name=secretKey,proto3" json:"secretKey,omitempty"`
+	// The location of the backup on the backup target, required.
+	Location string `protobuf:"bytes,10,opt,name=location,proto3" json:"location,omitempty"`
+	// The storage driver type.
+	DriverName string `protobuf:"bytes,11,opt,name=driverName,proto3" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,12,opt,name=context,proto3" json:"context,omitempty"`
+	// The Serialized profile
+	Profile string `protobuf:"bytes,13,opt,name=profile,proto3" json:"profile,omitempty"`
+	// The metadata of the fileshare, optional.
+	Metadata             map[string]string `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) Reset()         { *m = RestoreFileShareSnapshotBackupOpts{} }
+func (m *RestoreFileShareSnapshotBackupOpts) String() string { return proto.CompactTextString(m) }
+func (*RestoreFileShareSnapshotBackupOpts) ProtoMessage()    {}
+
+func (m *RestoreFileShareSnapshotBackupOpts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreFileShareSnapshotBackupOpts.Unmarshal(m, b)
+}
+func (m *RestoreFileShareSnapshotBackupOpts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreFileShareSnapshotBackupOpts.Marshal(b, m, deterministic)
+}
+func (m *RestoreFileShareSnapshotBackupOpts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreFileShareSnapshotBackupOpts.Merge(m, src)
+}
+func (m *RestoreFileShareSnapshotBackupOpts) XXX_Size() int {
+	return xxx_messageInfo_RestoreFileShareSnapshotBackupOpts.Size(m)
+}
+func (m *RestoreFileShareSnapshotBackupOpts) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreFileShareSnapshotBackupOpts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreFileShareSnapshotBackupOpts proto.InternalMessageInfo
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetFileshareId() string {
+	if m != nil {
+		return m.FileshareId
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetTargetType() string {
+	if m != nil {
+		return m.TargetType
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetTargetEndpoint() string {
+	if m != nil {
+		return m.TargetEndpoint
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetTargetRegion() string {
+	if m != nil {
+		return m.TargetRegion
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetTargetBucket() string {
+	if m != nil {
+		return m.TargetBucket
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetAccessKey() string {
+	if m != nil {
+		return m.AccessKey
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetSecretKey() string {
+	if m != nil {
+		return m.SecretKey
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *RestoreFileShareSnapshotBackupOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// GetReplicationStatusOpts is a structure which indicates all the properties
+// for querying the synchronization status of a replication.
+type GetReplicationStatusOpts struct {
+	// The uuid of the replication, required.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The replication driver type.
+	DriverName string `protobuf:"bytes,2,opt,name=driverName,proto3" json:"driverName,omitempty"`
+	// The Context
+	Context string `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
+	// The metadata of the replication, optional.
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetReplicationStatusOpts) Reset()         { *m = GetReplicationStatusOpts{} }
+func (m *GetReplicationStatusOpts) String() string { return proto.CompactTextString(m) }
+func (*GetReplicationStatusOpts) ProtoMessage()    {}
+
+func (m *GetReplicationStatusOpts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetReplicationStatusOpts.Unmarshal(m, b)
+}
+func (m *GetReplicationStatusOpts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetReplicationStatusOpts.Marshal(b, m, deterministic)
+}
+func (m *GetReplicationStatusOpts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReplicationStatusOpts.Merge(m, src)
+}
+func (m *GetReplicationStatusOpts) XXX_Size() int {
+	return xxx_messageInfo_GetReplicationStatusOpts.Size(m)
+}
+func (m *GetReplicationStatusOpts) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReplicationStatusOpts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetReplicationStatusOpts proto.InternalMessageInfo
+
+func (m *GetReplicationStatusOpts) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetReplicationStatusOpts) GetDriverName() string {
+	if m != nil {
+		return m.DriverName
+	}
+	return ""
+}
+
+func (m *GetReplicationStatusOpts) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+func (m *GetReplicationStatusOpts) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// ListBackendResourcesOpts is a structure which indicates all the
+// properties for listing the resources a backend actually hosts on one
+// pool, used by the consistency checker to find backend resources without
+// a database record.
+type ListBackendResourcesOpts struct {
+	// The uuid of the pool whose backend should be listed, required.
+	PoolId string `protobuf:"bytes,1,opt,name=poolId,proto3" json:"poolId,omitempty"`
+	// The name of the dock the pool belongs to.
+	DockId string `protobuf:"bytes,2,opt,name=dockId,proto3" json:"dockId,omitempty"`
+	// The storage driver type of the pool.
+	DriverName string `protobuf:"bytes,3,opt,name=driverName,proto3" json:"driverName,omitempty"`
+	// The Context
+	Context              string   `protobuf:"bytes,4,opt,name=context,proto3" json:"context,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBackendResourcesOpts) Reset()         { *m = ListBackendResourcesOpts{} }
+func (m *ListBackendResourcesOpts) String() string { return proto.CompactTextString(m) }
+func (*ListBackendResourcesOpts) ProtoMessage()    {}
+
+func (m *ListBackendResourcesOpts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBackendResourcesOpts.Unmarshal(m, b)
+}
+func (m *ListBackendResourcesOpts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBackendResourcesOpts.Marshal(b, m, deterministic)
+}
+func (m *ListBackendResourcesOpts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBackendResourcesOpts.Merge(m, src)
+}
+func (m *ListBackendResourcesOpts) XXX_Size() int {
+	return xxx_messageInfo_ListBackendResourcesOpts.Size(m)
+}
+func (m *ListBackendResourcesOpts) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBackendResourcesOpts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBackendResourcesOpts proto.InternalMessageInfo
+
+func (m *ListBackendResourcesOpts) GetPoolId() string {
 	if m != nil {
-		return m.Id
+		return m.PoolId
 	}
 	return ""
 }
 
-func (m *DeleteFileShareSnapshotOpts) GetFileshareId() string {
+func (m *ListBackendResourcesOpts) GetDockId() string {
 	if m != nil {
-		return m.FileshareId
+		return m.DockId
 	}
 	return ""
 }
 
-func (m *DeleteFileShareSnapshotOpts) GetDriverName() string {
+func (m *ListBackendResourcesOpts) GetDriverName() string {
 	if m != nil {
 		return m.DriverName
 	}
 	return ""
 }
 
-func (m *DeleteFileShareSnapshotOpts) GetContext() string {
+func (m *ListBackendResourcesOpts) GetContext() string {
 	if m != nil {
 		return m.Context
 	}
 	return ""
 }
 
-func (m *DeleteFileShareSnapshotOpts) GetProfile() string {
-	if m != nil {
-		return m.Profile
-	}
-	return ""
-}
-
-func (m *DeleteFileShareSnapshotOpts) GetMetadata() map[string]string {
-	if m != nil {
-		return m.Metadata
-	}
-	return nil
-}
-
-// Generic response, it return:
-// 1. Return result with message when create/update resource successfully.
-// 2. Return result without message when delete resource successfully.
-// 3. Return Error with error code and message when operate unsuccessfully.
 type GenericResponse struct {
 	// Types that are valid to be assigned to Reply:
 	//	*GenericResponse_Result_
@@ -3859,6 +4489,15 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "proto.CreateFileShareSnapshotOpts.MetadataEntry")
 	proto.RegisterType((*DeleteFileShareSnapshotOpts)(nil), "proto.DeleteFileShareSnapshotOpts")
 	proto.RegisterMapType((map[string]string)(nil), "proto.DeleteFileShareSnapshotOpts.MetadataEntry")
+	proto.RegisterType((*CopyFileShareSnapshotOpts)(nil), "proto.CopyFileShareSnapshotOpts")
+	proto.RegisterMapType((map[string]string)(nil), "proto.CopyFileShareSnapshotOpts.MetadataEntry")
+	proto.RegisterType((*BackupFileShareSnapshotOpts)(nil), "proto.BackupFileShareSnapshotOpts")
+	proto.RegisterMapType((map[string]string)(nil), "proto.BackupFileShareSnapshotOpts.MetadataEntry")
+	proto.RegisterType((*RestoreFileShareSnapshotBackupOpts)(nil), "proto.RestoreFileShareSnapshotBackupOpts")
+	proto.RegisterMapType((map[string]string)(nil), "proto.RestoreFileShareSnapshotBackupOpts.MetadataEntry")
+	proto.RegisterType((*GetReplicationStatusOpts)(nil), "proto.GetReplicationStatusOpts")
+	proto.RegisterMapType((map[string]string)(nil), "proto.GetReplicationStatusOpts.MetadataEntry")
+	proto.RegisterType((*ListBackendResourcesOpts)(nil), "proto.ListBackendResourcesOpts")
 	proto.RegisterType((*GenericResponse)(nil), "proto.GenericResponse")
 	proto.RegisterType((*GenericResponse_Result)(nil), "proto.GenericResponse.Result")
 	proto.RegisterType((*GenericResponse_Error)(nil), "proto.GenericResponse.Error")
@@ -4065,6 +4704,8 @@ type ControllerClient interface {
 	DisableReplication(ctx context.Context, in *DisableReplicationOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Failover a replication
 	FailoverReplication(ctx context.Context, in *FailoverReplicationOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Get the synchronization status of a replication
+	GetReplicationStatus(ctx context.Context, in *GetReplicationStatusOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Create a volume group
 	CreateVolumeGroup(ctx context.Context, in *CreateVolumeGroupOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Update volume group
@@ -4077,6 +4718,8 @@ type ControllerClient interface {
 	CollectMetrics(ctx context.Context, in *CollectMetricsOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Get 3rd party re-direct URLs for telemetry
 	GetUrls(ctx context.Context, in *NoParams, opts ...grpc.CallOption) (*GenericResponse, error)
+	// List the resources a backend actually hosts on one pool
+	ListBackendResources(ctx context.Context, in *ListBackendResourcesOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 }
 
 type controllerClient struct {
@@ -4195,6 +4838,15 @@ func (c *controllerClient) FailoverReplication(ctx context.Context, in *Failover
 	return out, nil
 }
 
+func (c *controllerClient) GetReplicationStatus(ctx context.Context, in *GetReplicationStatusOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/proto.Controller/GetReplicationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controllerClient) CreateVolumeGroup(ctx context.Context, in *CreateVolumeGroupOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
 	out := new(GenericResponse)
 	err := c.cc.Invoke(ctx, "/proto.Controller/CreateVolumeGroup", in, out, opts...)
@@ -4249,6 +4901,15 @@ func (c *controllerClient) GetUrls(ctx context.Context, in *NoParams, opts ...gr
 	return out, nil
 }
 
+func (c *controllerClient) ListBackendResources(ctx context.Context, in *ListBackendResourcesOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/proto.Controller/ListBackendResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ControllerServer is the server API for Controller service.
 type ControllerServer interface {
 	// Create a volume
@@ -4275,6 +4936,8 @@ type ControllerServer interface {
 	DisableReplication(context.Context, *DisableReplicationOpts) (*GenericResponse, error)
 	// Failover a replication
 	FailoverReplication(context.Context, *FailoverReplicationOpts) (*GenericResponse, error)
+	// Get the synchronization status of a replication
+	GetReplicationStatus(context.Context, *GetReplicationStatusOpts) (*GenericResponse, error)
 	// Create a volume group
 	CreateVolumeGroup(context.Context, *CreateVolumeGroupOpts) (*GenericResponse, error)
 	// Update volume group
@@ -4287,6 +4950,8 @@ type ControllerServer interface {
 	CollectMetrics(context.Context, *CollectMetricsOpts) (*GenericResponse, error)
 	// Get 3rd party re-direct URLs for telemetry
 	GetUrls(context.Context, *NoParams) (*GenericResponse, error)
+	// List the resources a backend actually hosts on one pool
+	ListBackendResources(context.Context, *ListBackendResourcesOpts) (*GenericResponse, error)
 }
 
 // UnimplementedControllerServer can be embedded to have forward compatible implementations.
@@ -4329,6 +4994,9 @@ func (*UnimplementedControllerServer) DisableReplication(ctx context.Context, re
 func (*UnimplementedControllerServer) FailoverReplication(ctx context.Context, req *FailoverReplicationOpts) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FailoverReplication not implemented")
 }
+func (*UnimplementedControllerServer) GetReplicationStatus(ctx context.Context, req *GetReplicationStatusOpts) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicationStatus not implemented")
+}
 func (*UnimplementedControllerServer) CreateVolumeGroup(ctx context.Context, req *CreateVolumeGroupOpts) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateVolumeGroup not implemented")
 }
@@ -4347,6 +5015,9 @@ func (*UnimplementedControllerServer) CollectMetrics(ctx context.Context, req *C
 func (*UnimplementedControllerServer) GetUrls(ctx context.Context, req *NoParams) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUrls not implemented")
 }
+func (*UnimplementedControllerServer) ListBackendResources(ctx context.Context, req *ListBackendResourcesOpts) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBackendResources not implemented")
+}
 
 func RegisterControllerServer(s *grpc.Server, srv ControllerServer) {
 	s.RegisterService(&_Controller_serviceDesc, srv)
@@ -4568,6 +5239,24 @@ func _Controller_FailoverReplication_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Controller_GetReplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplicationStatusOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).GetReplicationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Controller/GetReplicationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).GetReplicationStatus(ctx, req.(*GetReplicationStatusOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Controller_CreateVolumeGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateVolumeGroupOpts)
 	if err := dec(in); err != nil {
@@ -4676,6 +5365,24 @@ func _Controller_GetUrls_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Controller_ListBackendResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackendResourcesOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).ListBackendResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Controller/ListBackendResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).ListBackendResources(ctx, req.(*ListBackendResourcesOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Controller_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Controller",
 	HandlerType: (*ControllerServer)(nil),
@@ -4728,6 +5435,10 @@ var _Controller_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FailoverReplication",
 			Handler:    _Controller_FailoverReplication_Handler,
 		},
+		{
+			MethodName: "GetReplicationStatus",
+			Handler:    _Controller_GetReplicationStatus_Handler,
+		},
 		{
 			MethodName: "CreateVolumeGroup",
 			Handler:    _Controller_CreateVolumeGroup_Handler,
@@ -4752,6 +5463,10 @@ var _Controller_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetUrls",
 			Handler:    _Controller_GetUrls_Handler,
 		},
+		{
+			MethodName: "ListBackendResources",
+			Handler:    _Controller_ListBackendResources_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/model.proto",
@@ -4785,6 +5500,8 @@ type ProvisionDockClient interface {
 	DisableReplication(ctx context.Context, in *DisableReplicationOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Failover a replication
 	FailoverReplication(ctx context.Context, in *FailoverReplicationOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Get the synchronization status of a replication
+	GetReplicationStatus(ctx context.Context, in *GetReplicationStatusOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Create a volume group
 	CreateVolumeGroup(ctx context.Context, in *CreateVolumeGroupOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Update volume group
@@ -4797,6 +5514,8 @@ type ProvisionDockClient interface {
 	GetMetrics(ctx context.Context, in *GetMetricsOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Get 3rd party re-direct URLs for telemetry
 	GetUrls(ctx context.Context, in *NoParams, opts ...grpc.CallOption) (*GenericResponse, error)
+	// List the resources a backend actually hosts on one pool
+	ListBackendResources(ctx context.Context, in *ListBackendResourcesOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 }
 
 type provisionDockClient struct {
@@ -4915,6 +5634,15 @@ func (c *provisionDockClient) FailoverReplication(ctx context.Context, in *Failo
 	return out, nil
 }
 
+func (c *provisionDockClient) GetReplicationStatus(ctx context.Context, in *GetReplicationStatusOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/proto.ProvisionDock/GetReplicationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *provisionDockClient) CreateVolumeGroup(ctx context.Context, in *CreateVolumeGroupOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
 	out := new(GenericResponse)
 	err := c.cc.Invoke(ctx, "/proto.ProvisionDock/CreateVolumeGroup", in, out, opts...)
@@ -4969,6 +5697,15 @@ func (c *provisionDockClient) GetUrls(ctx context.Context, in *NoParams, opts ..
 	return out, nil
 }
 
+func (c *provisionDockClient) ListBackendResources(ctx context.Context, in *ListBackendResourcesOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/proto.ProvisionDock/ListBackendResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProvisionDockServer is the server API for ProvisionDock service.
 type ProvisionDockServer interface {
 	// Create a volume
@@ -4995,6 +5732,8 @@ type ProvisionDockServer interface {
 	DisableReplication(context.Context, *DisableReplicationOpts) (*GenericResponse, error)
 	// Failover a replication
 	FailoverReplication(context.Context, *FailoverReplicationOpts) (*GenericResponse, error)
+	// Get the synchronization status of a replication
+	GetReplicationStatus(context.Context, *GetReplicationStatusOpts) (*GenericResponse, error)
 	// Create a volume group
 	CreateVolumeGroup(context.Context, *CreateVolumeGroupOpts) (*GenericResponse, error)
 	// Update volume group
@@ -5007,6 +5746,8 @@ type ProvisionDockServer interface {
 	GetMetrics(context.Context, *GetMetricsOpts) (*GenericResponse, error)
 	// Get 3rd party re-direct URLs for telemetry
 	GetUrls(context.Context, *NoParams) (*GenericResponse, error)
+	// List the resources a backend actually hosts on one pool
+	ListBackendResources(context.Context, *ListBackendResourcesOpts) (*GenericResponse, error)
 }
 
 // UnimplementedProvisionDockServer can be embedded to have forward compatible implementations.
@@ -5049,6 +5790,9 @@ func (*UnimplementedProvisionDockServer) DisableReplication(ctx context.Context,
 func (*UnimplementedProvisionDockServer) FailoverReplication(ctx context.Context, req *FailoverReplicationOpts) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FailoverReplication not implemented")
 }
+func (*UnimplementedProvisionDockServer) GetReplicationStatus(ctx context.Context, req *GetReplicationStatusOpts) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicationStatus not implemented")
+}
 func (*UnimplementedProvisionDockServer) CreateVolumeGroup(ctx context.Context, req *CreateVolumeGroupOpts) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateVolumeGroup not implemented")
 }
@@ -5067,6 +5811,9 @@ func (*UnimplementedProvisionDockServer) GetMetrics(ctx context.Context, req *Ge
 func (*UnimplementedProvisionDockServer) GetUrls(ctx context.Context, req *NoParams) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUrls not implemented")
 }
+func (*UnimplementedProvisionDockServer) ListBackendResources(ctx context.Context, req *ListBackendResourcesOpts) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBackendResources not implemented")
+}
 
 func RegisterProvisionDockServer(s *grpc.Server, srv ProvisionDockServer) {
 	s.RegisterService(&_ProvisionDock_serviceDesc, srv)
@@ -5288,6 +6035,24 @@ func _ProvisionDock_FailoverReplication_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProvisionDock_GetReplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplicationStatusOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).GetReplicationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/GetReplicationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).GetReplicationStatus(ctx, req.(*GetReplicationStatusOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProvisionDock_CreateVolumeGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateVolumeGroupOpts)
 	if err := dec(in); err != nil {
@@ -5396,6 +6161,24 @@ func _ProvisionDock_GetUrls_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProvisionDock_ListBackendResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackendResourcesOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionDockServer).ListBackendResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ProvisionDock/ListBackendResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionDockServer).ListBackendResources(ctx, req.(*ListBackendResourcesOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ProvisionDock_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.ProvisionDock",
 	HandlerType: (*ProvisionDockServer)(nil),
@@ -5448,6 +6231,10 @@ var _ProvisionDock_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FailoverReplication",
 			Handler:    _ProvisionDock_FailoverReplication_Handler,
 		},
+		{
+			MethodName: "GetReplicationStatus",
+			Handler:    _ProvisionDock_GetReplicationStatus_Handler,
+		},
 		{
 			MethodName: "CreateVolumeGroup",
 			Handler:    _ProvisionDock_CreateVolumeGroup_Handler,
@@ -5472,6 +6259,10 @@ var _ProvisionDock_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetUrls",
 			Handler:    _ProvisionDock_GetUrls_Handler,
 		},
+		{
+			MethodName: "ListBackendResources",
+			Handler:    _ProvisionDock_ListBackendResources_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/model.proto",
@@ -5489,6 +6280,12 @@ type FileShareControllerClient interface {
 	CreateFileShareSnapshot(ctx context.Context, in *CreateFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	//Delete a file share snapshot
 	DeleteFileShareSnapshot(ctx context.Context, in *DeleteFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Copy a file share snapshot to a remote endpoint
+	CopyFileShareSnapshot(ctx context.Context, in *CopyFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Back up a file share snapshot to an object storage backup target
+	BackupFileShareSnapshot(ctx context.Context, in *BackupFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Restore a file share snapshot backup into a file share
+	RestoreFileShareSnapshotBackup(ctx context.Context, in *RestoreFileShareSnapshotBackupOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Create a file share Acl
 	CreateFileShareAcl(ctx context.Context, in *CreateFileShareAclOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Delete a file share Acl
@@ -5539,6 +6336,33 @@ func (c *fileShareControllerClient) DeleteFileShareSnapshot(ctx context.Context,
 	return out, nil
 }
 
+func (c *fileShareControllerClient) CopyFileShareSnapshot(ctx context.Context, in *CopyFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/proto.FileShareController/CopyFileShareSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileShareControllerClient) BackupFileShareSnapshot(ctx context.Context, in *BackupFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/proto.FileShareController/BackupFileShareSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileShareControllerClient) RestoreFileShareSnapshotBackup(ctx context.Context, in *RestoreFileShareSnapshotBackupOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/proto.FileShareController/RestoreFileShareSnapshotBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fileShareControllerClient) CreateFileShareAcl(ctx context.Context, in *CreateFileShareAclOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
 	out := new(GenericResponse)
 	err := c.cc.Invoke(ctx, "/proto.FileShareController/CreateFileShareAcl", in, out, opts...)
@@ -5567,6 +6391,12 @@ type FileShareControllerServer interface {
 	CreateFileShareSnapshot(context.Context, *CreateFileShareSnapshotOpts) (*GenericResponse, error)
 	//Delete a file share snapshot
 	DeleteFileShareSnapshot(context.Context, *DeleteFileShareSnapshotOpts) (*GenericResponse, error)
+	// Copy a file share snapshot to a remote endpoint
+	CopyFileShareSnapshot(context.Context, *CopyFileShareSnapshotOpts) (*GenericResponse, error)
+	// Back up a file share snapshot to an object storage backup target
+	BackupFileShareSnapshot(context.Context, *BackupFileShareSnapshotOpts) (*GenericResponse, error)
+	// Restore a file share snapshot backup into a file share
+	RestoreFileShareSnapshotBackup(context.Context, *RestoreFileShareSnapshotBackupOpts) (*GenericResponse, error)
 	// Create a file share Acl
 	CreateFileShareAcl(context.Context, *CreateFileShareAclOpts) (*GenericResponse, error)
 	// Delete a file share Acl
@@ -5589,6 +6419,15 @@ func (*UnimplementedFileShareControllerServer) CreateFileShareSnapshot(ctx conte
 func (*UnimplementedFileShareControllerServer) DeleteFileShareSnapshot(ctx context.Context, req *DeleteFileShareSnapshotOpts) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteFileShareSnapshot not implemented")
 }
+func (*UnimplementedFileShareControllerServer) CopyFileShareSnapshot(ctx context.Context, req *CopyFileShareSnapshotOpts) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyFileShareSnapshot not implemented")
+}
+func (*UnimplementedFileShareControllerServer) BackupFileShareSnapshot(ctx context.Context, req *BackupFileShareSnapshotOpts) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupFileShareSnapshot not implemented")
+}
+func (*UnimplementedFileShareControllerServer) RestoreFileShareSnapshotBackup(ctx context.Context, req *RestoreFileShareSnapshotBackupOpts) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreFileShareSnapshotBackup not implemented")
+}
 func (*UnimplementedFileShareControllerServer) CreateFileShareAcl(ctx context.Context, req *CreateFileShareAclOpts) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateFileShareAcl not implemented")
 }
@@ -5708,6 +6547,60 @@ func _FileShareController_DeleteFileShareAcl_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileShareController_CopyFileShareSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyFileShareSnapshotOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileShareControllerServer).CopyFileShareSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.FileShareController/CopyFileShareSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileShareControllerServer).CopyFileShareSnapshot(ctx, req.(*CopyFileShareSnapshotOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileShareController_BackupFileShareSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupFileShareSnapshotOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileShareControllerServer).BackupFileShareSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.FileShareController/BackupFileShareSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileShareControllerServer).BackupFileShareSnapshot(ctx, req.(*BackupFileShareSnapshotOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileShareController_RestoreFileShareSnapshotBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreFileShareSnapshotBackupOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileShareControllerServer).RestoreFileShareSnapshotBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.FileShareController/RestoreFileShareSnapshotBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileShareControllerServer).RestoreFileShareSnapshotBackup(ctx, req.(*RestoreFileShareSnapshotBackupOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _FileShareController_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.FileShareController",
 	HandlerType: (*FileShareControllerServer)(nil),
@@ -5728,6 +6621,18 @@ var _FileShareController_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFileShareSnapshot",
 			Handler:    _FileShareController_DeleteFileShareSnapshot_Handler,
 		},
+		{
+			MethodName: "CopyFileShareSnapshot",
+			Handler:    _FileShareController_CopyFileShareSnapshot_Handler,
+		},
+		{
+			MethodName: "BackupFileShareSnapshot",
+			Handler:    _FileShareController_BackupFileShareSnapshot_Handler,
+		},
+		{
+			MethodName: "RestoreFileShareSnapshotBackup",
+			Handler:    _FileShareController_RestoreFileShareSnapshotBackup_Handler,
+		},
 		{
 			MethodName: "CreateFileShareAcl",
 			Handler:    _FileShareController_CreateFileShareAcl_Handler,
@@ -5753,6 +6658,12 @@ type FileShareDockClient interface {
 	CreateFileShareSnapshot(ctx context.Context, in *CreateFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	//Delete a file share snapshot
 	DeleteFileShareSnapshot(ctx context.Context, in *DeleteFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Copy a file share snapshot to a remote endpoint
+	CopyFileShareSnapshot(ctx context.Context, in *CopyFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Back up a file share snapshot to an object storage backup target
+	BackupFileShareSnapshot(ctx context.Context, in *BackupFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error)
+	// Restore a file share snapshot backup into a file share
+	RestoreFileShareSnapshotBackup(ctx context.Context, in *RestoreFileShareSnapshotBackupOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Create a file share Acl
 	CreateFileShareAcl(ctx context.Context, in *CreateFileShareAclOpts, opts ...grpc.CallOption) (*GenericResponse, error)
 	// Delete a file share Acl
@@ -5803,6 +6714,33 @@ func (c *fileShareDockClient) DeleteFileShareSnapshot(ctx context.Context, in *D
 	return out, nil
 }
 
+func (c *fileShareDockClient) CopyFileShareSnapshot(ctx context.Context, in *CopyFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/proto.FileShareDock/CopyFileShareSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileShareDockClient) BackupFileShareSnapshot(ctx context.Context, in *BackupFileShareSnapshotOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/proto.FileShareDock/BackupFileShareSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileShareDockClient) RestoreFileShareSnapshotBackup(ctx context.Context, in *RestoreFileShareSnapshotBackupOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/proto.FileShareDock/RestoreFileShareSnapshotBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fileShareDockClient) CreateFileShareAcl(ctx context.Context, in *CreateFileShareAclOpts, opts ...grpc.CallOption) (*GenericResponse, error) {
 	out := new(GenericResponse)
 	err := c.cc.Invoke(ctx, "/proto.FileShareDock/CreateFileShareAcl", in, out, opts...)
@@ -5831,6 +6769,12 @@ type FileShareDockServer interface {
 	CreateFileShareSnapshot(context.Context, *CreateFileShareSnapshotOpts) (*GenericResponse, error)
 	//Delete a file share snapshot
 	DeleteFileShareSnapshot(context.Context, *DeleteFileShareSnapshotOpts) (*GenericResponse, error)
+	// Copy a file share snapshot to a remote endpoint
+	CopyFileShareSnapshot(context.Context, *CopyFileShareSnapshotOpts) (*GenericResponse, error)
+	// Back up a file share snapshot to an object storage backup target
+	BackupFileShareSnapshot(context.Context, *BackupFileShareSnapshotOpts) (*GenericResponse, error)
+	// Restore a file share snapshot backup into a file share
+	RestoreFileShareSnapshotBackup(context.Context, *RestoreFileShareSnapshotBackupOpts) (*GenericResponse, error)
 	// Create a file share Acl
 	CreateFileShareAcl(context.Context, *CreateFileShareAclOpts) (*GenericResponse, error)
 	// Delete a file share Acl
@@ -5853,6 +6797,15 @@ func (*UnimplementedFileShareDockServer) CreateFileShareSnapshot(ctx context.Con
 func (*UnimplementedFileShareDockServer) DeleteFileShareSnapshot(ctx context.Context, req *DeleteFileShareSnapshotOpts) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteFileShareSnapshot not implemented")
 }
+func (*UnimplementedFileShareDockServer) CopyFileShareSnapshot(ctx context.Context, req *CopyFileShareSnapshotOpts) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyFileShareSnapshot not implemented")
+}
+func (*UnimplementedFileShareDockServer) BackupFileShareSnapshot(ctx context.Context, req *BackupFileShareSnapshotOpts) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupFileShareSnapshot not implemented")
+}
+func (*UnimplementedFileShareDockServer) RestoreFileShareSnapshotBackup(ctx context.Context, req *RestoreFileShareSnapshotBackupOpts) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreFileShareSnapshotBackup not implemented")
+}
 func (*UnimplementedFileShareDockServer) CreateFileShareAcl(ctx context.Context, req *CreateFileShareAclOpts) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateFileShareAcl not implemented")
 }
@@ -5972,6 +6925,60 @@ func _FileShareDock_DeleteFileShareAcl_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileShareDock_CopyFileShareSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyFileShareSnapshotOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileShareDockServer).CopyFileShareSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.FileShareDock/CopyFileShareSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileShareDockServer).CopyFileShareSnapshot(ctx, req.(*CopyFileShareSnapshotOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileShareDock_BackupFileShareSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupFileShareSnapshotOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileShareDockServer).BackupFileShareSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.FileShareDock/BackupFileShareSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileShareDockServer).BackupFileShareSnapshot(ctx, req.(*BackupFileShareSnapshotOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileShareDock_RestoreFileShareSnapshotBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreFileShareSnapshotBackupOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileShareDockServer).RestoreFileShareSnapshotBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.FileShareDock/RestoreFileShareSnapshotBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileShareDockServer).RestoreFileShareSnapshotBackup(ctx, req.(*RestoreFileShareSnapshotBackupOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _FileShareDock_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.FileShareDock",
 	HandlerType: (*FileShareDockServer)(nil),
@@ -5992,6 +6999,18 @@ var _FileShareDock_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFileShareSnapshot",
 			Handler:    _FileShareDock_DeleteFileShareSnapshot_Handler,
 		},
+		{
+			MethodName: "CopyFileShareSnapshot",
+			Handler:    _FileShareDock_CopyFileShareSnapshot_Handler,
+		},
+		{
+			MethodName: "BackupFileShareSnapshot",
+			Handler:    _FileShareDock_BackupFileShareSnapshot_Handler,
+		},
+		{
+			MethodName: "RestoreFileShareSnapshotBackup",
+			Handler:    _FileShareDock_RestoreFileShareSnapshotBackup_Handler,
+		},
 		{
 			MethodName: "CreateFileShareAcl",
 			Handler:    _FileShareDock_CreateFileShareAcl_Handler,
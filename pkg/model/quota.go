@@ -0,0 +1,43 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+// QuotaSpec is the capacity quota of one tenant. It caps the GB a tenant
+// can provision across volumes and file shares; creates beyond the cap are
+// rejected and warning events are emitted when configurable soft
+// thresholds are crossed.
+type QuotaSpec struct {
+	*BaseModel
+
+	// The uuid of the project the quota applies to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The GB the tenant may provision in total across volumes and file
+	// shares. A zero quota does not limit the tenant.
+	GbQuota int64 `json:"gbQuota,omitempty"`
+
+	// The GB the tenant currently has provisioned. Filled when the quota
+	// is read, not stored.
+	// +readOnly
+	UsedGb int64 `json:"usedGb,omitempty"`
+
+	// UsedGb as a percentage of GbQuota. Filled when the quota is read,
+	// not stored.
+	// +readOnly
+	UsedPercent float64 `json:"usedPercent,omitempty"`
+}
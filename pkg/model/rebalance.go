@@ -0,0 +1,79 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// RebalanceRequestSpec is a schema of the admin rebalance request. Without
+// execute set the planner only proposes migrations, the database is left
+// untouched.
+type RebalanceRequestSpec struct {
+	// If execute is set the proposed migrations are carried out instead of
+	// only being reported. Default is false, a dry run.
+	Execute bool `json:"execute,omitempty"`
+
+	// Pools whose utilization is within this many percent points of each
+	// other are considered balanced and left alone. Default is 10.
+	SpreadPercent float64 `json:"spreadPercent,omitempty"`
+
+	// The most migrations the planner may propose in one request.
+	// Default is 10.
+	MaxMigrations int `json:"maxMigrations,omitempty"`
+
+	// The most bandwidth each proposed migration may use in MB/s, carried
+	// into the plan so executing it does not saturate production links.
+	// Zero leaves it unlimited.
+	MaxBandwidthMBps int64 `json:"maxBandwidthMBps,omitempty"`
+}
+
+// RebalanceMigrationSpec describes one fileshare migration the planner
+// proposes to even out pool utilization.
+type RebalanceMigrationSpec struct {
+	// The uuid of the fileshare to migrate.
+	FileShareId string `json:"fileshareId,omitempty"`
+
+	// The uuid of the project that the fileshare belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The provisioned size of the fileshare.
+	// Default unit of Size is GB.
+	Size int64 `json:"size,omitempty"`
+
+	// The pool the fileshare currently lives on.
+	SourcePoolId string `json:"sourcePoolId,omitempty"`
+
+	// The pool the fileshare should move to, always in the same
+	// availability zone as the source.
+	TargetPoolId string `json:"targetPoolId,omitempty"`
+
+	// The availability zone the migration stays within.
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// The most bandwidth the migration may use in MB/s, zero leaves it
+	// unlimited.
+	MaxBandwidthMBps int64 `json:"maxBandwidthMBps,omitempty"`
+}
+
+// RebalancePlanSpec is the response body of the admin rebalance request.
+type RebalancePlanSpec struct {
+	// If the request was a dry run, the plan only describes what would be
+	// migrated.
+	DryRun bool `json:"dryRun"`
+
+	// The proposed migrations, in the order they should run.
+	Migrations []*RebalanceMigrationSpec `json:"migrations"`
+}
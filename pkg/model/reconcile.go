@@ -0,0 +1,85 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// Resource types which can be selected in a reconciliation request.
+const (
+	ReconcileFileShare         = "fileshare"
+	ReconcileFileShareSnapshot = "fileshareSnapshot"
+	ReconcileFileShareAcl      = "fileshareAcl"
+	ReconcileVolume            = "volume"
+	ReconcileVolumeSnapshot    = "volumeSnapshot"
+	ReconcileVolumeAttachment  = "volumeAttachment"
+)
+
+// Actions recorded in a reconciliation report item.
+const (
+	ReconcileActionPurged      = "purged"
+	ReconcileActionMarkedError = "markedError"
+)
+
+// ReconcileRequestSpec is a schema of the admin reconciliation request. It
+// selects which resource types should be checked and how old a resource in a
+// transitional status must be before it is considered stale.
+type ReconcileRequestSpec struct {
+	// The resource types to be reconciled. If empty, all supported resource
+	// types are checked.
+	ResourceTypes []string `json:"resourceTypes,omitempty"`
+
+	// Only resources whose last update is older than this number of seconds
+	// are considered stale. Default is 0, which means no age limit.
+	OlderThan int64 `json:"olderThan,omitempty"`
+
+	// If dryRun is set, the report is generated but the database is left
+	// untouched.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ReconcileItemSpec describes one resource whose status was found stale and
+// the action that was taken to fix it.
+type ReconcileItemSpec struct {
+	// The type of the resource. One of the Reconcile* resource type constants.
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The uuid of the resource.
+	Id string `json:"id,omitempty"`
+
+	// The uuid of the project that the resource belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The stale status the resource was found in.
+	OldStatus string `json:"oldStatus,omitempty"`
+
+	// The action taken, one of "purged" and "markedError".
+	Action string `json:"action,omitempty"`
+}
+
+// ReconcileReportSpec is the response body of the admin reconciliation
+// request.
+type ReconcileReportSpec struct {
+	// Number of resources which were inspected.
+	Checked int64 `json:"checked"`
+
+	// If the request was a dry run, the report only describes what would have
+	// been done.
+	DryRun bool `json:"dryRun"`
+
+	// The resources whose statuses were fixed.
+	Reconciled []*ReconcileItemSpec `json:"reconciled"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+// The health states a region endpoint can be in. A freshly registered
+// region is unknown until someone reports its state.
+const (
+	RegionStatusUp      = "up"
+	RegionStatusDown    = "down"
+	RegionStatusUnknown = "unknown"
+)
+
+// RegionSpec is an entry of the multi-region endpoint registry. It names a
+// peer SODA API endpoint together with its capabilities and health, so
+// clients and cross-region features such as replication can discover
+// remote endpoints from the API instead of from out-of-band config.
+type RegionSpec struct {
+	*BaseModel
+
+	// The name of the region, e.g. "eu-west".
+	Name string `json:"name,omitempty"`
+
+	// The description of the region.
+	Description string `json:"description,omitempty"`
+
+	// The url of the peer SODA API endpoint, e.g. "https://soda.eu-west.example.com:50040".
+	Url string `json:"url,omitempty"`
+
+	// The capabilities the peer endpoint offers, e.g. "block", "file",
+	// "replication".
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// The health of the peer endpoint, one of the RegionStatus constants.
+	Status string `json:"status,omitempty"`
+}
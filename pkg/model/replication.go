@@ -67,6 +67,10 @@ type ReplicationSpec struct {
 	SecondaryReplicationDriverData map[string]string `json:"secondaryReplicationDriverData,omitempty"`
 	// replication status
 	ReplicationStatus string `json:"replicationStatus,omitempty"`
+
+	// The reason the replication last entered an error status.
+	// +readOnly
+	Fault *FaultSpec `json:"fault,omitempty"`
 	// supports "async" or "sync" now
 	ReplicationMode string `json:"replicationMode,omitempty"`
 	// 0 means sync replication.
@@ -87,3 +91,51 @@ type FailoverReplicationSpec struct {
 	AllowAttachedVolume bool   `json:"allowAttachedVolume,omitempty"`
 	SecondaryBackendId  string `json:"secondaryBackendId,omitempty"`
 }
+
+// ReplicationTopologyLegSpec describes one side of a replication: the
+// volume on that site and the pool, availability zone and dock serving it.
+type ReplicationTopologyLegSpec struct {
+	// The uuid of the volume on this site.
+	VolumeId string `json:"volumeId,omitempty"`
+	// The name of the volume on this site.
+	VolumeName string `json:"volumeName,omitempty"`
+	// The uuid of the pool backing the volume.
+	PoolId string `json:"poolId,omitempty"`
+	// The name of the pool backing the volume.
+	PoolName string `json:"poolName,omitempty"`
+	// The uuid of the dock the pool belongs to.
+	DockId string `json:"dockId,omitempty"`
+	// The availability zone the volume lives in.
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+}
+
+// ReplicationTopologySpec is one edge of the replication topology, relating
+// the primary and the secondary side of a replication so the whole topology
+// can be rendered from a single list call.
+type ReplicationTopologySpec struct {
+	// The uuid of the replication.
+	ReplicationId string `json:"replicationId,omitempty"`
+	// The name of the replication.
+	Name string `json:"name,omitempty"`
+	// The status of the replication.
+	ReplicationStatus string `json:"replicationStatus,omitempty"`
+	// The mode of the replication, "async" or "sync".
+	ReplicationMode string `json:"replicationMode,omitempty"`
+	// The primary side of the replication.
+	Primary *ReplicationTopologyLegSpec `json:"primary,omitempty"`
+	// The secondary side of the replication.
+	Secondary *ReplicationTopologyLegSpec `json:"secondary,omitempty"`
+}
+
+// ReplicationStatusSpec reports the synchronization progress of a
+// replication, collected from the replication driver on request.
+type ReplicationStatusSpec struct {
+	// The uuid of the replication the status belongs to.
+	ReplicationId string `json:"replicationId,omitempty"`
+	// The time the secondary volume was last synchronized with the primary.
+	LastSyncTime string `json:"lastSyncTime,omitempty"`
+	// The number of seconds the secondary site lags behind the primary.
+	LagSeconds int64 `json:"lagSeconds"`
+	// The number of bytes transferred by the last synchronization.
+	TransferredBytes int64 `json:"transferredBytes"`
+}
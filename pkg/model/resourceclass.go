@@ -0,0 +1,81 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ResourceClassSpec is a named bundle of validation rules that admins
+// attach to tenants. Create requests of an attached tenant are checked
+// against the rules, so organization policy lives in one place instead of
+// in every client.
+type ResourceClassSpec struct {
+	*BaseModel
+
+	// The name of the resource class.
+	Name string `json:"name,omitempty"`
+
+	// The description of the resource class.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The uuids of the projects the class is attached to. A tenant has at
+	// most one class.
+	// +optional
+	Tenants []string `json:"tenants,omitempty"`
+
+	// Metadata keys every new resource of an attached tenant must carry.
+	// +optional
+	RequiredTags []string `json:"requiredTags,omitempty"`
+
+	// A regular expression the name of every new resource must match. An
+	// empty pattern allows every name.
+	// +optional
+	NamingPattern string `json:"namingPattern,omitempty"`
+
+	// The size bounds in GB for new resources, zero meaning unbounded.
+	// +optional
+	MinSize int64 `json:"minSize,omitempty"`
+	MaxSize int64 `json:"maxSize,omitempty"`
+
+	// The availability zones new resources may be placed in. An empty
+	// list allows every zone.
+	// +optional
+	AllowedAvailabilityZones []string `json:"allowedAvailabilityZones,omitempty"`
+}
+
+// Validate checks that the rules of the class are well-formed.
+func (r *ResourceClassSpec) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("resource class name is required")
+	}
+	if r.NamingPattern != "" {
+		if _, err := regexp.Compile(r.NamingPattern); err != nil {
+			return fmt.Errorf("invalid naming pattern %q: %v", r.NamingPattern, err)
+		}
+	}
+	if r.MinSize < 0 || r.MaxSize < 0 {
+		return fmt.Errorf("size bounds must not be negative")
+	}
+	if r.MaxSize > 0 && r.MinSize > r.MaxSize {
+		return fmt.Errorf("minimum size %dGB exceeds maximum size %dGB", r.MinSize, r.MaxSize)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+
+package model
+
+// ScopedTokenSpec is a short lived credential restricted to a set of
+// actions and, optionally, to specific resources. Automation like a backup
+// agent presents it in the X-Auth-ScopedToken header instead of carrying
+// full tenant credentials, so a leaked token can at worst perform the few
+// actions it was minted for.
+type ScopedTokenSpec struct {
+	*BaseModel
+
+	// The uuid of the project that the scoped token belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The uuid of the user that created the scoped token.
+	UserId string `json:"userId,omitempty"`
+
+	// The name of the scoped token.
+	Name string `json:"name,omitempty"`
+
+	// The description of the scoped token.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The actions the token is allowed to perform, as "resource:action"
+	// entries matching the policy actions, e.g. "fileshare_snapshot:create".
+	Scope []string `json:"scope,omitempty"`
+
+	// The uuids of the resources the token is restricted to. A request
+	// authenticated by the token must reference one of them; when empty,
+	// the token covers all resources of its tenant.
+	// +optional
+	ResourceIds []string `json:"resourceIds,omitempty"`
+
+	// The server time after which the token stops working. It's represented
+	// as a time string in RFC8601 format and is always set, scoped tokens
+	// can not be minted without an expiry.
+	ExpiresAt string `json:"expiresAt,omitempty"`
+
+	// HashedSecret is the sha256 of the token secret, the secret itself is
+	// never kept at rest.
+	HashedSecret string `json:"hashedSecret,omitempty"`
+
+	// Secret is only returned once, in the response of a create, and is
+	// never stored.
+	Secret string `json:"secret,omitempty"`
+}
@@ -0,0 +1,46 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+// ShareNetworkSpec describes one export network of a multi-network NAS
+// deployment: a subnet, optionally VLAN tagged, that fileshares can be
+// exported on. Admins register the networks; a share create picks one
+// explicitly, through its profile, or falls back to the default network.
+type ShareNetworkSpec struct {
+	*BaseModel
+
+	// The name of the share network, unique among the registered networks.
+	Name string `json:"name,omitempty"`
+
+	// The description of the share network.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The subnet the exports of this network are addressed in, CIDR form.
+	Cidr string `json:"cidr,omitempty"`
+
+	// The VLAN id of the network. Zero means untagged.
+	// +optional
+	Vlan int64 `json:"vlan,omitempty"`
+
+	// Default marks the network a share is exported on when neither the
+	// create request nor its profile picks one. At most one network
+	// carries the mark.
+	// +optional
+	Default bool `json:"default,omitempty"`
+}
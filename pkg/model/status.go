@@ -33,6 +33,21 @@ const (
 	FileShareSnapErrorDeleting = "errorDeleting"
 )
 
+// fileshare snapshot copy status
+const (
+	FileShareSnapCopyCopying   = "copying"
+	FileShareSnapCopyAvailable = "available"
+	FileShareSnapCopyError     = "error"
+)
+
+// fileshare snapshot backup status
+const (
+	FileShareSnapBackupBackingUp = "backingUp"
+	FileShareSnapBackupAvailable = "available"
+	FileShareSnapBackupRestoring = "restoring"
+	FileShareSnapBackupError     = "error"
+)
+
 // fileshare acl status
 const (
 	FileShareAclAvailable     = "available"
@@ -42,6 +57,13 @@ const (
 	FileShareAclInUse         = "in_Use"
 )
 
+// fileshare acl application status per export location
+const (
+	AclExportPending = "pending"
+	AclExportApplied = "applied"
+	AclExportFailed  = "failed"
+)
+
 // volume status
 const (
 	VolumeCreating       = "creating"
@@ -76,6 +98,7 @@ const (
 // volume attachment status
 const (
 	VolumeAttachCreating      = "creating"
+	VolumeAttachReserved      = "reserved"
 	VolumeAttachAvailable     = "available"
 	VolumeAttachDeleting      = "deleting"
 	VolumeAttachErrorDeleting = "errorDeleting"
@@ -113,3 +136,9 @@ const (
 	VolumeGroupUpdating      = "updating"
 	VolumeGroupInUse         = "inUse"
 )
+
+// dock status
+const (
+	DockAvailable   = "available"
+	DockUnavailable = "unavailable"
+)
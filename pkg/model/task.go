@@ -0,0 +1,69 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+// The states a task moves through.
+const (
+	TaskRunning   = "running"
+	TaskSucceeded = "succeeded"
+	TaskFailed    = "failed"
+)
+
+// TaskTransitionSpec is one state change of a task, with the time it
+// happened and, for a failure, why.
+type TaskTransitionSpec struct {
+	// One of the Task status constants.
+	Status string `json:"status,omitempty"`
+
+	// When the task entered the status.
+	Timestamp string `json:"timestamp,omitempty"`
+
+	// Why the task entered the status, only set for failures.
+	Message string `json:"message,omitempty"`
+}
+
+// TaskSpec is the record of one asynchronous operation, like a volume
+// create or a file share snapshot delete. It keeps the state transitions the
+// operation went through and the error that failed it, so clients follow an
+// operation through its task instead of polling the resource status field
+// and guessing why it went to error.
+type TaskSpec struct {
+	*BaseModel
+
+	// The uuid of the project the task belongs to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The kind of the resource the operation runs on, e.g. "volume" or
+	// "fileshareSnapshot".
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The uuid of the resource the operation runs on.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// What the operation does, e.g. "createVolume" or "deleteFileShare".
+	Operation string `json:"operation,omitempty"`
+
+	// One of the Task status constants.
+	Status string `json:"status,omitempty"`
+
+	// Why the task failed, empty while it runs or when it succeeded.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// The state changes the task went through, oldest first.
+	Transitions []*TaskTransitionSpec `json:"transitions,omitempty"`
+}
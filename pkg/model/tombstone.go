@@ -0,0 +1,53 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This module implements the common data structure.
+*/
+package model
+
+// The resource kinds a tombstone can be left behind for.
+const (
+	TombstoneResourceVolume    = "volume"
+	TombstoneResourceFileShare = "fileShare"
+)
+
+// TombstoneSpec is the record left behind when a resource is deleted, kept
+// for a configurable retention so incident investigations and billing
+// reconciliation can still see what existed and who removed it.
+type TombstoneSpec struct {
+	*BaseModel
+
+	// The kind of the deleted resource, one of the TombstoneResource
+	// constants.
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// The uuid the deleted resource had.
+	ResourceId string `json:"resourceId,omitempty"`
+
+	// The name the deleted resource had.
+	Name string `json:"name,omitempty"`
+
+	// The uuid of the project the deleted resource belonged to.
+	TenantId string `json:"tenantId,omitempty"`
+
+	// The size in GB the deleted resource had.
+	Size int64 `json:"size,omitempty"`
+
+	// The time the resource was deleted.
+	DeletedAt string `json:"deletedAt,omitempty"`
+
+	// The uuid of the user whose request deleted the resource.
+	DeletedBy string `json:"deletedBy,omitempty"`
+}
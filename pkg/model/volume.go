@@ -52,6 +52,10 @@ type VolumeSpec struct {
 	// One of: "available", "error", "in-use", etc.
 	Status string `json:"status,omitempty"`
 
+	// The reason the volume last entered an error status.
+	// +readOnly
+	Fault *FaultSpec `json:"fault,omitempty"`
+
 	// The uuid of the pool which the volume belongs to.
 	// +readOnly
 	PoolId string `json:"poolId,omitempty"`
@@ -119,6 +123,16 @@ type VolumeSnapshotSpec struct {
 	// One of: "available", "error", etc.
 	Status string `json:"status,omitempty"`
 
+	// The reason the snapshot last entered an error status.
+	// +readOnly
+	Fault *FaultSpec `json:"fault,omitempty"`
+
+	// The consistency level the snapshot is taken with, either "crash"
+	// (the default) or "application", which quiesces the workload through
+	// the configured webhook around the cut.
+	// +optional
+	Consistency string `json:"consistency,omitempty"`
+
 	// The uuid of the volume which the snapshot belongs to.
 	VolumeId string `json:"volumeId,omitempty"`
 
@@ -133,6 +147,30 @@ type ExtendVolumeSpec struct {
 	NewSize int64 `json:"newSize,omitempty"`
 }
 
+// The actions a volume action request may carry. They drive the attach
+// state machine of a volume:
+//
+//	available --reserve--> reserved --create attachment--> attaching
+//	attaching --attach--> inUse --detach--> available
+//
+// reserve claims the volume for an orchestrator so concurrent workflows do
+// not race on it, unreserve returns a reserved volume to available when the
+// claim is abandoned before attaching, and attach confirms the host sees
+// the device after the attachment has been created.
+const (
+	VolumeActionReserve   = "reserve"
+	VolumeActionUnreserve = "unreserve"
+	VolumeActionAttach    = "attach"
+	VolumeActionDetach    = "detach"
+)
+
+// VolumeActionSpec is the body of a volume action request.
+type VolumeActionSpec struct {
+	// The action to perform. One of: "reserve", "unreserve", "attach" or
+	// "detach".
+	Action string `json:"action,omitempty"`
+}
+
 type VolumeGroupSpec struct {
 	*BaseModel
 	// The name of the volume group.
@@ -140,6 +178,10 @@ type VolumeGroupSpec struct {
 
 	Status string `json:"status,omitempty"`
 
+	// The reason the group last entered an error status.
+	// +readOnly
+	Fault *FaultSpec `json:"fault,omitempty"`
+
 	// The uuid of the project that the volume snapshot belongs to.
 	TenantId string `json:"tenantId,omitempty"`
 
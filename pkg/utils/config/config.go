@@ -211,6 +211,15 @@ func parseItems(section string, v reflect.Value, cfg *ini.File) error {
 			}
 			field.SetFloat(val)
 		case reflect.String:
+			if isSecretRef(strVal) {
+				// Only the reference may appear in the error, the resolved
+				// value must never be logged.
+				val, err := resolveSecretRef(strVal)
+				if err != nil {
+					return fmt.Errorf("cann't resolve secret reference %s for %s, %v", strVal, tags[0], err)
+				}
+				strVal = val
+			}
 			field.SetString(strVal)
 		case reflect.Slice:
 			setSlice(field, strVal)
@@ -281,6 +290,13 @@ func (c *Config) Load() {
 	initConf(GetConfigPath(), CONF)
 }
 
+// Reload re-reads the configuration file into the receiver without restarting
+// the process, so changed settings can be picked up at runtime. Settings that
+// were overridden on the command line fall back to the file values.
+func (c *Config) Reload() {
+	initConf(GetConfigPath(), c)
+}
+
 func GetBackendsMap() map[string]BackendProperties {
 	backendsMap := map[string]BackendProperties{}
 	v := reflect.ValueOf(CONF.Backends)
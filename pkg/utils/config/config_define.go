@@ -45,6 +45,156 @@ type OsdsApiServer struct {
 	GrafanaUrl        string `conf:"grafana_url,http://localhost:3000"`
 	// prometheus and alert manager configuration reload url
 	ConfReloadUrl string `conf:"conf_reload_url,/-/reload"`
+	// ttl reaper related
+	TtlReaperInterval time.Duration `conf:"ttl_reaper_interval,5m"`
+	// status watchdog related. A resource that stays in creating or deleting
+	// longer than stuck_status_timeout is transitioned to the matching error
+	// status with a fault record. A zero timeout disables the watchdog.
+	StuckStatusTimeout       time.Duration `conf:"stuck_status_timeout,0"`
+	StuckStatusCheckInterval time.Duration `conf:"stuck_status_check_interval,5m"`
+	// external validation webhook related, disabled when the url is empty
+	ValidationWebhookUrl           string        `conf:"validation_webhook_url,"`
+	ValidationWebhookTimeout       time.Duration `conf:"validation_webhook_timeout,10s"`
+	ValidationWebhookFailurePolicy string        `conf:"validation_webhook_failure_policy,fail"`
+	// fake controller related, replaces the gRPC controller client with an
+	// in-process simulator, for development only
+	FakeController            bool          `conf:"fake_controller,false"`
+	FakeControllerLatency     time.Duration `conf:"fake_controller_latency,200ms"`
+	FakeControllerFailureRate int           `conf:"fake_controller_failure_rate,0"`
+	RecordTraffic             bool          `conf:"record_traffic,false"`
+	RecordTrafficDir          string        `conf:"record_traffic_dir,/var/log/opensds/recordings"`
+	// controller grpc channel tuning. Compression helps when profiles and
+	// metadata embedded in the opts grow large; opts over the size limit are
+	// rejected with 413 instead of being sent to the controller.
+	CtrGrpcCompression bool `conf:"controller_grpc_compression,false"`
+	CtrMaxOptsSize     int  `conf:"controller_max_opts_size,4194304"`
+	// Format of CreatedAt/UpdatedAt written to new records, either 'v1beta'
+	// (the historical local time format without timezone) or 'rfc3339'
+	// (RFC 3339 UTC). Readers understand both, so the setting can be
+	// flipped without migrating existing records.
+	TimestampFormat string `conf:"timestamp_format,v1beta"`
+	// pool staleness related. A pool whose record was last refreshed by its
+	// dock more than pool_stale_threshold ago is reported as stale; with
+	// reject_stale_pools enabled stale pools are also no longer considered
+	// when checking capacity for new resources. A zero threshold disables
+	// staleness tracking.
+	PoolStaleThreshold time.Duration `conf:"pool_stale_threshold,0"`
+	RejectStalePools   bool          `conf:"reject_stale_pools,false"`
+	// slow request logging related, disabled when no budget is configured.
+	// Route overrides are a comma separated list of prefix=duration pairs.
+	LatencyBudget       time.Duration `conf:"latency_budget,0"`
+	LatencyRouteBudgets string        `conf:"latency_route_budgets,"`
+	// handler timeout related, disabled when no timeout is configured. A
+	// handler over its limit gets its request context canceled and the
+	// caller receives 503 with a Retry-After hint. Route overrides are a
+	// comma separated list of prefix=duration pairs.
+	HandlerTimeout       time.Duration `conf:"handler_timeout,0"`
+	HandlerRouteTimeouts string        `conf:"handler_route_timeouts,"`
+	// tombstone related. A deleted volume or fileshare leaves a tombstone
+	// record behind for this long, listable by admins with ?deleted=true.
+	// A zero retention disables tombstones.
+	TombstoneRetention time.Duration `conf:"tombstone_retention,0"`
+	// quota related. Warning events are emitted when a tenant crosses one
+	// of the soft thresholds (percent of its gb quota); the optional
+	// webhook is posted the same warnings.
+	QuotaWarnThresholds []int  `conf:"quota_warn_thresholds,80,90"`
+	QuotaWebhookUrl     string `conf:"quota_webhook_url,"`
+	// acl expiry related. An acl may carry an expiresAt after which a
+	// reaper revokes it; acl_max_lifetime caps how far in the future that
+	// may be. A zero cap leaves the lifetime unlimited.
+	AclMaxLifetime time.Duration `conf:"acl_max_lifetime,0"`
+	// availability zone fallback. When the requested zone has no capacity
+	// a create falls back to these zones in order, unless the profile
+	// names its own list. Empty keeps the behavior of failing.
+	AZFallbackZones []string `conf:"az_fallback_zones,"`
+	// snapshot chain limiting. Each snapshot taken of a resource adds one
+	// level to its snapshot/clone chain and resources created from a
+	// snapshot inherit its depth; a snapshot that would sit deeper than
+	// max_snapshot_chain_depth is rejected. Zero leaves chains unbounded.
+	MaxSnapshotChainDepth int `conf:"max_snapshot_chain_depth,0"`
+	// resize protection. Backends can be thrashed by rapid repeated
+	// resizes, so a resource may only be resized once every resize_cooldown;
+	// attempts inside the window are rejected with 429 carrying the
+	// nextAllowedAt timestamp. max_resize_step caps how many GB a single
+	// resize may add. A zero value disables either check.
+	ResizeCooldown time.Duration `conf:"resize_cooldown,0"`
+	MaxResizeStep  int64         `conf:"max_resize_step,0"`
+	// admin jobs. At most job_concurrency administrative jobs run at the
+	// same time in one api server; submissions over the limit are rejected
+	// with 409. Finished job records are kept for job_history_retention,
+	// a zero retention keeps them forever.
+	JobConcurrency      int           `conf:"job_concurrency,2"`
+	JobHistoryRetention time.Duration `conf:"job_history_retention,0"`
+	// client address derivation. Forwarded headers like X-Forwarded-For are
+	// client controlled, so they are only honored when the request comes in
+	// through one of these proxy addresses or CIDRs. With an empty list the
+	// socket address is always used.
+	TrustedProxies []string `conf:"trusted_proxies,"`
+	// consistency checking. Every interval the checker compares database
+	// records against the pool inventory the docks report and records the
+	// discrepancies, served through the admin consistency-report endpoint.
+	// A zero interval disables the checker.
+	ConsistencyCheckInterval time.Duration `conf:"consistency_check_interval,0"`
+	// field casing compatibility. While true, snake_case field names in
+	// request bodies are rewritten to the camelCase the API documents, so
+	// old SDKs keep working. Set to false to close the deprecation window.
+	AcceptSnakeCaseFields bool `conf:"accept_snake_case_fields,true"`
+	// cascade deletes. Cascade-style deletions (cascade host delete,
+	// detach-all, revoke-all-access) dispatch their dependent items to the
+	// controller in batches of batch_size with at most parallelism calls in
+	// flight, checkpointing progress in the cascade task record after every
+	// batch so an interrupted cleanup resumes instead of starting over.
+	CascadeDeleteParallelism int `conf:"cascade_delete_parallelism,4"`
+	CascadeDeleteBatchSize   int `conf:"cascade_delete_batch_size,100"`
+	// legacy compatibility. While true, responses on the routes the original
+	// OpenSDS v1beta API served are rewritten to the legacy conventions, so
+	// existing Cinder/Manila north-bound plugins keep working: field names
+	// go back to snake_case and status codes this API introduced are mapped
+	// to the codes the legacy API used. Endpoints added since follow the
+	// documented conventions regardless.
+	LegacyCompatMode bool `conf:"legacy_compat_mode,false"`
+	// telemetry ingestion. The backend selects the tsdb that samples pushed
+	// by docks are persisted to, one of: "influxdb", "prometheus" (a
+	// pushgateway compatible endpoint) or empty for the built-in bounded
+	// in-memory store. The endpoint is the base url of the external store,
+	// the database only applies to influxdb.
+	MetricsStoreBackend  string `conf:"metrics_store_backend,"`
+	MetricsStoreEndpoint string `conf:"metrics_store_endpoint,"`
+	MetricsStoreDatabase string `conf:"metrics_store_database,opensds"`
+	// load shedding. When the moving average db latency or the db queue
+	// depth the drivers report crosses its threshold, reads on shed_routes
+	// (every read when the list is empty) are rejected with 503 while
+	// mutations keep their db capacity. Zero thresholds disable shedding.
+	ShedDbLatencyThreshold    time.Duration `conf:"shed_db_latency_threshold,0"`
+	ShedDbQueueDepthThreshold int           `conf:"shed_db_queue_depth_threshold,0"`
+	ShedRoutes                string        `conf:"shed_routes,"`
+	// dock registration. A new dock joins by posting to the register
+	// endpoint with this bootstrap token; the server assigns its id and
+	// records the registration. Empty keeps the endpoint disabled.
+	DockBootstrapToken string `conf:"dock_bootstrap_token,"`
+	// snapshot quiescing. An application consistent snapshot freezes the
+	// workload through this webhook, posted once before the snapshot is
+	// cut and once after. Empty rejects application consistent requests,
+	// leaving only crash consistency.
+	SnapshotQuiesceWebhookUrl     string        `conf:"snapshot_quiesce_webhook_url,"`
+	SnapshotQuiesceWebhookTimeout time.Duration `conf:"snapshot_quiesce_webhook_timeout,10s"`
+	// async dispatch retry. A dock dispatch that fails transiently, e.g.
+	// a create while the controller restarts, is retried up to
+	// max_attempts times with an exponentially doubling backoff before
+	// the resource is marked error. One attempt keeps the old behavior of
+	// failing on the first error.
+	AsyncDispatchMaxAttempts  int           `conf:"async_dispatch_max_attempts,1"`
+	AsyncDispatchRetryBackoff time.Duration `conf:"async_dispatch_retry_backoff,2s"`
+	// bulk data movement. With a cap configured, snapshot copy, backup
+	// and migration requests must name a maxBandwidthMBps within it, so
+	// bulk transfers never saturate production links. Zero leaves the
+	// limit optional and unbounded.
+	TransferMaxBandwidthMBps int64 `conf:"transfer_max_bandwidth_mbps,0"`
+	// creator visibility. With a tenant admin role named here, a tenant
+	// user without that role only sees the resources their own user
+	// created; admins and users holding the role keep seeing everything
+	// in the tenant. Empty disables the scoping.
+	TenantAdminRole string `conf:"tenant_admin_role,"`
 }
 
 type OsdsLet struct {
@@ -83,6 +233,16 @@ type Database struct {
 	KeyFile         string `conf:"key_file,/etc/etcd/server.key"`
 	TrustedCAFile   string `conf:"ca_file,/etc/etcd/ca.crt"`
 	AllowClientAuth bool   `conf:"allowClientAuth,false"`
+	// etcd client tuning. At most max_inflight_requests requests run against
+	// etcd at once; further callers wait, so a slow etcd backs pressure up
+	// into the api instead of piling up goroutines. The endpoint list is
+	// refreshed from the cluster every endpoint_auto_sync_interval, so the
+	// client rotates to healthy members during leader elections.
+	MaxInflightRequests      int           `conf:"max_inflight_requests,64"`
+	RequestTimeout           time.Duration `conf:"request_timeout,3s"`
+	KeepaliveTime            time.Duration `conf:"keepalive_time,30s"`
+	KeepaliveTimeout         time.Duration `conf:"keepalive_timeout,10s"`
+	EndpointAutoSyncInterval time.Duration `conf:"endpoint_auto_sync_interval,5m"`
 }
 
 type BackendProperties struct {
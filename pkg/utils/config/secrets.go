@@ -0,0 +1,136 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Secret references keep credentials out of the configuration file. A string
+// config value written as one of
+//
+//	env://NAME                        the NAME environment variable
+//	file:///etc/opensds/etcd.pass     the content of the file
+//	vault://secret/data/opensds#key   a field of a secret read from Vault
+//
+// is resolved to the actual value when the configuration is loaded, and again
+// on every reload so rotated secrets are picked up. The Vault scheme talks to
+// the server's HTTP API, addressed and authenticated through the VAULT_ADDR
+// and VAULT_TOKEN environment variables, and understands both the kv v1 and
+// the nested kv v2 response layout. Errors and logs only ever carry the
+// reference, never the value it resolved to.
+const (
+	secretRefEnvScheme   = "env://"
+	secretRefFileScheme  = "file://"
+	secretRefVaultScheme = "vault://"
+)
+
+// vaultRequestTimeout bounds how long loading the configuration may block on
+// an unreachable Vault server.
+const vaultRequestTimeout = 10 * time.Second
+
+// isSecretRef tells whether a config value is a reference to be resolved
+// rather than the value itself.
+func isSecretRef(value string) bool {
+	return strings.HasPrefix(value, secretRefEnvScheme) ||
+		strings.HasPrefix(value, secretRefFileScheme) ||
+		strings.HasPrefix(value, secretRefVaultScheme)
+}
+
+// resolveSecretRef resolves a secret reference to its value. Trailing
+// whitespace is stripped from file and environment values, so secrets written
+// with a trailing newline work unchanged.
+func resolveSecretRef(ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, secretRefEnvScheme):
+		name := strings.TrimPrefix(ref, secretRefEnvScheme)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return strings.TrimRight(value, "\r\n"), nil
+	case strings.HasPrefix(ref, secretRefFileScheme):
+		path := strings.TrimPrefix(ref, secretRefFileScheme)
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	case strings.HasPrefix(ref, secretRefVaultScheme):
+		return resolveVaultRef(strings.TrimPrefix(ref, secretRefVaultScheme))
+	}
+	return "", fmt.Errorf("unknown secret reference scheme in %s", ref)
+}
+
+// resolveVaultRef reads one field of a secret from Vault. The reference names
+// the secret path and the field separated by '#', for example
+// secret/data/opensds#etcdPassword.
+func resolveVaultRef(ref string) (string, error) {
+	parts := strings.SplitN(ref, "#", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("a vault reference must look like vault://<path>#<field>")
+	}
+	path, fieldName := parts[0], parts[1]
+
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+	req, err := http.NewRequest("GET", strings.TrimRight(addr, "/")+"/v1/"+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", os.Getenv("VAULT_TOKEN"))
+
+	client := &http.Client{Timeout: vaultRequestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned %s for %s", resp.Status, path)
+	}
+
+	var body struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode vault response for %s failed: %v", path, err)
+	}
+	// The kv v2 engine nests the fields one level deeper than v1.
+	fields := body.Data
+	if nested, ok := fields["data"]; ok {
+		var inner map[string]json.RawMessage
+		if err := json.Unmarshal(nested, &inner); err == nil {
+			fields = inner
+		}
+	}
+	raw, ok := fields[fieldName]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no field %s", path, fieldName)
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("field %s of secret %s is not a string", fieldName, path)
+	}
+	return value, nil
+}
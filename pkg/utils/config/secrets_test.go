@@ -0,0 +1,134 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveSecretRefEnv(t *testing.T) {
+	os.Setenv("OPENSDS_TEST_SECRET", "s3cret\n")
+	defer os.Unsetenv("OPENSDS_TEST_SECRET")
+
+	value, err := resolveSecretRef("env://OPENSDS_TEST_SECRET")
+	if err != nil {
+		t.Errorf("resolve env reference failed: %v", err)
+	}
+	if value != "s3cret" {
+		t.Errorf("expected s3cret, got %s", value)
+	}
+
+	if _, err := resolveSecretRef("env://OPENSDS_TEST_SECRET_MISSING"); err == nil {
+		t.Errorf("expected an error for an unset environment variable")
+	}
+}
+
+func TestResolveSecretRefFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	if err != nil {
+		t.Fatalf("create temp dir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "etcd.pass")
+	if err := ioutil.WriteFile(path, []byte("s3cret\n"), 0600); err != nil {
+		t.Fatalf("write secret file failed: %v", err)
+	}
+
+	value, err := resolveSecretRef("file://" + path)
+	if err != nil {
+		t.Errorf("resolve file reference failed: %v", err)
+	}
+	if value != "s3cret" {
+		t.Errorf("expected s3cret, got %s", value)
+	}
+}
+
+func TestResolveSecretRefVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/opensds":
+			// The kv v2 layout nests the fields one level deeper.
+			w.Write([]byte(`{"data":{"data":{"etcdPassword":"s3cret"}}}`))
+		case "/v1/kv/opensds":
+			w.Write([]byte(`{"data":{"etcdPassword":"s3cret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	os.Setenv("VAULT_ADDR", server.URL)
+	os.Setenv("VAULT_TOKEN", "test-token")
+	defer os.Unsetenv("VAULT_ADDR")
+	defer os.Unsetenv("VAULT_TOKEN")
+
+	for _, ref := range []string{
+		"vault://secret/data/opensds#etcdPassword",
+		"vault://kv/opensds#etcdPassword",
+	} {
+		value, err := resolveSecretRef(ref)
+		if err != nil {
+			t.Errorf("resolve %s failed: %v", ref, err)
+		}
+		if value != "s3cret" {
+			t.Errorf("expected s3cret for %s, got %s", ref, value)
+		}
+	}
+
+	_, err := resolveSecretRef("vault://secret/data/opensds#missing")
+	if err == nil {
+		t.Errorf("expected an error for a missing field")
+	} else if strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("error %q leaks the secret value", err.Error())
+	}
+
+	if _, err := resolveSecretRef("vault://secret/data/opensds"); err == nil {
+		t.Errorf("expected an error for a reference without a field")
+	}
+}
+
+func TestSecretRefConfigLoad(t *testing.T) {
+	os.Setenv("OPENSDS_TEST_SECRET", "s3cret")
+	defer os.Unsetenv("OPENSDS_TEST_SECRET")
+
+	dir, err := ioutil.TempDir("", "secrets")
+	if err != nil {
+		t.Fatalf("create temp dir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	confFile := filepath.Join(dir, "opensds.conf")
+	content := "[database]\nusername = admin\npassword = env://OPENSDS_TEST_SECRET\n"
+	if err := ioutil.WriteFile(confFile, []byte(content), 0600); err != nil {
+		t.Fatalf("write conf file failed: %v", err)
+	}
+
+	conf := new(Config)
+	initConf(confFile, conf)
+	if conf.Database.Username != "admin" {
+		t.Errorf("expected username admin, got %s", conf.Database.Username)
+	}
+	if conf.Database.Password != "s3cret" {
+		t.Errorf("expected the password reference to be resolved, got %s", conf.Database.Password)
+	}
+}
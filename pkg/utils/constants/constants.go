@@ -19,14 +19,27 @@ const (
 	// exactly precision to seconds.
 	TimeFormat = `2006-01-02T15:04:05`
 
+	// RFC 3339 with an explicit timezone, the format new records are
+	// written in when timestamp_format = rfc3339 is configured. Readers
+	// accept both formats, so existing records stay valid.
+	TimeFormatRFC3339 = `2006-01-02T15:04:05Z07:00`
+
+	// The timestamp_format value that switches new records to RFC 3339 UTC.
+	TimestampFormatRFC3339 = "rfc3339"
+
 	DefaultOpensdsEndpoint = "http://localhost:50040"
 
 	// This is set for None Auth
 	DefaultTenantId = "e93b4c0934da416eb9c8d120c5d04d96"
 
 	// Token parameter name
-	AuthTokenHeader    = "X-Auth-Token"
-	SubjectTokenHeader = "X-Subject-Token"
+	AuthTokenHeader       = "X-Auth-Token"
+	AuthApiKeyHeader      = "X-Auth-Apikey"
+	AuthScopedTokenHeader = "X-Auth-ScopedToken"
+	SubjectTokenHeader    = "X-Subject-Token"
+
+	// Bootstrap token parameter name for dock registration
+	DockBootstrapTokenHeader = "X-Dock-Bootstrap-Token"
 
 	// ContentType for api
 	ContentType = "application/json"
@@ -55,6 +68,13 @@ const (
 	Block = "block"
 	File  = "file"
 
+	// Consistency levels a snapshot can be taken with. A crash consistent
+	// snapshot is the point-in-time image a power loss would leave behind,
+	// an application consistent snapshot quiesces the workload through the
+	// configured webhook around the cut.
+	SnapshotCrashConsistent       = "crash"
+	SnapshotApplicationConsistent = "application"
+
 	//StorageAccessCApability enum constants for profile
 	Read    = "Read"
 	Write   = "Write"
@@ -0,0 +1,48 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sodafoundation/api/pkg/utils/config"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+// FormatTime renders t in the configured api timestamp format. v1beta has
+// always written a local time format without timezone, which clients can
+// not parse reliably; with timestamp_format = rfc3339 new records are
+// written as RFC 3339 UTC instead.
+func FormatTime(t time.Time) string {
+	if config.CONF.OsdsApiServer.TimestampFormat == constants.TimestampFormatRFC3339 {
+		return t.UTC().Format(constants.TimeFormatRFC3339)
+	}
+	return t.Format(constants.TimeFormat)
+}
+
+// ParseTime parses an api timestamp, accepting both the RFC 3339 format and
+// the historical v1beta format, so records written before and after the
+// migration can be read alike.
+func ParseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(constants.TimeFormatRFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(constants.TimeFormat, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %s, expected the %s or %s format",
+		value, constants.TimeFormatRFC3339, constants.TimeFormat)
+}
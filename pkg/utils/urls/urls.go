@@ -31,6 +31,10 @@ func GenerateHostURL(urlType int, tenantId string, in ...string) string {
 	return generateURL("host/hosts", urlType, tenantId, in...)
 }
 
+func GenerateHostGroupURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("host/groups", urlType, tenantId, in...)
+}
+
 func GenerateFileShareAclURL(urlType int, tenantId string, in ...string) string {
 	return generateURL("file/acls", urlType, tenantId, in...)
 }
@@ -43,6 +47,74 @@ func GenerateFileShareSnapshotURL(urlType int, tenantId string, in ...string) st
 	return generateURL("file/snapshots", urlType, tenantId, in...)
 }
 
+func GenerateFileShareSnapshotCopyURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("file/snapshotCopies", urlType, tenantId, in...)
+}
+
+func GenerateBackupTargetURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("file/backupTargets", urlType, tenantId, in...)
+}
+
+func GenerateFileShareSnapshotBackupURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("file/snapshotBackups", urlType, tenantId, in...)
+}
+
+func GenerateAnnotationURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("annotations", urlType, tenantId, in...)
+}
+
+func GenerateApiKeyURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("apikeys", urlType, tenantId, in...)
+}
+
+func GenerateScopedTokenURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("scopedTokens", urlType, tenantId, in...)
+}
+
+func GenerateNetworkPolicyURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("networkPolicies", urlType, tenantId, in...)
+}
+
+func GenerateTombstoneURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("tombstones", urlType, tenantId, in...)
+}
+
+func GenerateCascadeTaskURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("cascadeTasks", urlType, tenantId, in...)
+}
+
+func GenerateTaskURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("tasks", urlType, tenantId, in...)
+}
+
+func GenerateJobURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("jobs", urlType, tenantId, in...)
+}
+
+func GenerateShareNetworkURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("shareNetworks", urlType, tenantId, in...)
+}
+
+func GenerateResourceClassURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("resourceClasses", urlType, tenantId, in...)
+}
+
+func GenerateRegionURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("regions", urlType, tenantId, in...)
+}
+
+func GenerateQuotaURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("quotas", urlType, tenantId, in...)
+}
+
+func GenerateDefaultTagsURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("defaultTags", urlType, tenantId, in...)
+}
+
+func GeneratePendingOperationURL(urlType int, tenantId string, in ...string) string {
+	return generateURL("pendingOperations", urlType, tenantId, in...)
+}
+
 func GenerateDockURL(urlType int, tenantId string, in ...string) string {
 	return generateURL("docks", urlType, tenantId, in...)
 }
@@ -22,6 +22,7 @@ import (
 	"os"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -221,8 +222,14 @@ func IsEqual(key string, value interface{}, reqValue interface{}) (bool, error)
 	}
 }
 
-// Filter filters slice by struct field
+// Filter filters slice by struct field. The parameter keys are the json
+// names the api serves, resolved through the json tags of the spec, and
+// the values are compared in the type of the field: numbers numerically,
+// booleans as booleans and everything else as strings. Numeric values may
+// carry a leading comparison operator, so both ?size=>=10 and its url
+// shorthand ?size>=10 select the records of at least that size.
 func Filter(arr interface{}, params map[string][]string) interface{} {
+	params = normalizeParams(params)
 	in := reflect.ValueOf(arr)
 	out := make([]interface{}, 0, in.Len())
 
@@ -230,22 +237,13 @@ func Filter(arr interface{}, params map[string][]string) interface{} {
 		element := in.Index(i).Interface()
 		ve := reflect.ValueOf(element).Elem()
 		satisfied := true
-		for key := range params {
-			fieldValue := ve.FieldByName(strings.Title(key))
+		for key, values := range params {
+			fieldValue := queryField(ve, key)
 			if !fieldValue.IsValid() {
 				continue
 			}
-			// Considering multiple input for one parameter, like: ?id=xx1&id=xx2
-			for j, value := range params[key] {
-				if reflect.DeepEqual(fmt.Sprintf("%v", fieldValue), value) {
-					break
-				}
-				if j == len(params[key])-1 {
-					satisfied = false
-				}
-			}
-
-			if !satisfied {
+			if !matchValues(fieldValue, values) {
+				satisfied = false
 				break
 			}
 		}
@@ -257,6 +255,145 @@ func Filter(arr interface{}, params map[string][]string) interface{} {
 	return out
 }
 
+// normalizeParams folds the ?size>=10 shorthand, which url parsing turns
+// into the key "size>" with the value "10", into the operator prefixed
+// form the value comparison understands.
+func normalizeParams(params map[string][]string) map[string][]string {
+	out := make(map[string][]string, len(params))
+	for key, values := range params {
+		if k := strings.TrimRight(key, "<>"); k != key {
+			op := key[len(k):] + "="
+			for _, value := range values {
+				out[k] = append(out[k], op+value)
+			}
+			continue
+		}
+		out[key] = append(out[key], values...)
+	}
+	return out
+}
+
+// queryField resolves a query parameter key to the struct field it
+// filters on. The json tag of the field is authoritative, since the keys
+// clients send are the names the api serves; the title-cased field name
+// is kept as a fallback for tagless fields and for the fields promoted
+// from the embedded base model.
+func queryField(ve reflect.Value, key string) reflect.Value {
+	t := ve.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]; tag == key {
+			return ve.Field(i)
+		}
+	}
+	return ve.FieldByName(strings.Title(key))
+}
+
+// matchValues applies every value of one parameter to a field. Values
+// carrying a comparison operator must all hold, so a range reads
+// ?size=>=10&size=<=100, while plain values keep their historic or
+// semantics: ?id=xx1&id=xx2 matches either id.
+func matchValues(fieldValue reflect.Value, values []string) bool {
+	anyPlain, plainSeen := false, false
+	for _, value := range values {
+		if op, _ := splitOperator(value); op != "" {
+			if !matchTyped(fieldValue, value) {
+				return false
+			}
+			continue
+		}
+		plainSeen = true
+		if matchTyped(fieldValue, value) {
+			anyPlain = true
+		}
+	}
+	return !plainSeen || anyPlain
+}
+
+// matchTyped compares a field against one query value in the type of the
+// field. A value that does not parse in that type falls back to plain
+// string equality, which keeps the historic behaviour for oddly formatted
+// input.
+func matchTyped(fieldValue reflect.Value, value string) bool {
+	op, operand := splitOperator(value)
+	switch fieldValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if want, err := strconv.ParseInt(operand, 10, 64); err == nil {
+			return holds(op, compareInt64(fieldValue.Int(), want))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if want, err := strconv.ParseUint(operand, 10, 64); err == nil {
+			return holds(op, compareUint64(fieldValue.Uint(), want))
+		}
+	case reflect.Float32, reflect.Float64:
+		if want, err := strconv.ParseFloat(operand, 64); err == nil {
+			return holds(op, compareFloat64(fieldValue.Float(), want))
+		}
+	case reflect.Bool:
+		// The comparison operators do not apply to booleans.
+		if want, err := strconv.ParseBool(operand); err == nil && op == "" {
+			return fieldValue.Bool() == want
+		}
+	}
+	return fmt.Sprintf("%v", fieldValue) == value
+}
+
+// splitOperator peels a leading comparison operator off a query value,
+// e.g. ">=10" becomes ">=" and "10". An empty operator means equality.
+func splitOperator(value string) (string, string) {
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(value, op) {
+			return op, value[len(op):]
+		}
+	}
+	return "", value
+}
+
+// holds reports whether a comparison outcome, expressed as the sign of
+// got minus want, satisfies the operator.
+func holds(op string, sign int) bool {
+	switch op {
+	case ">":
+		return sign > 0
+	case ">=":
+		return sign >= 0
+	case "<":
+		return sign < 0
+	case "<=":
+		return sign <= 0
+	}
+	return sign == 0
+}
+
+func compareInt64(got, want int64) int {
+	switch {
+	case got < want:
+		return -1
+	case got > want:
+		return 1
+	}
+	return 0
+}
+
+func compareUint64(got, want uint64) int {
+	switch {
+	case got < want:
+		return -1
+	case got > want:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat64(got, want float64) int {
+	switch {
+	case got < want:
+		return -1
+	case got > want:
+		return 1
+	}
+	return 0
+}
+
 // Sorting sorts slice with struct field, note: only string and int are supported now
 func Sort(arr interface{}, sortKey, sortDir string) interface{} {
 	// Sorting
@@ -282,6 +419,34 @@ func Sort(arr interface{}, sortKey, sortDir string) interface{} {
 	return arr
 }
 
+// SliceAfterMarker drops every element up to and including the one whose
+// Id equals marker, implementing marker pagination: a client pages with
+// ?marker=<last seen id>&limit=N instead of an offset that drifts while
+// records are created and deleted between pages. The marker is looked up
+// in the already filtered and sorted slice; when it is not found - for
+// example because the record was deleted between pages - the result is
+// empty, which ends the iteration.
+func SliceAfterMarker(arr interface{}, marker string) interface{} {
+	in := reflect.ValueOf(arr)
+	out := make([]interface{}, 0, in.Len())
+	found := false
+	for i := 0; i < in.Len(); i++ {
+		element := in.Index(i).Interface()
+		if found {
+			out = append(out, element)
+			continue
+		}
+		id := reflect.ValueOf(element).Elem().FieldByName("Id")
+		if id.IsValid() && id.Kind() == reflect.String && id.String() == marker {
+			found = true
+		}
+	}
+	if !found {
+		log.Warning("The marker", marker, "does not name a record on any page, returning an empty page")
+	}
+	return out
+}
+
 // Slicing implements pagination
 func Slice(arr interface{}, offset, limit int) interface{} {
 	// Slicing
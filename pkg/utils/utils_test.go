@@ -324,6 +324,60 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+// record covers every kind of field the model specs filter on, with json
+// tags resolving the query keys like the real specs do.
+type record struct {
+	Name      string  `json:"name"`
+	Size      int64   `json:"size"`
+	Count     uint    `json:"count"`
+	Density   float64 `json:"density"`
+	Shared    bool    `json:"shared"`
+	LegacyRef string  `json:"ref"`
+}
+
+func TestFilterTyped(t *testing.T) {
+
+	records := []*record{
+		{Name: "small", Size: 1, Count: 2, Density: 0.5, Shared: false, LegacyRef: "a"},
+		{Name: "medium", Size: 10, Count: 20, Density: 1.5, Shared: true, LegacyRef: "b"},
+		{Name: "large", Size: 100, Count: 200, Density: 2.5, Shared: true, LegacyRef: "c"},
+	}
+
+	testCases := []struct {
+		description string
+		params      map[string][]string
+		expected    []string
+	}{
+		{"int equality ignores formatting", map[string][]string{"size": {"010"}}, []string{"medium"}},
+		{"int at least", map[string][]string{"size": {">=10"}}, []string{"medium", "large"}},
+		{"int at most", map[string][]string{"size": {"<=10"}}, []string{"small", "medium"}},
+		{"int strictly greater", map[string][]string{"size": {">10"}}, []string{"large"}},
+		{"int strictly smaller", map[string][]string{"size": {"<10"}}, []string{"small"}},
+		{"int range combines with and", map[string][]string{"size": {">=10", "<=10"}}, []string{"medium"}},
+		{"int url shorthand key", map[string][]string{"size>": {"10"}}, []string{"medium", "large"}},
+		{"plain values keep or semantics", map[string][]string{"size": {"1", "100"}}, []string{"small", "large"}},
+		{"uint comparison", map[string][]string{"count": {">=20"}}, []string{"medium", "large"}},
+		{"float comparison", map[string][]string{"density": {">1.0"}}, []string{"medium", "large"}},
+		{"bool equality", map[string][]string{"shared": {"true"}}, []string{"medium", "large"}},
+		{"bool accepts other spellings", map[string][]string{"shared": {"False"}}, []string{"small"}},
+		{"string stays an exact match", map[string][]string{"name": {"medium"}}, []string{"medium"}},
+		{"key resolves through the json tag", map[string][]string{"ref": {"c"}}, []string{"large"}},
+		{"unknown key filters nothing", map[string][]string{"offset": {"0"}}, []string{"small", "medium", "large"}},
+		{"unparsable number matches nothing", map[string][]string{"size": {"ten"}}, nil},
+	}
+
+	for _, c := range testCases {
+		results := Filter(records, c.params).([]interface{})
+		var names []string
+		for _, r := range results {
+			names = append(names, r.(*record).Name)
+		}
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.description, c.expected, names)
+		}
+	}
+}
+
 func TestSort(t *testing.T) {
 
 	testCases := []struct {
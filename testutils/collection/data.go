@@ -346,6 +346,180 @@ var (
 		},
 	}
 
+	SampleAnnotations = []model.AnnotationSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id:        "f5a34f2e-a1b2-11e7-985e-d72c7f62af29",
+				CreatedAt: "2019-11-11T11:01:33",
+			},
+			ResourceType: "fileshare",
+			ResourceId:   "d2975ebe-d82c-430f-b28e-f373746a71ca",
+			Note:         "extended per ticket 4521",
+		},
+	}
+
+	SampleApiKeys = []model.ApiKeySpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id:        "4c1d7e29-a1b2-11e7-985e-d72c7f62af29",
+				CreatedAt: "2019-11-11T11:01:33",
+			},
+			TenantId: "x99ab1f4-235d-454e-91b4-25f5c757f6c3",
+			Name:     "ci-runner",
+			Role:     "user",
+			// sha256 of "fake-api-key-secret"
+			HashedSecret: "bc8cfcb8168ae97d5537ba3c9e8f217e07132af1f062e0978e5629e60e08a1e8",
+		},
+	}
+
+	SampleScopedTokens = []model.ScopedTokenSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id:        "9b3e4c0a-a1b2-11e7-985e-d72c7f62af29",
+				CreatedAt: "2019-11-11T11:01:33",
+			},
+			TenantId:    "x99ab1f4-235d-454e-91b4-25f5c757f6c3",
+			Name:        "nightly-backup",
+			Scope:       []string{"fileshare_snapshot:create"},
+			ResourceIds: []string{"d2975ebe-d82c-430f-b28e-f373746a71ca"},
+			ExpiresAt:   "2119-11-11T11:01:33",
+			// sha256 of "fake-scoped-token-secret"
+			HashedSecret: "d371925d05a4021a9393e12dfa7683f33b7c55da831b4e35594475d137467981",
+		},
+	}
+
+	SampleFileShareSnapshotCopies = []model.FileShareSnapshotCopySpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "e4f2bbd2-a102-11e7-b9f4-6f5e2b4f3c22",
+			},
+			SnapshotId:     "3769855c-a102-11e7-b772-17b880d2f537",
+			FileShareId:    "d2975ebe-d82c-430f-b28e-f373746a71ca",
+			TargetEndpoint: "http://remote-soda:50040",
+			Status:         "copying",
+		},
+	}
+
+	SampleNetworkPolicies = []model.NetworkPolicySpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "7a7e1a06-66f0-11e7-9ae0-2fbbbf092f12",
+			},
+			Description:  "sample network policy",
+			AllowedCidrs: []string{"10.0.0.0/8"},
+			DeniedCidrs:  []string{"10.1.0.0/16"},
+		},
+	}
+
+	SampleResourceClasses = []model.ResourceClassSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "3b8b1f56-6f72-11e9-9c6a-3b0ac2523f45",
+			},
+			Name:                     "sample-resource-class",
+			Description:              "sample resource class",
+			Tenants:                  []string{"x7a91c0a2ab41b9cf76f71a91b"},
+			RequiredTags:             []string{"cost-center"},
+			NamingPattern:            "^[a-z][a-z0-9-]*$",
+			MinSize:                  1,
+			MaxSize:                  100,
+			AllowedAvailabilityZones: []string{"default"},
+		},
+	}
+
+	SampleTombstones = []model.TombstoneSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id:        "5c8f2f9e-6f78-11e9-8cb4-8f1c8d2f0d4e",
+				CreatedAt: "2019-05-05T10:00:00",
+			},
+			ResourceType: model.TombstoneResourceFileShare,
+			ResourceId:   "d2975ebe-d82c-430f-b28e-f373746a71ca",
+			Name:         "sample-fileshare-01",
+			TenantId:     "e93b4c0934da416eb9c8d120c5d04d96",
+			Size:         1,
+			DeletedAt:    "2019-05-05T10:00:00",
+			DeletedBy:    "558057c4256545bd8a307c37464003c9",
+		},
+		{
+			BaseModel: &model.BaseModel{
+				Id:        "721e1fb2-6f78-11e9-9601-a73cc2a432d5",
+				CreatedAt: "2019-05-06T11:30:00",
+			},
+			ResourceType: model.TombstoneResourceVolume,
+			ResourceId:   "bd5b12a8-a101-11e7-941e-d77981b584d8",
+			Name:         "sample-volume",
+			TenantId:     "e93b4c0934da416eb9c8d120c5d04d96",
+			Size:         1,
+			DeletedAt:    "2019-05-06T11:30:00",
+			DeletedBy:    "558057c4256545bd8a307c37464003c9",
+		},
+	}
+
+	SampleRegions = []model.RegionSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "9a2c5e8a-7a94-11e9-bd36-17d27ce58a31",
+			},
+			Name:         "eu-west",
+			Description:  "sample peer region",
+			Url:          "https://soda.eu-west.example.com:50040",
+			Capabilities: []string{"block", "file", "replication"},
+			Status:       model.RegionStatusUp,
+		},
+	}
+
+	SampleQuotas = []model.QuotaSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "c2a6b9e4-7bb2-11e9-9d4d-df31ab2f64d5",
+			},
+			TenantId: "e93b4c0934da416eb9c8d120c5d04d96",
+			GbQuota:  100,
+		},
+	}
+
+	SampleDefaultTags = []model.DefaultTagsSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "5f8a2b9c-7bd4-11e9-b5f2-2b43091d3a7e",
+			},
+			TenantId: "e93b4c0934da416eb9c8d120c5d04d96",
+			Tags: map[string]string{
+				"costCenter":  "cc-1042",
+				"environment": "staging",
+			},
+		},
+	}
+
+	SampleBackupTargets = []model.BackupTargetSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "2f9c0a04-66ef-11e7-ade2-43158893e017",
+			},
+			Name:      "sample-backup-target",
+			Type:      "s3",
+			Endpoint:  "http://s3.example.com",
+			Region:    "default",
+			Bucket:    "opensds-backups",
+			AccessKey: "AKSAMPLE",
+			SecretKey: "sample-secret-key",
+		},
+	}
+
+	SampleFileShareSnapshotBackups = []model.FileShareSnapshotBackupSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id: "51f1f1d4-66ef-11e7-a2a9-7f6e0a0c8d4a",
+			},
+			SnapshotId:  "3769855c-a102-11e7-b772-17b880d2f537",
+			FileShareId: "d2975ebe-d82c-430f-b28e-f373746a71ca",
+			TargetId:    "2f9c0a04-66ef-11e7-ade2-43158893e017",
+			Status:      "available",
+			Location:    "opensds-backups/d2975ebe-d82c-430f-b28e-f373746a71ca/51f1f1d4-66ef-11e7-a2a9-7f6e0a0c8d4a",
+		},
+	}
+
 	SampleVolumeNames = []string{}
 
 	SampleVolumes = []model.VolumeSpec{
@@ -655,6 +829,22 @@ var (
 			},
 		},
 	}
+
+	SampleHostGroups = []model.HostGroupSpec{
+		{
+			BaseModel: &model.BaseModel{
+				Id:        "f2a27cd8-935b-47b9-9a0a-4c0d3d021f46",
+				CreatedAt: "2019-11-11T11:28:04",
+			},
+			TenantId:    "x",
+			Name:        "sap-cluster",
+			Description: "hosts of the sap cluster",
+			HostIds: []string{
+				"202964b5-8e73-46fd-b41b-a8e403f3c30b",
+				"eb73e59a-8b0f-4517-8b95-023ec134aec9",
+			},
+		},
+	}
 )
 
 // The Byte*** variable here is designed for unit test in client package.
@@ -1281,4 +1471,25 @@ var (
 			"profileId":         "1106b972-66ef-11e7-b172-db03f3689c9c"
 		}`,
 	}
+
+	StringSliceFileSharesAcl = []string{
+		`{
+			"id":               "6ad25d59-a160-45b2-8920-211be282e2df",
+			"description":      "This is a sample Acl for testing",
+			"profileId":        "1106b972-66ef-11e7-b172-db03f3689c9c",
+			"type":             "ip",
+			"accessCapability": ["Read", "Write"],
+			"accessTo":         "10.32.109.15",
+			"fileshareId":      "d2975ebe-d82c-430f-b28e-f373746a71ca"
+		}`,
+		`{
+			"id":               "ad25d59-a160-45b2-8920-211be282e2dfh",
+			"description":      "This is a sample Acl for testing",
+			"profileId":        "1106b972-66ef-11e7-b172-db03f3689c9c",
+			"type":             "ip",
+			"accessCapability": ["Read"],
+			"accessTo":         "10.32.109.151",
+			"fileshareId":      "d2975ebe-d82c-430f-b28e-f373746a71ca"
+		}`,
+	}
 )
@@ -0,0 +1,486 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conformance exercises every endpoint family of a live api
+// endpoint and reports where its behavior diverges from the blessed one:
+// status codes, response schemas and the state machines of provisioned
+// resources. Vendors integrating a dock run it against their deployment to
+// prove api compatibility. The suite provisions real resources, so it is
+// meant to run with its own tenant; everything it creates is cleaned up
+// again.
+package conformance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sodafoundation/api/client"
+	"github.com/sodafoundation/api/pkg/model"
+	"github.com/sodafoundation/api/pkg/utils/constants"
+)
+
+// Result is the outcome of one conformance check.
+type Result struct {
+	// The family and name of the check, for example "volume/lifecycle".
+	Name string
+
+	// Err is set when the endpoint diverged from the expected behavior.
+	Err error
+
+	// Skipped checks could not run against this deployment, for example
+	// because no pool can provision the resource; SkipReason says why.
+	Skipped    bool
+	SkipReason string
+
+	// How long the check took.
+	Duration time.Duration
+}
+
+// Passed reports whether the check ran and the endpoint conformed.
+func (r *Result) Passed() bool {
+	return r.Err == nil && !r.Skipped
+}
+
+// Suite runs the conformance checks against one endpoint.
+type Suite struct {
+	Client *client.Client
+
+	// PollInterval and Timeout bound the state machine waits, for example
+	// for a volume to leave "creating". The zero values mean one second
+	// and two minutes.
+	PollInterval time.Duration
+	Timeout      time.Duration
+}
+
+// NewSuite returns a suite with the default waits.
+func NewSuite(cli *client.Client) *Suite {
+	return &Suite{
+		Client:       cli,
+		PollInterval: time.Second,
+		Timeout:      2 * time.Minute,
+	}
+}
+
+// Run executes every check family in order and returns one result per
+// check. Families build on each other (the volume checks use the profile
+// the profile checks verified), so the order is fixed.
+func (s *Suite) Run() []*Result {
+	var results []*Result
+	run := func(name string, check func() error) {
+		start := time.Now()
+		result := &Result{Name: name, Err: check()}
+		result.Duration = time.Since(start)
+		var skip *skipError
+		if errors.As(result.Err, &skip) {
+			result.Err = nil
+			result.Skipped = true
+			result.SkipReason = skip.reason
+		}
+		results = append(results, result)
+	}
+
+	run("version/discovery", s.checkVersions)
+	run("pool/discovery", s.checkPools)
+	run("profile/lifecycle", s.checkProfileLifecycle)
+	run("host/lifecycle", s.checkHostLifecycle)
+	run("volume/lifecycle", s.checkVolumeLifecycle)
+	run("fileshare/lifecycle", s.checkFileShareLifecycle)
+	return results
+}
+
+// Failures filters the results down to the checks where the endpoint
+// diverged.
+func Failures(results []*Result) []*Result {
+	var failed []*Result
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// skipError aborts a check that can not run against this deployment
+// without counting it as a divergence.
+type skipError struct {
+	reason string
+}
+
+func (e *skipError) Error() string {
+	return e.reason
+}
+
+func skipf(format string, args ...interface{}) error {
+	return &skipError{reason: fmt.Sprintf(format, args...)}
+}
+
+// checkVersions verifies version discovery: the current version is listed
+// and readable, an unknown one answers not found.
+func (s *Suite) checkVersions() error {
+	versions, err := s.Client.ListVersions()
+	if err != nil {
+		return fmt.Errorf("list versions failed: %v", err)
+	}
+	var current *model.VersionSpec
+	for _, version := range versions {
+		if version.Name == constants.APIVersion {
+			current = version
+		}
+	}
+	if current == nil {
+		return fmt.Errorf("version %s is not listed", constants.APIVersion)
+	}
+	if current.Status == "" {
+		return fmt.Errorf("version %s carries no status", constants.APIVersion)
+	}
+	if _, err := s.Client.GetVersion(constants.APIVersion); err != nil {
+		return fmt.Errorf("get version %s failed: %v", constants.APIVersion, err)
+	}
+	if _, err := s.Client.GetVersion("v0unknown"); !errors.Is(err, client.ErrNotFound) {
+		return fmt.Errorf("get of an unknown version should answer not found, got %v", err)
+	}
+	return nil
+}
+
+// checkPools verifies pool discovery reports consistent records.
+func (s *Suite) checkPools() error {
+	pools, err := s.Client.ListPools()
+	if err != nil {
+		return fmt.Errorf("list pools failed: %v", err)
+	}
+	for _, pool := range pools {
+		if pool.Id == "" {
+			return fmt.Errorf("a listed pool carries no id")
+		}
+		if _, err := s.Client.GetPool(pool.Id); err != nil {
+			return fmt.Errorf("get pool %s failed: %v", pool.Id, err)
+		}
+	}
+	return nil
+}
+
+// checkProfileLifecycle walks a profile through create, get, list, update
+// and delete, and verifies a deleted profile is gone.
+func (s *Suite) checkProfileLifecycle() error {
+	name := fmt.Sprintf("conformance-%d", time.Now().UnixNano())
+	created, err := s.Client.CreateProfile(&model.ProfileSpec{
+		Name:        name,
+		Description: "created by the api conformance suite",
+		StorageType: "block",
+	})
+	if err != nil {
+		return fmt.Errorf("create profile failed: %v", err)
+	}
+	if created.Id == "" || created.Name != name {
+		return fmt.Errorf("created profile echoes id %q name %q", created.Id, created.Name)
+	}
+	defer s.Client.DeleteProfile(created.Id)
+
+	got, err := s.Client.GetProfile(created.Id)
+	if err != nil {
+		return fmt.Errorf("get profile failed: %v", err)
+	}
+	if got.Name != name || got.StorageType != "block" {
+		return fmt.Errorf("got back profile name %q storage type %q", got.Name, got.StorageType)
+	}
+
+	listed, err := s.Client.ListProfiles()
+	if err != nil {
+		return fmt.Errorf("list profiles failed: %v", err)
+	}
+	found := false
+	for _, profile := range listed {
+		found = found || profile.Id == created.Id
+	}
+	if !found {
+		return fmt.Errorf("created profile %s is not listed", created.Id)
+	}
+
+	updated, err := s.Client.UpdateProfile(created.Id, &model.ProfileSpec{
+		Description: "updated by the api conformance suite",
+	})
+	if err != nil {
+		return fmt.Errorf("update profile failed: %v", err)
+	}
+	if updated.Description != "updated by the api conformance suite" {
+		return fmt.Errorf("updated profile echoes description %q", updated.Description)
+	}
+
+	if err := s.Client.DeleteProfile(created.Id); err != nil {
+		return fmt.Errorf("delete profile failed: %v", err)
+	}
+	if _, err := s.Client.GetProfile(created.Id); !errors.Is(err, client.ErrNotFound) {
+		return fmt.Errorf("get of a deleted profile should answer not found, got %v", err)
+	}
+	return nil
+}
+
+// checkHostLifecycle walks a host record through create, get, update and
+// delete.
+func (s *Suite) checkHostLifecycle() error {
+	name := fmt.Sprintf("conformance-host-%d", time.Now().UnixNano())
+	created, err := s.Client.CreateHost(&model.HostSpec{
+		HostName:   name,
+		IP:         "127.0.0.1",
+		OsType:     "linux",
+		AccessMode: "agentless",
+	})
+	if err != nil {
+		return fmt.Errorf("create host failed: %v", err)
+	}
+	defer s.Client.DeleteHost(created.Id)
+
+	got, err := s.Client.GetHost(created.Id)
+	if err != nil {
+		return fmt.Errorf("get host failed: %v", err)
+	}
+	if got.HostName != name {
+		return fmt.Errorf("got back host name %q", got.HostName)
+	}
+
+	if err := s.Client.DeleteHost(created.Id); err != nil {
+		return fmt.Errorf("delete host failed: %v", err)
+	}
+	if _, err := s.Client.GetHost(created.Id); !errors.Is(err, client.ErrNotFound) {
+		return fmt.Errorf("get of a deleted host should answer not found, got %v", err)
+	}
+	return nil
+}
+
+// checkVolumeLifecycle provisions a volume and walks it through the
+// documented state machine: creating to available, a snapshot, an extend
+// and the delete. It is skipped when no pool can provision block storage.
+func (s *Suite) checkVolumeLifecycle() error {
+	profile, err := s.provisionableProfile("block")
+	if err != nil {
+		return err
+	}
+	defer s.Client.DeleteProfile(profile.Id)
+
+	volume, err := s.Client.CreateVolume(&model.VolumeSpec{
+		Name:      fmt.Sprintf("conformance-%d", time.Now().UnixNano()),
+		ProfileId: profile.Id,
+		Size:      1,
+	})
+	if err != nil {
+		return fmt.Errorf("create volume failed: %v", err)
+	}
+	defer s.Client.DeleteVolume(volume.Id, &model.VolumeSpec{})
+
+	if err := s.waitVolumeStatus(volume.Id, model.VolumeAvailable); err != nil {
+		return err
+	}
+
+	snapshot, err := s.Client.CreateVolumeSnapshot(&model.VolumeSnapshotSpec{
+		Name:     fmt.Sprintf("conformance-snap-%d", time.Now().UnixNano()),
+		VolumeId: volume.Id,
+	})
+	if err != nil {
+		return fmt.Errorf("create volume snapshot failed: %v", err)
+	}
+	if err := s.waitVolumeSnapshotStatus(snapshot.Id, model.VolumeSnapAvailable); err != nil {
+		return err
+	}
+	if err := s.Client.DeleteVolumeSnapshot(snapshot.Id, nil); err != nil {
+		return fmt.Errorf("delete volume snapshot failed: %v", err)
+	}
+	if err := s.waitGone(func() error {
+		_, err := s.Client.GetVolumeSnapshot(snapshot.Id)
+		return err
+	}); err != nil {
+		return fmt.Errorf("deleted volume snapshot is still readable: %v", err)
+	}
+
+	if _, err := s.Client.ExtendVolume(volume.Id, &model.ExtendVolumeSpec{NewSize: 2}); err != nil {
+		return fmt.Errorf("extend volume failed: %v", err)
+	}
+	if err := s.waitVolumeStatus(volume.Id, model.VolumeAvailable); err != nil {
+		return err
+	}
+	extended, err := s.Client.GetVolume(volume.Id)
+	if err != nil {
+		return fmt.Errorf("get volume after extend failed: %v", err)
+	}
+	if extended.Size != 2 {
+		return fmt.Errorf("extended volume reports size %d, expected 2", extended.Size)
+	}
+
+	if err := s.Client.DeleteVolume(volume.Id, &model.VolumeSpec{}); err != nil {
+		return fmt.Errorf("delete volume failed: %v", err)
+	}
+	if err := s.waitGone(func() error {
+		_, err := s.Client.GetVolume(volume.Id)
+		return err
+	}); err != nil {
+		return fmt.Errorf("deleted volume is still readable: %v", err)
+	}
+	return nil
+}
+
+// checkFileShareLifecycle provisions a fileshare, grants and revokes an
+// acl and deletes it again. It is skipped when no pool can provision file
+// storage.
+func (s *Suite) checkFileShareLifecycle() error {
+	profile, err := s.provisionableProfile("file")
+	if err != nil {
+		return err
+	}
+	defer s.Client.DeleteProfile(profile.Id)
+
+	share, err := s.Client.CreateFileShare(&model.FileShareSpec{
+		Name:      fmt.Sprintf("conformance-%d", time.Now().UnixNano()),
+		ProfileId: profile.Id,
+		Size:      1,
+	})
+	if err != nil {
+		return fmt.Errorf("create fileshare failed: %v", err)
+	}
+	defer s.Client.DeleteFileShare(share.Id)
+
+	if err := s.waitFileShareStatus(share.Id, model.FileShareAvailable); err != nil {
+		return err
+	}
+
+	acl, err := s.Client.CreateFileShareAcl(&model.FileShareAclSpec{
+		FileShareId:      share.Id,
+		ProfileId:        profile.Id,
+		Type:             "ip",
+		AccessTo:         "10.0.0.10",
+		AccessCapability: []string{"Read", "Write"},
+	})
+	if err != nil {
+		return fmt.Errorf("create fileshare acl failed: %v", err)
+	}
+	if err := s.Client.DeleteFileShareAcl(acl.Id); err != nil {
+		return fmt.Errorf("delete fileshare acl failed: %v", err)
+	}
+
+	if err := s.Client.DeleteFileShare(share.Id); err != nil {
+		return fmt.Errorf("delete fileshare failed: %v", err)
+	}
+	if err := s.waitGone(func() error {
+		_, err := s.Client.GetFileShare(share.Id)
+		return err
+	}); err != nil {
+		return fmt.Errorf("deleted fileshare is still readable: %v", err)
+	}
+	return nil
+}
+
+// provisionableProfile creates a throwaway profile of the storage type
+// when a pool of the type exists, and skips the check otherwise.
+func (s *Suite) provisionableProfile(storageType string) (*model.ProfileSpec, error) {
+	pools, err := s.Client.ListPools()
+	if err != nil {
+		return nil, fmt.Errorf("list pools failed: %v", err)
+	}
+	found := false
+	for _, pool := range pools {
+		found = found || pool.StorageType == storageType
+	}
+	if !found {
+		return nil, skipf("no pool provisions %s storage", storageType)
+	}
+	profile, err := s.Client.CreateProfile(&model.ProfileSpec{
+		Name:        fmt.Sprintf("conformance-%s-%d", storageType, time.Now().UnixNano()),
+		Description: "created by the api conformance suite",
+		StorageType: storageType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create %s profile failed: %v", storageType, err)
+	}
+	return profile, nil
+}
+
+// waitVolumeStatus polls the volume until it reaches the status. An error
+// status fails immediately instead of waiting out the timeout.
+func (s *Suite) waitVolumeStatus(volID, status string) error {
+	return s.wait(fmt.Sprintf("volume %s to become %s", volID, status), func() (bool, error) {
+		volume, err := s.Client.GetVolume(volID)
+		if err != nil {
+			return false, fmt.Errorf("get volume failed: %v", err)
+		}
+		if volume.Status == model.VolumeError {
+			return false, fmt.Errorf("volume %s entered the error status", volID)
+		}
+		return volume.Status == status, nil
+	})
+}
+
+func (s *Suite) waitVolumeSnapshotStatus(snapID, status string) error {
+	return s.wait(fmt.Sprintf("snapshot %s to become %s", snapID, status), func() (bool, error) {
+		snapshot, err := s.Client.GetVolumeSnapshot(snapID)
+		if err != nil {
+			return false, fmt.Errorf("get volume snapshot failed: %v", err)
+		}
+		if snapshot.Status == model.VolumeSnapError {
+			return false, fmt.Errorf("snapshot %s entered the error status", snapID)
+		}
+		return snapshot.Status == status, nil
+	})
+}
+
+func (s *Suite) waitFileShareStatus(fshareID, status string) error {
+	return s.wait(fmt.Sprintf("fileshare %s to become %s", fshareID, status), func() (bool, error) {
+		share, err := s.Client.GetFileShare(fshareID)
+		if err != nil {
+			return false, fmt.Errorf("get fileshare failed: %v", err)
+		}
+		if share.Status == model.FileShareError {
+			return false, fmt.Errorf("fileshare %s entered the error status", fshareID)
+		}
+		return share.Status == status, nil
+	})
+}
+
+// waitGone polls until the get answers not found, verifying the delete
+// half of a state machine.
+func (s *Suite) waitGone(get func() error) error {
+	return s.wait("the record to be gone", func() (bool, error) {
+		err := get()
+		if err == nil {
+			return false, nil
+		}
+		if errors.Is(err, client.ErrNotFound) {
+			return true, nil
+		}
+		return false, err
+	})
+}
+
+func (s *Suite) wait(what string, done func() (bool, error)) error {
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, err := done()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %s", what)
+		}
+		time.Sleep(interval)
+	}
+}
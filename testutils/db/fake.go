@@ -113,6 +113,24 @@ func (fc *FakeDbClient) UpdateFileShare(ctx *c.Context, fshare *model.FileShareS
 	return &SampleFileShares[0], nil
 }
 
+// UpdateFileSharePublic
+func (fc *FakeDbClient) UpdateFileSharePublic(ctx *c.Context, fshareID string, isPublic bool) (*model.FileShareSpec, error) {
+	fshare := SampleFileShares[0]
+	fshare.IsPublic = isPublic
+	return &fshare, nil
+}
+
+// UpdateFileShareMetadata
+func (fc *FakeDbClient) UpdateFileShareMetadata(ctx *c.Context, fshareID string, update *model.MetadataUpdateSpec) (*model.FileShareSpec, error) {
+	fshare := SampleFileShares[0]
+	merged, err := update.Apply(fshare.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	fshare.Metadata = merged
+	return &fshare, nil
+}
+
 // DeleteFileShare
 func (fc *FakeDbClient) DeleteFileShare(ctx *c.Context, fshareID string) error {
 	return nil
@@ -172,6 +190,429 @@ func (fc *FakeDbClient) DeleteFileShareSnapshot(ctx *c.Context, snapshotID strin
 	return nil
 }
 
+// CreateFileShareSnapshotCopy
+func (fc *FakeDbClient) CreateFileShareSnapshotCopy(ctx *c.Context, copy *model.FileShareSnapshotCopySpec) (*model.FileShareSnapshotCopySpec, error) {
+	return copy, nil
+}
+
+// GetFileShareSnapshotCopy
+func (fc *FakeDbClient) GetFileShareSnapshotCopy(ctx *c.Context, copyID string) (*model.FileShareSnapshotCopySpec, error) {
+	snpCopy := SampleFileShareSnapshotCopies[0]
+	return &snpCopy, nil
+}
+
+// ListFileShareSnapshotCopies
+func (fc *FakeDbClient) ListFileShareSnapshotCopies(ctx *c.Context) ([]*model.FileShareSnapshotCopySpec, error) {
+	var copies []*model.FileShareSnapshotCopySpec
+
+	for i := range SampleFileShareSnapshotCopies {
+		copies = append(copies, &SampleFileShareSnapshotCopies[i])
+	}
+	return copies, nil
+}
+
+// UpdateFileShareSnapshotCopy
+func (fc *FakeDbClient) UpdateFileShareSnapshotCopy(ctx *c.Context, copyID string, copy *model.FileShareSnapshotCopySpec) (*model.FileShareSnapshotCopySpec, error) {
+	return &SampleFileShareSnapshotCopies[0], nil
+}
+
+// CreateBackupTarget
+func (fc *FakeDbClient) CreateBackupTarget(ctx *c.Context, target *model.BackupTargetSpec) (*model.BackupTargetSpec, error) {
+	return target, nil
+}
+
+// GetBackupTarget
+func (fc *FakeDbClient) GetBackupTarget(ctx *c.Context, targetID string) (*model.BackupTargetSpec, error) {
+	target := SampleBackupTargets[0]
+	return &target, nil
+}
+
+// ListBackupTargets
+func (fc *FakeDbClient) ListBackupTargets(ctx *c.Context) ([]*model.BackupTargetSpec, error) {
+	var targets []*model.BackupTargetSpec
+
+	for i := range SampleBackupTargets {
+		targets = append(targets, &SampleBackupTargets[i])
+	}
+	return targets, nil
+}
+
+// UpdateBackupTarget
+func (fc *FakeDbClient) UpdateBackupTarget(ctx *c.Context, targetID string, target *model.BackupTargetSpec) (*model.BackupTargetSpec, error) {
+	return &SampleBackupTargets[0], nil
+}
+
+// DeleteBackupTarget
+func (fc *FakeDbClient) DeleteBackupTarget(ctx *c.Context, targetID string) error {
+	return nil
+}
+
+// CreateShareNetwork
+func (fc *FakeDbClient) CreateShareNetwork(ctx *c.Context, network *model.ShareNetworkSpec) (*model.ShareNetworkSpec, error) {
+	return network, nil
+}
+
+// GetShareNetwork
+func (fc *FakeDbClient) GetShareNetwork(ctx *c.Context, networkID string) (*model.ShareNetworkSpec, error) {
+	return nil, errors.New("specified share network(" + networkID + ") can't find")
+}
+
+// ListShareNetworks
+func (fc *FakeDbClient) ListShareNetworks(ctx *c.Context) ([]*model.ShareNetworkSpec, error) {
+	return []*model.ShareNetworkSpec{}, nil
+}
+
+// UpdateShareNetwork
+func (fc *FakeDbClient) UpdateShareNetwork(ctx *c.Context, networkID string, network *model.ShareNetworkSpec) (*model.ShareNetworkSpec, error) {
+	return network, nil
+}
+
+// DeleteShareNetwork
+func (fc *FakeDbClient) DeleteShareNetwork(ctx *c.Context, networkID string) error {
+	return nil
+}
+
+// CreateFileShareSnapshotBackup
+func (fc *FakeDbClient) CreateFileShareSnapshotBackup(ctx *c.Context, backup *model.FileShareSnapshotBackupSpec) (*model.FileShareSnapshotBackupSpec, error) {
+	return backup, nil
+}
+
+// GetFileShareSnapshotBackup
+func (fc *FakeDbClient) GetFileShareSnapshotBackup(ctx *c.Context, backupID string) (*model.FileShareSnapshotBackupSpec, error) {
+	backup := SampleFileShareSnapshotBackups[0]
+	return &backup, nil
+}
+
+// ListFileShareSnapshotBackups
+func (fc *FakeDbClient) ListFileShareSnapshotBackups(ctx *c.Context) ([]*model.FileShareSnapshotBackupSpec, error) {
+	var backups []*model.FileShareSnapshotBackupSpec
+
+	for i := range SampleFileShareSnapshotBackups {
+		backups = append(backups, &SampleFileShareSnapshotBackups[i])
+	}
+	return backups, nil
+}
+
+// UpdateFileShareSnapshotBackup
+func (fc *FakeDbClient) UpdateFileShareSnapshotBackup(ctx *c.Context, backupID string, backup *model.FileShareSnapshotBackupSpec) (*model.FileShareSnapshotBackupSpec, error) {
+	return &SampleFileShareSnapshotBackups[0], nil
+}
+
+// DeleteFileShareSnapshotBackup
+func (fc *FakeDbClient) DeleteFileShareSnapshotBackup(ctx *c.Context, backupID string) error {
+	return nil
+}
+
+// CreateAnnotation
+func (fc *FakeDbClient) CreateAnnotation(ctx *c.Context, anno *model.AnnotationSpec) (*model.AnnotationSpec, error) {
+	return anno, nil
+}
+
+// ListAnnotations
+func (fc *FakeDbClient) ListAnnotations(ctx *c.Context, resourceType, resourceID string) ([]*model.AnnotationSpec, error) {
+	var annos []*model.AnnotationSpec
+
+	for i := range SampleAnnotations {
+		if SampleAnnotations[i].ResourceType == resourceType && SampleAnnotations[i].ResourceId == resourceID {
+			annos = append(annos, &SampleAnnotations[i])
+		}
+	}
+	return annos, nil
+}
+
+// AcquirePendingOperation
+func (fc *FakeDbClient) AcquirePendingOperation(ctx *c.Context, op *model.PendingOperationSpec) (*model.PendingOperationSpec, error) {
+	return op, nil
+}
+
+// ReleasePendingOperation
+func (fc *FakeDbClient) ReleasePendingOperation(ctx *c.Context, resourceType, resourceID, token string) error {
+	return nil
+}
+
+// CreateApiKey
+func (fc *FakeDbClient) CreateApiKey(ctx *c.Context, key *model.ApiKeySpec) (*model.ApiKeySpec, error) {
+	return key, nil
+}
+
+// GetApiKey
+func (fc *FakeDbClient) GetApiKey(ctx *c.Context, keyID string) (*model.ApiKeySpec, error) {
+	return &SampleApiKeys[0], nil
+}
+
+// ListApiKeys
+func (fc *FakeDbClient) ListApiKeys(ctx *c.Context) ([]*model.ApiKeySpec, error) {
+	var keys []*model.ApiKeySpec
+	for i := range SampleApiKeys {
+		keys = append(keys, &SampleApiKeys[i])
+	}
+	return keys, nil
+}
+
+// UpdateApiKey
+func (fc *FakeDbClient) UpdateApiKey(ctx *c.Context, key *model.ApiKeySpec) (*model.ApiKeySpec, error) {
+	return key, nil
+}
+
+// DeleteApiKey
+func (fc *FakeDbClient) DeleteApiKey(ctx *c.Context, keyID string) error {
+	return nil
+}
+
+// CreateScopedToken
+func (fc *FakeDbClient) CreateScopedToken(ctx *c.Context, token *model.ScopedTokenSpec) (*model.ScopedTokenSpec, error) {
+	return token, nil
+}
+
+// GetScopedToken
+func (fc *FakeDbClient) GetScopedToken(ctx *c.Context, tokenID string) (*model.ScopedTokenSpec, error) {
+	return &SampleScopedTokens[0], nil
+}
+
+// ListScopedTokens
+func (fc *FakeDbClient) ListScopedTokens(ctx *c.Context) ([]*model.ScopedTokenSpec, error) {
+	var tokens []*model.ScopedTokenSpec
+	for i := range SampleScopedTokens {
+		tokens = append(tokens, &SampleScopedTokens[i])
+	}
+	return tokens, nil
+}
+
+// DeleteScopedToken
+func (fc *FakeDbClient) DeleteScopedToken(ctx *c.Context, tokenID string) error {
+	return nil
+}
+
+// CreateNetworkPolicy
+func (fc *FakeDbClient) CreateNetworkPolicy(ctx *c.Context, policy *model.NetworkPolicySpec) (*model.NetworkPolicySpec, error) {
+	return policy, nil
+}
+
+// GetNetworkPolicy
+func (fc *FakeDbClient) GetNetworkPolicy(ctx *c.Context, policyID string) (*model.NetworkPolicySpec, error) {
+	policy := SampleNetworkPolicies[0]
+	return &policy, nil
+}
+
+// ListNetworkPolicies
+func (fc *FakeDbClient) ListNetworkPolicies(ctx *c.Context) ([]*model.NetworkPolicySpec, error) {
+	var policies []*model.NetworkPolicySpec
+
+	for i := range SampleNetworkPolicies {
+		policies = append(policies, &SampleNetworkPolicies[i])
+	}
+	return policies, nil
+}
+
+// UpdateNetworkPolicy
+func (fc *FakeDbClient) UpdateNetworkPolicy(ctx *c.Context, policyID string, policy *model.NetworkPolicySpec) (*model.NetworkPolicySpec, error) {
+	return &SampleNetworkPolicies[0], nil
+}
+
+// DeleteNetworkPolicy
+func (fc *FakeDbClient) DeleteNetworkPolicy(ctx *c.Context, policyID string) error {
+	return nil
+}
+
+// CreateResourceClass
+func (fc *FakeDbClient) CreateResourceClass(ctx *c.Context, class *model.ResourceClassSpec) (*model.ResourceClassSpec, error) {
+	return class, nil
+}
+
+// GetResourceClass
+func (fc *FakeDbClient) GetResourceClass(ctx *c.Context, classID string) (*model.ResourceClassSpec, error) {
+	class := SampleResourceClasses[0]
+	return &class, nil
+}
+
+// ListResourceClasses
+func (fc *FakeDbClient) ListResourceClasses(ctx *c.Context) ([]*model.ResourceClassSpec, error) {
+	var classes []*model.ResourceClassSpec
+
+	for i := range SampleResourceClasses {
+		classes = append(classes, &SampleResourceClasses[i])
+	}
+	return classes, nil
+}
+
+// UpdateResourceClass
+func (fc *FakeDbClient) UpdateResourceClass(ctx *c.Context, classID string, class *model.ResourceClassSpec) (*model.ResourceClassSpec, error) {
+	return &SampleResourceClasses[0], nil
+}
+
+// DeleteResourceClass
+func (fc *FakeDbClient) DeleteResourceClass(ctx *c.Context, classID string) error {
+	return nil
+}
+
+// ListTombstones
+func (fc *FakeDbClient) ListTombstones(ctx *c.Context) ([]*model.TombstoneSpec, error) {
+	var tombstones []*model.TombstoneSpec
+
+	for i := range SampleTombstones {
+		tombstones = append(tombstones, &SampleTombstones[i])
+	}
+	return tombstones, nil
+}
+
+// DeleteTombstone
+func (fc *FakeDbClient) DeleteTombstone(ctx *c.Context, tombstoneID string) error {
+	return nil
+}
+
+// CreateCascadeTask
+func (fc *FakeDbClient) CreateCascadeTask(ctx *c.Context, task *model.CascadeTaskSpec) (*model.CascadeTaskSpec, error) {
+	return task, nil
+}
+
+// GetCascadeTask
+func (fc *FakeDbClient) GetCascadeTask(ctx *c.Context, taskID string) (*model.CascadeTaskSpec, error) {
+	return nil, errors.New("specified cascade task(" + taskID + ") can't find")
+}
+
+// ListCascadeTasks
+func (fc *FakeDbClient) ListCascadeTasks(ctx *c.Context) ([]*model.CascadeTaskSpec, error) {
+	return []*model.CascadeTaskSpec{}, nil
+}
+
+// UpdateCascadeTask
+func (fc *FakeDbClient) UpdateCascadeTask(ctx *c.Context, task *model.CascadeTaskSpec) (*model.CascadeTaskSpec, error) {
+	return task, nil
+}
+
+// DeleteCascadeTask
+func (fc *FakeDbClient) DeleteCascadeTask(ctx *c.Context, taskID string) error {
+	return nil
+}
+
+// CreateTask
+func (fc *FakeDbClient) CreateTask(ctx *c.Context, task *model.TaskSpec) (*model.TaskSpec, error) {
+	return task, nil
+}
+
+// GetTask
+func (fc *FakeDbClient) GetTask(ctx *c.Context, taskID string) (*model.TaskSpec, error) {
+	return nil, errors.New("specified task(" + taskID + ") can't find")
+}
+
+// ListTasks
+func (fc *FakeDbClient) ListTasks(ctx *c.Context) ([]*model.TaskSpec, error) {
+	return []*model.TaskSpec{}, nil
+}
+
+// UpdateTask
+func (fc *FakeDbClient) UpdateTask(ctx *c.Context, task *model.TaskSpec) (*model.TaskSpec, error) {
+	return task, nil
+}
+
+// DeleteTask
+func (fc *FakeDbClient) DeleteTask(ctx *c.Context, taskID string) error {
+	return nil
+}
+
+// CreateJob
+func (fc *FakeDbClient) CreateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	return job, nil
+}
+
+// GetJob
+func (fc *FakeDbClient) GetJob(ctx *c.Context, jobID string) (*model.JobSpec, error) {
+	return nil, errors.New("specified job(" + jobID + ") can't find")
+}
+
+// ListJobs
+func (fc *FakeDbClient) ListJobs(ctx *c.Context) ([]*model.JobSpec, error) {
+	return []*model.JobSpec{}, nil
+}
+
+// UpdateJob
+func (fc *FakeDbClient) UpdateJob(ctx *c.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	return job, nil
+}
+
+// DeleteJob
+func (fc *FakeDbClient) DeleteJob(ctx *c.Context, jobID string) error {
+	return nil
+}
+
+// RebuildSearchIndexes
+func (fc *FakeDbClient) RebuildSearchIndexes(ctx *c.Context) (int, error) {
+	return 0, nil
+}
+
+// CreateRegion
+func (fc *FakeDbClient) CreateRegion(ctx *c.Context, region *model.RegionSpec) (*model.RegionSpec, error) {
+	return region, nil
+}
+
+// GetRegion
+func (fc *FakeDbClient) GetRegion(ctx *c.Context, regionID string) (*model.RegionSpec, error) {
+	region := SampleRegions[0]
+	return &region, nil
+}
+
+// ListRegions
+func (fc *FakeDbClient) ListRegions(ctx *c.Context) ([]*model.RegionSpec, error) {
+	var regions []*model.RegionSpec
+
+	for i := range SampleRegions {
+		regions = append(regions, &SampleRegions[i])
+	}
+	return regions, nil
+}
+
+// UpdateRegion
+func (fc *FakeDbClient) UpdateRegion(ctx *c.Context, regionID string, region *model.RegionSpec) (*model.RegionSpec, error) {
+	return &SampleRegions[0], nil
+}
+
+// DeleteRegion
+func (fc *FakeDbClient) DeleteRegion(ctx *c.Context, regionID string) error {
+	return nil
+}
+
+// GetQuota
+func (fc *FakeDbClient) GetQuota(ctx *c.Context, tenantID string) (*model.QuotaSpec, error) {
+	quota := SampleQuotas[0]
+	return &quota, nil
+}
+
+// ListQuotas
+func (fc *FakeDbClient) ListQuotas(ctx *c.Context) ([]*model.QuotaSpec, error) {
+	var quotas []*model.QuotaSpec
+
+	for i := range SampleQuotas {
+		quotas = append(quotas, &SampleQuotas[i])
+	}
+	return quotas, nil
+}
+
+// SetQuota
+func (fc *FakeDbClient) SetQuota(ctx *c.Context, quota *model.QuotaSpec) (*model.QuotaSpec, error) {
+	return quota, nil
+}
+
+// DeleteQuota
+func (fc *FakeDbClient) DeleteQuota(ctx *c.Context, tenantID string) error {
+	return nil
+}
+
+// GetDefaultTags
+func (fc *FakeDbClient) GetDefaultTags(ctx *c.Context, tenantID string) (*model.DefaultTagsSpec, error) {
+	tags := SampleDefaultTags[0]
+	return &tags, nil
+}
+
+// SetDefaultTags
+func (fc *FakeDbClient) SetDefaultTags(ctx *c.Context, tags *model.DefaultTagsSpec) (*model.DefaultTagsSpec, error) {
+	return tags, nil
+}
+
+// DeleteDefaultTags
+func (fc *FakeDbClient) DeleteDefaultTags(ctx *c.Context, tenantID string) error {
+	return nil
+}
+
 // CreateDock
 func (fc *FakeDbClient) CreateDock(ctx *c.Context, dck *model.DockSpec) (*model.DockSpec, error) {
 	return &SampleDocks[0], nil
@@ -278,6 +719,18 @@ func (fc *FakeDbClient) UpdatePool(ctx *c.Context, polID, name, desp string, use
 	return nil, nil
 }
 
+// UpdatePoolCapacitySettings
+func (fc *FakeDbClient) UpdatePoolCapacitySettings(ctx *c.Context, polID string, reservedCapacity int64, maxOverSubscriptionRatio float64) (*model.StoragePoolSpec, error) {
+	return &SamplePools[0], nil
+}
+
+// UpdatePoolMaintenance
+func (fc *FakeDbClient) UpdatePoolMaintenance(ctx *c.Context, polID string, maintenance bool) (*model.StoragePoolSpec, error) {
+	pol := SamplePools[0]
+	pol.Maintenance = maintenance
+	return &pol, nil
+}
+
 // DeletePool
 func (fc *FakeDbClient) DeletePool(ctx *c.Context, polID string) error {
 	return nil
@@ -312,7 +765,7 @@ func (fc *FakeDbClient) GetDefaultProfile(ctx *c.Context) (*model.ProfileSpec, e
 
 // GetDefaultProfileFileShare
 func (fc *FakeDbClient) GetDefaultProfileFileShare(ctx *c.Context) (*model.ProfileSpec, error) {
-	for _, profile := range SampleProfiles {
+	for _, profile := range SampleFileShareProfiles {
 		if profile.Name == "default" && profile.StorageType == "file" {
 			return &profile, nil
 		}
@@ -406,6 +859,17 @@ func (fc *FakeDbClient) UpdateVolume(ctx *c.Context, vol *model.VolumeSpec) (*mo
 	return &SampleVolumes[0], nil
 }
 
+// UpdateVolumeMetadata
+func (fc *FakeDbClient) UpdateVolumeMetadata(ctx *c.Context, volID string, update *model.MetadataUpdateSpec) (*model.VolumeSpec, error) {
+	vol := SampleVolumes[0]
+	merged, err := update.Apply(vol.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	vol.Metadata = merged
+	return &vol, nil
+}
+
 // DeleteVolume
 func (fc *FakeDbClient) DeleteVolume(ctx *c.Context, volID string) error {
 	return nil
@@ -621,3 +1085,27 @@ func (c *FakeDbClient) getHost(ctx *c.Context, hostId string) (*model.HostSpec,
 func (c *FakeDbClient) DeleteHost(ctx *c.Context, hostId string) error {
 	return nil
 }
+
+func (c *FakeDbClient) CreateHostGroup(ctx *c.Context, hg *model.HostGroupSpec) (*model.HostGroupSpec, error) {
+	return &SampleHostGroups[0], nil
+}
+
+func (c *FakeDbClient) GetHostGroup(ctx *c.Context, hgId string) (*model.HostGroupSpec, error) {
+	return &SampleHostGroups[0], nil
+}
+
+func (c *FakeDbClient) ListHostGroups(ctx *c.Context) ([]*model.HostGroupSpec, error) {
+	var hgs []*model.HostGroupSpec
+	for i := range SampleHostGroups {
+		hgs = append(hgs, &SampleHostGroups[i])
+	}
+	return hgs, nil
+}
+
+func (c *FakeDbClient) UpdateHostGroup(ctx *c.Context, hg *model.HostGroupSpec) (*model.HostGroupSpec, error) {
+	return &SampleHostGroups[0], nil
+}
+
+func (c *FakeDbClient) DeleteHostGroup(ctx *c.Context, hgId string) error {
+	return nil
+}
@@ -13,6 +13,28 @@ type Client struct {
 }
 
 // AddCustomProperty provides a mock function with given fields: ctx, prfID, custom
+func (_m *Client) AcquirePendingOperation(ctx *context.Context, op *model.PendingOperationSpec) (*model.PendingOperationSpec, error) {
+	ret := _m.Called(ctx, op)
+
+	var r0 *model.PendingOperationSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.PendingOperationSpec) *model.PendingOperationSpec); ok {
+		r0 = rf(ctx, op)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PendingOperationSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.PendingOperationSpec) error); ok {
+		r1 = rf(ctx, op)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 func (_m *Client) AddCustomProperty(ctx *context.Context, prfID string, custom model.CustomPropertiesSpec) (*model.CustomPropertiesSpec, error) {
 	ret := _m.Called(ctx, prfID, custom)
 
@@ -35,7 +57,542 @@ func (_m *Client) AddCustomProperty(ctx *context.Context, prfID string, custom m
 	return r0, r1
 }
 
+// CreateAnnotation provides a mock function with given fields: ctx, anno
+func (_m *Client) CreateAnnotation(ctx *context.Context, anno *model.AnnotationSpec) (*model.AnnotationSpec, error) {
+	ret := _m.Called(ctx, anno)
+
+	var r0 *model.AnnotationSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.AnnotationSpec) *model.AnnotationSpec); ok {
+		r0 = rf(ctx, anno)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AnnotationSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.AnnotationSpec) error); ok {
+		r1 = rf(ctx, anno)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateBackupTarget provides a mock function with given fields: ctx, target
+func (_m *Client) CreateBackupTarget(ctx *context.Context, target *model.BackupTargetSpec) (*model.BackupTargetSpec, error) {
+	ret := _m.Called(ctx, target)
+
+	var r0 *model.BackupTargetSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.BackupTargetSpec) *model.BackupTargetSpec); ok {
+		r0 = rf(ctx, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BackupTargetSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.BackupTargetSpec) error); ok {
+		r1 = rf(ctx, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDock provides a mock function with given fields: ctx, dck
+func (_m *Client) CreateApiKey(ctx *context.Context, key *model.ApiKeySpec) (*model.ApiKeySpec, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *model.ApiKeySpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.ApiKeySpec) *model.ApiKeySpec); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ApiKeySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.ApiKeySpec) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *Client) CreateScopedToken(ctx *context.Context, token *model.ScopedTokenSpec) (*model.ScopedTokenSpec, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *model.ScopedTokenSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.ScopedTokenSpec) *model.ScopedTokenSpec); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ScopedTokenSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.ScopedTokenSpec) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCascadeTask provides a mock function with given fields: ctx, task
+func (_m *Client) CreateCascadeTask(ctx *context.Context, task *model.CascadeTaskSpec) (*model.CascadeTaskSpec, error) {
+	ret := _m.Called(ctx, task)
+
+	var r0 *model.CascadeTaskSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.CascadeTaskSpec) *model.CascadeTaskSpec); ok {
+		r0 = rf(ctx, task)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CascadeTaskSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.CascadeTaskSpec) error); ok {
+		r1 = rf(ctx, task)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCascadeTask provides a mock function with given fields: ctx, taskID
+func (_m *Client) GetCascadeTask(ctx *context.Context, taskID string) (*model.CascadeTaskSpec, error) {
+	ret := _m.Called(ctx, taskID)
+
+	var r0 *model.CascadeTaskSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.CascadeTaskSpec); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CascadeTaskSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCascadeTasks provides a mock function with given fields: ctx
+func (_m *Client) ListCascadeTasks(ctx *context.Context) ([]*model.CascadeTaskSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.CascadeTaskSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.CascadeTaskSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.CascadeTaskSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCascadeTask provides a mock function with given fields: ctx, task
+func (_m *Client) UpdateCascadeTask(ctx *context.Context, task *model.CascadeTaskSpec) (*model.CascadeTaskSpec, error) {
+	ret := _m.Called(ctx, task)
+
+	var r0 *model.CascadeTaskSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.CascadeTaskSpec) *model.CascadeTaskSpec); ok {
+		r0 = rf(ctx, task)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CascadeTaskSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.CascadeTaskSpec) error); ok {
+		r1 = rf(ctx, task)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteCascadeTask provides a mock function with given fields: ctx, taskID
+func (_m *Client) DeleteCascadeTask(ctx *context.Context, taskID string) error {
+	ret := _m.Called(ctx, taskID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTask provides a mock function with given fields: ctx, task
+func (_m *Client) CreateTask(ctx *context.Context, task *model.TaskSpec) (*model.TaskSpec, error) {
+	ret := _m.Called(ctx, task)
+
+	var r0 *model.TaskSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.TaskSpec) *model.TaskSpec); ok {
+		r0 = rf(ctx, task)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.TaskSpec) error); ok {
+		r1 = rf(ctx, task)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTask provides a mock function with given fields: ctx, taskID
+func (_m *Client) GetTask(ctx *context.Context, taskID string) (*model.TaskSpec, error) {
+	ret := _m.Called(ctx, taskID)
+
+	var r0 *model.TaskSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.TaskSpec); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTasks provides a mock function with given fields: ctx
+func (_m *Client) ListTasks(ctx *context.Context) ([]*model.TaskSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.TaskSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.TaskSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TaskSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTask provides a mock function with given fields: ctx, task
+func (_m *Client) UpdateTask(ctx *context.Context, task *model.TaskSpec) (*model.TaskSpec, error) {
+	ret := _m.Called(ctx, task)
+
+	var r0 *model.TaskSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.TaskSpec) *model.TaskSpec); ok {
+		r0 = rf(ctx, task)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.TaskSpec) error); ok {
+		r1 = rf(ctx, task)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTask provides a mock function with given fields: ctx, taskID
+func (_m *Client) DeleteTask(ctx *context.Context, taskID string) error {
+	ret := _m.Called(ctx, taskID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateJob provides a mock function with given fields: ctx, job
+func (_m *Client) CreateJob(ctx *context.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	ret := _m.Called(ctx, job)
+
+	var r0 *model.JobSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.JobSpec) *model.JobSpec); ok {
+		r0 = rf(ctx, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.JobSpec) error); ok {
+		r1 = rf(ctx, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJob provides a mock function with given fields: ctx, jobID
+func (_m *Client) GetJob(ctx *context.Context, jobID string) (*model.JobSpec, error) {
+	ret := _m.Called(ctx, jobID)
+
+	var r0 *model.JobSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.JobSpec); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListJobs provides a mock function with given fields: ctx
+func (_m *Client) ListJobs(ctx *context.Context) ([]*model.JobSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.JobSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.JobSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.JobSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateJob provides a mock function with given fields: ctx, job
+func (_m *Client) UpdateJob(ctx *context.Context, job *model.JobSpec) (*model.JobSpec, error) {
+	ret := _m.Called(ctx, job)
+
+	var r0 *model.JobSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.JobSpec) *model.JobSpec); ok {
+		r0 = rf(ctx, job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.JobSpec) error); ok {
+		r1 = rf(ctx, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteJob provides a mock function with given fields: ctx, jobID
+func (_m *Client) DeleteJob(ctx *context.Context, jobID string) error {
+	ret := _m.Called(ctx, jobID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RebuildSearchIndexes provides a mock function with given fields: ctx
+func (_m *Client) RebuildSearchIndexes(ctx *context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateShareNetwork provides a mock function with given fields: ctx, network
+func (_m *Client) CreateShareNetwork(ctx *context.Context, network *model.ShareNetworkSpec) (*model.ShareNetworkSpec, error) {
+	ret := _m.Called(ctx, network)
+
+	var r0 *model.ShareNetworkSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.ShareNetworkSpec) *model.ShareNetworkSpec); ok {
+		r0 = rf(ctx, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShareNetworkSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.ShareNetworkSpec) error); ok {
+		r1 = rf(ctx, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShareNetwork provides a mock function with given fields: ctx, networkID
+func (_m *Client) GetShareNetwork(ctx *context.Context, networkID string) (*model.ShareNetworkSpec, error) {
+	ret := _m.Called(ctx, networkID)
+
+	var r0 *model.ShareNetworkSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.ShareNetworkSpec); ok {
+		r0 = rf(ctx, networkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShareNetworkSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, networkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListShareNetworks provides a mock function with given fields: ctx
+func (_m *Client) ListShareNetworks(ctx *context.Context) ([]*model.ShareNetworkSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.ShareNetworkSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.ShareNetworkSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ShareNetworkSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateShareNetwork provides a mock function with given fields: ctx, networkID, network
+func (_m *Client) UpdateShareNetwork(ctx *context.Context, networkID string, network *model.ShareNetworkSpec) (*model.ShareNetworkSpec, error) {
+	ret := _m.Called(ctx, networkID, network)
+
+	var r0 *model.ShareNetworkSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.ShareNetworkSpec) *model.ShareNetworkSpec); ok {
+		r0 = rf(ctx, networkID, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ShareNetworkSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, *model.ShareNetworkSpec) error); ok {
+		r1 = rf(ctx, networkID, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteShareNetwork provides a mock function with given fields: ctx, networkID
+func (_m *Client) DeleteShareNetwork(ctx *context.Context, networkID string) error {
+	ret := _m.Called(ctx, networkID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, networkID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 func (_m *Client) CreateDock(ctx *context.Context, dck *model.DockSpec) (*model.DockSpec, error) {
 	ret := _m.Called(ctx, dck)
 
@@ -127,6 +684,52 @@ func (_m *Client) CreateFileShareSnapshot(ctx *context.Context, vs *model.FileSh
 	return r0, r1
 }
 
+// CreateFileShareSnapshotBackup provides a mock function with given fields: ctx, backup
+func (_m *Client) CreateFileShareSnapshotBackup(ctx *context.Context, backup *model.FileShareSnapshotBackupSpec) (*model.FileShareSnapshotBackupSpec, error) {
+	ret := _m.Called(ctx, backup)
+
+	var r0 *model.FileShareSnapshotBackupSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.FileShareSnapshotBackupSpec) *model.FileShareSnapshotBackupSpec); ok {
+		r0 = rf(ctx, backup)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSnapshotBackupSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.FileShareSnapshotBackupSpec) error); ok {
+		r1 = rf(ctx, backup)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFileShareSnapshotCopy provides a mock function with given fields: ctx, copy
+func (_m *Client) CreateFileShareSnapshotCopy(ctx *context.Context, copy *model.FileShareSnapshotCopySpec) (*model.FileShareSnapshotCopySpec, error) {
+	ret := _m.Called(ctx, copy)
+
+	var r0 *model.FileShareSnapshotCopySpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.FileShareSnapshotCopySpec) *model.FileShareSnapshotCopySpec); ok {
+		r0 = rf(ctx, copy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSnapshotCopySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.FileShareSnapshotCopySpec) error); ok {
+		r1 = rf(ctx, copy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateHost provides a mock function with given fields: ctx, host
 func (_m *Client) CreateHost(ctx *context.Context, host *model.HostSpec) (*model.HostSpec, error) {
 	ret := _m.Called(ctx, host)
@@ -150,6 +753,52 @@ func (_m *Client) CreateHost(ctx *context.Context, host *model.HostSpec) (*model
 	return r0, r1
 }
 
+// CreateHostGroup provides a mock function with given fields: ctx, hg
+func (_m *Client) CreateHostGroup(ctx *context.Context, hg *model.HostGroupSpec) (*model.HostGroupSpec, error) {
+	ret := _m.Called(ctx, hg)
+
+	var r0 *model.HostGroupSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.HostGroupSpec) *model.HostGroupSpec); ok {
+		r0 = rf(ctx, hg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HostGroupSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.HostGroupSpec) error); ok {
+		r1 = rf(ctx, hg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateNetworkPolicy provides a mock function with given fields: ctx, policy
+func (_m *Client) CreateNetworkPolicy(ctx *context.Context, policy *model.NetworkPolicySpec) (*model.NetworkPolicySpec, error) {
+	ret := _m.Called(ctx, policy)
+
+	var r0 *model.NetworkPolicySpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.NetworkPolicySpec) *model.NetworkPolicySpec); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.NetworkPolicySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.NetworkPolicySpec) error); ok {
+		r1 = rf(ctx, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePool provides a mock function with given fields: ctx, pol
 func (_m *Client) CreatePool(ctx *context.Context, pol *model.StoragePoolSpec) (*model.StoragePoolSpec, error) {
 	ret := _m.Called(ctx, pol)
@@ -219,6 +868,52 @@ func (_m *Client) CreateReplication(ctx *context.Context, replication *model.Rep
 	return r0, r1
 }
 
+// CreateRegion provides a mock function with given fields: ctx, region
+func (_m *Client) CreateRegion(ctx *context.Context, region *model.RegionSpec) (*model.RegionSpec, error) {
+	ret := _m.Called(ctx, region)
+
+	var r0 *model.RegionSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.RegionSpec) *model.RegionSpec); ok {
+		r0 = rf(ctx, region)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RegionSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.RegionSpec) error); ok {
+		r1 = rf(ctx, region)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateResourceClass provides a mock function with given fields: ctx, class
+func (_m *Client) CreateResourceClass(ctx *context.Context, class *model.ResourceClassSpec) (*model.ResourceClassSpec, error) {
+	ret := _m.Called(ctx, class)
+
+	var r0 *model.ResourceClassSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.ResourceClassSpec) *model.ResourceClassSpec); ok {
+		r0 = rf(ctx, class)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ResourceClassSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.ResourceClassSpec) error); ok {
+		r1 = rf(ctx, class)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateVolume provides a mock function with given fields: ctx, vol
 func (_m *Client) CreateVolume(ctx *context.Context, vol *model.VolumeSpec) (*model.VolumeSpec, error) {
 	ret := _m.Called(ctx, vol)
@@ -296,22 +991,76 @@ func (_m *Client) CreateVolumeSnapshot(ctx *context.Context, vs *model.VolumeSna
 	if rf, ok := ret.Get(0).(func(*context.Context, *model.VolumeSnapshotSpec) *model.VolumeSnapshotSpec); ok {
 		r0 = rf(ctx, vs)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.VolumeSnapshotSpec)
-		}
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VolumeSnapshotSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.VolumeSnapshotSpec) error); ok {
+		r1 = rf(ctx, vs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteBackupTarget provides a mock function with given fields: ctx, targetID
+func (_m *Client) DeleteBackupTarget(ctx *context.Context, targetID string) error {
+	ret := _m.Called(ctx, targetID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDefaultTags provides a mock function with given fields: ctx, tenantID
+func (_m *Client) DeleteDefaultTags(ctx *context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDock provides a mock function with given fields: ctx, dckID
+func (_m *Client) DeleteApiKey(ctx *context.Context, keyID string) error {
+	ret := _m.Called(ctx, keyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, keyID)
+	} else {
+		r0 = ret.Error(0)
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*context.Context, *model.VolumeSnapshotSpec) error); ok {
-		r1 = rf(ctx, vs)
+	return r0
+}
+
+func (_m *Client) DeleteScopedToken(ctx *context.Context, tokenID string) error {
+	ret := _m.Called(ctx, tokenID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, tokenID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// DeleteDock provides a mock function with given fields: ctx, dckID
 func (_m *Client) DeleteDock(ctx *context.Context, dckID string) error {
 	ret := _m.Called(ctx, dckID)
 
@@ -367,6 +1116,20 @@ func (_m *Client) DeleteFileShareSnapshot(ctx *context.Context, snapshotID strin
 	return r0
 }
 
+// DeleteFileShareSnapshotBackup provides a mock function with given fields: ctx, backupID
+func (_m *Client) DeleteFileShareSnapshotBackup(ctx *context.Context, backupID string) error {
+	ret := _m.Called(ctx, backupID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, backupID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteHost provides a mock function with given fields: ctx, hostId
 func (_m *Client) DeleteHost(ctx *context.Context, hostId string) error {
 	ret := _m.Called(ctx, hostId)
@@ -381,6 +1144,34 @@ func (_m *Client) DeleteHost(ctx *context.Context, hostId string) error {
 	return r0
 }
 
+// DeleteHostGroup provides a mock function with given fields: ctx, hgId
+func (_m *Client) DeleteHostGroup(ctx *context.Context, hgId string) error {
+	ret := _m.Called(ctx, hgId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, hgId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNetworkPolicy provides a mock function with given fields: ctx, policyID
+func (_m *Client) DeleteNetworkPolicy(ctx *context.Context, policyID string) error {
+	ret := _m.Called(ctx, policyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, policyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeletePool provides a mock function with given fields: ctx, polID
 func (_m *Client) DeletePool(ctx *context.Context, polID string) error {
 	ret := _m.Called(ctx, polID)
@@ -409,6 +1200,34 @@ func (_m *Client) DeleteProfile(ctx *context.Context, prfID string) error {
 	return r0
 }
 
+// DeleteQuota provides a mock function with given fields: ctx, tenantID
+func (_m *Client) DeleteQuota(ctx *context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRegion provides a mock function with given fields: ctx, regionID
+func (_m *Client) DeleteRegion(ctx *context.Context, regionID string) error {
+	ret := _m.Called(ctx, regionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, regionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteReplication provides a mock function with given fields: ctx, replicationId
 func (_m *Client) DeleteReplication(ctx *context.Context, replicationId string) error {
 	ret := _m.Called(ctx, replicationId)
@@ -423,6 +1242,34 @@ func (_m *Client) DeleteReplication(ctx *context.Context, replicationId string)
 	return r0
 }
 
+// DeleteResourceClass provides a mock function with given fields: ctx, classID
+func (_m *Client) DeleteResourceClass(ctx *context.Context, classID string) error {
+	ret := _m.Called(ctx, classID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, classID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTombstone provides a mock function with given fields: ctx, tombstoneID
+func (_m *Client) DeleteTombstone(ctx *context.Context, tombstoneID string) error {
+	ret := _m.Called(ctx, tombstoneID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string) error); ok {
+		r0 = rf(ctx, tombstoneID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteVolume provides a mock function with given fields: ctx, volID
 func (_m *Client) DeleteVolume(ctx *context.Context, volID string) error {
 	ret := _m.Called(ctx, volID)
@@ -502,7 +1349,74 @@ func (_m *Client) ExtendVolume(ctx *context.Context, vol *model.VolumeSpec) (*mo
 	return r0, r1
 }
 
+// GetBackupTarget provides a mock function with given fields: ctx, targetID
+func (_m *Client) GetBackupTarget(ctx *context.Context, targetID string) (*model.BackupTargetSpec, error) {
+	ret := _m.Called(ctx, targetID)
+
+	var r0 *model.BackupTargetSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.BackupTargetSpec); ok {
+		r0 = rf(ctx, targetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BackupTargetSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *Client) GetScopedToken(ctx *context.Context, tokenID string) (*model.ScopedTokenSpec, error) {
+	ret := _m.Called(ctx, tokenID)
+
+	var r0 *model.ScopedTokenSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.ScopedTokenSpec); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ScopedTokenSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDefaultProfile provides a mock function with given fields: ctx
+func (_m *Client) GetApiKey(ctx *context.Context, keyID string) (*model.ApiKeySpec, error) {
+	ret := _m.Called(ctx, keyID)
+
+	var r0 *model.ApiKeySpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.ApiKeySpec); ok {
+		r0 = rf(ctx, keyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ApiKeySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, keyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 func (_m *Client) GetDefaultProfile(ctx *context.Context) (*model.ProfileSpec, error) {
 	ret := _m.Called(ctx)
 
@@ -548,6 +1462,29 @@ func (_m *Client) GetDefaultProfileFileShare(ctx *context.Context) (*model.Profi
 	return r0, r1
 }
 
+// GetDefaultTags provides a mock function with given fields: ctx, tenantID
+func (_m *Client) GetDefaultTags(ctx *context.Context, tenantID string) (*model.DefaultTagsSpec, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *model.DefaultTagsSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.DefaultTagsSpec); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DefaultTagsSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDock provides a mock function with given fields: ctx, dckID
 func (_m *Client) GetDock(ctx *context.Context, dckID string) (*model.DockSpec, error) {
 	ret := _m.Called(ctx, dckID)
@@ -663,6 +1600,52 @@ func (_m *Client) GetFileShareSnapshot(ctx *context.Context, snapshotID string)
 	return r0, r1
 }
 
+// GetFileShareSnapshotBackup provides a mock function with given fields: ctx, backupID
+func (_m *Client) GetFileShareSnapshotBackup(ctx *context.Context, backupID string) (*model.FileShareSnapshotBackupSpec, error) {
+	ret := _m.Called(ctx, backupID)
+
+	var r0 *model.FileShareSnapshotBackupSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.FileShareSnapshotBackupSpec); ok {
+		r0 = rf(ctx, backupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSnapshotBackupSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, backupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFileShareSnapshotCopy provides a mock function with given fields: ctx, copyID
+func (_m *Client) GetFileShareSnapshotCopy(ctx *context.Context, copyID string) (*model.FileShareSnapshotCopySpec, error) {
+	ret := _m.Called(ctx, copyID)
+
+	var r0 *model.FileShareSnapshotCopySpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.FileShareSnapshotCopySpec); ok {
+		r0 = rf(ctx, copyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSnapshotCopySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, copyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetHost provides a mock function with given fields: ctx, hostId
 func (_m *Client) GetHost(ctx *context.Context, hostId string) (*model.HostSpec, error) {
 	ret := _m.Called(ctx, hostId)
@@ -672,13 +1655,105 @@ func (_m *Client) GetHost(ctx *context.Context, hostId string) (*model.HostSpec,
 		r0 = rf(ctx, hostId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.HostSpec)
+			r0 = ret.Get(0).(*model.HostSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, hostId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHostGroup provides a mock function with given fields: ctx, hgId
+func (_m *Client) GetHostGroup(ctx *context.Context, hgId string) (*model.HostGroupSpec, error) {
+	ret := _m.Called(ctx, hgId)
+
+	var r0 *model.HostGroupSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.HostGroupSpec); ok {
+		r0 = rf(ctx, hgId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HostGroupSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, hgId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNetworkPolicy provides a mock function with given fields: ctx, policyID
+func (_m *Client) GetNetworkPolicy(ctx *context.Context, policyID string) (*model.NetworkPolicySpec, error) {
+	ret := _m.Called(ctx, policyID)
+
+	var r0 *model.NetworkPolicySpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.NetworkPolicySpec); ok {
+		r0 = rf(ctx, policyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.NetworkPolicySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, policyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPool provides a mock function with given fields: ctx, polID
+func (_m *Client) GetPool(ctx *context.Context, polID string) (*model.StoragePoolSpec, error) {
+	ret := _m.Called(ctx, polID)
+
+	var r0 *model.StoragePoolSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.StoragePoolSpec); ok {
+		r0 = rf(ctx, polID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StoragePoolSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, polID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProfile provides a mock function with given fields: ctx, prfID
+func (_m *Client) GetProfile(ctx *context.Context, prfID string) (*model.ProfileSpec, error) {
+	ret := _m.Called(ctx, prfID)
+
+	var r0 *model.ProfileSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.ProfileSpec); ok {
+		r0 = rf(ctx, prfID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ProfileSpec)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
-		r1 = rf(ctx, hostId)
+		r1 = rf(ctx, prfID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -686,22 +1761,22 @@ func (_m *Client) GetHost(ctx *context.Context, hostId string) (*model.HostSpec,
 	return r0, r1
 }
 
-// GetPool provides a mock function with given fields: ctx, polID
-func (_m *Client) GetPool(ctx *context.Context, polID string) (*model.StoragePoolSpec, error) {
-	ret := _m.Called(ctx, polID)
+// GetQuota provides a mock function with given fields: ctx, tenantID
+func (_m *Client) GetQuota(ctx *context.Context, tenantID string) (*model.QuotaSpec, error) {
+	ret := _m.Called(ctx, tenantID)
 
-	var r0 *model.StoragePoolSpec
-	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.StoragePoolSpec); ok {
-		r0 = rf(ctx, polID)
+	var r0 *model.QuotaSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.QuotaSpec); ok {
+		r0 = rf(ctx, tenantID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.StoragePoolSpec)
+			r0 = ret.Get(0).(*model.QuotaSpec)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
-		r1 = rf(ctx, polID)
+		r1 = rf(ctx, tenantID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -709,22 +1784,22 @@ func (_m *Client) GetPool(ctx *context.Context, polID string) (*model.StoragePoo
 	return r0, r1
 }
 
-// GetProfile provides a mock function with given fields: ctx, prfID
-func (_m *Client) GetProfile(ctx *context.Context, prfID string) (*model.ProfileSpec, error) {
-	ret := _m.Called(ctx, prfID)
+// GetRegion provides a mock function with given fields: ctx, regionID
+func (_m *Client) GetRegion(ctx *context.Context, regionID string) (*model.RegionSpec, error) {
+	ret := _m.Called(ctx, regionID)
 
-	var r0 *model.ProfileSpec
-	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.ProfileSpec); ok {
-		r0 = rf(ctx, prfID)
+	var r0 *model.RegionSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.RegionSpec); ok {
+		r0 = rf(ctx, regionID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.ProfileSpec)
+			r0 = ret.Get(0).(*model.RegionSpec)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
-		r1 = rf(ctx, prfID)
+		r1 = rf(ctx, regionID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -778,6 +1853,29 @@ func (_m *Client) GetReplicationByVolumeId(ctx *context.Context, volumeId string
 	return r0, r1
 }
 
+// GetResourceClass provides a mock function with given fields: ctx, classID
+func (_m *Client) GetResourceClass(ctx *context.Context, classID string) (*model.ResourceClassSpec, error) {
+	ret := _m.Called(ctx, classID)
+
+	var r0 *model.ResourceClassSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) *model.ResourceClassSpec); ok {
+		r0 = rf(ctx, classID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ResourceClassSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, classID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVolume provides a mock function with given fields: ctx, volID
 func (_m *Client) GetVolume(ctx *context.Context, volID string) (*model.VolumeSpec, error) {
 	ret := _m.Called(ctx, volID)
@@ -893,7 +1991,74 @@ func (_m *Client) ListAttachmentsByVolumeId(ctx *context.Context, volId string)
 	return r0, r1
 }
 
+// ListAnnotations provides a mock function with given fields: ctx, resourceType, resourceID
+func (_m *Client) ListAnnotations(ctx *context.Context, resourceType string, resourceID string) ([]*model.AnnotationSpec, error) {
+	ret := _m.Called(ctx, resourceType, resourceID)
+
+	var r0 []*model.AnnotationSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, string) []*model.AnnotationSpec); ok {
+		r0 = rf(ctx, resourceType, resourceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AnnotationSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, string) error); ok {
+		r1 = rf(ctx, resourceType, resourceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListAvailabilityZones provides a mock function with given fields: ctx
+func (_m *Client) ListApiKeys(ctx *context.Context) ([]*model.ApiKeySpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.ApiKeySpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.ApiKeySpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ApiKeySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (_m *Client) ListScopedTokens(ctx *context.Context) ([]*model.ScopedTokenSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.ScopedTokenSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.ScopedTokenSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ScopedTokenSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 func (_m *Client) ListAvailabilityZones(ctx *context.Context) ([]string, error) {
 	ret := _m.Called(ctx)
 
@@ -916,6 +2081,29 @@ func (_m *Client) ListAvailabilityZones(ctx *context.Context) ([]string, error)
 	return r0, r1
 }
 
+// ListBackupTargets provides a mock function with given fields: ctx
+func (_m *Client) ListBackupTargets(ctx *context.Context) ([]*model.BackupTargetSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.BackupTargetSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.BackupTargetSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BackupTargetSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListCustomProperties provides a mock function with given fields: ctx, prfID
 func (_m *Client) ListCustomProperties(ctx *context.Context, prfID string) (*model.CustomPropertiesSpec, error) {
 	ret := _m.Called(ctx, prfID)
@@ -1008,6 +2196,52 @@ func (_m *Client) ListFileShareAclsByShareId(ctx *context.Context, fileshareId s
 	return r0, r1
 }
 
+// ListFileShareSnapshotBackups provides a mock function with given fields: ctx
+func (_m *Client) ListFileShareSnapshotBackups(ctx *context.Context) ([]*model.FileShareSnapshotBackupSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.FileShareSnapshotBackupSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.FileShareSnapshotBackupSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileShareSnapshotBackupSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFileShareSnapshotCopies provides a mock function with given fields: ctx
+func (_m *Client) ListFileShareSnapshotCopies(ctx *context.Context) ([]*model.FileShareSnapshotCopySpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.FileShareSnapshotCopySpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.FileShareSnapshotCopySpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileShareSnapshotCopySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListFileShareSnapshots provides a mock function with given fields: ctx
 func (_m *Client) ListFileShareSnapshots(ctx *context.Context) ([]*model.FileShareSnapshotSpec, error) {
 	ret := _m.Called(ctx)
@@ -1169,6 +2403,29 @@ func (_m *Client) ListFileSharesWithFilter(ctx *context.Context, m map[string][]
 	return r0, r1
 }
 
+// ListHostGroups provides a mock function with given fields: ctx
+func (_m *Client) ListHostGroups(ctx *context.Context) ([]*model.HostGroupSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.HostGroupSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.HostGroupSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.HostGroupSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListHosts provides a mock function with given fields: ctx, m
 func (_m *Client) ListHosts(ctx *context.Context, m map[string][]string) ([]*model.HostSpec, error) {
 	ret := _m.Called(ctx, m)
@@ -1215,6 +2472,29 @@ func (_m *Client) ListHostsByName(ctx *context.Context, hostName string) ([]*mod
 	return r0, r1
 }
 
+// ListNetworkPolicies provides a mock function with given fields: ctx
+func (_m *Client) ListNetworkPolicies(ctx *context.Context) ([]*model.NetworkPolicySpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.NetworkPolicySpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.NetworkPolicySpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.NetworkPolicySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListPools provides a mock function with given fields: ctx
 func (_m *Client) ListPools(ctx *context.Context) ([]*model.StoragePoolSpec, error) {
 	ret := _m.Called(ctx)
@@ -1307,6 +2587,52 @@ func (_m *Client) ListProfilesWithFilter(ctx *context.Context, m map[string][]st
 	return r0, r1
 }
 
+// ListQuotas provides a mock function with given fields: ctx
+func (_m *Client) ListQuotas(ctx *context.Context) ([]*model.QuotaSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.QuotaSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.QuotaSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.QuotaSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRegions provides a mock function with given fields: ctx
+func (_m *Client) ListRegions(ctx *context.Context) ([]*model.RegionSpec, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.RegionSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.RegionSpec); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RegionSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListReplication provides a mock function with given fields: ctx
 func (_m *Client) ListReplication(ctx *context.Context) ([]*model.ReplicationSpec, error) {
 	ret := _m.Called(ctx)
@@ -1353,22 +2679,68 @@ func (_m *Client) ListReplicationWithFilter(ctx *context.Context, m map[string][
 	return r0, r1
 }
 
-// ListSnapshotsByShareId provides a mock function with given fields: ctx, fileshareId
-func (_m *Client) ListSnapshotsByShareId(ctx *context.Context, fileshareId string) ([]*model.FileShareSnapshotSpec, error) {
-	ret := _m.Called(ctx, fileshareId)
+// ListSnapshotsByShareId provides a mock function with given fields: ctx, fileshareId
+func (_m *Client) ListSnapshotsByShareId(ctx *context.Context, fileshareId string) ([]*model.FileShareSnapshotSpec, error) {
+	ret := _m.Called(ctx, fileshareId)
+
+	var r0 []*model.FileShareSnapshotSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) []*model.FileShareSnapshotSpec); ok {
+		r0 = rf(ctx, fileshareId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileShareSnapshotSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, fileshareId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSnapshotsByVolumeId provides a mock function with given fields: ctx, volId
+func (_m *Client) ListSnapshotsByVolumeId(ctx *context.Context, volId string) ([]*model.VolumeSnapshotSpec, error) {
+	ret := _m.Called(ctx, volId)
+
+	var r0 []*model.VolumeSnapshotSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string) []*model.VolumeSnapshotSpec); ok {
+		r0 = rf(ctx, volId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.VolumeSnapshotSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
+		r1 = rf(ctx, volId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListResourceClasses provides a mock function with given fields: ctx
+func (_m *Client) ListResourceClasses(ctx *context.Context) ([]*model.ResourceClassSpec, error) {
+	ret := _m.Called(ctx)
 
-	var r0 []*model.FileShareSnapshotSpec
-	if rf, ok := ret.Get(0).(func(*context.Context, string) []*model.FileShareSnapshotSpec); ok {
-		r0 = rf(ctx, fileshareId)
+	var r0 []*model.ResourceClassSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.ResourceClassSpec); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.FileShareSnapshotSpec)
+			r0 = ret.Get(0).([]*model.ResourceClassSpec)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
-		r1 = rf(ctx, fileshareId)
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1376,22 +2748,22 @@ func (_m *Client) ListSnapshotsByShareId(ctx *context.Context, fileshareId strin
 	return r0, r1
 }
 
-// ListSnapshotsByVolumeId provides a mock function with given fields: ctx, volId
-func (_m *Client) ListSnapshotsByVolumeId(ctx *context.Context, volId string) ([]*model.VolumeSnapshotSpec, error) {
-	ret := _m.Called(ctx, volId)
+// ListTombstones provides a mock function with given fields: ctx
+func (_m *Client) ListTombstones(ctx *context.Context) ([]*model.TombstoneSpec, error) {
+	ret := _m.Called(ctx)
 
-	var r0 []*model.VolumeSnapshotSpec
-	if rf, ok := ret.Get(0).(func(*context.Context, string) []*model.VolumeSnapshotSpec); ok {
-		r0 = rf(ctx, volId)
+	var r0 []*model.TombstoneSpec
+	if rf, ok := ret.Get(0).(func(*context.Context) []*model.TombstoneSpec); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.VolumeSnapshotSpec)
+			r0 = ret.Get(0).([]*model.TombstoneSpec)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*context.Context, string) error); ok {
-		r1 = rf(ctx, volId)
+	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1630,6 +3002,19 @@ func (_m *Client) ListVolumesWithFilter(ctx *context.Context, m map[string][]str
 }
 
 // RemoveCustomProperty provides a mock function with given fields: ctx, prfID, customKey
+func (_m *Client) ReleasePendingOperation(ctx *context.Context, resourceType string, resourceID string, token string) error {
+	ret := _m.Called(ctx, resourceType, resourceID, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, resourceType, resourceID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 func (_m *Client) RemoveCustomProperty(ctx *context.Context, prfID string, customKey string) error {
 	ret := _m.Called(ctx, prfID, customKey)
 
@@ -1643,7 +3028,98 @@ func (_m *Client) RemoveCustomProperty(ctx *context.Context, prfID string, custo
 	return r0
 }
 
+// SetDefaultTags provides a mock function with given fields: ctx, tags
+func (_m *Client) SetDefaultTags(ctx *context.Context, tags *model.DefaultTagsSpec) (*model.DefaultTagsSpec, error) {
+	ret := _m.Called(ctx, tags)
+
+	var r0 *model.DefaultTagsSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.DefaultTagsSpec) *model.DefaultTagsSpec); ok {
+		r0 = rf(ctx, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DefaultTagsSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.DefaultTagsSpec) error); ok {
+		r1 = rf(ctx, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetQuota provides a mock function with given fields: ctx, quota
+func (_m *Client) SetQuota(ctx *context.Context, quota *model.QuotaSpec) (*model.QuotaSpec, error) {
+	ret := _m.Called(ctx, quota)
+
+	var r0 *model.QuotaSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.QuotaSpec) *model.QuotaSpec); ok {
+		r0 = rf(ctx, quota)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.QuotaSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.QuotaSpec) error); ok {
+		r1 = rf(ctx, quota)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateBackupTarget provides a mock function with given fields: ctx, targetID, target
+func (_m *Client) UpdateBackupTarget(ctx *context.Context, targetID string, target *model.BackupTargetSpec) (*model.BackupTargetSpec, error) {
+	ret := _m.Called(ctx, targetID, target)
+
+	var r0 *model.BackupTargetSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.BackupTargetSpec) *model.BackupTargetSpec); ok {
+		r0 = rf(ctx, targetID, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BackupTargetSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, *model.BackupTargetSpec) error); ok {
+		r1 = rf(ctx, targetID, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDock provides a mock function with given fields: ctx, dckID, name, desp
+func (_m *Client) UpdateApiKey(ctx *context.Context, key *model.ApiKeySpec) (*model.ApiKeySpec, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *model.ApiKeySpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.ApiKeySpec) *model.ApiKeySpec); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ApiKeySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.ApiKeySpec) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 func (_m *Client) UpdateDock(ctx *context.Context, dckID string, name string, desp string) (*model.DockSpec, error) {
 	ret := _m.Called(ctx, dckID, name, desp)
 
@@ -1689,6 +3165,52 @@ func (_m *Client) UpdateFileShare(ctx *context.Context, fshare *model.FileShareS
 	return r0, r1
 }
 
+// UpdateFileSharePublic provides a mock function with given fields: ctx, fshareID, isPublic
+func (_m *Client) UpdateFileSharePublic(ctx *context.Context, fshareID string, isPublic bool) (*model.FileShareSpec, error) {
+	ret := _m.Called(ctx, fshareID, isPublic)
+
+	var r0 *model.FileShareSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, bool) *model.FileShareSpec); ok {
+		r0 = rf(ctx, fshareID, isPublic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, bool) error); ok {
+		r1 = rf(ctx, fshareID, isPublic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateFileShareMetadata provides a mock function with given fields: ctx, fshareID, update
+func (_m *Client) UpdateFileShareMetadata(ctx *context.Context, fshareID string, update *model.MetadataUpdateSpec) (*model.FileShareSpec, error) {
+	ret := _m.Called(ctx, fshareID, update)
+
+	var r0 *model.FileShareSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.MetadataUpdateSpec) *model.FileShareSpec); ok {
+		r0 = rf(ctx, fshareID, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, *model.MetadataUpdateSpec) error); ok {
+		r1 = rf(ctx, fshareID, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateFileShareAcl provides a mock function with given fields: ctx, acl
 func (_m *Client) UpdateFileShareAcl(ctx *context.Context, acl *model.FileShareAclSpec) (*model.FileShareAclSpec, error) {
 	ret := _m.Called(ctx, acl)
@@ -1735,6 +3257,52 @@ func (_m *Client) UpdateFileShareSnapshot(ctx *context.Context, snapshotID strin
 	return r0, r1
 }
 
+// UpdateFileShareSnapshotBackup provides a mock function with given fields: ctx, backupID, backup
+func (_m *Client) UpdateFileShareSnapshotBackup(ctx *context.Context, backupID string, backup *model.FileShareSnapshotBackupSpec) (*model.FileShareSnapshotBackupSpec, error) {
+	ret := _m.Called(ctx, backupID, backup)
+
+	var r0 *model.FileShareSnapshotBackupSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.FileShareSnapshotBackupSpec) *model.FileShareSnapshotBackupSpec); ok {
+		r0 = rf(ctx, backupID, backup)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSnapshotBackupSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, *model.FileShareSnapshotBackupSpec) error); ok {
+		r1 = rf(ctx, backupID, backup)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateFileShareSnapshotCopy provides a mock function with given fields: ctx, copyID, copy
+func (_m *Client) UpdateFileShareSnapshotCopy(ctx *context.Context, copyID string, copy *model.FileShareSnapshotCopySpec) (*model.FileShareSnapshotCopySpec, error) {
+	ret := _m.Called(ctx, copyID, copy)
+
+	var r0 *model.FileShareSnapshotCopySpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.FileShareSnapshotCopySpec) *model.FileShareSnapshotCopySpec); ok {
+		r0 = rf(ctx, copyID, copy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileShareSnapshotCopySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, *model.FileShareSnapshotCopySpec) error); ok {
+		r1 = rf(ctx, copyID, copy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateHost provides a mock function with given fields: ctx, host
 func (_m *Client) UpdateHost(ctx *context.Context, host *model.HostSpec) (*model.HostSpec, error) {
 	ret := _m.Called(ctx, host)
@@ -1758,6 +3326,52 @@ func (_m *Client) UpdateHost(ctx *context.Context, host *model.HostSpec) (*model
 	return r0, r1
 }
 
+// UpdateHostGroup provides a mock function with given fields: ctx, hg
+func (_m *Client) UpdateHostGroup(ctx *context.Context, hg *model.HostGroupSpec) (*model.HostGroupSpec, error) {
+	ret := _m.Called(ctx, hg)
+
+	var r0 *model.HostGroupSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, *model.HostGroupSpec) *model.HostGroupSpec); ok {
+		r0 = rf(ctx, hg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HostGroupSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, *model.HostGroupSpec) error); ok {
+		r1 = rf(ctx, hg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateNetworkPolicy provides a mock function with given fields: ctx, policyID, policy
+func (_m *Client) UpdateNetworkPolicy(ctx *context.Context, policyID string, policy *model.NetworkPolicySpec) (*model.NetworkPolicySpec, error) {
+	ret := _m.Called(ctx, policyID, policy)
+
+	var r0 *model.NetworkPolicySpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.NetworkPolicySpec) *model.NetworkPolicySpec); ok {
+		r0 = rf(ctx, policyID, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.NetworkPolicySpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, *model.NetworkPolicySpec) error); ok {
+		r1 = rf(ctx, policyID, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePool provides a mock function with given fields: ctx, polID, name, desp, usedCapacity, used
 func (_m *Client) UpdatePool(ctx *context.Context, polID string, name string, desp string, usedCapacity int64, used bool) (*model.StoragePoolSpec, error) {
 	ret := _m.Called(ctx, polID, name, desp, usedCapacity, used)
@@ -1781,6 +3395,52 @@ func (_m *Client) UpdatePool(ctx *context.Context, polID string, name string, de
 	return r0, r1
 }
 
+// UpdatePoolCapacitySettings provides a mock function with given fields: ctx, polID, reservedCapacity, maxOverSubscriptionRatio
+func (_m *Client) UpdatePoolCapacitySettings(ctx *context.Context, polID string, reservedCapacity int64, maxOverSubscriptionRatio float64) (*model.StoragePoolSpec, error) {
+	ret := _m.Called(ctx, polID, reservedCapacity, maxOverSubscriptionRatio)
+
+	var r0 *model.StoragePoolSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, int64, float64) *model.StoragePoolSpec); ok {
+		r0 = rf(ctx, polID, reservedCapacity, maxOverSubscriptionRatio)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StoragePoolSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, int64, float64) error); ok {
+		r1 = rf(ctx, polID, reservedCapacity, maxOverSubscriptionRatio)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePoolMaintenance provides a mock function with given fields: ctx, polID, maintenance
+func (_m *Client) UpdatePoolMaintenance(ctx *context.Context, polID string, maintenance bool) (*model.StoragePoolSpec, error) {
+	ret := _m.Called(ctx, polID, maintenance)
+
+	var r0 *model.StoragePoolSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, bool) *model.StoragePoolSpec); ok {
+		r0 = rf(ctx, polID, maintenance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StoragePoolSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, bool) error); ok {
+		r1 = rf(ctx, polID, maintenance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateProfile provides a mock function with given fields: ctx, prfID, input
 func (_m *Client) UpdateProfile(ctx *context.Context, prfID string, input *model.ProfileSpec) (*model.ProfileSpec, error) {
 	ret := _m.Called(ctx, prfID, input)
@@ -1804,6 +3464,29 @@ func (_m *Client) UpdateProfile(ctx *context.Context, prfID string, input *model
 	return r0, r1
 }
 
+// UpdateRegion provides a mock function with given fields: ctx, regionID, region
+func (_m *Client) UpdateRegion(ctx *context.Context, regionID string, region *model.RegionSpec) (*model.RegionSpec, error) {
+	ret := _m.Called(ctx, regionID, region)
+
+	var r0 *model.RegionSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.RegionSpec) *model.RegionSpec); ok {
+		r0 = rf(ctx, regionID, region)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RegionSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, *model.RegionSpec) error); ok {
+		r1 = rf(ctx, regionID, region)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateReplication provides a mock function with given fields: ctx, replicationId, input
 func (_m *Client) UpdateReplication(ctx *context.Context, replicationId string, input *model.ReplicationSpec) (*model.ReplicationSpec, error) {
 	ret := _m.Called(ctx, replicationId, input)
@@ -1827,6 +3510,29 @@ func (_m *Client) UpdateReplication(ctx *context.Context, replicationId string,
 	return r0, r1
 }
 
+// UpdateResourceClass provides a mock function with given fields: ctx, classID, class
+func (_m *Client) UpdateResourceClass(ctx *context.Context, classID string, class *model.ResourceClassSpec) (*model.ResourceClassSpec, error) {
+	ret := _m.Called(ctx, classID, class)
+
+	var r0 *model.ResourceClassSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.ResourceClassSpec) *model.ResourceClassSpec); ok {
+		r0 = rf(ctx, classID, class)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ResourceClassSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, *model.ResourceClassSpec) error); ok {
+		r1 = rf(ctx, classID, class)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateStatus provides a mock function with given fields: ctx, object, status
 func (_m *Client) UpdateStatus(ctx *context.Context, object interface{}, status string) error {
 	ret := _m.Called(ctx, object, status)
@@ -1864,6 +3570,29 @@ func (_m *Client) UpdateVolume(ctx *context.Context, vol *model.VolumeSpec) (*mo
 	return r0, r1
 }
 
+// UpdateVolumeMetadata provides a mock function with given fields: ctx, volID, update
+func (_m *Client) UpdateVolumeMetadata(ctx *context.Context, volID string, update *model.MetadataUpdateSpec) (*model.VolumeSpec, error) {
+	ret := _m.Called(ctx, volID, update)
+
+	var r0 *model.VolumeSpec
+	if rf, ok := ret.Get(0).(func(*context.Context, string, *model.MetadataUpdateSpec) *model.VolumeSpec); ok {
+		r0 = rf(ctx, volID, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VolumeSpec)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*context.Context, string, *model.MetadataUpdateSpec) error); ok {
+		r1 = rf(ctx, volID, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateVolumeAttachment provides a mock function with given fields: ctx, attachmentId, attachment
 func (_m *Client) UpdateVolumeAttachment(ctx *context.Context, attachmentId string, attachment *model.VolumeAttachmentSpec) (*model.VolumeAttachmentSpec, error) {
 	ret := _m.Called(ctx, attachmentId, attachment)
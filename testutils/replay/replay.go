@@ -0,0 +1,146 @@
+// Copyright 2019 The OpenSDS Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replay turns traffic recordings taken by the recorder filter
+// into regression or load tests: it reads a recording file, replays every
+// request against an api endpoint and reports where the responses diverge
+// from what was recorded.
+
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/sodafoundation/api/pkg/api/filter/recorder"
+)
+
+// Result is the outcome of replaying one recorded entry.
+type Result struct {
+	Entry *recorder.Entry
+
+	// The status the endpoint returned this time.
+	Status int
+
+	// How long the replayed request took.
+	Duration time.Duration
+
+	// Err is set when the request could not be sent at all.
+	Err error
+}
+
+// Matched reports whether the replayed response status equals the
+// recorded one.
+func (r *Result) Matched() bool {
+	return r.Err == nil && r.Status == r.Entry.Status
+}
+
+// Load reads a recording file written by the recorder filter.
+func Load(path string) ([]*recorder.Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []*recorder.Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		entry := &recorder.Entry{}
+		if err := json.Unmarshal(line, entry); err != nil {
+			return nil, fmt.Errorf("invalid recording entry %s: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Player replays recorded entries against an api endpoint.
+type Player struct {
+	// The base endpoint the recorded paths are appended to, for example
+	// http://localhost:50040.
+	Endpoint string
+
+	// Headers added to every replayed request, typically the auth token
+	// or api key of the test tenant.
+	Headers map[string]string
+
+	// The client used for the requests, http.DefaultClient when nil.
+	Client *http.Client
+}
+
+// Replay sends every entry in order and returns one result per entry.
+func (p *Player) Replay(entries []*recorder.Entry) []*Result {
+	var results []*Result
+	for _, entry := range entries {
+		results = append(results, p.replayOne(entry))
+	}
+	return results
+}
+
+func (p *Player) replayOne(entry *recorder.Entry) *Result {
+	result := &Result{Entry: entry}
+	url := p.Endpoint + entry.Path
+	if entry.Query != "" {
+		url += "?" + entry.Query
+	}
+	req, err := http.NewRequest(entry.Method, url, bytes.NewReader(entry.RequestBody))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if len(entry.RequestBody) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range p.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+	result.Status = resp.StatusCode
+	return result
+}
+
+// Mismatches filters the results down to the entries whose replayed
+// response diverged from the recording.
+func Mismatches(results []*Result) []*Result {
+	var diverged []*Result
+	for _, result := range results {
+		if !result.Matched() {
+			diverged = append(diverged, result)
+		}
+	}
+	return diverged
+}